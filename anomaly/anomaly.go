@@ -0,0 +1,56 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package anomaly defines the interface for scoring how unusual a request is, so that experimental detection logic
+// can be plugged into the checker.
+package anomaly
+
+import (
+	"fmt"
+	"time"
+)
+
+// Request is the information about a request that a Scorer sees.
+type Request struct {
+	// Source and Destination identify the peers by SPIFFE ID, or by IP address if they have none.
+	Source      string
+	Destination string
+	Method      string
+	// Path is the request path, without the query string.
+	Path string
+	Time time.Time
+}
+
+// Scorer scores requests. Scores range from 0 for an entirely normal request to 1 for one that is certainly
+// anomalous. Score is called once for every request the checker evaluates policy for, so scorers may learn from the requests
+// they see, and must be safe for concurrent use.
+type Scorer interface {
+	Score(r *Request) float64
+}
+
+// ScorerFunc adapts a function to the Scorer interface.
+type ScorerFunc func(r *Request) float64
+
+func (f ScorerFunc) Score(r *Request) float64 {
+	return f(r)
+}
+
+// New returns the built-in scorer with the given name.
+func New(name string) (Scorer, error) {
+	switch name {
+	case "novelty":
+		return NewNoveltyScorer(), nil
+	}
+	return nil, fmt.Errorf("unknown anomaly scorer %q", name)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomaly
+
+import (
+	"sync"
+)
+
+const (
+	// DefaultWarmup is the number of requests from an identity that NoveltyScorer learns from before scoring.
+	DefaultWarmup = 100
+	// DefaultMaxIdentities bounds the identities NoveltyScorer tracks.
+	DefaultMaxIdentities = 10000
+	// DefaultMaxPaths bounds the paths NoveltyScorer remembers for each identity.
+	DefaultMaxPaths = 1000
+
+	// maxMethods bounds the methods remembered for each identity. Clients choose the method, so it is not limited to
+	// the standard ones.
+	maxMethods = 32
+)
+
+// NoveltyScorer learns the methods and paths each source identity uses, and scores requests by how novel they are
+// for their source: 0 for a method and path it has used before, 0.5 for a new path, and 1 for a new method. The first
+// Warmup requests from an identity score 0. Once an identity has used MaxPaths paths, further new paths are not
+// learned and keep scoring as novel. Once MaxIdentities identities are tracked, requests from new identities score 0.
+type NoveltyScorer struct {
+	Warmup        int
+	MaxIdentities int
+	MaxPaths      int
+
+	mu         sync.Mutex
+	identities map[string]*history
+}
+
+type history struct {
+	requests int
+	methods  map[string]bool
+	paths    map[string]bool
+}
+
+// NewNoveltyScorer creates a NoveltyScorer with the default limits.
+func NewNoveltyScorer() *NoveltyScorer {
+	return &NoveltyScorer{
+		Warmup:        DefaultWarmup,
+		MaxIdentities: DefaultMaxIdentities,
+		MaxPaths:      DefaultMaxPaths,
+		identities:    map[string]*history{},
+	}
+}
+
+func (n *NoveltyScorer) Score(r *Request) float64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	h, ok := n.identities[r.Source]
+	if !ok {
+		if len(n.identities) >= n.MaxIdentities {
+			return 0
+		}
+		h = &history{methods: map[string]bool{}, paths: map[string]bool{}}
+		n.identities[r.Source] = h
+	}
+	score := 0.0
+	if !h.methods[r.Method] {
+		score = 1
+	} else if !h.paths[r.Path] {
+		score = 0.5
+	}
+	if len(h.methods) < maxMethods {
+		h.methods[r.Method] = true
+	}
+	if len(h.paths) < n.MaxPaths {
+		h.paths[r.Path] = true
+	}
+	h.requests++
+	if h.requests <= n.Warmup {
+		return 0
+	}
+	return score
+}
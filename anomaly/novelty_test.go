@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anomaly
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNoveltyScorer(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := NewNoveltyScorer()
+	uut.Warmup = 2
+	alice := func(method, path string) float64 {
+		return uut.Score(&Request{Source: "alice", Method: method, Path: path})
+	}
+
+	// Requests during warmup score 0, but are learned.
+	Expect(alice("GET", "/a")).To(Equal(0.0))
+	Expect(alice("POST", "/b")).To(Equal(0.0))
+
+	Expect(alice("GET", "/b")).To(Equal(0.0))
+	Expect(alice("GET", "/c")).To(Equal(0.5))
+	Expect(alice("GET", "/c")).To(Equal(0.0))
+	Expect(alice("DELETE", "/a")).To(Equal(1.0))
+
+	// Identities are learned separately.
+	Expect(uut.Score(&Request{Source: "bob", Method: "DELETE", Path: "/a"})).To(Equal(0.0))
+	Expect(uut.identities).To(HaveLen(2))
+}
+
+func TestNoveltyScorerLimits(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := NewNoveltyScorer()
+	uut.Warmup = 0
+	uut.MaxIdentities = 1
+	uut.MaxPaths = 1
+
+	Expect(uut.Score(&Request{Source: "alice", Method: "GET", Path: "/a"})).To(Equal(1.0))
+	Expect(uut.Score(&Request{Source: "alice", Method: "GET", Path: "/a"})).To(Equal(0.0))
+	// Paths beyond the limit are not learned.
+	Expect(uut.Score(&Request{Source: "alice", Method: "GET", Path: "/b"})).To(Equal(0.5))
+	Expect(uut.Score(&Request{Source: "alice", Method: "GET", Path: "/b"})).To(Equal(0.5))
+	// Nor are identities.
+	Expect(uut.Score(&Request{Source: "bob", Method: "GET", Path: "/a"})).To(Equal(0.0))
+	Expect(uut.identities).To(HaveLen(1))
+}
+
+func TestNew(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, err := New("novelty")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(s).To(BeAssignableToTypeOf(&NoveltyScorer{}))
+	_, err = New("magic")
+	Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auditlog writes a JSON record of every authorization decision to stdout or a file, so that security teams
+// can show what application layer policy enforced. Files are rotated by size.
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/projectcalico/app-policy/decisionsink"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Stdout is the path that writes the audit log to standard output.
+	Stdout = "-"
+	// DefaultMaxBytes is the size at which an audit log file is rotated.
+	DefaultMaxBytes = 100 << 20
+	// DefaultBackups is the number of rotated audit log files kept.
+	DefaultBackups = 5
+	// DefaultBufferSize is the number of records held while waiting to be written. Records published while it is full
+	// are dropped.
+	DefaultBufferSize = 10000
+)
+
+var (
+	writtenRecords = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "dikastes_audit_records_written_total",
+		Help: "Decision records written to the audit log.",
+	})
+	droppedRecords = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dikastes_audit_records_dropped_total",
+		Help: "Decision records dropped, because the buffer was full or they couldn't be written, by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	prometheus.MustRegister(writtenRecords, droppedRecords)
+}
+
+// Logger writes the decisions published to it to the audit log, one JSON record per line.
+type Logger struct {
+	// MaxBytes is the size at which the log file is rotated, and Backups the number of rotated files kept, as
+	// <path>.1, the newest, to <path>.<Backups>. They must be set before Start, and don't apply to Stdout.
+	MaxBytes int64
+	Backups  int
+
+	path    string
+	records chan decisionsink.Event
+	// out is what records are written to, and file and size the log file and its size, when writing to one. Only the
+	// run loop uses them once started.
+	out  io.Writer
+	file *os.File
+	size int64
+}
+
+// New returns a Logger that writes to the file at path, or to standard output if path is Stdout, once started.
+func New(path string) *Logger {
+	return &Logger{
+		MaxBytes: DefaultMaxBytes,
+		Backups:  DefaultBackups,
+		path:     path,
+		records:  make(chan decisionsink.Event, DefaultBufferSize),
+	}
+}
+
+// Publish queues a decision to be written, dropping it if the buffer is full.
+func (l *Logger) Publish(e decisionsink.Event) {
+	select {
+	case l.records <- e:
+	default:
+		droppedRecords.WithLabelValues("buffer_full").Inc()
+	}
+}
+
+// Start opens the audit log, and writes published decisions to it until ctx is done.
+func (l *Logger) Start(ctx context.Context) error {
+	if l.path == Stdout {
+		l.out = os.Stdout
+	} else if err := l.open(); err != nil {
+		return err
+	}
+	go l.run(ctx)
+	return nil
+}
+
+func (l *Logger) run(ctx context.Context) {
+	defer func() {
+		if l.file != nil {
+			_ = l.file.Close()
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-l.records:
+			if err := l.write(e); err != nil {
+				log.WithError(err).Warn("Unable to write to audit log.")
+				droppedRecords.WithLabelValues("write_failed").Inc()
+				continue
+			}
+			writtenRecords.Inc()
+		}
+	}
+}
+
+// write writes a record, rotating the log file first if the record would take it over MaxBytes.
+func (l *Logger) write(e decisionsink.Event) error {
+	b, err := json.Marshal(&e)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if l.path != Stdout {
+		if l.file != nil && l.size > 0 && l.size+int64(len(b)) > l.MaxBytes {
+			if err := l.rotate(); err != nil {
+				return err
+			}
+		}
+		// A log file that couldn't be reopened after rotating is tried again with each record.
+		if l.file == nil {
+			if err := l.open(); err != nil {
+				return err
+			}
+		}
+	}
+	n, err := l.out.Write(b)
+	l.size += int64(n)
+	return err
+}
+
+// open opens the log file for appending.
+func (l *Logger) open() error {
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	l.file, l.out, l.size = f, f, info.Size()
+	return nil
+}
+
+// rotate moves the log file to the first backup, shifting the others along and removing the oldest, then opens a new
+// one.
+func (l *Logger) rotate() error {
+	err := l.file.Close()
+	l.file, l.out = nil, nil
+	if err != nil {
+		log.WithError(err).Warn("Unable to close audit log before rotating it.")
+	}
+	for i := l.Backups; i > 0; i-- {
+		from := l.path
+		if i > 1 {
+			from = backup(l.path, i-1)
+		}
+		if err := os.Rename(from, backup(l.path, i)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if l.Backups <= 0 {
+		if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return l.open()
+}
+
+// backup returns the path of the i'th newest rotated log file.
+func backup(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/decisionsink"
+)
+
+// records reads the records in the audit log file at path.
+func records(path string) []decisionsink.Event {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	Expect(err).ToNot(HaveOccurred())
+	defer f.Close()
+	var events []decisionsink.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e decisionsink.Event
+		Expect(json.Unmarshal(scanner.Bytes(), &e)).To(Succeed())
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestLogger(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "auditlog")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	l := New(path)
+	Expect(l.Start(ctx)).To(Succeed())
+	written := testutil.ToFloat64(writtenRecords)
+	e := decisionsink.Event{
+		Time:        time.Unix(1700000000, 0).UTC(),
+		Source:      decisionsink.Peer{Principal: "spiffe://cluster.local/ns/web/sa/frontend", Namespace: "web"},
+		Destination: decisionsink.Peer{IP: "10.0.0.2", Port: 8080},
+		Method:      "DELETE",
+		Path:        "/carts/1",
+		Verdict:     "PERMISSION_DENIED",
+		Enforced:    true,
+		Policy:      "default/carts",
+		RuleIndex:   2,
+	}
+	l.Publish(e)
+	Eventually(func() []decisionsink.Event { return records(path) }).Should(Equal([]decisionsink.Event{e}))
+	Expect(testutil.ToFloat64(writtenRecords)).To(Equal(written + 1))
+
+	info, err := os.Stat(path)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+}
+
+func TestRotate(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "auditlog")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	l := New(path)
+	l.Backups = 2
+	Expect(l.open()).To(Succeed())
+	defer l.file.Close()
+	// Each record is the same size, so set the limit to fit two.
+	b, err := json.Marshal(decisionsink.Event{Path: "/0"})
+	Expect(err).ToNot(HaveOccurred())
+	l.MaxBytes = int64(2 * (len(b) + 1))
+
+	for _, p := range []string{"/0", "/1", "/2", "/3", "/4", "/5", "/6"} {
+		Expect(l.write(decisionsink.Event{Path: p})).To(Succeed())
+	}
+	paths := func(events []decisionsink.Event) (ps []string) {
+		for _, e := range events {
+			ps = append(ps, e.Path)
+		}
+		return
+	}
+	Expect(paths(records(path))).To(Equal([]string{"/6"}))
+	Expect(paths(records(path + ".1"))).To(Equal([]string{"/4", "/5"}))
+	Expect(paths(records(path + ".2"))).To(Equal([]string{"/2", "/3"}))
+	// The oldest records are gone.
+	_, err = os.Stat(path + ".3")
+	Expect(os.IsNotExist(err)).To(BeTrue())
+}
+
+func TestLoggerAppends(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "auditlog")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	Expect(ioutil.WriteFile(path, []byte(`{"path":"/before"}`+"\n"), 0600)).To(Succeed())
+
+	// A restarted Dikastes carries on where the last left off, counting the existing records towards rotation.
+	l := New(path)
+	Expect(l.open()).To(Succeed())
+	defer l.file.Close()
+	Expect(l.size).To(BeEquivalentTo(len(`{"path":"/before"}`) + 1))
+	Expect(l.write(decisionsink.Event{Path: "/after"})).To(Succeed())
+	events := records(path)
+	Expect(events).To(HaveLen(2))
+	Expect(events[0].Path).To(Equal("/before"))
+	Expect(events[1].Path).To(Equal("/after"))
+}
+
+func TestLoggerDropsWhenFull(t *testing.T) {
+	RegisterTestingT(t)
+
+	// The logger isn't started, so nothing takes records from its buffer.
+	l := New(Stdout)
+	dropped := testutil.ToFloat64(droppedRecords.WithLabelValues("buffer_full"))
+	for i := 0; i < DefaultBufferSize+2; i++ {
+		l.Publish(decisionsink.Event{})
+	}
+	Expect(testutil.ToFloat64(droppedRecords.WithLabelValues("buffer_full"))).To(Equal(dropped + 2))
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bruteforce counts login attempts by source, so that rules can deny or slow down sources that appear to be
+// guessing or stuffing credentials.
+package bruteforce
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultWindow is how long attempts are counted for if the config doesn't say.
+	DefaultWindow = 15 * time.Minute
+	// DefaultMaxSources bounds the number of sources a Counter tracks.
+	DefaultMaxSources = 65536
+)
+
+// Config is the brute-force detection configuration file.
+type Config struct {
+	// LoginPaths are the path prefixes of login endpoints. The query string is ignored.
+	LoginPaths []string `json:"loginPaths"`
+	// Methods are the HTTP methods that count as login attempts. Defaults to POST.
+	Methods []string `json:"methods,omitempty"`
+	// Window is how long attempts are counted for, as a Go duration. Defaults to 15 minutes.
+	Window string `json:"window,omitempty"`
+
+	window time.Duration
+}
+
+// LoadConfig reads and validates a brute-force detection configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(b)
+}
+
+// ParseConfig parses and validates a brute-force detection configuration.
+func ParseConfig(b []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	if len(c.LoginPaths) == 0 {
+		return nil, fmt.Errorf("no login paths")
+	}
+	if len(c.Methods) == 0 {
+		c.Methods = []string{"POST"}
+	}
+	c.window = DefaultWindow
+	if c.Window != "" {
+		w, err := time.ParseDuration(c.Window)
+		if err != nil || w <= 0 {
+			return nil, fmt.Errorf("invalid window %q", c.Window)
+		}
+		c.window = w
+	}
+	return c, nil
+}
+
+// IsLogin returns true if a request with the given method and path is a login attempt.
+func (c *Config) IsLogin(method, path string) bool {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	methodMatches := false
+	for _, m := range c.Methods {
+		methodMatches = methodMatches || strings.EqualFold(m, method)
+	}
+	if !methodMatches {
+		return false
+	}
+	for _, p := range c.LoginPaths {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// counter estimates the attempts in a sliding window from the counts in the current and previous fixed windows.
+type counter struct {
+	start time.Time
+	cur   int
+	prev  int
+}
+
+// advance moves the counter's windows on to the one containing now.
+func (c *counter) advance(now time.Time, window time.Duration) {
+	n := now.Sub(c.start) / window
+	if n <= 0 {
+		return
+	}
+	if n == 1 {
+		c.prev = c.cur
+	} else {
+		c.prev = 0
+	}
+	c.cur = 0
+	c.start = c.start.Add(n * window)
+}
+
+func (c *counter) estimate(now time.Time, window time.Duration) int {
+	prevWeight := 1 - float64(now.Sub(c.start))/float64(window)
+	return c.cur + int(math.Round(float64(c.prev)*prevWeight))
+}
+
+// Counter counts login attempts by source over the configured window. It is safe for concurrent use.
+type Counter struct {
+	config     *Config
+	maxSources int
+
+	mu       sync.Mutex
+	counters map[string]*counter
+}
+
+// NewCounter creates a Counter for the login endpoints in config.
+func NewCounter(config *Config) *Counter {
+	return &Counter{config: config, maxSources: DefaultMaxSources, counters: map[string]*counter{}}
+}
+
+// IsLogin returns true if a request with the given method and path is a login attempt.
+func (c *Counter) IsLogin(method, path string) bool {
+	return c.config.IsLogin(method, path)
+}
+
+// Record counts a login attempt by each of the given sources.
+func (c *Counter) Record(now time.Time, sources ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range sources {
+		ctr, ok := c.counters[s]
+		if !ok {
+			c.makeRoom(now)
+			ctr = &counter{start: now}
+			c.counters[s] = ctr
+		}
+		ctr.advance(now, c.config.window)
+		ctr.cur++
+	}
+}
+
+// Attempts returns the number of login attempts by source over the last window.
+func (c *Counter) Attempts(source string, now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ctr, ok := c.counters[source]
+	if !ok {
+		return 0
+	}
+	ctr.advance(now, c.config.window)
+	return ctr.estimate(now, c.config.window)
+}
+
+// makeRoom ensures there is space for a new source. Sources with no attempts in the last window are dropped first;
+// if that is not enough the source with the fewest attempts is evicted, keeping those most likely to be attacking.
+func (c *Counter) makeRoom(now time.Time) {
+	if len(c.counters) < c.maxSources {
+		return
+	}
+	fewest, fewestAttempts := "", 0
+	for s, ctr := range c.counters {
+		ctr.advance(now, c.config.window)
+		n := ctr.estimate(now, c.config.window)
+		if n == 0 {
+			delete(c.counters, s)
+		} else if fewest == "" || n < fewestAttempts {
+			fewest, fewestAttempts = s, n
+		}
+	}
+	if len(c.counters) < c.maxSources {
+		return
+	}
+	log.WithField("source", fewest).Warn("Login attempt counter is tracking too many sources, evicting the quietest.")
+	delete(c.counters, fewest)
+}
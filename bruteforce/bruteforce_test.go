@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bruteforce
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte(`
+loginPaths: [/login, /api/v1/session]
+window: 10m
+`))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(c.window).To(Equal(10 * time.Minute))
+	Expect(c.IsLogin("POST", "/login")).To(BeTrue())
+	Expect(c.IsLogin("post", "/api/v1/session?next=/")).To(BeTrue())
+	Expect(c.IsLogin("GET", "/login")).To(BeFalse())
+	Expect(c.IsLogin("POST", "/logout")).To(BeFalse())
+
+	c, err = ParseConfig([]byte(`{loginPaths: [/login], methods: [GET, POST]}`))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(c.window).To(Equal(DefaultWindow))
+	Expect(c.IsLogin("GET", "/login")).To(BeTrue())
+
+	for _, bad := range []string{`{}`, `{loginPaths: [/login], window: forever}`, `{loginPaths: [/login], windows: 1m}`} {
+		_, err = ParseConfig([]byte(bad))
+		Expect(err).To(HaveOccurred(), bad)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte(`{loginPaths: [/login], window: 10m}`))
+	Expect(err).ToNot(HaveOccurred())
+	uut := NewCounter(c)
+	start := time.Unix(1000, 0)
+
+	for i := 0; i < 20; i++ {
+		uut.Record(start.Add(time.Duration(i)*time.Second), "mallory", "192.0.2.1")
+	}
+	uut.Record(start, "alice")
+	Expect(uut.Attempts("mallory", start.Add(time.Minute))).To(Equal(20))
+	Expect(uut.Attempts("192.0.2.1", start.Add(time.Minute))).To(Equal(20))
+	Expect(uut.Attempts("alice", start.Add(time.Minute))).To(Equal(1))
+	Expect(uut.Attempts("bob", start.Add(time.Minute))).To(Equal(0))
+
+	// Attempts in the previous window count for less the further into the next one we get.
+	Expect(uut.Attempts("mallory", start.Add(15*time.Minute))).To(Equal(10))
+	uut.Record(start.Add(15*time.Minute), "mallory")
+	Expect(uut.Attempts("mallory", start.Add(15*time.Minute))).To(Equal(11))
+	Expect(uut.Attempts("mallory", start.Add(19*time.Minute))).To(Equal(3))
+	// And not at all once the window has passed.
+	Expect(uut.Attempts("mallory", start.Add(time.Hour))).To(Equal(0))
+}
+
+func TestCounterMaxSources(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte(`{loginPaths: [/login], window: 10m}`))
+	Expect(err).ToNot(HaveOccurred())
+	uut := NewCounter(c)
+	uut.maxSources = 2
+	now := time.Unix(1000, 0)
+
+	uut.Record(now, "mallory", "mallory", "alice")
+	uut.Record(now, "bob")
+	Expect(uut.counters).To(HaveLen(2))
+	Expect(uut.Attempts("mallory", now)).To(Equal(2))
+	Expect(uut.Attempts("alice", now)).To(Equal(0))
+	Expect(uut.Attempts("bob", now)).To(Equal(1))
+
+	// Sources that have gone quiet make way first.
+	uut.Record(now.Add(time.Hour), "carol")
+	Expect(uut.counters).To(HaveLen(1))
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	gogojsonpb "github.com/gogo/protobuf/jsonpb"
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/jsonpb"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// MaxExplainBytes bounds the size of the CheckRequests that the explain API accepts.
+const MaxExplainBytes = 1 << 20
+
+// Status is the state of the checker, as the admin API reports it.
+type Status struct {
+	// Synced is true once the checker has a PolicyStore that has been in sync with the Policy Sync API.
+	Synced   bool   `json:"synced"`
+	Endpoint string `json:"endpoint,omitempty"`
+	// Endpoints is how many endpoints a node-level Dikastes serves.
+	Endpoints int  `json:"endpoints"`
+	Policies  int  `json:"policies"`
+	Profiles  int  `json:"profiles"`
+	IPSets    int  `json:"ipSets"`
+	DryRun    bool `json:"dryRun"`
+}
+
+// Explanation is how policy decides a CheckRequest, as the admin API reports it.
+type Explanation struct {
+	// Status is the name of the verdict's status code, such as "PERMISSION_DENIED".
+	Status string `json:"status"`
+	// Policy is the policy, as "<tier>/<name>", or profile, as "profile/<name>", that decides the request. It is
+	// empty if the request falls through to a default action.
+	Policy string `json:"policy,omitempty"`
+	// Rule is the rule that decides the request.
+	Rule json.RawMessage `json:"rule,omitempty"`
+}
+
+// Status returns a handler for the admin API that reports the checker's state.
+func (as *authServer) Status() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		st := Status{DryRun: as.dryRun}
+		if store := as.Store; store != nil {
+			st.Synced = true
+			store.Read(func(ps *policystore.PolicyStore) {
+				st.Endpoint = ps.Endpoint.GetName()
+				st.Endpoints = len(ps.EndpointByID)
+				st.Policies = len(ps.PolicyByID)
+				st.Profiles = len(ps.ProfileByID)
+				st.IPSets = len(ps.IPSetByID)
+			})
+		}
+		writeJSON(w, st)
+	})
+}
+
+// Dump returns a handler for the admin API that writes the endpoint, policies, profiles, service accounts and
+// namespaces in the PolicyStore, and the IDs of its IP sets, as JSON.
+func (as *authServer) Dump() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		store := as.Store
+		if store == nil {
+			http.Error(w, "not in sync with policy", http.StatusServiceUnavailable)
+			return
+		}
+		dump := struct {
+			Endpoint        json.RawMessage            `json:"endpoint,omitempty"`
+			Policies        map[string]json.RawMessage `json:"policies"`
+			Profiles        map[string]json.RawMessage `json:"profiles"`
+			ServiceAccounts map[string]json.RawMessage `json:"serviceAccounts"`
+			Namespaces      map[string]json.RawMessage `json:"namespaces"`
+			IPSets          []string                   `json:"ipSets"`
+		}{
+			Policies:        map[string]json.RawMessage{},
+			Profiles:        map[string]json.RawMessage{},
+			ServiceAccounts: map[string]json.RawMessage{},
+			Namespaces:      map[string]json.RawMessage{},
+			IPSets:          []string{},
+		}
+		store.Read(func(ps *policystore.PolicyStore) {
+			if ps.Endpoint != nil {
+				dump.Endpoint = marshalFelixProto(ps.Endpoint)
+			}
+			for id, p := range ps.PolicyByID {
+				dump.Policies[id.Tier+"/"+id.Name] = marshalFelixProto(p)
+			}
+			for id, p := range ps.ProfileByID {
+				dump.Profiles[id.Name] = marshalFelixProto(p)
+			}
+			for id, sa := range ps.ServiceAccountByID {
+				dump.ServiceAccounts[id.Namespace+"/"+id.Name] = marshalFelixProto(sa)
+			}
+			for id, ns := range ps.NamespaceByID {
+				dump.Namespaces[id.Name] = marshalFelixProto(ns)
+			}
+			for id := range ps.IPSetByID {
+				dump.IPSets = append(dump.IPSets, id)
+			}
+		})
+		sort.Strings(dump.IPSets)
+		writeJSON(w, dump)
+	})
+}
+
+// Export returns a handler for the admin API that writes the contents of the PolicyStore in the versioned format of
+// policystore.Export, which policystore.ReadExport reads back, for support bundles.
+func (as *authServer) Export() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		store := as.Store
+		if store == nil {
+			http.Error(w, "not in sync with policy", http.StatusServiceUnavailable)
+			return
+		}
+		var export *policystore.Export
+		store.Read(func(ps *policystore.PolicyStore) { export = ps.Export() })
+		// Marshal before writing, so that a failure can still be reported.
+		b, err := json.Marshal(export)
+		if err != nil {
+			log.WithError(err).Warn("Failed to export PolicyStore.")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
+
+// Explain returns a handler for the admin API that reports how policy decides the CheckRequest POSTed to it as JSON.
+// The request is only checked against policy; it isn't counted, rate limited or recorded.
+func (as *authServer) Explain() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "POST a CheckRequest", http.StatusMethodNotAllowed)
+			return
+		}
+		req := &authz.CheckRequest{}
+		if err := jsonpb.Unmarshal(http.MaxBytesReader(w, r.Body, MaxExplainBytes), req); err != nil {
+			http.Error(w, "invalid CheckRequest: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		store := as.Store
+		if store == nil {
+			http.Error(w, "not in sync with policy", http.StatusServiceUnavailable)
+			return
+		}
+		canonicalizeHeaders(req)
+		var rule *proto.Rule
+		var e Explanation
+		opts := append(as.requestOptions(),
+			withRuleObserver(func(r *proto.Rule, _ int) { rule = r }),
+			withPolicyObserver(func(p string) { e.Policy = p }),
+		)
+		store.Read(func(ps *policystore.PolicyStore) {
+			e.Status = code.Code(checkStore(ps, req, opts...).Code).String()
+		})
+		if rule != nil && e.Policy != "" {
+			e.Rule = marshalFelixProto(rule)
+		}
+		writeJSON(w, e)
+	})
+}
+
+// marshalFelixProto converts a Policy Sync API message to JSON.
+func marshalFelixProto(m gogoproto.Message) json.RawMessage {
+	s, err := (&gogojsonpb.Marshaler{}).MarshalToString(m)
+	if err != nil {
+		log.WithError(err).Warn("Failed to marshal message for admin API.")
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(s)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
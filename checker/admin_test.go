@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func adminStore() *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Name:  "frontend",
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"reads"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "reads"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{Action: "allow", RuleId: "r1", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}},
+		},
+	}
+	store.IPSetByID["blocked"] = policystore.NewIPSet(proto.IPSetUpdate_IP)
+	return store
+}
+
+func TestAdminStatus(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithDryRun(true))
+
+	status := func() Status {
+		w := httptest.NewRecorder()
+		uut.Status().ServeHTTP(w, httptest.NewRequest("GET", "/status", nil))
+		var st Status
+		Expect(json.Unmarshal(w.Body.Bytes(), &st)).To(Succeed())
+		return st
+	}
+	Expect(status()).To(Equal(Status{DryRun: true}))
+	uut.Store = adminStore()
+	Expect(status()).To(Equal(Status{Synced: true, Endpoint: "frontend", Policies: 1, IPSets: 1, DryRun: true}))
+}
+
+func TestAdminDump(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore))
+
+	w := httptest.NewRecorder()
+	uut.Dump().ServeHTTP(w, httptest.NewRequest("GET", "/dump", nil))
+	Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+
+	uut.Store = adminStore()
+	w = httptest.NewRecorder()
+	uut.Dump().ServeHTTP(w, httptest.NewRequest("GET", "/dump", nil))
+	Expect(w.Code).To(Equal(http.StatusOK))
+	var dump struct {
+		Endpoint map[string]interface{}            `json:"endpoint"`
+		Policies map[string]map[string]interface{} `json:"policies"`
+		IPSets   []string                          `json:"ipSets"`
+	}
+	Expect(json.Unmarshal(w.Body.Bytes(), &dump)).To(Succeed())
+	Expect(dump.Endpoint["name"]).To(Equal("frontend"))
+	Expect(dump.Policies).To(HaveKey("default/reads"))
+	Expect(dump.IPSets).To(Equal([]string{"blocked"}))
+}
+
+func TestAdminExport(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore))
+
+	w := httptest.NewRecorder()
+	uut.Export().ServeHTTP(w, httptest.NewRequest("GET", "/export", nil))
+	Expect(w.Code).To(Equal(http.StatusServiceUnavailable))
+
+	uut.Store = adminStore()
+	w = httptest.NewRecorder()
+	uut.Export().ServeHTTP(w, httptest.NewRequest("GET", "/export", nil))
+	Expect(w.Code).To(Equal(http.StatusOK))
+	export, err := policystore.ReadExport(w.Body)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(export.Version).To(Equal(policystore.ExportVersion))
+	Expect(export.Endpoint.GetName()).To(Equal("frontend"))
+	Expect(export.Store().PolicyByID).To(HaveLen(1))
+}
+
+func TestAdminExplain(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore))
+	uut.Store = adminStore()
+
+	explain := func(method, body string) (int, Explanation) {
+		w := httptest.NewRecorder()
+		uut.Explain().ServeHTTP(w, httptest.NewRequest(method, "/explain", strings.NewReader(body)))
+		var e Explanation
+		if w.Code == http.StatusOK {
+			Expect(json.Unmarshal(w.Body.Bytes(), &e)).To(Succeed())
+		}
+		return w.Code, e
+	}
+	code, e := explain("POST", `{"attributes":{
+		"destination":{"address":{"socketAddress":{"address":"10.0.0.2","portValue":8080}}},
+		"request":{"http":{"method":"GET","path":"/"}}}}`)
+	Expect(code).To(Equal(http.StatusOK))
+	Expect(e.Status).To(Equal("OK"))
+	Expect(e.Policy).To(Equal("default/reads"))
+	Expect(string(e.Rule)).To(ContainSubstring(`"r1"`))
+
+	code, e = explain("POST", `{"attributes":{
+		"destination":{"address":{"socketAddress":{"address":"10.0.0.2","portValue":8080}}},
+		"request":{"http":{"method":"DELETE","path":"/"}}}}`)
+	Expect(code).To(Equal(http.StatusOK))
+	Expect(e).To(Equal(Explanation{Status: "PERMISSION_DENIED"}))
+
+	code, _ = explain("POST", `{"attributes":`)
+	Expect(code).To(Equal(http.StatusBadRequest))
+	code, _ = explain("GET", "")
+	Expect(code).To(Equal(http.StatusMethodNotAllowed))
+}
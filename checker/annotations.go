@@ -0,0 +1,35 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AnnotationPrefix is the prefix of the rule annotations that carry Dikastes-specific rule conditions.
+const AnnotationPrefix = "alp.projectcalico.org/"
+
+// failSafe is the result of a rule condition that can't be evaluated, because its annotation is malformed or the
+// request lacks the data it needs. It never loosens policy: a deny rule stays in effect and any other rule does not
+// match.
+func failSafe(r *proto.Rule, annotation string, err error) bool {
+	log.WithError(err).WithFields(log.Fields{
+		"rule":       r.GetRuleId(),
+		"annotation": annotation,
+	}).Warn("Unable to evaluate rule condition.")
+	return actionFromString(r.Action) == DENY
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/projectcalico/app-policy/anomaly"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// MinAnomalyScoreAnnotation restricts a rule to requests whose anomaly score is at least the given value, e.g. "0.8".
+// Rules with the annotation fail safe if no anomaly scorer is configured.
+const MinAnomalyScoreAnnotation = AnnotationPrefix + "min-anomaly-score"
+
+var errNoAnomalyScore = errors.New("no anomaly scorer configured")
+
+// scoreRequest scores the request with s, logging it if the score is at least logThreshold.
+func scoreRequest(s anomaly.Scorer, req *authz.CheckRequest, now time.Time, logThreshold float64) float64 {
+	attr := req.GetAttributes()
+	path := attr.GetRequest().GetHttp().GetPath()
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	r := &anomaly.Request{
+		Source:      peerIdentity(attr.GetSource()),
+		Destination: peerIdentity(attr.GetDestination()),
+		Method:      attr.GetRequest().GetHttp().GetMethod(),
+		Path:        path,
+		Time:        now,
+	}
+	score := s.Score(r)
+	entry := log.WithFields(log.Fields{
+		"source":      r.Source,
+		"destination": r.Destination,
+		"method":      r.Method,
+		"path":        r.Path,
+		"score":       score,
+	})
+	if score >= logThreshold {
+		entry.Warn("Anomalous request.")
+	} else {
+		entry.Debug("Scored request.")
+	}
+	return score
+}
+
+// matchAnomalyScore checks the rule's minimum anomaly score, if any, against the request's score.
+func matchAnomalyScore(r *proto.Rule, req *requestCache) bool {
+	v, ok := r.GetMetadata().GetAnnotations()[MinAnomalyScoreAnnotation]
+	if !ok {
+		return true
+	}
+	min, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return failSafe(r, MinAnomalyScoreAnnotation, err)
+	}
+	if !req.scored {
+		return failSafe(r, MinAnomalyScoreAnnotation, errNoAnomalyScore)
+	}
+	return req.anomalyScore >= min
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/anomaly"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func anomalyStore() *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"anomalies"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "anomalies"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{
+				Action: "deny",
+				Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+					MinAnomalyScoreAnnotation: "0.8",
+				}},
+			},
+			{Action: "allow"},
+		},
+	}
+	return store
+}
+
+func anomalyRequest() *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/sue",
+		},
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: "GET", Path: "/admin?x=1"},
+		},
+	}}
+}
+
+func TestCheckStoreAnomalyScore(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := anomalyStore()
+	req := anomalyRequest()
+	Expect(checkStore(store, req, withAnomalyScore(0.9)).Code).To(Equal(PERMISSION_DENIED))
+	Expect(checkStore(store, req, withAnomalyScore(0.8)).Code).To(Equal(PERMISSION_DENIED))
+	Expect(checkStore(store, req, withAnomalyScore(0.1)).Code).To(Equal(OK))
+
+	// Without a score the deny rule fails safe, so still applies.
+	Expect(checkStore(store, req).Code).To(Equal(PERMISSION_DENIED))
+
+	// As does a malformed annotation.
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "anomalies"}].InboundRules[0].
+		Metadata.Annotations[MinAnomalyScoreAnnotation] = "high"
+	Expect(checkStore(store, req, withAnomalyScore(0.1)).Code).To(Equal(PERMISSION_DENIED))
+}
+
+func TestCheckWithAnomalyScorer(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var seen []*anomaly.Request
+	score := 0.0
+	scorer := anomaly.ScorerFunc(func(r *anomaly.Request) float64 {
+		seen = append(seen, r)
+		return score
+	})
+	stores := make(chan *policystore.PolicyStore)
+	uut := NewServer(ctx, stores, WithAnomalyScorer(scorer, 0.5))
+	uut.Store = anomalyStore()
+
+	resp, err := uut.Check(ctx, anomalyRequest())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(seen).To(HaveLen(1))
+	Expect(seen[0].Source).To(Equal("spiffe://cluster.local/ns/default/sa/steve"))
+	Expect(seen[0].Destination).To(Equal("spiffe://cluster.local/ns/default/sa/sue"))
+	Expect(seen[0].Method).To(Equal("GET"))
+	Expect(seen[0].Path).To(Equal("/admin"))
+
+	score = 1
+	resp, err = uut.Check(ctx, anomalyRequest())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// PolicyAttachments scopes policies to Gateway API routes, as gatewayapi.Controller does.
+type PolicyAttachments interface {
+	// Applies returns true if the policy, named "<tier>/<name>", applies to requests on the route.
+	Applies(route, policy string) bool
+}
+
+// policyApplies returns true if the policy applies to the request's route.
+func policyApplies(id proto.PolicyID, req *requestCache) bool {
+	if req.attachments == nil {
+		return true
+	}
+	route := req.Request.GetAttributes().GetContextExtensions()[ContextExtensionRoute]
+	if req.attachments.Applies(route, id.Tier+"/"+id.Name) {
+		return true
+	}
+	log.WithFields(log.Fields{"route": route, "PolicyID": id}).Debug("Policy not attached to route, skipping.")
+	return false
+}
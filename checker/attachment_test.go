@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// routeAttachments attaches policies to routes.
+type routeAttachments map[string][]string
+
+func (a routeAttachments) Applies(route, policy string) bool {
+	attached := false
+	for r, policies := range a {
+		for _, p := range policies {
+			if p == policy {
+				if r == route {
+					return true
+				}
+				attached = true
+			}
+		}
+	}
+	return !attached
+}
+
+func TestCheckStorePolicyAttachments(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"deny-admin", "allow-all"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "deny-admin"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "deny"}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "allow-all"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "allow"}},
+	}
+	attachments := routeAttachments{"shop/admin": {"default/deny-admin"}}
+	check := func(route string, opts ...requestOption) int32 {
+		req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			ContextExtensions: map[string]string{ContextExtensionRoute: route},
+			Destination:       tcpDestination(),
+			Request: &authz.AttributeContext_Request{
+				Http: &authz.AttributeContext_HttpRequest{Method: "GET", Path: "/"},
+			},
+		}}
+		return checkStore(store, req, opts...).Code
+	}
+
+	Expect(check("shop/admin", withPolicyAttachments(attachments))).To(Equal(PERMISSION_DENIED))
+	Expect(check("shop/storefront", withPolicyAttachments(attachments))).To(Equal(OK))
+	Expect(check("", withPolicyAttachments(attachments))).To(Equal(OK))
+	Expect(check("shop/storefront")).To(Equal(PERMISSION_DENIED))
+}
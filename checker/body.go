@@ -0,0 +1,301 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// BodyFieldsAnnotation restricts a rule to requests whose body has the listed fields. It is a comma separated
+	// list of conditions, each either "<field><op><value>" or just "<field>" to require that the field is present.
+	// The operators are "=" and "!=", which compare strings, and ">", ">=", "<" and "<=", which compare numbers. JSON
+	// and gRPC message fields are dotted paths such as "spec.replicas" or "items.0.name"; form fields are parameter
+	// names.
+	BodyFieldsAnnotation = AnnotationPrefix + "body-fields"
+
+	// DefaultMaxBodyBytes is the default limit on how much of a request body is inspected.
+	DefaultMaxBodyBytes = 64 * 1024
+
+	// partialBodyHeader is set by Envoy when it sends only part of the request body.
+	partialBodyHeader = "x-envoy-auth-partial-body"
+)
+
+var (
+	errBodyTruncated = errors.New("request body is truncated")
+	errNoGRPCDecoder = errors.New("gRPC message inspection is not configured")
+)
+
+// GRPCDecoder decodes the request message of a gRPC method, as grpcmsg.Decoder does.
+type GRPCDecoder interface {
+	Decode(path string, body []byte, encoding string) (interface{}, error)
+}
+
+// requestBody is the request body sent by Envoy's with_request_body option. It is decoded according to its content
+// type when a rule first needs one of its fields.
+type requestBody struct {
+	raw         []byte
+	truncated   bool
+	contentType string
+	// path and encoding locate and decompress gRPC messages, which grpc decodes.
+	path     string
+	encoding string
+	grpc     GRPCDecoder
+
+	decoded bool
+	// At most one of json and form is set once the body is decoded.
+	json      interface{}
+	form      url.Values
+	decodeErr error
+}
+
+// newRequestBody returns up to max bytes of the request body.
+func newRequestBody(http *authz.AttributeContext_HttpRequest, max int) *requestBody {
+	b := &requestBody{
+		raw:         []byte(http.GetBody()),
+		truncated:   strings.EqualFold(http.GetHeaders()[partialBodyHeader], "true"),
+		contentType: http.GetHeaders()["content-type"],
+		path:        http.GetPath(),
+		encoding:    http.GetHeaders()["grpc-encoding"],
+	}
+	if max > 0 && len(b.raw) > max {
+		b.raw = b.raw[:max]
+		b.truncated = true
+	}
+	return b
+}
+
+func (b *requestBody) decodeOnce() {
+	if b.decoded {
+		return
+	}
+	b.decoded = true
+	switch {
+	case len(b.raw) == 0:
+		return
+	case b.truncated:
+		// A partial body is still useful to the WAF, but can't be decoded reliably.
+		b.decodeErr = errBodyTruncated
+	default:
+		b.decodeErr = b.decode(b.contentType)
+	}
+	if b.decodeErr != nil {
+		log.WithError(b.decodeErr).Debug("Unable to decode request body.")
+	}
+}
+
+func (b *requestBody) decode(contentType string) error {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("bad content-type %q: %v", contentType, err)
+	}
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		d := json.NewDecoder(bytes.NewReader(b.raw))
+		d.UseNumber()
+		return d.Decode(&b.json)
+	case mediaType == "application/grpc" || mediaType == "application/grpc+proto",
+		mediaType == "application/grpc-web" || mediaType == "application/grpc-web+proto":
+		return b.decodeGRPC(b.raw)
+	case mediaType == "application/grpc-web-text" || mediaType == "application/grpc-web-text+proto":
+		raw, err := base64.StdEncoding.DecodeString(string(b.raw))
+		if err != nil {
+			return err
+		}
+		return b.decodeGRPC(raw)
+	case mediaType == "application/x-www-form-urlencoded":
+		b.form, err = url.ParseQuery(string(b.raw))
+		return err
+	case mediaType == "multipart/form-data":
+		form, err := multipart.NewReader(bytes.NewReader(b.raw), params["boundary"]).ReadForm(int64(len(b.raw)))
+		if err != nil {
+			return err
+		}
+		defer form.RemoveAll()
+		b.form = form.Value
+		return nil
+	}
+	return fmt.Errorf("cannot decode body of type %q", mediaType)
+}
+
+// decodeGRPC decodes a gRPC message, which gRPC-Web frames in the same way.
+func (b *requestBody) decodeGRPC(raw []byte) (err error) {
+	if b.grpc == nil {
+		return errNoGRPCDecoder
+	}
+	b.json, err = b.grpc.Decode(b.path, raw, b.encoding)
+	return err
+}
+
+// field returns the values of a field in the decoded body, and whether the field is present. Fields holding JSON
+// objects or arrays are present but have no values.
+func (b *requestBody) field(name string) ([]string, bool, error) {
+	b.decodeOnce()
+	if b.decodeErr != nil {
+		return nil, false, b.decodeErr
+	}
+	if b.form != nil {
+		v, ok := b.form[name]
+		return v, ok, nil
+	}
+	if b.json == nil {
+		return nil, false, nil
+	}
+	v, ok := jsonPath(b.json, name)
+	if !ok {
+		return nil, false, nil
+	}
+	if s, ok := jsonScalar(v); ok {
+		return []string{s}, true, nil
+	}
+	return nil, true, nil
+}
+
+// jsonPath returns the value at a dotted path into decoded JSON, and whether it is present.
+func jsonPath(v interface{}, name string) (interface{}, bool) {
+	for _, k := range strings.Split(name, ".") {
+		switch n := v.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if v, ok = n[k]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, false
+			}
+			v = n[i]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// jsonScalar returns a decoded JSON value as a string, unless it is an object or array.
+func jsonScalar(v interface{}) (string, bool) {
+	switch n := v.(type) {
+	case string:
+		return n, true
+	case json.Number:
+		return n.String(), true
+	case bool:
+		return strconv.FormatBool(n), true
+	case nil:
+		return "null", true
+	}
+	return "", false
+}
+
+// matchBodyFields checks the rule's body field conditions, if any, against the request body. If the body is needed
+// but can't be decoded, for example because it was truncated, the result is fail-safe.
+func matchBodyFields(r *proto.Rule, req *requestCache) bool {
+	conditions, ok := r.GetMetadata().GetAnnotations()[BodyFieldsAnnotation]
+	if !ok {
+		return true
+	}
+	body := req.Body()
+	for _, c := range strings.Split(conditions, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		field, op, value := parseBodyCondition(c)
+		values, present, err := body.field(field)
+		if err != nil {
+			return failSafe(r, BodyFieldsAnnotation, err)
+		}
+		if !present {
+			return false
+		}
+		ok, err := compareBodyField(values, op, value)
+		if err != nil {
+			return failSafe(r, BodyFieldsAnnotation, err)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBodyCondition splits a body field condition into its field, operator and value. The operator is "" if the
+// condition only requires the field to be present.
+func parseBodyCondition(c string) (field, op, value string) {
+	i := strings.IndexAny(c, "=!<>")
+	if i < 0 {
+		return c, "", ""
+	}
+	op = c[i : i+1]
+	if op != "=" && strings.HasPrefix(c[i+1:], "=") {
+		op += "="
+	}
+	return c[:i], op, c[i+len(op):]
+}
+
+// compareBodyField returns whether any of a field's values satisfies a condition.
+func compareBodyField(values []string, op, value string) (bool, error) {
+	switch op {
+	case "":
+		return true, nil
+	case "=":
+		return containsString(values, value), nil
+	case "!=":
+		return !containsString(values, value), nil
+	case ">", ">=", "<", "<=":
+	default:
+		return false, fmt.Errorf("bad operator %q", op)
+	}
+	want, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false, fmt.Errorf("bad number %q", value)
+	}
+	for _, v := range values {
+		// Values that aren't numbers don't satisfy the comparison.
+		got, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case op == ">" && got > want, op == ">=" && got >= want, op == "<" && got < want, op == "<=" && got <= want:
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
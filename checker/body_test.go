@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func httpWithBody(contentType, body string) *authz.AttributeContext_HttpRequest {
+	return &authz.AttributeContext_HttpRequest{
+		Method:  "POST",
+		Headers: map[string]string{"content-type": contentType},
+		Body:    body,
+	}
+}
+
+func TestRequestBodyJSON(t *testing.T) {
+	RegisterTestingT(t)
+
+	b := newRequestBody(httpWithBody("application/json; charset=utf-8",
+		`{"user": {"role": "admin", "id": 42, "active": true}, "items": [{"name": "a"}], "note": null}`), 0)
+	for field, value := range map[string]string{
+		"user.role":    "admin",
+		"user.id":      "42",
+		"user.active":  "true",
+		"items.0.name": "a",
+		"note":         "null",
+	} {
+		v, ok, err := b.field(field)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue(), field)
+		Expect(v).To(Equal([]string{value}), field)
+	}
+	v, ok, err := b.field("user")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ok).To(BeTrue())
+	Expect(v).To(BeEmpty())
+	for _, missing := range []string{"user.name", "items.1.name", "items.x", "note.x"} {
+		_, ok, err = b.field(missing)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse(), missing)
+	}
+}
+
+func TestRequestBodyForm(t *testing.T) {
+	RegisterTestingT(t)
+
+	b := newRequestBody(httpWithBody("application/x-www-form-urlencoded", "action=delete&id=1&id=2"), 0)
+	v, ok, err := b.field("id")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ok).To(BeTrue())
+	Expect(v).To(Equal([]string{"1", "2"}))
+
+	multipart := "--XYZ\r\n" +
+		"Content-Disposition: form-data; name=\"action\"\r\n\r\n" +
+		"delete\r\n" +
+		"--XYZ--\r\n"
+	b = newRequestBody(httpWithBody("multipart/form-data; boundary=XYZ", multipart), 0)
+	v, ok, err = b.field("action")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ok).To(BeTrue())
+	Expect(v).To(Equal([]string{"delete"}))
+}
+
+func TestRequestBodyUndecodable(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Truncated by the size limit.
+	b := newRequestBody(httpWithBody("application/json", `{"role": "admin"}`), 8)
+	Expect(b.raw).To(Equal([]byte(`{"role":`)))
+	_, _, err := b.field("role")
+	Expect(err).To(Equal(errBodyTruncated))
+
+	// Truncated by Envoy.
+	http := httpWithBody("application/json", `{"role": "admin"}`)
+	http.Headers[partialBodyHeader] = "true"
+	_, _, err = newRequestBody(http, 0).field("role")
+	Expect(err).To(Equal(errBodyTruncated))
+
+	_, _, err = newRequestBody(httpWithBody("application/octet-stream", "abc"), 0).field("role")
+	Expect(err).To(HaveOccurred())
+	_, _, err = newRequestBody(httpWithBody("application/json", "{"), 0).field("role")
+	Expect(err).To(HaveOccurred())
+
+	// An empty body has no fields.
+	_, ok, err := newRequestBody(httpWithBody("application/json", ""), 0).field("role")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ok).To(BeFalse())
+}
+
+func TestMatchBodyFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/sue",
+		},
+		Request: &authz.AttributeContext_Request{
+			Http: httpWithBody("application/json", `{"action": "delete", "force": true}`),
+		},
+	}}
+	rule := func(action, conditions string) *proto.Rule {
+		return &proto.Rule{
+			Action:   action,
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{BodyFieldsAnnotation: conditions}},
+		}
+	}
+	rc := func(max int) *requestCache {
+		c, err := NewRequestCache(policystore.NewPolicyStore(), req, withMaxBodyBytes(max))
+		Expect(err).ToNot(HaveOccurred())
+		return c
+	}
+
+	Expect(matchBodyFields(rule("deny", "action=delete"), rc(0))).To(BeTrue())
+	Expect(matchBodyFields(rule("deny", "action=delete, force=true"), rc(0))).To(BeTrue())
+	Expect(matchBodyFields(rule("deny", "action=delete,force=false"), rc(0))).To(BeFalse())
+	Expect(matchBodyFields(rule("deny", "force"), rc(0))).To(BeTrue())
+	Expect(matchBodyFields(rule("deny", "dryRun"), rc(0))).To(BeFalse())
+	Expect(matchBodyFields(&proto.Rule{Action: "deny"}, rc(0))).To(BeTrue())
+
+	// A body that can't be decoded fails safe.
+	Expect(matchBodyFields(rule("deny", "action=delete"), rc(10))).To(BeTrue())
+	Expect(matchBodyFields(rule("allow", "action=delete"), rc(10))).To(BeFalse())
+}
+
+func TestMatchBodyFieldsComparisons(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Request: &authz.AttributeContext_Request{
+			Http: httpWithBody("application/json", `{"amount": 1500, "currency": "USD", "note": "n/a"}`),
+		},
+	}}
+	rc, err := NewRequestCache(policystore.NewPolicyStore(), req)
+	Expect(err).ToNot(HaveOccurred())
+	match := func(action, conditions string) bool {
+		return matchBodyFields(&proto.Rule{
+			Action:   action,
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{BodyFieldsAnnotation: conditions}},
+		}, rc)
+	}
+
+	Expect(match("deny", "amount>1000")).To(BeTrue())
+	Expect(match("deny", "amount>1500")).To(BeFalse())
+	Expect(match("deny", "amount>=1500")).To(BeTrue())
+	Expect(match("deny", "amount<1500.5")).To(BeTrue())
+	Expect(match("deny", "amount<=1499")).To(BeFalse())
+	Expect(match("deny", "currency!=EUR")).To(BeTrue())
+	Expect(match("deny", "currency!=USD")).To(BeFalse())
+	Expect(match("deny", "amount>1000,currency=USD")).To(BeTrue())
+	// Fields that aren't numbers don't satisfy numeric comparisons, and missing fields satisfy nothing.
+	Expect(match("deny", "note>0")).To(BeFalse())
+	Expect(match("deny", "limit!=0")).To(BeFalse())
+
+	// Malformed conditions fail safe.
+	Expect(match("deny", "amount>lots")).To(BeTrue())
+	Expect(match("allow", "amount>lots")).To(BeFalse())
+	Expect(match("deny", "amount!1000")).To(BeTrue())
+}
+
+type fakeGRPCDecoder struct {
+	path, encoding string
+	body           []byte
+}
+
+func (d *fakeGRPCDecoder) Decode(path string, body []byte, encoding string) (interface{}, error) {
+	d.path, d.body, d.encoding = path, body, encoding
+	return map[string]interface{}{"amount": map[string]interface{}{"units": "1500"}}, nil
+}
+
+func TestRequestBodyGRPC(t *testing.T) {
+	RegisterTestingT(t)
+
+	http := httpWithBody("application/grpc", "\x00\x00\x00\x00\x02ab")
+	http.Path = "/billing.v1.Payments/Charge"
+	http.Headers["grpc-encoding"] = "identity"
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Request: &authz.AttributeContext_Request{Http: http},
+	}}
+
+	// Without a decoder, gRPC messages can't be inspected.
+	rc, err := NewRequestCache(policystore.NewPolicyStore(), req)
+	Expect(err).ToNot(HaveOccurred())
+	_, _, err = rc.Body().field("amount.units")
+	Expect(err).To(Equal(errNoGRPCDecoder))
+
+	d := &fakeGRPCDecoder{}
+	rc, err = NewRequestCache(policystore.NewPolicyStore(), req, withGRPCDecoder(d))
+	Expect(err).ToNot(HaveOccurred())
+	v, ok, err := rc.Body().field("amount.units")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ok).To(BeTrue())
+	Expect(v).To(Equal([]string{"1500"}))
+	Expect(d.path).To(Equal("/billing.v1.Payments/Charge"))
+	Expect(d.encoding).To(Equal("identity"))
+	Expect(d.body).To(Equal([]byte("\x00\x00\x00\x00\x02ab")))
+}
+
+func TestRequestBodyGRPCWeb(t *testing.T) {
+	RegisterTestingT(t)
+
+	for contentType, body := range map[string]string{
+		"application/grpc-web":            "\x00\x00\x00\x00\x02ab",
+		"application/grpc-web+proto":      "\x00\x00\x00\x00\x02ab",
+		"application/grpc-web-text":       "AAAAAAJhYg==",
+		"application/grpc-web-text+proto": "AAAAAAJhYg==",
+	} {
+		d := &fakeGRPCDecoder{}
+		b := newRequestBody(httpWithBody(contentType, body), 0)
+		b.grpc = d
+		_, ok, err := b.field("amount.units")
+		Expect(err).ToNot(HaveOccurred(), contentType)
+		Expect(ok).To(BeTrue(), contentType)
+		Expect(d.body).To(Equal([]byte("\x00\x00\x00\x00\x02ab")), contentType)
+	}
+
+	b := newRequestBody(httpWithBody("application/grpc-web-text", "not base64!"), 0)
+	b.grpc = &fakeGRPCDecoder{}
+	_, _, err := b.field("amount.units")
+	Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/projectcalico/app-policy/bruteforce"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// MinLoginAttemptsAnnotation restricts a rule to sources that have made at least the given number of login attempts
+// in the brute-force detection window. Attempts are counted both by source identity and by original client address,
+// and the higher count applies. Rules with the annotation fail safe if brute-force detection isn't configured.
+const MinLoginAttemptsAnnotation = AnnotationPrefix + "min-login-attempts"
+
+// TarpitAnnotation delays the response to requests that the rule decides by the given duration, e.g. "2s", to slow
+// down automated clients. The delay is capped at MaxTarpit, and ends early if Envoy stops waiting for the check.
+const TarpitAnnotation = AnnotationPrefix + "tarpit"
+
+// MaxTarpit is the longest delay TarpitAnnotation can add to a response.
+const MaxTarpit = 10 * time.Second
+
+var errNoLoginCounter = errors.New("brute-force detection not configured")
+
+// loginSources returns the keys under which the request's login attempts are counted: its source identity and the
+// address of its original client.
+func loginSources(req *authz.CheckRequest, trustedHops int) []string {
+	var sources []string
+	if id := peerIdentity(req.GetAttributes().GetSource()); id != "" {
+		sources = append(sources, "id/"+id)
+	}
+	if ip := clientIP(req, trustedHops); ip != nil {
+		sources = append(sources, "ip/"+ip.String())
+	}
+	return sources
+}
+
+// recordLogin counts the request against its sources if it is a login attempt.
+func recordLogin(c *bruteforce.Counter, req *authz.CheckRequest, trustedHops int, now time.Time) {
+	http := req.GetAttributes().GetRequest().GetHttp()
+	if c.IsLogin(http.GetMethod(), http.GetPath()) {
+		c.Record(now, loginSources(req, trustedHops)...)
+	}
+}
+
+// matchLoginAttempts checks the rule's login attempt threshold, if any, against the request's sources.
+func matchLoginAttempts(r *proto.Rule, req *requestCache) bool {
+	v, ok := r.GetMetadata().GetAnnotations()[MinLoginAttemptsAnnotation]
+	if !ok {
+		return true
+	}
+	min, err := strconv.Atoi(v)
+	if err != nil || min < 1 {
+		return failSafe(r, MinLoginAttemptsAnnotation, fmt.Errorf("invalid attempt count %q", v))
+	}
+	n, err := req.LoginAttempts()
+	if err != nil {
+		return failSafe(r, MinLoginAttemptsAnnotation, err)
+	}
+	return n >= min
+}
+
+// tarpitDelay returns the delay annotated on the rule, or zero if there is none or it is malformed.
+func tarpitDelay(r *proto.Rule) time.Duration {
+	v, ok := r.GetMetadata().GetAnnotations()[TarpitAnnotation]
+	if !ok {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		log.WithFields(log.Fields{"rule": r.GetRuleId(), "tarpit": v}).Warn("Invalid tarpit annotation, ignoring.")
+		return 0
+	}
+	if d > MaxTarpit {
+		d = MaxTarpit
+	}
+	return d
+}
+
+// tarpit waits for d, or until ctx is done.
+func tarpit(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/bruteforce"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func loginRequest(source, path string) *authz.CheckRequest {
+	req := rateLimitRequest(source, path)
+	req.Attributes.Request.Http.Method = "POST"
+	return req
+}
+
+func TestCheckStoreLoginAttempts(t *testing.T) {
+	RegisterTestingT(t)
+
+	cfg, err := bruteforce.ParseConfig([]byte(`{loginPaths: [/login]}`))
+	Expect(err).ToNot(HaveOccurred())
+	logins := bruteforce.NewCounter(cfg)
+	now := time.Unix(1000, 0)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"stuffing"}}},
+	}
+	deny := &proto.Rule{
+		Action:   "deny",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{MinLoginAttemptsAnnotation: "3"}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "stuffing"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{deny, {Action: "allow"}},
+	}
+	mallory := "spiffe://cluster.local/ns/default/sa/mallory"
+	attempt := func(source, path string) int32 {
+		req := loginRequest(source, path)
+		recordLogin(logins, req, 0, now)
+		return checkStore(store, req, withLoginCounter(logins), withClock(FixedClock(now))).Code
+	}
+
+	Expect(attempt(mallory, "/login")).To(Equal(OK))
+	Expect(attempt(mallory, "/login")).To(Equal(OK))
+	Expect(attempt(mallory, "/login")).To(Equal(PERMISSION_DENIED))
+	// Once over the threshold, the source is denied everywhere the rule applies.
+	Expect(attempt(mallory, "/")).To(Equal(PERMISSION_DENIED))
+	Expect(attempt("spiffe://cluster.local/ns/default/sa/alice", "/login")).To(Equal(OK))
+
+	// Rules fail safe without a counter, or with a malformed threshold.
+	Expect(checkStore(store, loginRequest("", "/")).Code).To(Equal(PERMISSION_DENIED))
+	deny.Metadata.Annotations[MinLoginAttemptsAnnotation] = "lots"
+	Expect(attempt("spiffe://cluster.local/ns/default/sa/alice", "/")).To(Equal(PERMISSION_DENIED))
+}
+
+func TestTarpitDelay(t *testing.T) {
+	RegisterTestingT(t)
+
+	rule := func(v string) *proto.Rule {
+		return &proto.Rule{Metadata: &proto.RuleMetadata{Annotations: map[string]string{TarpitAnnotation: v}}}
+	}
+	Expect(tarpitDelay(nil)).To(BeZero())
+	Expect(tarpitDelay(&proto.Rule{})).To(BeZero())
+	Expect(tarpitDelay(rule("2s"))).To(Equal(2 * time.Second))
+	Expect(tarpitDelay(rule("1h"))).To(Equal(MaxTarpit))
+	Expect(tarpitDelay(rule("soon"))).To(BeZero())
+}
+
+func TestCheckTarpit(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := bruteforce.ParseConfig([]byte(`{loginPaths: [/login]}`))
+	Expect(err).ToNot(HaveOccurred())
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithBruteForceDetection(cfg))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{
+			{
+				Action: "deny",
+				Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+					MinLoginAttemptsAnnotation: "2",
+					TarpitAnnotation:           "200ms",
+				}},
+			},
+			{Action: "allow"},
+		},
+	}
+	uut.Store = store
+
+	req := loginRequest("spiffe://cluster.local/ns/default/sa/mallory", "/login")
+	start := time.Now()
+	resp, err := uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+
+	start = time.Now()
+	resp, err = uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(time.Since(start)).To(BeNumerically(">=", 200*time.Millisecond))
+
+	// The tarpit ends if Envoy gives up.
+	short, cancelShort := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancelShort()
+	start = time.Now()
+	_, err = uut.Check(short, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(time.Since(start)).To(BeNumerically("<", 200*time.Millisecond))
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"time"
+
+	"github.com/projectcalico/app-policy/evalbudget"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var budgetsExceeded = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "dikastes_evaluation_budget_exceeded_total",
+	Help: "Requests whose policy evaluation ran over its budget and got the budget's verdict, by the budget's service.",
+}, []string{"service"})
+
+func init() {
+	prometheus.MustRegister(budgetsExceeded)
+}
+
+// budgetExceeded is panicked with when a request's evaluation runs over its budget, and recovered by checkStore.
+type budgetExceeded struct {
+	// service names the budget.
+	service string
+	// verdict is the status code that requests over the budget get.
+	verdict int32
+}
+
+// evaluationBudget is the time a request's evaluation has left.
+type evaluationBudget struct {
+	deadline time.Time
+	exceeded *budgetExceeded
+}
+
+// startBudget starts the clock on the budget for the request's destination, if it has one.
+func (r *requestCache) startBudget() {
+	if r.budgets == nil {
+		return
+	}
+	service, b := r.budgets.Lookup(r.DestinationServices())
+	if b == nil {
+		return
+	}
+	// The config only allows verdicts that ParseVerdict accepts.
+	verdict, _ := ParseVerdict(b.Verdict)
+	r.budget = &evaluationBudget{
+		deadline: r.clock.Now().Add(b.MaxTime()),
+		exceeded: &budgetExceeded{service: service, verdict: verdict},
+	}
+}
+
+// checkBudget ends the request's evaluation if it has run over its budget. Evaluation is checked between rules, so
+// a single slow rule can overrun the budget by the time it takes, but not decide the request.
+func (r *requestCache) checkBudget() {
+	if r.budget != nil && r.clock.Now().After(r.budget.deadline) {
+		panic(r.budget.exceeded)
+	}
+}
+
+// overBudget returns the status for a request whose evaluation ran over its budget.
+func overBudget(b *budgetExceeded, req *requestCache) int32 {
+	budgetsExceeded.WithLabelValues(b.service).Inc()
+	log.WithFields(log.Fields{
+		"budget":  b.service,
+		"verdict": b.verdict,
+		"path":    req.Request.GetAttributes().GetRequest().GetHttp().GetPath(),
+	}).Debug("Policy evaluation ran over its budget")
+	// The verdict depends on how long evaluation took, so it mustn't be shared.
+	req.notCacheable()
+	return b.verdict
+}
+
+// withEvaluationBudgets bounds how long evaluating the request can take, by its destination.
+func withEvaluationBudgets(c *evalbudget.Config) requestOption {
+	return func(r *requestCache) {
+		r.budgets = c
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectcalico/app-policy/evalbudget"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCheckStoreEvaluationBudget(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := serviceStore()
+	budgets, err := evalbudget.ParseConfig([]byte(`
+default:
+  maxEvaluationTime: 1ms
+  verdict: allow
+services:
+- service: shop/cart
+  maxEvaluationTime: 5ms
+  verdict: unavailable
+`))
+	Expect(err).ToNot(HaveOccurred())
+	// Each rule takes the clock a further 3ms, so a POST to shop/cart, decided by the third rule, runs over its 5ms
+	// budget after the second.
+	check := func(method, originalDst string, budgets *evalbudget.Config) int32 {
+		return checkStore(store, serviceRequest(method, "10.0.0.5", 8080, originalDst),
+			withClock(&steppingClock{now: time.Unix(1700000000, 0), step: 3 * time.Millisecond}),
+			withEvaluationBudgets(budgets)).Code
+	}
+
+	exceeded := testutil.ToFloat64(budgetsExceeded.WithLabelValues("shop/cart"))
+	Expect(check("POST", "10.96.0.10:80", budgets)).To(Equal(UNAVAILABLE))
+	Expect(testutil.ToFloat64(budgetsExceeded.WithLabelValues("shop/cart"))).To(Equal(exceeded + 1))
+
+	// Requests to destinations without budgets of their own get the default budget.
+	Expect(check("GET", "", budgets)).To(Equal(OK))
+
+	// Within budget, policy decides.
+	budgets, err = evalbudget.ParseConfig([]byte("services: [{service: shop/cart, maxEvaluationTime: 10ms}]"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(check("POST", "10.96.0.10:80", budgets)).To(Equal(OK))
+	Expect(check("GET", "", budgets)).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// SrcCertExpiresWithinAnnotation restricts a rule to requests whose client certificate expires within the given
+// duration, such as "24h", or has already expired. Rules with the annotation fail safe for requests without a
+// certificate, which Envoy only sends if its ext_authz filter sets include_peer_certificate.
+const SrcCertExpiresWithinAnnotation = AnnotationPrefix + "src-cert-expires-within"
+
+var errNoPeerCertificate = errors.New("no client certificate")
+
+var peerCertificates = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "dikastes_peer_certificate_requests_total",
+	Help: "Requests from clients whose certificates are expiring soon or have expired, by state.",
+}, []string{"state"})
+
+func init() {
+	prometheus.MustRegister(peerCertificates)
+}
+
+// peerCertificate parses the certificate a peer presented, which Envoy sends URL-encoded in PEM format. Envoy encodes
+// "+" in the base64, so it is unescaped as part of a path rather than a query, where it would mean a space.
+func peerCertificate(p *authz.AttributeContext_Peer) (*x509.Certificate, error) {
+	if p.GetCertificate() == "" {
+		return nil, errNoPeerCertificate
+	}
+	s, err := url.PathUnescape(p.GetCertificate())
+	if err != nil {
+		return nil, err
+	}
+	b, _ := pem.Decode([]byte(s))
+	if b == nil || b.Type != "CERTIFICATE" {
+		return nil, errors.New("client certificate is not a PEM certificate")
+	}
+	return x509.ParseCertificate(b.Bytes)
+}
+
+// matchCertExpiry checks the rule's certificate expiry condition, if any, against the request's client certificate.
+func matchCertExpiry(r *proto.Rule, req *requestCache) bool {
+	v, ok := r.GetMetadata().GetAnnotations()[SrcCertExpiresWithinAnnotation]
+	if !ok {
+		return true
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return failSafe(r, SrcCertExpiresWithinAnnotation, fmt.Errorf("bad duration %q", v))
+	}
+	cert, err := req.PeerCertificate()
+	if err != nil {
+		return failSafe(r, SrcCertExpiresWithinAnnotation, err)
+	}
+	return cert.NotAfter.Before(req.Now().Add(d))
+}
+
+// checkCertExpiry logs and counts requests whose client certificate expires within warnWithin, if it is positive,
+// or has expired. Requests with expired certificates are denied with UNAUTHENTICATED if denyExpired is set; the
+// connection was accepted before the certificate expired, or by a peer that doesn't check expiry. Requests without a
+// certificate are left to policy.
+func checkCertExpiry(
+	warnWithin time.Duration, denyExpired bool, req *authz.CheckRequest, now time.Time,
+) *status.Status {
+	if warnWithin <= 0 && !denyExpired {
+		return &status.Status{Code: OK}
+	}
+	cert, err := peerCertificate(req.GetAttributes().GetSource())
+	if err != nil {
+		if err != errNoPeerCertificate {
+			log.WithError(err).Debug("Unable to parse client certificate.")
+		}
+		return &status.Status{Code: OK}
+	}
+	fields := log.Fields{
+		"source":   req.GetAttributes().GetSource().GetPrincipal(),
+		"subject":  cert.Subject.String(),
+		"serial":   cert.SerialNumber.String(),
+		"notAfter": cert.NotAfter,
+	}
+	switch {
+	case now.After(cert.NotAfter):
+		peerCertificates.WithLabelValues("expired").Inc()
+		if denyExpired {
+			log.WithFields(fields).Warn("Client certificate has expired, denying request.")
+			return &status.Status{Code: UNAUTHENTICATED, Message: "client certificate has expired"}
+		}
+		log.WithFields(fields).Warn("Client certificate has expired.")
+	case warnWithin > 0 && cert.NotAfter.Before(now.Add(warnWithin)):
+		peerCertificates.WithLabelValues("expiring").Inc()
+		log.WithFields(fields).Warn("Client certificate expires soon.")
+	}
+	return &status.Status{Code: OK}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// certRequest returns a request whose client presented a certificate valid until notAfter, encoded as Envoy sends
+// it.
+func certRequest(notAfter time.Time) *authz.CheckRequest {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "steve"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	pemCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	req := rateLimitRequest("spiffe://cluster.local/ns/default/sa/steve", "/")
+	req.Attributes.Source.Certificate = url.PathEscape(string(pemCert))
+	return req
+}
+
+func TestPeerCertificate(t *testing.T) {
+	RegisterTestingT(t)
+
+	notAfter := time.Unix(2000000000, 0).UTC()
+	cert, err := peerCertificate(certRequest(notAfter).GetAttributes().GetSource())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(cert.NotAfter).To(Equal(notAfter))
+	Expect(cert.Subject.CommonName).To(Equal("steve"))
+
+	_, err = peerCertificate(&authz.AttributeContext_Peer{})
+	Expect(err).To(Equal(errNoPeerCertificate))
+	_, err = peerCertificate(&authz.AttributeContext_Peer{Certificate: "not%20a%20certificate"})
+	Expect(err).To(HaveOccurred())
+}
+
+func TestCheckCertExpiry(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Unix(2000000000, 0)
+	expired := certRequest(now.Add(-time.Hour))
+	expiring := certRequest(now.Add(time.Hour))
+	valid := certRequest(now.Add(30 * 24 * time.Hour))
+	none := rateLimitRequest("", "/")
+
+	expiredBefore := testutil.ToFloat64(peerCertificates.WithLabelValues("expired"))
+	expiringBefore := testutil.ToFloat64(peerCertificates.WithLabelValues("expiring"))
+
+	Expect(checkCertExpiry(24*time.Hour, false, expired, now).Code).To(Equal(OK))
+	Expect(checkCertExpiry(24*time.Hour, true, expired, now).Code).To(Equal(UNAUTHENTICATED))
+	Expect(checkCertExpiry(0, true, expired, now).Code).To(Equal(UNAUTHENTICATED))
+	Expect(checkCertExpiry(24*time.Hour, true, expiring, now).Code).To(Equal(OK))
+	Expect(checkCertExpiry(24*time.Hour, true, valid, now).Code).To(Equal(OK))
+	Expect(checkCertExpiry(24*time.Hour, true, none, now).Code).To(Equal(OK))
+	// Disabled checks don't parse or count anything.
+	Expect(checkCertExpiry(0, false, expired, now).Code).To(Equal(OK))
+
+	Expect(testutil.ToFloat64(peerCertificates.WithLabelValues("expired"))).To(Equal(expiredBefore + 3))
+	Expect(testutil.ToFloat64(peerCertificates.WithLabelValues("expiring"))).To(Equal(expiringBefore + 1))
+}
+
+func TestCheckStoreCertExpiry(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"rotation"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "rotation"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{
+			Action:   "deny",
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{SrcCertExpiresWithinAnnotation: "1h"}},
+		}, {Action: "allow"}},
+	}
+	now := time.Unix(2000000000, 0)
+	check := func(req *authz.CheckRequest) int32 {
+		return checkStore(store, req, withClock(FixedClock(now))).Code
+	}
+
+	Expect(check(certRequest(now.Add(-time.Minute)))).To(Equal(PERMISSION_DENIED))
+	Expect(check(certRequest(now.Add(30 * time.Minute)))).To(Equal(PERMISSION_DENIED))
+	Expect(check(certRequest(now.Add(2 * time.Hour)))).To(Equal(OK))
+	// Requests without certificates fail safe.
+	Expect(check(rateLimitRequest("", "/"))).To(Equal(PERMISSION_DENIED))
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2018 Tigera, Inc. All rights reserved.
+// Copyright (c) 2018-2026 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -31,6 +31,8 @@ var PERMISSION_DENIED = int32(code.Code_PERMISSION_DENIED)
 var UNAVAILABLE = int32(code.Code_UNAVAILABLE)
 var INVALID_ARGUMENT = int32(code.Code_INVALID_ARGUMENT)
 var INTERNAL = int32(code.Code_INTERNAL)
+var RESOURCE_EXHAUSTED = int32(code.Code_RESOURCE_EXHAUSTED)
+var UNAUTHENTICATED = int32(code.Code_UNAUTHENTICATED)
 
 // Action is an enumeration of actions a policy rule can take if it is matched.
 type Action int
@@ -41,43 +43,74 @@ const (
 	LOG
 	PASS
 	NO_MATCH // Indicates policy did not match request. Cannot be assigned to rule.
+	DELEGATE // A pass rule that hands the decision to the upstream policy decision point.
 )
 
 // checkStore applies the policy in the given store and returns OK if the check passes, or PERMISSION_DENIED if the
-// check fails. Note, if no policy matches, the default is PERMISSION_DENIED.
-func checkStore(store *policystore.PolicyStore, req *authz.CheckRequest) (s status.Status) {
+// check fails. Note, if no policy matches, the default is PERMISSION_DENIED unless the route overrides it with
+// ContextExtensionDefaultAction.
+func checkStore(store *policystore.PolicyStore, req *authz.CheckRequest, opts ...requestOption) (s status.Status) {
 	s = status.Status{Code: PERMISSION_DENIED}
-	ep := store.Endpoint
-	if ep == nil {
-		log.Warning("CheckRequest before we synced Endpoint information.")
-		return
-	}
-	reqCache, err := NewRequestCache(store, req)
+	reqCache, err := NewRequestCache(store, req, opts...)
 	if err != nil {
 		log.WithField("error", err).Error("Failed to init requestCache")
 		return
 	}
+	ep := reqCache.endpoint
+	if ep == nil {
+		log.Warning("CheckRequest before we synced Endpoint information, or to an unknown endpoint.")
+		return
+	}
 	defer func() {
 		if r := recover(); r != nil {
 			// Recover from the panic if we know what it is and we know what to do with it.
 			if _, ok := r.(*InvalidDataFromDataPlane); ok {
 				s = status.Status{Code: INVALID_ARGUMENT}
+			} else if b, ok := r.(*budgetExceeded); ok {
+				s = status.Status{Code: overBudget(b, reqCache), Message: "policy evaluation budget exceeded"}
 			} else {
 				panic(r)
 			}
 		}
 	}()
+	reqCache.startBudget()
+	if reqCache.intentions != nil || reqCache.attachments != nil {
+		// Intentions and attachments change without changing the store's generation.
+		reqCache.notCacheable()
+	}
+	if reqCache.intentions != nil {
+		if code, decided := checkIntentions(reqCache.intentions, reqCache); decided {
+			s.Code = code
+			return
+		}
+	}
 	if len(ep.Tiers) > 0 {
 		// We only support a single tier.
 		log.Debug("Checking policy tier 1.")
 
 		tier := ep.Tiers[0]
 		policies := tier.IngressPolicies
+		if reqCache.outbound {
+			log.Debug("Outbound request, checking egress policy.")
+			policies = tier.EgressPolicies
+		}
 		action := NO_MATCH
+		// enforced and staged count the policies that applied to the request, by whether they are enforced.
+		enforced, staged := 0, 0
 	Policy:
 		for i, name := range policies {
 			pID := proto.PolicyID{Tier: tier.GetName(), Name: name}
+			if !policyApplies(pID, reqCache) {
+				continue
+			}
 			policy := store.PolicyByID[pID]
+			if policystore.IsStaged(pID) {
+				// Staged policies only report what they would have done, and evaluation carries on past them.
+				checkStagedPolicy(pID, policy, reqCache)
+				staged++
+				continue
+			}
+			enforced++
 			action = checkPolicy(policy, reqCache)
 			log.WithFields(log.Fields{
 				"ordinal":  i,
@@ -89,23 +122,30 @@ func checkStore(store *policystore.PolicyStore, req *authz.CheckRequest) (s stat
 				continue
 			// If the Policy matches, end evaluation (skipping profiles, if any)
 			case ALLOW:
+				reqCache.decidedBy(pID.Tier + "/" + pID.Name)
 				s.Code = OK
 				return
 			case DENY:
+				reqCache.decidedBy(pID.Tier + "/" + pID.Name)
 				s.Code = PERMISSION_DENIED
 				return
 			case PASS:
 				// Pass means end evaluation of policies and proceed to profiles, if any.
 				break Policy
+			case DELEGATE:
+				// The server asks the upstream policy decision point, if it has one. Otherwise, deny.
+				reqCache.decidedBy(pID.Tier + "/" + pID.Name)
+				s.Code = PERMISSION_DENIED
+				return
 			case LOG:
 				panic("policy should never return LOG action")
 			}
 		}
 		// Done evaluating policies in the tier. If no policy rules have matched, there is an implicit default deny
-		// at the end of the tier.
-		if action == NO_MATCH {
-			log.Debug("No policy matched. Tier default DENY applies.")
-			s.Code = PERMISSION_DENIED
+		// at the end of the tier, unless the only policies in it are staged.
+		if action == NO_MATCH && (enforced > 0 || staged == 0) {
+			log.Debug("No policy matched. Tier default action applies.")
+			s.Code = defaultVerdict(req)
 			return
 		}
 	}
@@ -124,40 +164,59 @@ func checkStore(store *policystore.PolicyStore, req *authz.CheckRequest) (s stat
 			case NO_MATCH:
 				continue
 			case ALLOW:
+				reqCache.decidedBy("profile/" + name)
 				s.Code = OK
 				return
-			case DENY, PASS:
+			case DENY, PASS, DELEGATE:
+				reqCache.decidedBy("profile/" + name)
 				s.Code = PERMISSION_DENIED
 				return
 			case LOG:
 				log.Panic("profile should never return LOG action")
 			}
 		}
+		log.Debug("No profile matched, default action applies.")
 	} else {
-		log.Debug("0 active profiles, deny request.")
-		s.Code = PERMISSION_DENIED
+		log.Debug("0 active profiles, default action applies.")
 	}
+	s.Code = defaultVerdict(req)
 	return
 }
 
 // checkPolicy checks if the policy matches the request data, and returns the action.
 func checkPolicy(policy *proto.Policy, req *requestCache) (action Action) {
-	// Note that we support only inbound policy.
+	if req.outbound {
+		return checkRules(policy.OutboundRules, req, policy.Namespace)
+	}
 	return checkRules(policy.InboundRules, req, policy.Namespace)
 }
 
 func checkProfile(p *proto.Profile, req *requestCache) (action Action) {
+	if req.outbound {
+		return checkRules(p.OutboundRules, req, "")
+	}
 	return checkRules(p.InboundRules, req, "")
 }
 
 func checkRules(rules []*proto.Rule, req *requestCache, policyNamespace string) (action Action) {
-	for _, r := range rules {
-		if match(r, req, policyNamespace) {
+	for i, r := range rules {
+		if !cacheableRule(r) {
+			req.notCacheable()
+		}
+		matched := match(r, req, policyNamespace)
+		req.checkBudget()
+		if matched {
 			log.Debugf("Rule matched.")
 			a := actionFromString(r.Action)
+			if delegates(r) {
+				a = DELEGATE
+			}
 			if a != LOG {
 				// We don't support actually logging requests, but if we hit a LOG action, we should
 				// continue processing rules.
+				if req.ruleMatched != nil {
+					req.ruleMatched(r, i)
+				}
 				return a
 			}
 		}
@@ -17,6 +17,7 @@ package checker
 import (
 	"testing"
 
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	. "github.com/onsi/gomega"
 
@@ -24,6 +25,18 @@ import (
 	"github.com/projectcalico/app-policy/proto"
 )
 
+// tcpDestination returns the destination peer of test requests. Envoy's HTTP filter always sends the TCP socket
+// address a request was sent to, and rules don't match requests without one, since they check its protocol.
+func tcpDestination() *authz.AttributeContext_Peer {
+	return &authz.AttributeContext_Peer{
+		Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+			Address:       "10.0.0.2",
+			PortSpecifier: &core.SocketAddress_PortValue{PortValue: 8080},
+			Protocol:      core.SocketAddress_TCP,
+		}}},
+	}
+}
+
 // actionFromString should parse strings in case insensitive mode.
 func TestActionFromString(t *testing.T) {
 	RegisterTestingT(t)
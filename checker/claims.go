@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// ClaimsAnnotation restricts a rule to requests whose JWT has the listed claims. It is a comma separated list of
+// conditions in the same form as BodyFieldsAnnotation's, such as "iss=https://accounts.example.com,groups=admin".
+// Claims holding arrays, such as groups or scopes, match if any of their values does. Rules with the annotation fail
+// safe for requests without a valid token.
+const ClaimsAnnotation = AnnotationPrefix + "claims"
+
+// JWTAuthnNamespace is the filter metadata namespace that Envoy's jwt_authn filter writes the claims of validated
+// tokens to, under its payload_in_metadata key. Envoy sends it if it is listed in the ext_authz filter's
+// metadata_context_namespaces.
+const JWTAuthnNamespace = "envoy.filters.http.jwt_authn"
+
+var errNoClaims = errors.New("no validated JWT claims")
+
+// TokenValidator validates the JWT in a request's headers and returns its claims, as jwks.Validator does.
+type TokenValidator interface {
+	Validate(headers map[string]string, now time.Time) (map[string]interface{}, error)
+}
+
+// requestClaims returns the claims of the request's JWT. Claims that Envoy's jwt_authn filter validated are used if
+// present, and otherwise the token is validated by v, if set.
+func requestClaims(req *authz.CheckRequest, v TokenValidator, now time.Time) (map[string]interface{}, error) {
+	md := req.GetAttributes().GetMetadataContext().GetFilterMetadata()[JWTAuthnNamespace]
+	// jwt_authn writes each provider's claims under its own key; use the first in a stable order.
+	keys := make([]string, 0, len(md.GetFields()))
+	for k := range md.GetFields() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if s := md.GetFields()[k].GetStructValue(); s != nil {
+			return structClaims(s), nil
+		}
+	}
+	if v == nil {
+		return nil, errNoClaims
+	}
+	return v.Validate(req.GetAttributes().GetRequest().GetHttp().GetHeaders(), now)
+}
+
+// structClaims converts claims from metadata into the form they have when decoded from JSON.
+func structClaims(s *structpb.Struct) map[string]interface{} {
+	claims := make(map[string]interface{}, len(s.GetFields()))
+	for k, v := range s.GetFields() {
+		claims[k] = structValue(v)
+	}
+	return claims
+}
+
+func structValue(v *structpb.Value) interface{} {
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_StringValue:
+		return k.StringValue
+	case *structpb.Value_NumberValue:
+		return json.Number(strconv.FormatFloat(k.NumberValue, 'f', -1, 64))
+	case *structpb.Value_BoolValue:
+		return k.BoolValue
+	case *structpb.Value_StructValue:
+		return structClaims(k.StructValue)
+	case *structpb.Value_ListValue:
+		l := make([]interface{}, len(k.ListValue.GetValues()))
+		for i, e := range k.ListValue.GetValues() {
+			l[i] = structValue(e)
+		}
+		return l
+	}
+	return nil
+}
+
+// claimValues returns the values of a claim, which may be a dotted path into nested claims, and whether it is
+// present. Unlike body fields, arrays of strings and numbers have a value for each element.
+func claimValues(claims map[string]interface{}, name string) ([]string, bool) {
+	v, ok := jsonPath(claims, name)
+	if !ok {
+		return nil, false
+	}
+	if l, isList := v.([]interface{}); isList {
+		var values []string
+		for _, e := range l {
+			if s, ok := jsonScalar(e); ok {
+				values = append(values, s)
+			}
+		}
+		return values, true
+	}
+	s, ok := jsonScalar(v)
+	if !ok {
+		return nil, true
+	}
+	// Space separated scope claims are matched by scope, as in RFC 8693.
+	if name == "scope" {
+		return strings.Fields(s), true
+	}
+	return []string{s}, true
+}
+
+// matchClaims checks the rule's claim conditions, if any, against the request's JWT.
+func matchClaims(r *proto.Rule, req *requestCache) bool {
+	conditions, ok := r.GetMetadata().GetAnnotations()[ClaimsAnnotation]
+	if !ok {
+		return true
+	}
+	claims, err := req.Claims()
+	if err != nil {
+		return failSafe(r, ClaimsAnnotation, err)
+	}
+	for _, c := range strings.Split(conditions, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		name, op, value := parseBodyCondition(c)
+		values, present := claimValues(claims, name)
+		if !present {
+			return false
+		}
+		ok, err := compareBodyField(values, op, value)
+		if err != nil {
+			return failSafe(r, ClaimsAnnotation, err)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
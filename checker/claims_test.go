@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// fakeTokenValidator accepts the tokens it has claims for.
+type fakeTokenValidator map[string]map[string]interface{}
+
+func (f fakeTokenValidator) Validate(headers map[string]string, _ time.Time) (map[string]interface{}, error) {
+	claims, ok := f[headers["authorization"]]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// claimsRequest returns a CheckRequest with the given headers, and with claims in the jwt_authn metadata if set.
+func claimsRequest(headers map[string]string, claims *structpb.Struct) *authz.CheckRequest {
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Destination: tcpDestination(),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Path: "/", Headers: headers},
+		},
+	}}
+	if claims != nil {
+		req.Attributes.MetadataContext = &core.Metadata{FilterMetadata: map[string]*structpb.Struct{
+			JWTAuthnNamespace: {Fields: map[string]*structpb.Value{
+				"jwt_payload": {Kind: &structpb.Value_StructValue{StructValue: claims}},
+			}},
+		}}
+	}
+	return req
+}
+
+func TestClaimValues(t *testing.T) {
+	RegisterTestingT(t)
+
+	claims := map[string]interface{}{
+		"sub":    "alice",
+		"groups": []interface{}{"dev", "admin", map[string]interface{}{}},
+		"scope":  "read write",
+		"level":  json.Number("3"),
+		"org":    map[string]interface{}{"id": "acme"},
+	}
+	for name, want := range map[string][]string{
+		"sub":    {"alice"},
+		"groups": {"dev", "admin"},
+		"scope":  {"read", "write"},
+		"level":  {"3"},
+		"org.id": {"acme"},
+		"org":    nil,
+	} {
+		values, present := claimValues(claims, name)
+		Expect(present).To(BeTrue(), name)
+		Expect(values).To(Equal(want), name)
+	}
+	_, present := claimValues(claims, "email")
+	Expect(present).To(BeFalse())
+}
+
+func TestRequestClaimsFromMetadata(t *testing.T) {
+	RegisterTestingT(t)
+
+	md := &structpb.Struct{Fields: map[string]*structpb.Value{
+		"sub": {Kind: &structpb.Value_StringValue{StringValue: "alice"}},
+		"exp": {Kind: &structpb.Value_NumberValue{NumberValue: 1700000000}},
+		"groups": {Kind: &structpb.Value_ListValue{ListValue: &structpb.ListValue{Values: []*structpb.Value{
+			{Kind: &structpb.Value_StringValue{StringValue: "admin"}},
+		}}}},
+	}}
+	// Claims Envoy validated take precedence over the validator.
+	v := fakeTokenValidator{"Bearer t": {"sub": "bob"}}
+	claims, err := requestClaims(claimsRequest(map[string]string{"authorization": "Bearer t"}, md), v, time.Now())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(claims).To(Equal(map[string]interface{}{
+		"sub":    "alice",
+		"exp":    json.Number("1700000000"),
+		"groups": []interface{}{"admin"},
+	}))
+
+	claims, err = requestClaims(claimsRequest(map[string]string{"authorization": "Bearer t"}, nil), v, time.Now())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(claims["sub"]).To(Equal("bob"))
+	_, err = requestClaims(claimsRequest(nil, nil), nil, time.Now())
+	Expect(err).To(Equal(errNoClaims))
+}
+
+func TestCheckStoreClaims(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"admins"}}},
+	}
+	deny := &proto.Rule{
+		Action:   "deny",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{ClaimsAnnotation: "suspended=true"}},
+	}
+	allow := &proto.Rule{
+		Action: "allow",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+			ClaimsAnnotation: "iss=https://issuer.example.com, groups=admin, level>=2",
+		}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "admins"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{deny, allow},
+	}
+	v := fakeTokenValidator{
+		"admin": {"iss": "https://issuer.example.com", "groups": []interface{}{"dev", "admin"}, "level": json.Number("2")},
+		"dev":   {"iss": "https://issuer.example.com", "groups": []interface{}{"dev"}, "level": json.Number("5")},
+		"suspended": {"iss": "https://issuer.example.com", "groups": []interface{}{"admin"}, "level": json.Number("2"),
+			"suspended": true},
+	}
+	check := func(token string) int32 {
+		return checkStore(store, claimsRequest(map[string]string{"authorization": token}, nil), withTokenValidator(v)).Code
+	}
+
+	Expect(check("admin")).To(Equal(OK))
+	Expect(check("dev")).To(Equal(PERMISSION_DENIED))
+	Expect(check("suspended")).To(Equal(PERMISSION_DENIED))
+	// Requests without a valid token fail safe.
+	Expect(check("forged")).To(Equal(PERMISSION_DENIED))
+	// Without the deny rule, allow rules with invalid tokens don't match.
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "admins"}].InboundRules = []*proto.Rule{allow}
+	Expect(check("forged")).To(Equal(PERMISSION_DENIED))
+	Expect(check("suspended")).To(Equal(OK))
+}
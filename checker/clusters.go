@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/trustdomain"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Annotations that restrict a rule by the cluster its source comes from, as comma separated lists of cluster names.
+// A source's cluster is found from the trust domain of its principal; see WithTrustDomains. Rules with either
+// annotation fail safe if trust domains aren't configured or the source has no principal.
+const (
+	SrcClustersAnnotation    = AnnotationPrefix + "src-clusters"
+	NotSrcClustersAnnotation = AnnotationPrefix + "not-src-clusters"
+)
+
+var (
+	errNoTrustDomains  = errors.New("trust domains not configured")
+	errNoSourceCluster = errors.New("source has no principal to find its cluster from")
+)
+
+// linkerdIdentityPart separates the service account and namespace of a Linkerd identity from its trust domain.
+const linkerdIdentityPart = ".serviceaccount.identity."
+
+// TrustDomains maps the trust domains of peers' principals to the clusters they come from, and their namespaces to
+// this cluster's.
+type TrustDomains interface {
+	Map(trustDomain, namespace string) trustdomain.Identity
+}
+
+// principalTrustDomain returns the trust domain of a SPIFFE ID or Linkerd identity, e.g. "cluster.local" for both
+// spiffe://cluster.local/ns/default/sa/foo and foo.default.serviceaccount.identity.linkerd.cluster.local.
+func principalTrustDomain(principal string) string {
+	if strings.HasPrefix(principal, "spiffe://") {
+		td := strings.TrimPrefix(principal, "spiffe://")
+		if i := strings.Index(td, "/"); i >= 0 {
+			td = td[:i]
+		}
+		return td
+	}
+	if i := strings.Index(principal, linkerdIdentityPart); i >= 0 {
+		// The label after the identity part is the namespace of Linkerd's control plane.
+		rest := principal[i+len(linkerdIdentityPart):]
+		if j := strings.Index(rest, "."); j >= 0 {
+			return rest[j+1:]
+		}
+	}
+	return ""
+}
+
+// mapTrustDomain places the peer with the given principal in its cluster, and its namespace in this cluster. Peers
+// from external trust domains lose their namespace and service account, so that only rules matching their cluster or
+// principal apply to them.
+func mapTrustDomain(t TrustDomains, principal string, p *peer) {
+	if principal == "" {
+		return
+	}
+	id := t.Map(principalTrustDomain(principal), p.Namespace)
+	p.Cluster = id.Cluster
+	p.Namespace = id.Namespace
+	if id.External {
+		p.Name = ""
+		p.External = true
+	}
+}
+
+// matchExternalPeer stops rules that match source service accounts or namespaces from matching peers from external
+// trust domains. Their empty service account and namespace would otherwise match, as a plain text peer's do.
+func matchExternalPeer(r *proto.Rule, nsMatch *namespaceMatch, p peer) bool {
+	if !p.External {
+		return true
+	}
+	sa := r.GetSrcServiceAccountMatch()
+	return len(sa.GetNames()) == 0 && sa.GetSelector() == "" && len(nsMatch.Names) == 0 && nsMatch.Selector == ""
+}
+
+// matchSrcClusters checks the rule's cluster conditions, if any, against the cluster the request's source comes from.
+func matchSrcClusters(r *proto.Rule, req *requestCache) bool {
+	annotations := r.GetMetadata().GetAnnotations()
+	for _, a := range []string{SrcClustersAnnotation, NotSrcClustersAnnotation} {
+		v, ok := annotations[a]
+		if !ok {
+			continue
+		}
+		if req.trustDomains == nil {
+			return failSafe(r, a, errNoTrustDomains)
+		}
+		cluster := req.SourcePeer().Cluster
+		if cluster == "" {
+			return failSafe(r, a, errNoSourceCluster)
+		}
+		if containsFold(v, cluster) != (a == SrcClustersAnnotation) {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), a: v, "cluster": cluster}).Debug(
+				"Source cluster doesn't match rule")
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/trustdomain"
+)
+
+func clusterRequest(method, principal string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source:      &authz.AttributeContext_Peer{Principal: principal},
+		Destination: tcpDestination(),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: method, Path: "/"},
+		},
+	}}
+}
+
+func clusterStore() *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"shop/default.cross-cluster"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "shop/default.cross-cluster"}] = &proto.Policy{
+		Namespace: "shop",
+		InboundRules: []*proto.Rule{
+			{
+				Action:                 "allow",
+				SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"web"}},
+				HttpMatch:              &proto.HTTPMatch{Methods: []string{"GET"}},
+			},
+			{
+				Action:    "allow",
+				HttpMatch: &proto.HTTPMatch{Methods: []string{"POST"}},
+				Metadata:  &proto.RuleMetadata{Annotations: map[string]string{SrcClustersAnnotation: "partner"}},
+			},
+			{
+				Action:    "deny",
+				HttpMatch: &proto.HTTPMatch{Methods: []string{"PUT"}},
+				Metadata:  &proto.RuleMetadata{Annotations: map[string]string{NotSrcClustersAnnotation: "west, east"}},
+			},
+			{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"PUT"}}},
+		},
+	}
+	return store
+}
+
+func TestPrincipalTrustDomain(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(principalTrustDomain("spiffe://cluster.local/ns/default/sa/foo")).To(Equal("cluster.local"))
+	Expect(principalTrustDomain("spiffe://east.example.com")).To(Equal("east.example.com"))
+	Expect(principalTrustDomain("foo.default.serviceaccount.identity.linkerd.east.example.com")).To(
+		Equal("east.example.com"))
+	Expect(principalTrustDomain("")).To(Equal(""))
+	Expect(principalTrustDomain("foo")).To(Equal(""))
+}
+
+func TestCheckStoreSrcClusters(t *testing.T) {
+	RegisterTestingT(t)
+
+	m, err := trustdomain.NewMapper(&trustdomain.Config{
+		LocalCluster: "west",
+		TrustDomains: []trustdomain.TrustDomain{
+			{Name: "east.example.com", Cluster: "east", Namespaces: map[string]string{"shop-east": "shop"}},
+			{Name: "partner.example.org", Cluster: "partner", External: true},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	store := clusterStore()
+	check := func(method, principal string, opts ...requestOption) int32 {
+		return checkStore(store, clusterRequest(method, principal), opts...).Code
+	}
+	local := "spiffe://cluster.local/ns/shop/sa/web"
+	east := "spiffe://east.example.com/ns/shop-east/sa/web"
+	partner := "spiffe://partner.example.org/ns/shop/sa/web"
+
+	// Namespaces are mapped to this cluster's, and external peers have none.
+	Expect(check("GET", local, withTrustDomains(m))).To(Equal(OK))
+	Expect(check("GET", east, withTrustDomains(m))).To(Equal(OK))
+	Expect(check("GET", "web.shop-east.serviceaccount.identity.linkerd.east.example.com", withTrustDomains(m))).To(
+		Equal(OK))
+	Expect(check("GET", partner, withTrustDomains(m))).To(Equal(PERMISSION_DENIED))
+	Expect(check("GET", east)).To(Equal(PERMISSION_DENIED))
+
+	Expect(check("POST", partner, withTrustDomains(m))).To(Equal(OK))
+	Expect(check("POST", east, withTrustDomains(m))).To(Equal(PERMISSION_DENIED))
+	Expect(check("PUT", partner, withTrustDomains(m))).To(Equal(PERMISSION_DENIED))
+	Expect(check("PUT", east, withTrustDomains(m))).To(Equal(OK))
+
+	// Without trust domains, or a principal to find the cluster from, the rules fail safe.
+	Expect(check("POST", partner)).To(Equal(PERMISSION_DENIED))
+	Expect(check("PUT", east)).To(Equal(PERMISSION_DENIED))
+	Expect(check("POST", "", withTrustDomains(m))).To(Equal(PERMISSION_DENIED))
+	Expect(check("PUT", "", withTrustDomains(m))).To(Equal(PERMISSION_DENIED))
+}
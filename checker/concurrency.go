@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/projectcalico/app-policy/concurrency"
+	"github.com/projectcalico/app-policy/proto"
+
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// MaxConcurrentRequestsAnnotation caps the requests each source identity can have in flight through an Allow rule.
+// Requests over the cap are rejected with a 429. Dikastes learns that requests have completed from Envoy's HTTP gRPC
+// access log, which must be sent to its AccessLogService; requests it doesn't hear about count as in flight until
+// their lease expires.
+const MaxConcurrentRequestsAnnotation = AnnotationPrefix + "max-concurrent-requests"
+
+// checkConcurrency counts the request as in flight if the rule that allowed it has a concurrency cap. It returns OK if
+// the request's source is under the cap, and otherwise RESOURCE_EXHAUSTED along with a 429 response. A malformed
+// annotation, or a request with no ID to track it by, denies the request.
+func checkConcurrency(t *concurrency.Tracker, rule *proto.Rule, req *authz.CheckRequest, now time.Time) (status.Status, *authz.CheckResponse_DeniedResponse) {
+	a, ok := rule.GetMetadata().GetAnnotations()[MaxConcurrentRequestsAnnotation]
+	if !ok {
+		return status.Status{Code: OK}, nil
+	}
+	limit, err := strconv.Atoi(a)
+	if err != nil || limit < 1 {
+		log.WithField("rule", rule.GetRuleId()).Warn("Invalid concurrency cap annotation, denying request.")
+		return status.Status{Code: PERMISSION_DENIED}, nil
+	}
+	id := req.GetAttributes().GetRequest().GetHttp().GetId()
+	if id == "" {
+		log.WithField("rule", rule.GetRuleId()).Warn("Request has no ID to track concurrency by, denying request.")
+		return status.Status{Code: PERMISSION_DENIED}, nil
+	}
+	src := peerIdentity(req.GetAttributes().GetSource())
+	if t.Acquire("rule/"+rule.GetRuleId()+"/"+src, id, limit, now) {
+		return status.Status{Code: OK}, nil
+	}
+	log.WithFields(log.Fields{"source": src, "rule": rule.GetRuleId(), "limit": limit}).Info(
+		"Too many concurrent requests.")
+	return status.Status{Code: RESOURCE_EXHAUSTED, Message: "too many concurrent requests"},
+		&authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{
+			Status: &_type.HttpStatus{Code: _type.StatusCode_TooManyRequests},
+		}}
+}
+
+// accessLogServer implements Envoy's AccessLogService, releasing the in-flight requests it reports complete.
+type accessLogServer struct {
+	tracker *concurrency.Tracker
+}
+
+// AccessLogServer returns an AccessLogService that Envoy's HTTP gRPC access log can report completed requests to, so
+// that they stop counting towards concurrency caps.
+func (as *authServer) AccessLogServer() *accessLogServer {
+	return &accessLogServer{tracker: as.concurrency}
+}
+
+// StreamAccessLogs releases each request in the stream's HTTP access log entries.
+func (s *accessLogServer) StreamAccessLogs(stream accesslog.AccessLogService_StreamAccessLogsServer) error {
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&accesslog.StreamAccessLogsResponse{})
+		}
+		if err != nil {
+			log.WithError(err).Debug("Access log stream ended.")
+			return err
+		}
+		for _, e := range msg.GetHttpLogs().GetLogEntry() {
+			s.tracker.Release(e.GetRequest().GetRequestId())
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	data "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/projectcalico/app-policy/concurrency"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// fakeAccessLogStream delivers the given messages, then ends.
+type fakeAccessLogStream struct {
+	grpc.ServerStream
+	msgs   []*accesslog.StreamAccessLogsMessage
+	closed bool
+}
+
+func (s *fakeAccessLogStream) Recv() (*accesslog.StreamAccessLogsMessage, error) {
+	if len(s.msgs) == 0 {
+		return nil, io.EOF
+	}
+	m := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return m, nil
+}
+
+func (s *fakeAccessLogStream) SendAndClose(*accesslog.StreamAccessLogsResponse) error {
+	s.closed = true
+	return nil
+}
+
+func completed(ids ...string) *accesslog.StreamAccessLogsMessage {
+	var entries []*data.HTTPAccessLogEntry
+	for _, id := range ids {
+		entries = append(entries, &data.HTTPAccessLogEntry{Request: &data.HTTPRequestProperties{RequestId: id}})
+	}
+	return &accesslog.StreamAccessLogsMessage{LogEntries: &accesslog.StreamAccessLogsMessage_HttpLogs{
+		HttpLogs: &accesslog.StreamAccessLogsMessage_HTTPAccessLogEntries{LogEntry: entries},
+	}}
+}
+
+func TestCheckConcurrency(t *testing.T) {
+	RegisterTestingT(t)
+
+	tracker := concurrency.NewTracker(time.Minute)
+	rule := &proto.Rule{
+		Action:   "allow",
+		RuleId:   "capped",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{MaxConcurrentRequestsAnnotation: "1"}},
+	}
+	now := time.Unix(1000, 0)
+	check := func(source, id string) int32 {
+		req := rateLimitRequest(source, "/")
+		req.Attributes.Request.Http.Id = id
+		st, _ := checkConcurrency(tracker, rule, req, now)
+		return st.Code
+	}
+
+	Expect(check("spiffe://cluster.local/ns/default/sa/steve", "1")).To(Equal(OK))
+	Expect(check("spiffe://cluster.local/ns/default/sa/steve", "2")).To(Equal(RESOURCE_EXHAUSTED))
+	Expect(check("spiffe://cluster.local/ns/default/sa/bob", "3")).To(Equal(OK))
+	tracker.Release("1")
+	Expect(check("spiffe://cluster.local/ns/default/sa/steve", "2")).To(Equal(OK))
+
+	// Requests that can't be tracked, and malformed caps, are denied. Rules without a cap don't track requests.
+	Expect(check("spiffe://cluster.local/ns/default/sa/alice", "")).To(Equal(PERMISSION_DENIED))
+	rule.Metadata.Annotations[MaxConcurrentRequestsAnnotation] = "many"
+	Expect(check("spiffe://cluster.local/ns/default/sa/alice", "4")).To(Equal(PERMISSION_DENIED))
+	rule.Metadata = nil
+	Expect(check("spiffe://cluster.local/ns/default/sa/steve", "5")).To(Equal(OK))
+	Expect(tracker.InFlight("rule/capped/spiffe://cluster.local/ns/default/sa/steve", now)).To(Equal(1))
+}
+
+// Concurrency caps apply to requests that policy allows, which count until Envoy's access log reports them complete.
+func TestCheckWithConcurrencyCap(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{
+			Action:   "Allow",
+			RuleId:   "capped",
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{MaxConcurrentRequestsAnnotation: "2"}},
+		}},
+	}
+	uut.Store = store
+	check := func(id string) *authz.CheckResponse {
+		req := rateLimitRequest("spiffe://cluster.local/ns/default/sa/steve", "/")
+		req.Attributes.Request.Http.Id = id
+		resp, err := uut.Check(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	Expect(check("1").GetStatus().GetCode()).To(Equal(OK))
+	Expect(check("2").GetStatus().GetCode()).To(Equal(OK))
+	resp := check("3")
+	Expect(resp.GetStatus().GetCode()).To(Equal(RESOURCE_EXHAUSTED))
+	Expect(resp.GetDeniedResponse().GetStatus().GetCode()).To(Equal(_type.StatusCode_TooManyRequests))
+
+	stream := &fakeAccessLogStream{msgs: []*accesslog.StreamAccessLogsMessage{completed("1", "unknown"), {}}}
+	Expect(uut.AccessLogServer().StreamAccessLogs(stream)).To(Succeed())
+	Expect(stream.closed).To(BeTrue())
+	Expect(check("3").GetStatus().GetCode()).To(Equal(OK))
+	Expect(check("4").GetStatus().GetCode()).To(Equal(RESOURCE_EXHAUSTED))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// ContextExtensionsAnnotation restricts a rule to requests whose route sets the listed ext_authz context_extensions.
+// It is a comma separated list of conditions in the same form as BodyFieldsAnnotation's, such as
+// "route=admin,tenant!=internal".
+const ContextExtensionsAnnotation = AnnotationPrefix + "context-extensions"
+
+// Context extensions that a route can set to override how Dikastes treats its requests.
+const (
+	// ContextExtensionDryRun set to "true" handles the route's requests as with WithDryRun, and set to "false"
+	// enforces them even if Dikastes is otherwise in dry-run mode.
+	ContextExtensionDryRun = "calico.dry-run"
+	// ContextExtensionDefaultAction set to "allow" allows the route's requests that no policy or profile decides,
+	// rather than denying them.
+	ContextExtensionDefaultAction = "calico.default-action"
+	// ContextExtensionRoute names the gateway's Gateway API route, as "<namespace>/<name>", so that only the policies
+	// attached to it apply. See WithPolicyAttachments.
+	ContextExtensionRoute = "calico.route"
+)
+
+// matchContextExtensions checks the rule's context extension conditions, if any, against the request's route.
+func matchContextExtensions(r *proto.Rule, req *requestCache) bool {
+	conditions, ok := r.GetMetadata().GetAnnotations()[ContextExtensionsAnnotation]
+	if !ok {
+		return true
+	}
+	extensions := req.Request.GetAttributes().GetContextExtensions()
+	for _, c := range strings.Split(conditions, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		key, op, value := parseBodyCondition(c)
+		v, present := extensions[key]
+		if !present {
+			return false
+		}
+		ok, err := compareBodyField([]string{v}, op, value)
+		if err != nil {
+			return failSafe(r, ContextExtensionsAnnotation, err)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// routeDryRun returns the route's dry-run override, and whether it has one.
+func routeDryRun(req *authz.CheckRequest) (dryRun, ok bool) {
+	v, ok := req.GetAttributes().GetContextExtensions()[ContextExtensionDryRun]
+	if !ok {
+		return false, false
+	}
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil {
+		log.WithField(ContextExtensionDryRun, v).Warn("Ignoring invalid context extension.")
+		return false, false
+	}
+	return dryRun, true
+}
+
+// defaultVerdict returns the verdict for a request that no policy or profile decides: PERMISSION_DENIED unless the
+// route's default action is "allow".
+func defaultVerdict(req *authz.CheckRequest) int32 {
+	v, ok := req.GetAttributes().GetContextExtensions()[ContextExtensionDefaultAction]
+	if !ok {
+		return PERMISSION_DENIED
+	}
+	switch strings.ToLower(v) {
+	case "allow":
+		log.Debug("Route's default action allows request.")
+		return OK
+	case "deny":
+	default:
+		log.WithField(ContextExtensionDefaultAction, v).Warn("Ignoring invalid context extension.")
+	}
+	return PERMISSION_DENIED
+}
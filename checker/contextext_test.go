@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func routeRequest(method string, extensions map[string]string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		ContextExtensions: extensions,
+		Destination:       tcpDestination(),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: method, Path: "/"},
+		},
+	}}
+}
+
+func routeStore() *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"routes"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "routes"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{
+				Action: "deny",
+				Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+					ContextExtensionsAnnotation: "route=admin, tenant!=ops",
+				}},
+			},
+			{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}},
+		},
+	}
+	return store
+}
+
+func TestCheckStoreContextExtensions(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := routeStore()
+	check := func(method string, extensions map[string]string) int32 {
+		return checkStore(store, routeRequest(method, extensions)).Code
+	}
+
+	Expect(check("GET", nil)).To(Equal(OK))
+	// Conditions on extensions the route doesn't set never match.
+	Expect(check("GET", map[string]string{"route": "admin"})).To(Equal(OK))
+	Expect(check("GET", map[string]string{"route": "admin", "tenant": "web"})).To(Equal(PERMISSION_DENIED))
+	Expect(check("GET", map[string]string{"route": "admin", "tenant": "ops"})).To(Equal(OK))
+	Expect(check("GET", map[string]string{"route": "public"})).To(Equal(OK))
+
+	// Routes can allow requests that no policy decides, but not those that policy denies.
+	Expect(check("POST", nil)).To(Equal(PERMISSION_DENIED))
+	Expect(check("POST", map[string]string{ContextExtensionDefaultAction: "Allow"})).To(Equal(OK))
+	Expect(check("POST", map[string]string{ContextExtensionDefaultAction: "maybe"})).To(Equal(PERMISSION_DENIED))
+	Expect(check("GET", map[string]string{"route": "admin", "tenant": "web", ContextExtensionDefaultAction: "allow"})).To(
+		Equal(PERMISSION_DENIED))
+
+	// The same applies to profiles.
+	store.Endpoint = &proto.WorkloadEndpoint{}
+	Expect(check("GET", nil)).To(Equal(PERMISSION_DENIED))
+	Expect(check("GET", map[string]string{ContextExtensionDefaultAction: "allow"})).To(Equal(OK))
+}
+
+func TestCheckRouteDryRun(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	check := func(uut *authServer, extensions map[string]string) int32 {
+		uut.Store = routeStore()
+		resp, err := uut.Check(ctx, routeRequest("POST", extensions))
+		Expect(err).ToNot(HaveOccurred())
+		return resp.GetStatus().GetCode()
+	}
+	enforcing := NewServer(ctx, make(chan *policystore.PolicyStore))
+	Expect(check(enforcing, nil)).To(Equal(PERMISSION_DENIED))
+	Expect(check(enforcing, map[string]string{ContextExtensionDryRun: "true"})).To(Equal(OK))
+	Expect(check(enforcing, map[string]string{ContextExtensionDryRun: "yes please"})).To(Equal(PERMISSION_DENIED))
+
+	dryRun := NewServer(ctx, make(chan *policystore.PolicyStore), WithDryRun(true))
+	Expect(check(dryRun, nil)).To(Equal(OK))
+	Expect(check(dryRun, map[string]string{ContextExtensionDryRun: "false"})).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"strings"
+	"time"
+
+	"github.com/projectcalico/app-policy/decisionsink"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+// DecisionPublisher is told about every decision, for example to stream them to a security analytics platform. It
+// must not block.
+type DecisionPublisher interface {
+	Publish(e decisionsink.Event)
+}
+
+// decisionEvent describes the decision on req. The verdict is the one policy and the checks after it reached, before
+// any dry run allows the request.
+func decisionEvent(
+	principals principalTemplates, req *authz.CheckRequest, verdict int32, enforced bool, policy string,
+	rule *proto.Rule, ruleIndex int, now time.Time, latency time.Duration,
+) decisionsink.Event {
+	if rule == nil {
+		ruleIndex = -1
+	}
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	path := httpReq.GetPath()
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return decisionsink.Event{
+		Time:          now,
+		Source:        eventPeer(principals, req.GetAttributes().GetSource()),
+		Destination:   eventPeer(principals, req.GetAttributes().GetDestination()),
+		Method:        httpReq.GetMethod(),
+		Host:          httpReq.GetHost(),
+		Path:          path,
+		Verdict:       code.Code(verdict).String(),
+		Enforced:      enforced,
+		Policy:        policy,
+		RuleID:        rule.GetRuleId(),
+		RuleIndex:     ruleIndex,
+		LatencyMicros: latency.Microseconds(),
+	}
+}
+
+func eventPeer(principals principalTemplates, p *authz.AttributeContext_Peer) decisionsink.Peer {
+	addr := p.GetAddress().GetSocketAddress()
+	e := decisionsink.Peer{Principal: p.GetPrincipal(), IP: addr.GetAddress(), Port: addr.GetPortValue()}
+	if e.Principal != "" {
+		if id, err := principals.parse(e.Principal); err == nil {
+			e.Namespace, e.ServiceAccount = id.Namespace, id.Name
+		}
+	}
+	return e
+}
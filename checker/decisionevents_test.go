@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/decisionsink"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+type fakeDecisionPublisher struct {
+	events []decisionsink.Event
+}
+
+func (p *fakeDecisionPublisher) Publish(e decisionsink.Event) {
+	p.events = append(p.events, e)
+}
+
+func TestCheckPublishesDecisions(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pub := &fakeDecisionPublisher{}
+	start := time.Unix(1700000000, 0)
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore),
+		WithDecisionPublisher(pub), WithClock(&steppingClock{now: start, step: time.Millisecond}))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"writes"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "writes"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{Action: "deny", RuleId: "no-deletes", HttpMatch: &proto.HTTPMatch{Methods: []string{"DELETE"}}},
+			{Action: "allow", RuleId: "gets", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}},
+		},
+	}
+	uut.Store = store
+
+	req := keyedRequest(40000, "/carts/1?token=secret", nil)
+	_, err := uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(pub.events).To(HaveLen(1))
+	e := pub.events[0]
+	Expect(e.Time.After(start)).To(BeTrue())
+	Expect(e.LatencyMicros).To(BeNumerically(">", 0))
+	e.Time, e.LatencyMicros = time.Time{}, 0
+	Expect(e).To(Equal(decisionsink.Event{
+		Source: decisionsink.Peer{
+			Principal:      "spiffe://cluster.local/ns/default/sa/steve",
+			Namespace:      "default",
+			ServiceAccount: "steve",
+			IP:             "10.0.0.1",
+			Port:           40000,
+		},
+		Destination: decisionsink.Peer{IP: "10.0.0.2", Port: 8080},
+		Method:      "GET",
+		Path:        "/carts/1",
+		Verdict:     "OK",
+		Enforced:    true,
+		Policy:      "default/writes",
+		RuleID:      "gets",
+		RuleIndex:   1,
+	}))
+
+	// Decisions that aren't enforced are published with the verdict that would have been enforced.
+	req.Attributes.Request.Http.Method = "DELETE"
+	req.Attributes.ContextExtensions = map[string]string{ContextExtensionDryRun: "true"}
+	resp, err := uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(pub.events).To(HaveLen(2))
+	Expect(pub.events[1].Verdict).To(Equal("PERMISSION_DENIED"))
+	Expect(pub.events[1].Enforced).To(BeFalse())
+	Expect(pub.events[1].RuleID).To(Equal("no-deletes"))
+	Expect(pub.events[1].RuleIndex).To(Equal(0))
+}
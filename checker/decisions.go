@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// DecisionCache stores the decisions of the replicas of a node-level Dikastes, for them to share.
+type DecisionCache interface {
+	// Get returns the value stored under key, or found false if there is none.
+	Get(key string) (value []byte, found bool, err error)
+	// Set stores value under key for ttl.
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// sharedDecisions caches the verdicts that policy reaches in a DecisionCache. Decisions are keyed by the generation of
+// the policy store, so that a change to policy invalidates them, and by the parts of the request that rules without
+// annotations match, so that only decisions made by such rules are cached.
+type sharedDecisions struct {
+	cache DecisionCache
+	ttl   time.Duration
+}
+
+// cachedDecision is the outcome of checking the store, as cached.
+type cachedDecision struct {
+	Code   int32  `json:"code"`
+	Policy string `json:"policy,omitempty"`
+	RuleID string `json:"rule,omitempty"`
+	// Expires is when the decision expires, in Unix nanoseconds, in case the cache keeps it for longer than its TTL.
+	Expires int64 `json:"expires"`
+}
+
+// rule returns the rule that made the decision, as far as the checks after policy need it, or nil if there wasn't one.
+// Rules with annotations aren't cached, so the rule's ID is all that's needed.
+func (d *cachedDecision) rule() *proto.Rule {
+	if d.RuleID == "" {
+		return nil
+	}
+	return &proto.Rule{RuleId: d.RuleID}
+}
+
+// get returns the decision cached under key, or nil if there isn't one, it has expired, or the cache is unavailable.
+func (s *sharedDecisions) get(key string, now time.Time) *cachedDecision {
+	b, found, err := s.cache.Get(key)
+	if err != nil {
+		log.WithError(err).Debug("Unable to read shared decision cache.")
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	d := &cachedDecision{}
+	if err := json.Unmarshal(b, d); err != nil {
+		log.WithError(err).Warn("Invalid shared decision, ignoring.")
+		return nil
+	}
+	if now.UnixNano() >= d.Expires {
+		return nil
+	}
+	return d
+}
+
+// put caches a decision under key for the TTL.
+func (s *sharedDecisions) put(key string, code int32, policy string, rule *proto.Rule, now time.Time) {
+	d := cachedDecision{
+		Code:    code,
+		Policy:  policy,
+		RuleID:  rule.GetRuleId(),
+		Expires: now.Add(s.ttl).UnixNano(),
+	}
+	b, err := json.Marshal(d)
+	if err != nil {
+		log.WithError(err).Error("Unable to encode shared decision.")
+		return
+	}
+	if err := s.cache.Set(key, b, s.ttl); err != nil {
+		log.WithError(err).Debug("Unable to write shared decision cache.")
+	}
+}
+
+// decisionKey returns the key of decisions on req by policy of the given generation. It covers everything that rules
+// without annotations match, but not the source port, which differs for every connection. Rules that match anything
+// else make the decision uncacheable; see cacheableRule.
+func decisionKey(generation uint64, req *authz.CheckRequest) string {
+	h := sha256.New()
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], generation)
+	h.Write(b[:])
+	attr := req.GetAttributes()
+	for _, p := range []*authz.AttributeContext_Peer{attr.GetSource(), attr.GetDestination()} {
+		writeField(h, p.GetPrincipal())
+		writeMap(h, p.GetLabels())
+		addr := p.GetAddress().GetSocketAddress()
+		writeField(h, ipaddr.Canonical(addr.GetAddress()))
+		writeField(h, addr.GetProtocol().String())
+	}
+	binary.BigEndian.PutUint64(b[:], uint64(attr.GetDestination().GetAddress().GetSocketAddress().GetPortValue()))
+	h.Write(b[:])
+	httpReq := attr.GetRequest().GetHttp()
+	writeField(h, httpReq.GetMethod())
+	path := httpReq.GetPath()
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	writeField(h, path)
+	writeMap(h, attr.GetContextExtensions())
+	for _, name := range []string{IstioPeerMetadataHeader, IstioPeerMetadataIDHeader} {
+		writeField(h, httpReq.GetHeaders()[name])
+	}
+	return "dikastes-decision-" + hex.EncodeToString(h.Sum(nil))
+}
+
+// writeField hashes a length-prefixed string, so that adjacent fields can't run into each other.
+func writeField(h hash.Hash, s string) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(len(s)))
+	h.Write(b[:])
+	h.Write([]byte(s))
+}
+
+// writeMap hashes a map in key order.
+func writeMap(h hash.Hash, m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(len(keys)))
+	h.Write(b[:])
+	for _, k := range keys {
+		writeField(h, k)
+		writeField(h, m[k])
+	}
+}
+
+// cacheableRule returns false if a decision that depends on r can't be cached, because r matches something that
+// decisionKey doesn't cover: the source port, request headers, or anything matched with annotations.
+func cacheableRule(r *proto.Rule) bool {
+	if len(r.GetSrcPorts()) > 0 || len(r.GetNotSrcPorts()) > 0 ||
+		len(r.GetSrcNamedPortIpSetIds()) > 0 || len(r.GetNotSrcNamedPortIpSetIds()) > 0 ||
+		len(r.GetHttpMatch().GetHeaders()) > 0 {
+		return false
+	}
+	for k := range r.GetMetadata().GetAnnotations() {
+		if strings.HasPrefix(k, AnnotationPrefix) {
+			return false
+		}
+	}
+	return true
+}
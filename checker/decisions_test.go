@@ -0,0 +1,159 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+type fakeDecisionCache struct {
+	values map[string][]byte
+	err    error
+}
+
+func (c *fakeDecisionCache) Get(key string) ([]byte, bool, error) {
+	v, ok := c.values[key]
+	return v, ok, c.err
+}
+
+func (c *fakeDecisionCache) Set(key string, value []byte, _ time.Duration) error {
+	if c.err == nil {
+		c.values[key] = value
+	}
+	return c.err
+}
+
+func keyedRequest(srcPort uint32, path string, labels map[string]string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+			Labels:    labels,
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address: "10.0.0.1", PortSpecifier: &core.SocketAddress_PortValue{PortValue: srcPort},
+			}}},
+		},
+		Destination: tcpDestination(),
+		Request:     &authz.AttributeContext_Request{Http: &authz.AttributeContext_HttpRequest{Method: "GET", Path: path}},
+	}}
+}
+
+func TestDecisionKey(t *testing.T) {
+	RegisterTestingT(t)
+
+	key := decisionKey(1, keyedRequest(40000, "/a", map[string]string{"app": "web", "tier": "1"}))
+	Expect(decisionKey(1, keyedRequest(40001, "/a?b=c", map[string]string{"tier": "1", "app": "web"}))).To(Equal(key))
+	Expect(decisionKey(2, keyedRequest(40000, "/a", map[string]string{"app": "web", "tier": "1"}))).ToNot(Equal(key))
+	Expect(decisionKey(1, keyedRequest(40000, "/b", map[string]string{"app": "web", "tier": "1"}))).ToNot(Equal(key))
+	Expect(decisionKey(1, keyedRequest(40000, "/a", map[string]string{"app": "web1", "tier": ""}))).ToNot(Equal(key))
+
+	// The same client, however its address is written, shares decisions.
+	mapped := keyedRequest(40000, "/a", map[string]string{"app": "web", "tier": "1"})
+	mapped.Attributes.Source.Address.GetSocketAddress().Address = "::ffff:10.0.0.1"
+	Expect(decisionKey(1, mapped)).To(Equal(key))
+}
+
+func TestCacheableRule(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(cacheableRule(&proto.Rule{DstPorts: []*proto.PortRange{{First: 80, Last: 80}}})).To(BeTrue())
+	Expect(cacheableRule(&proto.Rule{Metadata: &proto.RuleMetadata{Annotations: map[string]string{"owner": "me"}}})).
+		To(BeTrue())
+	Expect(cacheableRule(&proto.Rule{SrcPorts: []*proto.PortRange{{First: 80, Last: 80}}})).To(BeFalse())
+	Expect(cacheableRule(&proto.Rule{HttpMatch: &proto.HTTPMatch{Paths: []*proto.HTTPMatch_PathMatch{
+		{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/carts"}},
+	}}})).To(BeTrue())
+	Expect(cacheableRule(&proto.Rule{HttpMatch: &proto.HTTPMatch{Headers: []*proto.HTTPMatch_HeaderMatch{
+		{Name: "x-internal-caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: true}},
+	}}})).To(BeFalse())
+	Expect(cacheableRule(&proto.Rule{Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+		TarpitAnnotation: "1s",
+	}}})).To(BeFalse())
+}
+
+func TestSharedDecisionsExpire(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Unix(1000, 0)
+	d := &sharedDecisions{cache: &fakeDecisionCache{values: map[string][]byte{}}, ttl: time.Second}
+	d.put("k", OK, "default/p", &proto.Rule{RuleId: "r1"}, now)
+	Expect(d.get("k", now.Add(999*time.Millisecond))).To(Equal(&cachedDecision{
+		Code: OK, Policy: "default/p", RuleID: "r1", Expires: now.Add(time.Second).UnixNano(),
+	}))
+	Expect(d.get("k", now.Add(time.Second))).To(BeNil())
+	Expect(d.get("other", now)).To(BeNil())
+}
+
+func TestCheckSharedDecisions(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cache := &fakeDecisionCache{values: map[string][]byte{}}
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore),
+		WithDecisionCache(cache, time.Minute), WithClock(FixedClock(time.Unix(1000, 0))))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"p"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "p"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "allow", RuleId: "r1"}},
+	}
+	store.SetGeneration("policy p", "allow")
+	uut.Store = store
+	check := func() *authz.CheckResponse {
+		resp, err := uut.Check(ctx, keyedRequest(40000, "/", nil))
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	Expect(check().GetStatus().GetCode()).To(Equal(OK))
+	Expect(cache.values).To(HaveLen(1))
+
+	// Changing policy without changing the generation shows that the decision comes from the cache, complete with the
+	// rule that made it.
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "p"}].InboundRules[0].Action = "deny"
+	resp := check()
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(resp.GetDynamicMetadata().GetFields()[RuleMetadataKey].GetStringValue()).To(Equal("r1"))
+
+	// A new generation invalidates it.
+	store.SetGeneration("policy p", "deny")
+	Expect(check().GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(cache.values).To(HaveLen(2))
+
+	// Decisions that depend on annotations aren't shared.
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "p"}].InboundRules[0].Metadata = &proto.RuleMetadata{
+		Annotations: map[string]string{TarpitAnnotation: "0s"},
+	}
+	store.SetGeneration("policy p", "deny with tarpit")
+	Expect(check().GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(cache.values).To(HaveLen(2))
+
+	// Checks evaluate policy themselves if the cache is unavailable, rather than use the decision cached under the
+	// first generation.
+	cache.err = errors.New("connection refused")
+	store.SetGeneration("policy p", "allow")
+	Expect(check().GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// DelegateAnnotation, set to "true" on a pass rule, hands the decision on the requests it matches to the upstream
+// policy decision point configured with WithUpstreamPDP, another ext_authz server. Evaluation ends at the rule and the
+// upstream's verdict and response are returned as Dikastes's own. Without an upstream, the requests are denied.
+const DelegateAnnotation = AnnotationPrefix + "delegate"
+
+// DefaultUpstreamTimeout is how long the upstream policy decision point has to decide a request by default.
+const DefaultUpstreamTimeout = 200 * time.Millisecond
+
+// upstreamPDP is an ext_authz server that rules delegate decisions to.
+type upstreamPDP struct {
+	client authz.AuthorizationClient
+	// timeout is how long the upstream has to decide a request.
+	timeout time.Duration
+	// fallback is the status code returned when the upstream fails or times out.
+	fallback int32
+}
+
+// delegates returns true if r is a pass rule that delegates the decision to the upstream.
+func delegates(r *proto.Rule) bool {
+	v, ok := r.GetMetadata().GetAnnotations()[DelegateAnnotation]
+	if !ok {
+		return false
+	}
+	d, err := strconv.ParseBool(v)
+	if err != nil {
+		log.WithFields(log.Fields{"rule": r.GetRuleId(), "delegate": v}).Warn("Invalid delegate annotation, ignoring.")
+		return false
+	}
+	return d && actionFromString(r.Action) == PASS
+}
+
+// check asks the upstream to decide req, returning its verdict and the response it has Envoy send or the request
+// carry, or the fallback verdict if it doesn't answer in time.
+func (u *upstreamPDP) check(ctx context.Context, req *authz.CheckRequest) (status.Status, *authz.CheckResponse) {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+	resp, err := u.client.Check(ctx, req)
+	if err != nil {
+		log.WithError(err).WithField("code", u.fallback).Warn(
+			"Upstream policy decision point failed, returning fallback verdict.")
+		return status.Status{Code: u.fallback}, nil
+	}
+	return status.Status{Code: resp.GetStatus().GetCode(), Message: resp.GetStatus().GetMessage()}, resp
+}
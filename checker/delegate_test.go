@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+type fakeUpstream struct {
+	resp  *authz.CheckResponse
+	err   error
+	block bool
+	reqs  []*authz.CheckRequest
+}
+
+func (f *fakeUpstream) Check(ctx context.Context, req *authz.CheckRequest, _ ...grpc.CallOption) (
+	*authz.CheckResponse, error) {
+	f.reqs = append(f.reqs, req)
+	if f.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return f.resp, f.err
+}
+
+func delegateRule(action, delegate string) *proto.Rule {
+	return &proto.Rule{
+		Action:    action,
+		RuleId:    "r1",
+		HttpMatch: &proto.HTTPMatch{Methods: []string{"POST"}},
+		Metadata:  &proto.RuleMetadata{Annotations: map[string]string{DelegateAnnotation: delegate}},
+	}
+}
+
+func delegateStore() *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers:      []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"writes"}}},
+		ProfileIds: []string{"default"},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "writes"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			delegateRule("pass", "true"),
+			{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}},
+		},
+	}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "allow"}},
+	}
+	return store
+}
+
+func delegateRequest(method string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Destination: tcpDestination(),
+		Request:     &authz.AttributeContext_Request{Http: &authz.AttributeContext_HttpRequest{Method: method, Path: "/"}},
+	}}
+}
+
+func TestDelegates(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(delegates(delegateRule("pass", "true"))).To(BeTrue())
+	Expect(delegates(delegateRule("next-tier", "true"))).To(BeTrue())
+	Expect(delegates(delegateRule("pass", "false"))).To(BeFalse())
+	Expect(delegates(delegateRule("pass", "sometimes"))).To(BeFalse())
+	Expect(delegates(delegateRule("allow", "true"))).To(BeFalse())
+	Expect(delegates(&proto.Rule{Action: "pass"})).To(BeFalse())
+}
+
+// Without an upstream, delegated requests are denied rather than passed on to the profiles.
+func TestCheckStoreDelegateDenies(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := delegateStore()
+	Expect(checkStore(store, delegateRequest("POST")).Code).To(Equal(PERMISSION_DENIED))
+	Expect(checkStore(store, delegateRequest("GET")).Code).To(Equal(OK))
+}
+
+func TestCheckUpstreamPDP(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	up := &fakeUpstream{}
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithUpstreamPDP(up, 50*time.Millisecond, OK))
+	uut.Store = delegateStore()
+	check := func(method string) *authz.CheckResponse {
+		resp, err := uut.Check(ctx, delegateRequest(method))
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	// Requests the rule doesn't match never reach the upstream.
+	Expect(check("GET").GetStatus().GetCode()).To(Equal(OK))
+	Expect(up.reqs).To(BeEmpty())
+
+	// The upstream's verdict and response are returned.
+	ok := &authz.OkHttpResponse{Headers: []*core.HeaderValueOption{
+		{Header: &core.HeaderValue{Key: "x-decided-by", Value: "upstream"}},
+	}}
+	up.resp = &authz.CheckResponse{
+		Status:       &status.Status{Code: OK},
+		HttpResponse: &authz.CheckResponse_OkResponse{OkResponse: ok},
+	}
+	resp := check("POST")
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(resp.GetOkResponse()).To(Equal(ok))
+	Expect(up.reqs).To(HaveLen(1))
+
+	denied := &authz.DeniedHttpResponse{Status: &_type.HttpStatus{Code: _type.StatusCode_Forbidden}, Body: "no"}
+	up.resp = &authz.CheckResponse{
+		Status:       &status.Status{Code: PERMISSION_DENIED},
+		HttpResponse: &authz.CheckResponse_DeniedResponse{DeniedResponse: denied},
+	}
+	resp = check("POST")
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(resp.GetDeniedResponse()).To(Equal(denied))
+
+	// If the upstream fails or times out, the fallback verdict applies.
+	up.resp, up.err = nil, errors.New("connection refused")
+	resp = check("POST")
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(resp.GetHttpResponse()).To(BeNil())
+
+	up.block = true
+	start := time.Now()
+	Expect(check("POST").GetStatus().GetCode()).To(Equal(OK))
+	Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"strings"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// DenialRecorder is told about the inbound requests that policy denies, for example to raise Kubernetes Events on
+// the pods they were denied to.
+type DenialRecorder interface {
+	// Denied records that policy, or the default deny if it is empty, denied source's request to the pod.
+	Denied(source, namespace, pod, policy string)
+}
+
+// endpointPod returns the namespace and name of the Kubernetes pod that ep belongs to, or empty strings if it isn't
+// one.
+func endpointPod(store *policystore.PolicyStore, ep *proto.WorkloadEndpoint) (namespace, pod string) {
+	if ep == nil {
+		return "", ""
+	}
+	for id, e := range store.EndpointByID {
+		if e != ep || id.OrchestratorId != "k8s" {
+			continue
+		}
+		// Felix identifies Kubernetes workloads as "<namespace>/<pod>".
+		if parts := strings.SplitN(id.WorkloadId, "/", 2); len(parts) == 2 {
+			return parts[0], parts[1]
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+type fakeDenialRecorder struct {
+	denials [][4]string
+}
+
+func (r *fakeDenialRecorder) Denied(source, namespace, pod, policy string) {
+	r.denials = append(r.denials, [4]string{source, namespace, pod, policy})
+}
+
+func TestEndpointPod(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	ep := &proto.WorkloadEndpoint{Name: "eth0"}
+	other := &proto.WorkloadEndpoint{Name: "eth0"}
+	store.EndpointByID[proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "shop/cart-1"}] = ep
+	store.EndpointByID[proto.WorkloadEndpointID{OrchestratorId: "openstack", WorkloadId: "vm/1"}] = other
+
+	ns, pod := endpointPod(store, ep)
+	Expect(ns).To(Equal("shop"))
+	Expect(pod).To(Equal("cart-1"))
+	ns, pod = endpointPod(store, other)
+	Expect(ns + pod).To(BeEmpty())
+	ns, pod = endpointPod(store, nil)
+	Expect(ns + pod).To(BeEmpty())
+}
+
+func TestCheckRecordsDenials(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := &fakeDenialRecorder{}
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithDenialRecorder(rec))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"writes"}}},
+	}
+	store.EndpointByID[proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "shop/cart-1"}] = store.Endpoint
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "writes"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{Action: "deny", HttpMatch: &proto.HTTPMatch{Methods: []string{"DELETE"}}},
+			{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}},
+		},
+	}
+	uut.Store = store
+	check := func(method string, ext map[string]string) {
+		req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			Source:      &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/default/sa/steve"},
+			Destination: tcpDestination(),
+			Request: &authz.AttributeContext_Request{
+				Http: &authz.AttributeContext_HttpRequest{Method: method, Path: "/"},
+			},
+			ContextExtensions: ext,
+		}}
+		_, err := uut.Check(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	check("GET", nil)
+	Expect(rec.denials).To(BeEmpty())
+	check("DELETE", nil)
+	check("POST", nil)
+	Expect(rec.denials).To(Equal([][4]string{
+		{"spiffe://cluster.local/ns/default/sa/steve", "shop", "cart-1", "default/writes"},
+		{"spiffe://cluster.local/ns/default/sa/steve", "shop", "cart-1", ""},
+	}))
+
+	// Denials that aren't enforced aren't recorded.
+	check("DELETE", map[string]string{ContextExtensionDryRun: "true"})
+	Expect(rec.denials).To(HaveLen(2))
+}
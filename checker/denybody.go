@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"time"
+
+	"github.com/projectcalico/app-policy/denybody"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// denyTemplateResponse renders the templated response for a request that policy denied, or returns nil if there is
+// no template for the namespace of the workload whose policy denied it.
+func denyTemplateResponse(
+	r *denybody.Renderer, principals principalTemplates, req *authz.CheckRequest, policy string, outbound bool,
+	now time.Time,
+) *authz.CheckResponse_DeniedResponse {
+	workload := req.GetAttributes().GetDestination()
+	if outbound {
+		workload = req.GetAttributes().GetSource()
+	}
+	// A malformed principal has already denied the request; it just doesn't get a templated body.
+	id, _ := principals.parse(workload.GetPrincipal())
+	http := req.GetAttributes().GetRequest().GetHttp()
+	requestID := http.GetId()
+	if requestID == "" {
+		requestID = http.GetHeaders()["x-request-id"]
+	}
+	resp, err := r.Render(id.Namespace, denybody.Vars{
+		Policy:    policy,
+		Namespace: id.Namespace,
+		RequestID: requestID,
+		Timestamp: now.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		log.WithError(err).WithField("namespace", id.Namespace).Warn("Unable to render deny body.")
+		return nil
+	}
+	if resp == nil {
+		return nil
+	}
+	return &authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{
+		Status: &_type.HttpStatus{Code: _type.StatusCode(resp.Status)},
+		Headers: []*core.HeaderValueOption{
+			{Header: &core.HeaderValue{Key: "content-type", Value: resp.ContentType}},
+		},
+		Body: resp.Body,
+	}}
+}
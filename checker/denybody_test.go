@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/denybody"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestCheckDenyTemplates(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	renderer, err := denybody.NewRenderer(&denybody.Config{
+		Namespaces: map[string]*denybody.Template{
+			"api": {Body: `{"policy":{{json .Policy}},"requestId":{{json .RequestID}},"time":{{json .Timestamp}}}`},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithDenyTemplates(renderer), WithClock(FixedClock(now)))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"no-deletes"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "no-deletes"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{Action: "Deny", HttpMatch: &proto.HTTPMatch{Methods: []string{"DELETE"}}},
+			{Action: "Allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET", "DELETE"}}},
+		},
+	}
+	uut.Store = store
+	check := func(method, dst string) *authz.CheckResponse {
+		resp, err := uut.Check(ctx, &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			Source:      &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/web/sa/frontend"},
+			Destination: &authz.AttributeContext_Peer{Principal: dst},
+			Request: &authz.AttributeContext_Request{
+				Http: &authz.AttributeContext_HttpRequest{Id: "a1b2", Method: method, Path: "/"},
+			},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+	const backend = "spiffe://cluster.local/ns/api/sa/backend"
+
+	Expect(check("GET", backend).GetStatus().GetCode()).To(Equal(OK))
+	resp := check("DELETE", backend)
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	denied := resp.GetDeniedResponse()
+	Expect(denied.GetStatus().GetCode()).To(Equal(_type.StatusCode_Forbidden))
+	Expect(denied.GetHeaders()[0].GetHeader().GetValue()).To(Equal("application/json"))
+	Expect(denied.GetBody()).To(Equal(`{"policy":"default/no-deletes","requestId":"a1b2","time":"2026-03-01T12:00:00Z"}`))
+
+	// Falling through to the tier's default deny leaves the policy empty.
+	resp = check("POST", backend)
+	Expect(resp.GetDeniedResponse().GetBody()).To(ContainSubstring(`"policy":""`))
+
+	// Namespaces without a template get Envoy's default response.
+	resp = check("DELETE", "spiffe://cluster.local/ns/db/sa/postgres")
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(resp.GetHttpResponse()).To(BeNil())
+}
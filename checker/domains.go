@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DstDomainsAnnotation restricts a rule to requests whose destination address is one that any of the given comma
+// separated domain names resolves to. Rules with the annotation fail safe if a domain can't be resolved. Wildcard
+// names never match, since they would need the names clients actually looked up, which Dikastes doesn't see; failing
+// safe on them would make a deny rule deny every destination.
+const DstDomainsAnnotation = AnnotationPrefix + "dst-domains"
+
+var errNoDomainResolver = errors.New("domain name resolution not configured")
+
+// DomainResolver maps domain names to addresses.
+type DomainResolver interface {
+	// LookupIP returns the addresses name resolves to.
+	LookupIP(name string) ([]net.IP, error)
+}
+
+// matchDstDomains checks the rule's destination domains, if any, against the request's destination address.
+func matchDstDomains(r *proto.Rule, req *requestCache) bool {
+	v, ok := r.GetMetadata().GetAnnotations()[DstDomainsAnnotation]
+	if !ok {
+		return true
+	}
+	if req.domains == nil {
+		return failSafe(r, DstDomainsAnnotation, errNoDomainResolver)
+	}
+	dst := socketIP(req.Request.GetAttributes().GetDestination())
+	if dst == nil {
+		return failSafe(r, DstDomainsAnnotation, errors.New("request has no destination address"))
+	}
+	// A domain that can't be resolved only fails the rule safe if none of the others match.
+	var lookupErr error
+	for _, name := range strings.Split(v, ",") {
+		name = strings.TrimSpace(name)
+		if IsWildcardDomain(name) {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), "domain": name}).Debug("Wildcard domain never matches")
+			continue
+		}
+		ips, err := req.domains.LookupIP(name)
+		if err != nil {
+			lookupErr = err
+			continue
+		}
+		for _, ip := range ips {
+			if ip.Equal(dst) {
+				log.WithFields(log.Fields{"rule": r.GetRuleId(), "domain": name, "destination": dst}).Debug(
+					"Destination matches domain")
+				return true
+			}
+		}
+	}
+	if lookupErr != nil {
+		return failSafe(r, DstDomainsAnnotation, lookupErr)
+	}
+	return false
+}
+
+// IsWildcardDomain returns true if name, from a DstDomainsAnnotation, is a wildcard, which never matches.
+func IsWildcardDomain(name string) bool {
+	return strings.Contains(name, "*")
+}
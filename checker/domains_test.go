@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/dnscache"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// fakeResolver maps domain names to addresses. A nil entry is still being resolved.
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupIP(name string) ([]net.IP, error) {
+	addrs, ok := f[name]
+	if !ok {
+		return nil, fmt.Errorf("no such host %q", name)
+	}
+	if addrs == nil {
+		return nil, dnscache.ErrPending
+	}
+	var ips []net.IP
+	for _, a := range addrs {
+		ips = append(ips, net.ParseIP(a))
+	}
+	return ips, nil
+}
+
+func TestCheckStoreDstDomains(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Ipv4Nets: []string{"10.0.0.5/32"},
+		Tiers:    []*proto.TierInfo{{Name: "default", EgressPolicies: []string{"egress"}}},
+	}
+	allow := &proto.Rule{
+		Action: "allow",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+			DstDomainsAnnotation: "api.example.com, storage.example.com",
+		}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "egress"}] = &proto.Policy{
+		OutboundRules: []*proto.Rule{allow},
+	}
+	resolver := fakeResolver{
+		"api.example.com":     {"192.0.2.1", "2001:db8::1"},
+		"storage.example.com": {"192.0.2.2"},
+	}
+	check := func(dst string) int32 {
+		return checkStore(store, flowRequest("10.0.0.5", dst), withDomainResolver(resolver)).Code
+	}
+
+	Expect(check("192.0.2.1")).To(Equal(OK))
+	Expect(check("192.0.2.2")).To(Equal(OK))
+	Expect(check("192.0.2.3")).To(Equal(PERMISSION_DENIED))
+
+	// A domain that can't be resolved yet only fails safe if no other domain matches.
+	resolver["storage.example.com"] = nil
+	Expect(check("192.0.2.1")).To(Equal(OK))
+	Expect(check("192.0.2.2")).To(Equal(PERMISSION_DENIED))
+
+	// Wildcards never match.
+	allow.Metadata.Annotations[DstDomainsAnnotation] = "*.example.com"
+	Expect(check("192.0.2.1")).To(Equal(PERMISSION_DENIED))
+	allow.Metadata.Annotations[DstDomainsAnnotation] = "*.example.com, api.example.com"
+	Expect(check("192.0.2.1")).To(Equal(OK))
+
+	// Deny rules fail safe by matching.
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "egress"}] = &proto.Policy{
+		OutboundRules: []*proto.Rule{
+			{
+				Action: "deny",
+				Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+					DstDomainsAnnotation: "storage.example.com",
+				}},
+			},
+			{Action: "allow"},
+		},
+	}
+	Expect(check("192.0.2.1")).To(Equal(PERMISSION_DENIED))
+	resolver["storage.example.com"] = []string{"192.0.2.2"}
+	Expect(check("192.0.2.1")).To(Equal(OK))
+	Expect(check("192.0.2.2")).To(Equal(PERMISSION_DENIED))
+	Expect(checkStore(store, flowRequest("10.0.0.5", "192.0.2.1")).Code).To(Equal(PERMISSION_DENIED))
+
+	// A wildcard deny rule doesn't deny every destination, but other domains it lists still apply.
+	deny := store.PolicyByID[proto.PolicyID{Tier: "default", Name: "egress"}].OutboundRules[0]
+	deny.Metadata.Annotations[DstDomainsAnnotation] = "*.example.com"
+	Expect(check("192.0.2.1")).To(Equal(OK))
+	Expect(check("192.0.2.2")).To(Equal(OK))
+	deny.Metadata.Annotations[DstDomainsAnnotation] = "*.example.com, storage.example.com"
+	Expect(check("192.0.2.1")).To(Equal(OK))
+	Expect(check("192.0.2.2")).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	familyRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dikastes_requests_by_ip_family_total",
+		Help: "Requests checked, by the IP family of their destination and whether they were allowed.",
+	}, []string{"family", "allowed"})
+	familyUnresolved = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dikastes_unresolved_endpoints_by_ip_family_total",
+		Help: "Requests that a node-level Dikastes couldn't place on an endpoint, by the IP family of their destination.",
+	}, []string{"family"})
+)
+
+func init() {
+	prometheus.MustRegister(familyRequests, familyUnresolved)
+}
+
+// ipFamily returns the family of ip, or ANY if there is no address.
+func ipFamily(ip net.IP) proto.IPVersion {
+	switch {
+	case ip == nil:
+		return proto.IPVersion_ANY
+	case ip.To4() != nil:
+		return proto.IPVersion_IPV4
+	default:
+		return proto.IPVersion_IPV6
+	}
+}
+
+// requestFamily returns the IP family of a request: that of its destination or, if that isn't an IP address, its
+// source. Envoy reports IPv4 clients of a dual-stack listener with IPv4-mapped addresses, which are IPv4.
+func requestFamily(req *authz.CheckRequest) proto.IPVersion {
+	if f := ipFamily(socketIP(req.GetAttributes().GetDestination())); f != proto.IPVersion_ANY {
+		return f
+	}
+	return ipFamily(socketIP(req.GetAttributes().GetSource()))
+}
+
+// familyLabel returns the metrics label for an IP family.
+func familyLabel(f proto.IPVersion) string {
+	switch f {
+	case proto.IPVersion_IPV4:
+		return "ipv4"
+	case proto.IPVersion_IPV6:
+		return "ipv6"
+	default:
+		return "unknown"
+	}
+}
+
+// familyNets returns the endpoint's networks in the family of ip, so that a dual-stack endpoint's addresses are only
+// compared with addresses they could be.
+func familyNets(ep *proto.WorkloadEndpoint, ip net.IP) []string {
+	if ipFamily(ip) == proto.IPVersion_IPV4 {
+		return ep.GetIpv4Nets()
+	}
+	return ep.GetIpv6Nets()
+}
+
+// matchIPVersion matches rules that are restricted to one IP family against requests in that family. Requests
+// between addresses that aren't IP, such as pipes, match only rules for either family.
+func matchIPVersion(r *proto.Rule, req *requestCache) bool {
+	v := r.GetIpVersion()
+	if v == proto.IPVersion_ANY {
+		return true
+	}
+	f := requestFamily(req.Request)
+	log.WithFields(log.Fields{"ipVersion": v, "family": f}).Debug("Matching IP version.")
+	return f == v
+}
+
+// recordFamily counts a checked request by its IP family and verdict, and, if it was to be checked against the policy
+// of an endpoint on the node but matched none, as unresolved.
+func recordFamily(req *authz.CheckRequest, code int32, unresolved bool) {
+	family := familyLabel(requestFamily(req))
+	familyRequests.WithLabelValues(family, strconv.FormatBool(code == OK)).Inc()
+	if unresolved {
+		familyUnresolved.WithLabelValues(family).Inc()
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestRequestFamily(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(requestFamily(sharedRequest("192.0.2.1", "10.0.0.1"))).To(Equal(proto.IPVersion_IPV4))
+	Expect(requestFamily(sharedRequest("::ffff:192.0.2.1", "::ffff:10.0.0.1"))).To(Equal(proto.IPVersion_IPV4))
+	Expect(requestFamily(sharedRequest("2001:db8::1", "fd00::1"))).To(Equal(proto.IPVersion_IPV6))
+	Expect(requestFamily(sharedRequest("2001:db8::1", ""))).To(Equal(proto.IPVersion_IPV6))
+	Expect(requestFamily(&authz.CheckRequest{})).To(Equal(proto.IPVersion_ANY))
+}
+
+func TestMatchIPVersion(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	match := func(v proto.IPVersion, src, dst string) bool {
+		req, err := NewRequestCache(store, sharedRequest(src, dst))
+		Expect(err).ToNot(HaveOccurred())
+		return matchIPVersion(&proto.Rule{IpVersion: v}, req)
+	}
+	Expect(match(proto.IPVersion_ANY, "192.0.2.1", "10.0.0.1")).To(BeTrue())
+	Expect(match(proto.IPVersion_ANY, "2001:db8::1", "fd00::1")).To(BeTrue())
+	Expect(match(proto.IPVersion_IPV4, "192.0.2.1", "10.0.0.1")).To(BeTrue())
+	Expect(match(proto.IPVersion_IPV4, "::ffff:192.0.2.1", "::ffff:10.0.0.1")).To(BeTrue())
+	Expect(match(proto.IPVersion_IPV4, "2001:db8::1", "fd00::1")).To(BeFalse())
+	Expect(match(proto.IPVersion_IPV6, "2001:db8::1", "fd00::1")).To(BeTrue())
+	Expect(match(proto.IPVersion_IPV6, "192.0.2.1", "10.0.0.1")).To(BeFalse())
+	Expect(match(proto.IPVersion_IPV6, "", "")).To(BeFalse())
+}
+
+// A node-level Dikastes finds dual-stack endpoints by either of their addresses, and checks requests against the
+// rules for their family.
+func TestCheckStoreDualStack(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.EndpointByID[proto.WorkloadEndpointID{WorkloadId: "web"}] = &proto.WorkloadEndpoint{
+		Name:     "web",
+		Ipv4Nets: []string{"10.0.0.1/32"},
+		Ipv6Nets: []string{"fd00::1/128"},
+		Tiers:    []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"v6-only"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "v6-only"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "allow", IpVersion: proto.IPVersion_IPV6}},
+	}
+	check := func(src, dst string) int32 {
+		return checkStore(store, sharedRequest(src, dst), withSharedEndpoints()).Code
+	}
+
+	Expect(check("2001:db8::7", "fd00::1")).To(Equal(OK))
+	Expect(check("2001:db8::7", "fd00:0::1")).To(Equal(OK))
+	Expect(check("192.0.2.7", "10.0.0.1")).To(Equal(PERMISSION_DENIED))
+	Expect(check("::ffff:192.0.2.7", "::ffff:10.0.0.1")).To(Equal(PERMISSION_DENIED))
+}
+
+func TestCheckCountsFamilies(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithSharedEndpoints(true))
+	store := policystore.NewPolicyStore()
+	store.EndpointByID[proto.WorkloadEndpointID{WorkloadId: "web"}] = &proto.WorkloadEndpoint{
+		Name:       "web",
+		Ipv4Nets:   []string{"10.0.0.1/32"},
+		Ipv6Nets:   []string{"fd00::1/128"},
+		ProfileIds: []string{"default"},
+	}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "allow"}},
+	}
+	uut.Store = store
+	count := func(family, allowed string) float64 {
+		return testutil.ToFloat64(familyRequests.WithLabelValues(family, allowed))
+	}
+	v4, v6, v6Denied := count("ipv4", "true"), count("ipv6", "true"), count("ipv6", "false")
+	v6Unresolved := testutil.ToFloat64(familyUnresolved.WithLabelValues("ipv6"))
+
+	for _, r := range []struct{ src, dst string }{
+		{"192.0.2.7", "10.0.0.1"},
+		{"2001:db8::7", "fd00::1"},
+		{"2001:db8::7", "fd00::1"},
+		{"2001:db8::7", "fd00::2"},
+	} {
+		_, err := uut.Check(ctx, sharedRequest(r.src, r.dst))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(count("ipv4", "true")).To(Equal(v4 + 1))
+	Expect(count("ipv6", "true")).To(Equal(v6 + 2))
+	Expect(count("ipv6", "false")).To(Equal(v6Denied + 1))
+	Expect(testutil.ToFloat64(familyUnresolved.WithLabelValues("ipv6"))).To(Equal(v6Unresolved + 1))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"net"
+
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+)
+
+// isOutbound returns true if the request is leaving the endpoint rather than arriving at it, that is, if it comes from
+// one of the endpoint's addresses and is going to some other address. Outbound requests are checked against egress
+// policy.
+func isOutbound(ep *proto.WorkloadEndpoint, req *authz.CheckRequest) bool {
+	src := socketIP(req.GetAttributes().GetSource())
+	dst := socketIP(req.GetAttributes().GetDestination())
+	if src == nil || dst == nil {
+		return false
+	}
+	return endpointHasIP(ep, src) && !endpointHasIP(ep, dst)
+}
+
+// socketIP returns the IP address of the peer's socket, or nil if it has none.
+func socketIP(p *authz.AttributeContext_Peer) net.IP {
+	return ipaddr.Parse(p.GetAddress().GetSocketAddress().GetAddress())
+}
+
+// endpointHasIP returns true if ip is one of the endpoint's addresses in its family.
+func endpointHasIP(ep *proto.WorkloadEndpoint, ip net.IP) bool {
+	for _, n := range familyNets(ep, ip) {
+		cidr, err := ipaddr.ParseCIDR(n)
+		if err != nil {
+			continue
+		}
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// flowRequest returns a CheckRequest for a request between the given addresses.
+func flowRequest(src, dst string) *authz.CheckRequest {
+	peer := func(addr string) *authz.AttributeContext_Peer {
+		return &authz.AttributeContext_Peer{
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address:       addr,
+				PortSpecifier: &core.SocketAddress_PortValue{PortValue: 443},
+			}}},
+		}
+	}
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source:      peer(src),
+		Destination: peer(dst),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: "GET", Path: "/"},
+		},
+	}}
+}
+
+func TestIsOutbound(t *testing.T) {
+	RegisterTestingT(t)
+
+	ep := &proto.WorkloadEndpoint{Ipv4Nets: []string{"10.0.0.5/32"}, Ipv6Nets: []string{"fd00::5/128"}}
+	Expect(isOutbound(ep, flowRequest("10.0.0.5", "192.0.2.1"))).To(BeTrue())
+	Expect(isOutbound(ep, flowRequest("fd00::5", "2001:db8::1"))).To(BeTrue())
+	Expect(isOutbound(ep, flowRequest("::ffff:10.0.0.5", "::ffff:192.0.2.1"))).To(BeTrue())
+	Expect(isOutbound(ep, flowRequest("fd00:0:0::5", "fd00::5"))).To(BeFalse())
+	Expect(isOutbound(ep, flowRequest("192.0.2.1", "10.0.0.5"))).To(BeFalse())
+	// Requests from the endpoint to itself are inbound.
+	Expect(isOutbound(ep, flowRequest("10.0.0.5", "10.0.0.5"))).To(BeFalse())
+	// So are requests we can't place, and all requests if we don't know the endpoint's addresses.
+	Expect(isOutbound(ep, flowRequest("10.0.0.5", ""))).To(BeFalse())
+	Expect(isOutbound(&proto.WorkloadEndpoint{}, flowRequest("10.0.0.5", "192.0.2.1"))).To(BeFalse())
+}
+
+func TestCheckStoreEgress(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Ipv4Nets: []string{"10.0.0.5/32"},
+		Tiers: []*proto.TierInfo{{
+			Name:            "default",
+			IngressPolicies: []string{"ingress"},
+			EgressPolicies:  []string{"egress"},
+		}},
+		ProfileIds: []string{"default"},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "ingress"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "allow", SrcNet: []string{"192.0.2.0/24"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "egress"}] = &proto.Policy{
+		OutboundRules: []*proto.Rule{
+			{Action: "allow", DstNet: []string{"198.51.100.0/24"}},
+			{Action: "pass", DstNet: []string{"203.0.113.0/24"}},
+		},
+	}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules:  []*proto.Rule{{Action: "allow"}},
+		OutboundRules: []*proto.Rule{{Action: "allow", DstNet: []string{"203.0.113.1/32"}}},
+	}
+	check := func(src, dst string) int32 {
+		return checkStore(store, flowRequest(src, dst)).Code
+	}
+
+	Expect(check("192.0.2.1", "10.0.0.5")).To(Equal(OK))
+	Expect(check("198.51.100.1", "10.0.0.5")).To(Equal(PERMISSION_DENIED))
+	Expect(check("10.0.0.5", "198.51.100.1")).To(Equal(OK))
+	Expect(check("10.0.0.5", "192.0.2.1")).To(Equal(PERMISSION_DENIED))
+	// Outbound requests passed by policy are checked against the profiles' outbound rules.
+	Expect(check("10.0.0.5", "203.0.113.1")).To(Equal(OK))
+	Expect(check("10.0.0.5", "203.0.113.2")).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"hash/fnv"
+
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/libcalico-go/lib/selector"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// DryRunVerdictKey is the dynamic metadata key under which the verdict that would have been enforced is exported
+// when running in dry-run mode. Envoy access logs can reference it as
+// %DYNAMIC_METADATA(envoy.filters.http.ext_authz:dry_run_verdict)%.
+const DryRunVerdictKey = "dry_run_verdict"
+
+// applyDryRun replaces the verdict in resp with OK, recording the original verdict in the logs and alongside any other
+// dynamic metadata in the response.
+func applyDryRun(req *authz.CheckRequest, resp *authz.CheckResponse) {
+	verdict := code.Code(resp.GetStatus().GetCode()).String()
+	if resp.GetStatus().GetCode() != OK {
+		log.WithFields(log.Fields{
+			"Req.Method":      req.GetAttributes().GetRequest().GetHttp().GetMethod(),
+			"Req.Path":        req.GetAttributes().GetRequest().GetHttp().GetPath(),
+			"Req.Source":      req.GetAttributes().GetSource().GetPrincipal(),
+			"Req.Destination": req.GetAttributes().GetDestination().GetPrincipal(),
+			"verdict":         verdict,
+		}).Info("Dry run: allowing request that policy would not allow")
+	}
+	resp.Status = &status.Status{Code: OK}
+	resp.HttpResponse = nil
+	if resp.DynamicMetadata == nil {
+		resp.DynamicMetadata = &structpb.Struct{}
+	}
+	if resp.DynamicMetadata.Fields == nil {
+		resp.DynamicMetadata.Fields = map[string]*structpb.Value{}
+	}
+	resp.DynamicMetadata.Fields[DryRunVerdictKey] = stringValue(verdict)
+}
+
+// namespaceEnforced returns true if the labels of the namespace of ep, the workload the request is checked for, match
+// sel. Requests are enforced if the namespace can't be determined, such as for an unresolved or non-Kubernetes
+// endpoint, or one whose namespace isn't synced, so that they can't bypass enforcement.
+func namespaceEnforced(store *policystore.PolicyStore, ep *proto.WorkloadEndpoint, sel selector.Selector) bool {
+	namespace, _ := endpointPod(store, ep)
+	if namespace == "" {
+		log.Debug("Unknown endpoint namespace, enforcing.")
+		return true
+	}
+	ns, ok := store.NamespaceByID[proto.NamespaceID{Name: namespace}]
+	if !ok {
+		log.WithField("namespace", namespace).Debug("Endpoint namespace not synced, enforcing.")
+		return true
+	}
+	return sel.Evaluate(ns.GetLabels())
+}
+
+// clientEnforced returns true if the request's client falls within the enforced percentage. Clients are identified by
+// their principal or, for plain text requests, their IP address, so that all requests from a client get consistent
+// treatment.
+func clientEnforced(req *authz.CheckRequest, percent uint32) bool {
+	client := peerIdentity(req.GetAttributes().GetSource())
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(client))
+	enforced := h.Sum32()%100 < percent
+	log.WithFields(log.Fields{
+		"client":   client,
+		"percent":  percent,
+		"enforced": enforced,
+	}).Debug("Checked client against enforcement percentage")
+	return enforced
+}
+
+// peerIdentity identifies a peer by its principal or, for plain text requests, its IP address in canonical form.
+func peerIdentity(p *authz.AttributeContext_Peer) string {
+	if id := p.GetPrincipal(); id != "" {
+		return id
+	}
+	return ipaddr.Canonical(p.GetAddress().GetSocketAddress().GetAddress())
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/status"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/libcalico-go/lib/selector"
+)
+
+func TestApplyDryRunDenied(t *testing.T) {
+	RegisterTestingT(t)
+
+	resp := &authz.CheckResponse{Status: &status.Status{Code: PERMISSION_DENIED}}
+	applyDryRun(&authz.CheckRequest{}, resp)
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(resp.GetDynamicMetadata().GetFields()[DryRunVerdictKey].GetStringValue()).To(Equal("PERMISSION_DENIED"))
+}
+
+func TestApplyDryRunAllowed(t *testing.T) {
+	RegisterTestingT(t)
+
+	resp := &authz.CheckResponse{Status: &status.Status{Code: OK}}
+	applyDryRun(&authz.CheckRequest{}, resp)
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(resp.GetDynamicMetadata().GetFields()[DryRunVerdictKey].GetStringValue()).To(Equal("OK"))
+}
+
+// In dry-run mode the server allows requests that policy denies, and requests made before it is in sync.
+func TestCheckDryRun(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stores := make(chan *policystore.PolicyStore)
+	uut := NewServer(ctx, stores, WithDryRun(true))
+
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/sammy",
+		},
+	}}
+	resp, err := uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(resp.GetDynamicMetadata().GetFields()[DryRunVerdictKey].GetStringValue()).To(Equal("UNAVAILABLE"))
+
+	store := policystore.NewPolicyStore()
+	store.Write(func(s *policystore.PolicyStore) {
+		s.Endpoint = &proto.WorkloadEndpoint{
+			ProfileIds: []string{"default"},
+		}
+		s.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+			InboundRules: []*proto.Rule{{Action: "Deny"}},
+		}
+	})
+	stores <- store
+
+	Eventually(func() string {
+		rsp, err := uut.Check(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rsp.GetStatus().GetCode()).To(Equal(OK))
+		return rsp.GetDynamicMetadata().GetFields()[DryRunVerdictKey].GetStringValue()
+	}).Should(Equal("PERMISSION_DENIED"))
+}
+
+func TestNamespaceEnforced(t *testing.T) {
+	sel, err := selector.Parse("alp-enforce == 'true'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := policystore.NewPolicyStore()
+	store.NamespaceByID[proto.NamespaceID{Name: "prod"}] = &proto.NamespaceUpdate{
+		Id:     &proto.NamespaceID{Name: "prod"},
+		Labels: map[string]string{"alp-enforce": "true"},
+	}
+	store.NamespaceByID[proto.NamespaceID{Name: "dev"}] = &proto.NamespaceUpdate{
+		Id:     &proto.NamespaceID{Name: "dev"},
+		Labels: map[string]string{"alp-enforce": "false"},
+	}
+	endpoint := func(orchestrator, workload string) *proto.WorkloadEndpoint {
+		ep := &proto.WorkloadEndpoint{Name: workload}
+		store.EndpointByID[proto.WorkloadEndpointID{OrchestratorId: orchestrator, WorkloadId: workload}] = ep
+		return ep
+	}
+
+	testCases := []struct {
+		title    string
+		endpoint *proto.WorkloadEndpoint
+		result   bool
+	}{
+		{"opted in", endpoint("k8s", "prod/sammy"), true},
+		{"not opted in", endpoint("k8s", "dev/sammy"), false},
+		{"namespace not synced", endpoint("k8s", "other/sammy"), true},
+		{"not kubernetes", endpoint("openstack", "dev/sammy"), true},
+		{"unresolved", nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			Expect(namespaceEnforced(store, tc.endpoint, sel)).To(Equal(tc.result))
+		})
+	}
+}
+
+// With enforced namespaces configured, denials are only enforced for workloads in opted-in namespaces, whether or
+// not requests to them have principals.
+func TestCheckEnforcedNamespaces(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sel, err := selector.Parse("alp-enforce == 'true'")
+	Expect(err).ToNot(HaveOccurred())
+	stores := make(chan *policystore.PolicyStore)
+	uut := NewServer(ctx, stores, WithEnforcedNamespaces(sel), WithSharedEndpoints(true))
+
+	store := policystore.NewPolicyStore()
+	store.Write(func(s *policystore.PolicyStore) {
+		for workload, ip := range map[string]string{"prod/sammy": "10.0.0.2", "dev/sammy": "10.0.0.3"} {
+			s.EndpointByID[proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: workload}] =
+				&proto.WorkloadEndpoint{ProfileIds: []string{"default"}, Ipv4Nets: []string{ip + "/32"}}
+		}
+		s.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+			InboundRules: []*proto.Rule{{Action: "Deny"}},
+		}
+		s.NamespaceByID[proto.NamespaceID{Name: "prod"}] = &proto.NamespaceUpdate{
+			Id:     &proto.NamespaceID{Name: "prod"},
+			Labels: map[string]string{"alp-enforce": "true"},
+		}
+		s.NamespaceByID[proto.NamespaceID{Name: "dev"}] = &proto.NamespaceUpdate{
+			Id:     &proto.NamespaceID{Name: "dev"},
+			Labels: map[string]string{"alp-enforce": "false"},
+		}
+	})
+	stores <- store
+
+	check := func(src, dst, ip string) func() int32 {
+		destination := tcpDestination()
+		destination.Principal = dst
+		destination.GetAddress().GetSocketAddress().Address = ip
+		req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			Source:      &authz.AttributeContext_Peer{Principal: src},
+			Destination: destination,
+		}}
+		return func() int32 {
+			rsp, err := uut.Check(ctx, req)
+			Expect(err).ToNot(HaveOccurred())
+			return rsp.GetStatus().GetCode()
+		}
+	}
+	steve := "spiffe://cluster.local/ns/default/sa/steve"
+	Eventually(check(steve, "spiffe://cluster.local/ns/prod/sa/sammy", "10.0.0.2")).Should(Equal(PERMISSION_DENIED))
+	Expect(check(steve, "spiffe://cluster.local/ns/dev/sa/sammy", "10.0.0.3")()).To(Equal(OK))
+
+	// Plain text requests have no principals, and are enforced by the namespace of the endpoint they are sent to.
+	Expect(check("", "", "10.0.0.2")()).To(Equal(PERMISSION_DENIED))
+	Expect(check("", "", "10.0.0.3")()).To(Equal(OK))
+
+	// A principal naming another namespace doesn't change the endpoint's.
+	Expect(check(steve, "spiffe://cluster.local/ns/dev/sa/sammy", "10.0.0.2")()).To(Equal(PERMISSION_DENIED))
+
+	// Requests that aren't for a known endpoint are enforced.
+	Expect(check(steve, "", "10.0.0.9")()).To(Equal(PERMISSION_DENIED))
+}
+
+func TestClientEnforced(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := func(principal, ip string) *authz.CheckRequest {
+		return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			Source: &authz.AttributeContext_Peer{
+				Principal: principal,
+				Address: &core.Address{Address: &core.Address_SocketAddress{
+					SocketAddress: &core.SocketAddress{Address: ip},
+				}},
+			},
+		}}
+	}
+
+	// The extremes enforce none or all clients.
+	Expect(clientEnforced(req("spiffe://cluster.local/ns/default/sa/steve", ""), 0)).To(BeFalse())
+	Expect(clientEnforced(req("spiffe://cluster.local/ns/default/sa/steve", ""), 100)).To(BeTrue())
+
+	// Decisions are consistent per client, and roughly proportional across clients.
+	enforced := 0
+	for i := 0; i < 1000; i++ {
+		r := req(fmt.Sprintf("spiffe://cluster.local/ns/default/sa/sa-%d", i), "")
+		e := clientEnforced(r, 25)
+		Expect(clientEnforced(r, 25)).To(Equal(e))
+		if e {
+			enforced++
+		}
+	}
+	Expect(enforced).To(BeNumerically("~", 250, 75))
+
+	// Plain text clients are identified by IP address, ignoring the port.
+	a := req("", "10.0.0.1")
+	b := req("", "10.0.0.1")
+	b.Attributes.Source.Address.GetSocketAddress().PortSpecifier = &core.SocketAddress_PortValue{PortValue: 5555}
+	for p := uint32(0); p <= 100; p += 10 {
+		Expect(clientEnforced(a, p)).To(Equal(clientEnforced(b, p)))
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"container/list"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectcalico/app-policy/dlp"
+	"github.com/projectcalico/app-policy/proto"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	extproc "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3alpha"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// BlockResponsePatternsAnnotation blocks responses to requests that the rule allows if they contain any of the given
+// comma separated sensitive patterns, as named in the configured dlp.Config. Responses are inspected by Envoy's
+// ext_proc filter, which must send Dikastes the request headers, so that the response can be tied to the check of its
+// request by request ID, and the response headers and body. If patterns aren't configured, or a pattern is unknown,
+// the response is blocked.
+const BlockResponsePatternsAnnotation = AnnotationPrefix + "block-response-patterns"
+
+const (
+	// pendingResponseTTL is how long to wait for the response to a request whose response is to be inspected.
+	pendingResponseTTL = 5 * time.Minute
+	// maxPendingResponses bounds the number of responses awaiting inspection.
+	maxPendingResponses = 100000
+	// responseOverlap is how much of each response body chunk is scanned again with the next, to catch patterns that
+	// straddle chunks.
+	responseOverlap = 1024
+)
+
+var errNoResponsePatterns = errors.New("response patterns not configured")
+
+// responseInspection is what to look for in the response to an allowed request.
+type responseInspection struct {
+	id       string
+	rule     string
+	source   string
+	patterns []string
+	// enforce is false if the request was handled in dry-run mode, so the response is only logged.
+	enforce bool
+	expires time.Time
+}
+
+// pendingResponses holds the inspections for responses that haven't been seen yet, by request ID. It is safe for
+// concurrent use.
+type pendingResponses struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// order holds the inspections in order of expiry, which is the order they were added in.
+	order *list.List
+}
+
+func newPendingResponses() *pendingResponses {
+	return &pendingResponses{entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// add awaits the response to the request described by i.
+func (p *pendingResponses) add(i *responseInspection, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.expire(now)
+	if e, ok := p.entries[i.id]; ok {
+		p.order.Remove(e)
+	}
+	if p.order.Len() >= maxPendingResponses {
+		log.Warn("Too many responses awaiting inspection, dropping the oldest.")
+		delete(p.entries, p.order.Remove(p.order.Front()).(*responseInspection).id)
+	}
+	i.expires = now.Add(pendingResponseTTL)
+	p.entries[i.id] = p.order.PushBack(i)
+}
+
+// take returns and forgets the inspection for the response to the request with the given ID, or nil if there is none.
+func (p *pendingResponses) take(id string, now time.Time) *responseInspection {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.expire(now)
+	e, ok := p.entries[id]
+	if !ok {
+		return nil
+	}
+	delete(p.entries, id)
+	return p.order.Remove(e).(*responseInspection)
+}
+
+// expire drops the inspections whose responses were never seen. p.mu must be held.
+func (p *pendingResponses) expire(now time.Time) {
+	for e := p.order.Front(); e != nil && now.After(e.Value.(*responseInspection).expires); e = p.order.Front() {
+		delete(p.entries, p.order.Remove(e).(*responseInspection).id)
+	}
+}
+
+// awaitResponse arranges for the response to req to be inspected, if the rule that decided it has response patterns.
+func awaitResponse(p *pendingResponses, rule *proto.Rule, req *authz.CheckRequest, enforce bool, now time.Time) {
+	v, ok := rule.GetMetadata().GetAnnotations()[BlockResponsePatternsAnnotation]
+	if !ok {
+		return
+	}
+	id := req.GetAttributes().GetRequest().GetHttp().GetId()
+	if id == "" {
+		log.WithField("rule", rule.GetRuleId()).Warn("Request has no ID, so its response can't be inspected.")
+		return
+	}
+	var patterns []string
+	for _, n := range strings.Split(v, ",") {
+		patterns = append(patterns, strings.TrimSpace(n))
+	}
+	p.add(&responseInspection{
+		id:       id,
+		rule:     rule.GetRuleId(),
+		source:   peerIdentity(req.GetAttributes().GetSource()),
+		patterns: patterns,
+		enforce:  enforce,
+	}, now)
+}
+
+// extProcServer implements Envoy's ExternalProcessor, blocking responses that contain sensitive patterns.
+type extProcServer struct {
+	pending *pendingResponses
+	scanner *dlp.Scanner
+	clock   Clock
+}
+
+// ExtProcServer returns an ExternalProcessor that Envoy's ext_proc filter can send responses to for inspection.
+func (as *authServer) ExtProcServer() *extProcServer {
+	return &extProcServer{pending: as.responses, scanner: as.responsePatterns, clock: as.clock}
+}
+
+// Process handles the processing requests for one HTTP request. The request phase passes through unchanged; if the
+// request was allowed by a rule with response patterns, the response headers and body are scanned for them.
+func (s *extProcServer) Process(stream extproc.ExternalProcessor_ProcessServer) error {
+	var inspect *responseInspection
+	var tail []byte
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.WithError(err).Debug("External processing stream ended.")
+			return err
+		}
+		resp := &extproc.ProcessingResponse{}
+		switch r := req.Request.(type) {
+		case *extproc.ProcessingRequest_RequestHeaders:
+			id := headerValue(r.RequestHeaders.GetHeaders(), "x-request-id")
+			inspect = s.pending.take(id, s.clock.Now())
+			resp.Response = &extproc.ProcessingResponse_RequestHeaders{RequestHeaders: &extproc.HeadersResponse{}}
+		case *extproc.ProcessingRequest_RequestBody:
+			resp.Response = &extproc.ProcessingResponse_RequestBody{RequestBody: &extproc.BodyResponse{}}
+		case *extproc.ProcessingRequest_RequestTrailers:
+			resp.Response = &extproc.ProcessingResponse_RequestTrailers{RequestTrailers: &extproc.TrailersResponse{}}
+		case *extproc.ProcessingRequest_ResponseHeaders:
+			var b strings.Builder
+			for _, h := range r.ResponseHeaders.GetHeaders().GetHeaders() {
+				b.WriteString(h.GetKey() + ": " + h.GetValue() + "\n")
+			}
+			if s.blocked(inspect, []byte(b.String())) {
+				resp.Response = responseBlocked()
+			} else {
+				resp.Response = &extproc.ProcessingResponse_ResponseHeaders{ResponseHeaders: &extproc.HeadersResponse{}}
+			}
+		case *extproc.ProcessingRequest_ResponseBody:
+			body := r.ResponseBody.GetBody()
+			if s.blocked(inspect, append(tail, body...)) {
+				resp.Response = responseBlocked()
+			} else {
+				resp.Response = &extproc.ProcessingResponse_ResponseBody{ResponseBody: &extproc.BodyResponse{}}
+			}
+			if len(body) > responseOverlap {
+				body = body[len(body)-responseOverlap:]
+			}
+			tail = append([]byte(nil), body...)
+		case *extproc.ProcessingRequest_ResponseTrailers:
+			resp.Response = &extproc.ProcessingResponse_ResponseTrailers{ResponseTrailers: &extproc.TrailersResponse{}}
+		default:
+			log.WithField("request", req).Warn("Unknown external processing request.")
+			continue
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// blocked returns true if data contains any of the patterns in inspect, or can't be scanned for them, and the
+// response is enforced.
+func (s *extProcServer) blocked(inspect *responseInspection, data []byte) bool {
+	if inspect == nil {
+		return false
+	}
+	fields := log.Fields{"rule": inspect.rule, "source": inspect.source, "request": inspect.id}
+	if s.scanner == nil {
+		fields["error"] = errNoResponsePatterns
+	} else if match, err := s.scanner.Scan(inspect.patterns, data); err != nil {
+		fields["error"] = err
+	} else if match != "" {
+		fields["pattern"] = match
+	} else {
+		return false
+	}
+	if !inspect.enforce {
+		log.WithFields(fields).Info("Dry run: allowing response that policy would block")
+		return false
+	}
+	log.WithFields(fields).Info("Blocking response.")
+	return true
+}
+
+// responseBlocked is the response that replaces one with sensitive content.
+func responseBlocked() *extproc.ProcessingResponse_ImmediateResponse {
+	return &extproc.ProcessingResponse_ImmediateResponse{ImmediateResponse: &extproc.ImmediateResponse{
+		Status:  &_type.HttpStatus{Code: _type.StatusCode_Forbidden},
+		Details: "response_blocked_by_policy",
+	}}
+}
+
+// headerValue returns the value of the named header, or "" if it isn't present.
+func headerValue(headers *core.HeaderMap, name string) string {
+	for _, h := range headers.GetHeaders() {
+		if strings.EqualFold(h.GetKey(), name) {
+			return h.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/projectcalico/app-policy/ipaddr"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// Headers that describe the original request to the forward auth endpoint. nginx's auth_request doesn't set any, so
+// its config must, for example:
+//
+//	proxy_set_header X-Original-Method $request_method;
+//	proxy_set_header X-Original-URI $request_uri;
+//	proxy_set_header X-Real-IP $remote_addr;
+//
+// Traefik's ForwardAuth middleware sets the X-Forwarded-* headers itself.
+const (
+	OriginalMethodHeader  = "x-original-method"
+	OriginalURIHeader     = "x-original-uri"
+	RealIPHeader          = "x-real-ip"
+	ForwardedMethodHeader = "x-forwarded-method"
+	ForwardedURIHeader    = "x-forwarded-uri"
+	ForwardedHostHeader   = "x-forwarded-host"
+	ForwardedProtoHeader  = "x-forwarded-proto"
+)
+
+// ForwardAuth returns a handler for the authorization subrequests of nginx's auth_request and Traefik's ForwardAuth,
+// for proxies other than Envoy. It checks the original request that the subrequest's headers describe, and answers
+// 200 if policy allows it, 401 if it is unauthenticated, or 403 otherwise, with the headers and body of Dikastes'
+// denied response, if any.
+func (as *authServer) ForwardAuth() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := as.Check(r.Context(), forwardAuthRequest(r))
+		if err != nil {
+			log.WithError(err).Error("Forward auth check failed.")
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		switch resp.GetStatus().GetCode() {
+		case OK:
+			w.WriteHeader(http.StatusOK)
+			return
+		case UNAUTHENTICATED:
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			for _, h := range resp.GetDeniedResponse().GetHeaders() {
+				w.Header().Add(h.GetHeader().GetKey(), h.GetHeader().GetValue())
+			}
+			w.WriteHeader(http.StatusForbidden)
+		}
+		_, _ = w.Write([]byte(resp.GetDeniedResponse().GetBody()))
+	})
+}
+
+// forwardAuthRequest converts an authorization subrequest into a CheckRequest for the original request it describes.
+func forwardAuthRequest(r *http.Request) *authz.CheckRequest {
+	headers := make(map[string]string, len(r.Header))
+	for k, v := range r.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	first := func(keys ...string) string {
+		for _, k := range keys {
+			if v := headers[k]; v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	method := first(OriginalMethodHeader, ForwardedMethodHeader)
+	if method == "" {
+		method = r.Method
+	}
+	path := first(OriginalURIHeader, ForwardedURIHeader)
+	if path == "" {
+		path = r.URL.RequestURI()
+	}
+	host := first(ForwardedHostHeader)
+	if host == "" {
+		host = r.Host
+	}
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{Address: socketAddress(forwardAuthClient(r, headers))},
+		// The proxies don't say which address the original request was sent to, only that it was HTTP, over TCP.
+		Destination: &authz.AttributeContext_Peer{Address: &core.Address{Address: &core.Address_SocketAddress{
+			SocketAddress: &core.SocketAddress{Protocol: core.SocketAddress_TCP},
+		}}},
+		Request: &authz.AttributeContext_Request{Http: &authz.AttributeContext_HttpRequest{
+			Method:   method,
+			Path:     path,
+			Host:     host,
+			Scheme:   first(ForwardedProtoHeader),
+			Protocol: r.Proto,
+			Headers:  headers,
+		}},
+	}}
+}
+
+// forwardAuthClient returns the original request's client address, in canonical form: X-Real-IP if the proxy sets it,
+// or else the last address in X-Forwarded-For, which the proxy appended.
+func forwardAuthClient(r *http.Request, headers map[string]string) string {
+	if ip := strings.TrimSpace(headers[RealIPHeader]); ip != "" {
+		return ipaddr.Canonical(ip)
+	}
+	if xff := headers["x-forwarded-for"]; xff != "" {
+		hops := strings.Split(xff, ",")
+		return ipaddr.Canonical(hops[len(hops)-1])
+	}
+	return ipaddr.Canonical(r.RemoteAddr)
+}
+
+func socketAddress(ip string) *core.Address {
+	return &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{Address: ip}}}
+}
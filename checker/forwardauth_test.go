@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestForwardAuthRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := httptest.NewRequest("GET", "http://dikastes/auth", nil)
+	r.RemoteAddr = "10.0.0.2:40000"
+	r.Header.Set("X-Original-Method", "POST")
+	r.Header.Set("X-Original-URI", "/orders?id=7")
+	r.Header.Set("X-Real-IP", "192.0.2.9")
+	r.Header.Add("Accept", "text/html")
+	r.Header.Add("Accept", "application/json")
+	req := forwardAuthRequest(r)
+	h := req.GetAttributes().GetRequest().GetHttp()
+	Expect(h.GetMethod()).To(Equal("POST"))
+	Expect(h.GetPath()).To(Equal("/orders?id=7"))
+	Expect(h.GetHost()).To(Equal("dikastes"))
+	Expect(h.GetHeaders()["accept"]).To(Equal("text/html,application/json"))
+	Expect(req.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress()).To(Equal("192.0.2.9"))
+	Expect(req.GetAttributes().GetDestination().GetAddress().GetSocketAddress().GetProtocol()).To(
+		Equal(core.SocketAddress_TCP))
+
+	// Traefik's headers.
+	r = httptest.NewRequest("GET", "http://dikastes/auth", nil)
+	r.RemoteAddr = "10.0.0.2:40000"
+	r.Header.Set("X-Forwarded-Method", "DELETE")
+	r.Header.Set("X-Forwarded-Uri", "/orders/7")
+	r.Header.Set("X-Forwarded-Host", "shop.example.com")
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-For", "203.0.113.5, 198.51.100.1")
+	req = forwardAuthRequest(r)
+	h = req.GetAttributes().GetRequest().GetHttp()
+	Expect(h.GetMethod()).To(Equal("DELETE"))
+	Expect(h.GetPath()).To(Equal("/orders/7"))
+	Expect(h.GetHost()).To(Equal("shop.example.com"))
+	Expect(h.GetScheme()).To(Equal("https"))
+	Expect(req.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress()).To(Equal("198.51.100.1"))
+
+	// Without either, the subrequest itself is checked.
+	r = httptest.NewRequest("GET", "http://dikastes/auth", nil)
+	r.RemoteAddr = "10.0.0.2:40000"
+	req = forwardAuthRequest(r)
+	Expect(req.GetAttributes().GetRequest().GetHttp().GetPath()).To(Equal("/auth"))
+	Expect(req.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress()).To(Equal("10.0.0.2"))
+}
+
+func TestForwardAuth(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"read-only"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "read-only"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}}},
+	}
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore))
+	handler := uut.ForwardAuth()
+	check := func(method string) int {
+		r := httptest.NewRequest("GET", "http://dikastes/auth", nil)
+		r.Header.Set(OriginalMethodHeader, method)
+		r.Header.Set(OriginalURIHeader, "/")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	// Before sync, the fallback verdict is refused.
+	Expect(check("GET")).To(Equal(http.StatusForbidden))
+
+	uut.Store = store
+	Expect(check("GET")).To(Equal(http.StatusOK))
+	Expect(check("POST")).To(Equal(http.StatusForbidden))
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/projectcalico/app-policy/geoip"
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// Annotations that restrict a rule by where its client is, as comma separated lists of ISO 3166-1 alpha-2 country
+// codes or AS numbers. The client is the original client at a gateway; see clientIP. Clients whose country or ASN is
+// unknown are in no list. Rules with any of these annotations fail safe if GeoIP is not configured.
+const (
+	SrcCountriesAnnotation    = AnnotationPrefix + "src-countries"
+	NotSrcCountriesAnnotation = AnnotationPrefix + "not-src-countries"
+	SrcASNsAnnotation         = AnnotationPrefix + "src-asns"
+	NotSrcASNsAnnotation      = AnnotationPrefix + "not-src-asns"
+)
+
+const externalAddressHeader = "x-envoy-external-address"
+
+var (
+	errNoGeoIP    = errors.New("GeoIP not configured")
+	errNoClientIP = errors.New("no client IP address")
+)
+
+// GeoIP looks up the location of IP addresses.
+type GeoIP interface {
+	Lookup(ip net.IP) geoip.Location
+}
+
+// clientIP returns the address of the original client of the request. Envoy sets x-envoy-external-address to it for
+// requests from external clients. Otherwise, if trustedHops is positive, the request is expected to have passed
+// through that many proxies that each appended the address they received it from to X-Forwarded-For, and the client
+// is the address the outermost of them appended. Failing both, the client is the peer Envoy received the request
+// from.
+func clientIP(req *authz.CheckRequest, trustedHops int) net.IP {
+	attr := req.GetAttributes()
+	headers := attr.GetRequest().GetHttp().GetHeaders()
+	if ip := ipaddr.Parse(headers[externalAddressHeader]); ip != nil {
+		return ip
+	}
+	if xff := headers["x-forwarded-for"]; trustedHops > 0 && xff != "" {
+		hops := strings.Split(xff, ",")
+		if len(hops) >= trustedHops {
+			if ip := ipaddr.Parse(hops[len(hops)-trustedHops]); ip != nil {
+				return ip
+			}
+		}
+	}
+	return ipaddr.Parse(attr.GetSource().GetAddress().GetSocketAddress().GetAddress())
+}
+
+// matchGeoIP checks the rule's country and ASN conditions, if any, against the location of the request's client.
+func matchGeoIP(r *proto.Rule, req *requestCache) bool {
+	annotations := r.GetMetadata().GetAnnotations()
+	for _, a := range []string{SrcCountriesAnnotation, NotSrcCountriesAnnotation, SrcASNsAnnotation, NotSrcASNsAnnotation} {
+		v, ok := annotations[a]
+		if !ok {
+			continue
+		}
+		loc, err := req.ClientLocation()
+		if err != nil {
+			return failSafe(r, a, err)
+		}
+		var in bool
+		if a == SrcCountriesAnnotation || a == NotSrcCountriesAnnotation {
+			in = loc.Country != "" && containsFold(v, loc.Country)
+		} else {
+			in, err = containsASN(v, loc.ASN)
+			if err != nil {
+				return failSafe(r, a, err)
+			}
+		}
+		if in != (a == SrcCountriesAnnotation || a == SrcASNsAnnotation) {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), a: v, "location": loc}).Debug("Client location doesn't match rule")
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(list, s string) bool {
+	for _, v := range strings.Split(list, ",") {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsASN(list string, asn uint32) (bool, error) {
+	in := false
+	for _, v := range strings.Split(list, ",") {
+		v = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(v)), "AS")
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return false, fmt.Errorf("invalid ASN %q", v)
+		}
+		in = in || (asn != 0 && uint32(n) == asn)
+	}
+	return in, nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"net"
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/geoip"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+type fakeGeoIP map[string]geoip.Location
+
+func (f fakeGeoIP) Lookup(ip net.IP) geoip.Location {
+	return f[ip.String()]
+}
+
+func geoRequest(peer string, headers map[string]string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address: peer,
+			}}},
+		},
+		Destination: tcpDestination(),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Path: "/admin", Headers: headers},
+		},
+	}}
+}
+
+func TestClientIP(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := geoRequest("10.0.0.1", nil)
+	Expect(clientIP(req, 0).String()).To(Equal("10.0.0.1"))
+	Expect(clientIP(req, 1).String()).To(Equal("10.0.0.1"))
+
+	req = geoRequest("10.0.0.1", map[string]string{"x-forwarded-for": "203.0.113.7, 198.51.100.2,192.0.2.1"})
+	Expect(clientIP(req, 0).String()).To(Equal("10.0.0.1"))
+	Expect(clientIP(req, 1).String()).To(Equal("192.0.2.1"))
+	Expect(clientIP(req, 3).String()).To(Equal("203.0.113.7"))
+	// A shorter chain than expected can't be trusted.
+	Expect(clientIP(req, 4).String()).To(Equal("10.0.0.1"))
+
+	req.Attributes.Request.Http.Headers[externalAddressHeader] = "2001:db8::1"
+	Expect(clientIP(req, 1).String()).To(Equal("2001:db8::1"))
+
+	// Hops may carry ports and brackets, and mapped addresses are the IPv4 clients they stand for.
+	req = geoRequest("::ffff:10.0.0.1", map[string]string{"x-forwarded-for": "[2001:db8::7]:4711, 192.0.2.1:80"})
+	Expect(clientIP(req, 0).String()).To(Equal("10.0.0.1"))
+	Expect(clientIP(req, 1).String()).To(Equal("192.0.2.1"))
+	Expect(clientIP(req, 2).String()).To(Equal("2001:db8::7"))
+}
+
+func TestCheckStoreGeoIP(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"edge"}}},
+	}
+	deny := &proto.Rule{
+		Action: "deny",
+		HttpMatch: &proto.HTTPMatch{Paths: []*proto.HTTPMatch_PathMatch{
+			{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/admin"}},
+		}},
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{NotSrcCountriesAnnotation: "gb, ie"}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "edge"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{deny, {Action: "allow"}},
+	}
+	geo := fakeGeoIP{
+		"81.2.69.160": {Country: "GB", ASN: 20712},
+		"1.2.3.4":     {Country: "AU", ASN: 13335},
+	}
+	check := func(peer string) int32 {
+		return checkStore(store, geoRequest(peer, nil), withGeoIP(geo)).Code
+	}
+
+	Expect(check("81.2.69.160")).To(Equal(OK))
+	Expect(check("1.2.3.4")).To(Equal(PERMISSION_DENIED))
+	// Clients with an unknown country are outside every list.
+	Expect(check("192.0.2.1")).To(Equal(PERMISSION_DENIED))
+
+	deny.Metadata.Annotations = map[string]string{SrcASNsAnnotation: "AS13335,64512"}
+	Expect(check("81.2.69.160")).To(Equal(OK))
+	Expect(check("1.2.3.4")).To(Equal(PERMISSION_DENIED))
+	Expect(check("192.0.2.1")).To(Equal(OK))
+
+	// Malformed ASN lists, and rules checked without GeoIP, fail safe.
+	deny.Metadata.Annotations = map[string]string{SrcASNsAnnotation: "cloudflare"}
+	Expect(check("81.2.69.160")).To(Equal(PERMISSION_DENIED))
+	deny.Metadata.Annotations = map[string]string{SrcCountriesAnnotation: "AU"}
+	Expect(checkStore(store, geoRequest("81.2.69.160", nil)).Code).To(Equal(PERMISSION_DENIED))
+}
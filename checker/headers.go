@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+)
+
+// obsFold matches a line folded onto the next in the obsolete header syntax.
+var obsFold = regexp.MustCompile(`\r?\n[ \t]+`)
+
+// canonicalizeHeaders rewrites the request's headers into the form that rules and the WAF evaluate: names are lower
+// case, folded values are unfolded, repeated Content-Length values are collapsed, and Content-Length is dropped if
+// there's a Transfer-Encoding, which takes precedence. It returns a description of each ambiguity it found; these
+// are what a proxy and a backend can disagree on, to smuggle a request past policy.
+func canonicalizeHeaders(req *authz.CheckRequest) (ambiguities []string) {
+	http := req.GetAttributes().GetRequest().GetHttp()
+	if len(http.GetHeaders()) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(http.Headers))
+	for n := range http.Headers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	headers := make(map[string]string, len(http.Headers))
+	for _, n := range names {
+		name := strings.ToLower(n)
+		if !validHeaderName(name) {
+			ambiguities = append(ambiguities, fmt.Sprintf("invalid header name %q", n))
+			continue
+		}
+		value := http.Headers[n]
+		if obsFold.MatchString(value) {
+			ambiguities = append(ambiguities, fmt.Sprintf("folded %s header", name))
+			value = obsFold.ReplaceAllString(value, " ")
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			ambiguities = append(ambiguities, fmt.Sprintf("control characters in %s header", name))
+			value = strings.Map(func(r rune) rune {
+				if r == '\r' || r == '\n' || r == 0 {
+					return -1
+				}
+				return r
+			}, value)
+		}
+		value = strings.Trim(value, " \t")
+		if prev, ok := headers[name]; ok {
+			ambiguities = append(ambiguities, fmt.Sprintf("repeated %s header", name))
+			value = prev + "," + value
+		}
+		headers[name] = value
+	}
+
+	if cl, ok := headers["content-length"]; ok {
+		if n, err := contentLength(cl); err != nil {
+			ambiguities = append(ambiguities, err.Error())
+			delete(headers, "content-length")
+		} else {
+			headers["content-length"] = n
+		}
+	}
+	if te, ok := headers["transfer-encoding"]; ok {
+		if _, ok := headers["content-length"]; ok {
+			ambiguities = append(ambiguities, "both Content-Length and Transfer-Encoding")
+			delete(headers, "content-length")
+		}
+		codings := strings.Split(te, ",")
+		if !strings.EqualFold(strings.TrimSpace(codings[len(codings)-1]), "chunked") {
+			ambiguities = append(ambiguities, fmt.Sprintf("Transfer-Encoding %q doesn't end with chunked", te))
+		}
+	}
+	http.Headers = headers
+	return ambiguities
+}
+
+// validHeaderName returns true if name is an HTTP token, or an HTTP/2 pseudo-header such as ":path".
+func validHeaderName(name string) bool {
+	name = strings.TrimPrefix(name, ":")
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// contentLength returns the length given by a Content-Length header, which may have been repeated with the same value.
+func contentLength(v string) (string, error) {
+	var n string
+	for _, l := range strings.Split(v, ",") {
+		l = strings.TrimSpace(l)
+		if _, err := strconv.ParseUint(l, 10, 63); err != nil {
+			return "", fmt.Errorf("invalid Content-Length %q", v)
+		}
+		if n != "" && l != n {
+			return "", fmt.Errorf("conflicting Content-Length %q", v)
+		}
+		n = l
+	}
+	return n, nil
+}
+
+// badRequestResponse is the 400 Bad Request response for a request with ambiguous headers.
+func badRequestResponse() *authz.CheckResponse_DeniedResponse {
+	return &authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{
+		Status: &_type.HttpStatus{Code: _type.StatusCode_BadRequest},
+	}}
+}
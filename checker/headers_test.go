@@ -0,0 +1,139 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func headersRequest(headers map[string]string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Destination: tcpDestination(),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: "POST", Path: "/", Headers: headers},
+		},
+	}}
+}
+
+func TestCanonicalizeHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, tc := range []struct {
+		name        string
+		headers     map[string]string
+		expected    map[string]string
+		ambiguities int
+	}{
+		{
+			name:     "canonical",
+			headers:  map[string]string{":path": "/", "content-length": "5", "content-type": "text/plain"},
+			expected: map[string]string{":path": "/", "content-length": "5", "content-type": "text/plain"},
+		},
+		{
+			name:     "repeated identical content-length",
+			headers:  map[string]string{"content-length": "5, 5"},
+			expected: map[string]string{"content-length": "5"},
+		},
+		{
+			name:     "whitespace around values",
+			headers:  map[string]string{"x-user": " alice\t"},
+			expected: map[string]string{"x-user": "alice"},
+		},
+		{
+			name:        "conflicting content-length",
+			headers:     map[string]string{"content-length": "5,6"},
+			expected:    map[string]string{},
+			ambiguities: 1,
+		},
+		{
+			name:        "content-length and transfer-encoding",
+			headers:     map[string]string{"content-length": "5", "transfer-encoding": "chunked"},
+			expected:    map[string]string{"transfer-encoding": "chunked"},
+			ambiguities: 1,
+		},
+		{
+			name:        "transfer-encoding not ending in chunked",
+			headers:     map[string]string{"transfer-encoding": "chunked, identity"},
+			expected:    map[string]string{"transfer-encoding": "chunked, identity"},
+			ambiguities: 1,
+		},
+		{
+			name:        "names differing in case",
+			headers:     map[string]string{"X-User": "alice", "x-user": "bob"},
+			expected:    map[string]string{"x-user": "alice,bob"},
+			ambiguities: 1,
+		},
+		{
+			name:        "invalid name",
+			headers:     map[string]string{"transfer-encoding ": "chunked", "content-length": "5"},
+			expected:    map[string]string{"content-length": "5"},
+			ambiguities: 1,
+		},
+		{
+			name:        "obs-fold",
+			headers:     map[string]string{"x-user": "alice\r\n bob"},
+			expected:    map[string]string{"x-user": "alice bob"},
+			ambiguities: 1,
+		},
+		{
+			name:        "bare line break",
+			headers:     map[string]string{"x-user": "alice\r\nx-admin: true"},
+			expected:    map[string]string{"x-user": "alicex-admin: true"},
+			ambiguities: 1,
+		},
+	} {
+		req := headersRequest(tc.headers)
+		ambiguities := canonicalizeHeaders(req)
+		Expect(req.GetAttributes().GetRequest().GetHttp().GetHeaders()).To(Equal(tc.expected), tc.name)
+		Expect(ambiguities).To(HaveLen(tc.ambiguities), tc.name)
+	}
+}
+
+func TestCheckStrictHeaders(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "allow"}},
+	}
+	smuggled := map[string]string{"content-length": "5", "transfer-encoding": "chunked"}
+
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore))
+	uut.Store = store
+	resp, err := uut.Check(ctx, headersRequest(smuggled))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+
+	uut = NewServer(ctx, make(chan *policystore.PolicyStore), WithStrictHeaders(true))
+	uut.Store = store
+	resp, err = uut.Check(ctx, headersRequest(smuggled))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(INVALID_ARGUMENT))
+	Expect(resp.GetDeniedResponse().GetStatus().GetCode()).To(Equal(_type.StatusCode_BadRequest))
+	resp, err = uut.Check(ctx, headersRequest(map[string]string{"content-length": "5"}))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+
+	"github.com/projectcalico/app-policy/hmacsig"
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// HMACSignatureAnnotation restricts a rule to requests with a valid HMAC signature made with the named scheme from
+// the HMAC signature config. The body is signed by most schemes, so Envoy must send it with the with_request_body
+// option. Rules with the annotation fail safe if the signature can't be checked, for example because the body was
+// truncated or the secret can't be read.
+const HMACSignatureAnnotation = AnnotationPrefix + "hmac-signature"
+
+var errNoSignatureVerifier = errors.New("HMAC signature verification is not configured")
+
+// matchHMACSignature checks the rule's HMAC signature condition, if any, against the request.
+func matchHMACSignature(r *proto.Rule, req *requestCache) bool {
+	name, ok := r.GetMetadata().GetAnnotations()[HMACSignatureAnnotation]
+	if !ok {
+		return true
+	}
+	if req.signatures == nil {
+		return failSafe(r, HMACSignatureAnnotation, errNoSignatureVerifier)
+	}
+	body := req.Body()
+	if body.truncated {
+		return failSafe(r, HMACSignatureAnnotation, errBodyTruncated)
+	}
+	http := req.Request.GetAttributes().GetRequest().GetHttp()
+	err := req.signatures.Verify(name, hmacsig.Request{
+		Method:  http.GetMethod(),
+		Path:    http.GetPath(),
+		Headers: http.GetHeaders(),
+		Body:    body.raw,
+	})
+	switch err {
+	case nil:
+		return true
+	case hmacsig.ErrNoSignature, hmacsig.ErrBadSignature:
+		log.WithFields(log.Fields{"rule": r.GetRuleId(), "scheme": name}).WithError(err).Debug(
+			"Request signature doesn't match rule")
+		return false
+	}
+	return failSafe(r, HMACSignatureAnnotation, err)
+}
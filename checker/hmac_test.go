@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/hmacsig"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func signedRequest(body, signature string, headers map[string]string) *authz.CheckRequest {
+	h := map[string]string{"x-hub-signature-256": signature}
+	for k, v := range headers {
+		h[k] = v
+	}
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Destination: tcpDestination(),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: "POST", Path: "/hooks", Headers: h, Body: body},
+		},
+	}}
+}
+
+func TestCheckStoreHMACSignature(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hmac")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "secret")
+	Expect(ioutil.WriteFile(secretFile, []byte("s3cret"), 0600)).To(Succeed())
+	verifier, err := hmacsig.NewVerifier(&hmacsig.Config{Schemes: []hmacsig.Scheme{
+		{Name: "github", Header: "X-Hub-Signature-256", Prefix: "sha256=", SecretFile: secretFile},
+	}})
+	Expect(err).ToNot(HaveOccurred())
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"webhooks"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "webhooks"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{
+			Action:   "allow",
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{HMACSignatureAnnotation: "github"}},
+		}},
+	}
+	check := func(req *authz.CheckRequest, opts ...requestOption) int32 {
+		return checkStore(store, req, opts...).Code
+	}
+
+	body := `{"action":"opened"}`
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	Expect(check(signedRequest(body, signature, nil), withSignatureVerifier(verifier))).To(Equal(OK))
+	Expect(check(signedRequest(body+" ", signature, nil), withSignatureVerifier(verifier))).To(
+		Equal(PERMISSION_DENIED))
+	Expect(check(signedRequest(body, "", nil), withSignatureVerifier(verifier))).To(Equal(PERMISSION_DENIED))
+	// Signatures can't be checked over truncated bodies, or without a verifier.
+	partial := map[string]string{partialBodyHeader: "true"}
+	Expect(check(signedRequest(body, signature, partial), withSignatureVerifier(verifier))).To(
+		Equal(PERMISSION_DENIED))
+	Expect(check(signedRequest(body, signature, nil))).To(Equal(PERMISSION_DENIED))
+}
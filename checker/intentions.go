@@ -0,0 +1,49 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// IntentionsPolicyName is the name that decisions made by Consul intentions are reported under.
+const IntentionsPolicyName = "consul/intentions"
+
+// IntentionSource supplies Consul intentions compiled into a policy, as consul.Source does. Policy returns nil until
+// the intentions have been read.
+type IntentionSource interface {
+	Policy() *proto.Policy
+}
+
+// checkIntentions applies the intentions to an inbound request. Like a tier ahead of Calico's, they allow or deny the
+// requests they match, and leave the rest to Calico policy.
+func checkIntentions(intentions *proto.Policy, req *requestCache) (code int32, decided bool) {
+	if req.outbound {
+		return 0, false
+	}
+	action := checkRules(intentions.GetInboundRules(), req, "")
+	log.WithField("result", action).Debug("Consul intentions checked")
+	switch action {
+	case ALLOW:
+		req.decidedBy(IntentionsPolicyName)
+		return OK, true
+	case DENY:
+		req.decidedBy(IntentionsPolicyName)
+		return PERMISSION_DENIED, true
+	}
+	return 0, false
+}
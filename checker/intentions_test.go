@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestCheckStoreIntentions(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"get-only"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "get-only"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}}},
+	}
+	intentions := &proto.Policy{InboundRules: []*proto.Rule{
+		{Action: "deny", SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"batch"}}},
+		{Action: "allow", SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"admin"}}},
+		{Action: "pass", SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"web"}}},
+		{Action: "deny"},
+	}}
+	check := func(account, method string) (int32, string) {
+		req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			Source:      &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/default/sa/" + account},
+			Destination: tcpDestination(),
+			Request: &authz.AttributeContext_Request{
+				Http: &authz.AttributeContext_HttpRequest{Method: method, Path: "/"},
+			},
+		}}
+		var policy string
+		code := checkStore(store, req, withIntentions(intentions), withPolicyObserver(func(p string) { policy = p })).Code
+		return code, policy
+	}
+
+	code, policy := check("batch", "GET")
+	Expect(code).To(Equal(PERMISSION_DENIED))
+	Expect(policy).To(Equal(IntentionsPolicyName))
+	code, policy = check("admin", "POST")
+	Expect(code).To(Equal(OK))
+	Expect(policy).To(Equal(IntentionsPolicyName))
+
+	// Passed requests are decided by Calico policy.
+	code, policy = check("web", "GET")
+	Expect(code).To(Equal(OK))
+	Expect(policy).To(Equal("default/get-only"))
+	code, _ = check("web", "POST")
+	Expect(code).To(Equal(PERMISSION_DENIED))
+
+	code, policy = check("other", "GET")
+	Expect(code).To(Equal(PERMISSION_DENIED))
+	Expect(policy).To(Equal(IntentionsPolicyName))
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// Headers in which Istio's metadata exchange passes the sending workload's node metadata, and its proxy ID.
+const (
+	IstioPeerMetadataHeader   = "x-envoy-peer-metadata"
+	IstioPeerMetadataIDHeader = "x-envoy-peer-metadata-id"
+)
+
+// istioPeer is the workload information that Istio's metadata exchange reports about a request's source.
+type istioPeer struct {
+	// Name is the pod's name.
+	Name           string
+	Namespace      string
+	ServiceAccount string
+	Workload       string
+	Labels         map[string]string
+}
+
+// parseIstioPeerMetadata decodes the base64 encoded protobuf Struct in an x-envoy-peer-metadata header. The pod name
+// and namespace fall back to those in the x-envoy-peer-metadata-id header, id, which has the form
+// "sidecar~<ip>~<pod>.<namespace>~<namespace>.svc.<domain>".
+func parseIstioPeerMetadata(metadata, id string) (*istioPeer, error) {
+	b, err := base64.StdEncoding.DecodeString(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", IstioPeerMetadataHeader, err)
+	}
+	var s structpb.Struct
+	if err := proto.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", IstioPeerMetadataHeader, err)
+	}
+	fields := s.GetFields()
+	p := &istioPeer{
+		Name:           fields["NAME"].GetStringValue(),
+		Namespace:      fields["NAMESPACE"].GetStringValue(),
+		ServiceAccount: fields["SERVICE_ACCOUNT"].GetStringValue(),
+		Workload:       fields["WORKLOAD_NAME"].GetStringValue(),
+		Labels:         map[string]string{},
+	}
+	for k, v := range fields["LABELS"].GetStructValue().GetFields() {
+		if l, ok := v.GetKind().(*structpb.Value_StringValue); ok {
+			p.Labels[k] = l.StringValue
+		}
+	}
+	if parts := strings.Split(id, "~"); len(parts) == 4 {
+		if i := strings.LastIndex(parts[2], "."); i > 0 {
+			if p.Name == "" {
+				p.Name = parts[2][:i]
+			}
+			if p.Namespace == "" {
+				p.Namespace = parts[2][i+1:]
+			}
+		}
+	}
+	return p, nil
+}
+
+// istioSourcePeer returns the metadata that Istio reports about the request's source, or nil if there is none or it
+// doesn't belong to the source's service account. Metadata is only trusted if it agrees with the identity
+// authenticated by mTLS, as it is otherwise no more than headers chosen by the client.
+func (r *requestCache) istioSourcePeer(src peer) *istioPeer {
+	headers := r.Request.GetAttributes().GetRequest().GetHttp().GetHeaders()
+	metadata, ok := headers[IstioPeerMetadataHeader]
+	if !ok || src.Name == "" {
+		return nil
+	}
+	p, err := parseIstioPeerMetadata(metadata, headers[IstioPeerMetadataIDHeader])
+	if err != nil {
+		log.WithError(err).Warn("Ignoring Istio peer metadata.")
+		return nil
+	}
+	if p.Namespace != src.Namespace || p.ServiceAccount != src.Name {
+		log.WithFields(log.Fields{
+			"namespace":       p.Namespace,
+			"service_account": p.ServiceAccount,
+			"principal":       r.Request.GetAttributes().GetSource().GetPrincipal(),
+		}).Warn("Ignoring Istio peer metadata that doesn't match the source's principal.")
+		return nil
+	}
+	return p
+}
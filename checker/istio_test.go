@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"encoding/base64"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	. "github.com/onsi/gomega"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func istioMetadata(fields map[string]*structpb.Value) string {
+	b, err := protobuf.Marshal(&structpb.Struct{Fields: fields})
+	Expect(err).ToNot(HaveOccurred())
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+func istioLabels(labels map[string]string) *structpb.Value {
+	s := &structpb.Struct{Fields: map[string]*structpb.Value{}}
+	for k, v := range labels {
+		s.Fields[k] = stringValue(v)
+	}
+	return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: s}}
+}
+
+func TestParseIstioPeerMetadata(t *testing.T) {
+	RegisterTestingT(t)
+
+	p, err := parseIstioPeerMetadata(istioMetadata(map[string]*structpb.Value{
+		"NAMESPACE":       stringValue("web"),
+		"SERVICE_ACCOUNT": stringValue("frontend"),
+		"WORKLOAD_NAME":   stringValue("frontend-v2"),
+		"LABELS":          istioLabels(map[string]string{"app": "frontend", "version": "v2"}),
+	}), "sidecar~10.0.0.7~frontend-v2-5d8f.web~web.svc.cluster.local")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(*p).To(Equal(istioPeer{
+		Name:           "frontend-v2-5d8f",
+		Namespace:      "web",
+		ServiceAccount: "frontend",
+		Workload:       "frontend-v2",
+		Labels:         map[string]string{"app": "frontend", "version": "v2"},
+	}))
+
+	_, err = parseIstioPeerMetadata("not base64!", "")
+	Expect(err).To(HaveOccurred())
+	_, err = parseIstioPeerMetadata(base64.StdEncoding.EncodeToString([]byte{0xff, 0xff}), "")
+	Expect(err).To(HaveOccurred())
+}
+
+func TestCheckStoreIstioPeerMetadata(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"v2-only"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "v2-only"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{
+			Action:                 "allow",
+			SrcServiceAccountMatch: &proto.ServiceAccountMatch{Selector: "version == 'v2'"},
+		}},
+	}
+	check := func(metadata string, opts ...requestOption) int32 {
+		req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			Source:      &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/web/sa/frontend"},
+			Destination: tcpDestination(),
+			Request: &authz.AttributeContext_Request{
+				Http: &authz.AttributeContext_HttpRequest{
+					Method:  "GET",
+					Path:    "/",
+					Headers: map[string]string{IstioPeerMetadataHeader: metadata},
+				},
+			},
+		}}
+		return checkStore(store, req, opts...).Code
+	}
+
+	v2 := istioMetadata(map[string]*structpb.Value{
+		"NAMESPACE":       stringValue("web"),
+		"SERVICE_ACCOUNT": stringValue("frontend"),
+		"LABELS":          istioLabels(map[string]string{"version": "v2"}),
+	})
+	Expect(check(v2, withIstioPeerMetadata())).To(Equal(OK))
+	Expect(check(v2)).To(Equal(PERMISSION_DENIED))
+
+	// Metadata for another identity is ignored.
+	other := istioMetadata(map[string]*structpb.Value{
+		"NAMESPACE":       stringValue("web"),
+		"SERVICE_ACCOUNT": stringValue("admin"),
+		"LABELS":          istioLabels(map[string]string{"version": "v2"}),
+	})
+	Expect(check(other, withIstioPeerMetadata())).To(Equal(PERMISSION_DENIED))
+	Expect(check("garbage", withIstioPeerMetadata())).To(Equal(PERMISSION_DENIED))
+
+	// The service account's own labels take precedence.
+	store.ServiceAccountByID[proto.ServiceAccountID{Name: "frontend", Namespace: "web"}] = &proto.ServiceAccountUpdate{
+		Labels: map[string]string{"version": "v1"},
+	}
+	Expect(check(v2, withIstioPeerMetadata())).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// isL4 returns true if the request is for a connection rather than an HTTP request, as those from Envoy's network
+// ext_authz filter, in front of plain TCP services, are. They have no HTTP attributes.
+func isL4(req *authz.CheckRequest) bool {
+	return req.GetAttributes().GetRequest().GetHttp() == nil
+}
+
+// hasHTTPClauses returns true if the HTTP match restricts the methods, paths or headers of requests. An L4 request
+// has none of these, so it doesn't match a rule with such a clause.
+func hasHTTPClauses(m *proto.HTTPMatch) bool {
+	return len(m.GetMethods()) > 0 || len(m.GetPaths()) > 0 || len(m.GetHeaders()) > 0
+}
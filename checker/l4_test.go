@@ -0,0 +1,145 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	core_v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz_v2 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v2"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// l4Request is a CheckRequest from Envoy's network ext_authz filter, for a TCP connection to port 5432.
+func l4Request(principal, srcIP string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: principal,
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address: srcIP, PortSpecifier: &core.SocketAddress_PortValue{PortValue: 40000},
+			}}},
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/db/sa/postgres",
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address:       "10.0.0.2",
+				Protocol:      core.SocketAddress_TCP,
+				PortSpecifier: &core.SocketAddress_PortValue{PortValue: 5432},
+			}}},
+		},
+	}}
+}
+
+// l4Store has the policies of a TCP-only database service, in the default tier.
+func l4Store(policies map[string]*proto.Policy) *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	var names []string
+	for name, p := range policies {
+		names = append(names, name)
+		store.PolicyByID[proto.PolicyID{Tier: "default", Name: name}] = p
+	}
+	store.Endpoint = &proto.WorkloadEndpoint{Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: names}}}
+	return store
+}
+
+func TestL4NetworkPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	tcp := &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: "TCP"}}
+	store := l4Store(map[string]*proto.Policy{"db/knp.default.postgres": {
+		Namespace: "db",
+		InboundRules: []*proto.Rule{
+			// HTTP clauses don't match connections, rather than matching everything.
+			{Action: "deny", HttpMatch: &proto.HTTPMatch{
+				Paths: []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/"}}},
+			}},
+			{Action: "allow", HttpMatch: &proto.HTTPMatch{
+				Headers: []*proto.HTTPMatch_HeaderMatch{{
+					Name: "x-debug", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: false},
+				}},
+			}},
+			{
+				Action:                 "allow",
+				Protocol:               tcp,
+				DstPorts:               []*proto.PortRange{{First: 5432, Last: 5432}},
+				SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"api"}},
+			},
+		},
+	}})
+
+	// Service accounts in the policy's namespace, on the allowed port, connect.
+	Expect(checkStore(store, l4Request("spiffe://cluster.local/ns/db/sa/api", "10.0.0.1")).Code).To(Equal(OK))
+	// A NetworkPolicy's service account match only applies in its own namespace.
+	Expect(checkStore(store, l4Request("spiffe://cluster.local/ns/shop/sa/api", "10.0.0.1")).Code).To(
+		Equal(PERMISSION_DENIED))
+	Expect(checkStore(store, l4Request("spiffe://cluster.local/ns/db/sa/cron", "10.0.0.1")).Code).To(
+		Equal(PERMISSION_DENIED))
+
+	// An empty HTTP match has no clauses, so it matches connections too.
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "db/knp.default.postgres"}].InboundRules = []*proto.Rule{
+		{Action: "allow", HttpMatch: &proto.HTTPMatch{}},
+	}
+	Expect(checkStore(store, l4Request("spiffe://cluster.local/ns/db/sa/cron", "10.0.0.1")).Code).To(Equal(OK))
+}
+
+func TestL4GlobalNetworkPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := l4Store(map[string]*proto.Policy{"gnp.postgres": {
+		InboundRules: []*proto.Rule{
+			{Action: "deny", SrcNet: []string{"192.168.0.0/16"}},
+			{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"*"}}},
+			{
+				Action:                 "allow",
+				SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"api"}},
+			},
+		},
+	}})
+
+	// A GlobalNetworkPolicy's service account match applies in every namespace.
+	Expect(checkStore(store, l4Request("spiffe://cluster.local/ns/shop/sa/api", "10.0.0.1")).Code).To(Equal(OK))
+	Expect(checkStore(store, l4Request("spiffe://cluster.local/ns/shop/sa/api", "192.168.1.1")).Code).To(
+		Equal(PERMISSION_DENIED))
+	// The wildcard method only matches HTTP requests.
+	Expect(checkStore(store, l4Request("spiffe://cluster.local/ns/shop/sa/web", "10.0.0.1")).Code).To(
+		Equal(PERMISSION_DENIED))
+
+	// Envoy may send an empty request, rather than none, with the network filter.
+	req := l4Request("spiffe://cluster.local/ns/shop/sa/api", "10.0.0.1")
+	req.Attributes.Request = &authz.AttributeContext_Request{}
+	Expect(checkStore(store, req).Code).To(Equal(OK))
+}
+
+func TestL4MTLS(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(isMTLS(l4Request("spiffe://cluster.local/ns/db/sa/api", "10.0.0.1"))).To(BeTrue())
+	Expect(isMTLS(l4Request("", "10.0.0.1"))).To(BeFalse())
+}
+
+func TestL4CheckRequestV3Compat(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := checkRequestV3Compat(&authz_v2.CheckRequest{Attributes: &authz_v2.AttributeContext{
+		Source:      &authz_v2.AttributeContext_Peer{Address: &core_v2.Address{}},
+		Destination: &authz_v2.AttributeContext_Peer{Address: &core_v2.Address{}},
+	}})
+	Expect(isL4(req)).To(BeTrue())
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"time"
+
+	"github.com/projectcalico/app-policy/learn"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+)
+
+// recordObservation records the request's peers, method and path for policy suggestions. Peers whose principals
+// aren't SPIFFE IDs are recorded without an identity.
+func recordObservation(rec *learn.Recorder, principals principalTemplates, req *authz.CheckRequest, now time.Time) {
+	attrs := req.GetAttributes()
+	identity := func(p *authz.AttributeContext_Peer) learn.Identity {
+		id, err := principals.parse(p.GetPrincipal())
+		if err != nil {
+			return learn.Identity{}
+		}
+		return learn.Identity{Namespace: id.Namespace, ServiceAccount: id.Name}
+	}
+	rec.Record(learn.Observation{
+		Source:      identity(attrs.GetSource()),
+		Destination: identity(attrs.GetDestination()),
+		Method:      attrs.GetRequest().GetHttp().GetMethod(),
+		Path:        attrs.GetRequest().GetHttp().GetPath(),
+	}, now)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/learn"
+	"github.com/projectcalico/app-policy/policystore"
+)
+
+func TestCheckLearning(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rec := learn.NewRecorder(now, time.Hour, 1)
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithLearning(rec), WithClock(FixedClock(now)))
+
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source:      &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/web/sa/frontend"},
+		Destination: &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/api/sa/backend"},
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: "GET", Path: "/users/42"},
+		},
+	}}
+	// Requests are recorded even if they are denied, here because policy isn't in sync yet.
+	resp, err := uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(UNAVAILABLE))
+
+	policies := rec.Suggest()
+	Expect(policies).To(HaveLen(1))
+	Expect(policies[0].Metadata).To(Equal(learn.PolicyMetadata{Name: "learned-backend", Namespace: "api"}))
+	Expect(policies[0].Spec.Ingress).To(HaveLen(1))
+	Expect(policies[0].Spec.Ingress[0].HTTP).To(Equal(learn.HTTPMatch{
+		Methods: []string{"GET"},
+		Paths:   []learn.HTTPPath{{Prefix: "/users"}},
+	}))
+}
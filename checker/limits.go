@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"sort"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+// Limits of RequestLimits that a request can exceed, as reported in the oversized request metric.
+const (
+	limitHeaders     = "headers"
+	limitHeaderBytes = "header_bytes"
+	limitBodyBytes   = "body_bytes"
+)
+
+var oversizedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "dikastes_oversized_requests_total",
+	Help: "Requests that exceeded a size limit, by the limit and whether they were trimmed or rejected.",
+}, []string{"limit", "action"})
+
+func init() {
+	prometheus.MustRegister(oversizedRequests)
+}
+
+// RequestLimits bounds the size of the CheckRequests that are evaluated, so that huge requests can't exhaust memory
+// in policy evaluation. Requests over the limits are rejected with RESOURCE_EXHAUSTED and a 431 or 413 response,
+// unless Trim is set.
+type RequestLimits struct {
+	// MaxHeaders is the most headers, including pseudo-headers, a request may have, or 0 for no limit.
+	MaxHeaders int
+	// MaxHeaderBytes is the most bytes the names and values of a request's headers may total, or 0 for no limit.
+	MaxHeaderBytes int
+	// MaxBodyBytes is the most bytes of body a request may have, or 0 for no limit.
+	MaxBodyBytes int
+	// Trim evaluates oversized requests with their headers, in order of name, up to the limits, and their bodies
+	// truncated, rather than rejecting them. Rules matching headers that were dropped don't match, and rules that
+	// need to decode a truncated body fail safe.
+	Trim bool
+}
+
+// enforce checks req against the limits, trimming it if l.Trim is set. It returns the first limit that req exceeded,
+// or "" if it is within them.
+func (l *RequestLimits) enforce(req *authz.CheckRequest) string {
+	http := req.GetAttributes().GetRequest().GetHttp()
+	if http == nil {
+		return ""
+	}
+	exceeded := ""
+	if limit := l.headersLimit(http.GetHeaders()); limit != "" {
+		exceeded = limit
+		if l.Trim {
+			http.Headers = l.trimHeaders(http.GetHeaders())
+		}
+	}
+	if l.MaxBodyBytes > 0 && len(http.GetBody()) > l.MaxBodyBytes {
+		if exceeded == "" {
+			exceeded = limitBodyBytes
+		}
+		if l.Trim {
+			http.Body = http.Body[:l.MaxBodyBytes]
+			if http.Headers == nil {
+				http.Headers = map[string]string{}
+			}
+			http.Headers[partialBodyHeader] = "true"
+		}
+	}
+	if exceeded != "" {
+		action := "rejected"
+		if l.Trim {
+			action = "trimmed"
+		}
+		oversizedRequests.WithLabelValues(exceeded, action).Inc()
+		log.WithFields(log.Fields{
+			"limit":       exceeded,
+			"action":      action,
+			"Req.Headers": len(http.GetHeaders()),
+			"Req.Body":    len(http.GetBody()),
+		}).Info("Request exceeds size limits.")
+	}
+	return exceeded
+}
+
+// headersLimit returns the limit on headers that headers exceed, if any.
+func (l *RequestLimits) headersLimit(headers map[string]string) string {
+	if l.MaxHeaders > 0 && len(headers) > l.MaxHeaders {
+		return limitHeaders
+	}
+	if l.MaxHeaderBytes > 0 {
+		size := 0
+		for k, v := range headers {
+			size += len(k) + len(v)
+		}
+		if size > l.MaxHeaderBytes {
+			return limitHeaderBytes
+		}
+	}
+	return ""
+}
+
+// trimHeaders returns the headers, in order of name, that fit within the limits. Pseudo-headers sort first, so they
+// are kept.
+func (l *RequestLimits) trimHeaders(headers map[string]string) map[string]string {
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	trimmed := map[string]string{}
+	size := 0
+	for _, k := range names {
+		if l.MaxHeaders > 0 && len(trimmed) == l.MaxHeaders {
+			break
+		}
+		n := len(k) + len(headers[k])
+		if l.MaxHeaderBytes > 0 && size+n > l.MaxHeaderBytes {
+			continue
+		}
+		trimmed[k] = headers[k]
+		size += n
+	}
+	return trimmed
+}
+
+// tooLargeResponse is the response to a request rejected for exceeding limit: 413 Payload Too Large for its body,
+// and 431 Request Header Fields Too Large for its headers.
+func tooLargeResponse(limit string) *authz.CheckResponse_DeniedResponse {
+	code := _type.StatusCode_RequestHeaderFieldsTooLarge
+	if limit == limitBodyBytes {
+		code = _type.StatusCode_PayloadTooLarge
+	}
+	return &authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{
+		Status: &_type.HttpStatus{Code: code},
+	}}
+}
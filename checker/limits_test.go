@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestRequestLimitsEnforce(t *testing.T) {
+	RegisterTestingT(t)
+
+	headers := map[string]string{":path": "/", "a": "1", "b": "22", "c": "333"}
+	for _, tc := range []struct {
+		name     string
+		limits   RequestLimits
+		body     string
+		exceeded string
+		headers  map[string]string
+		trimmed  string
+	}{
+		{
+			name:    "within limits",
+			limits:  RequestLimits{MaxHeaders: 4, MaxHeaderBytes: 15, MaxBodyBytes: 4},
+			body:    "body",
+			headers: headers,
+			trimmed: "body",
+		},
+		{
+			name:     "too many headers",
+			limits:   RequestLimits{MaxHeaders: 3},
+			exceeded: limitHeaders,
+		},
+		{
+			name:     "headers too large",
+			limits:   RequestLimits{MaxHeaderBytes: 14},
+			exceeded: limitHeaderBytes,
+		},
+		{
+			name:     "body too large",
+			limits:   RequestLimits{MaxBodyBytes: 3},
+			body:     "body",
+			exceeded: limitBodyBytes,
+		},
+		{
+			name:     "trim headers by count",
+			limits:   RequestLimits{MaxHeaders: 2, Trim: true},
+			exceeded: limitHeaders,
+			headers:  map[string]string{":path": "/", "a": "1"},
+		},
+		{
+			name:     "trim headers by size",
+			limits:   RequestLimits{MaxHeaderBytes: 12, Trim: true},
+			exceeded: limitHeaderBytes,
+			headers:  map[string]string{":path": "/", "a": "1", "b": "22"},
+		},
+		{
+			name:     "trim body",
+			limits:   RequestLimits{MaxBodyBytes: 3, Trim: true},
+			body:     "body",
+			exceeded: limitBodyBytes,
+			headers: map[string]string{
+				":path": "/", "a": "1", "b": "22", "c": "333", partialBodyHeader: "true",
+			},
+			trimmed: "bod",
+		},
+	} {
+		req := headersRequest(map[string]string{})
+		for k, v := range headers {
+			req.Attributes.Request.Http.Headers[k] = v
+		}
+		req.Attributes.Request.Http.Body = tc.body
+		Expect(tc.limits.enforce(req)).To(Equal(tc.exceeded), tc.name)
+		if tc.headers != nil {
+			Expect(req.GetAttributes().GetRequest().GetHttp().GetHeaders()).To(Equal(tc.headers), tc.name)
+			Expect(req.GetAttributes().GetRequest().GetHttp().GetBody()).To(Equal(tc.trimmed), tc.name)
+		}
+	}
+}
+
+func TestCheckRequestLimits(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "allow"}},
+	}
+	large := map[string]string{"x-large": strings.Repeat("a", 100)}
+	rejected := oversizedRequests.WithLabelValues(limitHeaderBytes, "rejected")
+	before := testutil.ToFloat64(rejected)
+
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithRequestLimits(RequestLimits{MaxHeaderBytes: 64}))
+	uut.Store = store
+	resp, err := uut.Check(ctx, headersRequest(large))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(RESOURCE_EXHAUSTED))
+	Expect(resp.GetDeniedResponse().GetStatus().GetCode()).To(Equal(_type.StatusCode_RequestHeaderFieldsTooLarge))
+	Expect(testutil.ToFloat64(rejected) - before).To(Equal(1.0))
+	resp, err = uut.Check(ctx, headersRequest(map[string]string{"x-small": "a"}))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+
+	uut = NewServer(ctx, make(chan *policystore.PolicyStore),
+		WithRequestLimits(RequestLimits{MaxHeaderBytes: 64, Trim: true}))
+	uut.Store = store
+	req := headersRequest(large)
+	resp, err = uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(req.GetAttributes().GetRequest().GetHttp().GetHeaders()).To(BeEmpty())
+}
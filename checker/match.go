@@ -1,4 +1,4 @@
-// Copyright (c) 2018 Tigera, Inc. All rights reserved.
+// Copyright (c) 2018-2026 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,11 +15,11 @@
 package checker
 
 import (
-	"net"
 	"strings"
 
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/policystore"
 	"github.com/projectcalico/app-policy/proto"
-	"github.com/projectcalico/libcalico-go/lib/selector"
 
 	"fmt"
 
@@ -59,10 +59,26 @@ func match(rule *proto.Rule, req *requestCache, policyNamespace string) bool {
 		"Req.Destination": req.Request.GetAttributes().GetDestination(),
 	}).Debug("Checking rule on request")
 	attr := req.Request.GetAttributes()
-	return matchSource(rule, req, policyNamespace) &&
+	return matchIPVersion(rule, req) &&
+		matchSource(rule, req, policyNamespace) &&
 		matchDestination(rule, req, policyNamespace) &&
-		matchRequest(rule, attr.GetRequest()) &&
-		matchL4Protocol(rule, attr.GetDestination())
+		matchRequest(req.store, rule, attr.GetRequest()) &&
+		matchL4Protocol(rule, attr.GetDestination()) &&
+		matchTimeWindows(rule, req) &&
+		matchBodyFields(rule, req) &&
+		matchAnomalyScore(rule, req) &&
+		matchGeoIP(rule, req) &&
+		matchThreatFeeds(rule, req) &&
+		matchDstDomains(rule, req) &&
+		matchTLSFingerprints(rule, req) &&
+		matchLoginAttempts(rule, req) &&
+		matchProtocols(rule, req) &&
+		matchCertExpiry(rule, req) &&
+		matchClaims(rule, req) &&
+		matchHMACSignature(rule, req) &&
+		matchContextExtensions(rule, req) &&
+		matchSrcClusters(rule, req) &&
+		matchDstServices(rule, req, policyNamespace)
 }
 
 func matchSource(r *proto.Rule, req *requestCache, policyNamespace string) bool {
@@ -73,11 +89,14 @@ func matchSource(r *proto.Rule, req *requestCache, policyNamespace string) bool
 		r.GetOriginalNotSrcSelector(),
 		r.GetSrcServiceAccountMatch())
 	addr := req.Request.GetAttributes().GetSource().GetAddress()
-	return matchServiceAccounts(r.GetSrcServiceAccountMatch(), req.SourcePeer()) &&
-		matchNamespace(nsMatch, req.SourceNamespace()) &&
+	return matchServiceAccounts(req.store, r.GetSrcServiceAccountMatch(), req.SourcePeer()) &&
+		matchNamespace(req.store, nsMatch, req.SourceNamespace()) &&
+		matchExternalPeer(r, nsMatch, req.SourcePeer()) &&
 		matchSrcIPSets(r, req) &&
 		matchPort("src", r.GetSrcPorts(), r.GetSrcNamedPortIpSetIds(), req, addr) &&
-		matchNet("src", r.GetSrcNet(), addr)
+		matchNotPort("src", r.GetNotSrcPorts(), r.GetNotSrcNamedPortIpSetIds(), req, addr) &&
+		matchNet("src", r.GetSrcNet(), addr) &&
+		matchNotNet("src", r.GetNotSrcNet(), addr)
 }
 
 func computeNamespaceMatch(
@@ -111,23 +130,30 @@ func matchDestination(r *proto.Rule, req *requestCache, policyNamespace string)
 		r.GetOriginalNotDstSelector(),
 		r.GetDstServiceAccountMatch())
 	addr := req.Request.GetAttributes().GetDestination().GetAddress()
-	return matchServiceAccounts(r.GetDstServiceAccountMatch(), req.DestinationPeer()) &&
-		matchNamespace(nsMatch, req.DestinationNamespace()) &&
+	return matchServiceAccounts(req.store, r.GetDstServiceAccountMatch(), req.DestinationPeer()) &&
+		matchNamespace(req.store, nsMatch, req.DestinationNamespace()) &&
 		matchDstIPSets(r, req) &&
 		matchPort("dst", r.GetDstPorts(), r.GetDstNamedPortIpSetIds(), req, addr) &&
-		matchNet("dst", r.GetDstNet(), addr)
+		matchNotPort("dst", r.GetNotDstPorts(), r.GetNotDstNamedPortIpSetIds(), req, addr) &&
+		matchNet("dst", r.GetDstNet(), addr) &&
+		matchNotNet("dst", r.GetNotDstNet(), addr)
 }
 
-func matchRequest(rule *proto.Rule, req *authz.AttributeContext_Request) bool {
+func matchRequest(store *policystore.PolicyStore, rule *proto.Rule, req *authz.AttributeContext_Request) bool {
 	log.WithField("request", req).Debug("Matching request.")
-	return matchHTTP(rule.GetHttpMatch(), req.GetHttp())
+	if req.GetHttp() == nil {
+		log.Debug("L4 request, only rules without HTTP clauses match.")
+		return !hasHTTPClauses(rule.GetHttpMatch())
+	}
+	return matchHTTP(store, rule.GetHttpMatch(), req.GetHttp())
 }
 
-func matchServiceAccounts(saMatch *proto.ServiceAccountMatch, p peer) bool {
+func matchServiceAccounts(store *policystore.PolicyStore, saMatch *proto.ServiceAccountMatch, p peer) bool {
 	log.WithFields(log.Fields{
 		"name":      p.Name,
 		"namespace": p.Namespace,
 		"labels":    p.Labels,
+		"workload":  p.Workload,
 		"rule":      saMatch},
 	).Debug("Matching service account.")
 	if saMatch == nil {
@@ -140,7 +166,7 @@ func matchServiceAccounts(saMatch *proto.ServiceAccountMatch, p peer) bool {
 	// IP sets of a policy rule. So empty service account is considered a match in such a case.
 	return p.Name == "" ||
 		(matchName(saMatch.GetNames(), p.Name) &&
-			matchLabels(saMatch.GetSelector(), p.Labels))
+			matchLabels(store, saMatch.GetSelector(), p.Labels))
 }
 
 func matchName(names []string, name string) bool {
@@ -160,21 +186,21 @@ func matchName(names []string, name string) bool {
 	return false
 }
 
-func matchLabels(selectorStr string, labels map[string]string) bool {
+// matchLabels matches labels against a selector, parsed when the policy store synced it.
+func matchLabels(store *policystore.PolicyStore, selectorStr string, labels map[string]string) bool {
 	log.WithFields(log.Fields{
 		"selector": selectorStr,
 		"labels":   labels,
 	}).Debug("Matching labels.")
-	sel, err := selector.Parse(selectorStr)
+	sel, err := store.Selector(selectorStr)
 	if err != nil {
 		log.Warnf("Could not parse label selector %v, %v", selectorStr, err)
 		return false
 	}
-	log.Debugf("Parsed selector.")
 	return sel.Evaluate(labels)
 }
 
-func matchNamespace(nsMatch *namespaceMatch, ns namespace) bool {
+func matchNamespace(store *policystore.PolicyStore, nsMatch *namespaceMatch, ns namespace) bool {
 	log.WithFields(log.Fields{
 		"namespace": ns.Name,
 		"labels":    ns.Labels,
@@ -186,10 +212,10 @@ func matchNamespace(nsMatch *namespaceMatch, ns namespace) bool {
 	// IP sets of a policy rule. So empty namespace is considered a match in such a case.
 	return ns.Name == "" ||
 		(matchName(nsMatch.Names, ns.Name) &&
-			matchLabels(nsMatch.Selector, ns.Labels))
+			matchLabels(store, nsMatch.Selector, ns.Labels))
 }
 
-func matchHTTP(rule *proto.HTTPMatch, req *authz.AttributeContext_HttpRequest) bool {
+func matchHTTP(store *policystore.PolicyStore, rule *proto.HTTPMatch, req *authz.AttributeContext_HttpRequest) bool {
 	log.WithFields(log.Fields{
 		"rule": rule,
 	}).Debug("Matching HTTP.")
@@ -197,7 +223,8 @@ func matchHTTP(rule *proto.HTTPMatch, req *authz.AttributeContext_HttpRequest) b
 		log.Debug("nil HTTPRule.  Return true")
 		return true
 	}
-	return matchHTTPMethods(rule.GetMethods(), req.GetMethod()) && matchHTTPPaths(rule.GetPaths(), req.GetPath())
+	return matchHTTPMethods(rule.GetMethods(), req.GetMethod()) && matchHTTPPaths(store, rule.GetPaths(), req.GetPath()) &&
+		matchHTTPHeaders(rule.GetHeaders(), req.GetHeaders())
 }
 
 func matchHTTPMethods(methods []string, reqMethod string) bool {
@@ -223,7 +250,9 @@ func matchHTTPMethods(methods []string, reqMethod string) bool {
 	return false
 }
 
-func matchHTTPPaths(paths []*proto.HTTPMatch_PathMatch, reqPath string) bool {
+// matchHTTPPaths matches requests whose path matches any of the path matches. Regexes are compiled when the policy
+// store syncs them, and one that doesn't compile never matches, like an invalid label selector.
+func matchHTTPPaths(store *policystore.PolicyStore, paths []*proto.HTTPMatch_PathMatch, reqPath string) bool {
 	log.WithFields(log.Fields{
 		"paths":   paths,
 		"reqPath": reqPath,
@@ -255,12 +284,45 @@ func matchHTTPPaths(paths []*proto.HTTPMatch_PathMatch, reqPath string) bool {
 				log.Debugf("HTTP Path prefix %s matched.", pathMatch.GetPrefix())
 				return true
 			}
+		case *proto.HTTPMatch_PathMatch_Regex:
+			re, err := store.PathRegexp(pathMatch.GetRegex())
+			if err != nil {
+				log.Warnf("Could not compile HTTP Path regex %v, %v", pathMatch.GetRegex(), err)
+				continue
+			}
+			if re.MatchString(reqPath) {
+				log.Debugf("HTTP Path regex %s matched.", pathMatch.GetRegex())
+				return true
+			}
 		}
 	}
 	log.Debug("HTTP Path not matched.")
 	return false
 }
 
+// matchHTTPHeaders matches requests that match all of the header matches. Envoy sends header names in lower case.
+func matchHTTPHeaders(headers []*proto.HTTPMatch_HeaderMatch, reqHeaders map[string]string) bool {
+	log.WithFields(log.Fields{
+		"headers": headers,
+	}).Debug("Matching HTTP Headers")
+	for _, h := range headers {
+		value, ok := reqHeaders[strings.ToLower(h.GetName())]
+		switch h.GetHeaderMatch().(type) {
+		case *proto.HTTPMatch_HeaderMatch_Exact:
+			ok = ok && value == h.GetExact()
+		case *proto.HTTPMatch_HeaderMatch_Prefix:
+			ok = ok && strings.HasPrefix(value, h.GetPrefix())
+		case *proto.HTTPMatch_HeaderMatch_Present:
+			ok = ok == h.GetPresent()
+		}
+		if !ok {
+			log.Debugf("HTTP Header %s not matched.", h.GetName())
+			return false
+		}
+	}
+	return true
+}
+
 func matchSrcIPSets(r *proto.Rule, req *requestCache) bool {
 	log.WithFields(log.Fields{
 		"SrcIpSetIds":    r.SrcIpSetIds,
@@ -328,6 +390,22 @@ func matchPort(dir string, ranges []*proto.PortRange, namedPortSets []string, re
 	return false
 }
 
+// matchNotPort matches addresses whose port is outside all of ranges, and that aren't in any of the named port sets.
+// Like matchNotNet, it doesn't match addresses that aren't sockets, such as pipes, since they have no port to be
+// outside the ranges.
+func matchNotPort(
+	dir string, ranges []*proto.PortRange, namedPortSets []string, req *requestCache, addr *core.Address,
+) bool {
+	if len(ranges) == 0 && len(namedPortSets) == 0 {
+		return true
+	}
+	if addr.GetSocketAddress() == nil {
+		log.WithField("addr", addr).Warn("unable to match negated ports of a non-socket address")
+		return false
+	}
+	return !matchPort(dir, ranges, namedPortSets, req, addr)
+}
+
 func matchNet(dir string, nets []string, addr *core.Address) bool {
 	log.WithFields(log.Fields{
 		"nets": nets,
@@ -337,7 +415,7 @@ func matchNet(dir string, nets []string, addr *core.Address) bool {
 	if len(nets) == 0 {
 		return true
 	}
-	ip := net.ParseIP(addr.GetSocketAddress().GetAddress())
+	ip := ipaddr.Parse(addr.GetSocketAddress().GetAddress())
 	if ip == nil {
 		// Envoy should not send us malformed IP addresses, but its possible we could get requests from non-IP
 		// connections, like Pipes.
@@ -345,7 +423,7 @@ func matchNet(dir string, nets []string, addr *core.Address) bool {
 		return false
 	}
 	for _, n := range nets {
-		_, ipn, err := net.ParseCIDR(n)
+		ipn, err := ipaddr.ParseCIDR(n)
 		if err != nil {
 			// Don't match CIDRs if they are malformed. This case should generally be weeded out by validation earlier
 			// in processing before it gets to Dikastes.
@@ -359,6 +437,35 @@ func matchNet(dir string, nets []string, addr *core.Address) bool {
 	return false
 }
 
+// matchNotNet matches addresses outside all of nets. Like matchNet, it doesn't match addresses that aren't IPs, or when
+// a CIDR is malformed, since there's no telling whether the address is outside it.
+func matchNotNet(dir string, nets []string, addr *core.Address) bool {
+	log.WithFields(log.Fields{
+		"notNets": nets,
+		"addr":    addr,
+		"dir":     dir,
+	}).Debug("matching not net")
+	if len(nets) == 0 {
+		return true
+	}
+	ip := ipaddr.Parse(addr.GetSocketAddress().GetAddress())
+	if ip == nil {
+		log.WithField("ip", addr.GetSocketAddress().GetAddress()).Warn("unable to parse IP")
+		return false
+	}
+	for _, n := range nets {
+		ipn, err := ipaddr.ParseCIDR(n)
+		if err != nil {
+			log.WithField("cidr", n).Warn("unable to parse CIDR")
+			return false
+		}
+		if ipn.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
 func matchL4Protocol(rule *proto.Rule, dest *authz.AttributeContext_Peer) bool {
 	// Extract L4 protocol type of socket address for destination peer context. Match against rules.
 	if dest == nil {
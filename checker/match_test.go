@@ -81,7 +81,7 @@ func TestMatchLabels(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.title, func(t *testing.T) {
 			RegisterTestingT(t)
-			result := matchLabels(tc.selector, tc.labels)
+			result := matchLabels(policystore.NewPolicyStore(), tc.selector, tc.labels)
 			Expect(result).To(Equal(tc.result))
 		})
 	}
@@ -129,12 +129,72 @@ func TestMatchHTTPPaths(t *testing.T) {
 		{"exact path with fragment", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Exact{Exact: "/foo"}}}, "/foo#xyz", true},
 		{"prefix path with query fail", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/foobar"}}}, "/foo?bar", false},
 		{"prefix path with fragment fail", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/foobar"}}}, "/foo#bar", false},
+		{"regex", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/carts/[0-9]+"}}}, "/carts/42", true},
+		{"regex fail", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/carts/[0-9]+"}}}, "/carts/abc", false},
+		{"regex matches whole path", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/carts/[0-9]+"}}}, "/carts/42/items", false},
+		{"regex alternatives match whole path", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/a|/b"}}}, "/ab", false},
+		{"regex path with query", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/carts/[0-9]+"}}}, "/carts/42?x=y", true},
+		{"invalid regex", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/carts/("}}}, "/carts/1", false},
+		{"invalid regex alternative", []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/carts/("}}, {PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/carts"}}}, "/carts/1", true},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.title, func(t *testing.T) {
 			RegisterTestingT(t)
-			Expect(matchHTTPPaths(tc.paths, tc.reqPath)).To(Equal(tc.result))
+			Expect(matchHTTPPaths(policystore.NewPolicyStore(), tc.paths, tc.reqPath)).To(Equal(tc.result))
+		})
+	}
+}
+
+func TestMatchHTTPHeaders(t *testing.T) {
+	reqHeaders := map[string]string{"x-internal-caller": "billing-v2", "content-type": "application/json"}
+	testCases := []struct {
+		title   string
+		headers []*proto.HTTPMatch_HeaderMatch
+		result  bool
+	}{
+		{"empty", nil, true},
+		{"exact", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-internal-caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "billing-v2"}},
+		}, true},
+		{"exact fail", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-internal-caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "billing"}},
+		}, false},
+		{"name case insensitive", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "X-Internal-Caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "billing-v2"}},
+		}, true},
+		{"prefix", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-internal-caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Prefix{Prefix: "billing"}},
+		}, true},
+		{"prefix fail", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-internal-caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Prefix{Prefix: "orders"}},
+		}, false},
+		{"prefix of missing header", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-forwarded-for", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Prefix{Prefix: ""}},
+		}, false},
+		{"present", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-internal-caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: true}},
+		}, true},
+		{"present fail", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "authorization", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: true}},
+		}, false},
+		{"absent", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "authorization", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: false}},
+		}, true},
+		{"absent fail", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-internal-caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: false}},
+		}, false},
+		{"name only", []*proto.HTTPMatch_HeaderMatch{{Name: "content-type"}}, true},
+		{"all must match", []*proto.HTTPMatch_HeaderMatch{
+			{Name: "x-internal-caller", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Present{Present: true}},
+			{Name: "content-type", HeaderMatch: &proto.HTTPMatch_HeaderMatch_Exact{Exact: "text/plain"}},
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+			Expect(matchHTTPHeaders(tc.headers, reqHeaders)).To(Equal(tc.result))
 		})
 	}
 }
@@ -144,7 +204,7 @@ func TestMatchHTTPNil(t *testing.T) {
 	RegisterTestingT(t)
 
 	req := &auth.AttributeContext_HttpRequest{}
-	Expect(matchHTTP(nil, req)).To(BeTrue())
+	Expect(matchHTTP(nil, nil, req)).To(BeTrue())
 }
 
 // Test HTTPPaths panic on invalid data.
@@ -155,7 +215,7 @@ func TestPanicHTTPPaths(t *testing.T) {
 		Expect(recover()).To(BeAssignableToTypeOf(&InvalidDataFromDataPlane{}))
 	}()
 	paths := []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Exact{Exact: "/foo"}}}
-	matchHTTPPaths(paths, "foo")
+	matchHTTPPaths(policystore.NewPolicyStore(), paths, "foo")
 }
 
 // Matching a whole rule should require matching all subclauses.
@@ -692,6 +752,38 @@ func TestMatchPort(t *testing.T) {
 	}
 }
 
+func TestMatchNotPort(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	set22 := policystore.NewIPSet(proto.IPSetUpdate_IP_AND_PORT)
+	set22.AddString("192.168.4.5,tcp:22")
+	store.IPSetByID["set22"] = set22
+	req, err := NewRequestCache(store, &auth.CheckRequest{})
+	Expect(err).ToNot(HaveOccurred())
+	addr := func(port uint32) *core.Address {
+		return &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+			Address:       "192.168.4.5",
+			PortSpecifier: &core.SocketAddress_PortValue{PortValue: port},
+		}}}
+	}
+
+	Expect(matchNotPort("test", nil, nil, req, addr(22))).To(BeTrue())
+	ranges := []*proto.PortRange{{First: 10, Last: 20}}
+	Expect(matchNotPort("test", ranges, nil, req, addr(15))).To(BeFalse())
+	Expect(matchNotPort("test", ranges, nil, req, addr(21))).To(BeTrue())
+	Expect(matchNotPort("test", ranges, []string{"set22"}, req, addr(22))).To(BeFalse())
+	Expect(matchNotPort("test", nil, []string{"set22"}, req, addr(23))).To(BeTrue())
+
+	// Like negated nets, negated ports don't match addresses without a port.
+	pipe := &core.Address{Address: &core.Address_Pipe{Pipe: &core.Pipe{Path: "/tmp/t.sock"}}}
+	Expect(matchNotPort("test", ranges, nil, req, pipe)).To(BeFalse())
+	Expect(matchNotNet("test", []string{"10.0.0.0/8"}, pipe)).To(BeFalse())
+	Expect(matchNotPort("test", ranges, nil, req, nil)).To(BeFalse())
+	Expect(matchNotNet("test", []string{"10.0.0.0/8"}, nil)).To(BeFalse())
+	Expect(matchNotPort("test", nil, nil, req, pipe)).To(BeTrue())
+}
+
 func TestMatchNet(t *testing.T) {
 	testCases := []struct {
 		title string
@@ -765,6 +857,36 @@ func TestMatchNet(t *testing.T) {
 			ip:    "45.81.99.1",
 			match: true,
 		},
+		{
+			title: "v4-mapped ip v4 net match",
+			nets:  []string{"192.168.0.0/16"},
+			ip:    "::ffff:192.168.3.145",
+			match: true,
+		},
+		{
+			title: "v4 ip v4-mapped net match",
+			nets:  []string{"::ffff:192.168.0.0/112"},
+			ip:    "192.168.3.145",
+			match: true,
+		},
+		{
+			title: "bracketed v6 ip match",
+			nets:  []string{"45ab:23::/32"},
+			ip:    "[45ab:0023::abcd]",
+			match: true,
+		},
+		{
+			title: "zoned v6 ip match",
+			nets:  []string{"fe80::/10"},
+			ip:    "fe80::1%eth0",
+			match: true,
+		},
+		{
+			title: "bare v6 address match",
+			nets:  []string{"45ab:0023::abcd"},
+			ip:    "45ab:23:0:0::abcd",
+			match: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -787,6 +909,41 @@ func TestMatchNetPipe(t *testing.T) {
 	Expect(matchNet("test", nets, addr)).To(BeFalse())
 }
 
+func TestMatchNotNet(t *testing.T) {
+	testCases := []struct {
+		title string
+		nets  []string
+		ip    string
+		match bool
+	}{
+		{"empty", nil, "192.168.3.145", true},
+		{"outside v4 net", []string{"10.0.0.0/8"}, "192.168.3.145", true},
+		{"inside v4 net", []string{"10.0.0.0/8"}, "10.1.2.3", false},
+		{"inside any net", []string{"45ab:0023::/32", "10.0.0.0/8"}, "45ab:0023::abcd", false},
+		{"outside all nets", []string{"45ab:0023::/32", "10.0.0.0/8"}, "85ab:0023::abcd", true},
+		{"v4-mapped ip inside v4 net", []string{"192.168.0.0/16"}, "::ffff:192.168.3.145", false},
+		{"bad CIDR", []string{"192.168.0.0.0/16"}, "10.1.2.3", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.title, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			addr := &core.Address{Address: &core.Address_SocketAddress{
+				SocketAddress: &core.SocketAddress{Address: tc.ip}}}
+			Expect(matchNotNet("test", tc.nets, addr)).To(Equal(tc.match))
+		})
+	}
+}
+
+// "Pipe" style addresses can't be shown to be outside IP nets either.
+func TestMatchNotNetPipe(t *testing.T) {
+	RegisterTestingT(t)
+
+	addr := &core.Address{Address: &core.Address_Pipe{Pipe: &core.Pipe{Path: "/tmp/t.sock"}}}
+	Expect(matchNotNet("test", []string{"192.168.0.0/16"}, addr)).To(BeFalse())
+}
+
 func TestMatchNetBadCIDR(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"time"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+)
+
+// Keys of the dynamic metadata attached to every CheckResponse, so that downstream filters and access logs can record
+// how a request was decided, for example with %DYNAMIC_METADATA(envoy.filters.http.ext_authz:policy)%.
+const (
+	// PolicyMetadataKey is the policy, as "tier/name", or profile, as "profile/name", that decided the request.
+	PolicyMetadataKey = "policy"
+	// RuleMetadataKey is the ID of the rule that decided the request.
+	RuleMetadataKey = "rule_id"
+	// SourceMetadataKey and DestinationMetadataKey are the identities of the request's peers, each a struct with the
+	// peer's principal and, if it is a SPIFFE ID, namespace and service account.
+	SourceMetadataKey      = "source"
+	DestinationMetadataKey = "destination"
+	// LatencyMetadataKey is the time taken to decide the request, in microseconds.
+	LatencyMetadataKey = "decision_latency_us"
+)
+
+// decisionMetadata returns the dynamic metadata describing the decision on req. policy and rule are empty if no policy
+// or profile decided the request.
+func decisionMetadata(
+	principals principalTemplates, req *authz.CheckRequest, policy string, rule *proto.Rule, latency time.Duration,
+) *structpb.Struct {
+	fields := map[string]*structpb.Value{
+		SourceMetadataKey:      identityValue(principals, req.GetAttributes().GetSource()),
+		DestinationMetadataKey: identityValue(principals, req.GetAttributes().GetDestination()),
+		LatencyMetadataKey:     numberValue(float64(latency.Microseconds())),
+	}
+	if policy != "" {
+		fields[PolicyMetadataKey] = stringValue(policy)
+	}
+	if id := rule.GetRuleId(); id != "" {
+		fields[RuleMetadataKey] = stringValue(id)
+	}
+	return &structpb.Struct{Fields: fields}
+}
+
+// identityValue returns the metadata describing a peer's identity.
+func identityValue(principals principalTemplates, p *authz.AttributeContext_Peer) *structpb.Value {
+	fields := map[string]*structpb.Value{}
+	if principal := p.GetPrincipal(); principal != "" {
+		fields["principal"] = stringValue(principal)
+		if id, err := principals.parse(principal); err == nil {
+			fields["namespace"] = stringValue(id.Namespace)
+			fields["service_account"] = stringValue(id.Name)
+		}
+	}
+	return &structpb.Value{Kind: &structpb.Value_StructValue{StructValue: &structpb.Struct{Fields: fields}}}
+}
+
+func stringValue(s string) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_StringValue{StringValue: s}}
+}
+
+func numberValue(n float64) *structpb.Value {
+	return &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: n}}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// steppingClock advances by step each time it is read.
+type steppingClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+func TestCheckDecisionMetadata(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"writers"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "writers"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{Action: "allow", RuleId: "allow-get", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}},
+		},
+	}
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore),
+		WithClock(&steppingClock{now: time.Unix(1700000000, 0), step: 3 * time.Millisecond}))
+	uut.Store = store
+
+	check := func(method string) *authz.CheckResponse {
+		resp, err := uut.Check(ctx, &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			Source:      &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/web/sa/frontend"},
+			Destination: tcpDestination(),
+			Request: &authz.AttributeContext_Request{
+				Http: &authz.AttributeContext_HttpRequest{Method: method, Path: "/"},
+			},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	resp := check("GET")
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	fields := resp.GetDynamicMetadata().GetFields()
+	Expect(fields[PolicyMetadataKey].GetStringValue()).To(Equal("default/writers"))
+	Expect(fields[RuleMetadataKey].GetStringValue()).To(Equal("allow-get"))
+	Expect(fields[LatencyMetadataKey].GetNumberValue()).To(BeNumerically(">", 0))
+	src := fields[SourceMetadataKey].GetStructValue().GetFields()
+	Expect(src["principal"].GetStringValue()).To(Equal("spiffe://cluster.local/ns/web/sa/frontend"))
+	Expect(src["namespace"].GetStringValue()).To(Equal("web"))
+	Expect(src["service_account"].GetStringValue()).To(Equal("frontend"))
+	Expect(fields[DestinationMetadataKey].GetStructValue().GetFields()).To(BeEmpty())
+
+	// Requests denied by the tier default have no policy or rule.
+	resp = check("POST")
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(resp.GetDynamicMetadata().GetFields()).ToNot(HaveKey(PolicyMetadataKey))
+	Expect(resp.GetDynamicMetadata().GetFields()).ToNot(HaveKey(RuleMetadataKey))
+	Expect(resp.GetDynamicMetadata().GetFields()).To(HaveKey(SourceMetadataKey))
+
+	// Dry-run verdicts are recorded alongside the decision.
+	uut.dryRun = true
+	fields = check("POST").GetDynamicMetadata().GetFields()
+	Expect(fields[DryRunVerdictKey].GetStringValue()).To(Equal("PERMISSION_DENIED"))
+	Expect(fields).To(HaveKey(SourceMetadataKey))
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/genproto/googleapis/rpc/code"
+)
+
+var (
+	checkRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dikastes_check_requests_total",
+		Help: "Check requests decided, by verdict, whether the verdict was enforced rather than logged in dry-run, " +
+			"and the policy or profile that decided them, if any.",
+	}, []string{"verdict", "enforced", "policy"})
+	checkDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: "dikastes_check_duration_seconds",
+		Help: "Time taken to decide check requests.",
+		// From 100µs to about 1.6s.
+		Buckets: prometheus.ExponentialBuckets(0.0001, 2, 15),
+	})
+)
+
+func init() {
+	prometheus.MustRegister(checkRequests, checkDuration)
+}
+
+// recordCheck counts a decided request and observes how long it took to decide.
+func recordCheck(policy string, verdict int32, enforced bool, d time.Duration) {
+	checkRequests.WithLabelValues(code.Code(verdict).String(), strconv.FormatBool(enforced), policy).Inc()
+	checkDuration.Observe(d.Seconds())
+}
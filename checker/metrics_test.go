@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/policystore"
+)
+
+func TestCheckMetrics(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	allowed := checkRequests.WithLabelValues("OK", "true", "default/ns1/default.reads")
+	denied := checkRequests.WithLabelValues("PERMISSION_DENIED", "false", "")
+	before := []float64{testutil.ToFloat64(allowed), testutil.ToFloat64(denied)}
+
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore))
+	uut.Store = policyStatusStore()
+	_, err := uut.Check(ctx, policyStatusRequest("GET"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(testutil.ToFloat64(allowed)).To(Equal(before[0] + 1))
+
+	// Requests that no policy decides, in dry-run.
+	uut = NewServer(ctx, make(chan *policystore.PolicyStore), WithDryRun(true))
+	uut.Store = policyStatusStore()
+	_, err = uut.Check(ctx, policyStatusRequest("DELETE"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(testutil.ToFloat64(denied)).To(Equal(before[1] + 1))
+}
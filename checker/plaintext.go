@@ -0,0 +1,87 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// RequireMTLSAnnotation, set to "true" on an allow rule, denies requests the rule allows unless they arrived over
+// mTLS, rather than by plaintext fallback. Such requests are denied with UNAUTHENTICATED and a 426 Upgrade Required
+// response, so they can be told apart from requests that policy denies.
+const RequireMTLSAnnotation = AnnotationPrefix + "require-mtls"
+
+var plaintextDenied = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "dikastes_plaintext_requests_denied_total",
+	Help: "Requests denied because they did not arrive over mTLS.",
+})
+
+func init() {
+	prometheus.MustRegister(plaintextDenied)
+}
+
+// isMTLS returns true if the request arrived over mTLS: the client presented a certificate with an identity, and
+// Envoy saw the request over TLS. L4 requests have no scheme, and Envoy only reports the principal of a connection
+// that presented a certificate.
+func isMTLS(req *authz.CheckRequest) bool {
+	attr := req.GetAttributes()
+	if attr.GetSource().GetPrincipal() == "" {
+		return false
+	}
+	return isL4(req) || strings.EqualFold(attr.GetRequest().GetHttp().GetScheme(), "https")
+}
+
+// checkMTLS denies requests that must arrive over mTLS, because required is set or the rule that allowed them
+// requires it, but didn't.
+func checkMTLS(
+	required bool, rule *proto.Rule, req *authz.CheckRequest,
+) (status.Status, *authz.CheckResponse_DeniedResponse) {
+	if !required && rule.GetMetadata().GetAnnotations()[RequireMTLSAnnotation] != "true" {
+		return status.Status{Code: OK}, nil
+	}
+	if isMTLS(req) {
+		return status.Status{Code: OK}, nil
+	}
+	attr := req.GetAttributes()
+	log.WithFields(log.Fields{
+		"rule":   rule.GetRuleId(),
+		"source": attr.GetSource().GetAddress(),
+		"scheme": attr.GetRequest().GetHttp().GetScheme(),
+		"path":   attr.GetRequest().GetHttp().GetPath(),
+	}).Info("Plaintext request denied.")
+	plaintextDenied.Inc()
+	return status.Status{Code: UNAUTHENTICATED, Message: "mTLS required"}, upgradeRequiredResponse()
+}
+
+// upgradeRequiredResponse is the 426 Upgrade Required response for plaintext requests, which asks the client to
+// switch to TLS as RFC 2817 describes.
+func upgradeRequiredResponse() *authz.CheckResponse_DeniedResponse {
+	return &authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{
+		Status: &_type.HttpStatus{Code: _type.StatusCode_UpgradeRequired},
+		Headers: []*core.HeaderValueOption{
+			{Header: &core.HeaderValue{Key: "upgrade", Value: "TLS/1.2, HTTP/1.1"}},
+			{Header: &core.HeaderValue{Key: "connection", Value: "Upgrade"}},
+		},
+	}}
+}
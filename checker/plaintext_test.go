@@ -0,0 +1,108 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func mtlsRequest(principal, scheme string) *authz.CheckRequest {
+	req := rateLimitRequest(principal, "/")
+	req.Attributes.Request.Http.Scheme = scheme
+	return req
+}
+
+func TestCheckMTLS(t *testing.T) {
+	RegisterTestingT(t)
+
+	steve := "spiffe://cluster.local/ns/default/sa/steve"
+	required := &proto.Rule{
+		Action:   "Allow",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{RequireMTLSAnnotation: "true"}},
+	}
+	plain := &proto.Rule{Action: "Allow"}
+
+	for _, c := range []struct {
+		global bool
+		rule   *proto.Rule
+		req    *authz.CheckRequest
+		code   int32
+	}{
+		{false, plain, mtlsRequest("", "http"), OK},
+		{false, required, mtlsRequest(steve, "https"), OK},
+		{false, required, mtlsRequest(steve, "HTTPS"), OK},
+		{false, required, mtlsRequest(steve, "http"), UNAUTHENTICATED},
+		{false, required, mtlsRequest("", "https"), UNAUTHENTICATED},
+		{true, plain, mtlsRequest("", "http"), UNAUTHENTICATED},
+		{true, plain, mtlsRequest(steve, "https"), OK},
+		{true, nil, mtlsRequest("", "http"), UNAUTHENTICATED},
+	} {
+		st, denied := checkMTLS(c.global, c.rule, c.req)
+		Expect(st.Code).To(Equal(c.code), c)
+		if c.code == OK {
+			Expect(denied).To(BeNil())
+		} else {
+			Expect(denied.DeniedResponse.GetStatus().GetCode()).To(Equal(_type.StatusCode_UpgradeRequired))
+		}
+	}
+}
+
+// Plaintext requests are denied after policy allows them, and counted.
+func TestCheckRequireMTLS(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithRequireMTLS(true))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{
+			Action:    "Deny",
+			HttpMatch: &proto.HTTPMatch{Methods: []string{"DELETE"}},
+		}, {
+			Action: "Allow",
+		}},
+	}
+	uut.Store = store
+	check := func(req *authz.CheckRequest) *authz.CheckResponse {
+		resp, err := uut.Check(ctx, req)
+		Expect(err).ToNot(HaveOccurred())
+		return resp
+	}
+
+	before := testutil.ToFloat64(plaintextDenied)
+	Expect(check(mtlsRequest("spiffe://cluster.local/ns/default/sa/steve", "https")).GetStatus().GetCode()).To(
+		Equal(OK))
+	resp := check(mtlsRequest("", "http"))
+	Expect(resp.GetStatus().GetCode()).To(Equal(UNAUTHENTICATED))
+	Expect(resp.GetDeniedResponse().GetStatus().GetCode()).To(Equal(_type.StatusCode_UpgradeRequired))
+	Expect(testutil.ToFloat64(plaintextDenied)).To(Equal(before + 1))
+
+	// Requests that policy denies are denied as usual.
+	req := mtlsRequest("", "http")
+	req.Attributes.Request.Http.Method = "DELETE"
+	Expect(check(req).GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(testutil.ToFloat64(plaintextDenied)).To(Equal(before + 1))
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"strings"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+
+	"github.com/projectcalico/app-policy/statscache"
+)
+
+// policyStats records a hit on the Calico policy that decided a request, against the connection the request arrived
+// on, so that policy status can show which policies are matching traffic. Profiles and Consul intentions aren't
+// Calico policies, so their decisions aren't recorded, and nor is the default deny. ok is false if there's nothing
+// to record.
+func policyStats(req *authz.CheckRequest, policy string, enforced bool) (d statscache.DPStats, ok bool) {
+	if policy == IntentionsPolicyName || strings.HasPrefix(policy, "profile/") {
+		return d, false
+	}
+	parts := strings.SplitN(policy, "/", 2)
+	if len(parts) != 2 {
+		return d, false
+	}
+	hit := statscache.PolicyHit{Tier: parts[0], Name: parts[1], Enforced: enforced}
+	return statscache.DPStats{
+		Tuple:  statsTuple(req),
+		Values: statscache.Values{PolicyHits: map[statscache.PolicyHit]int64{hit: 1}},
+	}, true
+}
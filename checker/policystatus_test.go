@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/statscache"
+)
+
+func policyStatusRequest(method string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{Address: &core.Address{Address: &core.Address_SocketAddress{
+			SocketAddress: &core.SocketAddress{Address: "10.0.0.1", PortSpecifier: &core.SocketAddress_PortValue{
+				PortValue: 40000,
+			}},
+		}}},
+		Destination: &authz.AttributeContext_Peer{Address: &core.Address{Address: &core.Address_SocketAddress{
+			SocketAddress: &core.SocketAddress{Address: "10.0.0.2", PortSpecifier: &core.SocketAddress_PortValue{
+				PortValue: 8080,
+			}},
+		}}},
+		Request: &authz.AttributeContext_Request{Http: &authz.AttributeContext_HttpRequest{Method: method, Path: "/"}},
+	}}
+}
+
+func policyStatusStore() *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers:      []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"ns1/default.reads"}}},
+		ProfileIds: []string{"kns.ns1"},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "ns1/default.reads"}] = &proto.Policy{
+		Namespace: "ns1",
+		InboundRules: []*proto.Rule{
+			{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}},
+			{Action: "pass", HttpMatch: &proto.HTTPMatch{Methods: []string{"POST"}}},
+		},
+	}
+	store.ProfileByID[proto.ProfileID{Name: "kns.ns1"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "allow"}},
+	}
+	return store
+}
+
+func TestPolicyStats(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := policyStatusRequest("GET")
+	d, ok := policyStats(req, "default/ns1/default.reads", true)
+	Expect(ok).To(BeTrue())
+	Expect(d).To(Equal(statscache.DPStats{
+		Tuple: statscache.Tuple{SrcIp: "10.0.0.1", DstIp: "10.0.0.2", SrcPort: 40000, DstPort: 8080, Protocol: "TCP"},
+		Values: statscache.Values{PolicyHits: map[statscache.PolicyHit]int64{
+			{Tier: "default", Name: "ns1/default.reads", Enforced: true}: 1,
+		}},
+	}))
+
+	for _, policy := range []string{"", "profile/kns.ns1", IntentionsPolicyName} {
+		_, ok := policyStats(req, policy, true)
+		Expect(ok).To(BeFalse(), policy)
+	}
+}
+
+func TestCheckPolicyStatus(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	check := func(uut *authServer, method string) {
+		_, err := uut.Check(ctx, policyStatusRequest(method))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	hits := func(stats []statscache.DPStats) []statscache.PolicyHit {
+		var hs []statscache.PolicyHit
+		for _, d := range stats {
+			for h := range d.Values.PolicyHits {
+				hs = append(hs, h)
+			}
+		}
+		return hs
+	}
+
+	stats := &fakeStatsReporter{}
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithPolicyStatus(stats))
+	uut.Store = policyStatusStore()
+	check(uut, "GET")
+	// Requests that pass to the profiles aren't decided by a policy.
+	check(uut, "POST")
+	Expect(hits(stats.stats)).To(Equal([]statscache.PolicyHit{
+		{Tier: "default", Name: "ns1/default.reads", Enforced: true},
+	}))
+
+	// Hits in dry-run are reported as not enforced.
+	stats = &fakeStatsReporter{}
+	uut = NewServer(ctx, make(chan *policystore.PolicyStore), WithPolicyStatus(stats), WithDryRun(true))
+	uut.Store = policyStatusStore()
+	check(uut, "GET")
+	Expect(hits(stats.stats)).To(Equal([]statscache.PolicyHit{
+		{Tier: "default", Name: "ns1/default.reads", Enforced: false},
+	}))
+}
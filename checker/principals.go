@@ -0,0 +1,120 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Placeholders of a PrincipalTemplate that capture the peer's namespace and service account. Either the long or
+// short form may be used.
+var (
+	namespacePlaceholders      = []string{"{namespace}", "{ns}"}
+	serviceAccountPlaceholders = []string{"{serviceaccount}", "{sa}"}
+)
+
+// placeholderRegExp matches any placeholder of a PrincipalTemplate.
+var placeholderRegExp = regexp.MustCompile(`\{[a-zA-Z0-9_-]+\}`)
+
+// PrincipalTemplate is the layout of the paths of SPIFFE IDs from an issuer that doesn't follow Istio's
+// /ns/{namespace}/sa/{serviceaccount}, e.g. /region/{region}/ns/{namespace}/sa/{serviceaccount}, so that the
+// namespaces and service accounts of its peers can be found. Placeholders other than the namespace and service account
+// match anything within a path segment.
+type PrincipalTemplate struct {
+	template string
+	re       *regexp.Regexp
+	// namespace and serviceAccount are the indexes of the submatches of re that capture them.
+	namespace, serviceAccount int
+}
+
+// ParsePrincipalTemplate parses a template for the paths of SPIFFE IDs, which must have exactly one namespace and one
+// service account placeholder.
+func ParsePrincipalTemplate(template string) (*PrincipalTemplate, error) {
+	if !strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("principal template %q must start with /", template)
+	}
+	t := &PrincipalTemplate{template: template}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	group, last := 0, 0
+	for _, loc := range placeholderRegExp.FindAllStringIndex(template, -1) {
+		literal := template[last:loc[0]]
+		if strings.ContainsAny(literal, "{}") {
+			return nil, fmt.Errorf("principal template %q has a malformed placeholder", template)
+		}
+		pattern.WriteString(regexp.QuoteMeta(literal))
+		pattern.WriteString("([^/]+)")
+		group++
+		placeholder := strings.ToLower(template[loc[0]:loc[1]])
+		switch {
+		case containsString(namespacePlaceholders, placeholder):
+			if t.namespace != 0 {
+				return nil, fmt.Errorf("principal template %q has more than one namespace", template)
+			}
+			t.namespace = group
+		case containsString(serviceAccountPlaceholders, placeholder):
+			if t.serviceAccount != 0 {
+				return nil, fmt.Errorf("principal template %q has more than one service account", template)
+			}
+			t.serviceAccount = group
+		}
+		last = loc[1]
+	}
+	if strings.ContainsAny(template[last:], "{}") {
+		return nil, fmt.Errorf("principal template %q has a malformed placeholder", template)
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+	if t.namespace == 0 || t.serviceAccount == 0 {
+		return nil, fmt.Errorf("principal template %q must have a {namespace} and a {serviceaccount}", template)
+	}
+	t.re = regexp.MustCompile(pattern.String())
+	return t, nil
+}
+
+// String returns the template.
+func (t *PrincipalTemplate) String() string {
+	return t.template
+}
+
+// match returns the peer named by the path of a SPIFFE ID, or false if the path doesn't fit the template.
+func (t *PrincipalTemplate) match(path string) (peer, bool) {
+	m := t.re.FindStringSubmatch(path)
+	if m == nil {
+		return peer{}, false
+	}
+	return peer{Name: m[t.serviceAccount], Namespace: m[t.namespace]}, true
+}
+
+// principalTemplates are the layouts of SPIFFE IDs tried before Istio's.
+type principalTemplates []*PrincipalTemplate
+
+// parse finds the peer named by a principal from the first template that its path fits, falling back to
+// parseSpiffeID.
+func (ts principalTemplates) parse(id string) (peer, error) {
+	if len(ts) > 0 && strings.HasPrefix(id, "spiffe://") {
+		path := strings.TrimPrefix(id, "spiffe://")
+		if i := strings.Index(path, "/"); i > 0 {
+			for _, t := range ts {
+				if p, ok := t.match(path[i:]); ok {
+					return p, nil
+				}
+			}
+		}
+	}
+	return parseSpiffeID(id)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+)
+
+func mustParsePrincipalTemplates(templates ...string) principalTemplates {
+	var ts principalTemplates
+	for _, s := range templates {
+		t, err := ParsePrincipalTemplate(s)
+		Expect(err).ToNot(HaveOccurred())
+		ts = append(ts, t)
+	}
+	return ts
+}
+
+func TestParsePrincipalTemplate(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, s := range []string{
+		"/region/{region}/ns/{namespace}/sa/{serviceaccount}",
+		"/{ns}/{sa}",
+		"/k8s/{NameSpace}.{sa}",
+	} {
+		_, err := ParsePrincipalTemplate(s)
+		Expect(err).ToNot(HaveOccurred(), s)
+	}
+	for _, s := range []string{
+		"",
+		"ns/{namespace}/sa/{serviceaccount}",
+		"/ns/{namespace}",
+		"/sa/{serviceaccount}",
+		"/ns/{namespace}/sa/{serviceaccount}/{ns}",
+		"/ns/{namespace}/sa/{serviceaccount}/{sa}",
+		"/ns/{namespace/sa/{serviceaccount}",
+		"/ns/{namespace}/sa/{serviceaccount}}",
+	} {
+		_, err := ParsePrincipalTemplate(s)
+		Expect(err).To(HaveOccurred(), s)
+	}
+}
+
+func TestPrincipalTemplatesParse(t *testing.T) {
+	RegisterTestingT(t)
+
+	ts := mustParsePrincipalTemplates("/region/{r}/ns/{ns}/sa/{sa}", "/workload/{sa}.{namespace}")
+	parse := func(id string) peer {
+		p, err := ts.parse(id)
+		Expect(err).ToNot(HaveOccurred(), id)
+		return p
+	}
+
+	Expect(parse("spiffe://corp.example.com/region/eu-west/ns/shop/sa/cart")).To(
+		Equal(peer{Name: "cart", Namespace: "shop"}))
+	Expect(parse("spiffe://corp.example.com/workload/cart.shop")).To(Equal(peer{Name: "cart", Namespace: "shop"}))
+	// Istio's layout, and Linkerd's identities, still parse.
+	Expect(parse("spiffe://cluster.local/ns/shop/sa/web")).To(Equal(peer{Name: "web", Namespace: "shop"}))
+	Expect(parse("web.shop.serviceaccount.identity.linkerd.cluster.local")).To(
+		Equal(peer{Name: "web", Namespace: "shop"}))
+
+	// Placeholders match within a single path segment.
+	_, err := ts.parse("spiffe://corp.example.com/region/eu/west/ns/shop/sa/cart")
+	Expect(err).To(HaveOccurred())
+	_, err = ts.parse("spiffe://corp.example.com/workload/cart")
+	Expect(err).To(HaveOccurred())
+	// Only the path is matched, not the trust domain.
+	_, err = ts.parse("spiffe:///workload/cart.shop")
+	Expect(err).To(HaveOccurred())
+}
+
+func TestRequestCachePrincipalTemplates(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source:      &authz.AttributeContext_Peer{Principal: "spiffe://corp.example.com/region/eu/ns/shop/sa/cart"},
+		Destination: &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/shop/sa/web"},
+	}}
+	_, err := NewRequestCache(policystore.NewPolicyStore(), req)
+	Expect(err).To(HaveOccurred())
+
+	ts := mustParsePrincipalTemplates("/region/{region}/ns/{namespace}/sa/{serviceaccount}")
+	uut, err := NewRequestCache(policystore.NewPolicyStore(), req, withPrincipalTemplates(ts))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(uut.SourcePeer().Name).To(Equal("cart"))
+	Expect(uut.SourcePeer().Namespace).To(Equal("shop"))
+	Expect(uut.DestinationPeer().Name).To(Equal("web"))
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// Annotations that restrict a rule by the protocol a request speaks, as comma separated lists of protocols. The
+// protocols are:
+//
+//   - "upgrade" for any request to switch protocols, and the name of the protocol it switches to, e.g. "websocket"
+//   - "grpc" for gRPC requests, and "grpc-web" for gRPC-Web requests
+//   - "http" for any other request
+//
+// so that, for example, a deny rule for "upgrade" stops clients switching protocols, while an allow rule for
+// "websocket" permits only WebSocket upgrades. Without either annotation, upgrades match rules like any other request.
+const (
+	ProtocolsAnnotation    = AnnotationPrefix + "protocols"
+	NotProtocolsAnnotation = AnnotationPrefix + "not-protocols"
+)
+
+// requestProtocols returns the protocols that an HTTP request speaks, as named by ProtocolsAnnotation.
+func requestProtocols(http *authz.AttributeContext_HttpRequest) []string {
+	var protocols []string
+	if upgrades := upgradeProtocols(http); len(upgrades) > 0 {
+		protocols = append([]string{"upgrade"}, upgrades...)
+	}
+	mediaType, _, _ := mime.ParseMediaType(http.GetHeaders()["content-type"])
+	switch {
+	case mediaType == "application/grpc-web-text" || strings.HasPrefix(mediaType, "application/grpc-web-text+"),
+		mediaType == "application/grpc-web" || strings.HasPrefix(mediaType, "application/grpc-web+"):
+		protocols = append(protocols, "grpc-web")
+	case mediaType == "application/grpc" || strings.HasPrefix(mediaType, "application/grpc+"):
+		protocols = append(protocols, "grpc")
+	}
+	if len(protocols) == 0 {
+		protocols = []string{"http"}
+	}
+	return protocols
+}
+
+// upgradeProtocols returns the lowercase names, without versions, of the protocols that a request asks to switch to.
+// HTTP/1.1 requests ask with the Upgrade and Connection headers, and HTTP/2 requests with an extended CONNECT.
+func upgradeProtocols(http *authz.AttributeContext_HttpRequest) []string {
+	headers := http.GetHeaders()
+	if p := headers[":protocol"]; p != "" && strings.EqualFold(http.GetMethod(), "CONNECT") {
+		return []string{strings.ToLower(p)}
+	}
+	if !containsFold(headers["connection"], "upgrade") {
+		return nil
+	}
+	var protocols []string
+	for _, p := range strings.Split(headers["upgrade"], ",") {
+		p = strings.TrimSpace(p)
+		if i := strings.Index(p, "/"); i >= 0 {
+			p = p[:i]
+		}
+		if p != "" {
+			protocols = append(protocols, strings.ToLower(p))
+		}
+	}
+	return protocols
+}
+
+// matchProtocols checks the rule's protocol conditions, if any, against the request.
+func matchProtocols(r *proto.Rule, req *requestCache) bool {
+	annotations := r.GetMetadata().GetAnnotations()
+	for _, a := range []string{ProtocolsAnnotation, NotProtocolsAnnotation} {
+		v, ok := annotations[a]
+		if !ok {
+			continue
+		}
+		protocols := req.Protocols()
+		in := false
+		for _, p := range protocols {
+			if containsFold(v, p) {
+				in = true
+				break
+			}
+		}
+		if in != (a == ProtocolsAnnotation) {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), a: v, "protocols": protocols}).Debug(
+				"Request protocol doesn't match rule")
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func protocolRequest(method string, headers map[string]string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Destination: tcpDestination(),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: method, Headers: headers},
+		},
+	}}
+}
+
+func TestRequestProtocols(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, c := range []struct {
+		method    string
+		headers   map[string]string
+		protocols []string
+	}{
+		{"GET", nil, []string{"http"}},
+		{"GET", map[string]string{"connection": "keep-alive, Upgrade", "upgrade": "WebSocket"},
+			[]string{"upgrade", "websocket"}},
+		{"GET", map[string]string{"connection": "upgrade", "upgrade": "h2c, foo/2"}, []string{"upgrade", "h2c", "foo"}},
+		// Upgrade without Connection: upgrade isn't a request to switch protocols.
+		{"GET", map[string]string{"upgrade": "websocket"}, []string{"http"}},
+		{"CONNECT", map[string]string{":protocol": "websocket"}, []string{"upgrade", "websocket"}},
+		{"POST", map[string]string{":protocol": "websocket"}, []string{"http"}},
+		{"POST", map[string]string{"content-type": "application/grpc"}, []string{"grpc"}},
+		{"POST", map[string]string{"content-type": "application/grpc+proto"}, []string{"grpc"}},
+		{"POST", map[string]string{"content-type": "application/grpc-web+proto"}, []string{"grpc-web"}},
+		{"POST", map[string]string{"content-type": "application/grpc-web-text"}, []string{"grpc-web"}},
+		{"POST", map[string]string{"content-type": "application/grpcfoo"}, []string{"http"}},
+	} {
+		req := protocolRequest(c.method, c.headers)
+		Expect(requestProtocols(req.GetAttributes().GetRequest().GetHttp())).To(Equal(c.protocols), c.headers)
+	}
+}
+
+func TestCheckStoreProtocols(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"upgrades"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "upgrades"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{
+			// Only WebSocket upgrades are allowed.
+			Action:   "allow",
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{ProtocolsAnnotation: "websocket"}},
+		}, {
+			Action:   "deny",
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{ProtocolsAnnotation: "upgrade"}},
+		}, {
+			Action:   "deny",
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{NotProtocolsAnnotation: "http, grpc"}},
+		}, {
+			Action: "allow",
+		}},
+	}
+	check := func(method string, headers map[string]string) int32 {
+		return checkStore(store, protocolRequest(method, headers)).Code
+	}
+
+	Expect(check("GET", nil)).To(Equal(OK))
+	Expect(check("GET", map[string]string{"connection": "upgrade", "upgrade": "websocket"})).To(Equal(OK))
+	Expect(check("CONNECT", map[string]string{":protocol": "websocket"})).To(Equal(OK))
+	Expect(check("GET", map[string]string{"connection": "upgrade", "upgrade": "h2c"})).To(Equal(PERMISSION_DENIED))
+	Expect(check("POST", map[string]string{"content-type": "application/grpc"})).To(Equal(OK))
+	Expect(check("POST", map[string]string{"content-type": "application/grpc-web"})).To(Equal(PERMISSION_DENIED))
+}
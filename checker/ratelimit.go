@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"sort"
+	"time"
+
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/ratelimit"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// RateLimitAnnotation limits the requests an Allow rule lets through, in the format accepted by ratelimit.ParseLimit,
+// e.g. "10/s" or "100/m,burst=20". Each source identity gets its own bucket per destination and path class.
+const RateLimitAnnotation = AnnotationPrefix + "rate-limit"
+
+// checkRateLimit takes a token for the request from the first configured limit it matches and from the rate limit
+// annotated on the rule that allowed it, if any. It returns OK if both have tokens to spare, and otherwise
+// RESOURCE_EXHAUSTED along with a 429 response carrying the rate limit headers. A malformed annotation denies the
+// request.
+func checkRateLimit(l *ratelimit.Limiter, rule *proto.Rule, req *authz.CheckRequest, now time.Time) (status.Status, *authz.CheckResponse_DeniedResponse) {
+	attr := req.GetAttributes()
+	src := peerIdentity(attr.GetSource())
+	dst := peerIdentity(attr.GetDestination())
+	path := attr.GetRequest().GetHttp().GetPath()
+
+	d, limited := l.Check(src, dst, path, now)
+	if a, ok := rule.GetMetadata().GetAnnotations()[RateLimitAnnotation]; ok {
+		limit, err := ratelimit.ParseLimit(a)
+		if err != nil {
+			log.WithError(err).WithField("rule", rule.GetRuleId()).Warn("Invalid rate limit annotation, denying request.")
+			return status.Status{Code: PERMISSION_DENIED}, nil
+		}
+		k := ratelimit.Key{Scope: "rule/" + rule.GetRuleId(), Source: src, Destination: dst, PathClass: l.PathClass(path)}
+		rd := l.Take(k, limit, now)
+		if limited {
+			d = d.Stricter(rd)
+		} else {
+			d, limited = rd, true
+		}
+	}
+	if !limited || d.Allowed {
+		return status.Status{Code: OK}, nil
+	}
+	log.WithFields(log.Fields{
+		"source":      src,
+		"destination": dst,
+		"path":        path,
+		"retryAfter":  d.RetryAfter,
+	}).Info("Request rate limited.")
+	return status.Status{Code: RESOURCE_EXHAUSTED, Message: "rate limit exceeded"}, rateLimitedResponse(d)
+}
+
+// rateLimitedResponse is the 429 Too Many Requests response for a request denied by d.
+func rateLimitedResponse(d ratelimit.Decision) *authz.CheckResponse_DeniedResponse {
+	h := d.Headers()
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	headers := make([]*core.HeaderValueOption, len(keys))
+	for i, k := range keys {
+		headers[i] = &core.HeaderValueOption{Header: &core.HeaderValue{Key: k, Value: h[k]}}
+	}
+	return &authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{
+		Status:  &_type.HttpStatus{Code: _type.StatusCode_TooManyRequests},
+		Headers: headers,
+	}}
+}
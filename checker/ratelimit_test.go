@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/ratelimit"
+)
+
+func rateLimitRequest(source, path string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source:      &authz.AttributeContext_Peer{Principal: source},
+		Destination: &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/default/sa/api"},
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: "GET", Path: path},
+		},
+	}}
+}
+
+func TestCheckRateLimitAnnotation(t *testing.T) {
+	RegisterTestingT(t)
+
+	l := ratelimit.NewLimiter(nil)
+	now := time.Unix(1000, 0)
+	rule := &proto.Rule{
+		Action:   "Allow",
+		RuleId:   "r1",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{RateLimitAnnotation: "1/m"}},
+	}
+	alice := rateLimitRequest("spiffe://cluster.local/ns/default/sa/alice", "/")
+
+	st, denied := checkRateLimit(l, rule, alice, now)
+	Expect(st.Code).To(Equal(OK))
+	Expect(denied).To(BeNil())
+
+	st, denied = checkRateLimit(l, rule, alice, now)
+	Expect(st.Code).To(Equal(RESOURCE_EXHAUSTED))
+	Expect(denied.DeniedResponse.GetStatus().GetCode()).To(Equal(_type.StatusCode_TooManyRequests))
+	Expect(denied.DeniedResponse.GetHeaders()).To(Equal([]*core.HeaderValueOption{
+		{Header: &core.HeaderValue{Key: "RateLimit-Limit", Value: "1"}},
+		{Header: &core.HeaderValue{Key: "RateLimit-Remaining", Value: "0"}},
+		{Header: &core.HeaderValue{Key: "RateLimit-Reset", Value: "60"}},
+		{Header: &core.HeaderValue{Key: "Retry-After", Value: "60"}},
+	}))
+
+	// Other sources have their own buckets.
+	st, _ = checkRateLimit(l, rule, rateLimitRequest("spiffe://cluster.local/ns/default/sa/bob", "/"), now)
+	Expect(st.Code).To(Equal(OK))
+
+	// Rules without the annotation are not limited.
+	st, _ = checkRateLimit(l, &proto.Rule{Action: "Allow"}, alice, now)
+	Expect(st.Code).To(Equal(OK))
+
+	// A malformed annotation denies the request.
+	rule.Metadata.Annotations[RateLimitAnnotation] = "lots"
+	st, denied = checkRateLimit(l, rule, alice, now)
+	Expect(st.Code).To(Equal(PERMISSION_DENIED))
+	Expect(denied).To(BeNil())
+}
+
+func TestCheckRateLimitConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	cfg, err := ratelimit.ParseConfig([]byte(`
+pathClasses:
+- name: login
+  prefixes: [/login]
+limits:
+- pathClass: login
+  rate: 1/m
+`))
+	Expect(err).ToNot(HaveOccurred())
+	l := ratelimit.NewLimiter(cfg)
+	now := time.Unix(1000, 0)
+	rule := &proto.Rule{Action: "Allow"}
+	alice := "spiffe://cluster.local/ns/default/sa/alice"
+
+	st, _ := checkRateLimit(l, rule, rateLimitRequest(alice, "/login"), now)
+	Expect(st.Code).To(Equal(OK))
+	st, _ = checkRateLimit(l, rule, rateLimitRequest(alice, "/login?next=/"), now)
+	Expect(st.Code).To(Equal(RESOURCE_EXHAUSTED))
+	st, _ = checkRateLimit(l, rule, rateLimitRequest(alice, "/home"), now)
+	Expect(st.Code).To(Equal(OK))
+
+	// An annotated rule and the config both apply; the stricter wins.
+	rule.Metadata = &proto.RuleMetadata{Annotations: map[string]string{RateLimitAnnotation: "10/s"}}
+	st, _ = checkRateLimit(l, rule, rateLimitRequest(alice, "/login"), now)
+	Expect(st.Code).To(Equal(RESOURCE_EXHAUSTED))
+}
+
+// Rate limits only apply to requests that policy allows, and are reported as a 429.
+func TestCheckWithRateLimit(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stores := make(chan *policystore.PolicyStore)
+	uut := NewServer(ctx, stores, WithClock(FixedClock(time.Unix(1000, 0))))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{
+			Action:                 "Allow",
+			RuleId:                 "steve",
+			SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"steve"}},
+			Metadata:               &proto.RuleMetadata{Annotations: map[string]string{RateLimitAnnotation: "1/s"}},
+		}},
+	}
+	uut.Store = store
+
+	req := rateLimitRequest("spiffe://cluster.local/ns/default/sa/steve", "/")
+	resp, err := uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+
+	resp, err = uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(RESOURCE_EXHAUSTED))
+	Expect(resp.GetDeniedResponse().GetStatus().GetCode()).To(Equal(_type.StatusCode_TooManyRequests))
+
+	// Requests that policy denies never reach the rate limiter, and keep their normal response.
+	req.Attributes.Source.Principal = "spiffe://cluster.local/ns/default/sa/bob"
+	resp, err = uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(resp.GetHttpResponse()).To(BeNil())
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2018 Tigera, Inc. All rights reserved.
+// Copyright (c) 2018-2026 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,13 +15,22 @@
 package checker
 
 import (
+	"crypto/x509"
 	"fmt"
+	"net"
 	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/projectcalico/app-policy/bruteforce"
+	"github.com/projectcalico/app-policy/decisionsink"
+	"github.com/projectcalico/app-policy/evalbudget"
+	"github.com/projectcalico/app-policy/geoip"
+	"github.com/projectcalico/app-policy/hmacsig"
 	"github.com/projectcalico/app-policy/policystore"
 	"github.com/projectcalico/app-policy/proto"
 )
@@ -34,6 +43,267 @@ type requestCache struct {
 	destination          *peer
 	sourceNamespace      *namespace
 	destinationNamespace *namespace
+	clock                Clock
+	now                  time.Time
+	maxBodyBytes         int
+	body                 *requestBody
+	// grpc decodes the request messages of gRPC methods that rules match body fields of.
+	grpc GRPCDecoder
+	// ruleMatched, if set, is called with each rule whose action decides a policy or profile's verdict, and its index.
+	ruleMatched func(*proto.Rule, int)
+	// policyDecided, if set, is called with the name of the policy or profile that decides the request.
+	policyDecided func(string)
+	// stagedMatched, if set, is called with the verdict of each staged policy that matches the request.
+	stagedMatched func(decisionsink.StagedVerdict)
+	// uncacheable, if set, is called if the decision depends on more than decisionKey covers.
+	uncacheable func()
+	// anomalyScore is the request's anomaly score, if scored is true.
+	anomalyScore float64
+	scored       bool
+	// trustedHops is the number of proxies in front of Envoy trusted to report the client address.
+	trustedHops int
+	clientIP    net.IP
+	// geo looks up the location of the request's client.
+	geo      GeoIP
+	location *geoip.Location
+	// threatFeeds holds IP blocklists that rules can match the client against.
+	threatFeeds ThreatFeeds
+	// modes, if set, makes threat feeds detect-only.
+	modes EnforcementModes
+	// endpoint is the endpoint whose policy applies to the request, and outbound is true if the request leaves it, and
+	// so is checked against egress policy.
+	endpoint *proto.WorkloadEndpoint
+	outbound bool
+	// sharedEndpoints selects the endpoint from those that a node-level Dikastes serves.
+	sharedEndpoints bool
+	// domains resolves the domain names that rules match destinations against.
+	domains DomainResolver
+	// fingerprintHeader, if set, is the header that carries the client's TLS fingerprint.
+	fingerprintHeader string
+	fingerprint       *string
+	// certificate is the client's parsed certificate, or certificateErr why there isn't one.
+	certificate    *x509.Certificate
+	certificateErr error
+	// protocols are the protocols the request speaks, such as "websocket" for WebSocket upgrades.
+	protocols []string
+	// tokens, if set, validates the request's JWT, whose claims are cached with the error if it isn't valid.
+	tokens    TokenValidator
+	claims    map[string]interface{}
+	claimsErr error
+	// signatures, if set, verifies HMAC signatures of requests.
+	signatures *hmacsig.Verifier
+	// spiffe, if set, records peers whose principals aren't SPIFFE IDs.
+	spiffe *spiffeAudit
+	// logins counts login attempts by source.
+	logins        *bruteforce.Counter
+	loginAttempts *int
+	// intentions, if set, are Consul intentions to apply ahead of Calico policy.
+	intentions *proto.Policy
+	// attachments, if set, scopes policies to the routes they are attached to.
+	attachments PolicyAttachments
+	// istioPeerMetadata enriches the source peer with the workload metadata that Istio's metadata exchange reports.
+	istioPeerMetadata bool
+	// trustDomains, if set, places peers in the clusters their principals' trust domains belong to.
+	trustDomains TrustDomains
+	// serviceEndpoints, if set, finds the services of destination pods.
+	serviceEndpoints ServiceEndpoints
+	// dstServices are the services the request was sent to, once dstServicesFound is set.
+	dstServices      []policystore.ServiceID
+	dstServicesFound bool
+	// principalTemplates are tried before Istio's layout to parse peers' SPIFFE IDs.
+	principalTemplates principalTemplates
+	// budgets, if set, bound how long evaluating requests can take, and budget is the time this one has left.
+	budgets *evalbudget.Config
+	budget  *evaluationBudget
+}
+
+// requestOption configures optional behaviour of a requestCache.
+type requestOption func(*requestCache)
+
+// withClock sets the clock used to evaluate time-dependent rule conditions.
+func withClock(c Clock) requestOption {
+	return func(r *requestCache) {
+		r.clock = c
+	}
+}
+
+// withMaxBodyBytes limits how much of the request body is inspected.
+func withMaxBodyBytes(n int) requestOption {
+	return func(r *requestCache) {
+		r.maxBodyBytes = n
+	}
+}
+
+// withGRPCDecoder sets the decoder for gRPC request messages, so that rules can match their fields.
+func withGRPCDecoder(d GRPCDecoder) requestOption {
+	return func(r *requestCache) {
+		r.grpc = d
+	}
+}
+
+// withRuleObserver calls f with each rule that decides a policy or profile's verdict, and its index in the policy or
+// profile's rules for the request's direction. The last rule it is called with decides the request.
+func withRuleObserver(f func(*proto.Rule, int)) requestOption {
+	return func(r *requestCache) {
+		r.ruleMatched = f
+	}
+}
+
+// withCacheObserver calls f if the decision can't be shared under its decisionKey, because it depends on other
+// parts of the request or on state outside the policy store.
+func withCacheObserver(f func()) requestOption {
+	return func(r *requestCache) {
+		r.uncacheable = f
+	}
+}
+
+// withPolicyObserver calls f with the name of the policy or profile that decides the request, as "<tier>/<name>" or
+// "profile/<name>". It isn't called if the request falls through to a default deny.
+func withPolicyObserver(f func(string)) requestOption {
+	return func(r *requestCache) {
+		r.policyDecided = f
+	}
+}
+
+// withStagedObserver calls f with the verdict of each staged policy that matches the request, in the order they are
+// evaluated.
+func withStagedObserver(f func(decisionsink.StagedVerdict)) requestOption {
+	return func(r *requestCache) {
+		r.stagedMatched = f
+	}
+}
+
+// withAnomalyScore sets the request's anomaly score.
+func withAnomalyScore(score float64) requestOption {
+	return func(r *requestCache) {
+		r.anomalyScore = score
+		r.scored = true
+	}
+}
+
+// withTrustedHops sets the number of proxies in front of Envoy that are trusted to report the client's address in
+// X-Forwarded-For.
+func withTrustedHops(n int) requestOption {
+	return func(r *requestCache) {
+		r.trustedHops = n
+	}
+}
+
+// withGeoIP sets the database used to locate the request's client.
+func withGeoIP(geo GeoIP) requestOption {
+	return func(r *requestCache) {
+		r.geo = geo
+	}
+}
+
+// withThreatFeeds sets the IP blocklists that rules can match the client against.
+func withThreatFeeds(f ThreatFeeds) requestOption {
+	return func(r *requestCache) {
+		r.threatFeeds = f
+	}
+}
+
+// withEnforcementModes sets which threat feeds only detect, rather than match, clients.
+func withEnforcementModes(m EnforcementModes) requestOption {
+	return func(r *requestCache) {
+		r.modes = m
+	}
+}
+
+// withDomainResolver sets the resolver used to match destinations against domain names.
+func withDomainResolver(d DomainResolver) requestOption {
+	return func(r *requestCache) {
+		r.domains = d
+	}
+}
+
+// withTLSFingerprintHeader reads the client's TLS fingerprint from the given header, if it isn't in the metadata
+// context.
+func withTLSFingerprintHeader(h string) requestOption {
+	return func(r *requestCache) {
+		r.fingerprintHeader = strings.ToLower(h)
+	}
+}
+
+// withTokenValidator sets the validator of JWTs that Envoy hasn't validated, for rules to match their claims.
+func withTokenValidator(v TokenValidator) requestOption {
+	return func(r *requestCache) {
+		r.tokens = v
+	}
+}
+
+// withSignatureVerifier sets the verifier of requests' HMAC signatures, for rules that require them.
+func withSignatureVerifier(v *hmacsig.Verifier) requestOption {
+	return func(r *requestCache) {
+		r.signatures = v
+	}
+}
+
+// withSPIFFEAudit records peers whose principals aren't SPIFFE IDs in a.
+func withSPIFFEAudit(a *spiffeAudit) requestOption {
+	return func(r *requestCache) {
+		r.spiffe = a
+	}
+}
+
+// withLoginCounter sets the counter of login attempts that rules can match sources against.
+func withLoginCounter(c *bruteforce.Counter) requestOption {
+	return func(r *requestCache) {
+		r.logins = c
+	}
+}
+
+// withIstioPeerMetadata enriches the source peer with the workload name and labels from Istio's peer metadata
+// headers, when they agree with its principal.
+func withIstioPeerMetadata() requestOption {
+	return func(r *requestCache) {
+		r.istioPeerMetadata = true
+	}
+}
+
+// withTrustDomains places peers in the clusters that t maps their trust domains to, and maps their namespaces to this
+// cluster's.
+func withTrustDomains(t TrustDomains) requestOption {
+	return func(r *requestCache) {
+		r.trustDomains = t
+	}
+}
+
+// withServiceEndpoints finds the services of destination pods with e, when the request's original destination isn't
+// a synced service.
+func withServiceEndpoints(e ServiceEndpoints) requestOption {
+	return func(r *requestCache) {
+		r.serviceEndpoints = e
+	}
+}
+
+// withPrincipalTemplates parses peers' SPIFFE IDs with the templates, before Istio's layout.
+func withPrincipalTemplates(t principalTemplates) requestOption {
+	return func(r *requestCache) {
+		r.principalTemplates = t
+	}
+}
+
+// withIntentions applies Consul intentions, compiled into a policy, ahead of Calico policy.
+func withIntentions(p *proto.Policy) requestOption {
+	return func(r *requestCache) {
+		r.intentions = p
+	}
+}
+
+// withPolicyAttachments only applies the policies attached to the request's route, and those not attached to any.
+func withPolicyAttachments(a PolicyAttachments) requestOption {
+	return func(r *requestCache) {
+		r.attachments = a
+	}
+}
+
+// withSharedEndpoints selects the endpoint whose policy applies to the request from every endpoint in the store, as a
+// node-level Dikastes serving many workloads must.
+func withSharedEndpoints() requestOption {
+	return func(r *requestCache) {
+		r.sharedEndpoints = true
+	}
 }
 
 // peer is derived from the request Service Account and any label information we have about the account
@@ -42,6 +312,13 @@ type peer struct {
 	Name      string
 	Namespace string
 	Labels    map[string]string
+	// Pod and Workload name the peer's pod and the workload it belongs to, if Istio reports them.
+	Pod      string
+	Workload string
+	// Cluster is the cluster the peer's trust domain belongs to, if trust domains are configured.
+	Cluster string
+	// External is set for peers from external trust domains, which have no service account or namespace here.
+	External bool
 }
 
 type namespace struct {
@@ -52,11 +329,26 @@ type namespace struct {
 // SPIFFE_ID_PATTERN is a regular expression to match SPIFFE ID URIs, e.g. spiffe://cluster.local/ns/default/sa/foo
 const SPIFFE_ID_PATTERN = "^spiffe://[^/]+/ns/([^/]+)/sa/([^/]+)$"
 
+// LINKERD_IDENTITY_PATTERN is a regular expression to match the identities Linkerd issues to meshed pods, e.g.
+// foo.default.serviceaccount.identity.linkerd.cluster.local
+const LINKERD_IDENTITY_PATTERN = `^([^.]+)\.([^.]+)\.serviceaccount\.identity\.[^.]+\..+$`
+
 var spiffeIdRegExp *regexp.Regexp
+var linkerdIdentityRegExp *regexp.Regexp
 var spiffeIdRegExpOnce = sync.Once{}
 
-func NewRequestCache(store *policystore.PolicyStore, req *authz.CheckRequest) (*requestCache, error) {
-	r := &requestCache{Request: req, store: store}
+func NewRequestCache(store *policystore.PolicyStore, req *authz.CheckRequest, opts ...requestOption) (*requestCache, error) {
+	r := &requestCache{
+		Request:      req,
+		store:        store,
+		clock:        realClock{},
+		maxBodyBytes: DefaultMaxBodyBytes,
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	r.endpoint = requestEndpoint(store, req, r.sharedEndpoints)
+	r.outbound = isOutbound(r.endpoint, req)
 	err := r.initPeers()
 	if err != nil {
 		return nil, err
@@ -64,6 +356,121 @@ func NewRequestCache(store *policystore.PolicyStore, req *authz.CheckRequest) (*
 	return r, nil
 }
 
+// Now returns the time at which the request is evaluated. It is read from the clock once, so every rule in a check
+// sees the same time.
+func (r *requestCache) Now() time.Time {
+	if r.now.IsZero() {
+		r.now = r.clock.Now()
+	}
+	return r.now
+}
+
+// Body returns the request body, decoding it on first use.
+func (r *requestCache) Body() *requestBody {
+	if r.body == nil {
+		r.body = newRequestBody(r.Request.GetAttributes().GetRequest().GetHttp(), r.maxBodyBytes)
+		r.body.grpc = r.grpc
+	}
+	return r.body
+}
+
+// decidedBy reports the policy or profile that decides the request to the policy observer, if any.
+func (r *requestCache) decidedBy(name string) {
+	if r.policyDecided != nil {
+		r.policyDecided(name)
+	}
+}
+
+// notCacheable records that the decision can't be shared.
+func (r *requestCache) notCacheable() {
+	if r.uncacheable != nil {
+		r.uncacheable()
+	}
+}
+
+// DestinationServices returns the services the request was sent to, looking them up on first use.
+func (r *requestCache) DestinationServices() []policystore.ServiceID {
+	if !r.dstServicesFound {
+		r.dstServices = destinationServices(r.store, r.serviceEndpoints, r.Request)
+		r.dstServicesFound = true
+	}
+	return r.dstServices
+}
+
+// ClientIP returns the address of the request's original client, or nil if it has none.
+func (r *requestCache) ClientIP() net.IP {
+	if r.clientIP == nil {
+		r.clientIP = clientIP(r.Request, r.trustedHops)
+	}
+	return r.clientIP
+}
+
+// ClientLocation returns the location of the request's original client, looking it up on first use.
+func (r *requestCache) ClientLocation() (geoip.Location, error) {
+	if r.location == nil {
+		if r.geo == nil {
+			return geoip.Location{}, errNoGeoIP
+		}
+		ip := r.ClientIP()
+		if ip == nil {
+			return geoip.Location{}, errNoClientIP
+		}
+		loc := r.geo.Lookup(ip)
+		r.location = &loc
+	}
+	return *r.location, nil
+}
+
+// TLSFingerprint returns the fingerprint of the client's TLS handshake, or "" if there is none.
+func (r *requestCache) TLSFingerprint() string {
+	if r.fingerprint == nil {
+		fp := tlsFingerprint(r.Request, r.fingerprintHeader)
+		r.fingerprint = &fp
+	}
+	return *r.fingerprint
+}
+
+// PeerCertificate returns the client's certificate, parsing it on first use.
+func (r *requestCache) PeerCertificate() (*x509.Certificate, error) {
+	if r.certificate == nil && r.certificateErr == nil {
+		r.certificate, r.certificateErr = peerCertificate(r.Request.GetAttributes().GetSource())
+	}
+	return r.certificate, r.certificateErr
+}
+
+// Protocols returns the protocols the request speaks, as named by ProtocolsAnnotation.
+func (r *requestCache) Protocols() []string {
+	if r.protocols == nil {
+		r.protocols = requestProtocols(r.Request.GetAttributes().GetRequest().GetHttp())
+	}
+	return r.protocols
+}
+
+// Claims returns the claims of the request's JWT, validating it on first use.
+func (r *requestCache) Claims() (map[string]interface{}, error) {
+	if r.claims == nil && r.claimsErr == nil {
+		r.claims, r.claimsErr = requestClaims(r.Request, r.tokens, r.Now())
+	}
+	return r.claims, r.claimsErr
+}
+
+// LoginAttempts returns the number of recent login attempts by the request's sources.
+func (r *requestCache) LoginAttempts() (int, error) {
+	if r.loginAttempts == nil {
+		if r.logins == nil {
+			return 0, errNoLoginCounter
+		}
+		n := 0
+		for _, s := range loginSources(r.Request, r.trustedHops) {
+			if a := r.logins.Attempts(s, r.Now()); a > n {
+				n = a
+			}
+		}
+		r.loginAttempts = &n
+	}
+	return *r.loginAttempts, nil
+}
+
 // SourcePeer returns the cached source peer.
 func (r *requestCache) SourcePeer() peer {
 	return *r.source
@@ -94,12 +501,12 @@ func (r *requestCache) DestinationNamespace() namespace {
 
 // initPeers initializes the source and destination peers.
 func (r *requestCache) initPeers() error {
-	src, err := r.initPeer(r.Request.GetAttributes().GetSource())
+	src, err := r.initPeer("source", r.Request.GetAttributes().GetSource())
 	if err != nil {
 		return err
 	}
 	r.source = src
-	dst, err := r.initPeer(r.Request.GetAttributes().GetDestination())
+	dst, err := r.initPeer("destination", r.Request.GetAttributes().GetDestination())
 	if err != nil {
 		return err
 	}
@@ -107,9 +514,12 @@ func (r *requestCache) initPeers() error {
 	return nil
 }
 
-func (r *requestCache) initPeer(aPeer *authz.AttributeContext_Peer) (*peer, error) {
-	peer, err := parseSpiffeID(aPeer.GetPrincipal())
+func (r *requestCache) initPeer(name string, aPeer *authz.AttributeContext_Peer) (*peer, error) {
+	peer, err := r.principalTemplates.parse(aPeer.GetPrincipal())
 	if err != nil {
+		if r.spiffe != nil {
+			r.spiffe.record(name, aPeer.GetPrincipal(), r.Now())
+		}
 		return nil, err
 	}
 	// Copy any labels from the request.
@@ -117,6 +527,18 @@ func (r *requestCache) initPeer(aPeer *authz.AttributeContext_Peer) (*peer, erro
 	for k, v := range aPeer.GetLabels() {
 		peer.Labels[k] = v
 	}
+	if name == "source" && r.istioPeerMetadata {
+		if p := r.istioSourcePeer(peer); p != nil {
+			peer.Pod = p.Name
+			peer.Workload = p.Workload
+			for k, v := range p.Labels {
+				peer.Labels[k] = v
+			}
+		}
+	}
+	if r.trustDomains != nil {
+		mapTrustDomain(r.trustDomains, aPeer.GetPrincipal(), &peer)
+	}
 
 	// If the service account is in the store, copy labels over.
 	id := proto.ServiceAccountID{Name: peer.Name, Namespace: peer.Namespace}
@@ -152,7 +574,7 @@ func (r *requestCache) GetIPSet(ipset string) policystore.IPSet {
 	return s
 }
 
-// parseSpiffeId parses an Istio SPIFFE ID and extracts the service account name and namespace.
+// parseSpiffeId parses an Istio SPIFFE ID, or a Linkerd identity, and extracts the service account name and namespace.
 func parseSpiffeID(id string) (peer peer, err error) {
 	if id == "" {
 		log.Debug("empty spiffe/plain text request.")
@@ -162,13 +584,16 @@ func parseSpiffeID(id string) (peer peer, err error) {
 	// Init the regexp the first time this is called, and store it in the package namespace.
 	spiffeIdRegExpOnce.Do(func() {
 		spiffeIdRegExp, _ = regexp.Compile(SPIFFE_ID_PATTERN)
+		linkerdIdentityRegExp, _ = regexp.Compile(LINKERD_IDENTITY_PATTERN)
 	})
-	match := spiffeIdRegExp.FindStringSubmatch(id)
-	if match == nil {
-		err = fmt.Errorf("expected match %s, got %s", SPIFFE_ID_PATTERN, id)
-	} else {
+	if match := spiffeIdRegExp.FindStringSubmatch(id); match != nil {
 		peer.Name = match[2]
 		peer.Namespace = match[1]
+	} else if match := linkerdIdentityRegExp.FindStringSubmatch(id); match != nil {
+		peer.Name = match[1]
+		peer.Namespace = match[2]
+	} else {
+		err = fmt.Errorf("expected match %s or %s, got %s", SPIFFE_ID_PATTERN, LINKERD_IDENTITY_PATTERN, id)
 	}
 	return
 }
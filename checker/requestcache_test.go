@@ -45,6 +45,21 @@ func TestParseSpiffeIdFail(t *testing.T) {
 	Expect(err).ToNot(BeNil())
 }
 
+// Linkerd identities name the service account before its namespace.
+func TestParseLinkerdIdentity(t *testing.T) {
+	RegisterTestingT(t)
+
+	peer, err := parseSpiffeID("bacon.sandwich.serviceaccount.identity.linkerd.cluster.local")
+	Expect(err).To(BeNil())
+	Expect(peer.Name).To(Equal("bacon"))
+	Expect(peer.Namespace).To(Equal("sandwich"))
+
+	_, err = parseSpiffeID("bacon.sandwich.svc.cluster.local")
+	Expect(err).ToNot(BeNil())
+	_, err = parseSpiffeID("sandwich.serviceaccount.identity.linkerd.cluster.local")
+	Expect(err).ToNot(BeNil())
+}
+
 func TestInitSourceBadSpiffe(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,30 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+
+	"github.com/projectcalico/app-policy/statscache"
+)
+
+// requestStats counts a request against the connection it arrived on, by its direction and whether it was denied.
+func requestStats(req *authz.CheckRequest, outbound, denied bool) statscache.DPStats {
+	outcome := statscache.RequestOutcome{Outbound: outbound, Denied: denied}
+	return statscache.DPStats{
+		Tuple:  statsTuple(req),
+		Values: statscache.Values{HTTPRequests: map[statscache.RequestOutcome]int64{outcome: 1}},
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/statscache"
+)
+
+func TestCheckRequestStats(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tuple := statscache.Tuple{SrcIp: "10.0.0.1", DstIp: "10.0.0.2", SrcPort: 40000, DstPort: 8080, Protocol: "TCP"}
+	outcomes := func(stats []statscache.DPStats) []statscache.RequestOutcome {
+		var got []statscache.RequestOutcome
+		for _, d := range stats {
+			Expect(d.Tuple).To(Equal(tuple))
+			for o, n := range d.Values.HTTPRequests {
+				Expect(n).To(BeEquivalentTo(1))
+				got = append(got, o)
+			}
+		}
+		return got
+	}
+
+	stats := &fakeStatsReporter{}
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithRequestStats(stats))
+	uut.Store = policyStatusStore()
+	for _, method := range []string{"GET", "DELETE"} {
+		_, err := uut.Check(ctx, policyStatusRequest(method))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(outcomes(stats.stats)).To(Equal([]statscache.RequestOutcome{{Denied: false}, {Denied: true}}))
+
+	// Requests that policy would deny in dry-run are allowed.
+	stats = &fakeStatsReporter{}
+	uut = NewServer(ctx, make(chan *policystore.PolicyStore), WithRequestStats(stats), WithDryRun(true))
+	uut.Store = policyStatusStore()
+	_, err := uut.Check(ctx, policyStatusRequest("DELETE"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(outcomes(stats.stats)).To(Equal([]statscache.RequestOutcome{{Denied: false}}))
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2018 Tigera, Inc. All rights reserved.
+// Copyright (c) 2018-2026 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -15,7 +15,24 @@
 package checker
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/projectcalico/app-policy/anomaly"
+	"github.com/projectcalico/app-policy/bruteforce"
+	"github.com/projectcalico/app-policy/concurrency"
+	"github.com/projectcalico/app-policy/decisionsink"
+	"github.com/projectcalico/app-policy/denybody"
+	"github.com/projectcalico/app-policy/dlp"
+	"github.com/projectcalico/app-policy/evalbudget"
+	"github.com/projectcalico/app-policy/hmacsig"
+	"github.com/projectcalico/app-policy/learn"
 	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/ratelimit"
+	"github.com/projectcalico/app-policy/waf"
+	"github.com/projectcalico/libcalico-go/lib/selector"
 
 	core_v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -31,11 +48,493 @@ import (
 type authServer struct {
 	stores <-chan *policystore.PolicyStore
 	Store  *policystore.PolicyStore
+
+	// fallback is the status code returned while there is no in-sync PolicyStore.
+	fallback int32
+	// unsyncedGrace is how long a PolicyStore is enforced after it stops being synced, before the fallback verdict is
+	// returned instead. Negative means indefinitely.
+	unsyncedGrace time.Duration
+	// dryRun evaluates policy but always allows the request.
+	dryRun bool
+	// enforcedNamespaces, if set, restricts enforcement to workloads in namespaces whose labels it matches. Requests
+	// for workloads in other namespaces get dry-run behaviour.
+	enforcedNamespaces selector.Selector
+	// enforcePercent is the percentage of clients, by identity, whose requests are enforced. The rest get dry-run
+	// behaviour.
+	enforcePercent uint32
+	// clock supplies the time for rules restricted to time windows.
+	clock Clock
+	// wafRulesets inspect requests that policy allows.
+	wafRulesets []wafRuleset
+	// modes, if set, makes WAF rulesets and threat feeds detect-only.
+	modes EnforcementModes
+	// maxBodyBytes limits how much of a request body rules and the WAF inspect.
+	maxBodyBytes int
+	// grpc, if set, decodes gRPC request messages for rules to match fields of.
+	grpc GRPCDecoder
+	// stats, if set, receives WAF rule hits for reporting to Felix.
+	stats StatsReporter
+	// rateLimiter applies the configured rate limits and those annotated on rules.
+	rateLimiter *ratelimit.Limiter
+	// scorer, if set, scores every request for rules to match on.
+	scorer anomaly.Scorer
+	// anomalyLogThreshold is the score at and above which requests are logged as anomalous.
+	anomalyLogThreshold float64
+	// trustedHops is the number of proxies in front of Envoy trusted to report the client address.
+	trustedHops int
+	// geo, if set, locates clients for rules that match on country or ASN.
+	geo GeoIP
+	// threatFeeds, if set, holds IP blocklists that rules can match clients against.
+	threatFeeds ThreatFeeds
+	// domains, if set, resolves the domain names that egress rules match destinations against.
+	domains DomainResolver
+	// fingerprintHeader, if set, is the header Envoy puts the client's TLS fingerprint in.
+	fingerprintHeader string
+	// tokens, if set, validates JWTs for rules that match their claims.
+	tokens TokenValidator
+	// requireMTLS denies inbound requests that didn't arrive over mTLS.
+	requireMTLS bool
+	// certWarning is how soon before a client certificate expires that requests using it are logged.
+	certWarning time.Duration
+	// denyExpiredCerts denies requests with expired client certificates.
+	denyExpiredCerts bool
+	// strictHeaders denies requests with ambiguous headers, rather than evaluating them in canonical form.
+	strictHeaders bool
+	// logins, if set, counts attempts on login endpoints for rules to match sources against.
+	logins *bruteforce.Counter
+	// concurrency tracks in-flight requests for rules that cap them.
+	concurrency *concurrency.Tracker
+	// responsePatterns, if set, holds the sensitive patterns that rules can block responses for.
+	responsePatterns *dlp.Scanner
+	// responses holds the inspections awaiting responses from Envoy's ext_proc filter.
+	responses *pendingResponses
+	// signatures, if set, verifies HMAC signatures for rules that require them.
+	signatures *hmacsig.Verifier
+	// denyTemplates, if set, renders the bodies of responses to requests that policy denies.
+	denyTemplates *denybody.Renderer
+	// learning, if set, records requests to suggest policy from.
+	learning *learn.Recorder
+	// spiffe counts and keeps the malformed principals of requests' peers.
+	spiffe *spiffeAudit
+	// intentions, if set, supplies Consul intentions to apply ahead of Calico policy.
+	intentions IntentionSource
+	// sharedEndpoints serves every workload on the node, selecting each request's endpoint by its address.
+	sharedEndpoints bool
+	// attachments, if set, scopes policies to the Gateway API routes they are attached to.
+	attachments PolicyAttachments
+	// istioPeerMetadata enriches sources with the workload labels in Istio's peer metadata headers.
+	istioPeerMetadata bool
+	// upstream, if set, decides the requests that rules delegate with DelegateAnnotation.
+	upstream *upstreamPDP
+	// decisions, if set, shares the verdicts of policy with other replicas.
+	decisions *sharedDecisions
+	// denials, if set, is told about the inbound requests that policy denies.
+	denials DenialRecorder
+	// policyStatus, if set, receives a hit for each request a Calico policy decides.
+	policyStatus StatsReporter
+	// requestStats, if set, counts the requests allowed and denied on each connection.
+	requestStats StatsReporter
+	// trustDomains, if set, places peers in the clusters of a multi-cluster mesh.
+	trustDomains TrustDomains
+	// serviceEndpoints, if set, finds the services of destination pods that Felix's services don't.
+	serviceEndpoints ServiceEndpoints
+	// limits, if set, bounds the size of the requests that are evaluated.
+	limits *RequestLimits
+	// principalTemplates are the layouts of SPIFFE IDs, other than Istio's, that peers' principals are parsed with.
+	principalTemplates principalTemplates
+	// budgets, if set, bounds how long policy evaluation can take for requests to each destination.
+	budgets *evalbudget.Config
+	// publishers are told about every decision.
+	publishers []DecisionPublisher
+}
+
+// ServerOption configures optional behaviour of the authServer.
+type ServerOption func(*authServer)
+
+// WithFallbackVerdict sets the status code returned for checks made before the server has an in-sync PolicyStore.
+// The default is UNAVAILABLE, which leaves the decision to Envoy's failure_mode_allow setting.
+func WithFallbackVerdict(code int32) ServerOption {
+	return func(s *authServer) {
+		s.fallback = code
+	}
+}
+
+// DefaultUnsyncedGracePeriod is how long the last synced policy is enforced after losing the Policy Sync stream, while
+// the sync client retries, before the fallback verdict is returned instead.
+const DefaultUnsyncedGracePeriod = 30 * time.Second
+
+// WithUnsyncedGracePeriod returns the fallback verdict once the Policy Sync stream has been lost for d, rather than
+// after DefaultUnsyncedGracePeriod. If d is negative, the last synced policy is enforced however stale it gets.
+func WithUnsyncedGracePeriod(d time.Duration) ServerOption {
+	return func(s *authServer) {
+		s.unsyncedGrace = d
+	}
+}
+
+// WithDryRun makes the server allow every request, while logging the verdict it would otherwise have returned.
+func WithDryRun(dryRun bool) ServerOption {
+	return func(s *authServer) {
+		s.dryRun = dryRun
+	}
+}
+
+// WithEnforcedNamespaces limits enforcement to requests for workloads whose namespace labels match sel. Requests for
+// workloads in any other namespace are handled as if in dry-run mode.
+func WithEnforcedNamespaces(sel selector.Selector) ServerOption {
+	return func(s *authServer) {
+		s.enforcedNamespaces = sel
+	}
+}
+
+// WithEnforcePercent limits enforcement to the given percentage of clients. Clients are selected by a consistent hash
+// of their identity, so a given client is either always or never enforced for a fixed percentage.
+func WithEnforcePercent(percent uint32) ServerOption {
+	return func(s *authServer) {
+		s.enforcePercent = percent
+	}
+}
+
+// WithClock sets the clock used to evaluate rules restricted to time windows. The default is the system clock.
+func WithClock(c Clock) ServerOption {
+	return func(s *authServer) {
+		s.clock = c
+	}
+}
+
+// WithWAF runs requests that policy allows through the given WAF engine, as the DefaultWAFRuleset ruleset, denying
+// those it blocks.
+func WithWAF(e waf.Engine) ServerOption {
+	return WithWAFRuleset(DefaultWAFRuleset, e)
+}
+
+// WithWAFRuleset runs requests that policy allows through the given WAF engine as the named ruleset, denying those it
+// blocks unless the ruleset is detect-only. Every ruleset inspects every allowed HTTP request; connections from the
+// network ext_authz filter have no HTTP request to inspect.
+func WithWAFRuleset(name string, e waf.Engine) ServerOption {
+	return func(s *authServer) {
+		s.wafRulesets = append(s.wafRulesets, wafRuleset{name: name, engine: e})
+	}
+}
+
+// WithEnforcementModes sets which WAF rulesets and threat feeds only detect requests. The modes are read on every
+// check, so they can change at runtime. By default, everything blocks.
+func WithEnforcementModes(m EnforcementModes) ServerOption {
+	return func(s *authServer) {
+		s.modes = m
+	}
+}
+
+// WithMaxBodyBytes limits how much of a request body is inspected. Longer bodies are truncated, and rules that need
+// to decode a truncated body fail safe. Zero means no limit.
+func WithMaxBodyBytes(n int) ServerOption {
+	return func(s *authServer) {
+		s.maxBodyBytes = n
+	}
+}
+
+// WithRequestLimits rejects, or trims, requests whose headers or bodies exceed the limits before they are evaluated.
+func WithRequestLimits(l RequestLimits) ServerOption {
+	return func(s *authServer) {
+		s.limits = &l
+	}
+}
+
+// WithStatsReporter sends WAF rule hits to r, keyed by the connection the request arrived on.
+func WithStatsReporter(r StatsReporter) ServerOption {
+	return func(s *authServer) {
+		s.stats = r
+	}
+}
+
+// WithRateLimits applies the limits in config to requests that policy allows. Rate limits annotated on rules apply
+// whether or not this option is set.
+func WithRateLimits(config *ratelimit.Config) ServerOption {
+	return func(s *authServer) {
+		s.rateLimiter = ratelimit.NewLimiter(config)
+	}
+}
+
+// WithAnomalyScorer scores every request with s. Rules can match on the score with MinAnomalyScoreAnnotation, and
+// requests scoring at least logThreshold are logged.
+func WithAnomalyScorer(s anomaly.Scorer, logThreshold float64) ServerOption {
+	return func(as *authServer) {
+		as.scorer = s
+		as.anomalyLogThreshold = logThreshold
+	}
+}
+
+// WithTrustedHops sets the number of proxies in front of Envoy that are trusted to append the address of the original
+// client to X-Forwarded-For, for rules that match on it. The default is zero.
+func WithTrustedHops(n int) ServerOption {
+	return func(s *authServer) {
+		s.trustedHops = n
+	}
+}
+
+// WithGeoIP lets rules match on the country and ASN of the original client, looked up in geo.
+func WithGeoIP(geo GeoIP) ServerOption {
+	return func(s *authServer) {
+		s.geo = geo
+	}
+}
+
+// WithThreatFeeds lets rules match the original client against the IP blocklists in f.
+func WithThreatFeeds(f ThreatFeeds) ServerOption {
+	return func(s *authServer) {
+		s.threatFeeds = f
+	}
+}
+
+// WithDomainResolver lets rules match destinations against domain names, resolved with d.
+func WithDomainResolver(d DomainResolver) ServerOption {
+	return func(s *authServer) {
+		s.domains = d
+	}
+}
+
+// WithTLSFingerprintHeader reads the client's TLS fingerprint from the given request header when it isn't in the
+// metadata context. Envoy must overwrite the header, or clients can choose their own fingerprint.
+func WithTLSFingerprintHeader(h string) ServerOption {
+	return func(s *authServer) {
+		s.fingerprintHeader = h
+	}
+}
+
+// WithIstioPeerMetadata matches rules' source selectors against the workload labels that Istio's metadata exchange
+// reports in the x-envoy-peer-metadata header, as well as the service account's. The metadata is ignored unless it
+// agrees with the source's principal.
+func WithIstioPeerMetadata(enabled bool) ServerOption {
+	return func(s *authServer) {
+		s.istioPeerMetadata = enabled
+	}
+}
+
+// WithConsulIntentions applies the Consul intentions that src supplies to inbound requests ahead of Calico policy.
+// Requests that no intention allows or denies are decided by Calico policy.
+func WithConsulIntentions(src IntentionSource) ServerOption {
+	return func(s *authServer) {
+		s.intentions = src
+	}
+}
+
+// WithSharedEndpoints serves every workload on the node from a single Dikastes, rather than one per pod. Each request
+// is checked against the policy of the endpoint it is to or, for egress, from, selected by address from the
+// endpoints that the Policy Sync API sends.
+func WithSharedEndpoints(shared bool) ServerOption {
+	return func(s *authServer) {
+		s.sharedEndpoints = shared
+	}
+}
+
+// WithPolicyAttachments only applies policies attached to Gateway API routes to requests on those routes, which a
+// gateway identifies with the ContextExtensionRoute context extension. Policies not attached to any route apply to
+// every request.
+func WithPolicyAttachments(a PolicyAttachments) ServerOption {
+	return func(s *authServer) {
+		s.attachments = a
+	}
+}
+
+// WithJWTValidation validates the JWTs of requests with v for rules with ClaimsAnnotation, when Envoy's jwt_authn
+// filter hasn't already validated them.
+func WithJWTValidation(v TokenValidator) ServerOption {
+	return func(s *authServer) {
+		s.tokens = v
+	}
+}
+
+// WithHMACSignatures verifies the HMAC signatures that rules with HMACSignatureAnnotation require with v.
+func WithHMACSignatures(v *hmacsig.Verifier) ServerOption {
+	return func(s *authServer) {
+		s.signatures = v
+	}
+}
+
+// WithDenyTemplates renders the responses to requests that policy denies with r, so that clients get an explanation.
+// Requests denied for other reasons, such as rate limits or the WAF, keep their usual responses.
+func WithDenyTemplates(r *denybody.Renderer) ServerOption {
+	return func(s *authServer) {
+		s.denyTemplates = r
+	}
+}
+
+// WithLearning records the peers, methods and paths of requests in r, so that it can suggest policies that would allow
+// them. Requests are recorded whatever the verdict, so learning is usually combined with WithDryRun.
+func WithLearning(r *learn.Recorder) ServerOption {
+	return func(s *authServer) {
+		s.learning = r
+	}
+}
+
+// WithStrictHeaders denies requests whose headers are ambiguous, such as those with both Content-Length and
+// Transfer-Encoding, with a 400 response. Otherwise they are evaluated with their headers in canonical form.
+func WithStrictHeaders(strict bool) ServerOption {
+	return func(s *authServer) {
+		s.strictHeaders = strict
+	}
+}
+
+// WithRequireMTLS denies inbound requests that policy allows unless they arrived over mTLS, as if every allow rule had
+// RequireMTLSAnnotation. Requests leaving the workload are only checked by rules with the annotation.
+func WithRequireMTLS(required bool) ServerOption {
+	return func(s *authServer) {
+		s.requireMTLS = required
+	}
+}
+
+// WithCertExpiry logs and counts requests whose client certificates expire within warnWithin, if it is positive, or
+// have expired, and denies those with expired certificates if denyExpired is set. Envoy only sends certificates if
+// its ext_authz filter sets include_peer_certificate.
+func WithCertExpiry(warnWithin time.Duration, denyExpired bool) ServerOption {
+	return func(s *authServer) {
+		s.certWarning = warnWithin
+		s.denyExpiredCerts = denyExpired
+	}
+}
+
+// WithBruteForceDetection counts attempts on the login endpoints in config, so that rules can match sources that
+// have made too many with MinLoginAttemptsAnnotation.
+func WithBruteForceDetection(config *bruteforce.Config) ServerOption {
+	return func(s *authServer) {
+		s.logins = bruteforce.NewCounter(config)
+	}
+}
+
+// WithConcurrencyLease sets how long a request counts towards concurrency caps if Envoy's access log never reports it
+// complete. The default is concurrency.DefaultLease.
+func WithConcurrencyLease(d time.Duration) ServerOption {
+	return func(s *authServer) {
+		s.concurrency = concurrency.NewTracker(d)
+	}
+}
+
+// WithResponsePatterns lets rules block responses that contain the sensitive patterns in s, using
+// BlockResponsePatternsAnnotation.
+func WithResponsePatterns(s *dlp.Scanner) ServerOption {
+	return func(as *authServer) {
+		as.responsePatterns = s
+	}
+}
+
+// WithGRPCInspection lets rules match fields of the request messages that d decodes with BodyFieldsAnnotation. Envoy
+// must send request bodies, with its with_request_body option.
+func WithGRPCInspection(d GRPCDecoder) ServerOption {
+	return func(s *authServer) {
+		s.grpc = d
+	}
+}
+
+// WithUpstreamPDP has client, another ext_authz server, decide the requests that rules delegate to it with
+// DelegateAnnotation. If it fails or takes longer than timeout, the fallback verdict applies.
+func WithUpstreamPDP(client authz.AuthorizationClient, timeout time.Duration, fallback int32) ServerOption {
+	return func(s *authServer) {
+		s.upstream = &upstreamPDP{client: client, timeout: timeout, fallback: fallback}
+	}
+}
+
+// WithDecisionCache shares the verdicts of policy with the other replicas of a node-level Dikastes through c, for
+// ttl. Only verdicts reached by rules without annotations are shared, and changes to policy invalidate them.
+func WithDecisionCache(c DecisionCache, ttl time.Duration) ServerOption {
+	return func(s *authServer) {
+		s.decisions = &sharedDecisions{cache: c, ttl: ttl}
+	}
+}
+
+// WithDenialRecorder tells r about the inbound requests that policy denies, when the verdict is enforced.
+func WithDenialRecorder(r DenialRecorder) ServerOption {
+	return func(s *authServer) {
+		s.denials = r
+	}
+}
+
+// WithPolicyStatus sends r a hit for the policy that decided each request, noting whether its verdict was enforced, so
+// that the policy's status can be reported to the datastore.
+func WithPolicyStatus(r StatsReporter) ServerOption {
+	return func(s *authServer) {
+		s.policyStatus = r
+	}
+}
+
+// WithRequestStats sends r a count of each request allowed or denied, keyed by the connection the request arrived on,
+// so that Felix can report ALP decisions in its flow logs. Requests allowed in dry-run count as allowed.
+func WithRequestStats(r StatsReporter) ServerOption {
+	return func(s *authServer) {
+		s.requestStats = r
+	}
+}
+
+// WithTrustDomains maps the trust domains of peers' principals to the clusters of a multi-cluster mesh, and their
+// namespaces to this cluster's, so that rules can match the source's cluster with SrcClustersAnnotation.
+func WithTrustDomains(t TrustDomains) ServerOption {
+	return func(s *authServer) {
+		s.trustDomains = t
+	}
+}
+
+// WithServiceEndpoints finds the services that requests were sent to from the pods that back them, when the services
+// Felix syncs don't have the request's original destination, so that DstServicesAnnotation works without them.
+func WithServiceEndpoints(e ServiceEndpoints) ServerOption {
+	return func(s *authServer) {
+		s.serviceEndpoints = e
+	}
+}
+
+// WithPrincipalTemplates parses peers' SPIFFE IDs with the templates, in order, before Istio's layout, so that the
+// namespaces and service accounts of peers with IDs from other issuers are known.
+func WithPrincipalTemplates(templates ...*PrincipalTemplate) ServerOption {
+	return func(s *authServer) {
+		s.principalTemplates = templates
+	}
+}
+
+// WithEvaluationBudgets bounds how long evaluating policy can take for requests to each destination service. Requests
+// whose evaluation runs over their budget get the budget's verdict instead of policy's.
+func WithEvaluationBudgets(c *evalbudget.Config) ServerOption {
+	return func(s *authServer) {
+		s.budgets = c
+	}
+}
+
+// WithDecisionPublisher tells p about every decision, including those only logged because they aren't enforced. It
+// can be given more than once.
+func WithDecisionPublisher(p DecisionPublisher) ServerOption {
+	return func(s *authServer) {
+		s.publishers = append(s.publishers, p)
+	}
+}
+
+// ParseVerdict converts a verdict name ("allow", "deny", "unavailable" or "internal-error") into a status code.
+func ParseVerdict(v string) (int32, error) {
+	switch strings.ToLower(v) {
+	case "allow":
+		return OK, nil
+	case "deny":
+		return PERMISSION_DENIED, nil
+	case "unavailable":
+		return UNAVAILABLE, nil
+	case "internal-error":
+		return INTERNAL, nil
+	}
+	return 0, fmt.Errorf("unknown verdict %q", v)
 }
 
 // NewServer creates a new authServer and returns a pointer to it.
-func NewServer(ctx context.Context, stores <-chan *policystore.PolicyStore) *authServer {
-	s := &authServer{stores, nil}
+func NewServer(ctx context.Context, stores <-chan *policystore.PolicyStore, opts ...ServerOption) *authServer {
+	s := &authServer{
+		stores:         stores,
+		fallback:       UNAVAILABLE,
+		unsyncedGrace:  DefaultUnsyncedGracePeriod,
+		enforcePercent: 100,
+		clock:          realClock{},
+		maxBodyBytes:   DefaultMaxBodyBytes,
+		rateLimiter:    ratelimit.NewLimiter(nil),
+		concurrency:    concurrency.NewTracker(concurrency.DefaultLease),
+		responses:      newPendingResponses(),
+		spiffe:         newSPIFFEAudit(),
+	}
+	for _, o := range opts {
+		o(s)
+	}
 	go s.updateStores(ctx)
 	return s
 }
@@ -50,20 +549,184 @@ func (as *authServer) Check(ctx context.Context, req *authz.CheckRequest) (*auth
 		"Req.Source":      req.GetAttributes().GetSource(),
 		"Req.Destination": req.GetAttributes().GetDestination(),
 	}).Debug("Check start")
+	start := as.clock.Now()
 	resp := authz.CheckResponse{Status: &status.Status{Code: INTERNAL}}
 	var st status.Status
+	oversized := ""
+	if as.limits != nil {
+		oversized = as.limits.enforce(req)
+	}
+	ambiguities := canonicalizeHeaders(req)
+	if len(ambiguities) > 0 {
+		log.WithFields(log.Fields{
+			"Req.Path":    req.GetAttributes().GetRequest().GetHttp().GetPath(),
+			"Req.Source":  req.GetAttributes().GetSource(),
+			"ambiguities": ambiguities,
+		}).Info("Request has ambiguous headers")
+	}
+	if as.learning != nil {
+		recordObservation(as.learning, as.principalTemplates, req, as.clock.Now())
+	}
 
 	// Ensure that we only access as.Store once per Check call. The authServer can be updated to point to a different
 	// store asynchronously with this call, so we use a local variable to reference the PolicyStore for the duration of
 	// this call for consistency.
 	store := as.Store
-	if store == nil {
-		log.Warn("Check request before synchronized to Policy, failing.")
-		resp.Status.Code = UNAVAILABLE
-		return &resp, nil
+	enforce := !as.dryRun && as.enforcedNamespaces == nil
+	// rule is the rule that decided the request, if any, and policy the policy or profile it is in.
+	var rule *proto.Rule
+	var policy string
+	// ruleIndex is the index of rule in its policy's rules, or -1 if it isn't known.
+	ruleIndex := -1
+	// staged are the verdicts of the staged policies that matched the request.
+	var staged []decisionsink.StagedVerdict
+	// ns and pod are the destination pod of an inbound request that policy denied, when denials are recorded.
+	var ns, pod string
+	// unresolved is true if the request was to be checked against an endpoint on the node, but matched none.
+	unresolved := false
+	// outbound is true if the request was checked against its source endpoint's egress policy.
+	outbound := false
+	if oversized != "" && !as.limits.Trim {
+		resp.Status = &status.Status{Code: RESOURCE_EXHAUSTED, Message: "request exceeds " + oversized + " limit"}
+		resp.HttpResponse = tooLargeResponse(oversized)
+	} else if store == nil {
+		log.WithField("code", as.fallback).Warn("Check request before synchronized to Policy, returning fallback verdict.")
+		resp.Status.Code = as.fallback
+	} else if lost := as.syncLost(store); lost > 0 {
+		log.WithFields(log.Fields{"code": as.fallback, "unsynced": lost}).Warn(
+			"Check request after losing Policy Sync for longer than the grace period, returning fallback verdict.")
+		resp.Status.Code = as.fallback
+	} else if as.strictHeaders && len(ambiguities) > 0 {
+		resp.Status = &status.Status{Code: INVALID_ARGUMENT, Message: "ambiguous request headers"}
+		resp.HttpResponse = badRequestResponse()
+	} else {
+		opts := append(as.requestOptions(),
+			withRuleObserver(func(r *proto.Rule, i int) { rule, ruleIndex = r, i }),
+			withPolicyObserver(func(p string) { policy = p }),
+			withStagedObserver(func(v decisionsink.StagedVerdict) { staged = append(staged, v) }),
+			withSPIFFEAudit(as.spiffe),
+		)
+		if as.logins != nil {
+			recordLogin(as.logins, req, as.trustedHops, as.clock.Now())
+		}
+		if as.scorer != nil {
+			opts = append(opts, withAnomalyScore(scoreRequest(as.scorer, req, as.clock.Now(), as.anomalyLogThreshold)))
+		}
+		certStatus := checkCertExpiry(as.certWarning, as.denyExpiredCerts, req, as.clock.Now())
+		// Look up shared decisions without holding the store's lock, so that a slow cache doesn't hold up syncing.
+		var key string
+		var shared *cachedDecision
+		cacheable := as.decisions != nil
+		if cacheable {
+			store.Read(func(ps *policystore.PolicyStore) { key = decisionKey(ps.Generation, req) })
+			shared = as.decisions.get(key, as.clock.Now())
+			opts = append(opts, withCacheObserver(func() { cacheable = false }))
+		}
+		store.Read(func(ps *policystore.PolicyStore) {
+			if shared != nil {
+				st = status.Status{Code: shared.Code}
+				policy, rule = shared.Policy, shared.rule()
+				cacheable = false
+			} else {
+				if cacheable {
+					key = decisionKey(ps.Generation, req)
+				}
+				st = checkStore(ps, req, opts...)
+			}
+			ep := requestEndpoint(ps, req, as.sharedEndpoints)
+			unresolved = as.sharedEndpoints && ep == nil
+			outbound = isOutbound(ep, req)
+			if as.denials != nil && !outbound {
+				ns, pod = endpointPod(ps, ep)
+			}
+			if !as.dryRun && as.enforcedNamespaces != nil {
+				enforce = namespaceEnforced(ps, ep, as.enforcedNamespaces)
+			}
+		})
+		if cacheable {
+			as.decisions.put(key, st.Code, policy, rule, as.clock.Now())
+		}
+		if as.upstream != nil && delegates(rule) {
+			var up *authz.CheckResponse
+			st, up = as.upstream.check(ctx, req)
+			resp.HttpResponse = up.GetHttpResponse()
+		}
+		if st.Code != PERMISSION_DENIED {
+			ns, pod = "", ""
+		}
+		if st.Code == PERMISSION_DENIED && as.denyTemplates != nil && resp.HttpResponse == nil {
+			denied := denyTemplateResponse(as.denyTemplates, as.principalTemplates, req, policy, outbound, as.clock.Now())
+			if denied != nil {
+				resp.HttpResponse = denied
+			}
+		}
+		if st.Code == OK {
+			st = status.Status{Code: certStatus.Code, Message: certStatus.Message}
+		}
+		if st.Code == OK {
+			var denied *authz.CheckResponse_DeniedResponse
+			st, denied = checkMTLS(as.requireMTLS && !outbound, rule, req)
+			if denied != nil {
+				resp.HttpResponse = denied
+			}
+		}
+		if st.Code == OK {
+			var denied *authz.CheckResponse_DeniedResponse
+			st, denied = checkRateLimit(as.rateLimiter, rule, req, as.clock.Now())
+			if denied != nil {
+				resp.HttpResponse = denied
+			}
+		}
+		if st.Code == OK && len(as.wafRulesets) > 0 && !isL4(req) {
+			ws := checkWAFRulesets(as.wafRulesets, as.modes, req, as.maxBodyBytes, as.stats)
+			st = status.Status{Code: ws.Code, Message: ws.Message}
+		}
+		if st.Code == OK {
+			var denied *authz.CheckResponse_DeniedResponse
+			st, denied = checkConcurrency(as.concurrency, rule, req, as.clock.Now())
+			if denied != nil {
+				resp.HttpResponse = denied
+			}
+		}
+		resp.Status = &st
+	}
+	recordFamily(req, resp.GetStatus().GetCode(), unresolved)
+	resp.DynamicMetadata = decisionMetadata(as.principalTemplates, req, policy, rule, as.clock.Now().Sub(start))
+	if dryRun, ok := routeDryRun(req); ok {
+		enforce = !dryRun
+	}
+	if enforce && as.enforcePercent < 100 {
+		enforce = clientEnforced(req, as.enforcePercent)
+	}
+	if enforce && pod != "" {
+		as.denials.Denied(peerIdentity(req.GetAttributes().GetSource()), ns, pod, policy)
+	}
+	if as.policyStatus != nil {
+		if d, ok := policyStats(req, policy, enforce); ok {
+			as.policyStatus.Add(d)
+		}
+	}
+	if as.requestStats != nil {
+		as.requestStats.Add(requestStats(req, outbound, enforce && resp.GetStatus().GetCode() != OK))
+	}
+	recordCheck(policy, resp.GetStatus().GetCode(), enforce, as.clock.Now().Sub(start))
+	if len(as.publishers) > 0 {
+		now := as.clock.Now()
+		e := decisionEvent(as.principalTemplates, req, resp.GetStatus().GetCode(), enforce, policy, rule, ruleIndex, now,
+			now.Sub(start))
+		e.Staged = staged
+		for _, p := range as.publishers {
+			p.Publish(e)
+		}
+	}
+	if resp.GetStatus().GetCode() == OK {
+		awaitResponse(as.responses, rule, req, enforce, as.clock.Now())
+	}
+	if !enforce {
+		applyDryRun(req, &resp)
+	} else if d := tarpitDelay(rule); d > 0 {
+		tarpit(ctx, d)
 	}
-	store.Read(func(ps *policystore.PolicyStore) { st = checkStore(ps, req) })
-	resp.Status = &st
 	log.WithFields(log.Fields{
 		"Req.Method":               req.GetAttributes().GetRequest().GetHttp().GetMethod(),
 		"Req.Path":                 req.GetAttributes().GetRequest().GetHttp().GetPath(),
@@ -77,6 +740,68 @@ func (as *authServer) Check(ctx context.Context, req *authz.CheckRequest) (*auth
 	return &resp, nil
 }
 
+// requestOptions returns the options that requests are checked with, other than those that observe the decision or
+// record the request.
+func (as *authServer) requestOptions() []requestOption {
+	opts := []requestOption{
+		withClock(as.clock),
+		withMaxBodyBytes(as.maxBodyBytes),
+		withTrustedHops(as.trustedHops),
+		withTLSFingerprintHeader(as.fingerprintHeader),
+	}
+	if as.grpc != nil {
+		opts = append(opts, withGRPCDecoder(as.grpc))
+	}
+	if as.geo != nil {
+		opts = append(opts, withGeoIP(as.geo))
+	}
+	if as.threatFeeds != nil {
+		opts = append(opts, withThreatFeeds(as.threatFeeds))
+	}
+	if as.modes != nil {
+		opts = append(opts, withEnforcementModes(as.modes))
+	}
+	if as.domains != nil {
+		opts = append(opts, withDomainResolver(as.domains))
+	}
+	if as.tokens != nil {
+		opts = append(opts, withTokenValidator(as.tokens))
+	}
+	if as.signatures != nil {
+		opts = append(opts, withSignatureVerifier(as.signatures))
+	}
+	if as.intentions != nil {
+		if p := as.intentions.Policy(); p != nil {
+			opts = append(opts, withIntentions(p))
+		}
+	}
+	if as.attachments != nil {
+		opts = append(opts, withPolicyAttachments(as.attachments))
+	}
+	if as.sharedEndpoints {
+		opts = append(opts, withSharedEndpoints())
+	}
+	if as.istioPeerMetadata {
+		opts = append(opts, withIstioPeerMetadata())
+	}
+	if as.trustDomains != nil {
+		opts = append(opts, withTrustDomains(as.trustDomains))
+	}
+	if as.serviceEndpoints != nil {
+		opts = append(opts, withServiceEndpoints(as.serviceEndpoints))
+	}
+	if len(as.principalTemplates) > 0 {
+		opts = append(opts, withPrincipalTemplates(as.principalTemplates))
+	}
+	if as.logins != nil {
+		opts = append(opts, withLoginCounter(as.logins))
+	}
+	if as.budgets != nil {
+		opts = append(opts, withEvaluationBudgets(as.budgets))
+	}
+	return opts
+}
+
 func (as *authServer) V2Compat() *authServerV2 {
 	return &authServerV2{
 		v3: as,
@@ -97,7 +822,7 @@ func (as *authServerV2) Check(ctx context.Context, req *authz_v2.CheckRequest) (
 }
 
 func checkRequestV3Compat(reqV2 *authz_v2.CheckRequest) *authz.CheckRequest {
-	return &authz.CheckRequest{
+	req := &authz.CheckRequest{
 		Attributes: &authz.AttributeContext{
 			Source:      peerV3Compat(reqV2.GetAttributes().GetSource()),
 			Destination: peerV3Compat(reqV2.GetAttributes().GetDestination()),
@@ -123,9 +848,18 @@ func checkRequestV3Compat(reqV2 *authz_v2.CheckRequest) *authz.CheckRequest {
 			},
 		},
 	}
+	if reqV2.GetAttributes().GetRequest().GetHttp() == nil {
+		// Keep L4 requests, from the network ext_authz filter, without HTTP attributes.
+		req.Attributes.Request.Http = nil
+	}
+	return req
 }
 
 func peerV3Compat(peerV2 *authz_v2.AttributeContext_Peer) *authz.AttributeContext_Peer {
+	if peerV2 == nil {
+		// Envoy may leave out a peer it knows nothing about.
+		return nil
+	}
 	peer := authz.AttributeContext_Peer{
 		Service:     peerV2.Service,
 		Labels:      peerV2.GetLabels(),
@@ -199,17 +933,38 @@ func headersV2Compat(hdrs []*core.HeaderValueOption) []*core_v2.HeaderValueOptio
 				Key:   hv.GetHeader().GetKey(),
 				Value: hv.GetHeader().GetValue(),
 			},
+			Append: hv.GetAppend(),
 		}
 	}
 	return hdrsV2
 }
 
 func httpStatusV2Compat(s *_type.HttpStatus) *type_v2.HttpStatus {
+	if s == nil {
+		// Envoy defaults to 403 Forbidden.
+		return nil
+	}
 	return &type_v2.HttpStatus{
 		Code: type_v2.StatusCode(s.Code),
 	}
 }
 
+// syncLost returns how long store has been out of sync if that is longer than the grace period, and zero otherwise.
+func (as *authServer) syncLost(store *policystore.PolicyStore) time.Duration {
+	if as.unsyncedGrace < 0 {
+		return 0
+	}
+	var lostAt time.Time
+	store.Read(func(ps *policystore.PolicyStore) { lostAt = ps.SyncLostAt })
+	if lostAt.IsZero() {
+		return 0
+	}
+	if d := as.clock.Now().Sub(lostAt); d > as.unsyncedGrace {
+		return d
+	}
+	return 0
+}
+
 // updateStores pulls PolicyStores off the channel and assigns them.
 func (as *authServer) updateStores(ctx context.Context) {
 	for {
@@ -1,4 +1,4 @@
-// Copyright (c) 2018 Tigera, Inc. All rights reserved.
+// Copyright (c) 2018-2026 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -17,8 +17,14 @@ package checker
 import (
 	"context"
 	"testing"
+	"time"
 
+	core_v2 "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz_v2 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v2"
 	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	. "github.com/onsi/gomega"
 	"google.golang.org/genproto/googleapis/rpc/status"
 
@@ -40,6 +46,31 @@ func TestCheckNoStore(t *testing.T) {
 	Expect(resp.GetStatus().GetCode()).To(Equal(UNAVAILABLE))
 }
 
+func TestCheckNoStoreFallback(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stores := make(chan *policystore.PolicyStore)
+	uut := NewServer(ctx, stores, WithFallbackVerdict(PERMISSION_DENIED))
+
+	req := &authz.CheckRequest{}
+	resp, err := uut.Check(ctx, req)
+	Expect(err).To(BeNil())
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+}
+
+func TestParseVerdict(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ParseVerdict("allow")).To(Equal(OK))
+	Expect(ParseVerdict("Deny")).To(Equal(PERMISSION_DENIED))
+	Expect(ParseVerdict("unavailable")).To(Equal(UNAVAILABLE))
+	Expect(ParseVerdict("internal-error")).To(Equal(INTERNAL))
+	_, err := ParseVerdict("maybe")
+	Expect(err).To(HaveOccurred())
+}
+
 func TestCheckStore(t *testing.T) {
 	RegisterTestingT(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -73,5 +104,135 @@ func TestCheckStore(t *testing.T) {
 		Expect(err).ToNot(HaveOccurred())
 		return rsp
 	}
-	Eventually(chk).Should(Equal(&authz.CheckResponse{Status: &status.Status{Code: OK}}))
+	Eventually(func() *status.Status { return chk().GetStatus() }).Should(Equal(&status.Status{Code: OK}))
+	Expect(chk().GetHttpResponse()).To(BeNil())
+}
+
+func TestCheckUnsyncedGracePeriod(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lostAt := time.Unix(1700000000, 0)
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "Allow"}},
+	}
+	check := func(opts ...ServerOption) int32 {
+		uut := NewServer(ctx, make(chan *policystore.PolicyStore), opts...)
+		uut.Store = store
+		resp, err := uut.Check(ctx, &authz.CheckRequest{Attributes: &authz.AttributeContext{
+			Source:      &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/default/sa/steve"},
+			Destination: tcpDestination(),
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		return resp.GetStatus().GetCode()
+	}
+	withClock := func(d time.Duration) ServerOption { return WithClock(FixedClock(lostAt.Add(d))) }
+
+	// Synced policy is enforced, grace period or not.
+	Expect(check(WithUnsyncedGracePeriod(0), WithFallbackVerdict(INTERNAL), withClock(0))).To(Equal(OK))
+
+	// Policy that is no longer synced is enforced through the grace period, then the fallback verdict applies.
+	store.SyncLostAt = lostAt
+	Expect(check(WithUnsyncedGracePeriod(time.Minute), WithFallbackVerdict(INTERNAL),
+		withClock(time.Minute))).To(Equal(OK))
+	Expect(check(WithUnsyncedGracePeriod(time.Minute), WithFallbackVerdict(INTERNAL),
+		withClock(time.Minute+time.Second))).To(Equal(INTERNAL))
+
+	// The default grace period applies if none is set, and a negative one enforces policy indefinitely.
+	Expect(check(WithFallbackVerdict(INTERNAL), withClock(DefaultUnsyncedGracePeriod))).To(Equal(OK))
+	Expect(check(WithFallbackVerdict(INTERNAL), withClock(DefaultUnsyncedGracePeriod+time.Second))).To(Equal(INTERNAL))
+	Expect(check(WithUnsyncedGracePeriod(-1), WithFallbackVerdict(INTERNAL), withClock(24*time.Hour))).To(Equal(OK))
+}
+
+// The v2 Authorization service is served by the same checker as v3, with requests and responses translated.
+func TestCheckV2Compat(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uut := NewServer(ctx, make(chan *policystore.PolicyStore), WithFallbackVerdict(PERMISSION_DENIED))
+	resp, err := uut.V2Compat().Check(ctx, &authz_v2.CheckRequest{})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+
+	// A request without peers is denied rather than panicking.
+	resp, err = uut.V2Compat().Check(ctx, &authz_v2.CheckRequest{Attributes: &authz_v2.AttributeContext{
+		Request: &authz_v2.AttributeContext_Request{Http: &authz_v2.AttributeContext_HttpRequest{
+			Method: "GET",
+			Path:   "/carts",
+		}},
+	}})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+}
+
+func TestCheckRequestV3Compat(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := checkRequestV3Compat(&authz_v2.CheckRequest{Attributes: &authz_v2.AttributeContext{
+		Source: &authz_v2.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+			Address: &core_v2.Address{Address: &core_v2.Address_SocketAddress{SocketAddress: &core_v2.SocketAddress{
+				Address:       "10.0.0.1",
+				PortSpecifier: &core_v2.SocketAddress_PortValue{PortValue: 40000},
+			}}},
+		},
+		Destination: &authz_v2.AttributeContext_Peer{
+			Address: &core_v2.Address{Address: &core_v2.Address_Pipe{Pipe: &core_v2.Pipe{Path: "/tmp/t.sock"}}},
+		},
+		Request: &authz_v2.AttributeContext_Request{Http: &authz_v2.AttributeContext_HttpRequest{
+			Method: "GET",
+			Path:   "/carts",
+		}},
+		ContextExtensions: map[string]string{ContextExtensionDryRun: "true"},
+	}})
+	attr := req.GetAttributes()
+	Expect(attr.GetSource().GetPrincipal()).To(Equal("spiffe://cluster.local/ns/default/sa/steve"))
+	Expect(attr.GetSource().GetAddress().GetSocketAddress().GetAddress()).To(Equal("10.0.0.1"))
+	Expect(attr.GetSource().GetAddress().GetSocketAddress().GetPortValue()).To(BeEquivalentTo(40000))
+	Expect(attr.GetDestination().GetAddress().GetPipe().GetPath()).To(Equal("/tmp/t.sock"))
+	Expect(attr.GetRequest().GetHttp().GetMethod()).To(Equal("GET"))
+	Expect(attr.GetRequest().GetHttp().GetPath()).To(Equal("/carts"))
+	Expect(attr.GetContextExtensions()).To(Equal(map[string]string{ContextExtensionDryRun: "true"}))
+
+	// Peers Envoy leaves out stay out.
+	req = checkRequestV3Compat(&authz_v2.CheckRequest{Attributes: &authz_v2.AttributeContext{
+		Request: &authz_v2.AttributeContext_Request{Http: &authz_v2.AttributeContext_HttpRequest{Method: "GET"}},
+	}})
+	Expect(req.GetAttributes().GetSource()).To(BeNil())
+	Expect(req.GetAttributes().GetDestination()).To(BeNil())
+	Expect(req.GetAttributes().GetRequest().GetHttp().GetMethod()).To(Equal("GET"))
+}
+
+func TestCheckResponseV2Compat(t *testing.T) {
+	RegisterTestingT(t)
+
+	resp := checkResponseV2Compat(&authz.CheckResponse{
+		Status: &status.Status{Code: PERMISSION_DENIED},
+		HttpResponse: &authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{
+			Status: &_type.HttpStatus{Code: _type.StatusCode_TooManyRequests},
+			Headers: []*core.HeaderValueOption{{
+				Header: &core.HeaderValue{Key: "retry-after", Value: "1"},
+				Append: &wrappers.BoolValue{Value: false},
+			}},
+			Body: "slow down",
+		}},
+	})
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	denied := resp.GetDeniedResponse()
+	Expect(denied.GetStatus().GetCode()).To(BeEquivalentTo(_type.StatusCode_TooManyRequests))
+	Expect(denied.GetBody()).To(Equal("slow down"))
+	Expect(denied.GetHeaders()).To(HaveLen(1))
+	Expect(denied.GetHeaders()[0].GetHeader().GetKey()).To(Equal("retry-after"))
+	Expect(denied.GetHeaders()[0].GetAppend()).ToNot(BeNil())
+	Expect(denied.GetHeaders()[0].GetAppend().GetValue()).To(BeFalse())
+
+	// Denials without a status leave Envoy to pick its default.
+	resp = checkResponseV2Compat(&authz.CheckResponse{
+		HttpResponse: &authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{}},
+	})
+	Expect(resp.GetDeniedResponse().GetStatus()).To(BeNil())
 }
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// Annotations that restrict a rule by the Kubernetes service a request was sent to, rather than the pod that serves
+// it, as comma separated lists of services. Each service is "namespace/name", or just "name" for a service in the
+// policy's namespace, or in any namespace for global policies. The service is found from the original destination
+// address, the service's virtual IP, which Envoy reports in the x-envoy-original-dst-host header or as the
+// destination of outbound requests. Failing that, if EndpointSlices are watched (see WithServiceEndpoints), the
+// services are those the destination pod backs. Rules with either annotation fail safe until Felix has synced
+// services or the EndpointSlices have been listed.
+const (
+	DstServicesAnnotation    = AnnotationPrefix + "dst-services"
+	NotDstServicesAnnotation = AnnotationPrefix + "not-dst-services"
+)
+
+// originalDstHeader carries the address a request was sent to before Envoy redirected it.
+const originalDstHeader = "x-envoy-original-dst-host"
+
+var errNoServices = errors.New("no services synced")
+
+// ServiceEndpoints finds the services whose endpoints include a pod, such as from the cluster's EndpointSlices.
+type ServiceEndpoints interface {
+	// HasSynced returns true once the services' endpoints are known.
+	HasSynced() bool
+	// Services returns the services that the pod with the address backs on the port and protocol.
+	Services(ip net.IP, port int32, protocol string) []policystore.ServiceID
+}
+
+// originalDestination returns the address and port that the request was originally sent to.
+func originalDestination(req *authz.CheckRequest) (net.IP, int32) {
+	if host := req.GetAttributes().GetRequest().GetHttp().GetHeaders()[originalDstHeader]; host != "" {
+		if _, port, err := net.SplitHostPort(strings.TrimSpace(host)); err == nil {
+			if p, err := strconv.ParseUint(port, 10, 16); err == nil {
+				return ipaddr.Parse(host), int32(p)
+			}
+		}
+	}
+	sa := req.GetAttributes().GetDestination().GetAddress().GetSocketAddress()
+	return ipaddr.Parse(sa.GetAddress()), int32(sa.GetPortValue())
+}
+
+// destinationServices returns the service whose virtual IP the request was sent to or, if its original destination
+// isn't a synced service and endpoints is set, the services that its destination pod backs.
+func destinationServices(
+	store *policystore.PolicyStore, endpoints ServiceEndpoints, req *authz.CheckRequest,
+) []policystore.ServiceID {
+	sa := req.GetAttributes().GetDestination().GetAddress().GetSocketAddress()
+	protocol := sa.GetProtocol().String()
+	ip, port := originalDestination(req)
+	if id, found := store.ServiceWithAddress(ip, port, protocol); found {
+		return []policystore.ServiceID{id}
+	}
+	if endpoints == nil {
+		return nil
+	}
+	return endpoints.Services(ipaddr.Parse(sa.GetAddress()), int32(sa.GetPortValue()), protocol)
+}
+
+// containsService returns true if the comma separated list of services includes any of ids. Names without a
+// namespace are in namespace, or any namespace if it is empty.
+func containsService(list string, ids []policystore.ServiceID, namespace string) bool {
+	for _, v := range strings.Split(list, ",") {
+		v = strings.TrimSpace(v)
+		for _, id := range ids {
+			if strings.Contains(v, "/") {
+				if v == id.String() {
+					return true
+				}
+			} else if v == id.Name && (namespace == "" || namespace == id.Namespace) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchDstServices checks the rule's service conditions, if any, against the service the request was sent to.
+func matchDstServices(r *proto.Rule, req *requestCache, policyNamespace string) bool {
+	annotations := r.GetMetadata().GetAnnotations()
+	for _, a := range []string{DstServicesAnnotation, NotDstServicesAnnotation} {
+		v, ok := annotations[a]
+		if !ok {
+			continue
+		}
+		if len(req.store.ServiceByID) == 0 && (req.serviceEndpoints == nil || !req.serviceEndpoints.HasSynced()) {
+			return failSafe(r, a, errNoServices)
+		}
+		ids := req.DestinationServices()
+		if containsService(v, ids, policyNamespace) != (a == DstServicesAnnotation) {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), a: v, "services": ids}).Debug(
+				"Destination service doesn't match rule")
+			return false
+		}
+	}
+	return true
+}
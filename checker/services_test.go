@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// serviceRequest returns a CheckRequest for a request with the given method to the destination, and original
+// destination header if it isn't empty.
+func serviceRequest(method, dst string, port uint32, originalDst string) *authz.CheckRequest {
+	headers := map[string]string{}
+	if originalDst != "" {
+		headers[originalDstHeader] = originalDst
+	}
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Destination: &authz.AttributeContext_Peer{
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address:       dst,
+				PortSpecifier: &core.SocketAddress_PortValue{PortValue: port},
+			}}},
+		},
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: method, Path: "/", Headers: headers},
+		},
+	}}
+}
+
+func serviceStore() *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	store.UpdateService(&proto.ServiceUpdate{
+		Name: "cart", Namespace: "shop", ClusterIp: "10.96.0.10", Ports: []*proto.ServicePort{{Protocol: "TCP", Port: 80}},
+	})
+	store.UpdateService(&proto.ServiceUpdate{Name: "cart", Namespace: "staging", ClusterIp: "10.96.0.20"})
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"shop/default.services"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "shop/default.services"}] = &proto.Policy{
+		Namespace: "shop",
+		InboundRules: []*proto.Rule{
+			{
+				Action:    "allow",
+				HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}},
+				Metadata:  &proto.RuleMetadata{Annotations: map[string]string{DstServicesAnnotation: "cart"}},
+			},
+			{
+				Action:    "deny",
+				HttpMatch: &proto.HTTPMatch{Methods: []string{"POST"}},
+				Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+					NotDstServicesAnnotation: "shop/cart, staging/cart",
+				}},
+			},
+			{Action: "allow", HttpMatch: &proto.HTTPMatch{Methods: []string{"POST"}}},
+		},
+	}
+	return store
+}
+
+type fakeServiceEndpoints struct {
+	synced   bool
+	services map[string][]policystore.ServiceID
+}
+
+func (f *fakeServiceEndpoints) HasSynced() bool {
+	return f.synced
+}
+
+func (f *fakeServiceEndpoints) Services(ip net.IP, port int32, protocol string) []policystore.ServiceID {
+	return f.services[net.JoinHostPort(ip.String(), fmt.Sprint(port))+"/"+protocol]
+}
+
+func TestOriginalDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	ip, port := originalDestination(serviceRequest("GET", "10.0.0.5", 8080, ""))
+	Expect(ip.String()).To(Equal("10.0.0.5"))
+	Expect(port).To(Equal(int32(8080)))
+	ip, port = originalDestination(serviceRequest("GET", "10.0.0.5", 8080, "[fd00::a]:443"))
+	Expect(ip.String()).To(Equal("fd00::a"))
+	Expect(port).To(Equal(int32(443)))
+	// Headers without a port are ignored.
+	ip, _ = originalDestination(serviceRequest("GET", "10.0.0.5", 8080, "10.96.0.10"))
+	Expect(ip.String()).To(Equal("10.0.0.5"))
+}
+
+func TestContainsService(t *testing.T) {
+	RegisterTestingT(t)
+
+	cart := []policystore.ServiceID{{Namespace: "shop", Name: "cart"}}
+	Expect(containsService("shop/cart", cart, "")).To(BeTrue())
+	Expect(containsService("web, cart", cart, "shop")).To(BeTrue())
+	Expect(containsService("cart", cart, "")).To(BeTrue())
+	Expect(containsService("cart", cart, "staging")).To(BeFalse())
+	Expect(containsService("staging/cart", cart, "shop")).To(BeFalse())
+	Expect(containsService("", cart, "shop")).To(BeFalse())
+	Expect(containsService("cart", nil, "shop")).To(BeFalse())
+	// Pods may back more than one service.
+	both := append(cart, policystore.ServiceID{Namespace: "staging", Name: "cart"})
+	Expect(containsService("staging/cart", both, "shop")).To(BeTrue())
+}
+
+func TestCheckStoreDstServices(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := serviceStore()
+	check := func(method, dst string, port uint32, originalDst string) int32 {
+		return checkStore(store, serviceRequest(method, dst, port, originalDst)).Code
+	}
+
+	// The service is found from the original destination header, or the destination of outbound requests.
+	Expect(check("GET", "10.0.0.5", 8080, "10.96.0.10:80")).To(Equal(OK))
+	Expect(check("GET", "10.96.0.10", 80, "")).To(Equal(OK))
+	Expect(check("GET", "10.0.0.5", 8080, "10.96.0.10:8080")).To(Equal(PERMISSION_DENIED))
+	Expect(check("GET", "10.0.0.5", 8080, "10.96.0.20:80")).To(Equal(PERMISSION_DENIED))
+	Expect(check("GET", "10.0.0.5", 8080, "")).To(Equal(PERMISSION_DENIED))
+
+	Expect(check("POST", "10.0.0.5", 8080, "10.96.0.10:80")).To(Equal(OK))
+	Expect(check("POST", "10.0.0.5", 8080, "10.96.0.20:80")).To(Equal(OK))
+	Expect(check("POST", "10.0.0.5", 8080, "")).To(Equal(PERMISSION_DENIED))
+
+	// Until services are synced, rules with the annotations fail safe.
+	store.RemoveService(policystore.ServiceID{Namespace: "shop", Name: "cart"})
+	store.RemoveService(policystore.ServiceID{Namespace: "staging", Name: "cart"})
+	Expect(check("GET", "10.0.0.5", 8080, "10.96.0.10:80")).To(Equal(PERMISSION_DENIED))
+	Expect(check("POST", "10.0.0.5", 8080, "10.96.0.10:80")).To(Equal(PERMISSION_DENIED))
+}
+
+// Without Felix's services, the services are those that the destination pod backs.
+func TestCheckStoreDstServiceEndpoints(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := serviceStore()
+	store.RemoveService(policystore.ServiceID{Namespace: "shop", Name: "cart"})
+	store.RemoveService(policystore.ServiceID{Namespace: "staging", Name: "cart"})
+	endpoints := &fakeServiceEndpoints{services: map[string][]policystore.ServiceID{
+		"10.0.0.5:8080/TCP": {{Namespace: "shop", Name: "cart"}, {Namespace: "shop", Name: "cart-canary"}},
+		"10.0.0.6:8080/TCP": {{Namespace: "shop", Name: "web"}},
+	}}
+	check := func(method, dst string) int32 {
+		return checkStore(store, serviceRequest(method, dst, 8080, ""), withServiceEndpoints(endpoints)).Code
+	}
+
+	// Until the endpoints are synced, rules with the annotations fail safe.
+	Expect(check("GET", "10.0.0.5")).To(Equal(PERMISSION_DENIED))
+	endpoints.synced = true
+	Expect(check("GET", "10.0.0.5")).To(Equal(OK))
+	Expect(check("GET", "10.0.0.6")).To(Equal(PERMISSION_DENIED))
+	Expect(check("POST", "10.0.0.5")).To(Equal(OK))
+	Expect(check("POST", "10.0.0.6")).To(Equal(PERMISSION_DENIED))
+
+	// Felix's services take precedence.
+	store.UpdateService(&proto.ServiceUpdate{Name: "web", Namespace: "shop", ClusterIp: "10.96.0.30"})
+	req := serviceRequest("GET", "10.0.0.5", 8080, "10.96.0.30:80")
+	Expect(checkStore(store, req, withServiceEndpoints(endpoints)).Code).To(Equal(PERMISSION_DENIED))
+}
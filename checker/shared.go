@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"net"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// requestEndpoint returns the endpoint whose policy applies to req. A Dikastes serving a single workload applies its
+// endpoint's policy to every request. A node-level Dikastes, serving every workload on the node, selects the endpoint
+// with the request's destination address, so that the request is checked against its ingress policy or, if the
+// destination isn't on the node, the endpoint with the source address, for its egress policy. Endpoints don't have
+// ports, so workloads that share an address, such as those on the host network, can't be told apart; requests to
+// them have no endpoint, and are denied.
+func requestEndpoint(store *policystore.PolicyStore, req *authz.CheckRequest, shared bool) *proto.WorkloadEndpoint {
+	if !shared {
+		return store.Endpoint
+	}
+	dst := socketIP(req.GetAttributes().GetDestination())
+	if ep, found := endpointWithIP(store, dst); found {
+		return ep
+	}
+	src := socketIP(req.GetAttributes().GetSource())
+	if ep, found := endpointWithIP(store, src); found {
+		return ep
+	}
+	log.WithFields(log.Fields{"src": src, "dst": dst}).Warn("Request isn't to or from an endpoint on this node.")
+	return nil
+}
+
+// endpointWithIP returns the endpoint in the store with the given address. found is true if any endpoint has it,
+// although ep is nil if more than one does.
+func endpointWithIP(store *policystore.PolicyStore, ip net.IP) (ep *proto.WorkloadEndpoint, found bool) {
+	if ip == nil {
+		return nil, false
+	}
+	var matches []proto.WorkloadEndpointID
+	for id, e := range store.EndpointByID {
+		if endpointHasIP(e, ip) {
+			ep = e
+			matches = append(matches, id)
+		}
+	}
+	if len(matches) > 1 {
+		log.WithFields(log.Fields{"ip": ip, "endpoints": matches}).Warn("Address is shared by more than one endpoint.")
+		return nil, true
+	}
+	return ep, len(matches) == 1
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func sharedRequest(src, dst string) *authz.CheckRequest {
+	peer := func(ip string) *authz.AttributeContext_Peer {
+		return &authz.AttributeContext_Peer{Address: &core.Address{Address: &core.Address_SocketAddress{
+			SocketAddress: &core.SocketAddress{Address: ip},
+		}}}
+	}
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source:      peer(src),
+		Destination: peer(dst),
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Method: "GET", Path: "/"},
+		},
+	}}
+}
+
+func TestCheckStoreSharedEndpoints(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	endpoint := func(name, ip string, ingress, egress string) *proto.WorkloadEndpoint {
+		return &proto.WorkloadEndpoint{
+			Name:     name,
+			Ipv4Nets: []string{ip + "/32"},
+			Tiers: []*proto.TierInfo{{
+				Name:            "default",
+				IngressPolicies: []string{ingress},
+				EgressPolicies:  []string{egress},
+			}},
+		}
+	}
+	store.EndpointByID[proto.WorkloadEndpointID{WorkloadId: "web"}] = endpoint("web", "10.0.0.1", "allow", "deny")
+	store.EndpointByID[proto.WorkloadEndpointID{WorkloadId: "db"}] = endpoint("db", "10.0.0.2", "deny", "allow")
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "allow"}] = &proto.Policy{
+		InboundRules:  []*proto.Rule{{Action: "allow"}},
+		OutboundRules: []*proto.Rule{{Action: "allow"}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "deny"}] = &proto.Policy{
+		InboundRules:  []*proto.Rule{{Action: "deny"}},
+		OutboundRules: []*proto.Rule{{Action: "deny"}},
+	}
+	check := func(src, dst string) int32 {
+		return checkStore(store, sharedRequest(src, dst), withSharedEndpoints()).Code
+	}
+
+	// Requests to an endpoint are checked against its ingress policy.
+	Expect(check("192.0.2.1", "10.0.0.1")).To(Equal(OK))
+	Expect(check("192.0.2.1", "10.0.0.2")).To(Equal(PERMISSION_DENIED))
+	// Requests leaving the node are checked against their source's egress policy.
+	Expect(check("10.0.0.1", "192.0.2.1")).To(Equal(PERMISSION_DENIED))
+	Expect(check("10.0.0.2", "192.0.2.1")).To(Equal(OK))
+	// Requests neither to nor from an endpoint on the node are denied.
+	Expect(check("192.0.2.1", "192.0.2.2")).To(Equal(PERMISSION_DENIED))
+
+	// Endpoints that share an address can't be told apart.
+	store.EndpointByID[proto.WorkloadEndpointID{WorkloadId: "web2"}] = endpoint("web2", "10.0.0.1", "allow", "allow")
+	Expect(check("192.0.2.1", "10.0.0.1")).To(Equal(PERMISSION_DENIED))
+
+	// Without shared endpoints, the store's single endpoint applies.
+	store.Endpoint = store.EndpointByID[proto.WorkloadEndpointID{WorkloadId: "db"}]
+	Expect(checkStore(store, sharedRequest("192.0.2.1", "10.0.0.1")).Code).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxMalformedSPIFFEIDs is how many unique malformed principals are kept for the admin API. Later ones are only
+	// counted.
+	MaxMalformedSPIFFEIDs = 20
+	// maxPrincipalLength truncates the principals that are kept, which clients may control.
+	maxPrincipalLength = 256
+)
+
+var malformedSPIFFEIDs = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "dikastes_malformed_spiffe_ids_total",
+	Help: "Number of requests denied because a peer's principal isn't a SPIFFE ID naming a service account.",
+}, []string{"peer"})
+
+func init() {
+	prometheus.MustRegister(malformedSPIFFEIDs)
+}
+
+// MalformedSPIFFEID is a principal that couldn't be parsed as a SPIFFE ID.
+type MalformedSPIFFEID struct {
+	Principal string `json:"principal"`
+	// Peer is "source" or "destination".
+	Peer      string    `json:"peer"`
+	FirstSeen time.Time `json:"firstSeen"`
+	Count     uint64    `json:"count"`
+}
+
+// spiffeAudit counts the malformed principals of requests' peers and keeps the first MaxMalformedSPIFFEIDs unique
+// ones, so that identity misconfigurations can be found. It serves them as JSON over HTTP.
+type spiffeAudit struct {
+	mu    sync.Mutex
+	seen  []*MalformedSPIFFEID
+	index map[string]*MalformedSPIFFEID
+	// dropped counts the malformed principals that weren't kept.
+	dropped uint64
+}
+
+func newSPIFFEAudit() *spiffeAudit {
+	return &spiffeAudit{index: map[string]*MalformedSPIFFEID{}}
+}
+
+// record counts a malformed principal. Each kept principal is logged the first time it is seen; the rest are only
+// logged at Debug level, so that a misconfigured client doesn't flood the log.
+func (a *spiffeAudit) record(peer, principal string, now time.Time) {
+	malformedSPIFFEIDs.WithLabelValues(peer).Inc()
+	if len(principal) > maxPrincipalLength {
+		principal = principal[:maxPrincipalLength]
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	key := peer + " " + principal
+	if m, ok := a.index[key]; ok {
+		m.Count++
+		log.WithFields(log.Fields{"peer": peer, "principal": principal}).Debug("Malformed SPIFFE ID.")
+		return
+	}
+	if len(a.seen) >= MaxMalformedSPIFFEIDs {
+		a.dropped++
+		log.WithFields(log.Fields{"peer": peer, "principal": principal}).Debug("Malformed SPIFFE ID.")
+		return
+	}
+	m := &MalformedSPIFFEID{Principal: principal, Peer: peer, FirstSeen: now, Count: 1}
+	a.seen = append(a.seen, m)
+	a.index[key] = m
+	log.WithFields(log.Fields{"peer": peer, "principal": principal}).Warn(
+		"Denying requests with malformed SPIFFE ID; further requests with it are counted but not logged.")
+}
+
+// ServeHTTP writes the kept malformed principals, in the order they were first seen, and how many others weren't kept.
+func (a *spiffeAudit) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	a.mu.Lock()
+	status := struct {
+		Principals []MalformedSPIFFEID `json:"principals"`
+		Dropped    uint64              `json:"dropped"`
+	}{Principals: make([]MalformedSPIFFEID, len(a.seen)), Dropped: a.dropped}
+	for i, m := range a.seen {
+		status.Principals[i] = *m
+	}
+	a.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+// MalformedSPIFFEIDs returns a handler for the admin API that lists the malformed peer principals seen.
+func (as *authServer) MalformedSPIFFEIDs() http.Handler {
+	return as.spiffe
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func principalRequest(src string) *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source:      &authz.AttributeContext_Peer{Principal: src},
+		Destination: &authz.AttributeContext_Peer{Principal: "spiffe://cluster.local/ns/default/sa/sue"},
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Path: "/"},
+		},
+	}}
+}
+
+func TestCheckStoreMalformedSPIFFEIDs(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"allow"}}
+	store.ProfileByID[proto.ProfileID{Name: "allow"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "allow"}},
+	}
+	audit := newSPIFFEAudit()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	check := func(src string) int32 {
+		return checkStore(store, principalRequest(src), withClock(FixedClock(now)), withSPIFFEAudit(audit)).Code
+	}
+	before := testutil.ToFloat64(malformedSPIFFEIDs.WithLabelValues("source"))
+
+	Expect(check("spiffe://cluster.local/ns/default/sa/steve")).To(Equal(OK))
+	Expect(check("")).To(Equal(OK))
+	Expect(check("spiffe://cluster.local/sa/steve")).To(Equal(PERMISSION_DENIED))
+	Expect(check("spiffe://cluster.local/sa/steve")).To(Equal(PERMISSION_DENIED))
+	Expect(check("steve")).To(Equal(PERMISSION_DENIED))
+	Expect(testutil.ToFloat64(malformedSPIFFEIDs.WithLabelValues("source")) - before).To(Equal(3.0))
+
+	w := httptest.NewRecorder()
+	audit.ServeHTTP(w, httptest.NewRequest("GET", "/malformed-spiffe-ids", nil))
+	var status struct {
+		Principals []MalformedSPIFFEID
+		Dropped    uint64
+	}
+	Expect(json.Unmarshal(w.Body.Bytes(), &status)).To(Succeed())
+	Expect(status.Dropped).To(BeZero())
+	Expect(status.Principals).To(HaveLen(2))
+	Expect(status.Principals[0].Principal).To(Equal("spiffe://cluster.local/sa/steve"))
+	Expect(status.Principals[0].Peer).To(Equal("source"))
+	Expect(status.Principals[0].Count).To(Equal(uint64(2)))
+	Expect(status.Principals[0].FirstSeen.Equal(now)).To(BeTrue())
+	Expect(status.Principals[1].Principal).To(Equal("steve"))
+}
+
+func TestSPIFFEAuditLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	audit := newSPIFFEAudit()
+	now := time.Now()
+	for i := 0; i < MaxMalformedSPIFFEIDs+5; i++ {
+		audit.record("destination", fmt.Sprintf("bad-%d", i), now)
+	}
+	audit.record("destination", "bad-0", now)
+	Expect(audit.seen).To(HaveLen(MaxMalformedSPIFFEIDs))
+	Expect(audit.seen[0].Count).To(Equal(uint64(2)))
+	Expect(audit.dropped).To(Equal(uint64(5)))
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"github.com/projectcalico/app-policy/decisionsink"
+	"github.com/projectcalico/app-policy/proto"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var stagedVerdicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "dikastes_staged_policy_verdicts_total",
+	Help: "Requests that staged policies matched, by policy and the action they would have taken.",
+}, []string{"policy", "action"})
+
+func init() {
+	prometheus.MustRegister(stagedVerdicts)
+}
+
+// checkStagedPolicy evaluates a staged policy against the request and reports its verdict, if it has one, without
+// it deciding the request. Invalid data in the request is reported when the enforced policies reach it, so a staged
+// policy that trips over it just has no verdict.
+func checkStagedPolicy(id proto.PolicyID, policy *proto.Policy, req *requestCache) {
+	// A shared decision would skip the staged policy, so its verdict would go unreported.
+	req.notCacheable()
+	var rule *proto.Rule
+	var index int
+	ruleMatched := req.ruleMatched
+	req.ruleMatched = func(r *proto.Rule, i int) { rule, index = r, i }
+	defer func() {
+		req.ruleMatched = ruleMatched
+		if r := recover(); r != nil {
+			if _, ok := r.(*InvalidDataFromDataPlane); !ok {
+				panic(r)
+			}
+			log.WithField("PolicyID", id).Debug("Staged policy couldn't evaluate the request.")
+		}
+	}()
+	action := checkPolicy(policy, req)
+	if action == NO_MATCH {
+		return
+	}
+	v := decisionsink.StagedVerdict{
+		Policy:    id.Tier + "/" + id.Name,
+		RuleID:    rule.GetRuleId(),
+		RuleIndex: index,
+		Action:    stagedAction(action),
+	}
+	log.WithFields(log.Fields{"PolicyID": id, "action": v.Action}).Debug("Staged policy matched")
+	stagedVerdicts.WithLabelValues(v.Policy, v.Action).Inc()
+	if req.stagedMatched != nil {
+		req.stagedMatched(v)
+	}
+}
+
+// stagedAction names the action a staged policy's rule would have taken. Delegating to the upstream policy decision
+// point is a pass as far as policy is concerned.
+func stagedAction(a Action) string {
+	switch a {
+	case ALLOW:
+		return "allow"
+	case DENY:
+		return "deny"
+	}
+	return "pass"
+}
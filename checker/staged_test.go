@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/decisionsink"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// stagedStore has a staged policy denying DELETEs ahead of the enforced policies in the tier.
+func stagedStore(enforced ...string) *policystore.PolicyStore {
+	store := policystore.NewPolicyStore()
+	policies := append([]string{"staged:no-deletes"}, enforced...)
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers:      []*proto.TierInfo{{Name: "default", IngressPolicies: policies}},
+		ProfileIds: []string{"default"},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "staged:no-deletes"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{Action: "allow", RuleId: "gets", HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}}},
+			{Action: "deny", RuleId: "deletes", HttpMatch: &proto.HTTPMatch{Methods: []string{"DELETE"}}},
+		},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "allow-all"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "allow", RuleId: "all"}},
+	}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "deny", RuleId: "profile"}},
+	}
+	return store
+}
+
+func TestStagedPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	check := func(store *policystore.PolicyStore, method string) (code int32, policy, ruleID string,
+		staged []decisionsink.StagedVerdict) {
+		req := keyedRequest(40000, "/carts/1", nil)
+		req.Attributes.Request.Http.Method = method
+		s := checkStore(store, req,
+			withRuleObserver(func(r *proto.Rule, _ int) { ruleID = r.GetRuleId() }),
+			withPolicyObserver(func(p string) { policy = p }),
+			withStagedObserver(func(v decisionsink.StagedVerdict) { staged = append(staged, v) }),
+		)
+		return s.Code, policy, ruleID, staged
+	}
+
+	// A staged policy's verdict is reported, but the enforced policy after it decides the request.
+	counted := testutil.ToFloat64(stagedVerdicts.WithLabelValues("default/staged:no-deletes", "deny"))
+	code, policy, ruleID, staged := check(stagedStore("allow-all"), "DELETE")
+	Expect(code).To(Equal(OK))
+	Expect(policy).To(Equal("default/allow-all"))
+	Expect(ruleID).To(Equal("all"))
+	Expect(staged).To(Equal([]decisionsink.StagedVerdict{
+		{Policy: "default/staged:no-deletes", RuleID: "deletes", RuleIndex: 1, Action: "deny"},
+	}))
+	Expect(testutil.ToFloat64(stagedVerdicts.WithLabelValues("default/staged:no-deletes", "deny"))).To(
+		Equal(counted + 1))
+
+	// Staged policies that don't match report nothing.
+	_, _, _, staged = check(stagedStore("allow-all"), "PUT")
+	Expect(staged).To(BeEmpty())
+
+	// A tier with only staged policies doesn't deny by default, but passes the request on to the profiles.
+	code, policy, _, staged = check(stagedStore(), "GET")
+	Expect(code).To(Equal(PERMISSION_DENIED))
+	Expect(policy).To(Equal("profile/default"))
+	Expect(staged).To(HaveLen(1))
+	Expect(staged[0].Action).To(Equal("allow"))
+}
+
+func TestStagedPolicyNotCacheable(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Decisions that evaluate staged policies aren't shared, so that each request reports their verdicts.
+	uncacheable := false
+	s := checkStore(stagedStore("allow-all"), keyedRequest(40000, "/carts/1", nil),
+		withCacheObserver(func() { uncacheable = true }))
+	Expect(s.Code).To(Equal(OK))
+	Expect(uncacheable).To(BeTrue())
+
+	// Without staged policies, they are.
+	store := stagedStore("allow-all")
+	store.Endpoint.Tiers[0].IngressPolicies = []string{"allow-all"}
+	uncacheable = false
+	s = checkStore(store, keyedRequest(40000, "/carts/1", nil), withCacheObserver(func() { uncacheable = true }))
+	Expect(s.Code).To(Equal(OK))
+	Expect(uncacheable).To(BeFalse())
+}
+
+func TestStagedPolicyInvalidData(t *testing.T) {
+	RegisterTestingT(t)
+
+	// A staged policy that can't evaluate the request has no verdict, and doesn't stop the enforced policies.
+	store := stagedStore("allow-all")
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "staged:no-deletes"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "deny", HttpMatch: &proto.HTTPMatch{
+			Paths: []*proto.HTTPMatch_PathMatch{{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "("}}},
+		}}},
+	}
+	var staged []decisionsink.StagedVerdict
+	s := checkStore(store, keyedRequest(40000, "/carts/1", nil),
+		withStagedObserver(func(v decisionsink.StagedVerdict) { staged = append(staged, v) }))
+	Expect(s.Code).To(Equal(OK))
+	Expect(staged).To(BeEmpty())
+}
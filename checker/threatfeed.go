@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SrcThreatFeedsAnnotation restricts a rule to requests whose original client is in any of the given comma separated
+// threat feeds. Rules with the annotation fail safe if a feed is unknown or hasn't been downloaded yet. Detect-only
+// feeds never match, but clients in them are logged.
+const SrcThreatFeedsAnnotation = AnnotationPrefix + "src-threat-feeds"
+
+var errNoThreatFeeds = errors.New("threat feeds not configured")
+
+// ThreatFeeds holds IP blocklists.
+type ThreatFeeds interface {
+	// Contains returns true if ip is in the named feed.
+	Contains(feed string, ip net.IP) (bool, error)
+}
+
+// matchThreatFeeds checks the rule's threat feeds, if any, against the request's client.
+func matchThreatFeeds(r *proto.Rule, req *requestCache) bool {
+	v, ok := r.GetMetadata().GetAnnotations()[SrcThreatFeedsAnnotation]
+	if !ok {
+		return true
+	}
+	if req.threatFeeds == nil {
+		return failSafe(r, SrcThreatFeedsAnnotation, errNoThreatFeeds)
+	}
+	ip := req.ClientIP()
+	if ip == nil {
+		return failSafe(r, SrcThreatFeedsAnnotation, errNoClientIP)
+	}
+	for _, feed := range strings.Split(v, ",") {
+		feed = strings.TrimSpace(feed)
+		detectOnly := req.modes != nil && req.modes.ThreatFeedDetectOnly(feed)
+		in, err := req.threatFeeds.Contains(feed, ip)
+		if err != nil {
+			if detectOnly {
+				log.WithError(err).WithField("feed", feed).Debug("Detect-only threat feed unavailable")
+				continue
+			}
+			return failSafe(r, SrcThreatFeedsAnnotation, err)
+		}
+		if in && detectOnly {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), "feed": feed, "client": ip}).Info(
+				"Client in detect-only threat feed")
+			continue
+		}
+		if in {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), "feed": feed, "client": ip}).Debug("Client in threat feed")
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/threatfeed"
+)
+
+// fakeThreatFeeds maps feed names to the addresses in them. A nil feed has not loaded.
+type fakeThreatFeeds map[string][]string
+
+func (f fakeThreatFeeds) Contains(feed string, ip net.IP) (bool, error) {
+	ips, ok := f[feed]
+	if !ok {
+		return false, fmt.Errorf("unknown threat feed %q", feed)
+	}
+	if ips == nil {
+		return false, threatfeed.ErrNotLoaded
+	}
+	for _, i := range ips {
+		if i == ip.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestCheckStoreThreatFeeds(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"blocklist"}}},
+	}
+	deny := &proto.Rule{
+		Action:   "deny",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{SrcThreatFeedsAnnotation: "drop, edrop"}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "blocklist"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{deny, {Action: "allow"}},
+	}
+	feeds := fakeThreatFeeds{"drop": {"192.0.2.1"}, "edrop": {"192.0.2.2"}}
+	check := func(peer string, headers map[string]string) int32 {
+		return checkStore(store, geoRequest(peer, headers), withThreatFeeds(feeds), withTrustedHops(1)).Code
+	}
+
+	Expect(check("192.0.2.1", nil)).To(Equal(PERMISSION_DENIED))
+	Expect(check("192.0.2.2", nil)).To(Equal(PERMISSION_DENIED))
+	Expect(check("192.0.2.3", nil)).To(Equal(OK))
+	// The original client is matched, not the proxy in front of Envoy.
+	Expect(check("10.0.0.1", map[string]string{"x-forwarded-for": "192.0.2.2"})).To(Equal(PERMISSION_DENIED))
+	Expect(check("192.0.2.1", map[string]string{"x-forwarded-for": "192.0.2.3"})).To(Equal(OK))
+
+	// Feeds that are unknown or not loaded yet fail safe, as do rules checked without feeds.
+	feeds["edrop"] = nil
+	Expect(check("192.0.2.3", nil)).To(Equal(PERMISSION_DENIED))
+	deny.Metadata.Annotations[SrcThreatFeedsAnnotation] = "nope"
+	Expect(check("192.0.2.3", nil)).To(Equal(PERMISSION_DENIED))
+	deny.Metadata.Annotations[SrcThreatFeedsAnnotation] = "drop"
+	Expect(checkStore(store, geoRequest("192.0.2.3", nil)).Code).To(Equal(PERMISSION_DENIED))
+}
+
+// fakeModes lists the detect-only WAF rulesets and threat feeds.
+type fakeModes map[string]bool
+
+func (f fakeModes) WAFDetectOnly(ruleset string) bool {
+	return f["waf/"+ruleset]
+}
+
+func (f fakeModes) ThreatFeedDetectOnly(feed string) bool {
+	return f["feed/"+feed]
+}
+
+func TestCheckStoreDetectOnlyThreatFeeds(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"blocklist"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "blocklist"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{{
+			Action:   "deny",
+			Metadata: &proto.RuleMetadata{Annotations: map[string]string{SrcThreatFeedsAnnotation: "drop, new"}},
+		}, {Action: "allow"}},
+	}
+	feeds := fakeThreatFeeds{"drop": {"192.0.2.1"}, "new": {"192.0.2.2"}}
+	modes := fakeModes{"feed/new": true}
+	check := func(peer string) int32 {
+		return checkStore(store, geoRequest(peer, nil), withThreatFeeds(feeds), withEnforcementModes(modes)).Code
+	}
+
+	Expect(check("192.0.2.1")).To(Equal(PERMISSION_DENIED))
+	Expect(check("192.0.2.2")).To(Equal(OK))
+
+	// A detect-only feed that isn't loaded yet doesn't fail safe.
+	feeds["new"] = nil
+	Expect(check("192.0.2.3")).To(Equal(OK))
+
+	// Changing the mode takes effect on the next check.
+	feeds["new"] = []string{"192.0.2.2"}
+	modes["feed/new"] = false
+	Expect(check("192.0.2.2")).To(Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// ActiveWindowsAnnotation restricts a rule to the listed time windows; outside them the rule does not match.
+	ActiveWindowsAnnotation = AnnotationPrefix + "active-windows"
+	// InactiveWindowsAnnotation suspends a rule during the listed time windows, for example to relax a deny rule
+	// during a maintenance window.
+	InactiveWindowsAnnotation = AnnotationPrefix + "inactive-windows"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// timeWindow is a period of time during which a rule condition holds.
+type timeWindow interface {
+	contains(t time.Time) bool
+}
+
+// absoluteWindow is a single period between two instants, written "<start>/<end>" in RFC 3339 format. The end is
+// exclusive.
+type absoluteWindow struct {
+	start, end time.Time
+}
+
+func (w absoluteWindow) contains(t time.Time) bool {
+	return !t.Before(w.start) && t.Before(w.end)
+}
+
+// dailyWindow recurs each day, or on the given days, between two times of day in a time zone. If end is before start
+// the window spans midnight and belongs to the day it starts on.
+type dailyWindow struct {
+	days       [7]bool
+	start, end int // minutes after midnight
+	loc        *time.Location
+}
+
+func (w dailyWindow) contains(t time.Time) bool {
+	t = t.In(w.loc)
+	m := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if w.start <= w.end {
+		return w.days[day] && m >= w.start && m < w.end
+	}
+	if m >= w.start {
+		return w.days[day]
+	}
+	return m < w.end && w.days[(day+6)%7]
+}
+
+// parseTimeWindows parses a semicolon separated list of windows. Each window is either an absolute period,
+// "2026-10-17T22:00:00Z/2026-10-18T02:00:00Z", or a recurring one, "[days] HH:MM-HH:MM [zone]", where days is a comma
+// separated list of days or day ranges such as "Mon-Fri,Sun" (default every day) and zone is an IANA time zone name
+// (default UTC).
+func parseTimeWindows(s string) ([]timeWindow, error) {
+	var windows []timeWindow
+	for _, w := range strings.Split(s, ";") {
+		w = strings.TrimSpace(w)
+		if w == "" {
+			continue
+		}
+		tw, err := parseTimeWindow(w)
+		if err != nil {
+			return nil, fmt.Errorf("bad time window %q: %v", w, err)
+		}
+		windows = append(windows, tw)
+	}
+	if len(windows) == 0 {
+		return nil, fmt.Errorf("no time windows in %q", s)
+	}
+	return windows, nil
+}
+
+func parseTimeWindow(w string) (timeWindow, error) {
+	if parts := strings.Split(w, "/"); len(parts) == 2 && !strings.ContainsAny(w, " \t") {
+		start, err := time.Parse(time.RFC3339, parts[0])
+		if err != nil {
+			return nil, err
+		}
+		end, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			return nil, err
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("end is not after start")
+		}
+		return absoluteWindow{start: start, end: end}, nil
+	}
+
+	fields := strings.Fields(w)
+	dw := dailyWindow{loc: time.UTC}
+	if len(fields) > 0 && !strings.Contains(fields[0], ":") {
+		if err := parseDays(fields[0], &dw.days); err != nil {
+			return nil, err
+		}
+		fields = fields[1:]
+	} else {
+		for i := range dw.days {
+			dw.days[i] = true
+		}
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("expected [days] HH:MM-HH:MM [zone]")
+	}
+	times := strings.Split(fields[0], "-")
+	if len(times) != 2 {
+		return nil, fmt.Errorf("expected HH:MM-HH:MM, got %q", fields[0])
+	}
+	var err error
+	if dw.start, err = parseTimeOfDay(times[0]); err != nil {
+		return nil, err
+	}
+	if dw.end, err = parseTimeOfDay(times[1]); err != nil {
+		return nil, err
+	}
+	if dw.start == dw.end {
+		return nil, fmt.Errorf("window is empty")
+	}
+	if len(fields) == 2 {
+		if dw.loc, err = time.LoadLocation(fields[1]); err != nil {
+			return nil, err
+		}
+	}
+	return dw, nil
+}
+
+func parseDays(s string, days *[7]bool) error {
+	for _, d := range strings.Split(s, ",") {
+		r := strings.SplitN(d, "-", 2)
+		first, ok := weekdays[strings.ToLower(r[0])]
+		if !ok {
+			return fmt.Errorf("unknown day %q", r[0])
+		}
+		last := first
+		if len(r) == 2 {
+			if last, ok = weekdays[strings.ToLower(r[1])]; !ok {
+				return fmt.Errorf("unknown day %q", r[1])
+			}
+		}
+		// Ranges may wrap around the end of the week, e.g. Fri-Mon.
+		for day := first; ; day = (day + 1) % 7 {
+			days[day] = true
+			if day == last {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func parseTimeOfDay(s string) (int, error) {
+	hm := strings.Split(s, ":")
+	if len(hm) != 2 {
+		return 0, fmt.Errorf("expected HH:MM, got %q", s)
+	}
+	h, err := strconv.Atoi(hm[0])
+	if err != nil || h < 0 || h > 24 {
+		return 0, fmt.Errorf("bad hour in %q", s)
+	}
+	m, err := strconv.Atoi(hm[1])
+	if err != nil || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("bad minute in %q", s)
+	}
+	return h*60 + m, nil
+}
+
+type parsedWindows struct {
+	windows []timeWindow
+	err     error
+}
+
+// maxWindowAnnotations bounds the parsed window annotations cached. Like path regexes, policies seldom have more than
+// a few, so the bound is only reached if they churn, and then an arbitrary annotation is evicted.
+const maxWindowAnnotations = 1000
+
+// windowCache holds parsed window annotations keyed by their text, so that each annotation is parsed (and its time
+// zone loaded) once rather than on every request.
+var windowCache = struct {
+	sync.Mutex
+	m map[string]*parsedWindows
+}{m: map[string]*parsedWindows{}}
+
+func cachedTimeWindows(s string) ([]timeWindow, error) {
+	windowCache.Lock()
+	defer windowCache.Unlock()
+	if p, ok := windowCache.m[s]; ok {
+		return p.windows, p.err
+	}
+	w, err := parseTimeWindows(s)
+	if len(windowCache.m) >= maxWindowAnnotations {
+		for victim := range windowCache.m {
+			delete(windowCache.m, victim)
+			break
+		}
+	}
+	windowCache.m[s] = &parsedWindows{windows: w, err: err}
+	return w, err
+}
+
+func inAnyWindow(windows []timeWindow, t time.Time) bool {
+	for _, w := range windows {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTimeWindows checks the rule's time window annotations, if any, against the time of the request.
+func matchTimeWindows(r *proto.Rule, req *requestCache) bool {
+	annotations := r.GetMetadata().GetAnnotations()
+	if len(annotations) == 0 {
+		return true
+	}
+	for _, a := range []string{ActiveWindowsAnnotation, InactiveWindowsAnnotation} {
+		v, ok := annotations[a]
+		if !ok {
+			continue
+		}
+		windows, err := cachedTimeWindows(v)
+		if err != nil {
+			return failSafe(r, a, err)
+		}
+		if inAnyWindow(windows, req.Now()) != (a == ActiveWindowsAnnotation) {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), a: v}).Debug("Rule not in effect at this time")
+			return false
+		}
+	}
+	return true
+}
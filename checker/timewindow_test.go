@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func mustParseTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// 2026-10-16 is a Friday.
+func TestParseTimeWindows(t *testing.T) {
+	RegisterTestingT(t)
+
+	w, err := parseTimeWindows("Mon-Fri 09:00-17:00")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-16T09:00:00Z"))).To(BeTrue())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-16T16:59:59Z"))).To(BeTrue())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-16T17:00:00Z"))).To(BeFalse())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-17T12:00:00Z"))).To(BeFalse())
+
+	// Windows that span midnight belong to the day they start on.
+	w, err = parseTimeWindows("Sat 22:00-02:00")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-17T23:00:00Z"))).To(BeTrue())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-18T01:00:00Z"))).To(BeTrue())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-17T01:00:00Z"))).To(BeFalse())
+
+	// Day ranges wrap around the end of the week, and multiple windows are separated by semicolons.
+	w, err = parseTimeWindows("Fri-Sun 00:00-01:00; 12:00-13:00")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-18T00:30:00Z"))).To(BeTrue())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-19T00:30:00Z"))).To(BeFalse())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-19T12:30:00Z"))).To(BeTrue())
+
+	w, err = parseTimeWindows("2026-10-17T22:00:00Z/2026-10-18T02:00:00Z")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-17T22:00:00Z"))).To(BeTrue())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-18T02:00:00Z"))).To(BeFalse())
+
+	w, err = parseTimeWindows("09:00-10:00 UTC")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(inAnyWindow(w, mustParseTime("2026-10-16T09:30:00Z"))).To(BeTrue())
+
+	for _, bad := range []string{
+		"",
+		"Mon",
+		"Funday 09:00-10:00",
+		"09:00",
+		"25:00-26:00",
+		"09:00-09:00",
+		"09:00-10:00 Not/AZone",
+		"Tue 09:00-10:00 Nowhere/Town",
+		"2026-10-18T00:00:00Z/2026-10-17T00:00:00Z",
+	} {
+		_, err = parseTimeWindows(bad)
+		Expect(err).To(HaveOccurred(), bad)
+	}
+}
+
+func TestMatchTimeWindows(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/sue",
+		},
+	}}
+	deny := &proto.Rule{
+		Action: "deny",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+			InactiveWindowsAnnotation: "Sat 02:00-06:00",
+		}},
+	}
+	allow := &proto.Rule{
+		Action: "allow",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+			ActiveWindowsAnnotation: "Sat 02:00-06:00",
+		}},
+	}
+
+	at := func(s string) *requestCache {
+		rc, err := NewRequestCache(policystore.NewPolicyStore(), req, withClock(FixedClock(mustParseTime(s))))
+		Expect(err).ToNot(HaveOccurred())
+		return rc
+	}
+	Expect(matchTimeWindows(deny, at("2026-10-17T03:00:00Z"))).To(BeFalse())
+	Expect(matchTimeWindows(allow, at("2026-10-17T03:00:00Z"))).To(BeTrue())
+	Expect(matchTimeWindows(deny, at("2026-10-17T07:00:00Z"))).To(BeTrue())
+	Expect(matchTimeWindows(allow, at("2026-10-17T07:00:00Z"))).To(BeFalse())
+
+	// Malformed windows never loosen policy.
+	deny.Metadata.Annotations[InactiveWindowsAnnotation] = "sometime"
+	allow.Metadata.Annotations[ActiveWindowsAnnotation] = "sometime"
+	Expect(matchTimeWindows(deny, at("2026-10-17T03:00:00Z"))).To(BeTrue())
+	Expect(matchTimeWindows(allow, at("2026-10-17T03:00:00Z"))).To(BeFalse())
+
+	// Rules without annotations always match.
+	Expect(matchTimeWindows(&proto.Rule{Action: "deny"}, at("2026-10-17T03:00:00Z"))).To(BeTrue())
+}
+
+func TestWindowCacheBounded(t *testing.T) {
+	RegisterTestingT(t)
+
+	for i := 0; i < maxWindowAnnotations+10; i++ {
+		_, err := cachedTimeWindows(fmt.Sprintf("Mon %02d:%02d-24:00", i/60, i%60))
+		Expect(err).ToNot(HaveOccurred())
+	}
+	_, err := cachedTimeWindows("sometime")
+	Expect(err).To(HaveOccurred())
+	windowCache.Lock()
+	defer windowCache.Unlock()
+	Expect(windowCache.m).To(HaveLen(maxWindowAnnotations))
+	Expect(windowCache.m).To(HaveKey("sometime"))
+}
+
+// A maintenance window relaxes a deny rule for the duration of the window.
+func TestCheckStoreMaintenanceWindow(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"maintenance"}}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "maintenance"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{
+			{
+				Action: "deny",
+				Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+					InactiveWindowsAnnotation: "Sat 02:00-06:00 UTC",
+				}},
+			},
+			{Action: "allow"},
+		},
+	}
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/sue",
+		},
+	}}
+
+	st := checkStore(store, req, withClock(FixedClock(mustParseTime("2026-10-17T07:00:00Z"))))
+	Expect(st.Code).To(Equal(PERMISSION_DENIED))
+	st = checkStore(store, req, withClock(FixedClock(mustParseTime("2026-10-17T03:00:00Z"))))
+	Expect(st.Code).To(Equal(OK))
+}
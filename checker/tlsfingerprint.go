@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// Annotations that restrict a rule by the client's TLS fingerprint, such as a JA3 hash, as comma separated lists of
+// fingerprints. Rules with either annotation fail safe for requests that have no fingerprint.
+const (
+	SrcTLSFingerprintsAnnotation    = AnnotationPrefix + "src-tls-fingerprints"
+	NotSrcTLSFingerprintsAnnotation = AnnotationPrefix + "not-src-tls-fingerprints"
+)
+
+// The client's TLS fingerprint is read from the request's metadata context, under TLSFingerprintKey in the
+// TLSFingerprintNamespace filter metadata, which Envoy sends if the namespace is listed in the ext_authz filter's
+// metadata_context_namespaces.
+const (
+	TLSFingerprintNamespace = "calico.tls"
+	TLSFingerprintKey       = "fingerprint"
+)
+
+var errNoTLSFingerprint = errors.New("no client TLS fingerprint")
+
+// tlsFingerprint returns the fingerprint of the client's TLS handshake, or "" if the request doesn't have one. It is
+// taken from the metadata context if present, and otherwise from the given header, if any. Clients can set headers
+// themselves, so Envoy must be configured to overwrite the header for it to be trusted.
+func tlsFingerprint(req *authz.CheckRequest, header string) string {
+	md := req.GetAttributes().GetMetadataContext().GetFilterMetadata()[TLSFingerprintNamespace]
+	if fp := md.GetFields()[TLSFingerprintKey].GetStringValue(); fp != "" {
+		return strings.ToLower(fp)
+	}
+	if header == "" {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(req.GetAttributes().GetRequest().GetHttp().GetHeaders()[header]))
+}
+
+// matchTLSFingerprints checks the rule's TLS fingerprint conditions, if any, against the request's client.
+func matchTLSFingerprints(r *proto.Rule, req *requestCache) bool {
+	annotations := r.GetMetadata().GetAnnotations()
+	for _, a := range []string{SrcTLSFingerprintsAnnotation, NotSrcTLSFingerprintsAnnotation} {
+		v, ok := annotations[a]
+		if !ok {
+			continue
+		}
+		fp := req.TLSFingerprint()
+		if fp == "" {
+			return failSafe(r, a, errNoTLSFingerprint)
+		}
+		if containsFold(v, fp) != (a == SrcTLSFingerprintsAnnotation) {
+			log.WithFields(log.Fields{"rule": r.GetRuleId(), a: v, "fingerprint": fp}).Debug(
+				"Client TLS fingerprint doesn't match rule")
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+const (
+	curlJA3   = "456523fc94726331a4d5a2e1d40b2cd7"
+	chromeJA3 = "cd08e31494f9531f560d64c695473da9"
+)
+
+// fingerprintRequest returns a CheckRequest with the given TLS fingerprint in its metadata context, if it isn't empty.
+func fingerprintRequest(fp string, headers map[string]string) *authz.CheckRequest {
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Destination: &authz.AttributeContext_Peer{
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address:       "10.0.0.2",
+				PortSpecifier: &core.SocketAddress_PortValue{PortValue: 8443},
+				Protocol:      core.SocketAddress_TCP,
+			}}},
+		},
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{Path: "/", Headers: headers},
+		},
+	}}
+	if fp != "" {
+		req.Attributes.MetadataContext = &core.Metadata{FilterMetadata: map[string]*structpb.Struct{
+			TLSFingerprintNamespace: {Fields: map[string]*structpb.Value{
+				TLSFingerprintKey: {Kind: &structpb.Value_StringValue{StringValue: fp}},
+			}},
+		}}
+	}
+	return req
+}
+
+func TestTLSFingerprint(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(tlsFingerprint(fingerprintRequest(curlJA3, nil), "")).To(Equal(curlJA3))
+	Expect(tlsFingerprint(fingerprintRequest("", nil), "x-ja3")).To(Equal(""))
+	// The header is only read if configured, and the metadata context takes precedence.
+	hdr := map[string]string{"x-ja3": chromeJA3}
+	Expect(tlsFingerprint(fingerprintRequest("", hdr), "")).To(Equal(""))
+	Expect(tlsFingerprint(fingerprintRequest("", hdr), "x-ja3")).To(Equal(chromeJA3))
+	Expect(tlsFingerprint(fingerprintRequest(curlJA3, hdr), "x-ja3")).To(Equal(curlJA3))
+}
+
+func TestCheckStoreTLSFingerprints(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Tiers: []*proto.TierInfo{{Name: "default", IngressPolicies: []string{"bots"}}},
+	}
+	deny := &proto.Rule{
+		Action:   "deny",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{SrcTLSFingerprintsAnnotation: curlJA3}},
+	}
+	allow := &proto.Rule{
+		Action: "allow",
+		Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+			NotSrcTLSFingerprintsAnnotation: "00000000000000000000000000000000, 11111111111111111111111111111111",
+		}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "bots"}] = &proto.Policy{
+		InboundRules: []*proto.Rule{deny, allow},
+	}
+	check := func(req *authz.CheckRequest) int32 {
+		return checkStore(store, req, withTLSFingerprintHeader("X-JA3")).Code
+	}
+
+	Expect(check(fingerprintRequest(chromeJA3, nil))).To(Equal(OK))
+	Expect(check(fingerprintRequest("456523FC94726331A4D5A2E1D40B2CD7", nil))).To(Equal(PERMISSION_DENIED))
+	Expect(check(fingerprintRequest("", map[string]string{"x-ja3": curlJA3}))).To(Equal(PERMISSION_DENIED))
+	Expect(check(fingerprintRequest("11111111111111111111111111111111", nil))).To(Equal(PERMISSION_DENIED))
+	// Requests without a fingerprint fail safe.
+	Expect(check(fingerprintRequest("", nil))).To(Equal(PERMISSION_DENIED))
+	// Without the deny rule, the allow rule doesn't match requests without a fingerprint either.
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "bots"}].InboundRules = []*proto.Rule{allow}
+	Expect(check(fingerprintRequest("", nil))).To(Equal(PERMISSION_DENIED))
+	Expect(check(fingerprintRequest(curlJA3, nil))).To(Equal(OK))
+}
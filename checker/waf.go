@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+
+	"github.com/projectcalico/app-policy/statscache"
+	"github.com/projectcalico/app-policy/waf"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// StatsReporter receives statistics to aggregate and report to Felix.
+type StatsReporter interface {
+	Add(statscache.DPStats)
+}
+
+// DefaultWAFRuleset is the name of the ruleset that WithWAF adds.
+const DefaultWAFRuleset = "default"
+
+// EnforcementModes says which WAF rulesets and threat feeds only detect requests, rather than blocking them.
+type EnforcementModes interface {
+	WAFDetectOnly(ruleset string) bool
+	ThreatFeedDetectOnly(feed string) bool
+}
+
+// wafRuleset is a WAF engine loaded with one named set of rules.
+type wafRuleset struct {
+	name   string
+	engine waf.Engine
+}
+
+// checkWAFRulesets runs the request through every ruleset, and returns the first status from checkWAF that isn't OK.
+// Rulesets that modes, if not nil, sets to detect-only still log and report their matches, but never deny.
+func checkWAFRulesets(
+	rulesets []wafRuleset, modes EnforcementModes, req *authz.CheckRequest, maxBodyBytes int, stats StatsReporter,
+) *status.Status {
+	st := &status.Status{Code: OK}
+	for _, rs := range rulesets {
+		detectOnly := modes != nil && modes.WAFDetectOnly(rs.name)
+		if s := checkWAF(rs, detectOnly, req, maxBodyBytes, stats); st.Code == OK && s.Code != OK {
+			st = s
+		}
+	}
+	return st
+}
+
+// checkWAF runs the request through a WAF ruleset and returns the status to combine with the policy verdict: OK if
+// the ruleset lets the request through, PERMISSION_DENIED if a rule blocks it, or INTERNAL if the engine fails. A
+// detect-only ruleset always returns OK. Every matched rule is logged, and also sent to stats if it is not nil.
+func checkWAF(
+	rs wafRuleset, detectOnly bool, req *authz.CheckRequest, maxBodyBytes int, stats StatsReporter,
+) *status.Status {
+	res, err := rs.engine.Inspect(wafRequest(req, maxBodyBytes))
+	if err != nil {
+		log.WithError(err).WithField("ruleset", rs.name).Error("WAF inspection failed.")
+		if detectOnly {
+			return &status.Status{Code: OK}
+		}
+		return &status.Status{Code: INTERNAL}
+	}
+	blocked := res.Blocked && !detectOnly
+	if detectOnly && res.Blocked {
+		log.WithFields(log.Fields{"ruleset": rs.name, "rule_id": res.RuleID}).Info(
+			"Detect-only WAF ruleset would have blocked request.")
+	}
+	attr := req.GetAttributes()
+	for _, m := range res.Matches {
+		log.WithFields(log.Fields{
+			"ruleset":     rs.name,
+			"detect_only": detectOnly,
+			"rule_id":     m.RuleID,
+			"severity":    m.Severity,
+			"message":     m.Message,
+			"data":        m.Data,
+			"blocked":     blocked && m.RuleID == res.RuleID,
+			"source":      attr.GetSource().GetPrincipal(),
+			"destination": attr.GetDestination().GetPrincipal(),
+			"path":        attr.GetRequest().GetHttp().GetPath(),
+		}).Warn("WAF rule matched.")
+	}
+	if stats != nil && len(res.Matches) > 0 {
+		stats.Add(wafStats(req, res, blocked))
+	}
+	if blocked {
+		return &status.Status{Code: PERMISSION_DENIED, Message: fmt.Sprintf("blocked by WAF rule %d", res.RuleID)}
+	}
+	return &status.Status{Code: OK}
+}
+
+// wafStats records one hit for each rule the WAF matched, against the connection the request arrived on. blocked is
+// whether the result's blocking rule denied the request, which it doesn't in detect-only rulesets.
+func wafStats(req *authz.CheckRequest, res *waf.Result, blocked bool) statscache.DPStats {
+	d := statscache.DPStats{
+		Tuple:  statsTuple(req),
+		Values: statscache.Values{WAFHits: map[statscache.WAFHit]int64{}},
+	}
+	for _, m := range res.Matches {
+		h := statscache.WAFHit{
+			RuleID:   m.RuleID,
+			Severity: m.Severity,
+			Message:  m.Message,
+			Blocked:  blocked && m.RuleID == res.RuleID,
+		}
+		d.Values.WAFHits[h]++
+	}
+	return d
+}
+
+// statsTuple is the connection a request arrived on. Envoy only sends HTTP requests for authorization, so the protocol
+// is always TCP.
+func statsTuple(req *authz.CheckRequest) statscache.Tuple {
+	attr := req.GetAttributes()
+	src := attr.GetSource().GetAddress().GetSocketAddress()
+	dst := attr.GetDestination().GetAddress().GetSocketAddress()
+	return statscache.Tuple{
+		SrcIp:    src.GetAddress(),
+		DstIp:    dst.GetAddress(),
+		SrcPort:  int32(src.GetPortValue()),
+		DstPort:  int32(dst.GetPortValue()),
+		Protocol: "TCP",
+	}
+}
+
+// wafRequest converts the HTTP attributes of a CheckRequest, including up to maxBodyBytes of the body, into a WAF
+// request.
+func wafRequest(req *authz.CheckRequest, maxBodyBytes int) *waf.Request {
+	attr := req.GetAttributes()
+	src := attr.GetSource().GetAddress().GetSocketAddress()
+	dst := attr.GetDestination().GetAddress().GetSocketAddress()
+	http := attr.GetRequest().GetHttp()
+	r := &waf.Request{
+		SourceAddress:      src.GetAddress(),
+		SourcePort:         int(src.GetPortValue()),
+		DestinationAddress: dst.GetAddress(),
+		DestinationPort:    int(dst.GetPortValue()),
+		Method:             http.GetMethod(),
+		URI:                http.GetPath(),
+		Protocol:           http.GetProtocol(),
+		Host:               http.GetHost(),
+		Headers:            http.GetHeaders(),
+	}
+	if b := newRequestBody(http, maxBodyBytes).raw; len(b) > 0 {
+		r.Body = b
+	}
+	return r
+}
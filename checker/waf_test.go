@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/statscache"
+	"github.com/projectcalico/app-policy/waf"
+)
+
+type fakeEngine struct {
+	requests []*waf.Request
+	result   *waf.Result
+	err      error
+}
+
+func (f *fakeEngine) Inspect(r *waf.Request) (*waf.Result, error) {
+	f.requests = append(f.requests, r)
+	return f.result, f.err
+}
+
+type fakeStatsReporter struct {
+	stats []statscache.DPStats
+}
+
+func (f *fakeStatsReporter) Add(d statscache.DPStats) {
+	f.stats = append(f.stats, d)
+}
+
+func TestWAFRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := wafCheckRequest()
+	Expect(wafRequest(req, DefaultMaxBodyBytes)).To(Equal(&waf.Request{
+		SourceAddress:      "10.0.0.1",
+		SourcePort:         41234,
+		DestinationAddress: "10.0.0.2",
+		DestinationPort:    8080,
+		Method:             "POST",
+		URI:                "/search?q=1",
+		Protocol:           "HTTP/1.1",
+		Host:               "example.com",
+		Headers:            map[string]string{"content-type": "text/plain"},
+		Body:               []byte("hello"),
+	}))
+}
+
+func wafCheckRequest() *authz.CheckRequest {
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address:       "10.0.0.1",
+				PortSpecifier: &core.SocketAddress_PortValue{PortValue: 41234},
+			}}},
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+				Address:       "10.0.0.2",
+				PortSpecifier: &core.SocketAddress_PortValue{PortValue: 8080},
+			}}},
+		},
+		Request: &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{
+				Method:   "POST",
+				Path:     "/search?q=1",
+				Protocol: "HTTP/1.1",
+				Host:     "example.com",
+				Headers:  map[string]string{"content-type": "text/plain"},
+				Body:     "hello",
+			},
+		},
+	}}
+}
+
+func TestCheckWAF(t *testing.T) {
+	RegisterTestingT(t)
+
+	req := &authz.CheckRequest{}
+	engine := &fakeEngine{result: &waf.Result{Matches: []waf.Match{{RuleID: 920350, Severity: "warning"}}}}
+	Expect(checkWAF(wafRuleset{DefaultWAFRuleset, engine}, false, req, DefaultMaxBodyBytes, nil).Code).To(Equal(OK))
+
+	engine.result = &waf.Result{Blocked: true, RuleID: 949110, Status: 403}
+	st := checkWAF(wafRuleset{DefaultWAFRuleset, engine}, false, req, DefaultMaxBodyBytes, nil)
+	Expect(st.Code).To(Equal(PERMISSION_DENIED))
+	Expect(st.Message).To(ContainSubstring("949110"))
+
+	engine.err = errors.New("boom")
+	Expect(checkWAF(wafRuleset{DefaultWAFRuleset, engine}, false, req, DefaultMaxBodyBytes, nil).Code).To(Equal(INTERNAL))
+}
+
+func TestCheckWAFReportsHits(t *testing.T) {
+	RegisterTestingT(t)
+
+	stats := &fakeStatsReporter{}
+	engine := &fakeEngine{result: &waf.Result{Blocked: true, RuleID: 949110, Matches: []waf.Match{
+		{RuleID: 942100, Severity: "critical", Message: "SQL Injection Attack"},
+		{RuleID: 949110, Severity: "emergency", Message: "Inbound Anomaly Score Exceeded"},
+	}}}
+	Expect(checkWAF(wafRuleset{DefaultWAFRuleset, engine}, false, wafCheckRequest(), DefaultMaxBodyBytes, stats).Code).To(Equal(PERMISSION_DENIED))
+	Expect(stats.stats).To(Equal([]statscache.DPStats{{
+		Tuple: statscache.Tuple{SrcIp: "10.0.0.1", DstIp: "10.0.0.2", SrcPort: 41234, DstPort: 8080, Protocol: "TCP"},
+		Values: statscache.Values{WAFHits: map[statscache.WAFHit]int64{
+			{RuleID: 942100, Severity: "critical", Message: "SQL Injection Attack"}:                           1,
+			{RuleID: 949110, Severity: "emergency", Message: "Inbound Anomaly Score Exceeded", Blocked: true}: 1,
+		}},
+	}}))
+
+	// Requests that match no rules report nothing.
+	engine.result = &waf.Result{}
+	Expect(checkWAF(wafRuleset{DefaultWAFRuleset, engine}, false, wafCheckRequest(), DefaultMaxBodyBytes, stats).Code).To(Equal(OK))
+	Expect(stats.stats).To(HaveLen(1))
+}
+
+// The WAF only sees requests that policy allows, and can deny them.
+func TestCheckWithWAF(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	engine := &fakeEngine{result: &waf.Result{Blocked: true, RuleID: 949110}}
+	stores := make(chan *policystore.PolicyStore)
+	uut := NewServer(ctx, stores, WithWAF(engine))
+
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "Allow", SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"steve"}}}},
+	}
+	uut.Store = store
+
+	req := &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/steve",
+		},
+		Destination: &authz.AttributeContext_Peer{
+			Principal: "spiffe://cluster.local/ns/default/sa/sammy",
+		},
+		Request: &authz.AttributeContext_Request{Http: &authz.AttributeContext_HttpRequest{
+			Method: "GET",
+			Path:   "/search?q=1",
+		}},
+	}}
+	resp, err := uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(engine.requests).To(HaveLen(1))
+
+	engine.result = &waf.Result{}
+	resp, err = uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+
+	// Requests denied by policy never reach the WAF.
+	req.Attributes.Source.Principal = "spiffe://cluster.local/ns/default/sa/bob"
+	resp, err = uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(PERMISSION_DENIED))
+	Expect(engine.requests).To(HaveLen(2))
+
+	// Nor do connections from the network ext_authz filter, which have no HTTP request to inspect.
+	req.Attributes.Source.Principal = "spiffe://cluster.local/ns/default/sa/steve"
+	req.Attributes.Request = nil
+	engine.result = &waf.Result{Blocked: true, RuleID: 949110}
+	resp, err = uut.Check(ctx, req)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.GetStatus().GetCode()).To(Equal(OK))
+	Expect(engine.requests).To(HaveLen(2))
+}
+
+func TestCheckWAFRulesetsDetectOnly(t *testing.T) {
+	RegisterTestingT(t)
+
+	stats := &fakeStatsReporter{}
+	crs := &fakeEngine{result: &waf.Result{Blocked: true, RuleID: 949110, Matches: []waf.Match{
+		{RuleID: 949110, Severity: "emergency", Message: "Inbound Anomaly Score Exceeded"},
+	}}}
+	beta := &fakeEngine{result: &waf.Result{}}
+	rulesets := []wafRuleset{{"crs", crs}, {"beta", beta}}
+	modes := fakeModes{"waf/crs": true}
+
+	// The detect-only ruleset reports its match, unblocked, and the request is allowed.
+	Expect(checkWAFRulesets(rulesets, modes, wafCheckRequest(), DefaultMaxBodyBytes, stats).Code).To(Equal(OK))
+	Expect(crs.requests).To(HaveLen(1))
+	Expect(beta.requests).To(HaveLen(1))
+	Expect(stats.stats).To(HaveLen(1))
+	Expect(stats.stats[0].Values.WAFHits).To(Equal(map[statscache.WAFHit]int64{
+		{RuleID: 949110, Severity: "emergency", Message: "Inbound Anomaly Score Exceeded"}: 1,
+	}))
+
+	// Errors in detect-only rulesets don't deny either.
+	crs.err = errors.New("boom")
+	Expect(checkWAFRulesets(rulesets, modes, wafCheckRequest(), DefaultMaxBodyBytes, nil).Code).To(Equal(OK))
+
+	// Every ruleset runs, and any blocking ruleset can deny.
+	crs.err = nil
+	beta.result = &waf.Result{Blocked: true, RuleID: 1001}
+	st := checkWAFRulesets(rulesets, modes, wafCheckRequest(), DefaultMaxBodyBytes, nil)
+	Expect(st.Code).To(Equal(PERMISSION_DENIED))
+	Expect(st.Message).To(ContainSubstring("1001"))
+	Expect(crs.requests).To(HaveLen(3))
+
+	delete(modes, "waf/crs")
+	st = checkWAFRulesets(rulesets, modes, wafCheckRequest(), DefaultMaxBodyBytes, nil)
+	Expect(st.Message).To(ContainSubstring("949110"))
+	Expect(checkWAFRulesets(rulesets, nil, wafCheckRequest(), DefaultMaxBodyBytes, nil).Code).To(
+		Equal(PERMISSION_DENIED))
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/projectcalico/app-policy/uds"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc"
+	"sigs.k8s.io/yaml"
+)
+
+// batchCheck is a single entry in a --requests file.
+type batchCheck struct {
+	// Name identifies the check in the results table. Defaults to the check's index.
+	Name string `json:"name,omitempty"`
+	// Namespace and Account identify the source service account.
+	Namespace string `json:"namespace"`
+	Account   string `json:"account"`
+	// Method and Path, if set, are sent as HTTP request attributes.
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+	// Address and Port are the destination the request is sent to, as the TCP socket address Envoy reports.
+	// Default to 127.0.0.1:80.
+	Address string `json:"address,omitempty"`
+	Port    uint32 `json:"port,omitempty"`
+	// Expect is the expected verdict: "allow", "deny", or a gRPC status code name such as "UNAVAILABLE". If empty
+	// the result is reported but not compared.
+	Expect string `json:"expect,omitempty"`
+}
+
+// runBatchClient sends every check listed in file to the target and prints a table of the results. It exits non-zero
+// if any check fails to send or does not get its expected verdict.
+func runBatchClient(dial, file string) {
+	checks, err := readBatchChecks(file)
+	if err != nil {
+		log.WithError(err).Fatal("Unable to read requests file.")
+	}
+
+	opts := uds.GetDialOptions()
+	conn, err := grpc.Dial(dial, opts...)
+	if err != nil {
+		log.Fatalf("fail to dial: %v", err)
+	}
+	defer conn.Close()
+	client := authz.NewAuthorizationClient(conn)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSOURCE\tMETHOD\tPATH\tRESULT\tEXPECTED\tPASS")
+	failed := 0
+	for i, c := range checks {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		result := sendBatchCheck(client, c)
+		pass := "-"
+		if c.Expect != "" {
+			if result == expectedCode(c.Expect) {
+				pass = "yes"
+			} else {
+				pass = "NO"
+				failed++
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s/%s\t%s\t%s\t%s\t%s\t%s\n",
+			name, c.Namespace, c.Account, orDash(c.Method), orDash(c.Path), result, orDash(c.Expect), pass)
+	}
+	_ = w.Flush()
+
+	if failed > 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "%d of %d checks did not match their expected result\n", failed, len(checks))
+		os.Exit(1)
+	}
+}
+
+func readBatchChecks(file string) ([]batchCheck, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var checks []batchCheck
+	if err := yaml.UnmarshalStrict(b, &checks); err != nil {
+		return nil, err
+	}
+	for i, c := range checks {
+		if c.Namespace == "" || c.Account == "" {
+			return nil, fmt.Errorf("check %d: namespace and account are required", i)
+		}
+	}
+	return checks, nil
+}
+
+// request returns the CheckRequest for the check.
+func (c batchCheck) request() *authz.CheckRequest {
+	address, port := c.Address, c.Port
+	if address == "" {
+		address = defaultCheckAddress
+	}
+	if port == 0 {
+		port = defaultCheckPort
+	}
+	req := newCheckRequest(c.Namespace, c.Account, address, port)
+	if c.Method != "" || c.Path != "" {
+		req.Attributes.Request = &authz.AttributeContext_Request{
+			Http: &authz.AttributeContext_HttpRequest{
+				Method: c.Method,
+				Path:   c.Path,
+			},
+		}
+	}
+	return req
+}
+
+// sendBatchCheck sends a single check and returns the name of the response status code, or "ERROR" if the check
+// could not be sent.
+func sendBatchCheck(client authz.AuthorizationClient, c batchCheck) string {
+	resp, err := client.Check(context.Background(), c.request())
+	if err != nil {
+		log.WithError(err).WithField("check", c).Warn("Check failed")
+		return "ERROR"
+	}
+	return code.Code(resp.GetStatus().GetCode()).String()
+}
+
+// expectedCode converts the expect field of a check into the status code name it should produce.
+func expectedCode(expect string) string {
+	switch strings.ToLower(expect) {
+	case "allow":
+		return code.Code_OK.String()
+	case "deny":
+		return code.Code_PERMISSION_DENIED.String()
+	}
+	return strings.ToUpper(expect)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
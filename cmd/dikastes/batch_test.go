@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/projectcalico/app-policy/checker"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// serverClient sends checks straight to an authorization server.
+type serverClient struct {
+	server authz.AuthorizationServer
+}
+
+func (c serverClient) Check(ctx context.Context, req *authz.CheckRequest, _ ...grpc.CallOption) (*authz.CheckResponse, error) {
+	return c.server.Check(ctx, req)
+}
+
+// Batch checks are sent to a TCP destination, so policy that allows their source allows them.
+func TestSendBatchCheck(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	uut := checker.NewServer(ctx, make(chan *policystore.PolicyStore))
+	store := policystore.NewPolicyStore()
+	store.Endpoint = &proto.WorkloadEndpoint{ProfileIds: []string{"default"}}
+	store.ProfileByID[proto.ProfileID{Name: "default"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{
+			Action:                 "Allow",
+			SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"steve"}},
+			DstPorts:               []*proto.PortRange{{First: 80, Last: 80}},
+		}},
+	}
+	uut.Store = store
+	client := serverClient{uut}
+
+	Expect(sendBatchCheck(client, batchCheck{Namespace: "default", Account: "steve", Path: "/"})).To(Equal("OK"))
+	Expect(sendBatchCheck(client, batchCheck{Namespace: "default", Account: "bob"})).To(Equal("PERMISSION_DENIED"))
+	Expect(sendBatchCheck(client, batchCheck{Namespace: "default", Account: "steve", Port: 8080})).
+		To(Equal("PERMISSION_DENIED"))
+
+	req := batchCheck{Namespace: "default", Account: "steve", Address: "10.0.0.2", Port: 8080}.request()
+	addr := req.GetAttributes().GetDestination().GetAddress().GetSocketAddress()
+	Expect(addr.GetAddress()).To(Equal("10.0.0.2"))
+	Expect(addr.GetPortValue()).To(Equal(uint32(8080)))
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2018-2019 Tigera, Inc. All rights reserved.
+// Copyright (c) 2018-2026 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -16,25 +16,67 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/projectcalico/app-policy/anomaly"
+	"github.com/projectcalico/app-policy/auditlog"
+	"github.com/projectcalico/app-policy/bruteforce"
 	"github.com/projectcalico/app-policy/checker"
+	"github.com/projectcalico/app-policy/consul"
+	"github.com/projectcalico/app-policy/decisioncache"
+	"github.com/projectcalico/app-policy/decisionsink"
+	"github.com/projectcalico/app-policy/denybody"
+	"github.com/projectcalico/app-policy/dlp"
+	"github.com/projectcalico/app-policy/dnscache"
+	"github.com/projectcalico/app-policy/endpointslices"
+	"github.com/projectcalico/app-policy/envoyconfig"
+	"github.com/projectcalico/app-policy/evalbudget"
+	"github.com/projectcalico/app-policy/faultinject"
+	"github.com/projectcalico/app-policy/gatewayapi"
+	"github.com/projectcalico/app-policy/geoip"
+	"github.com/projectcalico/app-policy/grpcmsg"
+	"github.com/projectcalico/app-policy/handoff"
 	"github.com/projectcalico/app-policy/health"
+	"github.com/projectcalico/app-policy/hmacsig"
+	"github.com/projectcalico/app-policy/jwks"
+	"github.com/projectcalico/app-policy/kubeevents"
+	"github.com/projectcalico/app-policy/l7flows"
+	"github.com/projectcalico/app-policy/learn"
+	"github.com/projectcalico/app-policy/modes"
+	"github.com/projectcalico/app-policy/policylint"
+	"github.com/projectcalico/app-policy/policystatus"
 	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/profiling"
 	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/ratelimit"
+	"github.com/projectcalico/app-policy/statscache"
 	"github.com/projectcalico/app-policy/syncher"
+	"github.com/projectcalico/app-policy/threatfeed"
+	"github.com/projectcalico/app-policy/trustdomain"
 	"github.com/projectcalico/app-policy/uds"
+	"github.com/projectcalico/app-policy/waf"
 
 	"github.com/docopt/docopt-go"
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
 	authz_v2 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v2"
 	authz_v2alpha "github.com/envoyproxy/go-control-plane/envoy/service/auth/v2alpha"
 	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	extproc "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3alpha"
+	"github.com/projectcalico/libcalico-go/lib/selector"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 const usage = `Dikastes - the decider.
@@ -42,14 +84,164 @@ const usage = `Dikastes - the decider.
 Usage:
   dikastes server [options]
   dikastes client <namespace> <account> [--method <method>] [options]
+  dikastes client --requests <file> [options]
+  dikastes envoy-config [--format <format>] [--api-version <version>] [--failure-mode-allow] [--outbound] [options]
+  dikastes loadgen --qps <n> --profile <file> [--duration <time>] [--concurrency <n>] [options]
 
 Options:
-  <namespace>            Service account namespace.
-  <account>              Service account name.
-  -h --help              Show this screen.
-  -l --listen <port>     Unix domain socket path [default: /var/run/dikastes/dikastes.sock]
-  -d --dial <target>     Target to dial. [default: localhost:50051]
-  --debug                Log at Debug level.`
+  <namespace>                   Service account namespace.
+  <account>                     Service account name.
+  -h --help                     Show this screen.
+  -l --listen <port>            Unix domain socket path [default: /var/run/dikastes/dikastes.sock]
+  -d --dial <target>            Target to dial. [default: localhost:50051]
+  --tcp-listen <addr>           Also serve on this TCP address, e.g. :9095, for Envoys that can't share the Unix
+                                socket. Clients must authenticate with mutual TLS.
+  --tcp-tls-cert <file>         PEM certificate to serve --tcp-listen with. Reloaded when it changes.
+  --tcp-tls-key <file>          PEM key of --tcp-tls-cert.
+  --tcp-tls-ca <file>           PEM certificates of the CAs that issue the certificates of --tcp-listen clients.
+  --tcp-client-spiffe-ids <ids> Comma separated SPIFFE IDs, one of which --tcp-listen clients' certificates must
+                                have. Any certificate the CA issued is accepted if not set.
+  --requests <file>             YAML file listing checks to send; prints a results table.
+  --qps <n>                     Checks per second for loadgen to send.
+  --profile <file>              YAML file of the checks for loadgen to send, in the format of --requests, each with
+                                a weight giving its share of the mix.
+  --duration <time>             How long loadgen sends checks for. [default: 30s]
+  --concurrency <n>             Most checks loadgen waits on responses to at once. [default: 16]
+  --handoff-socket <path>       Unix socket to hand the listening socket and policy over on, to a new Dikastes
+                                started with the same flag, so that upgrades leave no gap in enforcement.
+  --sync-failure-mode <mode>    On Policy Sync errors, "retry" with backoff or "crash" to exit. [default: retry]
+  --readiness-grace-period <t>  How long Dikastes still reports itself ready after losing Policy Sync. [default: 0s]
+  --health-listen <addr>        Address to serve /readiness and /liveness on, e.g. :9094, for Kubernetes HTTP probes.
+  --unsynced-policy-action <a>  Verdict while policy isn't in sync, before the first sync and once
+                                --unsynced-grace-period has passed after losing it: unavailable, deny, allow or
+                                internal-error. [default: unavailable]
+  --unsynced-grace-period <t>   How long to keep enforcing the last synced policy after losing Policy Sync, before
+                                returning --unsynced-policy-action, or indefinitely if negative. [default: 30s]
+  --fallback-verdict <verdict>  Deprecated name for --unsynced-policy-action.
+  --dry-run                     Evaluate policy but allow every request, logging the verdict that would apply.
+  --enforce-namespaces <sel>    Only enforce verdicts for workloads in namespaces whose labels match the selector;
+                                requests for workloads in other namespaces are handled as with --dry-run.
+  --enforce-percent <percent>   Percentage of clients, chosen by a hash of their identity, whose requests are
+                                enforced; the rest are handled as with --dry-run. [default: 100]
+  --format <format>             Config to emit: "envoy" filter or "istio" EnvoyFilter. [default: envoy]
+  --api-version <version>       ext_authz API version for Envoy to call: v2 or v3. [default: v3]
+  --failure-mode-allow          Have Envoy allow requests if Dikastes cannot be reached.
+  --outbound                    Also check requests leaving the workload, against egress policy.
+  --include-peer-certificate    Have Envoy send client certificates, for Dikastes to check their expiry.
+  --cert-expiry-warning <time>  Log and count requests whose client certificates expire within this duration.
+  --deny-expired-certs          Deny requests whose client certificates have expired.
+  --require-mtls                Deny inbound requests that didn't arrive over mTLS, such as plaintext fallback
+                                traffic, with a 426 response.
+  --strict-headers              Deny requests with ambiguous headers, such as both Content-Length and
+                                Transfer-Encoding, rather than evaluating them in canonical form.
+  --hmac-config <file>          YAML file of HMAC signature schemes, and the secret files they use, that rules can
+                                require requests to be signed with.
+  --trust-domains <file>        YAML file naming this cluster and mapping the SPIFFE trust domains of other clusters
+                                in the mesh to their names and this cluster's namespaces, for rules that match the
+                                source's cluster.
+  --principal-templates <t>     Comma separated layouts of the paths of SPIFFE IDs from issuers other than Istio,
+                                e.g. /region/{region}/ns/{namespace}/sa/{serviceaccount}, that give peers'
+                                namespaces and service accounts. Istio's layout is tried last.
+  --deny-templates <file>       YAML file of Go templates, by namespace, for the bodies of responses to requests
+                                that policy denies.
+  --max-body-bytes <n>          Inspect at most this many bytes of request bodies sent by Envoy. [default: 65536]
+  --max-headers <n>             Reject requests with more headers than this, or 0 for no limit. [default: 0]
+  --max-header-bytes <n>        Reject requests whose header names and values total more bytes than this, or 0 for
+                                no limit. [default: 0]
+  --max-request-body <n>        Reject requests with bodies of more bytes than this, or 0 for no limit. [default: 0]
+  --trim-oversized-requests     Evaluate requests over the limits above with the headers, in order of name, that fit
+                                and their bodies truncated, rather than rejecting them.
+  --evaluation-budgets <file>   YAML file of how long policy evaluation may take for requests to each destination
+                                service, and the verdict for requests that take longer.
+  --grpc-inspection <file>      YAML file of gRPC methods, and descriptor sets defining them, whose request
+                                messages rules can match fields of.
+  --waf-crs                     Inspect requests that policy allows with the OWASP Core Rule Set. Needs Dikastes
+                                built with the coraza build tag, e.g. by make build-coraza.
+  --waf-rules <files>           Comma separated SecLang rule files or globs to inspect allowed requests with.
+                                Prefix an entry with <ruleset>= to load it into its own named ruleset; the rest
+                                are loaded with the Core Rule Set into the "default" ruleset. Needs Dikastes
+                                built with the coraza build tag.
+  --enforcement-modes <file>    YAML file setting WAF rulesets and threat feeds to "detect" or "block" mode.
+                                Reloaded on SIGHUP.
+  --rate-limit-config <file>    YAML file of rate limits to apply to requests that policy allows.
+  --brute-force-config <file>   YAML file of login endpoints to count attempts on, for rules to deny or tarpit
+                                sources that make too many.
+  --concurrency-lease <time>    How long a request counts towards concurrency caps if Envoy's access log never
+                                reports it complete. [default: 1m]
+  --response-patterns <file>    YAML file of sensitive patterns that rules can block responses for, when Envoy's
+                                ext_proc filter sends responses to Dikastes.
+  --anomaly-scorer <name>       Score requests for anomalies with the named scorer: "novelty".
+  --anomaly-log-score <n>       Log requests with at least this anomaly score. [default: 0.5]
+  --xff-trusted-hops <n>        Number of proxies in front of Envoy trusted to append the client address to
+                                X-Forwarded-For. [default: 0]
+  --geoip-db <files>            Comma separated MaxMind DB files, e.g. GeoLite2 Country and ASN, that rules
+                                can match client locations against.
+  --threat-feeds <file>         YAML file listing IP blocklists to download for rules to match clients against.
+  --jwt-config <file>           YAML file of JWT issuers and their https:// or file:// JWKS URLs, to validate
+                                tokens that Envoy's jwt_authn filter hasn't for rules that match claims.
+  --consul-intentions <file>    YAML file locating the Consul HTTP API, to read Connect intentions from and
+                                enforce ahead of Calico policy.
+  --gateway-api                 Watch the cluster's RoutePolicyAttachments and only apply the policies attached to a
+                                Gateway API route to its requests.
+  --endpoint-slices             Watch the cluster's EndpointSlices to find the services of destination pods, for
+                                rules that match destination services when Felix doesn't sync services.
+  --denial-events               Raise a Kubernetes Event on a pod when policy repeatedly denies the same source
+                                access to it.
+  --denial-event-threshold <n>  Denials of a source by a policy, within the interval, that raise an Event.
+                                [default: 10]
+  --denial-event-interval <t>   How long denials are counted over, and how often each source and policy can raise
+                                an Event on a pod. [default: 10m]
+  --policy-status <mode>        Report how many requests each policy decides, for policy status: "felix" to send
+                                them to Felix over the Policy Sync connection, or "datastore" to annotate the
+                                policies with them directly.
+  --policy-status-interval <t>  How often "datastore" mode writes the status of the policies hit. [default: 1m]
+  --request-stats               Report counts of the requests allowed and denied on each connection to Felix over
+                                the Policy Sync connection, for its flow logs.
+  --stats-flush-interval <t>    How often statistics reported to Felix are sent. [default: 5s]
+  --tls-fingerprint-header <h>  Request header Envoy sets to the client's TLS fingerprint, e.g. its JA3 hash, if
+                                not in the metadata context. Envoy must overwrite any value the client sends.
+  --shared-endpoints            Serve every workload on the node, checking each request against the policy of the
+                                endpoint with its destination, or for egress its source, address.
+  --istio-peer-metadata         Match source selectors against the workload labels Istio's metadata exchange
+                                reports, when they agree with the source's principal.
+  --upstream-pdp <target>       ext_authz server, e.g. opa:9191, to hand the decision on requests to when they
+                                match pass rules annotated alp.projectcalico.org/delegate.
+  --upstream-pdp-timeout <time> How long the upstream has to decide a request. [default: 200ms]
+  --upstream-pdp-fallback <v>   Verdict if the upstream fails or times out: deny or allow. [default: deny]
+  --decision-cache <url>        memcached://host:port or redis://[:password@]host:port cache to share decisions in
+                                with the other replicas of a node-level Dikastes.
+  --decision-cache-ttl <time>   How long shared decisions are cached for. [default: 5s]
+  --decision-sink <url>         kafka://broker:9092[,broker:9092...]/topic or nats://host:4222[,host:4222...]/subject
+                                to stream every decision to, as JSON, for security analytics.
+  --decision-sink-config <file> YAML file of the TLS settings and credentials to connect to --decision-sink with.
+  --audit-log <file>            File to write a JSON record of every decision to, or - for stdout, so that policy
+                                enforcement can be audited.
+  --audit-log-max-size <mb>     Size in MiB at which the audit log file is rotated. [default: 100]
+  --audit-log-backups <n>       Number of rotated audit log files to keep. [default: 5]
+  --flow-log-url <url>          URL of Calico's flow aggregation service to POST L7 flow logs of the decisions to.
+  --flow-log-token <file>       File of a bearer token to authenticate to the flow aggregation service with.
+  --flow-log-interval <time>    How long decisions are aggregated into flow logs for. [default: 15s]
+  --dns-cache-ttl <time>        How long domain names that egress rules match are cached for. [default: 30s]
+  --forward-auth-listen <addr>  Address to serve authorization subrequests from nginx's auth_request or Traefik's
+                                ForwardAuth on, e.g. :9092, for proxies other than Envoy.
+  --admin-listen <addr>         Address to serve the admin API on, e.g. :9091. It serves Prometheus metrics on
+                                /metrics, the checker's state on /status, the synced policy on /dump and, in a
+                                versioned format for support bundles, on /export, how policy decides a
+                                CheckRequest POSTed as JSON on /explain, the policy clauses Dikastes can't enforce
+                                on /unenforceable-clauses, peer principals that aren't SPIFFE IDs on
+                                /malformed-spiffe-ids and, in learning mode, suggested policies on
+                                /policy-recommendations.
+  --metrics-listen <addr>       Address to serve only Prometheus metrics on, at /metrics, e.g. :9093, for scraping
+                                without exposing the rest of the admin API.
+  --learn <time>                Record the traffic seen for this long, or indefinitely if 0, to suggest policies
+                                allowing it. Usually combined with --dry-run.
+  --learn-path-segments <n>     Number of path segments that suggested rules match prefixes of. [default: 1]
+  --profile-dir <dir>           Directory for profiles captured on SIGUSR1. Defaults to the system temp directory.
+  --profile-duration <time>     How long a CPU profile captured on SIGUSR1 runs for. [default: 30s]
+  --fault-injection <file>      For testing only: YAML file of latency, errors and connection drops to inject into
+                                check handling and the Policy Sync client, to test Envoy's failure_mode_allow and
+                                timeout settings against.
+  --debug                       Log at Debug level.`
 
 var VERSION string
 
@@ -66,56 +258,649 @@ func main() {
 		runServer(arguments)
 	} else if arguments["client"].(bool) {
 		runClient(arguments)
+	} else if arguments["envoy-config"].(bool) {
+		runEnvoyConfig(arguments)
+	} else if arguments["loadgen"].(bool) {
+		runLoadgen(arguments)
 	}
 }
 
 func runServer(arguments map[string]interface{}) {
 	filePath := arguments["--listen"].(string)
 	dial := arguments["--dial"].(string)
-	_, err := os.Stat(filePath)
-	if !os.IsNotExist(err) {
-		// file exists, try to delete it.
-		err := os.Remove(filePath)
+	handoffPath, _ := arguments["--handoff-socket"].(string)
+	var takeover *handoff.Takeover
+	if handoffPath != "" {
+		var err error
+		takeover, err = handoff.TakeOver(handoffPath, handoff.DefaultTimeout)
+		if err != nil {
+			log.WithError(err).Warn("Unable to take over from the previous Dikastes, starting afresh.")
+		}
+	}
+	var lis net.Listener
+	if takeover != nil {
+		log.WithField("listen", filePath).Info("Took over the listening socket of the previous Dikastes.")
+		lis = takeover.Listener
+	} else {
+		_, err := os.Stat(filePath)
+		if !os.IsNotExist(err) {
+			// file exists, try to delete it.
+			err := os.Remove(filePath)
+			if err != nil {
+				log.WithFields(log.Fields{
+					"listen": filePath,
+					"err":    err,
+				}).Fatal("File exists and unable to remove.")
+			}
+		}
+		lis, err = net.Listen("unix", filePath)
 		if err != nil {
 			log.WithFields(log.Fields{
 				"listen": filePath,
 				"err":    err,
-			}).Fatal("File exists and unable to remove.")
+			}).Fatal("Unable to listen.")
+		}
+		err = os.Chmod(filePath, 0777) // Anyone on system can connect.
+		if err != nil {
+			log.Fatal("Unable to set write permission on socket.")
+		}
+	}
+	defer lis.Close()
+	// serving is the listening socket itself, to hand over to the next Dikastes.
+	serving := lis.(*net.UnixListener)
+
+	tcpAddr, _ := arguments["--tcp-listen"].(string)
+	var tcpTLS *tls.Config
+	if tcpAddr != "" {
+		var err error
+		tcpTLS, err = tcpTLSConfig(arguments)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load TLS certificates for --tcp-listen.")
 		}
 	}
-	lis, err := net.Listen("unix", filePath)
+
+	failureMode, err := syncher.ParseFailureMode(arguments["--sync-failure-mode"].(string))
 	if err != nil {
-		log.WithFields(log.Fields{
-			"listen": filePath,
-			"err":    err,
-		}).Fatal("Unable to listen.")
+		log.WithError(err).Fatal("Invalid --sync-failure-mode.")
 	}
-	defer lis.Close()
-	err = os.Chmod(filePath, 0777) // Anyone on system can connect.
+	checkOpts := []checker.ServerOption{
+		checker.WithFallbackVerdict(unsyncedVerdict(arguments)),
+		checker.WithDryRun(arguments["--dry-run"].(bool)),
+		checker.WithStrictHeaders(arguments["--strict-headers"].(bool)),
+		checker.WithRequireMTLS(arguments["--require-mtls"].(bool)),
+		checker.WithIstioPeerMetadata(arguments["--istio-peer-metadata"].(bool)),
+		checker.WithSharedEndpoints(arguments["--shared-endpoints"].(bool)),
+	}
+	if v, ok := arguments["--unsynced-grace-period"].(string); ok {
+		grace, err := time.ParseDuration(v)
+		if err != nil {
+			log.WithField("value", v).Fatal("Invalid --unsynced-grace-period.")
+		}
+		checkOpts = append(checkOpts, checker.WithUnsyncedGracePeriod(grace))
+	}
+	var certWarning time.Duration
+	if v, ok := arguments["--cert-expiry-warning"].(string); ok {
+		if certWarning, err = time.ParseDuration(v); err != nil {
+			log.WithError(err).Fatal("Invalid --cert-expiry-warning.")
+		}
+	}
+	checkOpts = append(checkOpts, checker.WithCertExpiry(certWarning, arguments["--deny-expired-certs"].(bool)))
+	if s, ok := arguments["--enforce-namespaces"].(string); ok {
+		sel, err := selector.Parse(s)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid --enforce-namespaces selector.")
+		}
+		checkOpts = append(checkOpts, checker.WithEnforcedNamespaces(sel))
+	}
+	percent, err := strconv.ParseUint(arguments["--enforce-percent"].(string), 10, 32)
+	if err != nil || percent > 100 {
+		log.WithField("value", arguments["--enforce-percent"]).Fatal("--enforce-percent must be between 0 and 100.")
+	}
+	checkOpts = append(checkOpts, checker.WithEnforcePercent(uint32(percent)))
+	if file, ok := arguments["--hmac-config"].(string); ok {
+		cfg, err := hmacsig.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load HMAC signature config.")
+		}
+		verifier, err := hmacsig.NewVerifier(cfg)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid HMAC signature config.")
+		}
+		checkOpts = append(checkOpts, checker.WithHMACSignatures(verifier))
+	}
+	if file, ok := arguments["--trust-domains"].(string); ok {
+		cfg, err := trustdomain.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load trust domain config.")
+		}
+		mapper, err := trustdomain.NewMapper(cfg)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid trust domain config.")
+		}
+		checkOpts = append(checkOpts, checker.WithTrustDomains(mapper))
+	}
+	if list, ok := arguments["--principal-templates"].(string); ok {
+		var templates []*checker.PrincipalTemplate
+		for _, s := range strings.Split(list, ",") {
+			t, err := checker.ParsePrincipalTemplate(strings.TrimSpace(s))
+			if err != nil {
+				log.WithError(err).Fatal("Invalid --principal-templates.")
+			}
+			templates = append(templates, t)
+		}
+		checkOpts = append(checkOpts, checker.WithPrincipalTemplates(templates...))
+	}
+	if file, ok := arguments["--deny-templates"].(string); ok {
+		cfg, err := denybody.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load deny templates.")
+		}
+		renderer, err := denybody.NewRenderer(cfg)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid deny templates.")
+		}
+		checkOpts = append(checkOpts, checker.WithDenyTemplates(renderer))
+	}
+	maxBody, err := strconv.Atoi(arguments["--max-body-bytes"].(string))
+	if err != nil || maxBody < 0 {
+		log.WithField("value", arguments["--max-body-bytes"]).Fatal("--max-body-bytes must be a non-negative integer.")
+	}
+	checkOpts = append(checkOpts, checker.WithMaxBodyBytes(maxBody))
+	limits := checker.RequestLimits{Trim: arguments["--trim-oversized-requests"].(bool)}
+	for flag, limit := range map[string]*int{
+		"--max-headers":      &limits.MaxHeaders,
+		"--max-header-bytes": &limits.MaxHeaderBytes,
+		"--max-request-body": &limits.MaxBodyBytes,
+	} {
+		n, err := strconv.Atoi(arguments[flag].(string))
+		if err != nil || n < 0 {
+			log.WithField("value", arguments[flag]).Fatal(flag + " must be a non-negative integer.")
+		}
+		*limit = n
+	}
+	if limits.MaxHeaders > 0 || limits.MaxHeaderBytes > 0 || limits.MaxBodyBytes > 0 {
+		checkOpts = append(checkOpts, checker.WithRequestLimits(limits))
+	}
+	if file, ok := arguments["--evaluation-budgets"].(string); ok {
+		cfg, err := evalbudget.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load evaluation budgets.")
+		}
+		checkOpts = append(checkOpts, checker.WithEvaluationBudgets(cfg))
+	}
+	if file, ok := arguments["--grpc-inspection"].(string); ok {
+		cfg, err := grpcmsg.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load gRPC inspection config.")
+		}
+		decoder, err := grpcmsg.NewDecoder(cfg)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid gRPC inspection config.")
+		}
+		checkOpts = append(checkOpts, checker.WithGRPCInspection(decoder))
+	}
+	if file, ok := arguments["--rate-limit-config"].(string); ok {
+		cfg, err := ratelimit.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load rate limit config.")
+		}
+		checkOpts = append(checkOpts, checker.WithRateLimits(cfg))
+	}
+
+	if file, ok := arguments["--brute-force-config"].(string); ok {
+		cfg, err := bruteforce.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load brute-force detection config.")
+		}
+		checkOpts = append(checkOpts, checker.WithBruteForceDetection(cfg))
+	}
+
+	lease, err := time.ParseDuration(arguments["--concurrency-lease"].(string))
+	if err != nil || lease <= 0 {
+		log.WithField("value", arguments["--concurrency-lease"]).Fatal("--concurrency-lease must be a positive duration.")
+	}
+	checkOpts = append(checkOpts, checker.WithConcurrencyLease(lease))
+
+	if file, ok := arguments["--response-patterns"].(string); ok {
+		cfg, err := dlp.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load response patterns.")
+		}
+		scanner, err := dlp.NewScanner(cfg)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid response patterns.")
+		}
+		checkOpts = append(checkOpts, checker.WithResponsePatterns(scanner))
+	}
+
+	var serverOpts []grpc.ServerOption
+	opts := uds.GetDialOptions()
+	if file, ok := arguments["--fault-injection"].(string); ok {
+		cfg, err := faultinject.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load fault injection config.")
+		}
+		log.WithField("config", file).Warn("Injecting faults for testing. Do not use in production.")
+		checkFaults, syncFaults := faultinject.NewInjectors(cfg)
+		if checkFaults != nil {
+			lis = checkFaults.Listener(lis)
+			serverOpts = append(serverOpts, grpc.UnaryInterceptor(checkFaults.UnaryServerInterceptor()))
+		}
+		if syncFaults != nil {
+			opts = append(opts,
+				grpc.WithContextDialer(syncFaults.Dialer(uds.GetDialer())),
+				grpc.WithStreamInterceptor(syncFaults.StreamClientInterceptor()))
+		}
+	}
+
+	// Synchronize the policy store
+	lintReport := policylint.NewReport()
+	readinessGrace, err := time.ParseDuration(arguments["--readiness-grace-period"].(string))
+	if err != nil || readinessGrace < 0 {
+		log.WithField("value", arguments["--readiness-grace-period"]).Fatal("Invalid --readiness-grace-period.")
+	}
+	syncClient := syncher.NewClient(dial, opts,
+		syncher.WithFailureMode(failureMode),
+		syncher.WithLintReport(lintReport),
+		syncher.WithReadinessGracePeriod(readinessGrace))
+
+	if name, ok := arguments["--anomaly-scorer"].(string); ok {
+		scorer, err := anomaly.New(name)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid --anomaly-scorer.")
+		}
+		logScore, err := strconv.ParseFloat(arguments["--anomaly-log-score"].(string), 64)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid --anomaly-log-score.")
+		}
+		checkOpts = append(checkOpts, checker.WithAnomalyScorer(scorer, logScore))
+	}
+
+	hops, err := strconv.Atoi(arguments["--xff-trusted-hops"].(string))
+	if err != nil || hops < 0 {
+		log.WithField("value", arguments["--xff-trusted-hops"]).Fatal("--xff-trusted-hops must be a non-negative integer.")
+	}
+	checkOpts = append(checkOpts, checker.WithTrustedHops(hops))
+	if files, ok := arguments["--geoip-db"].(string); ok {
+		db, err := geoip.Open(strings.Split(files, ",")...)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load GeoIP database.")
+		}
+		checkOpts = append(checkOpts, checker.WithGeoIP(db))
+	}
+
+	if h, ok := arguments["--tls-fingerprint-header"].(string); ok {
+		checkOpts = append(checkOpts, checker.WithTLSFingerprintHeader(h))
+	}
+	dnsTTL, err := time.ParseDuration(arguments["--dns-cache-ttl"].(string))
+	if err != nil {
+		log.WithError(err).Fatal("Invalid --dns-cache-ttl.")
+	}
+	checkOpts = append(checkOpts, checker.WithDomainResolver(dnscache.NewResolver(dnsTTL)))
+
+	var feeds *threatfeed.Manager
+	if file, ok := arguments["--threat-feeds"].(string); ok {
+		cfg, err := threatfeed.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load threat feed config.")
+		}
+		feeds, err = threatfeed.NewManager(cfg)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid threat feed config.")
+		}
+		checkOpts = append(checkOpts, checker.WithThreatFeeds(feeds))
+	}
+
+	var tokens *jwks.Validator
+	if file, ok := arguments["--jwt-config"].(string); ok {
+		cfg, err := jwks.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load JWT config.")
+		}
+		tokens, err = jwks.NewValidator(cfg)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid JWT config.")
+		}
+		checkOpts = append(checkOpts, checker.WithJWTValidation(tokens))
+	}
+
+	var intentions *consul.Source
+	if file, ok := arguments["--consul-intentions"].(string); ok {
+		cfg, err := consul.LoadConfig(file)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load Consul intentions config.")
+		}
+		intentions = consul.NewSource(cfg)
+		checkOpts = append(checkOpts, checker.WithConsulIntentions(intentions))
+	}
+
+	if target, ok := arguments["--upstream-pdp"].(string); ok {
+		timeout, err := time.ParseDuration(arguments["--upstream-pdp-timeout"].(string))
+		if err != nil {
+			log.WithError(err).Fatal("Invalid --upstream-pdp-timeout.")
+		}
+		fallback, err := checker.ParseVerdict(arguments["--upstream-pdp-fallback"].(string))
+		if err != nil {
+			log.WithError(err).Fatal("Invalid --upstream-pdp-fallback.")
+		}
+		conn, err := grpc.Dial(target, grpc.WithInsecure())
+		if err != nil {
+			log.WithError(err).Fatal("Unable to dial upstream policy decision point.")
+		}
+		defer conn.Close()
+		checkOpts = append(checkOpts, checker.WithUpstreamPDP(authz.NewAuthorizationClient(conn), timeout, fallback))
+	}
+
+	if u, ok := arguments["--decision-cache"].(string); ok {
+		ttl, err := time.ParseDuration(arguments["--decision-cache-ttl"].(string))
+		if err != nil || ttl <= 0 {
+			log.WithField("value", arguments["--decision-cache-ttl"]).Fatal(
+				"--decision-cache-ttl must be a positive duration.")
+		}
+		cache, err := decisioncache.New(u, decisioncache.DefaultTimeout)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid --decision-cache.")
+		}
+		checkOpts = append(checkOpts, checker.WithDecisionCache(cache, ttl))
+	}
+
+	var decisionStream *decisionsink.Stream
+	if u, ok := arguments["--decision-sink"].(string); ok {
+		var cfg *decisionsink.Config
+		if file, ok := arguments["--decision-sink-config"].(string); ok {
+			var err error
+			cfg, err = decisionsink.LoadConfig(file)
+			if err != nil {
+				log.WithError(err).Fatal("Unable to load decision sink config.")
+			}
+		}
+		sink, err := decisionsink.New(u, cfg)
+		if err != nil {
+			log.WithError(err).Fatal("Invalid --decision-sink.")
+		}
+		decisionStream = decisionsink.NewStream(sink)
+		checkOpts = append(checkOpts, checker.WithDecisionPublisher(decisionStream))
+	}
+
+	var auditLog *auditlog.Logger
+	if path, ok := arguments["--audit-log"].(string); ok {
+		maxSize, err := strconv.Atoi(arguments["--audit-log-max-size"].(string))
+		if err != nil || maxSize < 1 {
+			log.WithField("value", arguments["--audit-log-max-size"]).Fatal(
+				"--audit-log-max-size must be a positive integer.")
+		}
+		backups, err := strconv.Atoi(arguments["--audit-log-backups"].(string))
+		if err != nil || backups < 0 {
+			log.WithField("value", arguments["--audit-log-backups"]).Fatal(
+				"--audit-log-backups must be a non-negative integer.")
+		}
+		auditLog = auditlog.New(path)
+		auditLog.MaxBytes = int64(maxSize) << 20
+		auditLog.Backups = backups
+		checkOpts = append(checkOpts, checker.WithDecisionPublisher(auditLog))
+	}
+
+	var flowLogs *l7flows.Aggregator
+	if u, ok := arguments["--flow-log-url"].(string); ok {
+		interval, err := time.ParseDuration(arguments["--flow-log-interval"].(string))
+		if err != nil || interval <= 0 {
+			log.WithField("value", arguments["--flow-log-interval"]).Fatal(
+				"--flow-log-interval must be a positive duration.")
+		}
+		tokenFile, _ := arguments["--flow-log-token"].(string)
+		flowLogs = l7flows.NewAggregator(u, tokenFile)
+		flowLogs.Interval = interval
+		flowLogs.Node = os.Getenv("NODENAME")
+		checkOpts = append(checkOpts, checker.WithDecisionPublisher(flowLogs))
+	}
+
+	var denialEvents *kubeevents.Recorder
+	if arguments["--denial-events"].(bool) {
+		threshold, err := strconv.Atoi(arguments["--denial-event-threshold"].(string))
+		if err != nil || threshold < 1 {
+			log.WithField("value", arguments["--denial-event-threshold"]).Fatal(
+				"--denial-event-threshold must be a positive integer.")
+		}
+		interval, err := time.ParseDuration(arguments["--denial-event-interval"].(string))
+		if err != nil || interval <= 0 {
+			log.WithField("value", arguments["--denial-event-interval"]).Fatal(
+				"--denial-event-interval must be a positive duration.")
+		}
+		cfg, err := gatewayapi.InClusterConfig()
+		if err != nil {
+			log.WithError(err).Fatal("Unable to configure Kubernetes API client.")
+		}
+		denialEvents = kubeevents.NewRecorder(cfg)
+		denialEvents.Threshold = threshold
+		denialEvents.Interval = interval
+		// Calico's manifests give its components the node name in NODENAME.
+		denialEvents.Node = os.Getenv("NODENAME")
+		checkOpts = append(checkOpts, checker.WithDenialRecorder(denialEvents))
+	}
+
+	var attachments *gatewayapi.Controller
+	if arguments["--gateway-api"].(bool) {
+		cfg, err := gatewayapi.InClusterConfig()
+		if err != nil {
+			log.WithError(err).Fatal("Unable to configure Kubernetes API client.")
+		}
+		attachments = gatewayapi.NewController(cfg)
+		checkOpts = append(checkOpts, checker.WithPolicyAttachments(attachments))
+	}
+
+	var serviceEndpoints *endpointslices.Watcher
+	if arguments["--endpoint-slices"].(bool) {
+		cfg, err := gatewayapi.InClusterConfig()
+		if err != nil {
+			log.WithError(err).Fatal("Unable to configure Kubernetes API client.")
+		}
+		serviceEndpoints = endpointslices.NewWatcher(cfg)
+		checkOpts = append(checkOpts, checker.WithServiceEndpoints(serviceEndpoints))
+	}
+
+	// WAF rule hits, request counts, and policy hits in "felix" mode, are reported to Felix over the Policy Sync
+	// connection.
+	var statsCache *statscache.StatsCache
+	crs, files := arguments["--waf-crs"].(bool), arguments["--waf-rules"]
+	policyStatus, _ := arguments["--policy-status"].(string)
+	requestStats := arguments["--request-stats"].(bool)
+	if crs || files != nil || policyStatus == "felix" || requestStats {
+		flushInterval, err := time.ParseDuration(arguments["--stats-flush-interval"].(string))
+		if err != nil || flushInterval <= 0 {
+			log.WithField("value", arguments["--stats-flush-interval"]).Fatal(
+				"--stats-flush-interval must be a positive duration.")
+		}
+		statsCache = statscache.New(flushInterval, syncClient.OnStatsCacheFlush)
+	}
+	if requestStats {
+		checkOpts = append(checkOpts, checker.WithRequestStats(statsCache))
+	}
+	if crs || files != nil {
+		var entries []string
+		if files != nil {
+			entries = strings.Split(files.(string), ",")
+		}
+		for _, rs := range wafRulesets(crs, entries) {
+			engine, err := waf.NewCoraza(rs.config)
+			if err == waf.ErrNotSupported {
+				log.Fatal("--waf-crs and --waf-rules need Dikastes built with the coraza build tag.")
+			} else if err != nil {
+				log.WithError(err).WithField("ruleset", rs.name).Fatal("Unable to load WAF rules.")
+			}
+			checkOpts = append(checkOpts, checker.WithWAFRuleset(rs.name, engine))
+		}
+		checkOpts = append(checkOpts, checker.WithStatsReporter(statsCache))
+	}
+	var statusWriter *policystatus.Writer
+	switch policyStatus {
+	case "":
+	case "felix":
+		checkOpts = append(checkOpts, checker.WithPolicyStatus(statsCache))
+	case "datastore":
+		interval, err := time.ParseDuration(arguments["--policy-status-interval"].(string))
+		if err != nil || interval <= 0 {
+			log.WithField("value", arguments["--policy-status-interval"]).Fatal(
+				"--policy-status-interval must be a positive duration.")
+		}
+		cfg, err := gatewayapi.InClusterConfig()
+		if err != nil {
+			log.WithError(err).Fatal("Unable to configure Kubernetes API client.")
+		}
+		// Each Dikastes reports under its own name, which in a pod is the pod's name.
+		reporter, err := os.Hostname()
+		if err != nil {
+			log.WithError(err).Fatal("Unable to get hostname to report policy status under.")
+		}
+		statusWriter = policystatus.NewWriter(cfg, reporter)
+		statusWriter.Interval = interval
+		checkOpts = append(checkOpts, checker.WithPolicyStatus(statusWriter))
+	default:
+		log.WithField("value", policyStatus).Fatal(`--policy-status must be "felix" or "datastore".`)
+	}
+	var enforcementModes *modes.Modes
+	modesFile, reloadModes := arguments["--enforcement-modes"].(string)
+	if reloadModes {
+		cfg, err := modes.LoadConfig(modesFile)
+		if err != nil {
+			log.WithError(err).Fatal("Unable to load enforcement modes.")
+		}
+		enforcementModes = modes.New(cfg)
+		checkOpts = append(checkOpts, checker.WithEnforcementModes(enforcementModes))
+	}
+	var recorder *learn.Recorder
+	if v, ok := arguments["--learn"].(string); ok {
+		window, err := time.ParseDuration(v)
+		if err != nil || window < 0 {
+			log.WithField("value", v).Fatal("--learn must be a non-negative duration.")
+		}
+		segments, err := strconv.Atoi(arguments["--learn-path-segments"].(string))
+		if err != nil || segments < 0 {
+			log.WithField("value", arguments["--learn-path-segments"]).Fatal(
+				"--learn-path-segments must be a non-negative integer.")
+		}
+		recorder = learn.NewRecorder(time.Now(), window, segments)
+		checkOpts = append(checkOpts, checker.WithLearning(recorder))
+	}
+	profileDuration, err := time.ParseDuration(arguments["--profile-duration"].(string))
 	if err != nil {
-		log.Fatal("Unable to set write permission on socket.")
+		log.WithError(err).Fatal("Invalid --profile-duration.")
+	}
+	capturer := &profiling.Capturer{Duration: profileDuration}
+	if dir, ok := arguments["--profile-dir"].(string); ok {
+		capturer.Dir = dir
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	// Check server
-	gs := grpc.NewServer()
+	gs := grpc.NewServer(serverOpts...)
 	stores := make(chan *policystore.PolicyStore)
-	checkServer := checker.NewServer(ctx, stores)
+	checkServer := checker.NewServer(ctx, stores, checkOpts...)
 	authz.RegisterAuthorizationServer(gs, checkServer)
 	checkServerV2 := checkServer.V2Compat()
 	authz_v2alpha.RegisterAuthorizationServer(gs, checkServerV2)
 	authz_v2.RegisterAuthorizationServer(gs, checkServerV2)
+	// Envoy's access log reports completed requests, so they stop counting towards concurrency caps.
+	accesslog.RegisterAccessLogServiceServer(gs, checkServer.AccessLogServer())
+	// Envoy's ext_proc filter sends responses for inspection.
+	extproc.RegisterExternalProcessorServer(gs, checkServer.ExtProcServer())
 
-	// Synchronize the policy store
-	opts := uds.GetDialOptions()
-	syncClient := syncher.NewClient(dial, opts)
-
-	// Register the health check service, which reports the syncClient's inSync status.
+	// Register the health check services, which report the syncClient's inSync status.
 	proto.RegisterHealthzServer(gs, health.NewHealthCheckService(syncClient))
+	healthpb.RegisterHealthServer(gs, health.NewGRPCHealthServer(syncClient))
 
+	if takeover != nil && takeover.Store != nil {
+		// Enforce the previous Dikastes's policy until this one has synced its own.
+		stores <- takeover.Store
+	}
 	go syncClient.Sync(ctx, stores)
+	if statsCache != nil {
+		go statsCache.Start(ctx)
+	}
+	if feeds != nil {
+		feeds.Start(ctx)
+	}
+	if tokens != nil {
+		tokens.Start(ctx)
+	}
+	if intentions != nil {
+		intentions.Start(ctx)
+	}
+	if attachments != nil {
+		attachments.Start(ctx)
+	}
+	if serviceEndpoints != nil {
+		serviceEndpoints.Start(ctx)
+	}
+	if denialEvents != nil {
+		denialEvents.Start(ctx)
+	}
+	if statusWriter != nil {
+		statusWriter.Start(ctx)
+	}
+	if decisionStream != nil {
+		decisionStream.Start(ctx)
+	}
+	if flowLogs != nil {
+		flowLogs.Start(ctx)
+	}
+	if auditLog != nil {
+		if err := auditLog.Start(ctx); err != nil {
+			log.WithError(err).Fatal("Unable to open --audit-log.")
+		}
+	}
+
+	// Capture CPU and heap profiles and a goroutine dump on SIGUSR1.
+	go capturer.OnSignal(ctx, syscall.SIGUSR1)
+	if reloadModes {
+		go enforcementModes.ReloadOnSignal(ctx, modesFile, syscall.SIGHUP)
+	}
+
+	if addr, ok := arguments["--admin-listen"].(string); ok {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		mux.Handle("/status", checkServer.Status())
+		mux.Handle("/dump", checkServer.Dump())
+		mux.Handle("/export", checkServer.Export())
+		mux.Handle("/explain", checkServer.Explain())
+		mux.Handle("/unenforceable-clauses", lintReport)
+		mux.Handle("/malformed-spiffe-ids", checkServer.MalformedSPIFFEIDs())
+		if recorder != nil {
+			mux.Handle("/policy-recommendations", recorder)
+		}
+		go func() {
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				log.WithError(err).Fatal("Failed to serve admin API.")
+			}
+		}()
+	}
+
+	if addr, ok := arguments["--health-listen"].(string); ok {
+		go func() {
+			if err := http.ListenAndServe(addr, health.NewHTTPHandler(syncClient)); err != nil {
+				log.WithError(err).Fatal("Failed to serve health checks.")
+			}
+		}()
+	}
+
+	if addr, ok := arguments["--metrics-listen"].(string); ok {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.Handler())
+		go func() {
+			if err := http.ListenAndServe(addr, mux); err != nil {
+				log.WithError(err).Fatal("Failed to serve metrics.")
+			}
+		}()
+	}
+
+	if addr, ok := arguments["--forward-auth-listen"].(string); ok {
+		go func() {
+			if err := http.ListenAndServe(addr, checkServer.ForwardAuth()); err != nil {
+				log.WithError(err).Fatal("Failed to serve forward auth endpoint.")
+			}
+		}()
+	}
 
 	// Run gRPC server on separate goroutine so we catch any signals and clean up.
 	go func() {
@@ -124,15 +909,131 @@ func runServer(arguments map[string]interface{}) {
 		}
 	}()
 
+	// handedOff is closed once a new Dikastes has taken over serving.
+	var handedOff chan struct{}
+	if takeover != nil {
+		if err := takeover.Ready(); err != nil {
+			log.WithError(err).Warn("Unable to tell the previous Dikastes to stop serving.")
+		}
+	}
+	if tcpAddr != "" {
+		go serveTCP(gs, tcpAddr, tcpTLS, takeover != nil)
+	}
+	if handoffPath != "" {
+		hs, err := handoff.NewServer(handoffPath, serving, func() *policystore.Export {
+			store := checkServer.Store
+			if store == nil {
+				return nil
+			}
+			var e *policystore.Export
+			store.Read(func(ps *policystore.PolicyStore) { e = ps.Export() })
+			return e
+		})
+		if err != nil {
+			log.WithError(err).Fatal("Unable to listen on the handoff socket.")
+		}
+		handedOff = make(chan struct{})
+		go func() {
+			if err := hs.Serve(ctx); err == nil {
+				close(handedOff)
+			} else if ctx.Err() == nil {
+				log.WithError(err).Error("Handoff socket failed.")
+			}
+		}()
+	}
+
 	// Use a buffered channel so we don't miss any signals
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
-	// Block until a signal is received.
-	log.Infof("Got signal: %v", <-c)
+	// Block until a signal is received, or a new Dikastes takes over.
+	select {
+	case sig := <-c:
+		log.Infof("Got signal: %v", sig)
+	case <-handedOff:
+		log.Info("Handed over to the new Dikastes, draining connections.")
+		gs.GracefulStop()
+	}
+}
+
+// tcpTLSConfig returns the mutual TLS config of --tcp-listen.
+func tcpTLSConfig(arguments map[string]interface{}) (*tls.Config, error) {
+	var f uds.TLSFiles
+	for _, opt := range []struct {
+		flag string
+		path *string
+	}{{"--tcp-tls-cert", &f.CertFile}, {"--tcp-tls-key", &f.KeyFile}, {"--tcp-tls-ca", &f.CAFile}} {
+		path, _ := arguments[opt.flag].(string)
+		if path == "" {
+			return nil, fmt.Errorf("%s is required with --tcp-listen", opt.flag)
+		}
+		*opt.path = path
+	}
+	if ids, ok := arguments["--tcp-client-spiffe-ids"].(string); ok {
+		for _, id := range strings.Split(ids, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				f.SPIFFEIDs = append(f.SPIFFEIDs, id)
+			}
+		}
+	}
+	return uds.NewTLSConfig(f)
+}
+
+// serveTCP serves gs on a TCP address with mutual TLS. After taking over from a previous Dikastes, which holds the
+// address until it stops serving, it retries listening until the handoff timeout.
+func serveTCP(gs *grpc.Server, addr string, config *tls.Config, tookOver bool) {
+	deadline := time.Now().Add(handoff.DefaultTimeout)
+	for {
+		lis, err := uds.ListenTLS(addr, config)
+		if err == nil {
+			log.WithField("listen", addr).Info("Serving over TCP with mutual TLS.")
+			if err := gs.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+				log.WithError(err).Fatal("Failed to serve over TCP.")
+			}
+			return
+		}
+		if !tookOver || time.Now().After(deadline) {
+			log.WithError(err).WithField("listen", addr).Fatal("Unable to listen on TCP.")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// namedWAFConfig is the config of a WAF ruleset.
+type namedWAFConfig struct {
+	name   string
+	config waf.Config
+}
+
+// wafRulesets groups --waf-rules entries into rulesets, in the order they are first named. Entries without a
+// "<ruleset>=" prefix join the Core Rule Set, if crs is set, in the default ruleset.
+func wafRulesets(crs bool, entries []string) []namedWAFConfig {
+	rulesets := []namedWAFConfig{{name: checker.DefaultWAFRuleset, config: waf.Config{CoreRuleSet: crs}}}
+	index := map[string]int{checker.DefaultWAFRuleset: 0}
+	for _, e := range entries {
+		name, pattern := checker.DefaultWAFRuleset, e
+		if i := strings.Index(e, "="); i >= 0 {
+			name, pattern = e[:i], e[i+1:]
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(rulesets)
+			index[name] = i
+			rulesets = append(rulesets, namedWAFConfig{name: name})
+		}
+		rulesets[i].config.Files = append(rulesets[i].config.Files, pattern)
+	}
+	if !crs && len(rulesets[0].config.Files) == 0 {
+		rulesets = rulesets[1:]
+	}
+	return rulesets
 }
 
 func runClient(arguments map[string]interface{}) {
+	if file, ok := arguments["--requests"].(string); ok {
+		runBatchClient(arguments["--dial"].(string), file)
+		return
+	}
 	dial := arguments["--dial"].(string)
 	namespace := arguments["<namespace>"].(string)
 	account := arguments["<account>"].(string)
@@ -146,14 +1047,7 @@ func runClient(arguments map[string]interface{}) {
 	}
 	defer conn.Close()
 	client := authz.NewAuthorizationClient(conn)
-	req := authz.CheckRequest{
-		Attributes: &authz.AttributeContext{
-			Source: &authz.AttributeContext_Peer{
-				Principal: fmt.Sprintf("spiffe://cluster.local/ns/%s/sa/%s",
-					namespace, account),
-			},
-		},
-	}
+	req := newCheckRequest(namespace, account, defaultCheckAddress, defaultCheckPort)
 	if useMethod {
 		req.Attributes.Request = &authz.AttributeContext_Request{
 			Http: &authz.AttributeContext_HttpRequest{
@@ -161,9 +1055,67 @@ func runClient(arguments map[string]interface{}) {
 			},
 		}
 	}
-	resp, err := client.Check(context.Background(), &req)
+	resp, err := client.Check(context.Background(), req)
 	if err != nil {
 		log.Fatalf("Failed %v", err)
 	}
 	log.Infof("Check response:\n %v", resp)
 }
+
+// unsyncedVerdict returns the verdict for checks made while policy isn't in sync.
+func unsyncedVerdict(arguments map[string]interface{}) int32 {
+	flag := "--unsynced-policy-action"
+	if _, ok := arguments["--fallback-verdict"].(string); ok {
+		flag = "--fallback-verdict"
+	}
+	v, err := checker.ParseVerdict(arguments[flag].(string))
+	if err != nil {
+		log.WithError(err).Fatalf("Invalid %s.", flag)
+	}
+	return v
+}
+
+// runEnvoyConfig prints the Envoy ext_authz filter config for a Dikastes server started with the same options.
+func runEnvoyConfig(arguments map[string]interface{}) {
+	fallback := unsyncedVerdict(arguments)
+	opts := envoyconfig.Options{
+		SocketPath: arguments["--listen"].(string),
+		APIVersion: arguments["--api-version"].(string),
+		// If Dikastes is configured to allow traffic before it is in sync, Envoy should do the same when it can't
+		// reach Dikastes at all.
+		FailureModeAllow:       arguments["--failure-mode-allow"].(bool) || fallback == checker.OK,
+		Outbound:               arguments["--outbound"].(bool),
+		IncludePeerCertificate: arguments["--include-peer-certificate"].(bool),
+	}
+	out, err := envoyconfig.Render(opts, arguments["--format"].(string))
+	if err != nil {
+		log.WithError(err).Fatal("Unable to generate Envoy config.")
+	}
+	fmt.Print(string(out))
+}
+
+// The destination of client checks that don't give one.
+const (
+	defaultCheckAddress = "127.0.0.1"
+	defaultCheckPort    = 80
+)
+
+// newCheckRequest returns a CheckRequest whose source is the given service account, sent to the given TCP address and
+// port. Policy rules check the destination's protocol, so requests need one to be allowed.
+func newCheckRequest(namespace, account, address string, port uint32) *authz.CheckRequest {
+	return &authz.CheckRequest{
+		Attributes: &authz.AttributeContext{
+			Source: &authz.AttributeContext_Peer{
+				Principal: fmt.Sprintf("spiffe://cluster.local/ns/%s/sa/%s",
+					namespace, account),
+			},
+			Destination: &authz.AttributeContext_Peer{
+				Address: &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+					Address:       address,
+					PortSpecifier: &core.SocketAddress_PortValue{PortValue: port},
+					Protocol:      core.SocketAddress_TCP,
+				}}},
+			},
+		},
+	}
+}
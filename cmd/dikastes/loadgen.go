@@ -0,0 +1,236 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/projectcalico/app-policy/uds"
+
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc"
+	"sigs.k8s.io/yaml"
+)
+
+// loadgenProfile is a --profile file: the mix of checks for loadgen to send.
+type loadgenProfile struct {
+	Requests []loadgenRequest `json:"requests"`
+}
+
+// loadgenRequest is a check in a loadgen profile. It has the fields of a --requests entry, and is sent in proportion
+// to its weight.
+type loadgenRequest struct {
+	batchCheck
+	// Weight is the share of checks that are this one, relative to the weights of the others. Defaults to 1.
+	Weight *int `json:"weight,omitempty"`
+}
+
+// loadgenStats are the results of the checks sent for one request in a profile.
+type loadgenStats struct {
+	latencies  []time.Duration
+	results    map[string]int
+	unexpected int
+}
+
+// runLoadgen sends checks from the profile to the target at qps checks per second for the duration, with at most
+// concurrency in flight, and prints their results and latency percentiles.
+func runLoadgen(arguments map[string]interface{}) {
+	qps, err := strconv.ParseFloat(arguments["--qps"].(string), 64)
+	if err != nil || qps <= 0 || qps > float64(time.Second) {
+		log.WithField("value", arguments["--qps"]).Fatal("--qps must be a positive number.")
+	}
+	duration, err := time.ParseDuration(arguments["--duration"].(string))
+	if err != nil || duration <= 0 {
+		log.WithField("value", arguments["--duration"]).Fatal("--duration must be a positive duration.")
+	}
+	concurrency, err := strconv.Atoi(arguments["--concurrency"].(string))
+	if err != nil || concurrency < 1 {
+		log.WithField("value", arguments["--concurrency"]).Fatal("--concurrency must be a positive integer.")
+	}
+	profile, err := readLoadgenProfile(arguments["--profile"].(string))
+	if err != nil {
+		log.WithError(err).Fatal("Unable to read load profile.")
+	}
+
+	opts := uds.GetDialOptions()
+	conn, err := grpc.Dial(arguments["--dial"].(string), opts...)
+	if err != nil {
+		log.Fatalf("fail to dial: %v", err)
+	}
+	defer conn.Close()
+	client := authz.NewAuthorizationClient(conn)
+
+	requests := make([]*authz.CheckRequest, len(profile.Requests))
+	stats := make([]*loadgenStats, len(profile.Requests))
+	// cumulative[i] is the total weight of the requests up to and including i.
+	cumulative := make([]int, len(profile.Requests))
+	total := 0
+	for i, r := range profile.Requests {
+		requests[i] = r.request()
+		stats[i] = &loadgenStats{results: map[string]int{}}
+		total += r.weight()
+		cumulative[i] = total
+	}
+
+	// Checks are sent on a schedule regardless of how quickly they are answered, as Envoy would send them. Checks
+	// that fall due while all the workers are busy are skipped and counted, rather than queued.
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for n := 0; n < concurrency; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				start := time.Now()
+				resp, err := client.Check(context.Background(), requests[i])
+				latency := time.Since(start)
+				result := "ERROR"
+				if err == nil {
+					result = code.Code(resp.GetStatus().GetCode()).String()
+				}
+				mu.Lock()
+				s := stats[i]
+				s.latencies = append(s.latencies, latency)
+				s.results[result]++
+				if expect := profile.Requests[i].Expect; expect != "" && result != expectedCode(expect) {
+					s.unexpected++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	skipped := 0
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / qps))
+	start := time.Now()
+	deadline := time.NewTimer(duration)
+	rnd := rand.New(rand.NewSource(start.UnixNano()))
+sending:
+	for {
+		select {
+		case <-deadline.C:
+			break sending
+		case <-ticker.C:
+			select {
+			case work <- sort.SearchInts(cumulative, rnd.Intn(total)+1):
+			default:
+				skipped++
+			}
+		}
+	}
+	ticker.Stop()
+	close(work)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	printLoadgenStats(profile, stats, elapsed, skipped)
+}
+
+// printLoadgenStats prints a table of the results and latency percentiles of the checks for each request in the
+// profile, and of all of them.
+func printLoadgenStats(profile *loadgenProfile, stats []*loadgenStats, elapsed time.Duration, skipped int) {
+	all := &loadgenStats{results: map[string]int{}}
+	for _, s := range stats {
+		all.latencies = append(all.latencies, s.latencies...)
+		for r, n := range s.results {
+			all.results[r] += n
+		}
+		all.unexpected += s.unexpected
+	}
+	fmt.Printf("Sent %d checks in %v (%.1f/s); skipped %d that fell due while all workers were busy.\n\n",
+		len(all.latencies), elapsed.Round(time.Millisecond), float64(len(all.latencies))/elapsed.Seconds(), skipped)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tSENT\tRESULTS\tUNEXPECTED\tP50\tP90\tP99\tP99.9\tMAX")
+	for i, r := range profile.Requests {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		printLoadgenRow(w, name, stats[i])
+	}
+	printLoadgenRow(w, "ALL", all)
+	_ = w.Flush()
+}
+
+func printLoadgenRow(w *tabwriter.Writer, name string, s *loadgenStats) {
+	sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+	var results []string
+	for r, n := range s.results {
+		results = append(results, fmt.Sprintf("%s=%d", r, n))
+	}
+	sort.Strings(results)
+	_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%d\t%v\t%v\t%v\t%v\t%v\n", name, len(s.latencies),
+		orDash(strings.Join(results, ",")), s.unexpected, percentile(s.latencies, 0.5), percentile(s.latencies, 0.9),
+		percentile(s.latencies, 0.99), percentile(s.latencies, 0.999), percentile(s.latencies, 1))
+}
+
+// percentile returns the pth quantile of sorted latencies, rounded for display.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i].Round(time.Microsecond)
+}
+
+func (r loadgenRequest) weight() int {
+	if r.Weight == nil {
+		return 1
+	}
+	return *r.Weight
+}
+
+func readLoadgenProfile(file string) (*loadgenProfile, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	p := &loadgenProfile{}
+	if err := yaml.UnmarshalStrict(b, p); err != nil {
+		return nil, err
+	}
+	total := 0
+	for i, r := range p.Requests {
+		if r.Namespace == "" || r.Account == "" {
+			return nil, fmt.Errorf("request %d: namespace and account are required", i)
+		}
+		if r.weight() < 0 {
+			return nil, fmt.Errorf("request %d: weight must not be negative", i)
+		}
+		total += r.weight()
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("no requests with a positive weight")
+	}
+	return p, nil
+}
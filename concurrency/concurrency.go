@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package concurrency tracks the requests each client has in flight, so that they can be capped.
+package concurrency
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultLease is how long a request is counted as in flight if its completion is never reported.
+	DefaultLease = time.Minute
+	// DefaultMaxRequests bounds the number of requests a Tracker tracks.
+	DefaultMaxRequests = 100000
+)
+
+type request struct {
+	id      string
+	key     string
+	expires time.Time
+}
+
+// Tracker counts in-flight requests by key. Requests are identified by their request ID, and stop being counted
+// when they are released or their lease expires. It is safe for concurrent use.
+type Tracker struct {
+	lease       time.Duration
+	maxRequests int
+
+	mu       sync.Mutex
+	inFlight map[string]int
+	requests map[string]*list.Element
+	// leases holds the tracked requests in order of expiry, which is the order they were acquired in.
+	leases *list.List
+}
+
+// NewTracker creates a Tracker that counts requests as in flight for at most lease.
+func NewTracker(lease time.Duration) *Tracker {
+	return &Tracker{
+		lease:       lease,
+		maxRequests: DefaultMaxRequests,
+		inFlight:    map[string]int{},
+		requests:    map[string]*list.Element{},
+		leases:      list.New(),
+	}
+}
+
+// Acquire counts the request with the given ID as in flight under key, if key has fewer than limit requests in
+// flight. It returns false if the key is at its limit. Acquiring a request that is already in flight succeeds
+// without counting it again.
+func (t *Tracker) Acquire(key, id string, limit int, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expire(now)
+	if _, ok := t.requests[id]; ok {
+		return true
+	}
+	if t.inFlight[key] >= limit {
+		return false
+	}
+	if len(t.requests) >= t.maxRequests {
+		r := t.leases.Front().Value.(*request)
+		log.WithField("key", r.key).Warn("Tracking too many in-flight requests, releasing the oldest.")
+		t.remove(t.leases.Front())
+	}
+	t.requests[id] = t.leases.PushBack(&request{id: id, key: key, expires: now.Add(t.lease)})
+	t.inFlight[key]++
+	return true
+}
+
+// Release stops counting the request with the given ID, if it is in flight.
+func (t *Tracker) Release(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.requests[id]; ok {
+		t.remove(e)
+	}
+}
+
+// InFlight returns the number of requests in flight under key.
+func (t *Tracker) InFlight(key string, now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.expire(now)
+	return t.inFlight[key]
+}
+
+// expire releases the requests whose leases have expired. t.mu must be held.
+func (t *Tracker) expire(now time.Time) {
+	for e := t.leases.Front(); e != nil && now.After(e.Value.(*request).expires); e = t.leases.Front() {
+		log.WithField("key", e.Value.(*request).key).Debug("In-flight request lease expired.")
+		t.remove(e)
+	}
+}
+
+// remove stops tracking the request in e. t.mu must be held.
+func (t *Tracker) remove(e *list.Element) {
+	r := t.leases.Remove(e).(*request)
+	delete(t.requests, r.id)
+	if t.inFlight[r.key]--; t.inFlight[r.key] <= 0 {
+		delete(t.inFlight, r.key)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTracker(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := NewTracker(time.Minute)
+	now := time.Unix(1000, 0)
+
+	Expect(uut.Acquire("mallory", "1", 2, now)).To(BeTrue())
+	Expect(uut.Acquire("mallory", "2", 2, now)).To(BeTrue())
+	Expect(uut.Acquire("mallory", "3", 2, now)).To(BeFalse())
+	Expect(uut.Acquire("alice", "4", 2, now)).To(BeTrue())
+	// Requests already in flight aren't counted twice.
+	Expect(uut.Acquire("mallory", "2", 2, now)).To(BeTrue())
+	Expect(uut.InFlight("mallory", now)).To(Equal(2))
+
+	uut.Release("1")
+	uut.Release("1")
+	uut.Release("unknown")
+	Expect(uut.InFlight("mallory", now)).To(Equal(1))
+	Expect(uut.Acquire("mallory", "3", 2, now)).To(BeTrue())
+	Expect(uut.Acquire("mallory", "5", 2, now)).To(BeFalse())
+
+	// Requests whose completion is never reported are released when their lease expires.
+	uut.Release("2")
+	Expect(uut.Acquire("mallory", "5", 2, now.Add(30*time.Second))).To(BeTrue())
+	Expect(uut.InFlight("mallory", now.Add(61*time.Second))).To(Equal(1))
+	Expect(uut.InFlight("alice", now.Add(61*time.Second))).To(Equal(0))
+	Expect(uut.InFlight("mallory", now.Add(2*time.Minute))).To(Equal(0))
+	Expect(uut.inFlight).To(BeEmpty())
+	Expect(uut.requests).To(BeEmpty())
+}
+
+func TestTrackerMaxRequests(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := NewTracker(time.Minute)
+	uut.maxRequests = 2
+	now := time.Unix(1000, 0)
+
+	Expect(uut.Acquire("mallory", "1", 10, now)).To(BeTrue())
+	Expect(uut.Acquire("alice", "2", 10, now.Add(time.Second))).To(BeTrue())
+	Expect(uut.Acquire("alice", "3", 10, now.Add(2*time.Second))).To(BeTrue())
+	Expect(uut.requests).To(HaveLen(2))
+	Expect(uut.InFlight("mallory", now.Add(2*time.Second))).To(Equal(0))
+	Expect(uut.InFlight("alice", now.Add(2*time.Second))).To(Equal(2))
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Wildcard matches any service or namespace in an intention.
+const Wildcard = "*"
+
+// Compile converts intentions into a policy whose inbound rules apply them in order of precedence. Like a Calico tier,
+// the policy allows or denies the requests that an intention matches, and passes the rest, so that Calico policy
+// decides them.
+//
+// The requests of an L7 intention's source and destination that none of its permissions match are passed too, rather
+// than being matched against lower precedence intentions. Permissions that match request headers or path regular
+// expressions can't be enforced: those that allow requests are dropped, and those that deny requests deny them
+// regardless of their headers and path.
+func Compile(intentions []Intention, mirrorNamespaces bool) *proto.Policy {
+	sorted := make([]Intention, len(intentions))
+	copy(sorted, intentions)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Precedence > sorted[j].Precedence })
+
+	policy := &proto.Policy{}
+	for _, in := range sorted {
+		if len(in.Permissions) == 0 {
+			action := strings.ToLower(in.Action)
+			if action != "allow" && action != "deny" {
+				log.WithFields(log.Fields{"id": in.ID, "action": in.Action}).Warn("Ignoring intention with unknown action.")
+				continue
+			}
+			r := pairRule(in, mirrorNamespaces)
+			r.Action = action
+			policy.InboundRules = append(policy.InboundRules, r)
+			continue
+		}
+		for i, p := range in.Permissions {
+			r, ok := permissionRule(in, p, mirrorNamespaces)
+			if !ok {
+				log.WithFields(log.Fields{"id": in.ID, "permission": i}).Warn(
+					"Ignoring intention permission that Dikastes can't enforce.")
+				continue
+			}
+			policy.InboundRules = append(policy.InboundRules, r)
+		}
+		r := pairRule(in, mirrorNamespaces)
+		r.Action = "pass"
+		policy.InboundRules = append(policy.InboundRules, r)
+	}
+	return policy
+}
+
+// permissionRule converts an L7 intention's permission into a rule, returning false if it can't.
+func permissionRule(in Intention, p Permission, mirrorNamespaces bool) (*proto.Rule, bool) {
+	action := strings.ToLower(p.Action)
+	if action != "allow" && action != "deny" {
+		return nil, false
+	}
+	r := pairRule(in, mirrorNamespaces)
+	r.Action = action
+	if p.HTTP == nil {
+		return r, true
+	}
+	unenforceable := len(p.HTTP.Header) > 0 || p.HTTP.PathRegex != ""
+	if unenforceable && action == "allow" {
+		return nil, false
+	}
+	r.HttpMatch = &proto.HTTPMatch{Methods: p.HTTP.Methods}
+	if unenforceable {
+		// Deny the permission's methods on any path.
+		return r, true
+	}
+	switch {
+	case p.HTTP.PathExact != "":
+		r.HttpMatch.Paths = []*proto.HTTPMatch_PathMatch{
+			{PathMatch: &proto.HTTPMatch_PathMatch_Exact{Exact: p.HTTP.PathExact}},
+		}
+	case p.HTTP.PathPrefix != "":
+		r.HttpMatch.Paths = []*proto.HTTPMatch_PathMatch{
+			{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: p.HTTP.PathPrefix}},
+		}
+	}
+	return r, true
+}
+
+// pairRule returns a rule without an action that matches requests from the intention's source to its destination.
+func pairRule(in Intention, mirrorNamespaces bool) *proto.Rule {
+	r := &proto.Rule{RuleId: "consul-intention-" + in.ID}
+	if in.SourceName != Wildcard && in.SourceName != "" {
+		r.SrcServiceAccountMatch = &proto.ServiceAccountMatch{Names: []string{in.SourceName}}
+	}
+	if in.DestinationName != Wildcard && in.DestinationName != "" {
+		r.DstServiceAccountMatch = &proto.ServiceAccountMatch{Names: []string{in.DestinationName}}
+	}
+	if mirrorNamespaces {
+		r.OriginalSrcNamespaceSelector = namespaceSelector(in.SourceNS)
+		r.OriginalDstNamespaceSelector = namespaceSelector(in.DestinationNS)
+	}
+	return r
+}
+
+// namespaceSelector returns a selector for the Kubernetes namespace that mirrors a Consul namespace.
+func namespaceSelector(ns string) string {
+	if ns == Wildcard || ns == "" {
+		return ""
+	}
+	return "projectcalico.org/name == '" + ns + "'"
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package consul reads Consul Connect intentions and compiles them into a policy, so that Dikastes can enforce them
+// alongside Calico policy in environments that mix Consul and Kubernetes services.
+package consul
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultAddress is the Consul agent's HTTP API if the config doesn't say.
+	DefaultAddress = "http://127.0.0.1:8500"
+	// MaxIntentionsBytes bounds the size of the intentions list.
+	MaxIntentionsBytes = 16 << 20
+	// blockingWait is how long a blocking query waits for intentions to change.
+	blockingWait = 5 * time.Minute
+)
+
+// retryInterval is how soon a failed query is retried. It is a variable for tests.
+var retryInterval = 10 * time.Second
+
+// Config is the Consul intentions configuration file.
+type Config struct {
+	// Address is the base URL of the Consul HTTP API. Defaults to the local agent.
+	Address string `json:"address,omitempty"`
+	// Datacenter, if set, is the datacenter to read intentions from, rather than the agent's.
+	Datacenter string `json:"datacenter,omitempty"`
+	// TokenFile, if set, holds the ACL token to read intentions with.
+	TokenFile string `json:"tokenFile,omitempty"`
+	// MirrorNamespaces maps Consul namespaces to Kubernetes namespaces of the same name, as consul-k8s does when
+	// mirroring them. Otherwise intentions match services of the same name in any namespace.
+	MirrorNamespaces bool `json:"mirrorNamespaces,omitempty"`
+}
+
+// LoadConfig reads a Consul intentions configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Intention is a Consul Connect intention, as listed by the /v1/connect/intentions API.
+type Intention struct {
+	ID              string `json:"ID"`
+	SourceNS        string `json:"SourceNS"`
+	SourceName      string `json:"SourceName"`
+	DestinationNS   string `json:"DestinationNS"`
+	DestinationName string `json:"DestinationName"`
+	// Action is "allow" or "deny" for L4 intentions, and empty for L7 intentions, which have Permissions instead.
+	Action      string       `json:"Action"`
+	Permissions []Permission `json:"Permissions"`
+	Precedence  int          `json:"Precedence"`
+}
+
+// Permission is an L7 intention's action for the requests that match it.
+type Permission struct {
+	Action string          `json:"Action"`
+	HTTP   *HTTPPermission `json:"HTTP"`
+}
+
+// HTTPPermission is the HTTP request a Permission applies to.
+type HTTPPermission struct {
+	PathExact  string             `json:"PathExact"`
+	PathPrefix string             `json:"PathPrefix"`
+	PathRegex  string             `json:"PathRegex"`
+	Methods    []string           `json:"Methods"`
+	Header     []HeaderPermission `json:"Header"`
+}
+
+// HeaderPermission is a condition on a request header. Dikastes can't enforce it.
+type HeaderPermission struct {
+	Name string `json:"Name"`
+}
+
+// Source keeps the intentions read from Consul, compiled into a policy.
+type Source struct {
+	config Config
+	client *http.Client
+
+	mu     sync.RWMutex
+	policy *proto.Policy
+	index  uint64
+}
+
+// NewSource creates a Source for config. Call Start to begin reading intentions.
+func NewSource(config *Config) *Source {
+	s := &Source{config: *config, client: &http.Client{Timeout: blockingWait + time.Minute}}
+	if s.config.Address == "" {
+		s.config.Address = DefaultAddress
+	}
+	return s
+}
+
+// Start reads intentions, and then waits for them to change, until ctx is done.
+func (s *Source) Start(ctx context.Context) {
+	go s.watch(ctx)
+}
+
+// Policy returns the intentions compiled into a policy, or nil if they haven't been read yet.
+func (s *Source) Policy() *proto.Policy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.policy
+}
+
+func (s *Source) watch(ctx context.Context) {
+	for ctx.Err() == nil {
+		if err := s.refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.WithError(err).Warn("Failed to read Consul intentions.")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+		}
+	}
+}
+
+// refresh reads the intentions, waiting until they change if they have been read before.
+func (s *Source) refresh(ctx context.Context) error {
+	s.mu.RLock()
+	index := s.index
+	s.mu.RUnlock()
+
+	q := url.Values{}
+	if s.config.Datacenter != "" {
+		q.Set("dc", s.config.Datacenter)
+	}
+	if index != 0 {
+		q.Set("index", strconv.FormatUint(index, 10))
+		q.Set("wait", fmt.Sprintf("%ds", int(blockingWait.Seconds())))
+	}
+	u := strings.TrimSuffix(s.config.Address, "/") + "/v1/connect/intentions?" + q.Encode()
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	if s.config.TokenFile != "" {
+		token, err := ioutil.ReadFile(s.config.TokenFile)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Consul-Token", strings.TrimSpace(string(token)))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var intentions []Intention
+	if err := json.NewDecoder(io.LimitReader(resp.Body, MaxIntentionsBytes)).Decode(&intentions); err != nil {
+		return err
+	}
+	newIndex, err := strconv.ParseUint(resp.Header.Get("X-Consul-Index"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid X-Consul-Index: %v", err)
+	}
+	// Consul's index can go backwards, for example when a server is restored from a snapshot, in which case the next
+	// query must start afresh. An index of 0 would never block, so is treated as 1.
+	if newIndex < index {
+		newIndex = 0
+	} else if newIndex == 0 {
+		newIndex = 1
+	}
+	policy := Compile(intentions, s.config.MirrorNamespaces)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.index != newIndex || s.policy == nil {
+		log.WithFields(log.Fields{"intentions": len(intentions), "index": newIndex}).Info("Read Consul intentions.")
+	}
+	s.policy = policy
+	s.index = newIndex
+	return nil
+}
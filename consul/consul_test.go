@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consul
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestCompile(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := Compile([]Intention{
+		{ID: "1", SourceName: "*", DestinationName: "db", Action: "deny", Precedence: 8},
+		{ID: "2", SourceNS: "web", SourceName: "frontend", DestinationNS: "data", DestinationName: "db", Action: "allow",
+			Precedence: 9},
+		{ID: "3", SourceName: "frontend", DestinationName: "api", Precedence: 9, Permissions: []Permission{
+			{Action: "allow", HTTP: &HTTPPermission{PathPrefix: "/v1/", Methods: []string{"GET"}}},
+			{Action: "allow", HTTP: &HTTPPermission{PathExact: "/admin", Header: []HeaderPermission{{Name: "x-admin"}}}},
+			{Action: "deny", HTTP: &HTTPPermission{PathRegex: "/v[0-9]+/secrets", Methods: []string{"DELETE"}}},
+			{Action: "deny"},
+		}},
+		{ID: "4", SourceName: "batch", DestinationName: "api", Action: "audit", Precedence: 9},
+	}, true)
+
+	Expect(policy.InboundRules).To(Equal([]*proto.Rule{
+		{
+			RuleId:                       "consul-intention-2",
+			Action:                       "allow",
+			SrcServiceAccountMatch:       &proto.ServiceAccountMatch{Names: []string{"frontend"}},
+			DstServiceAccountMatch:       &proto.ServiceAccountMatch{Names: []string{"db"}},
+			OriginalSrcNamespaceSelector: "projectcalico.org/name == 'web'",
+			OriginalDstNamespaceSelector: "projectcalico.org/name == 'data'",
+		},
+		{
+			RuleId:                 "consul-intention-3",
+			Action:                 "allow",
+			SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"frontend"}},
+			DstServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"api"}},
+			HttpMatch: &proto.HTTPMatch{Methods: []string{"GET"}, Paths: []*proto.HTTPMatch_PathMatch{
+				{PathMatch: &proto.HTTPMatch_PathMatch_Prefix{Prefix: "/v1/"}},
+			}},
+		},
+		{
+			RuleId:                 "consul-intention-3",
+			Action:                 "deny",
+			SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"frontend"}},
+			DstServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"api"}},
+			HttpMatch:              &proto.HTTPMatch{Methods: []string{"DELETE"}},
+		},
+		{
+			RuleId:                 "consul-intention-3",
+			Action:                 "deny",
+			SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"frontend"}},
+			DstServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"api"}},
+		},
+		{
+			RuleId:                 "consul-intention-3",
+			Action:                 "pass",
+			SrcServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"frontend"}},
+			DstServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"api"}},
+		},
+		{
+			RuleId:                 "consul-intention-1",
+			Action:                 "deny",
+			DstServiceAccountMatch: &proto.ServiceAccountMatch{Names: []string{"db"}},
+		},
+	}))
+}
+
+func TestSourceBlockingQueries(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "consul")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	Expect(ioutil.WriteFile(tokenFile, []byte("s3cret\n"), 0600)).To(Succeed())
+
+	var mu sync.Mutex
+	var queries []string
+	var tokens []string
+	changed := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		tokens = append(tokens, r.Header.Get("X-Consul-Token"))
+		n := len(queries)
+		mu.Unlock()
+		switch n {
+		case 1:
+			w.Header().Set("X-Consul-Index", "7")
+			fmt.Fprint(w, `[{"ID":"a","SourceName":"web","DestinationName":"db","Action":"allow","Precedence":9}]`)
+		case 2:
+			<-changed
+			w.Header().Set("X-Consul-Index", "8")
+			fmt.Fprint(w, `[{"ID":"b","SourceName":"web","DestinationName":"db","Action":"deny","Precedence":9}]`)
+		default:
+			<-r.Context().Done()
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src := NewSource(&Config{Address: srv.URL, Datacenter: "dc2", TokenFile: tokenFile})
+	Expect(src.Policy()).To(BeNil())
+	src.Start(ctx)
+
+	action := func() string {
+		if p := src.Policy(); p != nil && len(p.InboundRules) > 0 {
+			return p.InboundRules[0].Action
+		}
+		return ""
+	}
+	Eventually(action, time.Second, 10*time.Millisecond).Should(Equal("allow"))
+	close(changed)
+	Eventually(action, time.Second, 10*time.Millisecond).Should(Equal("deny"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	Expect(queries[0]).To(Equal("dc=dc2"))
+	Expect(queries[1]).To(Equal("dc=dc2&index=7&wait=300s"))
+	Expect(tokens[0]).To(Equal("s3cret"))
+}
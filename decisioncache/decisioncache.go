@@ -0,0 +1,153 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package decisioncache stores decisions in memcached or Redis, so that the replicas of a node-level Dikastes
+// serving a busy gateway can share them.
+package decisioncache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds each request to the cache server. Checks evaluate policy themselves rather than wait
+	// longer.
+	DefaultTimeout = 20 * time.Millisecond
+	// maxIdle is the number of idle connections kept to the cache server.
+	maxIdle = 64
+	// maxValueBytes bounds the values read from the cache server.
+	maxValueBytes = 64 << 10
+)
+
+var errValueTooLarge = errors.New("cached value too large")
+
+// Cache stores values for a TTL in a cache server.
+type Cache interface {
+	// Get returns the value stored under key, or found false if there is none.
+	Get(key string) (value []byte, found bool, err error)
+	// Set stores value under key for ttl.
+	Set(key string, value []byte, ttl time.Duration) error
+}
+
+// New connects to the cache server at rawurl, such as "memcached://10.0.0.1:11211" or "redis://:password@redis:6379",
+// with requests bounded by timeout.
+func New(rawurl string, timeout time.Duration) (Cache, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("decision cache URL %q has no host", rawurl)
+	}
+	switch u.Scheme {
+	case "memcached":
+		return &memcached{pool: newPool(u.Host, timeout, nil)}, nil
+	case "redis":
+		password, _ := u.User.Password()
+		return &redis{pool: newPool(u.Host, timeout, redisAuth(password))}, nil
+	}
+	return nil, fmt.Errorf("unknown decision cache scheme %q", u.Scheme)
+}
+
+// conn is a connection to a cache server.
+type conn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// pool holds idle connections to a cache server.
+type pool struct {
+	addr    string
+	timeout time.Duration
+	// init, if set, prepares new connections, such as by authenticating them.
+	init func(*conn) error
+	idle chan *conn
+}
+
+func newPool(addr string, timeout time.Duration, init func(*conn) error) *pool {
+	return &pool{addr: addr, timeout: timeout, init: init, idle: make(chan *conn, maxIdle)}
+}
+
+// do runs f with a connection, idle or new, whose deadline is the pool's timeout. The connection is closed if f fails,
+// since it may have been left mid-response.
+func (p *pool) do(f func(*conn) error) error {
+	var c *conn
+	select {
+	case c = <-p.idle:
+	default:
+		nc, err := net.DialTimeout("tcp", p.addr, p.timeout)
+		if err != nil {
+			return err
+		}
+		c = &conn{Conn: nc, r: bufio.NewReader(nc), w: bufio.NewWriter(nc)}
+		if p.init != nil {
+			if err := c.SetDeadline(time.Now().Add(p.timeout)); err != nil {
+				c.Close()
+				return err
+			}
+			if err := p.init(c); err != nil {
+				c.Close()
+				return err
+			}
+		}
+	}
+	if err := c.SetDeadline(time.Now().Add(p.timeout)); err != nil {
+		c.Close()
+		return err
+	}
+	if err := f(c); err != nil {
+		c.Close()
+		return err
+	}
+	select {
+	case p.idle <- c:
+	default:
+		c.Close()
+	}
+	return nil
+}
+
+// readLine reads a CRLF-terminated line, without the terminator.
+func (c *conn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("malformed response line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// readValue reads a value of n bytes followed by CRLF.
+func (c *conn) readValue(n int) ([]byte, error) {
+	if n < 0 || n > maxValueBytes {
+		return nil, errValueTooLarge
+	}
+	b := make([]byte, n+2)
+	if _, err := io.ReadFull(c.r, b); err != nil {
+		return nil, err
+	}
+	if b[n] != '\r' || b[n+1] != '\n' {
+		return nil, errors.New("malformed value terminator")
+	}
+	return b[:n], nil
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisioncache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeServer serves a cache over a text protocol, recording the TTLs values were set with.
+type fakeServer struct {
+	net.Listener
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]string
+	// password, if set, must be sent with AUTH before other Redis commands.
+	password string
+}
+
+func newFakeServer(password string, serve func(*fakeServer, *bufio.Reader, io.Writer)) *fakeServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ToNot(HaveOccurred())
+	s := &fakeServer{Listener: l, values: map[string]string{}, ttls: map[string]string{}, password: password}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				serve(s, bufio.NewReader(c), c)
+			}()
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) ttl(key string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ttls[key]
+}
+
+func serveMemcached(s *fakeServer, r *bufio.Reader, w io.Writer) {
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		f := strings.Fields(line)
+		s.mu.Lock()
+		switch f[0] {
+		case "get":
+			if v, ok := s.values[f[1]]; ok {
+				fmt.Fprintf(w, "VALUE %s 0 %d\r\n%s\r\n", f[1], len(v), v)
+			}
+			fmt.Fprint(w, "END\r\n")
+		case "set":
+			n, _ := strconv.Atoi(f[4])
+			b := make([]byte, n+2)
+			io.ReadFull(r, b)
+			s.values[f[1]] = string(b[:n])
+			s.ttls[f[1]] = f[3]
+			fmt.Fprint(w, "STORED\r\n")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func serveRedis(s *fakeServer, r *bufio.Reader, w io.Writer) {
+	authed := s.password == ""
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		var args []string
+		for i := 0; i < n; i++ {
+			line, _ = r.ReadString('\n')
+			l, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+			b := make([]byte, l+2)
+			io.ReadFull(r, b)
+			args = append(args, string(b[:l]))
+		}
+		s.mu.Lock()
+		switch {
+		case args[0] == "AUTH" && args[1] == s.password:
+			authed = true
+			fmt.Fprint(w, "+OK\r\n")
+		case !authed:
+			fmt.Fprint(w, "-NOAUTH Authentication required.\r\n")
+		case args[0] == "GET":
+			if v, ok := s.values[args[1]]; ok {
+				fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+			} else {
+				fmt.Fprint(w, "$-1\r\n")
+			}
+		case args[0] == "SET":
+			s.values[args[1]] = args[2]
+			s.ttls[args[1]] = args[3] + " " + args[4]
+			fmt.Fprint(w, "+OK\r\n")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func testCache(c Cache) {
+	_, found, err := c.Get("k1")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(found).To(BeFalse())
+
+	Expect(c.Set("k1", []byte("v1 with spaces\r\n"), 1500*time.Millisecond)).To(Succeed())
+	v, found, err := c.Get("k1")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(found).To(BeTrue())
+	Expect(string(v)).To(Equal("v1 with spaces\r\n"))
+
+	// Connections are reused.
+	for i := 0; i < 10; i++ {
+		_, _, err := c.Get("k1")
+		Expect(err).ToNot(HaveOccurred())
+	}
+}
+
+func TestMemcached(t *testing.T) {
+	RegisterTestingT(t)
+	s := newFakeServer("", serveMemcached)
+	defer s.Close()
+
+	c, err := New("memcached://"+s.Addr().String(), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	testCache(c)
+	// Expiry times round up to whole seconds.
+	Expect(s.ttl("k1")).To(Equal("2"))
+}
+
+func TestRedis(t *testing.T) {
+	RegisterTestingT(t)
+	s := newFakeServer("secret", serveRedis)
+	defer s.Close()
+
+	c, err := New("redis://:secret@"+s.Addr().String(), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	testCache(c)
+	Expect(s.ttl("k1")).To(Equal("PX 1500"))
+
+	c, err = New("redis://:wrong@"+s.Addr().String(), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	_, _, err = c.Get("k1")
+	Expect(err).To(MatchError("NOAUTH Authentication required."))
+}
+
+func TestTimeout(t *testing.T) {
+	RegisterTestingT(t)
+	// A server that never answers.
+	s := newFakeServer("", func(*fakeServer, *bufio.Reader, io.Writer) { time.Sleep(time.Second) })
+	defer s.Close()
+
+	c, err := New("memcached://"+s.Addr().String(), 50*time.Millisecond)
+	Expect(err).ToNot(HaveOccurred())
+	start := time.Now()
+	_, _, err = c.Get("k1")
+	Expect(err).To(HaveOccurred())
+	Expect(time.Since(start)).To(BeNumerically("<", 500*time.Millisecond))
+}
+
+func TestNewInvalid(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, u := range []string{"memcached://", "etcd://cache:2379", "::"} {
+		_, err := New(u, DefaultTimeout)
+		Expect(err).To(HaveOccurred(), u)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisioncache
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// memcached speaks memcached's text protocol.
+type memcached struct {
+	pool *pool
+}
+
+func (m *memcached) Get(key string) (value []byte, found bool, err error) {
+	err = m.pool.do(func(c *conn) error {
+		if _, err := fmt.Fprintf(c.w, "get %s\r\n", key); err != nil {
+			return err
+		}
+		if err := c.w.Flush(); err != nil {
+			return err
+		}
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if line == "END" {
+			return nil
+		}
+		// VALUE <key> <flags> <bytes>
+		f := strings.Fields(line)
+		if len(f) != 4 || f[0] != "VALUE" || f[1] != key {
+			return fmt.Errorf("unexpected memcached response %q", line)
+		}
+		n, err := strconv.Atoi(f[3])
+		if err != nil {
+			return fmt.Errorf("unexpected memcached response %q", line)
+		}
+		if value, err = c.readValue(n); err != nil {
+			return err
+		}
+		if line, err = c.readLine(); err != nil {
+			return err
+		}
+		if line != "END" {
+			return fmt.Errorf("unexpected memcached response %q", line)
+		}
+		found = true
+		return nil
+	})
+	return value, found, err
+}
+
+func (m *memcached) Set(key string, value []byte, ttl time.Duration) error {
+	// memcached expires items in whole seconds, so round up rather than store them forever.
+	exptime := int64((ttl + time.Second - 1) / time.Second)
+	return m.pool.do(func(c *conn) error {
+		if _, err := fmt.Fprintf(c.w, "set %s 0 %d %d\r\n", key, exptime, len(value)); err != nil {
+			return err
+		}
+		if _, err := c.w.Write(value); err != nil {
+			return err
+		}
+		if _, err := c.w.WriteString("\r\n"); err != nil {
+			return err
+		}
+		if err := c.w.Flush(); err != nil {
+			return err
+		}
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if line != "STORED" {
+			return fmt.Errorf("unexpected memcached response %q", line)
+		}
+		return nil
+	})
+}
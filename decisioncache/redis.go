@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisioncache
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// redis speaks the Redis serialization protocol.
+type redis struct {
+	pool *pool
+}
+
+func (r *redis) Get(key string) (value []byte, found bool, err error) {
+	err = r.pool.do(func(c *conn) error {
+		if err := writeCommand(c, "GET", key); err != nil {
+			return err
+		}
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if line == "$-1" {
+			return nil
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return redisError(line)
+		}
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return redisError(line)
+		}
+		if value, err = c.readValue(n); err != nil {
+			return err
+		}
+		found = true
+		return nil
+	})
+	return value, found, err
+}
+
+func (r *redis) Set(key string, value []byte, ttl time.Duration) error {
+	ms := int64(ttl / time.Millisecond)
+	if ms < 1 {
+		ms = 1
+	}
+	return r.pool.do(func(c *conn) error {
+		if err := writeCommand(c, "SET", key, string(value), "PX", strconv.FormatInt(ms, 10)); err != nil {
+			return err
+		}
+		return expectOK(c)
+	})
+}
+
+// redisAuth returns a pool init function that authenticates connections with password, if there is one.
+func redisAuth(password string) func(*conn) error {
+	if password == "" {
+		return nil
+	}
+	return func(c *conn) error {
+		if err := writeCommand(c, "AUTH", password); err != nil {
+			return err
+		}
+		return expectOK(c)
+	}
+}
+
+// writeCommand sends a command as an array of bulk strings.
+func writeCommand(c *conn, args ...string) error {
+	if _, err := fmt.Fprintf(c.w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, a := range args {
+		if _, err := fmt.Fprintf(c.w, "$%d\r\n%s\r\n", len(a), a); err != nil {
+			return err
+		}
+	}
+	return c.w.Flush()
+}
+
+func expectOK(c *conn) error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if line != "+OK" {
+		return redisError(line)
+	}
+	return nil
+}
+
+// redisError describes an error or unexpected reply.
+func redisError(line string) error {
+	if len(line) > 0 && line[0] == '-' {
+		return errors.New(line[1:])
+	}
+	return fmt.Errorf("unexpected redis response %q", line)
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package decisionsink streams authorization decisions, as JSON events, to external systems such as Kafka and NATS,
+// for security analytics platforms to consume.
+package decisionsink
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultTimeout bounds each batch a Stream sends.
+	DefaultTimeout = 5 * time.Second
+	// DefaultBufferSize is the number of events a Stream holds while waiting to send them. Events published while it
+	// is full are dropped.
+	DefaultBufferSize = 10000
+	// DefaultBatchSize is the most events a Stream sends at once.
+	DefaultBatchSize = 500
+	// DefaultFlushInterval is the longest a Stream holds events before sending them.
+	DefaultFlushInterval = time.Second
+)
+
+var (
+	sentEvents = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "dikastes_decision_events_sent_total",
+		Help: "Decision events sent to the decision sink.",
+	})
+	droppedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dikastes_decision_events_dropped_total",
+		Help: "Decision events dropped, because the buffer was full or the sink failed to take them, by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	prometheus.MustRegister(sentEvents, droppedEvents)
+}
+
+// Event describes an authorization decision.
+type Event struct {
+	Time        time.Time `json:"time"`
+	Source      Peer      `json:"source"`
+	Destination Peer      `json:"destination"`
+	Method      string    `json:"method,omitempty"`
+	Host        string    `json:"host,omitempty"`
+	// Path is the request path, without its query string, which may hold secrets.
+	Path string `json:"path,omitempty"`
+	// Verdict is the name of the gRPC status code returned to Envoy, e.g. OK or PERMISSION_DENIED.
+	Verdict string `json:"verdict"`
+	// Enforced is false if the verdict was only logged, such as in dry-run mode, and the request allowed.
+	Enforced bool `json:"enforced"`
+	// Policy is the policy, as "tier/name", or profile, as "profile/name", that decided the request, and RuleID the
+	// ID of the rule.
+	Policy string `json:"policy,omitempty"`
+	RuleID string `json:"rule_id,omitempty"`
+	// RuleIndex is the position of the rule in the policy's rules for the request's direction, or -1 if no rule
+	// decided the request, or it isn't known, as for decisions shared by other replicas.
+	RuleIndex int `json:"rule_index"`
+	// Staged are the verdicts of the staged policies that matched the request, which weren't enforced.
+	Staged []StagedVerdict `json:"staged,omitempty"`
+	// LatencyMicros is the time taken to decide the request.
+	LatencyMicros int64 `json:"decision_latency_us"`
+}
+
+// StagedVerdict is the verdict a staged policy would have reached on a request, had it been enforced.
+type StagedVerdict struct {
+	// Policy is the staged policy, as "tier/staged:name".
+	Policy    string `json:"policy"`
+	RuleID    string `json:"rule_id,omitempty"`
+	RuleIndex int    `json:"rule_index"`
+	// Action is the action of the rule that matched: "allow", "deny" or "pass".
+	Action string `json:"action"`
+}
+
+// Peer is the source or destination of a request.
+type Peer struct {
+	Principal      string `json:"principal,omitempty"`
+	Namespace      string `json:"namespace,omitempty"`
+	ServiceAccount string `json:"service_account,omitempty"`
+	IP             string `json:"ip,omitempty"`
+	Port           uint32 `json:"port,omitempty"`
+}
+
+// key returns the key that a sink that partitions events, such as Kafka, partitions them by, so that the events of
+// each source stay in order.
+func (e *Event) key() string {
+	if e.Source.Principal != "" {
+		return e.Source.Principal
+	}
+	return e.Source.IP
+}
+
+// Sink delivers decision events to an external system. A Stream calls Send with one batch at a time.
+type Sink interface {
+	// Send delivers the events, returning once the system has accepted them.
+	Send(ctx context.Context, events []Event) error
+	// Close releases the sink's connections.
+	Close() error
+}
+
+// Config is the decision sink configuration file, holding the TLS settings and credentials to connect with.
+type Config struct {
+	// TLS, if set, connects to the Kafka brokers or NATS servers with TLS.
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// SASL, if set, authenticates to Kafka brokers with SASL.
+	SASL *SASLConfig `json:"sasl,omitempty"`
+	// NATS, if set, holds the credentials to authenticate to NATS servers with.
+	NATS *NATSConfig `json:"nats,omitempty"`
+}
+
+// TLSConfig configures TLS connections to the sink.
+type TLSConfig struct {
+	// CAFile, if set, holds the PEM certificates of the CAs to verify the sink's certificates with, rather than the
+	// system's.
+	CAFile string `json:"caFile,omitempty"`
+	// CertFile and KeyFile, if set, hold the PEM client certificate and key to authenticate to the sink with.
+	CertFile string `json:"certFile,omitempty"`
+	KeyFile  string `json:"keyFile,omitempty"`
+	// ServerName, if set, is the name to verify the sink's certificates for, rather than the host in the URL.
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// SASLConfig configures SASL authentication to Kafka brokers.
+type SASLConfig struct {
+	// Mechanism is PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512. PLAIN sends the password as it is, so needs TLS.
+	Mechanism string `json:"mechanism"`
+	Username  string `json:"username"`
+	// PasswordFile holds the password.
+	PasswordFile string `json:"passwordFile"`
+}
+
+// NATSConfig configures authentication to NATS servers. At most one kind of credentials may be set.
+type NATSConfig struct {
+	// CredentialsFile, if set, holds the user JWT and NKey seed to authenticate with, as generated by nsc.
+	CredentialsFile string `json:"credentialsFile,omitempty"`
+	// TokenFile, if set, holds the token to authenticate with. It is read on each connection.
+	TokenFile string `json:"tokenFile,omitempty"`
+	// Username and PasswordFile, if set, are the user to authenticate as and the file holding their password.
+	Username     string `json:"username,omitempty"`
+	PasswordFile string `json:"passwordFile,omitempty"`
+}
+
+// LoadConfig reads a decision sink configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// tlsConfig returns the TLS configuration to connect with, or nil if TLS isn't configured.
+func (c *TLSConfig) tlsConfig() (*tls.Config, error) {
+	if c == nil {
+		return nil, nil
+	}
+	t := &tls.Config{ServerName: c.ServerName}
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		t.RootCAs = x509.NewCertPool()
+		if !t.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", c.CAFile)
+		}
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		t.Certificates = []tls.Certificate{cert}
+	}
+	return t, nil
+}
+
+// readSecret returns the contents of a file holding a password or token, without surrounding white space.
+func readSecret(file string) (string, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	s := strings.TrimSpace(string(b))
+	if s == "" {
+		return "", fmt.Errorf("%s is empty", file)
+	}
+	return s, nil
+}
+
+// New returns the sink for rawurl: "kafka://broker:9092[,broker:9092...]/topic" to produce to a Kafka topic, or
+// "nats://host:4222[,host:4222...]/subject" to publish on a NATS subject. Credentials come from config, which may be
+// nil, rather than the URL.
+func New(rawurl string, config *Config) (Sink, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("decision sink URL %q has no host", rawurl)
+	}
+	if u.User != nil {
+		return nil, errors.New("decision sink URL has credentials; put them in the decision sink config instead")
+	}
+	if config == nil {
+		config = &Config{}
+	}
+	name := strings.TrimPrefix(u.Path, "/")
+	switch u.Scheme {
+	case "kafka":
+		if name == "" {
+			return nil, fmt.Errorf("decision sink URL %q has no topic", rawurl)
+		}
+		if config.NATS != nil {
+			return nil, errors.New("NATS credentials configured for a Kafka decision sink")
+		}
+		return newKafkaSink(strings.Split(u.Host, ","), name, config)
+	case "nats":
+		if name == "" || strings.ContainsAny(name, " \t\r\n") {
+			return nil, fmt.Errorf("decision sink URL %q has no valid subject", rawurl)
+		}
+		if config.SASL != nil {
+			return nil, errors.New("SASL configured for a NATS decision sink")
+		}
+		return newNATSSink(strings.Split(u.Host, ","), name, config)
+	}
+	return nil, fmt.Errorf("unsupported decision sink %q", u.Scheme)
+}
+
+// Stream buffers the events published to it and sends them to its sink in batches, so that publishing never waits on
+// the sink.
+type Stream struct {
+	sink   Sink
+	events chan Event
+	// BatchSize and FlushInterval bound how many events are sent at once, and how long they wait to be sent. They must
+	// be set before Start.
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// NewStream returns a Stream that sends events to sink once started.
+func NewStream(sink Sink) *Stream {
+	return &Stream{
+		sink:          sink,
+		events:        make(chan Event, DefaultBufferSize),
+		BatchSize:     DefaultBatchSize,
+		FlushInterval: DefaultFlushInterval,
+	}
+}
+
+// Publish queues an event to be sent, dropping it if the buffer is full.
+func (s *Stream) Publish(e Event) {
+	select {
+	case s.events <- e:
+	default:
+		droppedEvents.WithLabelValues("buffer_full").Inc()
+	}
+}
+
+// Start sends published events to the sink until ctx is done.
+func (s *Stream) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+func (s *Stream) run(ctx context.Context) {
+	defer s.sink.Close()
+	ticker := time.NewTicker(s.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]Event, 0, s.BatchSize)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-s.events:
+			batch = append(batch, e)
+			if len(batch) < s.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		s.send(ctx, batch)
+		batch = batch[:0]
+	}
+}
+
+// send sends a batch of events. Batches the sink fails to take are dropped rather than retried, so that a sink that
+// is down doesn't hold up the events that follow once it is back.
+func (s *Stream) send(ctx context.Context, batch []Event) {
+	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
+	defer cancel()
+	if err := s.sink.Send(ctx, batch); err != nil {
+		log.WithError(err).WithField("events", len(batch)).Warn("Unable to send decision events.")
+		droppedEvents.WithLabelValues("send_failed").Add(float64(len(batch)))
+		return
+	}
+	sentEvents.Add(float64(len(batch)))
+}
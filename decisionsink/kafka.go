@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisionsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	kafka "github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	kafkaClientID = "dikastes"
+	// kafkaBatchTimeout is how long the writer waits to fill a partition's batch. A Stream already batches events,
+	// so there is no point waiting long.
+	kafkaBatchTimeout = 10 * time.Millisecond
+	// kafkaMaxAttempts bounds how often the writer tries each batch, within the Stream's timeout.
+	kafkaMaxAttempts = 3
+)
+
+// kafkaSink produces events to a Kafka topic. The writer finds the leaders of the topic's partitions, and follows them
+// as they move. Events are partitioned by their source, so that each source's events stay in order, and are
+// acknowledged by all in-sync replicas.
+type kafkaSink struct {
+	bootstrap []string
+	topic     string
+	dialer    *kafka.Dialer
+	writer    *kafka.Writer
+}
+
+func newKafkaSink(bootstrap []string, topic string, config *Config) (*kafkaSink, error) {
+	t, err := config.TLS.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("decision sink TLS: %v", err)
+	}
+	mechanism, err := kafkaSASLMechanism(config.SASL, t != nil)
+	if err != nil {
+		return nil, fmt.Errorf("decision sink SASL: %v", err)
+	}
+	dialer := &kafka.Dialer{
+		ClientID:      kafkaClientID,
+		Timeout:       DefaultTimeout,
+		DualStack:     true,
+		TLS:           t,
+		SASLMechanism: mechanism,
+	}
+	return &kafkaSink{
+		bootstrap: bootstrap,
+		topic:     topic,
+		dialer:    dialer,
+		writer: kafka.NewWriter(kafka.WriterConfig{
+			Brokers:      bootstrap,
+			Topic:        topic,
+			Dialer:       dialer,
+			Balancer:     &kafka.Hash{},
+			MaxAttempts:  kafkaMaxAttempts,
+			BatchSize:    DefaultBatchSize,
+			BatchTimeout: kafkaBatchTimeout,
+			RequiredAcks: -1,
+		}),
+	}, nil
+}
+
+// kafkaSASLMechanism returns the SASL mechanism that config configures, or nil if it is nil. PLAIN is only allowed
+// over TLS, since it sends the password as it is.
+func kafkaSASLMechanism(config *SASLConfig, tls bool) (sasl.Mechanism, error) {
+	if config == nil {
+		return nil, nil
+	}
+	if config.Username == "" || config.PasswordFile == "" {
+		return nil, fmt.Errorf("username and passwordFile are required")
+	}
+	password, err := readSecret(config.PasswordFile)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToUpper(config.Mechanism) {
+	case "PLAIN":
+		if !tls {
+			return nil, fmt.Errorf("PLAIN sends the password unencrypted, so needs TLS")
+		}
+		return plain.Mechanism{Username: config.Username, Password: password}, nil
+	case "SCRAM-SHA-256":
+		return scram.Mechanism(scram.SHA256, config.Username, password)
+	case "SCRAM-SHA-512":
+		return scram.Mechanism(scram.SHA512, config.Username, password)
+	}
+	return nil, fmt.Errorf("unsupported mechanism %q", config.Mechanism)
+}
+
+// Send produces the events to the topic, returning once the in-sync replicas of their partitions have them.
+func (k *kafkaSink) Send(ctx context.Context, events []Event) error {
+	msgs, err := kafkaMessages(events)
+	if err != nil {
+		return err
+	}
+	return k.writer.WriteMessages(ctx, msgs...)
+}
+
+// kafkaMessages converts events to messages, keyed by their source.
+func kafkaMessages(events []Event) ([]kafka.Message, error) {
+	msgs := make([]kafka.Message, len(events))
+	for i := range events {
+		value, err := json.Marshal(&events[i])
+		if err != nil {
+			return nil, err
+		}
+		msgs[i] = kafka.Message{Key: []byte(events[i].key()), Value: value, Time: events[i].Time}
+	}
+	return msgs, nil
+}
+
+func (k *kafkaSink) Close() error {
+	return k.writer.Close()
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisionsink
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/segmentio/kafka-go/sasl/plain"
+)
+
+func TestKafkaMessages(t *testing.T) {
+	RegisterTestingT(t)
+
+	now := time.Unix(1700000000, 0).UTC()
+	events := []Event{
+		{Time: now, Source: Peer{Principal: "spiffe://cluster.local/ns/a/sa/b", IP: "10.0.0.3"}, Verdict: "OK"},
+		{Time: now.Add(time.Second), Source: Peer{IP: "10.0.0.1"}, Verdict: "PERMISSION_DENIED"},
+	}
+	msgs, err := kafkaMessages(events)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(msgs).To(HaveLen(2))
+	// Messages are keyed by their source's principal, or its address if it has none.
+	Expect(string(msgs[0].Key)).To(Equal("spiffe://cluster.local/ns/a/sa/b"))
+	Expect(string(msgs[1].Key)).To(Equal("10.0.0.1"))
+	Expect(msgs[1].Time).To(Equal(events[1].Time))
+	var e Event
+	Expect(json.Unmarshal(msgs[1].Value, &e)).To(Succeed())
+	Expect(e).To(Equal(events[1]))
+}
+
+func TestKafkaSASL(t *testing.T) {
+	RegisterTestingT(t)
+	dir, err := ioutil.TempDir("", "decisionsink")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	password := writeSecret(dir, "password", "secret")
+
+	m, err := kafkaSASLMechanism(nil, false)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(m).To(BeNil())
+
+	m, err = kafkaSASLMechanism(&SASLConfig{Mechanism: "plain", Username: "dikastes", PasswordFile: password}, true)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(m).To(Equal(plain.Mechanism{Username: "dikastes", Password: "secret"}))
+
+	m, err = kafkaSASLMechanism(&SASLConfig{Mechanism: "SCRAM-SHA-512", Username: "dikastes", PasswordFile: password}, false)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(m.Name()).To(Equal("SCRAM-SHA-512"))
+
+	for _, bad := range []SASLConfig{
+		// PLAIN needs TLS.
+		{Mechanism: "PLAIN", Username: "dikastes", PasswordFile: password},
+		{Mechanism: "GSSAPI", Username: "dikastes", PasswordFile: password},
+		{Mechanism: "SCRAM-SHA-256", PasswordFile: password},
+		{Mechanism: "SCRAM-SHA-256", Username: "dikastes", PasswordFile: filepath.Join(dir, "missing")},
+		{Mechanism: "SCRAM-SHA-256", Username: "dikastes", PasswordFile: writeSecret(dir, "empty", "")},
+	} {
+		_, err := kafkaSASLMechanism(&bad, false)
+		Expect(err).To(HaveOccurred(), bad.Mechanism)
+	}
+}
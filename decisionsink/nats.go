@@ -0,0 +1,137 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisionsink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	nats "github.com/nats-io/nats.go"
+	log "github.com/sirupsen/logrus"
+)
+
+// natsSink publishes events on a NATS subject. It connects on the first Send, and the connection then reconnects to
+// any of the servers by itself.
+type natsSink struct {
+	servers []string
+	subject string
+	options []nats.Option
+
+	conn *nats.Conn
+	// lastErr is the last error the server reported for published messages, so that each is returned once.
+	lastErr error
+}
+
+func newNATSSink(hosts []string, subject string, config *Config) (*natsSink, error) {
+	n := &natsSink{subject: subject, options: []nats.Option{
+		nats.Name("dikastes"),
+		nats.Timeout(DefaultTimeout),
+		nats.MaxReconnects(-1),
+		nats.ErrorHandler(func(_ *nats.Conn, _ *nats.Subscription, err error) {
+			log.WithError(err).Warn("Error from NATS decision sink.")
+		}),
+	}}
+	for _, h := range hosts {
+		n.servers = append(n.servers, "nats://"+h)
+	}
+	t, err := config.TLS.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("decision sink TLS: %v", err)
+	}
+	if t != nil {
+		n.options = append(n.options, nats.Secure(t))
+	}
+	auth, err := natsAuth(config.NATS)
+	if err != nil {
+		return nil, fmt.Errorf("decision sink NATS credentials: %v", err)
+	}
+	n.options = append(n.options, auth...)
+	return n, nil
+}
+
+// natsAuth returns the options that authenticate with the credentials config configures, if any.
+func natsAuth(config *NATSConfig) ([]nats.Option, error) {
+	if config == nil {
+		return nil, nil
+	}
+	var opts []nats.Option
+	if config.CredentialsFile != "" {
+		opts = append(opts, nats.UserCredentials(config.CredentialsFile))
+	}
+	if config.TokenFile != "" {
+		file := config.TokenFile
+		opts = append(opts, nats.TokenHandler(func() string {
+			token, err := readSecret(file)
+			if err != nil {
+				log.WithError(err).Warn("Unable to read NATS decision sink token.")
+			}
+			return token
+		}))
+	}
+	if config.Username != "" || config.PasswordFile != "" {
+		if config.Username == "" || config.PasswordFile == "" {
+			return nil, errors.New("username and passwordFile must be set together")
+		}
+		password, err := readSecret(config.PasswordFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, nats.UserInfo(config.Username, password))
+	}
+	if len(opts) > 1 {
+		return nil, errors.New("only one of credentialsFile, tokenFile and username may be set")
+	}
+	return opts, nil
+}
+
+// Send publishes each event on the subject, then flushes them. The server answers the flush once it has processed the
+// events, after reporting any error for them, such as for a subject it doesn't permit.
+func (n *natsSink) Send(ctx context.Context, events []Event) error {
+	if n.conn == nil {
+		conn, err := nats.Connect(strings.Join(n.servers, ","), n.options...)
+		if err != nil {
+			return err
+		}
+		n.conn = conn
+	}
+	for i := range events {
+		payload, err := json.Marshal(&events[i])
+		if err != nil {
+			return err
+		}
+		if err := n.conn.Publish(n.subject, payload); err != nil {
+			return err
+		}
+	}
+	if err := n.conn.FlushWithContext(ctx); err != nil {
+		return err
+	}
+	if err := n.conn.LastError(); err != nil && err != n.lastErr {
+		n.lastErr = err
+		return err
+	}
+	return nil
+}
+
+func (n *natsSink) Close() error {
+	if n.conn != nil {
+		n.conn.Close()
+		n.conn = nil
+	}
+	return nil
+}
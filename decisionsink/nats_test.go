@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decisionsink
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeNATSServer accepts connections speaking the NATS client protocol and records the messages published to it.
+type fakeNATSServer struct {
+	net.Listener
+	// tls, if set, is the TLS configuration the server requires clients to connect with.
+	tls *tls.Config
+	// deny, if set, is a subject that publishing to gets a permissions error.
+	deny string
+
+	mu       sync.Mutex
+	connect  string
+	messages map[string][]string
+}
+
+func newFakeNATSServer(tlsConf *tls.Config, deny string) *fakeNATSServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ToNot(HaveOccurred())
+	s := &fakeNATSServer{Listener: l, tls: tlsConf, deny: deny, messages: map[string][]string{}}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	return s
+}
+
+func (s *fakeNATSServer) serve(c net.Conn) {
+	defer c.Close()
+	info := map[string]interface{}{"server_id": "fake", "max_payload": 1048576}
+	if s.tls != nil {
+		info["tls_required"] = true
+	}
+	b, _ := json.Marshal(info)
+	_, _ = io.WriteString(c, "INFO "+string(b)+"\r\n")
+	if s.tls != nil {
+		c = tls.Server(c, s.tls)
+	}
+	r := bufio.NewReader(c)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			s.mu.Lock()
+			s.connect = strings.TrimSpace(strings.TrimPrefix(line, "CONNECT"))
+			s.mu.Unlock()
+		case "PUB":
+			var n int
+			_, _ = fmt.Sscan(fields[2], &n)
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			if fields[1] == s.deny {
+				_, _ = io.WriteString(c, "-ERR 'Permissions Violation for Publish to \""+s.deny+"\"'\r\n")
+				continue
+			}
+			s.mu.Lock()
+			s.messages[fields[1]] = append(s.messages[fields[1]], string(payload[:n]))
+			s.mu.Unlock()
+		case "PING":
+			_, _ = io.WriteString(c, "PONG\r\n")
+		}
+	}
+}
+
+func (s *fakeNATSServer) connectOptions() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connect
+}
+
+func (s *fakeNATSServer) received(subject string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messages[subject]
+}
+
+// writeSecret writes a password or token file in dir.
+func writeSecret(dir, name, secret string) string {
+	file := filepath.Join(dir, name)
+	Expect(ioutil.WriteFile(file, []byte(secret+"\n"), 0600)).To(Succeed())
+	return file
+}
+
+func TestNATS(t *testing.T) {
+	RegisterTestingT(t)
+	dir, err := ioutil.TempDir("", "decisionsink")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	server := newFakeNATSServer(nil, "forbidden")
+	defer server.Close()
+	config := &Config{NATS: &NATSConfig{Username: "dikastes", PasswordFile: writeSecret(dir, "password", "secret")}}
+	sink, err := New("nats://"+server.Addr().String()+"/decisions", config)
+	Expect(err).ToNot(HaveOccurred())
+	defer sink.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := []Event{
+		{Time: time.Unix(1700000000, 0).UTC(), Source: Peer{Principal: "spiffe://cluster.local/ns/a/sa/b"}, Verdict: "OK"},
+		{Time: time.Unix(1700000001, 0).UTC(), Path: "/admin", Verdict: "PERMISSION_DENIED", Enforced: true},
+	}
+	Expect(sink.Send(ctx, events)).To(Succeed())
+	received := server.received("decisions")
+	Expect(received).To(HaveLen(2))
+	var e Event
+	Expect(json.Unmarshal([]byte(received[1]), &e)).To(Succeed())
+	Expect(e).To(Equal(events[1]))
+	Expect(server.connectOptions()).To(ContainSubstring(`"user":"dikastes"`))
+	Expect(server.connectOptions()).To(ContainSubstring(`"pass":"secret"`))
+
+	// Errors for the published messages are returned, once.
+	denied, err := New("nats://"+server.Addr().String()+"/forbidden", config)
+	Expect(err).ToNot(HaveOccurred())
+	defer denied.Close()
+	Expect(denied.Send(ctx, events)).To(MatchError(ContainSubstring(`Permissions Violation for Publish to "forbidden"`)))
+	Expect(sink.Send(ctx, events)).To(Succeed())
+}
+
+func TestNATSTLS(t *testing.T) {
+	RegisterTestingT(t)
+	dir, err := ioutil.TempDir("", "decisionsink")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	// The server's certificate is self-signed, for the name the client is configured to verify.
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "nats"},
+		DNSNames:              []string{"nats.example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	caFile := filepath.Join(dir, "ca.pem")
+	Expect(ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)).To(Succeed())
+	server := newFakeNATSServer(&tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}, "")
+	defer server.Close()
+
+	sink, err := New("nats://"+server.Addr().String()+"/decisions", &Config{
+		TLS:  &TLSConfig{CAFile: caFile, ServerName: "nats.example.com"},
+		NATS: &NATSConfig{TokenFile: writeSecret(dir, "token", "s3cr3t")},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	defer sink.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	Expect(sink.Send(ctx, []Event{{Verdict: "OK"}})).To(Succeed())
+	Expect(server.received("decisions")).To(HaveLen(1))
+	Expect(server.connectOptions()).To(ContainSubstring(`"auth_token":"s3cr3t"`))
+
+	// A client that doesn't trust the CA doesn't connect.
+	untrusted, err := New("nats://"+server.Addr().String()+"/decisions", &Config{
+		TLS: &TLSConfig{ServerName: "nats.example.com"},
+	})
+	Expect(err).ToNot(HaveOccurred())
+	defer untrusted.Close()
+	Expect(untrusted.Send(ctx, []Event{{Verdict: "OK"}})).ToNot(Succeed())
+	Expect(server.received("decisions")).To(HaveLen(1))
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package denybody renders the bodies of responses to requests that policy denies from Go templates, so that API
+// clients get structured errors they can act on.
+package denybody
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultContentType is the content type of bodies whose template doesn't say.
+const DefaultContentType = "application/json"
+
+// Config is the deny template configuration file.
+type Config struct {
+	// Default is the template for namespaces without their own. If unset, those namespaces get Envoy's empty 403.
+	Default *Template `json:"default,omitempty"`
+	// Namespaces holds templates for denials by the policy of workloads in particular namespaces.
+	Namespaces map[string]*Template `json:"namespaces,omitempty"`
+}
+
+// Template is a deny body template.
+type Template struct {
+	// ContentType of the body. Templates for text/html bodies escape their variables as HTML.
+	ContentType string `json:"contentType,omitempty"`
+	// Status is the HTTP status code. Defaults to 403.
+	Status int `json:"status,omitempty"`
+	// Body is a Go template, with Vars as its data. The json function encodes a value as JSON, quoting strings.
+	Body string `json:"body"`
+}
+
+// Vars are the variables that templates can use.
+type Vars struct {
+	// Policy is the policy or profile that denied the request, as "<tier>/<name>" or "profile/<name>". It is empty
+	// if no policy matched, and the request fell through to the default deny.
+	Policy string
+	// Namespace is the namespace of the workload whose policy denied the request.
+	Namespace string
+	// RequestID is Envoy's x-request-id for the request.
+	RequestID string
+	// Timestamp is when the request was denied, in RFC 3339 format.
+	Timestamp string
+}
+
+// LoadConfig reads a deny template configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type compiled struct {
+	contentType string
+	status      int
+	execute     func(*bytes.Buffer, Vars) error
+}
+
+// Renderer renders deny bodies.
+type Renderer struct {
+	def        *compiled
+	namespaces map[string]*compiled
+}
+
+var funcs = map[string]interface{}{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// NewRenderer parses the templates in config.
+func NewRenderer(config *Config) (*Renderer, error) {
+	r := &Renderer{namespaces: map[string]*compiled{}}
+	var err error
+	if config.Default != nil {
+		if r.def, err = compile("default", config.Default); err != nil {
+			return nil, err
+		}
+	}
+	for ns, t := range config.Namespaces {
+		if r.namespaces[ns], err = compile(ns, t); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func compile(name string, t *Template) (*compiled, error) {
+	c := &compiled{contentType: t.ContentType, status: t.Status}
+	if c.contentType == "" {
+		c.contentType = DefaultContentType
+	}
+	if c.status == 0 {
+		c.status = http.StatusForbidden
+	}
+	if c.status < 400 || c.status > 599 {
+		return nil, fmt.Errorf("deny template %s: status %d isn't an error", name, c.status)
+	}
+	mediaType, _, err := mime.ParseMediaType(c.contentType)
+	if err != nil {
+		return nil, fmt.Errorf("deny template %s: %v", name, err)
+	}
+	if mediaType == "text/html" {
+		tmpl, err := htmltemplate.New(name).Funcs(funcs).Parse(t.Body)
+		if err != nil {
+			return nil, fmt.Errorf("deny template %s: %v", name, err)
+		}
+		c.execute = func(b *bytes.Buffer, v Vars) error { return tmpl.Execute(b, v) }
+	} else {
+		tmpl, err := template.New(name).Funcs(funcs).Parse(t.Body)
+		if err != nil {
+			return nil, fmt.Errorf("deny template %s: %v", name, err)
+		}
+		c.execute = func(b *bytes.Buffer, v Vars) error { return tmpl.Execute(b, v) }
+	}
+	return c, nil
+}
+
+// Response is a rendered deny response.
+type Response struct {
+	Status      int
+	ContentType string
+	Body        string
+}
+
+// Render renders the deny response for a request denied by the policy of a workload in the given namespace. It
+// returns nil if there is no template for the namespace.
+func (r *Renderer) Render(namespace string, v Vars) (*Response, error) {
+	c, ok := r.namespaces[namespace]
+	if !ok {
+		c = r.def
+	}
+	if c == nil {
+		return nil, nil
+	}
+	var b bytes.Buffer
+	if err := c.execute(&b, v); err != nil {
+		return nil, err
+	}
+	return &Response{Status: c.status, ContentType: c.contentType, Body: b.String()}, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package denybody
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+var vars = Vars{
+	Policy:    "default/deny-admin",
+	Namespace: "payments",
+	RequestID: "a1b2",
+	Timestamp: "2026-03-01T12:00:00Z",
+}
+
+func TestRender(t *testing.T) {
+	RegisterTestingT(t)
+
+	r, err := NewRenderer(&Config{
+		Default: &Template{
+			Body: `{"error":"forbidden","policy":{{json .Policy}},"requestId":{{json .RequestID}},"time":{{json .Timestamp}}}`,
+		},
+		Namespaces: map[string]*Template{
+			"web": {ContentType: "text/html; charset=utf-8", Status: 451, Body: `<p>Denied by {{.Policy}}</p>`},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	resp, err := r.Render("payments", vars)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.Status).To(Equal(403))
+	Expect(resp.ContentType).To(Equal("application/json"))
+	var body map[string]string
+	Expect(json.Unmarshal([]byte(resp.Body), &body)).To(Succeed())
+	Expect(body).To(Equal(map[string]string{
+		"error": "forbidden", "policy": "default/deny-admin", "requestId": "a1b2", "time": "2026-03-01T12:00:00Z",
+	}))
+
+	// HTML templates escape their variables.
+	v := vars
+	v.Policy = "<script>"
+	resp, err = r.Render("web", v)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.Status).To(Equal(451))
+	Expect(resp.ContentType).To(Equal("text/html; charset=utf-8"))
+	Expect(resp.Body).To(Equal("<p>Denied by &lt;script&gt;</p>"))
+}
+
+func TestRenderNoTemplate(t *testing.T) {
+	RegisterTestingT(t)
+
+	r, err := NewRenderer(&Config{Namespaces: map[string]*Template{"web": {Body: "{}"}}})
+	Expect(err).ToNot(HaveOccurred())
+	resp, err := r.Render("payments", vars)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp).To(BeNil())
+}
+
+func TestNewRendererErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, cfg := range []*Config{
+		{Default: &Template{Body: "{{.Policy"}},
+		{Default: &Template{Body: "{}", Status: 200}},
+		{Namespaces: map[string]*Template{"web": {ContentType: "text/html; ;", Body: "{}"}}},
+	} {
+		_, err := NewRenderer(cfg)
+		Expect(err).To(HaveOccurred())
+	}
+}
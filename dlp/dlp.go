@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dlp scans data for sensitive patterns, such as credentials or card numbers, that should not leave a service.
+package dlp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the sensitive pattern configuration file.
+type Config struct {
+	Patterns []Pattern `json:"patterns"`
+}
+
+// Pattern is a named regular expression, in Go syntax, that matches sensitive data.
+type Pattern struct {
+	Name  string `json:"name"`
+	Regex string `json:"regex"`
+}
+
+// LoadConfig reads a sensitive pattern configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Scanner matches data against named patterns. It is safe for concurrent use.
+type Scanner struct {
+	patterns map[string]*regexp.Regexp
+}
+
+// NewScanner compiles the patterns in config.
+func NewScanner(config *Config) (*Scanner, error) {
+	s := &Scanner{patterns: map[string]*regexp.Regexp{}}
+	for _, p := range config.Patterns {
+		if p.Name == "" {
+			return nil, fmt.Errorf("pattern with no name")
+		}
+		if _, ok := s.patterns[p.Name]; ok {
+			return nil, fmt.Errorf("duplicate pattern %q", p.Name)
+		}
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("pattern %s: %v", p.Name, err)
+		}
+		s.patterns[p.Name] = re
+	}
+	return s, nil
+}
+
+// Scan returns the name of the first of the named patterns that data matches, or "" if it matches none.
+func (s *Scanner) Scan(names []string, data []byte) (string, error) {
+	for _, n := range names {
+		re, ok := s.patterns[n]
+		if !ok {
+			return "", fmt.Errorf("unknown pattern %q", n)
+		}
+		if re.Match(data) {
+			return n, nil
+		}
+	}
+	return "", nil
+}
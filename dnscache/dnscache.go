@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dnscache resolves the domain names used in egress rules, caching the results so that checks don't wait on
+// DNS.
+package dnscache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultTTL is how long resolved addresses are used before they are refreshed.
+	DefaultTTL = 30 * time.Second
+	// DefaultWait is how long a lookup of a name that has never been resolved waits for the answer.
+	DefaultWait = 100 * time.Millisecond
+	// DefaultMaxNames bounds the number of names cached.
+	DefaultMaxNames = 10000
+	// lookupTimeout bounds each DNS query.
+	lookupTimeout = 5 * time.Second
+	// retryInterval is how soon a name that failed to resolve is retried.
+	retryInterval = 5 * time.Second
+)
+
+// ErrPending is returned when a name is looked up for the first time and isn't resolved within the wait.
+var ErrPending = errors.New("domain name resolution pending")
+
+// Resolver resolves domain names, caching the addresses for the TTL. Once a name has resolved, lookups never wait:
+// expired addresses are refreshed in the background and keep being returned until the refresh succeeds.
+type Resolver struct {
+	TTL      time.Duration
+	Wait     time.Duration
+	MaxNames int
+
+	lookup func(ctx context.Context, host string) ([]net.IPAddr, error)
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	ips        []net.IP
+	err        error
+	expires    time.Time
+	refreshing bool
+	// resolved is closed once the first resolution completes.
+	resolved chan struct{}
+}
+
+// NewResolver returns a Resolver that uses the system resolver and caches addresses for ttl.
+func NewResolver(ttl time.Duration) *Resolver {
+	return &Resolver{
+		TTL:      ttl,
+		Wait:     DefaultWait,
+		MaxNames: DefaultMaxNames,
+		lookup:   net.DefaultResolver.LookupIPAddr,
+		now:      time.Now,
+		entries:  make(map[string]*entry),
+	}
+}
+
+// LookupIP returns the addresses name resolves to.
+func (r *Resolver) LookupIP(name string) ([]net.IP, error) {
+	name = strings.TrimSuffix(strings.ToLower(name), ".")
+	r.mu.Lock()
+	e, ok := r.entries[name]
+	if !ok {
+		r.makeRoom()
+		e = &entry{refreshing: true, resolved: make(chan struct{})}
+		r.entries[name] = e
+		go r.resolve(name, e)
+	} else if !e.refreshing && r.now().After(e.expires) {
+		e.refreshing = true
+		go r.resolve(name, e)
+	}
+	r.mu.Unlock()
+
+	timer := time.NewTimer(r.Wait)
+	defer timer.Stop()
+	select {
+	case <-e.resolved:
+	case <-timer.C:
+		return nil, ErrPending
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return e.ips, e.err
+}
+
+// resolve looks up name and updates its entry.
+func (r *Resolver) resolve(name string, e *entry) {
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	addrs, err := r.lookup(ctx, name)
+	cancel()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e.refreshing = false
+	if err != nil {
+		log.WithError(err).WithField("name", name).Warn("Unable to resolve domain name.")
+		e.expires = r.now().Add(retryInterval)
+		if e.ips == nil {
+			e.err = err
+		}
+	} else {
+		ips := make([]net.IP, len(addrs))
+		for i, a := range addrs {
+			ips[i] = a.IP
+		}
+		log.WithFields(log.Fields{"name": name, "addresses": ips}).Debug("Resolved domain name")
+		e.ips, e.err = ips, nil
+		e.expires = r.now().Add(r.TTL)
+	}
+	select {
+	case <-e.resolved:
+	default:
+		close(e.resolved)
+	}
+}
+
+// makeRoom evicts an entry if the cache is full, preferring one that has expired. r.mu must be held.
+func (r *Resolver) makeRoom() {
+	if len(r.entries) < r.MaxNames {
+		return
+	}
+	now := r.now()
+	victim := ""
+	for name, e := range r.entries {
+		if victim == "" {
+			victim = name
+		}
+		if now.After(e.expires) && !e.refreshing {
+			victim = name
+			break
+		}
+	}
+	delete(r.entries, victim)
+}
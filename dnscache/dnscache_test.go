@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnscache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeDNS answers lookups from a map, counting them. Names it doesn't have fail to resolve.
+type fakeDNS struct {
+	mu      sync.Mutex
+	answers map[string][]string
+	lookups int
+	now     time.Time
+	// block, if set, holds up every lookup until it is closed.
+	block chan struct{}
+}
+
+func (f *fakeDNS) lookup(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if f.block != nil {
+		<-f.block
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lookups++
+	ips, ok := f.answers[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	var addrs []net.IPAddr
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func (f *fakeDNS) set(host string, ips ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.answers[host] = ips
+}
+
+func (f *fakeDNS) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.lookups
+}
+
+func (f *fakeDNS) clock() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeDNS) advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+func newTestResolver(dns *fakeDNS) *Resolver {
+	r := NewResolver(time.Minute)
+	r.Wait = time.Second
+	r.lookup = dns.lookup
+	r.now = dns.clock
+	return r
+}
+
+func addresses(r *Resolver, name string) []string {
+	ips, err := r.LookupIP(name)
+	Expect(err).ToNot(HaveOccurred())
+	var s []string
+	for _, ip := range ips {
+		s = append(s, ip.String())
+	}
+	return s
+}
+
+func TestLookupIPCaches(t *testing.T) {
+	RegisterTestingT(t)
+
+	dns := &fakeDNS{answers: map[string][]string{"api.example.com": {"192.0.2.1", "2001:db8::1"}}, now: time.Unix(0, 0)}
+	r := newTestResolver(dns)
+
+	Expect(addresses(r, "api.example.com")).To(Equal([]string{"192.0.2.1", "2001:db8::1"}))
+	// Names are case insensitive and may be fully qualified.
+	Expect(addresses(r, "API.example.com.")).To(Equal([]string{"192.0.2.1", "2001:db8::1"}))
+	Expect(dns.count()).To(Equal(1))
+
+	// Once the TTL expires, the old addresses are returned while the name is refreshed.
+	dns.set("api.example.com", "192.0.2.2")
+	dns.advance(2 * time.Minute)
+	Expect(addresses(r, "api.example.com")).To(Equal([]string{"192.0.2.1", "2001:db8::1"}))
+	Eventually(func() []string { return addresses(r, "api.example.com") }).Should(Equal([]string{"192.0.2.2"}))
+	Expect(dns.count()).To(Equal(2))
+}
+
+func TestLookupIPErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	dns := &fakeDNS{answers: map[string][]string{"api.example.com": {"192.0.2.1"}}, now: time.Unix(0, 0)}
+	r := newTestResolver(dns)
+
+	// A name that has never resolved returns the error, and isn't retried until the retry interval passes.
+	_, err := r.LookupIP("nxdomain.example.com")
+	Expect(err).To(HaveOccurred())
+	_, err = r.LookupIP("nxdomain.example.com")
+	Expect(err).To(HaveOccurred())
+	Expect(dns.count()).To(Equal(1))
+	dns.set("nxdomain.example.com", "192.0.2.9")
+	dns.advance(retryInterval + time.Second)
+	Eventually(func() error { _, err := r.LookupIP("nxdomain.example.com"); return err }).ShouldNot(HaveOccurred())
+
+	// A name that fails to refresh keeps its old addresses.
+	Expect(addresses(r, "api.example.com")).To(Equal([]string{"192.0.2.1"}))
+	dns.mu.Lock()
+	delete(dns.answers, "api.example.com")
+	dns.mu.Unlock()
+	dns.advance(2 * time.Minute)
+	Expect(addresses(r, "api.example.com")).To(Equal([]string{"192.0.2.1"}))
+	Eventually(dns.count).Should(Equal(4))
+	Expect(addresses(r, "api.example.com")).To(Equal([]string{"192.0.2.1"}))
+}
+
+func TestLookupIPPending(t *testing.T) {
+	RegisterTestingT(t)
+
+	dns := &fakeDNS{answers: map[string][]string{"api.example.com": {"192.0.2.1"}}, block: make(chan struct{})}
+	r := newTestResolver(dns)
+	r.Wait = 10 * time.Millisecond
+
+	_, err := r.LookupIP("api.example.com")
+	Expect(err).To(Equal(ErrPending))
+	close(dns.block)
+	Eventually(func() error { _, err := r.LookupIP("api.example.com"); return err }).ShouldNot(HaveOccurred())
+	Expect(dns.count()).To(Equal(1))
+}
+
+func TestMaxNames(t *testing.T) {
+	RegisterTestingT(t)
+
+	dns := &fakeDNS{answers: map[string][]string{
+		"a.example.com": {"192.0.2.1"},
+		"b.example.com": {"192.0.2.2"},
+		"c.example.com": {"192.0.2.3"},
+	}}
+	r := newTestResolver(dns)
+	r.MaxNames = 2
+
+	addresses(r, "a.example.com")
+	addresses(r, "b.example.com")
+	addresses(r, "c.example.com")
+	Expect(r.entries).To(HaveLen(2))
+	Expect(r.entries).To(HaveKey("c.example.com"))
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package endpointslices watches the cluster's EndpointSlices to map the addresses of pods back to the services they
+// back, so that rules can match requests on their destination service when Felix doesn't sync services.
+package endpointslices
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectcalico/app-policy/gatewayapi"
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/policystore"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ServiceNameLabel is the label that names the service an EndpointSlice belongs to.
+const ServiceNameLabel = "kubernetes.io/service-name"
+
+// retryInterval is how soon a failed list or watch is retried. It is a variable for tests.
+var retryInterval = 5 * time.Second
+
+// errExpired is returned when a watch's resource version is too old to resume from.
+var errExpired = errors.New("resource version expired")
+
+// EndpointSlice is the part of a discovery.k8s.io/v1 EndpointSlice that the Watcher uses.
+type EndpointSlice struct {
+	Metadata  ObjectMeta `json:"metadata"`
+	Endpoints []Endpoint `json:"endpoints"`
+	Ports     []Port     `json:"ports"`
+}
+
+// ObjectMeta is the part of a Kubernetes resource's metadata that the Watcher uses.
+type ObjectMeta struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+// Endpoint is one of the pods behind a service.
+type Endpoint struct {
+	Addresses []string `json:"addresses"`
+}
+
+// Port is a port that a service's pods listen on. Slices without ports serve every port.
+type Port struct {
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// Service returns the service that the slice belongs to, or false if it doesn't belong to one.
+func (s *EndpointSlice) Service() (policystore.ServiceID, bool) {
+	name := s.Metadata.Labels[ServiceNameLabel]
+	if name == "" {
+		return policystore.ServiceID{}, false
+	}
+	return policystore.ServiceID{Namespace: s.Metadata.Namespace, Name: name}, true
+}
+
+// servesPort returns true if the slice's pods listen on the port, with the protocol if it is given.
+func (s *EndpointSlice) servesPort(port int32, protocol string) bool {
+	if len(s.Ports) == 0 {
+		return true
+	}
+	for _, p := range s.Ports {
+		// The protocol defaults to TCP.
+		proto := p.Protocol
+		if proto == "" {
+			proto = "TCP"
+		}
+		if p.Port == port && (protocol == "" || strings.EqualFold(proto, protocol)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Watcher keeps an index of the EndpointSlices in the cluster by their pods' addresses.
+type Watcher struct {
+	config gatewayapi.KubeConfig
+
+	mu     sync.RWMutex
+	synced bool
+	slices map[string]*EndpointSlice
+	// byIP holds the keys of the slices with each pod address, in canonical form.
+	byIP map[string]map[string]bool
+}
+
+// NewWatcher creates a Watcher of the EndpointSlices in the cluster that config locates. Call Start to begin
+// watching.
+func NewWatcher(config *gatewayapi.KubeConfig) *Watcher {
+	return &Watcher{
+		config: *config,
+		slices: map[string]*EndpointSlice{},
+		byIP:   map[string]map[string]bool{},
+	}
+}
+
+// Start lists and watches EndpointSlices until ctx is done.
+func (w *Watcher) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// HasSynced returns true once the EndpointSlices have been listed.
+func (w *Watcher) HasSynced() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.synced
+}
+
+// Services returns the services whose endpoints include the pod address, listening on the port with the protocol,
+// such as "TCP", if it is given. A pod may back more than one service.
+func (w *Watcher) Services(ip net.IP, port int32, protocol string) []policystore.ServiceID {
+	if ip == nil {
+		return nil
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	seen := map[policystore.ServiceID]bool{}
+	var ids []policystore.ServiceID
+	for key := range w.byIP[ip.String()] {
+		s := w.slices[key]
+		id, ok := s.Service()
+		if !ok || seen[id] || !s.servesPort(port, protocol) {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		rv, err := w.list(ctx)
+		for err == nil {
+			rv, err = w.watch(ctx, rv)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != errExpired {
+			log.WithError(err).Warn("Failed to watch EndpointSlices.")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+		}
+	}
+}
+
+func (w *Watcher) url() string {
+	return w.config.Host + "/apis/discovery.k8s.io/v1/endpointslices"
+}
+
+func (w *Watcher) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if w.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.config.Token)
+	}
+	resp, err := w.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusGone {
+			return nil, errExpired
+		}
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// list replaces the slices with those in the cluster, and returns the resource version to watch from.
+func (w *Watcher) list(ctx context.Context) (string, error) {
+	resp, err := w.get(ctx, w.url())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var list struct {
+		Metadata ObjectMeta      `json:"metadata"`
+		Items    []EndpointSlice `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return "", err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.slices = map[string]*EndpointSlice{}
+	w.byIP = map[string]map[string]bool{}
+	for i := range list.Items {
+		w.set(&list.Items[i])
+	}
+	w.synced = true
+	log.WithField("endpointSlices", len(list.Items)).Info("Listed EndpointSlices.")
+	return list.Metadata.ResourceVersion, nil
+}
+
+// watch applies changes to the slices from resource version rv until the watch ends, and returns the resource
+// version to resume from.
+func (w *Watcher) watch(ctx context.Context, rv string) (string, error) {
+	resp, err := w.get(ctx, w.url()+"?watch=1&allowWatchBookmarks=true&resourceVersion="+rv)
+	if err != nil {
+		return rv, err
+	}
+	defer resp.Body.Close()
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var event struct {
+			Type   string          `json:"type"`
+			Object json.RawMessage `json:"object"`
+		}
+		if err := dec.Decode(&event); err != nil {
+			if ctx.Err() != nil {
+				return rv, ctx.Err()
+			}
+			// The API server ends watches after a timeout; resume from the last version seen.
+			log.WithError(err).Debug("EndpointSlice watch ended.")
+			return rv, nil
+		}
+		if event.Type == "ERROR" {
+			var status struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			}
+			_ = json.Unmarshal(event.Object, &status)
+			if status.Code == http.StatusGone {
+				return rv, errExpired
+			}
+			return rv, fmt.Errorf("watch error: %s", status.Message)
+		}
+		var s EndpointSlice
+		if err := json.Unmarshal(event.Object, &s); err != nil {
+			return rv, err
+		}
+		rv = s.Metadata.ResourceVersion
+		w.mu.Lock()
+		switch event.Type {
+		case "ADDED", "MODIFIED":
+			w.set(&s)
+		case "DELETED":
+			w.remove(sliceKey(&s))
+		}
+		w.mu.Unlock()
+		log.WithFields(log.Fields{"type": event.Type, "endpointSlice": sliceKey(&s)}).Debug("EndpointSlice event.")
+	}
+}
+
+func sliceKey(s *EndpointSlice) string {
+	return s.Metadata.Namespace + "/" + s.Metadata.Name
+}
+
+// set adds or replaces a slice and indexes its addresses. It must be called with the lock held.
+func (w *Watcher) set(s *EndpointSlice) {
+	key := sliceKey(s)
+	w.remove(key)
+	w.slices[key] = s
+	for _, a := range sliceAddresses(s) {
+		if w.byIP[a] == nil {
+			w.byIP[a] = map[string]bool{}
+		}
+		w.byIP[a][key] = true
+	}
+}
+
+// remove removes a slice and its addresses from the index. It must be called with the lock held.
+func (w *Watcher) remove(key string) {
+	s, ok := w.slices[key]
+	if !ok {
+		return
+	}
+	delete(w.slices, key)
+	for _, a := range sliceAddresses(s) {
+		delete(w.byIP[a], key)
+		if len(w.byIP[a]) == 0 {
+			delete(w.byIP, a)
+		}
+	}
+}
+
+// sliceAddresses returns the canonical forms of the addresses of a slice's endpoints. Slices of FQDNs have none.
+func sliceAddresses(s *EndpointSlice) []string {
+	var addrs []string
+	for _, e := range s.Endpoints {
+		for _, a := range e.Addresses {
+			if ip := ipaddr.Parse(a); ip != nil {
+				addrs = append(addrs, ip.String())
+			}
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpointslices
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/gatewayapi"
+	"github.com/projectcalico/app-policy/policystore"
+)
+
+func slice(name, service string, port int32, addresses ...string) string {
+	a := ""
+	for i, addr := range addresses {
+		if i > 0 {
+			a += ","
+		}
+		a += fmt.Sprintf("%q", addr)
+	}
+	return fmt.Sprintf(`{"metadata":{"name":%q,"namespace":"shop","resourceVersion":"%d",`+
+		`"labels":{"kubernetes.io/service-name":%q}},"addressType":"IPv4",`+
+		`"endpoints":[{"addresses":[%s],"conditions":{"ready":true}}],"ports":[{"name":"http","port":%d}]}`,
+		name, time.Now().UnixNano(), service, a, port)
+}
+
+func TestServices(t *testing.T) {
+	RegisterTestingT(t)
+
+	w := NewWatcher(&gatewayapi.KubeConfig{})
+	w.set(&EndpointSlice{
+		Metadata:  ObjectMeta{Name: "cart-a", Namespace: "shop", Labels: map[string]string{ServiceNameLabel: "cart"}},
+		Endpoints: []Endpoint{{Addresses: []string{"10.0.0.5", "fd00::5"}}},
+		Ports:     []Port{{Port: 8080, Protocol: "TCP"}},
+	})
+	w.set(&EndpointSlice{
+		Metadata:  ObjectMeta{Name: "all-a", Namespace: "shop", Labels: map[string]string{ServiceNameLabel: "all"}},
+		Endpoints: []Endpoint{{Addresses: []string{"10.0.0.5"}}},
+	})
+	w.set(&EndpointSlice{
+		Metadata:  ObjectMeta{Name: "manual", Namespace: "shop"},
+		Endpoints: []Endpoint{{Addresses: []string{"10.0.0.5"}}},
+	})
+	cart := policystore.ServiceID{Namespace: "shop", Name: "cart"}
+	all := policystore.ServiceID{Namespace: "shop", Name: "all"}
+
+	Expect(w.Services(net.ParseIP("10.0.0.5"), 8080, "TCP")).To(Equal([]policystore.ServiceID{all, cart}))
+	Expect(w.Services(net.ParseIP("::ffff:10.0.0.5"), 8080, "")).To(Equal([]policystore.ServiceID{all, cart}))
+	Expect(w.Services(net.ParseIP("fd00:0::5"), 8080, "tcp")).To(Equal([]policystore.ServiceID{cart}))
+	Expect(w.Services(net.ParseIP("10.0.0.5"), 8080, "UDP")).To(Equal([]policystore.ServiceID{all}))
+	Expect(w.Services(net.ParseIP("10.0.0.5"), 9090, "TCP")).To(Equal([]policystore.ServiceID{all}))
+	Expect(w.Services(net.ParseIP("10.0.0.6"), 8080, "TCP")).To(BeEmpty())
+	Expect(w.Services(nil, 8080, "TCP")).To(BeEmpty())
+
+	// Updates replace slices' addresses, and removals drop them.
+	w.set(&EndpointSlice{
+		Metadata:  ObjectMeta{Name: "cart-a", Namespace: "shop", Labels: map[string]string{ServiceNameLabel: "cart"}},
+		Endpoints: []Endpoint{{Addresses: []string{"10.0.0.6"}}},
+	})
+	Expect(w.Services(net.ParseIP("fd00::5"), 8080, "TCP")).To(BeEmpty())
+	Expect(w.Services(net.ParseIP("10.0.0.6"), 8080, "TCP")).To(Equal([]policystore.ServiceID{cart}))
+	w.remove("shop/cart-a")
+	w.remove("shop/all-a")
+	w.remove("shop/manual")
+	Expect(w.byIP).To(BeEmpty())
+}
+
+func TestWatcherWatch(t *testing.T) {
+	RegisterTestingT(t)
+	retryInterval = 10 * time.Millisecond
+
+	events := make(chan string, 10)
+	var mu sync.Mutex
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Expect(r.URL.Path).To(Equal("/apis/discovery.k8s.io/v1/endpointslices"))
+		Expect(r.Header.Get("Authorization")).To(Equal("Bearer t0ken"))
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		if r.URL.Query().Get("watch") == "" {
+			fmt.Fprintf(w, `{"metadata":{"resourceVersion":"10"},"items":[%s]}`, slice("cart-a", "cart", 80, "10.0.0.5"))
+			return
+		}
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case e := <-events:
+				fmt.Fprintln(w, e)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := NewWatcher(&gatewayapi.KubeConfig{Host: srv.URL, Token: "t0ken", Client: srv.Client()})
+	Expect(w.HasSynced()).To(BeFalse())
+	w.Start(ctx)
+	services := func(ip string) func() int {
+		return func() int { return len(w.Services(net.ParseIP(ip), 80, "TCP")) }
+	}
+
+	Eventually(w.HasSynced, time.Second).Should(BeTrue())
+	Expect(services("10.0.0.5")()).To(Equal(1))
+
+	events <- `{"type":"ADDED","object":` + slice("web-a", "web", 80, "10.0.0.6") + `}`
+	Eventually(services("10.0.0.6"), time.Second).Should(Equal(1))
+
+	events <- `{"type":"DELETED","object":` + slice("cart-a", "cart", 80) + `}`
+	Eventually(services("10.0.0.5"), time.Second).Should(Equal(0))
+
+	// An expired resource version is relisted.
+	events <- `{"type":"ERROR","object":{"kind":"Status","code":410,"message":"too old"}}`
+	Eventually(services("10.0.0.6"), time.Second).Should(Equal(0))
+	Expect(services("10.0.0.5")()).To(Equal(1))
+	mu.Lock()
+	defer mu.Unlock()
+	Expect(queries[1]).To(Equal("watch=1&allowWatchBookmarks=true&resourceVersion=10"))
+	Expect(queries[2]).To(Equal(""))
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envoyconfig renders the Envoy ext_authz filter configuration that points Envoy at a Dikastes listener.
+package envoyconfig
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	FormatEnvoy = "envoy"
+	FormatIstio = "istio"
+
+	APIVersionV2 = "v2"
+	APIVersionV3 = "v3"
+
+	DefaultTimeout = 500 * time.Millisecond
+)
+
+// Options describes the Dikastes listener the generated config should talk to.
+type Options struct {
+	// SocketPath is the unix domain socket Dikastes listens on.
+	SocketPath string
+	// APIVersion is the ext_authz API version Envoy should call, "v2" or "v3".
+	APIVersion string
+	// FailureModeAllow lets requests through if Dikastes cannot be reached or returns an error.
+	FailureModeAllow bool
+	// Timeout is how long Envoy waits for a check response.
+	Timeout time.Duration
+	// IncludePeerCertificate has Envoy send the client's certificate, so that Dikastes can check its expiry.
+	IncludePeerCertificate bool
+	// Outbound also inserts the filter into sidecars' outbound listeners, so that egress policy applies.
+	Outbound bool
+	// Name and Namespace of the generated Istio EnvoyFilter.
+	Name      string
+	Namespace string
+}
+
+// Render returns the YAML for the given format: "envoy" emits just the HTTP filter, suitable for adding to an
+// http_connection_manager's http_filters, and "istio" emits an EnvoyFilter resource that inserts it into every
+// sidecar's inbound listeners.
+func Render(o Options, format string) ([]byte, error) {
+	filter, err := HTTPFilter(o)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatEnvoy:
+		return yaml.Marshal(filter)
+	case FormatIstio:
+		return yaml.Marshal(EnvoyFilter(o, filter))
+	}
+	return nil, fmt.Errorf("unknown config format %q", format)
+}
+
+// HTTPFilter returns the ext_authz HTTP filter definition.
+func HTTPFilter(o Options) (map[string]interface{}, error) {
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	config := map[string]interface{}{
+		"failure_mode_allow": o.FailureModeAllow,
+		"grpc_service": map[string]interface{}{
+			"google_grpc": map[string]interface{}{
+				"target_uri":  "unix://" + o.SocketPath,
+				"stat_prefix": "ext_authz",
+			},
+			"timeout": fmt.Sprintf("%gs", timeout.Seconds()),
+		},
+	}
+	if o.IncludePeerCertificate {
+		config["include_peer_certificate"] = true
+	}
+	switch o.APIVersion {
+	case APIVersionV2:
+		config["@type"] = "type.googleapis.com/envoy.config.filter.http.ext_authz.v2.ExtAuthz"
+	case APIVersionV3:
+		config["@type"] = "type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthz"
+		config["transport_api_version"] = "V3"
+	default:
+		return nil, fmt.Errorf("unknown ext_authz API version %q", o.APIVersion)
+	}
+	return map[string]interface{}{
+		"name":         "envoy.filters.http.ext_authz",
+		"typed_config": config,
+	}, nil
+}
+
+// EnvoyFilter wraps the HTTP filter in an Istio EnvoyFilter that inserts it before the router filter on inbound
+// sidecar listeners, and outbound ones too if requested.
+func EnvoyFilter(o Options, filter map[string]interface{}) map[string]interface{} {
+	name := o.Name
+	if name == "" {
+		name = "ext-authz"
+	}
+	namespace := o.Namespace
+	if namespace == "" {
+		namespace = "istio-system"
+	}
+	patches := []interface{}{insertFilter("SIDECAR_INBOUND", filter)}
+	if o.Outbound {
+		patches = append(patches, insertFilter("SIDECAR_OUTBOUND", filter))
+	}
+	return map[string]interface{}{
+		"apiVersion": "networking.istio.io/v1alpha3",
+		"kind":       "EnvoyFilter",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"configPatches": patches,
+		},
+	}
+}
+
+// insertFilter returns an EnvoyFilter config patch that inserts filter before the router filter on the sidecar
+// listeners in the given context.
+func insertFilter(context string, filter map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"applyTo": "HTTP_FILTER",
+		"match": map[string]interface{}{
+			"context": context,
+			"listener": map[string]interface{}{
+				"filterChain": map[string]interface{}{
+					"filter": map[string]interface{}{
+						"name": "envoy.filters.network.http_connection_manager",
+						"subFilter": map[string]interface{}{
+							"name": "envoy.filters.http.router",
+						},
+					},
+				},
+			},
+		},
+		"patch": map[string]interface{}{
+			"operation": "INSERT_BEFORE",
+			"value":     filter,
+		},
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envoyconfig
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+)
+
+func TestHTTPFilterV3(t *testing.T) {
+	RegisterTestingT(t)
+
+	f, err := HTTPFilter(Options{SocketPath: "/var/run/dikastes/dikastes.sock", APIVersion: APIVersionV3})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(f["name"]).To(Equal("envoy.filters.http.ext_authz"))
+	c := f["typed_config"].(map[string]interface{})
+	Expect(c["@type"]).To(Equal("type.googleapis.com/envoy.extensions.filters.http.ext_authz.v3.ExtAuthz"))
+	Expect(c["transport_api_version"]).To(Equal("V3"))
+	Expect(c["failure_mode_allow"]).To(BeFalse())
+	Expect(c).ToNot(HaveKey("include_peer_certificate"))
+	g := c["grpc_service"].(map[string]interface{})
+	Expect(g["timeout"]).To(Equal("0.5s"))
+	Expect(g["google_grpc"]).To(HaveKeyWithValue("target_uri", "unix:///var/run/dikastes/dikastes.sock"))
+}
+
+func TestHTTPFilterV2(t *testing.T) {
+	RegisterTestingT(t)
+
+	f, err := HTTPFilter(Options{
+		SocketPath:       "/tmp/d.sock",
+		APIVersion:       APIVersionV2,
+		FailureModeAllow: true,
+		Timeout:          2 * time.Second,
+	})
+	Expect(err).ToNot(HaveOccurred())
+	c := f["typed_config"].(map[string]interface{})
+	Expect(c["@type"]).To(Equal("type.googleapis.com/envoy.config.filter.http.ext_authz.v2.ExtAuthz"))
+	Expect(c).ToNot(HaveKey("transport_api_version"))
+	Expect(c["failure_mode_allow"]).To(BeTrue())
+	Expect(c["grpc_service"]).To(HaveKeyWithValue("timeout", "2s"))
+}
+
+func TestHTTPFilterPeerCertificate(t *testing.T) {
+	RegisterTestingT(t)
+
+	f, err := HTTPFilter(Options{APIVersion: APIVersionV3, IncludePeerCertificate: true})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(f["typed_config"]).To(HaveKeyWithValue("include_peer_certificate", true))
+}
+
+func TestHTTPFilterBadVersion(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := HTTPFilter(Options{APIVersion: "v4"})
+	Expect(err).To(HaveOccurred())
+}
+
+func TestRenderIstio(t *testing.T) {
+	RegisterTestingT(t)
+
+	b, err := Render(Options{SocketPath: "/var/run/dikastes/dikastes.sock", APIVersion: APIVersionV3}, FormatIstio)
+	Expect(err).ToNot(HaveOccurred())
+
+	var ef struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec struct {
+			ConfigPatches []struct {
+				ApplyTo string `json:"applyTo"`
+				Patch   struct {
+					Operation string                 `json:"operation"`
+					Value     map[string]interface{} `json:"value"`
+				} `json:"patch"`
+			} `json:"configPatches"`
+		} `json:"spec"`
+	}
+	Expect(yaml.Unmarshal(b, &ef)).To(Succeed())
+	Expect(ef.Kind).To(Equal("EnvoyFilter"))
+	Expect(ef.Metadata.Name).To(Equal("ext-authz"))
+	Expect(ef.Metadata.Namespace).To(Equal("istio-system"))
+	Expect(ef.Spec.ConfigPatches).To(HaveLen(1))
+	Expect(ef.Spec.ConfigPatches[0].ApplyTo).To(Equal("HTTP_FILTER"))
+	Expect(ef.Spec.ConfigPatches[0].Patch.Operation).To(Equal("INSERT_BEFORE"))
+	Expect(ef.Spec.ConfigPatches[0].Patch.Value["name"]).To(Equal("envoy.filters.http.ext_authz"))
+}
+
+func TestEnvoyFilterOutbound(t *testing.T) {
+	RegisterTestingT(t)
+
+	filter := map[string]interface{}{"name": "envoy.filters.http.ext_authz"}
+	spec := EnvoyFilter(Options{Outbound: true}, filter)["spec"].(map[string]interface{})
+	patches := spec["configPatches"].([]interface{})
+	Expect(patches).To(HaveLen(2))
+	var contexts []interface{}
+	for _, p := range patches {
+		contexts = append(contexts, p.(map[string]interface{})["match"].(map[string]interface{})["context"])
+	}
+	Expect(contexts).To(Equal([]interface{}{"SIDECAR_INBOUND", "SIDECAR_OUTBOUND"}))
+}
+
+func TestRenderBadFormat(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := Render(Options{APIVersion: APIVersionV3}, "nginx")
+	Expect(err).To(HaveOccurred())
+}
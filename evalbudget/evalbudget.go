@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package evalbudget configures how long policy evaluation may take for requests to each destination service, so
+// that expensive policy can't make latency-sensitive services wait.
+package evalbudget
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/projectcalico/app-policy/policystore"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultName is the name of the default budget, for destinations without one of their own.
+const DefaultName = "default"
+
+// Config is the evaluation budget configuration file.
+type Config struct {
+	// Default, if set, is the budget for requests to destinations that Services doesn't list.
+	Default *Budget `json:"default,omitempty"`
+	// Services are the budgets for requests to particular destination services.
+	Services []ServiceBudget `json:"services,omitempty"`
+
+	services map[policystore.ServiceID]*Budget
+}
+
+// Budget bounds the time policy evaluation may take.
+type Budget struct {
+	// MaxEvaluationTime is how long evaluation may take, as a Go duration.
+	MaxEvaluationTime string `json:"maxEvaluationTime"`
+	// Verdict is given to requests whose evaluation takes longer: "allow", "deny" or "unavailable". Defaults to
+	// "deny".
+	Verdict string `json:"verdict,omitempty"`
+
+	maxEvaluationTime time.Duration
+}
+
+// ServiceBudget is the budget for requests to a service.
+type ServiceBudget struct {
+	// Service is the destination service, as "namespace/name".
+	Service string `json:"service"`
+	Budget
+}
+
+// LoadConfig reads and validates an evaluation budget configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(b)
+}
+
+// ParseConfig parses and validates an evaluation budget configuration.
+func ParseConfig(b []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	if c.Default != nil {
+		if err := c.Default.validate(); err != nil {
+			return nil, fmt.Errorf("default: %v", err)
+		}
+	}
+	c.services = map[policystore.ServiceID]*Budget{}
+	for i := range c.Services {
+		s := &c.Services[i]
+		parts := strings.Split(s.Service, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("service %q is not namespace/name", s.Service)
+		}
+		id := policystore.ServiceID{Namespace: parts[0], Name: parts[1]}
+		if _, dup := c.services[id]; dup {
+			return nil, fmt.Errorf("service %q has more than one budget", s.Service)
+		}
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("service %q: %v", s.Service, err)
+		}
+		c.services[id] = &s.Budget
+	}
+	return c, nil
+}
+
+func (b *Budget) validate() error {
+	d, err := time.ParseDuration(b.MaxEvaluationTime)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid maxEvaluationTime %q", b.MaxEvaluationTime)
+	}
+	b.maxEvaluationTime = d
+	switch strings.ToLower(b.Verdict) {
+	case "":
+		b.Verdict = "deny"
+	case "allow", "deny", "unavailable":
+	default:
+		return fmt.Errorf("invalid verdict %q", b.Verdict)
+	}
+	return nil
+}
+
+// MaxTime returns how long evaluation may take.
+func (b *Budget) MaxTime() time.Duration {
+	return b.maxEvaluationTime
+}
+
+// Lookup returns the budget for a request to the services, and the name of the service it is for. If more than one
+// of the services has a budget, the tightest applies. It returns the default budget, if any, if none of them have
+// one.
+func (c *Config) Lookup(services []policystore.ServiceID) (string, *Budget) {
+	var name string
+	var budget *Budget
+	for _, id := range services {
+		if b := c.services[id]; b != nil && (budget == nil || b.maxEvaluationTime < budget.maxEvaluationTime) {
+			name, budget = id.String(), b
+		}
+	}
+	if budget == nil && c.Default != nil {
+		return DefaultName, c.Default
+	}
+	return name, budget
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evalbudget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/projectcalico/app-policy/policystore"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestLookup(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte(`
+default:
+  maxEvaluationTime: 50ms
+services:
+- service: shop/checkout
+  maxEvaluationTime: 5ms
+  verdict: allow
+- service: shop/cart
+  maxEvaluationTime: 10ms
+`))
+	Expect(err).ToNot(HaveOccurred())
+
+	checkout := policystore.ServiceID{Namespace: "shop", Name: "checkout"}
+	cart := policystore.ServiceID{Namespace: "shop", Name: "cart"}
+	other := policystore.ServiceID{Namespace: "shop", Name: "catalog"}
+
+	name, b := c.Lookup([]policystore.ServiceID{checkout})
+	Expect(name).To(Equal("shop/checkout"))
+	Expect(b.MaxTime()).To(Equal(5 * time.Millisecond))
+	Expect(b.Verdict).To(Equal("allow"))
+
+	// The tightest budget of the destination's services applies.
+	name, b = c.Lookup([]policystore.ServiceID{cart, checkout})
+	Expect(name).To(Equal("shop/checkout"))
+
+	name, b = c.Lookup([]policystore.ServiceID{cart})
+	Expect(name).To(Equal("shop/cart"))
+	Expect(b.Verdict).To(Equal("deny"))
+
+	name, b = c.Lookup([]policystore.ServiceID{other})
+	Expect(name).To(Equal(DefaultName))
+	Expect(b.MaxTime()).To(Equal(50 * time.Millisecond))
+
+	name, b = c.Lookup(nil)
+	Expect(name).To(Equal(DefaultName))
+
+	c, err = ParseConfig([]byte("services: [{service: shop/cart, maxEvaluationTime: 1ms}]"))
+	Expect(err).ToNot(HaveOccurred())
+	name, b = c.Lookup([]policystore.ServiceID{other})
+	Expect(name).To(Equal(""))
+	Expect(b).To(BeNil())
+}
+
+func TestParseConfigValidates(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, bad := range []string{
+		"default: {verdict: deny}",
+		"default: {maxEvaluationTime: 0s}",
+		"default: {maxEvaluationTime: 1ms, verdict: maybe}",
+		"services: [{service: checkout, maxEvaluationTime: 1ms}]",
+		"services: [{service: shop/cart, maxEvaluationTime: 1ms}, {service: shop/cart, maxEvaluationTime: 2ms}]",
+		"budgets: []",
+	} {
+		_, err := ParseConfig([]byte(bad))
+		Expect(err).To(HaveOccurred(), bad)
+	}
+}
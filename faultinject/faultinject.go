@@ -0,0 +1,303 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faultinject injects latency, errors and connection drops into Dikastes' gRPC traffic, for testing how
+// Envoy's ext_authz failure_mode_allow and timeout settings behave when Dikastes misbehaves. It is for test
+// environments only.
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// TargetCheck labels faults injected into check handling.
+	TargetCheck = "check"
+	// TargetSync labels faults injected into the Policy Sync client.
+	TargetSync = "sync"
+)
+
+// errDropped is returned by reads from connections that fault injection dropped.
+var errDropped = errors.New("connection dropped by fault injection")
+
+var injectedFaults = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "dikastes_injected_faults_total",
+	Help: "Faults injected by fault injection mode, by what they were injected into and kind of fault.",
+}, []string{"target", "fault"})
+
+func init() {
+	prometheus.MustRegister(injectedFaults)
+}
+
+// Config is the fault injection configuration file.
+type Config struct {
+	// Check are the faults to inject into check handling.
+	Check *Faults `json:"check,omitempty"`
+	// Sync are the faults to inject into the Policy Sync client.
+	Sync *Faults `json:"sync,omitempty"`
+	// Seed seeds the random choice of which calls to inject faults into, to make runs repeatable. Defaults to the
+	// time at startup.
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// Faults are the faults to inject into one kind of traffic.
+type Faults struct {
+	// Latency delays each call, or each message received by the Policy Sync client, as a Go duration.
+	Latency string `json:"latency,omitempty"`
+	// Jitter adds a further random delay of up to this Go duration.
+	Jitter string `json:"jitter,omitempty"`
+	// ErrorRate is the fraction, from 0 to 1, of calls or messages that fail with ErrorCode.
+	ErrorRate float64 `json:"errorRate,omitempty"`
+	// ErrorCode is the gRPC status code of injected errors, e.g. DEADLINE_EXCEEDED. Defaults to UNAVAILABLE.
+	ErrorCode string `json:"errorCode,omitempty"`
+	// DropRate is the fraction, from 0 to 1, of reads from a connection that close it instead.
+	DropRate float64 `json:"dropRate,omitempty"`
+
+	latency time.Duration
+	jitter  time.Duration
+	code    codes.Code
+}
+
+// LoadConfig reads and validates a fault injection configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(b)
+}
+
+// ParseConfig parses and validates a fault injection configuration.
+func ParseConfig(b []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	for name, f := range map[string]*Faults{TargetCheck: c.Check, TargetSync: c.Sync} {
+		if f == nil {
+			continue
+		}
+		if err := f.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return c, nil
+}
+
+func (f *Faults) validate() error {
+	for _, d := range []struct {
+		value string
+		into  *time.Duration
+	}{{f.Latency, &f.latency}, {f.Jitter, &f.jitter}} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil || v < 0 {
+			return fmt.Errorf("invalid duration %q", d.value)
+		}
+		*d.into = v
+	}
+	if f.ErrorRate < 0 || f.ErrorRate > 1 {
+		return fmt.Errorf("errorRate %v is not between 0 and 1", f.ErrorRate)
+	}
+	if f.DropRate < 0 || f.DropRate > 1 {
+		return fmt.Errorf("dropRate %v is not between 0 and 1", f.DropRate)
+	}
+	f.code = codes.Unavailable
+	if f.ErrorCode != "" {
+		if err := f.code.UnmarshalJSON([]byte(strconv.Quote(f.ErrorCode))); err != nil || f.code == codes.OK {
+			return fmt.Errorf("invalid errorCode %q", f.ErrorCode)
+		}
+	}
+	return nil
+}
+
+// NewInjectors returns the Injectors for check handling and the Policy Sync client. Either is nil if the config
+// doesn't give it any faults.
+func NewInjectors(c *Config) (checkFaults, syncFaults *Injector) {
+	seed := time.Now().UnixNano()
+	if c.Seed != nil {
+		seed = *c.Seed
+	}
+	rnd := &lockedRand{rnd: rand.New(rand.NewSource(seed))}
+	if c.Check != nil {
+		checkFaults = &Injector{target: TargetCheck, faults: c.Check, rnd: rnd}
+	}
+	if c.Sync != nil {
+		syncFaults = &Injector{target: TargetSync, faults: c.Sync, rnd: rnd}
+	}
+	return checkFaults, syncFaults
+}
+
+// lockedRand is a source of random numbers that is safe for concurrent use.
+type lockedRand struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func (r *lockedRand) Float64() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rnd.Float64()
+}
+
+// Injector injects one set of faults into gRPC calls and the connections they are made over.
+type Injector struct {
+	target string
+	faults *Faults
+	rnd    *lockedRand
+}
+
+// chance returns true with probability p.
+func (i *Injector) chance(p float64) bool {
+	return p > 0 && i.rnd.Float64() < p
+}
+
+// delay waits for the configured latency and jitter, or until ctx is done.
+func (i *Injector) delay(ctx context.Context) error {
+	d := i.faults.latency
+	if i.faults.jitter > 0 {
+		d += time.Duration(i.rnd.Float64() * float64(i.faults.jitter))
+	}
+	if d <= 0 {
+		return nil
+	}
+	injectedFaults.WithLabelValues(i.target, "latency").Inc()
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fault delays a call and then returns the error to fail it with, if any.
+func (i *Injector) fault(ctx context.Context) error {
+	if err := i.delay(ctx); err != nil {
+		return err
+	}
+	if i.chance(i.faults.ErrorRate) {
+		injectedFaults.WithLabelValues(i.target, "error").Inc()
+		return status.Error(i.faults.code, "injected fault")
+	}
+	return nil
+}
+
+// UnaryServerInterceptor injects faults into unary calls, such as checks, before they are handled.
+func (i *Injector) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.fault(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamClientInterceptor injects faults into opening streams, such as the Policy Sync stream, and into each
+// message received on them.
+func (i *Injector) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := i.fault(ctx); err != nil {
+			return nil, err
+		}
+		s, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &faultyClientStream{ClientStream: s, injector: i}, nil
+	}
+}
+
+type faultyClientStream struct {
+	grpc.ClientStream
+	injector *Injector
+}
+
+func (s *faultyClientStream) RecvMsg(m interface{}) error {
+	if err := s.injector.fault(s.Context()); err != nil {
+		return err
+	}
+	return s.ClientStream.RecvMsg(m)
+}
+
+// Listener wraps l so that reads from the connections it accepts are dropped at the configured rate.
+func (i *Injector) Listener(l net.Listener) net.Listener {
+	if i.faults.DropRate == 0 {
+		return l
+	}
+	return &faultyListener{Listener: l, injector: i}
+}
+
+// Dialer wraps dial so that reads from the connections it makes are dropped at the configured rate.
+func (i *Injector) Dialer(
+	dial func(context.Context, string) (net.Conn, error),
+) func(context.Context, string) (net.Conn, error) {
+	if i.faults.DropRate == 0 {
+		return dial
+	}
+	return func(ctx context.Context, target string) (net.Conn, error) {
+		c, err := dial(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		return &faultyConn{Conn: c, injector: i}, nil
+	}
+}
+
+type faultyListener struct {
+	net.Listener
+	injector *Injector
+}
+
+func (l *faultyListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &faultyConn{Conn: c, injector: l.injector}, nil
+}
+
+// faultyConn closes itself, instead of reading, at the injector's drop rate.
+type faultyConn struct {
+	net.Conn
+	injector *Injector
+}
+
+func (c *faultyConn) Read(b []byte) (int, error) {
+	if c.injector.chance(c.injector.faults.DropRate) {
+		injectedFaults.WithLabelValues(c.injector.target, "drop").Inc()
+		_ = c.Conn.Close()
+		return 0, errDropped
+	}
+	return c.Conn.Read(b)
+}
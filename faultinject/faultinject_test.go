@@ -0,0 +1,174 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faultinject
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte(`
+seed: 1
+check:
+  latency: 10ms
+  jitter: 5ms
+  errorRate: 0.5
+  errorCode: DEADLINE_EXCEEDED
+sync:
+  dropRate: 0.1
+`))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(*c.Seed).To(Equal(int64(1)))
+	Expect(c.Check.latency).To(Equal(10 * time.Millisecond))
+	Expect(c.Check.jitter).To(Equal(5 * time.Millisecond))
+	Expect(c.Check.code).To(Equal(codes.DeadlineExceeded))
+	Expect(c.Sync.code).To(Equal(codes.Unavailable))
+	Expect(c.Sync.DropRate).To(Equal(0.1))
+
+	for _, bad := range []string{
+		"check: {latency: soon}",
+		"check: {latency: -1s}",
+		"check: {errorRate: 2}",
+		"sync: {dropRate: -0.5}",
+		"check: {errorCode: OK}",
+		"check: {errorCode: BROKEN}",
+		"listen: {latency: 1s}",
+	} {
+		_, err := ParseConfig([]byte(bad))
+		Expect(err).To(HaveOccurred(), bad)
+	}
+}
+
+func TestNewInjectors(t *testing.T) {
+	RegisterTestingT(t)
+
+	check, sync := NewInjectors(&Config{Check: &Faults{}})
+	Expect(check).ToNot(BeNil())
+	Expect(sync).To(BeNil())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte("check: {latency: 20ms, errorRate: 1, errorCode: RESOURCE_EXHAUSTED}"))
+	Expect(err).ToNot(HaveOccurred())
+	check, _ := NewInjectors(c)
+	intercept := check.UnaryServerInterceptor()
+	handled := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = true
+		return "ok", nil
+	}
+
+	errors := testutil.ToFloat64(injectedFaults.WithLabelValues(TargetCheck, "error"))
+	start := time.Now()
+	_, err = intercept(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+	Expect(time.Since(start)).To(BeNumerically(">=", 20*time.Millisecond))
+	Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	Expect(handled).To(BeFalse())
+	Expect(testutil.ToFloat64(injectedFaults.WithLabelValues(TargetCheck, "error"))).To(Equal(errors + 1))
+
+	// A call whose deadline passes during the injected latency fails with the context's error.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = intercept(ctx, "req", &grpc.UnaryServerInfo{}, handler)
+	Expect(err).To(Equal(context.DeadlineExceeded))
+
+	check.faults.ErrorRate = 0
+	resp, err := intercept(context.Background(), "req", &grpc.UnaryServerInfo{}, handler)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp).To(Equal("ok"))
+	Expect(handled).To(BeTrue())
+}
+
+type fakeClientStream struct {
+	grpc.ClientStream
+	received int
+}
+
+func (s *fakeClientStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeClientStream) RecvMsg(m interface{}) error {
+	s.received++
+	return nil
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte("sync: {errorRate: 1}"))
+	Expect(err).ToNot(HaveOccurred())
+	_, sync := NewInjectors(c)
+	intercept := sync.StreamClientInterceptor()
+	fake := &fakeClientStream{}
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return fake, nil
+	}
+
+	_, err = intercept(context.Background(), &grpc.StreamDesc{}, nil, "/felixbackend.PolicySync/Sync", streamer)
+	Expect(status.Code(err)).To(Equal(codes.Unavailable))
+
+	sync.faults.ErrorRate = 0
+	s, err := intercept(context.Background(), &grpc.StreamDesc{}, nil, "/felixbackend.PolicySync/Sync", streamer)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(s.RecvMsg(nil)).To(Succeed())
+	Expect(fake.received).To(Equal(1))
+
+	sync.faults.ErrorRate = 1
+	Expect(status.Code(s.RecvMsg(nil))).To(Equal(codes.Unavailable))
+	Expect(fake.received).To(Equal(1))
+}
+
+func TestDrops(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte("check: {dropRate: 1}"))
+	Expect(err).ToNot(HaveOccurred())
+	check, _ := NewInjectors(c)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ToNot(HaveOccurred())
+	l = check.Listener(l)
+	defer l.Close()
+	go func() {
+		c, err := net.Dial("tcp", l.Addr().String())
+		if err == nil {
+			_, _ = c.Write([]byte("hello"))
+			c.Close()
+		}
+	}()
+	conn, err := l.Accept()
+	Expect(err).ToNot(HaveOccurred())
+	_, err = conn.Read(make([]byte, 5))
+	Expect(err).To(Equal(errDropped))
+
+	// With no drops configured, listeners are left as they are.
+	check.faults.DropRate = 0
+	Expect(check.Listener(l)).To(Equal(l))
+}
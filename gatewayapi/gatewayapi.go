@@ -0,0 +1,337 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gatewayapi watches the policy attachments that scope Calico policies to Gateway API routes, so that a
+// Dikastes serving an Envoy-based gateway only applies a route's attached policies to its requests.
+//
+// An attachment follows the Gateway API's policy attachment pattern, naming the route it targets and the Calico
+// policies to attach to it:
+//
+//	apiVersion: policy.projectcalico.org/v1alpha1
+//	kind: RoutePolicyAttachment
+//	metadata:
+//	  name: shop-reads
+//	  namespace: shop
+//	spec:
+//	  targetRef:
+//	    group: gateway.networking.k8s.io
+//	    kind: HTTPRoute
+//	    name: storefront
+//	  policies:
+//	  - default/allow-reads
+//
+// Policies are named "<tier>/<name>", as Felix names them. Policies attached to any route apply only to requests on
+// the routes they are attached to; the rest apply to every request.
+package gatewayapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Group and Version of the RoutePolicyAttachment resource.
+	Group   = "policy.projectcalico.org"
+	Version = "v1alpha1"
+	// GatewayGroup is the API group of the routes that attachments can target.
+	GatewayGroup = "gateway.networking.k8s.io"
+
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+// retryInterval is how soon a failed list or watch is retried. It is a variable for tests.
+var retryInterval = 5 * time.Second
+
+// errExpired is returned when a watch's resource version is too old to resume from.
+var errExpired = errors.New("resource version expired")
+
+// RoutePolicyAttachment attaches Calico policies to a Gateway API route.
+type RoutePolicyAttachment struct {
+	Metadata ObjectMeta                `json:"metadata"`
+	Spec     RoutePolicyAttachmentSpec `json:"spec"`
+}
+
+// ObjectMeta is the part of a Kubernetes resource's metadata that attachments use.
+type ObjectMeta struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// RoutePolicyAttachmentSpec is the route an attachment targets and the policies it attaches.
+type RoutePolicyAttachmentSpec struct {
+	TargetRef TargetRef `json:"targetRef"`
+	Policies  []string  `json:"policies"`
+}
+
+// TargetRef names a route. Its namespace defaults to the attachment's.
+type TargetRef struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Route returns the "<namespace>/<name>" of the route that a targets, or false if it doesn't target a Gateway API
+// route.
+func (a *RoutePolicyAttachment) Route() (string, bool) {
+	ref := a.Spec.TargetRef
+	if ref.Group != GatewayGroup || ref.Name == "" {
+		return "", false
+	}
+	switch ref.Kind {
+	case "HTTPRoute", "GRPCRoute":
+	default:
+		return "", false
+	}
+	ns := ref.Namespace
+	if ns == "" {
+		ns = a.Metadata.Namespace
+	}
+	return ns + "/" + ref.Name, true
+}
+
+// KubeConfig locates the Kubernetes API server.
+type KubeConfig struct {
+	// Host is the API server's base URL.
+	Host string
+	// Token is the bearer token to authenticate with.
+	Token string
+	// Client makes requests to the API server.
+	Client *http.Client
+}
+
+// InClusterConfig returns the KubeConfig of a pod's service account.
+func InClusterConfig() (*KubeConfig, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a Kubernetes cluster")
+	}
+	token, err := ioutil.ReadFile(serviceAccountDir + "/token")
+	if err != nil {
+		return nil, err
+	}
+	ca, err := ioutil.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid service account CA certificate")
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &KubeConfig{
+		Host:   "https://" + net.JoinHostPort(host, port),
+		Token:  string(token),
+		Client: &http.Client{Transport: t},
+	}, nil
+}
+
+// Controller keeps the policies attached to each route.
+type Controller struct {
+	config KubeConfig
+
+	mu          sync.RWMutex
+	attachments map[string]RoutePolicyAttachment
+	// byRoute holds the policies attached to each route, and attached all policies attached to any route.
+	byRoute  map[string]map[string]bool
+	attached map[string]bool
+}
+
+// NewController creates a Controller that watches attachments in the cluster that config locates. Call Start to
+// begin watching.
+func NewController(config *KubeConfig) *Controller {
+	return &Controller{
+		config:      *config,
+		attachments: map[string]RoutePolicyAttachment{},
+		byRoute:     map[string]map[string]bool{},
+		attached:    map[string]bool{},
+	}
+}
+
+// Start lists and watches attachments until ctx is done.
+func (c *Controller) Start(ctx context.Context) {
+	go c.run(ctx)
+}
+
+// Applies returns true if policy applies to requests on route: if it is attached to the route, or isn't attached to
+// any route. Every policy applies until the attachments have been listed.
+func (c *Controller) Applies(route, policy string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.attached[policy] {
+		return true
+	}
+	return c.byRoute[route][policy]
+}
+
+func (c *Controller) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		rv, err := c.list(ctx)
+		for err == nil {
+			rv, err = c.watch(ctx, rv)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != errExpired {
+			log.WithError(err).Warn("Failed to watch route policy attachments.")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryInterval):
+			}
+		}
+	}
+}
+
+func (c *Controller) url() string {
+	return c.config.Host + "/apis/" + Group + "/" + Version + "/routepolicyattachments"
+}
+
+func (c *Controller) get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+	resp, err := c.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusGone {
+			return nil, errExpired
+		}
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// list replaces the attachments with those in the cluster, and returns the resource version to watch from.
+func (c *Controller) list(ctx context.Context) (string, error) {
+	resp, err := c.get(ctx, c.url())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var list struct {
+		Metadata ObjectMeta              `json:"metadata"`
+		Items    []RoutePolicyAttachment `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return "", err
+	}
+	attachments := map[string]RoutePolicyAttachment{}
+	for _, a := range list.Items {
+		attachments[a.Metadata.Namespace+"/"+a.Metadata.Name] = a
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attachments = attachments
+	c.reindex()
+	log.WithField("attachments", len(attachments)).Info("Listed route policy attachments.")
+	return list.Metadata.ResourceVersion, nil
+}
+
+// watch applies changes to the attachments from resource version rv until the watch ends, and returns the resource
+// version to resume from.
+func (c *Controller) watch(ctx context.Context, rv string) (string, error) {
+	resp, err := c.get(ctx, c.url()+"?watch=1&allowWatchBookmarks=true&resourceVersion="+rv)
+	if err != nil {
+		return rv, err
+	}
+	defer resp.Body.Close()
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var event struct {
+			Type   string          `json:"type"`
+			Object json.RawMessage `json:"object"`
+		}
+		if err := dec.Decode(&event); err != nil {
+			if ctx.Err() != nil {
+				return rv, ctx.Err()
+			}
+			// The API server ends watches after a timeout; resume from the last version seen.
+			log.WithError(err).Debug("Route policy attachment watch ended.")
+			return rv, nil
+		}
+		if event.Type == "ERROR" {
+			var status struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			}
+			_ = json.Unmarshal(event.Object, &status)
+			if status.Code == http.StatusGone {
+				return rv, errExpired
+			}
+			return rv, fmt.Errorf("watch error: %s", status.Message)
+		}
+		var a RoutePolicyAttachment
+		if err := json.Unmarshal(event.Object, &a); err != nil {
+			return rv, err
+		}
+		rv = a.Metadata.ResourceVersion
+		key := a.Metadata.Namespace + "/" + a.Metadata.Name
+		c.mu.Lock()
+		switch event.Type {
+		case "ADDED", "MODIFIED":
+			c.attachments[key] = a
+			c.reindex()
+		case "DELETED":
+			delete(c.attachments, key)
+			c.reindex()
+		}
+		c.mu.Unlock()
+		log.WithFields(log.Fields{"type": event.Type, "attachment": key}).Debug("Route policy attachment event.")
+	}
+}
+
+// reindex rebuilds the policies attached to each route. It must be called with the lock held.
+func (c *Controller) reindex() {
+	c.byRoute = map[string]map[string]bool{}
+	c.attached = map[string]bool{}
+	for key, a := range c.attachments {
+		route, ok := a.Route()
+		if !ok {
+			log.WithFields(log.Fields{"attachment": key, "targetRef": a.Spec.TargetRef}).Warn(
+				"Ignoring route policy attachment that doesn't target a Gateway API route.")
+			continue
+		}
+		if c.byRoute[route] == nil {
+			c.byRoute[route] = map[string]bool{}
+		}
+		for _, p := range a.Spec.Policies {
+			c.byRoute[route][p] = true
+			c.attached[p] = true
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func attachment(name, route string, policies ...string) string {
+	p := ""
+	for i, policy := range policies {
+		if i > 0 {
+			p += ","
+		}
+		p += fmt.Sprintf("%q", policy)
+	}
+	return fmt.Sprintf(`{"metadata":{"name":%q,"namespace":"shop","resourceVersion":"%d"},`+
+		`"spec":{"targetRef":{"group":"gateway.networking.k8s.io","kind":"HTTPRoute","name":%q},"policies":[%s]}}`,
+		name, time.Now().UnixNano(), route, p)
+}
+
+func TestRoute(t *testing.T) {
+	RegisterTestingT(t)
+
+	a := RoutePolicyAttachment{
+		Metadata: ObjectMeta{Name: "a", Namespace: "shop"},
+		Spec:     RoutePolicyAttachmentSpec{TargetRef: TargetRef{Group: GatewayGroup, Kind: "HTTPRoute", Name: "web"}},
+	}
+	route, ok := a.Route()
+	Expect(ok).To(BeTrue())
+	Expect(route).To(Equal("shop/web"))
+
+	a.Spec.TargetRef.Namespace = "edge"
+	a.Spec.TargetRef.Kind = "GRPCRoute"
+	route, ok = a.Route()
+	Expect(ok).To(BeTrue())
+	Expect(route).To(Equal("edge/web"))
+
+	a.Spec.TargetRef.Kind = "Gateway"
+	_, ok = a.Route()
+	Expect(ok).To(BeFalse())
+}
+
+func TestControllerWatch(t *testing.T) {
+	RegisterTestingT(t)
+	retryInterval = 10 * time.Millisecond
+
+	events := make(chan string, 10)
+	var mu sync.Mutex
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Expect(r.URL.Path).To(Equal("/apis/policy.projectcalico.org/v1alpha1/routepolicyattachments"))
+		Expect(r.Header.Get("Authorization")).To(Equal("Bearer t0ken"))
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		if r.URL.Query().Get("watch") == "" {
+			fmt.Fprintf(w, `{"metadata":{"resourceVersion":"10"},"items":[%s]}`,
+				attachment("reads", "storefront", "default/allow-reads"))
+			return
+		}
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case e := <-events:
+				fmt.Fprintln(w, e)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewController(&KubeConfig{Host: srv.URL, Token: "t0ken", Client: srv.Client()})
+	Expect(c.Applies("shop/checkout", "default/allow-reads")).To(BeTrue())
+	c.Start(ctx)
+
+	Eventually(func() bool { return c.Applies("shop/checkout", "default/allow-reads") }, time.Second).Should(BeFalse())
+	Expect(c.Applies("shop/storefront", "default/allow-reads")).To(BeTrue())
+	Expect(c.Applies("shop/checkout", "default/deny-all")).To(BeTrue())
+
+	events <- `{"type":"ADDED","object":` + attachment("checkout", "checkout", "default/allow-reads") + `}`
+	Eventually(func() bool { return c.Applies("shop/checkout", "default/allow-reads") }, time.Second).Should(BeTrue())
+
+	events <- `{"type":"DELETED","object":` + attachment("reads", "storefront") + `}`
+	Eventually(func() bool { return c.Applies("shop/storefront", "default/allow-reads") }, time.Second).Should(BeFalse())
+
+	// An expired resource version is relisted.
+	events <- `{"type":"ERROR","object":{"kind":"Status","code":410,"message":"too old"}}`
+	Eventually(func() bool { return c.Applies("shop/checkout", "default/allow-reads") }, time.Second).Should(BeFalse())
+	mu.Lock()
+	defer mu.Unlock()
+	Expect(queries[1]).To(Equal("watch=1&allowWatchBookmarks=true&resourceVersion=10"))
+	Expect(queries[2]).To(Equal(""))
+}
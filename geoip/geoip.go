@@ -0,0 +1,105 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geoip looks up the country and autonomous system of IP addresses in MaxMind DB files, such as the GeoLite2
+// Country and ASN databases.
+package geoip
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+
+	maxminddb "github.com/oschwald/maxminddb-golang"
+	log "github.com/sirupsen/logrus"
+)
+
+// Location is what is known about where an IP address is.
+type Location struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country, or "" if unknown.
+	Country string
+	// ASN is the number of the autonomous system that announces the address, or 0 if unknown.
+	ASN uint32
+}
+
+// record holds the fields of the GeoLite2 Country and ASN databases' records that are looked up.
+type record struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	RegisteredCountry struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"registered_country"`
+	ASN uint32 `maxminddb:"autonomous_system_number"`
+}
+
+// DB looks up addresses in one or more MaxMind DB files. Each file contributes the fields it has, so a country
+// database and an ASN database can be used together.
+type DB struct {
+	files []*maxminddb.Reader
+}
+
+// Open loads the given MaxMind DB files into memory.
+func Open(files ...string) (*DB, error) {
+	db := &DB{}
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		r, err := load(b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", f, err)
+		}
+		log.WithFields(log.Fields{"file": f, "type": r.Metadata.DatabaseType}).Info("Loaded GeoIP database")
+		db.files = append(db.files, r)
+	}
+	return db, nil
+}
+
+// load parses a MaxMind DB file and checks that its search tree and data section are intact, so that a corrupt file
+// is rejected when it is loaded rather than failing lookups.
+func load(b []byte) (*maxminddb.Reader, error) {
+	r, err := maxminddb.FromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Verify(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Lookup returns the location of ip. Fields that no file knows are left empty.
+func (db *DB) Lookup(ip net.IP) Location {
+	var l Location
+	for _, f := range db.files {
+		var r record
+		if err := f.Lookup(ip, &r); err != nil {
+			log.WithError(err).WithFields(log.Fields{"ip": ip, "type": f.Metadata.DatabaseType}).Warn(
+				"GeoIP lookup failed")
+			continue
+		}
+		if l.Country == "" {
+			l.Country = r.Country.ISOCode
+		}
+		if l.Country == "" {
+			l.Country = r.RegisteredCountry.ISOCode
+		}
+		if l.ASN == 0 {
+			l.ASN = r.ASN
+		}
+	}
+	return l
+}
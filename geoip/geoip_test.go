@@ -0,0 +1,266 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geoip
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	maxminddb "github.com/oschwald/maxminddb-golang"
+)
+
+// The tests build small databases with the encoder below, which supports only what they need of the MaxMind DB format
+// (https://maxmind.github.io/MaxMind-DB/).
+
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparator is the number of zero bytes between the search tree and the data section.
+const dataSectionSeparator = 16
+
+// Data types in the data section.
+const (
+	typePointer = 1
+	typeString  = 2
+	typeUint16  = 5
+	typeUint32  = 6
+	typeMap     = 7
+	typeUint64  = 9
+)
+
+type entry struct {
+	cidr string
+	data interface{}
+}
+
+type pointer uint
+
+// encodeCtrl encodes a control byte, with any extended type and size bytes. Sizes must be below 285.
+func encodeCtrl(typ int, size int) []byte {
+	var ext []byte
+	if size >= 29 {
+		ext = []byte{byte(size - 29)}
+		size = 29
+	}
+	var b []byte
+	if typ > 7 {
+		b = []byte{byte(size), byte(typ - 7)}
+	} else {
+		b = []byte{byte(typ<<5 | size)}
+	}
+	return append(b, ext...)
+}
+
+func encodeUint(typ int, n uint64) []byte {
+	var v []byte
+	for ; n > 0; n >>= 8 {
+		v = append([]byte{byte(n)}, v...)
+	}
+	return append(encodeCtrl(typ, len(v)), v...)
+}
+
+func encode(v interface{}) []byte {
+	switch t := v.(type) {
+	case string:
+		return append(encodeCtrl(typeString, len(t)), t...)
+	case uint16:
+		return encodeUint(typeUint16, uint64(t))
+	case uint32:
+		return encodeUint(typeUint32, uint64(t))
+	case uint64:
+		return encodeUint(typeUint64, t)
+	case pointer:
+		// Only targets below 2048, which fit the smallest pointer encoding.
+		return []byte{byte(typePointer<<5 | int(t>>8)&0x7), byte(t)}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b := encodeCtrl(typeMap, len(t))
+		for _, k := range keys {
+			b = append(b, encode(k)...)
+			b = append(b, encode(t[k])...)
+		}
+		return b
+	}
+	panic("can't encode value")
+}
+
+// buildMMDB builds a database with 24-bit records.
+func buildMMDB(ipVersion int, entries []entry) []byte {
+	// Each node is a pair of records: a node index, -1 for no data, or -2-i for the data of entry i.
+	nodes := [][2]int{{-1, -1}}
+	for i, e := range entries {
+		_, n, err := net.ParseCIDR(e.cidr)
+		if err != nil {
+			panic(err)
+		}
+		ip := n.IP
+		ones, _ := n.Mask.Size()
+		if ipVersion == 6 && len(ip) == net.IPv4len {
+			// IPv4 networks are stored under ::/96.
+			ip = append(make(net.IP, 12), ip...)
+			ones += 96
+		}
+		node := 0
+		for bit := 0; bit < ones; bit++ {
+			b := int(ip[bit/8]>>(7-uint(bit%8))) & 1
+			if bit == ones-1 {
+				nodes[node][b] = -2 - i
+			} else {
+				if nodes[node][b] < 0 {
+					nodes = append(nodes, [2]int{-1, -1})
+					nodes[node][b] = len(nodes) - 1
+				}
+				node = nodes[node][b]
+			}
+		}
+	}
+
+	var data []byte
+	offsets := make([]int, len(entries))
+	for i, e := range entries {
+		offsets[i] = len(data)
+		data = append(data, encode(e.data)...)
+	}
+
+	var buf []byte
+	for _, n := range nodes {
+		for _, r := range n {
+			v := r
+			switch {
+			case r == -1:
+				v = len(nodes)
+			case r < -1:
+				v = len(nodes) + dataSectionSeparator + offsets[-2-r]
+			}
+			buf = append(buf, byte(v>>16), byte(v>>8), byte(v))
+		}
+	}
+	buf = append(buf, make([]byte, dataSectionSeparator)...)
+	buf = append(buf, data...)
+	buf = append(buf, metadataMarker...)
+	buf = append(buf, encode(map[string]interface{}{
+		"binary_format_major_version": uint16(2),
+		"build_epoch":                 uint64(1767225600),
+		"database_type":               "Test",
+		"description":                 map[string]interface{}{"en": "Test database"},
+		"ip_version":                  uint16(ipVersion),
+		"node_count":                  uint32(len(nodes)),
+		"record_size":                 uint16(24),
+	})...)
+	return buf
+}
+
+var testEntries = []entry{
+	{"81.2.69.0/24", map[string]interface{}{
+		"country": map[string]interface{}{"iso_code": "GB"},
+	}},
+	{"1.0.0.0/8", map[string]interface{}{
+		"registered_country": map[string]interface{}{"iso_code": "AU"},
+	}},
+	// The data of the first entry, by reference.
+	{"81.2.70.0/24", pointer(0)},
+}
+
+func TestLookup(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, v := range []int{4, 6} {
+		r, err := load(buildMMDB(v, testEntries))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r.Metadata.DatabaseType).To(Equal("Test"))
+		db := &DB{files: []*maxminddb.Reader{r}}
+
+		Expect(db.Lookup(net.ParseIP("81.2.69.160"))).To(Equal(Location{Country: "GB"}), "IPv%d", v)
+		Expect(db.Lookup(net.ParseIP("1.2.3.4"))).To(Equal(Location{Country: "AU"}), "IPv%d", v)
+		Expect(db.Lookup(net.ParseIP("81.2.70.1"))).To(Equal(Location{Country: "GB"}), "IPv%d", v)
+		Expect(db.Lookup(net.ParseIP("81.2.71.1"))).To(Equal(Location{}), "IPv%d", v)
+		Expect(db.Lookup(net.ParseIP("2001:db8::1"))).To(Equal(Location{}), "IPv%d", v)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "geoip")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	country := filepath.Join(dir, "country.mmdb")
+	asn := filepath.Join(dir, "asn.mmdb")
+	Expect(ioutil.WriteFile(country, buildMMDB(6, testEntries), 0644)).To(Succeed())
+	Expect(ioutil.WriteFile(asn, buildMMDB(4, []entry{{"81.2.0.0/16", map[string]interface{}{
+		"autonomous_system_number":       uint32(20712),
+		"autonomous_system_organization": "Andrews & Arnold Ltd",
+	}}}), 0644)).To(Succeed())
+
+	db, err := Open(country, asn)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(db.Lookup(net.ParseIP("81.2.69.160"))).To(Equal(Location{Country: "GB", ASN: 20712}))
+	Expect(db.Lookup(net.ParseIP("1.2.3.4"))).To(Equal(Location{Country: "AU"}))
+
+	_, err = Open(filepath.Join(dir, "missing.mmdb"))
+	Expect(err).To(HaveOccurred())
+}
+
+func TestCorrupt(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := load([]byte("not a database"))
+	Expect(err).To(HaveOccurred())
+
+	// Truncating the data section is caught when the file is loaded.
+	b := buildMMDB(4, testEntries)
+	i := bytes.LastIndex(b, metadataMarker)
+	_, err = load(append(b[:i-10:i-10], b[i:]...))
+	Expect(err).To(HaveOccurred())
+}
+
+// Files cut short or with any byte changed are either rejected when loaded or looked up without panicking.
+func TestDamaged(t *testing.T) {
+	RegisterTestingT(t)
+
+	ips := []net.IP{net.ParseIP("81.2.69.160"), net.ParseIP("1.2.3.4"), net.ParseIP("2001:db8::1")}
+	lookup := func(b []byte) {
+		r, err := load(b)
+		if err != nil {
+			return
+		}
+		db := &DB{files: []*maxminddb.Reader{r}}
+		for _, ip := range ips {
+			db.Lookup(ip)
+		}
+	}
+	for _, v := range []int{4, 6} {
+		b := buildMMDB(v, testEntries)
+		for n := 0; n < len(b); n++ {
+			Expect(func() { lookup(b[:n:n]) }).ToNot(Panic(), "IPv%d truncated to %d bytes", v, n)
+		}
+		for i := range b {
+			for _, x := range []byte{0x01, 0x80, 0xff} {
+				c := append([]byte(nil), b...)
+				c[i] ^= x
+				Expect(func() { lookup(c) }).ToNot(Panic(), "IPv%d byte %d xor %#x", v, i, x)
+			}
+		}
+	}
+}
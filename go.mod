@@ -6,14 +6,22 @@ require (
 	github.com/docopt/docopt-go v0.0.0-20180111231733-ee0de3bc6815
 	github.com/envoyproxy/go-control-plane v0.9.8
 	github.com/gogo/protobuf v1.3.2
+	github.com/golang/protobuf v1.4.3
 	github.com/kelseyhightower/envconfig v1.4.0 // indirect
 	github.com/konsorten/go-windows-terminal-sequences v1.0.2 // indirect
+	github.com/nats-io/nats.go v1.11.0
 	github.com/onsi/gomega v1.10.1
+	github.com/oschwald/maxminddb-golang v1.3.1
 	github.com/projectcalico/libcalico-go v1.7.2-0.20210713191420-8e9b91bd573a
+	github.com/prometheus/client_golang v1.4.0
+	github.com/segmentio/kafka-go v0.3.5
 	github.com/sirupsen/logrus v1.4.2
-	golang.org/x/net v0.0.0-20210224082022-3d97a244fca7
+	golang.org/x/crypto v0.14.0
+	golang.org/x/net v0.17.0
 	google.golang.org/genproto v0.0.0-20200526211855-cb27e3aa2013
 	google.golang.org/grpc v1.27.1
+	google.golang.org/protobuf v1.25.0
+	sigs.k8s.io/yaml v1.2.0
 )
 
 // Replace the envoy data-plane-api dependency with the projectcalico fork that includes the generated
@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpcmsg decodes the request messages of selected gRPC methods, so that rules can match their fields.
+package grpcmsg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"sigs.k8s.io/yaml"
+)
+
+// MaxMessageBytes limits the size of a compressed message once it is decompressed.
+const MaxMessageBytes = 4 * 1024 * 1024
+
+// frameHeaderLen is the length of the prefix gRPC puts before each message: a compressed flag and a 4 byte length.
+const frameHeaderLen = 5
+
+var (
+	ErrMethodNotInspected = errors.New("gRPC method is not configured for inspection")
+	ErrMultipleMessages   = errors.New("request has more than one gRPC message")
+)
+
+// Config is the gRPC inspection configuration file.
+type Config struct {
+	// DescriptorSets are files of serialized FileDescriptorSets, as written by protoc --descriptor_set_out with
+	// --include_imports, that define the methods' services and messages.
+	DescriptorSets []string `json:"descriptorSets"`
+	// Methods are the fully qualified names of the methods to inspect, e.g. "billing.v1.Payments/Charge".
+	Methods []string `json:"methods"`
+}
+
+// LoadConfig reads a gRPC inspection configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Decoder decodes the request messages of the configured methods. It is safe for concurrent use.
+type Decoder struct {
+	// inputs maps the HTTP/2 path of each method, e.g. "/billing.v1.Payments/Charge", to its request message.
+	inputs map[string]protoreflect.MessageDescriptor
+}
+
+// NewDecoder loads the descriptor sets in config and looks up the request message of each method.
+func NewDecoder(config *Config) (*Decoder, error) {
+	files := &descriptorpb.FileDescriptorSet{}
+	for _, f := range config.DescriptorSets {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		set := &descriptorpb.FileDescriptorSet{}
+		if err := proto.Unmarshal(b, set); err != nil {
+			return nil, fmt.Errorf("descriptor set %s: %v", f, err)
+		}
+		files.File = append(files.File, set.File...)
+	}
+	registry, err := protodesc.NewFiles(files)
+	if err != nil {
+		return nil, err
+	}
+	d := &Decoder{inputs: map[string]protoreflect.MessageDescriptor{}}
+	for _, m := range config.Methods {
+		parts := strings.Split(strings.TrimPrefix(m, "/"), "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("method %q is not of the form <service>/<method>", m)
+		}
+		desc, err := registry.FindDescriptorByName(protoreflect.FullName(parts[0]))
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %v", m, err)
+		}
+		svc, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("method %s: %s is not a service", m, parts[0])
+		}
+		method := svc.Methods().ByName(protoreflect.Name(parts[1]))
+		if method == nil {
+			return nil, fmt.Errorf("method %s: service has no method %s", m, parts[1])
+		}
+		if method.IsStreamingClient() {
+			return nil, fmt.Errorf("method %s: client streaming methods can't be inspected", m)
+		}
+		d.inputs["/"+parts[0]+"/"+parts[1]] = method.Input()
+	}
+	return d, nil
+}
+
+// Decode decodes the request message in body, a gRPC request to path, into the same form as encoding/json would
+// decode its canonical JSON, with fields named as in the .proto file and numbers as json.Number. encoding is the
+// value of the grpc-encoding header.
+func (d *Decoder) Decode(path string, body []byte, encoding string) (interface{}, error) {
+	input, ok := d.inputs[path]
+	if !ok {
+		return nil, ErrMethodNotInspected
+	}
+	if len(body) < frameHeaderLen {
+		return nil, fmt.Errorf("gRPC message is %d bytes, shorter than its header", len(body))
+	}
+	n := binary.BigEndian.Uint32(body[1:frameHeaderLen])
+	data := body[frameHeaderLen:]
+	if uint64(len(data)) < uint64(n) {
+		return nil, fmt.Errorf("gRPC message is truncated: %d of %d bytes", len(data), n)
+	}
+	if uint64(len(data)) > uint64(n) {
+		return nil, ErrMultipleMessages
+	}
+	if body[0] != 0 {
+		var err error
+		if data, err = decompress(data, encoding); err != nil {
+			return nil, err
+		}
+	}
+
+	msg := dynamicpb.NewMessage(input)
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	js, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func decompress(data []byte, encoding string) ([]byte, error) {
+	if encoding != "gzip" {
+		return nil, fmt.Errorf("unsupported grpc-encoding %q", encoding)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	out, err := ioutil.ReadAll(io.LimitReader(r, MaxMessageBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > MaxMessageBytes {
+		return nil, fmt.Errorf("gRPC message is more than %d bytes decompressed", MaxMessageBytes)
+	}
+	return out, nil
+}
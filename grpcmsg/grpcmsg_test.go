@@ -0,0 +1,199 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcmsg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// paymentsFile describes:
+//
+//	package billing.v1;
+//	message Money { string currency = 1; int64 units = 2; }
+//	message ChargeRequest { string account = 1; Money amount = 2; }
+//	service Payments {
+//	  rpc Charge(ChargeRequest) returns (ChargeRequest);
+//	  rpc Stream(stream ChargeRequest) returns (ChargeRequest);
+//	}
+var paymentsFile = &descriptorpb.FileDescriptorProto{
+	Name:    proto.String("billing/v1/payments.proto"),
+	Package: proto.String("billing.v1"),
+	Syntax:  proto.String("proto3"),
+	MessageType: []*descriptorpb.DescriptorProto{
+		{
+			Name: proto.String("Money"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("currency", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("units", 2, descriptorpb.FieldDescriptorProto_TYPE_INT64, ""),
+			},
+		},
+		{
+			Name: proto.String("ChargeRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("account", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				field("amount", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".billing.v1.Money"),
+			},
+		},
+	},
+	Service: []*descriptorpb.ServiceDescriptorProto{{
+		Name: proto.String("Payments"),
+		Method: []*descriptorpb.MethodDescriptorProto{
+			{
+				Name:       proto.String("Charge"),
+				InputType:  proto.String(".billing.v1.ChargeRequest"),
+				OutputType: proto.String(".billing.v1.ChargeRequest"),
+			},
+			{
+				Name:            proto.String("Stream"),
+				InputType:       proto.String(".billing.v1.ChargeRequest"),
+				OutputType:      proto.String(".billing.v1.ChargeRequest"),
+				ClientStreaming: proto.Bool(true),
+			},
+		},
+	}},
+}
+
+func field(
+	name string, n int32, t descriptorpb.FieldDescriptorProto_Type, typeName string,
+) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(n),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     t.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func newDecoder(methods ...string) (*Decoder, error) {
+	dir, err := ioutil.TempDir("", "grpcmsg")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	b, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{paymentsFile}})
+	Expect(err).ToNot(HaveOccurred())
+	set := filepath.Join(dir, "payments.pb")
+	Expect(ioutil.WriteFile(set, b, 0644)).To(Succeed())
+	cfg := filepath.Join(dir, "grpc.yaml")
+	js, err := json.Marshal(methods)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ioutil.WriteFile(cfg, []byte("descriptorSets: [\""+set+"\"]\nmethods: "+string(js)+"\n"), 0644)).To(Succeed())
+	c, err := LoadConfig(cfg)
+	Expect(err).ToNot(HaveOccurred())
+	return NewDecoder(c)
+}
+
+// chargeRequest returns a serialized ChargeRequest for account of units USD.
+func chargeRequest(d *Decoder, account string, units int64) []byte {
+	input := d.inputs["/billing.v1.Payments/Charge"]
+	money := dynamicpb.NewMessage(input.Fields().ByName("amount").Message())
+	money.Set(money.Descriptor().Fields().ByName("currency"), protoreflect.ValueOfString("USD"))
+	money.Set(money.Descriptor().Fields().ByName("units"), protoreflect.ValueOfInt64(units))
+	req := dynamicpb.NewMessage(input)
+	req.Set(input.Fields().ByName("account"), protoreflect.ValueOfString(account))
+	req.Set(input.Fields().ByName("amount"), protoreflect.ValueOfMessage(money))
+	b, err := proto.Marshal(req)
+	Expect(err).ToNot(HaveOccurred())
+	return b
+}
+
+func frame(compressed bool, msg []byte) []byte {
+	b := make([]byte, frameHeaderLen, frameHeaderLen+len(msg))
+	if compressed {
+		b[0] = 1
+	}
+	binary.BigEndian.PutUint32(b[1:], uint32(len(msg)))
+	return append(b, msg...)
+}
+
+func TestDecode(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut, err := newDecoder("billing.v1.Payments/Charge")
+	Expect(err).ToNot(HaveOccurred())
+	msg := chargeRequest(uut, "acme", 1500)
+	expected := map[string]interface{}{
+		"account": "acme",
+		// protojson encodes 64 bit integers as strings.
+		"amount": map[string]interface{}{"currency": "USD", "units": "1500"},
+	}
+
+	v, err := uut.Decode("/billing.v1.Payments/Charge", frame(false, msg), "")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(v).To(Equal(expected))
+
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err = w.Write(msg)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(w.Close()).To(Succeed())
+	v, err = uut.Decode("/billing.v1.Payments/Charge", frame(true, gz.Bytes()), "gzip")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(v).To(Equal(expected))
+}
+
+func TestDecodeErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut, err := newDecoder("/billing.v1.Payments/Charge")
+	Expect(err).ToNot(HaveOccurred())
+	msg := frame(false, chargeRequest(uut, "acme", 1500))
+
+	_, err = uut.Decode("/billing.v1.Payments/Refund", msg, "")
+	Expect(err).To(Equal(ErrMethodNotInspected))
+	_, err = uut.Decode("/billing.v1.Payments/Charge", append(msg, msg...), "")
+	Expect(err).To(Equal(ErrMultipleMessages))
+	_, err = uut.Decode("/billing.v1.Payments/Charge", msg[:len(msg)-1], "")
+	Expect(err).To(HaveOccurred())
+	_, err = uut.Decode("/billing.v1.Payments/Charge", msg[:3], "")
+	Expect(err).To(HaveOccurred())
+	_, err = uut.Decode("/billing.v1.Payments/Charge", frame(true, []byte("not gzip")), "gzip")
+	Expect(err).To(HaveOccurred())
+	_, err = uut.Decode("/billing.v1.Payments/Charge", frame(true, []byte("x")), "snappy")
+	Expect(err).To(HaveOccurred())
+	_, err = uut.Decode("/billing.v1.Payments/Charge", frame(false, []byte{0xff, 0xff}), "")
+	Expect(err).To(HaveOccurred())
+}
+
+func TestNewDecoderErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, m := range []string{
+		"billing.v1.Payments",
+		"billing.v1.Ledger/Charge",
+		"billing.v1.Money/Charge",
+		"billing.v1.Payments/Refund",
+		"billing.v1.Payments/Stream",
+	} {
+		_, err := newDecoder(m)
+		Expect(err).To(HaveOccurred(), m)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package handoff hands a running Dikastes's listening socket and policy over to a new Dikastes process, so that an
+// upgrade of the sidecar doesn't leave a gap in which Envoy can't reach an authorizer with policy.
+//
+// The old process serves a handoff socket. The new process connects to it and receives the listening socket's file
+// descriptor and a snapshot of the policy store. It serves checks with the snapshot until it has synced policy
+// itself, and once it is serving, tells the old process, which stops accepting connections and drains.
+package handoff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/projectcalico/app-policy/policystore"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultTimeout bounds a handoff, from the new process connecting until it tells the old one it is serving.
+	DefaultTimeout = 30 * time.Second
+
+	// protocolVersion is the version of the handoff protocol, sent with the listening socket.
+	protocolVersion = 1
+	// ready is the new process's acknowledgement that it is serving.
+	ready = "ready\n"
+)
+
+// offer is what the old process sends after the listening socket.
+type offer struct {
+	// Store is the policy store, as a policystore.Export, or empty if the old process had none.
+	Store json.RawMessage `json:"store,omitempty"`
+}
+
+// Server offers a listening socket, and the policy being enforced, to the next Dikastes that connects to its handoff
+// socket. The handoff socket can only be connected to by the user Dikastes runs as, since whoever takes the listening
+// socket over receives Envoy's checks.
+type Server struct {
+	Timeout time.Duration
+
+	listener *net.UnixListener
+	serving  *net.UnixListener
+	snapshot func() *policystore.Export
+}
+
+// NewServer listens on the handoff socket at path, replacing any stale socket there, to offer serving to the next
+// Dikastes. snapshot returns the policy store being enforced, or nil if there isn't one.
+func NewServer(path string, serving *net.UnixListener, snapshot func() *policystore.Export) (*Server, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// The next process replaces the socket with its own, which this one must not remove when it exits.
+	l.SetUnlinkOnClose(false)
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+	return &Server{Timeout: DefaultTimeout, listener: l, serving: serving, snapshot: snapshot}, nil
+}
+
+// Serve hands over to the first process that connects and completes the handoff, and returns nil once it has. It
+// returns an error if the handoff socket fails, or ctx is done first.
+func (s *Server) Serve(ctx context.Context) error {
+	defer s.listener.Close()
+	go func() {
+		<-ctx.Done()
+		_ = s.listener.Close()
+	}()
+	for {
+		conn, err := s.listener.AcceptUnix()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		} else if err != nil {
+			return err
+		}
+		err = s.handOff(conn)
+		_ = conn.Close()
+		if err == nil {
+			return nil
+		}
+		log.WithError(err).Warn("Unable to hand over to the new Dikastes, continuing to serve.")
+	}
+}
+
+func (s *Server) handOff(conn *net.UnixConn) error {
+	if err := conn.SetDeadline(time.Now().Add(s.Timeout)); err != nil {
+		return err
+	}
+	// The new process serves on the same socket, so closing this one's listener must leave it in place.
+	s.serving.SetUnlinkOnClose(false)
+	raw, err := s.serving.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sendErr error
+	err = raw.Control(func(fd uintptr) {
+		_, _, sendErr = conn.WriteMsgUnix([]byte{protocolVersion}, syscall.UnixRights(int(fd)), nil)
+	})
+	if err != nil {
+		return err
+	} else if sendErr != nil {
+		return sendErr
+	}
+
+	var o offer
+	if e := s.snapshot(); e != nil {
+		if o.Store, err = json.Marshal(e); err != nil {
+			return err
+		}
+	}
+	if err := json.NewEncoder(conn).Encode(&o); err != nil {
+		return err
+	}
+	ack := make([]byte, len(ready))
+	if _, err := io.ReadFull(conn, ack); err != nil {
+		return fmt.Errorf("new Dikastes didn't confirm it is serving: %v", err)
+	} else if string(ack) != ready {
+		return fmt.Errorf("unexpected handoff acknowledgement %q", ack)
+	}
+	return nil
+}
+
+// Takeover is a listening socket, and the policy enforced with it, handed over by the previous Dikastes.
+type Takeover struct {
+	Listener *net.UnixListener
+	// Store is the previous process's policy store, or nil if it had none.
+	Store *policystore.PolicyStore
+
+	conn    *net.UnixConn
+	timeout time.Duration
+}
+
+// TakeOver takes over from the Dikastes serving the handoff socket at path. It returns nil, without an error, if no
+// Dikastes is serving it.
+func TakeOver(path string, timeout time.Duration) (*Takeover, error) {
+	c, err := net.DialTimeout("unix", path, timeout)
+	if errors.Is(err, syscall.ENOENT) || errors.Is(err, syscall.ECONNREFUSED) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	conn := c.(*net.UnixConn)
+	t, err := receive(conn, timeout)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+func receive(conn *net.UnixConn, timeout time.Duration) (*Takeover, error) {
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	version := make([]byte, 1)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(version, oob)
+	if err != nil {
+		return nil, err
+	}
+	lis, err := receiveListener(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+	t := &Takeover{Listener: lis, conn: conn, timeout: timeout}
+	if err := t.readOffer(n, version[0]); err != nil {
+		_ = lis.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// receiveListener returns the listening socket passed in a socket control message.
+func receiveListener(oob []byte) (*net.UnixListener, error) {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil, err
+	}
+	var fds []int
+	for i := range msgs {
+		rights, err := syscall.ParseUnixRights(&msgs[i])
+		if err == nil {
+			fds = append(fds, rights...)
+		}
+	}
+	if len(fds) != 1 {
+		for _, fd := range fds {
+			_ = syscall.Close(fd)
+		}
+		return nil, fmt.Errorf("expected a listening socket, got %d file descriptors", len(fds))
+	}
+	f := os.NewFile(uintptr(fds[0]), "dikastes-listener")
+	defer f.Close()
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, err
+	}
+	lis, ok := l.(*net.UnixListener)
+	if !ok {
+		_ = l.Close()
+		return nil, fmt.Errorf("handed over a %s listener, expected a Unix socket", l.Addr().Network())
+	}
+	return lis, nil
+}
+
+func (t *Takeover) readOffer(n int, version byte) error {
+	if n != 1 || version != protocolVersion {
+		return fmt.Errorf("unsupported handoff protocol version %d, expected %d", version, protocolVersion)
+	}
+	var o offer
+	if err := json.NewDecoder(t.conn).Decode(&o); err != nil {
+		return err
+	}
+	if len(o.Store) == 0 {
+		return nil
+	}
+	e, err := policystore.ReadExport(bytes.NewReader(o.Store))
+	if err != nil {
+		return err
+	}
+	t.Store = e.Store()
+	return nil
+}
+
+// Ready tells the previous process that this one is serving, so that it stops.
+func (t *Takeover) Ready() error {
+	defer t.conn.Close()
+	if err := t.conn.SetDeadline(time.Now().Add(t.timeout)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(t.conn, ready)
+	return err
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handoff
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// oldProcess is the serving side of a handoff: a listening socket and a handoff server offering it.
+type oldProcess struct {
+	dir     string
+	serving *net.UnixListener
+	server  *Server
+	done    chan error
+}
+
+func startOldProcess(ctx context.Context, store *policystore.PolicyStore) *oldProcess {
+	dir, err := ioutil.TempDir("", "handoff")
+	Expect(err).ToNot(HaveOccurred())
+	p := &oldProcess{dir: dir, done: make(chan error, 1)}
+	p.serving, err = net.ListenUnix("unix", &net.UnixAddr{Name: p.path("dikastes.sock"), Net: "unix"})
+	Expect(err).ToNot(HaveOccurred())
+	p.server, err = NewServer(p.path("handoff.sock"), p.serving, func() *policystore.Export {
+		if store == nil {
+			return nil
+		}
+		var e *policystore.Export
+		store.Read(func(ps *policystore.PolicyStore) { e = ps.Export() })
+		return e
+	})
+	Expect(err).ToNot(HaveOccurred())
+	p.server.Timeout = time.Second
+	go func() { p.done <- p.server.Serve(ctx) }()
+	return p
+}
+
+func (p *oldProcess) path(name string) string {
+	return filepath.Join(p.dir, name)
+}
+
+func (p *oldProcess) stop() {
+	_ = p.serving.Close()
+	_ = os.RemoveAll(p.dir)
+}
+
+func TestHandoff(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := policystore.NewPolicyStore()
+	id := proto.PolicyID{Tier: "default", Name: "allow-web"}
+	store.PolicyByID[id] = &proto.Policy{InboundRules: []*proto.Rule{{Action: "allow"}}}
+	store.SetGeneration("policy allow-web", "1")
+	old := startOldProcess(ctx, store)
+	defer old.stop()
+
+	// Only the process's user can take over.
+	info, err := os.Stat(old.path("handoff.sock"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+	takeover, err := TakeOver(old.path("handoff.sock"), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(takeover).ToNot(BeNil())
+	defer takeover.Listener.Close()
+	Expect(takeover.Store.PolicyByID).To(Equal(store.PolicyByID))
+	Expect(takeover.Store.Generation).To(Equal(store.Generation))
+
+	// The old process keeps serving until the new one is ready.
+	Consistently(old.done, 50*time.Millisecond).ShouldNot(Receive())
+	Expect(takeover.Ready()).To(Succeed())
+	Expect(<-old.done).To(Succeed())
+
+	// The new process accepts connections on the socket, which outlives the old process's listener.
+	Expect(old.serving.Close()).To(Succeed())
+	accepted := make(chan error, 1)
+	go func() {
+		c, err := takeover.Listener.Accept()
+		if err == nil {
+			_ = c.Close()
+		}
+		accepted <- err
+	}()
+	c, err := net.Dial("unix", old.path("dikastes.sock"))
+	Expect(err).ToNot(HaveOccurred())
+	defer c.Close()
+	Expect(<-accepted).To(Succeed())
+}
+
+func TestHandoffWithoutStore(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	old := startOldProcess(ctx, nil)
+	defer old.stop()
+	takeover, err := TakeOver(old.path("handoff.sock"), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	defer takeover.Listener.Close()
+	Expect(takeover.Store).To(BeNil())
+	Expect(takeover.Ready()).To(Succeed())
+	Expect(<-old.done).To(Succeed())
+}
+
+func TestHandoffRetriesAfterFailure(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	old := startOldProcess(ctx, nil)
+	defer old.stop()
+	// A new process that fails before it is ready leaves the old one serving, for the next to take over.
+	failed, err := TakeOver(old.path("handoff.sock"), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	_ = failed.Listener.Close()
+	_ = failed.conn.Close()
+	Consistently(old.done, 50*time.Millisecond).ShouldNot(Receive())
+
+	takeover, err := TakeOver(old.path("handoff.sock"), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	defer takeover.Listener.Close()
+	Expect(takeover.Ready()).To(Succeed())
+	Expect(<-old.done).To(Succeed())
+}
+
+func TestTakeOverWithoutServer(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "handoff")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	takeover, err := TakeOver(filepath.Join(dir, "missing.sock"), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(takeover).To(BeNil())
+
+	// A socket left behind by a process that has exited is the same as none.
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: filepath.Join(dir, "stale.sock"), Net: "unix"})
+	Expect(err).ToNot(HaveOccurred())
+	stale.SetUnlinkOnClose(false)
+	Expect(stale.Close()).To(Succeed())
+	takeover, err = TakeOver(filepath.Join(dir, "stale.sock"), time.Second)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(takeover).To(BeNil())
+}
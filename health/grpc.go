@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// watchInterval is how often Watch checks for changes in readiness.
+var watchInterval = time.Second
+
+// grpcHealthServer implements the standard gRPC health checking protocol, grpc.health.v1.Health, so that Kubernetes
+// and gRPC clients can probe Dikastes without knowing its own Healthz service.
+type grpcHealthServer struct {
+	reporter ReadinessReporter
+}
+
+// NewGRPCHealthServer returns a grpc.health.v1.Health server that reports SERVING while r is ready, and NOT_SERVING
+// otherwise. Every service Dikastes serves depends on policy being in sync, so all report the same status.
+func NewGRPCHealthServer(r ReadinessReporter) healthpb.HealthServer {
+	return &grpcHealthServer{reporter: r}
+}
+
+func (h *grpcHealthServer) status() healthpb.HealthCheckResponse_ServingStatus {
+	if h.reporter.Readiness() {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+func (h *grpcHealthServer) Check(
+	_ context.Context, req *healthpb.HealthCheckRequest,
+) (*healthpb.HealthCheckResponse, error) {
+	s := h.status()
+	log.WithFields(log.Fields{"service": req.GetService(), "status": s}).Debug("health service: checked")
+	return &healthpb.HealthCheckResponse{Status: s}, nil
+}
+
+// Watch sends the status, then each change to it, until the client goes away.
+func (h *grpcHealthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	last := healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	for {
+		if s := h.status(); s != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: s}); err != nil {
+				return err
+			}
+			last = s
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// syncedReporter is a ReadinessReporter that can be changed while it is being read.
+type syncedReporter struct {
+	mu    sync.Mutex
+	ready bool
+}
+
+func (r *syncedReporter) Readiness() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ready
+}
+
+func (r *syncedReporter) set(ready bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ready = ready
+}
+
+// watchStream records the statuses sent on a Watch stream.
+type watchStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	mu       sync.Mutex
+	statuses []healthpb.HealthCheckResponse_ServingStatus
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(resp *healthpb.HealthCheckResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses = append(s.statuses, resp.Status)
+	return nil
+}
+
+func (s *watchStream) sent() []healthpb.HealthCheckResponse_ServingStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]healthpb.HealthCheckResponse_ServingStatus(nil), s.statuses...)
+}
+
+func TestGRPCHealthCheck(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := &syncedReporter{}
+	s := NewGRPCHealthServer(r)
+	resp, err := s.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.Status).To(Equal(healthpb.HealthCheckResponse_NOT_SERVING))
+
+	r.set(true)
+	resp, err = s.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "envoy.service.auth.v3.Authorization"})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(resp.Status).To(Equal(healthpb.HealthCheckResponse_SERVING))
+}
+
+func TestGRPCHealthWatch(t *testing.T) {
+	RegisterTestingT(t)
+	defer func(d time.Duration) { watchInterval = d }(watchInterval)
+	watchInterval = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &syncedReporter{}
+	stream := &watchStream{ctx: ctx}
+	done := make(chan error, 1)
+	go func() { done <- NewGRPCHealthServer(r).Watch(&healthpb.HealthCheckRequest{}, stream) }()
+
+	Eventually(stream.sent, time.Second).Should(Equal([]healthpb.HealthCheckResponse_ServingStatus{
+		healthpb.HealthCheckResponse_NOT_SERVING,
+	}))
+	// Only changes are sent.
+	r.set(true)
+	Eventually(stream.sent, time.Second).Should(Equal([]healthpb.HealthCheckResponse_ServingStatus{
+		healthpb.HealthCheckResponse_NOT_SERVING, healthpb.HealthCheckResponse_SERVING,
+	}))
+	Consistently(stream.sent, 50*time.Millisecond).Should(HaveLen(2))
+
+	cancel()
+	Expect(<-done).To(Equal(context.Canceled))
+}
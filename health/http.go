@@ -0,0 +1,37 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"io"
+	"net/http"
+)
+
+// NewHTTPHandler serves readiness on /readiness and liveness on /liveness, for Kubernetes' HTTP probes. Readiness is
+// 200 while r is ready and 503 otherwise, and liveness is 200 while the process can answer at all.
+func NewHTTPHandler(r ReadinessReporter) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/readiness", func(w http.ResponseWriter, _ *http.Request) {
+		if !r.Readiness() {
+			http.Error(w, "not in sync with policy", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok\n")
+	})
+	mux.HandleFunc("/liveness", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "ok\n")
+	})
+	return mux
+}
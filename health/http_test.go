@@ -0,0 +1,42 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := &reporter{}
+	h := NewHTTPHandler(r)
+	get := func(path string) int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w.Code
+	}
+
+	Expect(get("/readiness")).To(Equal(http.StatusServiceUnavailable))
+	Expect(get("/liveness")).To(Equal(http.StatusOK))
+	r.Ready = true
+	Expect(get("/readiness")).To(Equal(http.StatusOK))
+	Expect(get("/liveness")).To(Equal(http.StatusOK))
+	Expect(get("/metrics")).To(Equal(http.StatusNotFound))
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hmacsig verifies HMAC signatures that clients such as webhook senders put in a request header, over
+// configured elements of the request, using secrets mounted into the pod.
+package hmacsig
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// secretRecheckInterval is how often a secret file is checked for changes, so that rotated secrets are picked up.
+const secretRecheckInterval = 10 * time.Second
+
+var (
+	// ErrNoSignature is returned for requests without a signature header.
+	ErrNoSignature = errors.New("no signature")
+	// ErrBadSignature is returned for requests whose signature doesn't match.
+	ErrBadSignature = errors.New("signature doesn't match")
+)
+
+// Config is the HMAC signature configuration file.
+type Config struct {
+	Schemes []Scheme `json:"schemes"`
+}
+
+// Scheme is a way of signing requests.
+type Scheme struct {
+	// Name is how rules refer to the scheme.
+	Name string `json:"name"`
+	// Header carries the signature.
+	Header string `json:"header"`
+	// Prefix, if set, must precede the signature in the header, e.g. "sha256=".
+	Prefix string `json:"prefix,omitempty"`
+	// Algorithm is the hash the HMAC uses: sha1, sha256 or sha512. Defaults to sha256.
+	Algorithm string `json:"algorithm,omitempty"`
+	// Encoding of the signature: hex or base64. Defaults to hex.
+	Encoding string `json:"encoding,omitempty"`
+	// SecretFile holds the secret, such as a key of a mounted Kubernetes Secret. It is reread when it changes.
+	SecretFile string `json:"secretFile"`
+	// Elements are the parts of the request that are signed, joined by Separator: "method", "path", "body",
+	// "header:<name>" or "literal:<text>". Defaults to the body alone.
+	Elements []string `json:"elements,omitempty"`
+	// Separator joins the elements. Defaults to a newline.
+	Separator *string `json:"separator,omitempty"`
+}
+
+// LoadConfig reads an HMAC signature configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Request is the parts of a request that can be signed. Headers are keyed by lower case name.
+type Request struct {
+	Method  string
+	Path    string
+	Headers map[string]string
+	Body    []byte
+}
+
+type scheme struct {
+	Scheme
+	header    string
+	hash      func() hash.Hash
+	separator string
+
+	mu        sync.Mutex
+	secret    []byte
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// Verifier verifies signatures made with a set of schemes.
+type Verifier struct {
+	schemes map[string]*scheme
+	// now is the clock, for tests.
+	now func() time.Time
+}
+
+var hashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// NewVerifier validates config and creates a Verifier for its schemes. Secrets are read when first needed, so that
+// a missing secret only affects the rules that use it.
+func NewVerifier(config *Config) (*Verifier, error) {
+	v := &Verifier{schemes: map[string]*scheme{}, now: time.Now}
+	for _, s := range config.Schemes {
+		if s.Name == "" || s.Header == "" || s.SecretFile == "" {
+			return nil, errors.New("HMAC schemes need a name, header and secret file")
+		}
+		if _, ok := v.schemes[s.Name]; ok {
+			return nil, fmt.Errorf("duplicate HMAC scheme %q", s.Name)
+		}
+		sc := &scheme{Scheme: s, header: strings.ToLower(s.Header), separator: "\n"}
+		alg := s.Algorithm
+		if alg == "" {
+			alg = "sha256"
+		}
+		if sc.hash = hashes[strings.ToLower(alg)]; sc.hash == nil {
+			return nil, fmt.Errorf("HMAC scheme %s: unsupported algorithm %q", s.Name, s.Algorithm)
+		}
+		switch s.Encoding {
+		case "", "hex", "base64":
+		default:
+			return nil, fmt.Errorf("HMAC scheme %s: unsupported encoding %q", s.Name, s.Encoding)
+		}
+		if len(sc.Elements) == 0 {
+			sc.Elements = []string{"body"}
+		}
+		for _, e := range sc.Elements {
+			switch {
+			case e == "method", e == "path", e == "body":
+			case strings.HasPrefix(e, "header:"), strings.HasPrefix(e, "literal:"):
+			default:
+				return nil, fmt.Errorf("HMAC scheme %s: unknown element %q", s.Name, e)
+			}
+		}
+		if s.Separator != nil {
+			sc.separator = *s.Separator
+		}
+		v.schemes[s.Name] = sc
+	}
+	return v, nil
+}
+
+// Verify checks the signature of a request made with the named scheme.
+func (v *Verifier) Verify(name string, r Request) error {
+	s, ok := v.schemes[name]
+	if !ok {
+		return fmt.Errorf("unknown HMAC scheme %q", name)
+	}
+	sig := strings.TrimSpace(r.Headers[s.header])
+	if sig == "" {
+		return ErrNoSignature
+	}
+	if !strings.HasPrefix(sig, s.Prefix) {
+		return ErrBadSignature
+	}
+	got, err := s.decode(sig[len(s.Prefix):])
+	if err != nil {
+		return ErrBadSignature
+	}
+	secret, err := s.readSecret(v.now())
+	if err != nil {
+		return err
+	}
+	mac := hmac.New(s.hash, secret)
+	s.writeSigned(mac, r)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+func (s *scheme) decode(sig string) ([]byte, error) {
+	if s.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(sig)
+	}
+	return hex.DecodeString(strings.ToLower(sig))
+}
+
+// writeSigned writes the signed elements of the request.
+func (s *scheme) writeSigned(w hash.Hash, r Request) {
+	for i, e := range s.Elements {
+		if i > 0 {
+			w.Write([]byte(s.separator))
+		}
+		switch {
+		case e == "method":
+			w.Write([]byte(r.Method))
+		case e == "path":
+			w.Write([]byte(r.Path))
+		case e == "body":
+			w.Write(r.Body)
+		case strings.HasPrefix(e, "header:"):
+			w.Write([]byte(r.Headers[strings.ToLower(strings.TrimPrefix(e, "header:"))]))
+		case strings.HasPrefix(e, "literal:"):
+			w.Write([]byte(strings.TrimPrefix(e, "literal:")))
+		}
+	}
+}
+
+// readSecret returns the scheme's secret, rereading the file if it has changed since it was last checked.
+func (s *scheme) readSecret(now time.Time) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secret != nil && now.Sub(s.checkedAt) < secretRecheckInterval {
+		return s.secret, nil
+	}
+	info, err := os.Stat(s.SecretFile)
+	if err != nil {
+		return nil, err
+	}
+	s.checkedAt = now
+	if s.secret != nil && info.ModTime().Equal(s.modTime) {
+		return s.secret, nil
+	}
+	b, err := ioutil.ReadFile(s.SecretFile)
+	if err != nil {
+		return nil, err
+	}
+	// Secrets written by hand often end with a newline that isn't part of the secret.
+	b = bytes.TrimRight(b, "\r\n")
+	if len(b) == 0 {
+		return nil, fmt.Errorf("HMAC secret file %s is empty", s.SecretFile)
+	}
+	s.secret, s.modTime = b, info.ModTime()
+	return s.secret, nil
+}
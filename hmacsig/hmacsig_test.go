@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hmacsig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func sign(secret, msg string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(msg))
+	return mac.Sum(nil)
+}
+
+func writeSecret(t *testing.T, file, secret string, mtime time.Time) {
+	Expect(ioutil.WriteFile(file, []byte(secret), 0600)).To(Succeed())
+	Expect(os.Chtimes(file, mtime, mtime)).To(Succeed())
+}
+
+func TestVerify(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "hmacsig")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	secretFile := filepath.Join(dir, "secret")
+	writeSecret(t, secretFile, "s3cret\n", time.Unix(1000, 0))
+	colon := ":"
+	v, err := NewVerifier(&Config{Schemes: []Scheme{
+		{Name: "github", Header: "X-Hub-Signature-256", Prefix: "sha256=", SecretFile: secretFile},
+		{
+			Name:       "slack",
+			Header:     "X-Slack-Signature",
+			Prefix:     "v0=",
+			SecretFile: secretFile,
+			Elements:   []string{"literal:v0", "header:X-Slack-Request-Timestamp", "body"},
+			Separator:  &colon,
+		},
+		{
+			Name:       "internal",
+			Header:     "X-Signature",
+			Encoding:   "base64",
+			SecretFile: secretFile,
+			Elements:   []string{"method", "path", "body"},
+		},
+	}})
+	Expect(err).ToNot(HaveOccurred())
+	now := time.Unix(2000, 0)
+	v.now = func() time.Time { return now }
+
+	body := []byte(`{"action":"opened"}`)
+	req := func(headers map[string]string) Request {
+		return Request{Method: "POST", Path: "/hooks", Headers: headers, Body: body}
+	}
+
+	github := "sha256=" + hex.EncodeToString(sign("s3cret", string(body)))
+	Expect(v.Verify("github", req(map[string]string{"x-hub-signature-256": github}))).To(Succeed())
+	slack := "v0=" + hex.EncodeToString(sign("s3cret", "v0:1700000000:"+string(body)))
+	Expect(v.Verify("slack", req(map[string]string{
+		"x-slack-signature":         slack,
+		"x-slack-request-timestamp": "1700000000",
+	}))).To(Succeed())
+	internal := base64.StdEncoding.EncodeToString(sign("s3cret", "POST\n/hooks\n"+string(body)))
+	Expect(v.Verify("internal", req(map[string]string{"x-signature": internal}))).To(Succeed())
+
+	Expect(v.Verify("github", req(map[string]string{}))).To(Equal(ErrNoSignature))
+	Expect(v.Verify("github", req(map[string]string{"x-hub-signature-256": "sha256=00"}))).To(Equal(ErrBadSignature))
+	Expect(v.Verify("github", req(map[string]string{"x-hub-signature-256": github[7:]}))).To(Equal(ErrBadSignature))
+	Expect(v.Verify("slack", req(map[string]string{
+		"x-slack-signature":         slack,
+		"x-slack-request-timestamp": "1700000001",
+	}))).To(Equal(ErrBadSignature))
+	Expect(v.Verify("gitlab", req(map[string]string{}))).ToNot(Succeed())
+
+	// Rotated secrets are picked up once they are rechecked.
+	writeSecret(t, secretFile, "rotated", time.Unix(3000, 0))
+	Expect(v.Verify("github", req(map[string]string{"x-hub-signature-256": github}))).To(Succeed())
+	now = now.Add(secretRecheckInterval)
+	Expect(v.Verify("github", req(map[string]string{"x-hub-signature-256": github}))).To(Equal(ErrBadSignature))
+	rotated := "sha256=" + hex.EncodeToString(sign("rotated", string(body)))
+	Expect(v.Verify("github", req(map[string]string{"x-hub-signature-256": rotated}))).To(Succeed())
+
+	Expect(os.Remove(secretFile)).To(Succeed())
+	now = now.Add(secretRecheckInterval)
+	Expect(v.Verify("github", req(map[string]string{"x-hub-signature-256": rotated}))).ToNot(Succeed())
+}
+
+func TestNewVerifierErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, s := range []Scheme{
+		{Header: "X-Sig", SecretFile: "/s"},
+		{Name: "a", Header: "X-Sig", SecretFile: "/s", Algorithm: "md5"},
+		{Name: "a", Header: "X-Sig", SecretFile: "/s", Encoding: "base32"},
+		{Name: "a", Header: "X-Sig", SecretFile: "/s", Elements: []string{"query"}},
+	} {
+		_, err := NewVerifier(&Config{Schemes: []Scheme{s}})
+		Expect(err).To(HaveOccurred())
+	}
+	_, err := NewVerifier(&Config{Schemes: []Scheme{
+		{Name: "a", Header: "X-Sig", SecretFile: "/s"},
+		{Name: "a", Header: "X-Sig", SecretFile: "/s"},
+	}})
+	Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipaddr parses the IP addresses and CIDRs that Dikastes gets from Envoy, Felix and request headers into
+// canonical forms, so that IPv4, IPv6 and IPv4-mapped IPv6 addresses compare and match consistently. An Envoy
+// listening on a dual-stack socket, for example, reports IPv4 clients as ::ffff:10.0.0.1, which policy written for
+// 10.0.0.0/8 must still match.
+package ipaddr
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Parse parses an IP address in any of the forms it takes in socket addresses and headers: bare, in brackets, with a
+// port, or with an IPv6 zone, which is dropped. IPv4 and IPv4-mapped IPv6 addresses are returned in their 4-byte
+// form. Parse returns nil if s isn't an address.
+func Parse(s string) net.IP {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		s = s[1 : len(s)-1]
+	}
+	if i := strings.LastIndex(s, "%"); i >= 0 {
+		s = s[:i]
+	}
+	ip := net.ParseIP(s)
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
+
+// ParseCIDR parses a CIDR, or a single address as a full-length prefix. IPv4 networks, including IPv4-mapped IPv6
+// networks of at least 96 bits, are returned with 4-byte addresses and masks, so that they match IPv4 addresses
+// however those are written.
+func ParseCIDR(s string) (*net.IPNet, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid CIDR address: %s", s)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}, nil
+	}
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, err
+	}
+	ones, bits := n.Mask.Size()
+	if ip4 := n.IP.To4(); ip4 != nil && bits == 8*net.IPv6len && ones >= 96 {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(ones-96, 8*net.IPv4len)}, nil
+	}
+	return n, nil
+}
+
+// Canonical returns the canonical form of the address in s, as Parse reads it: dotted decimal for IPv4 and
+// IPv4-mapped addresses, and RFC 5952 form for IPv6. It returns s unchanged if it isn't an address.
+func Canonical(s string) string {
+	if ip := Parse(s); ip != nil {
+		return ip.String()
+	}
+	return s
+}
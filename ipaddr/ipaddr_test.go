@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipaddr
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	RegisterTestingT(t)
+
+	for s, want := range map[string]string{
+		"10.0.0.1":                "10.0.0.1",
+		" 10.0.0.1 ":              "10.0.0.1",
+		"10.0.0.1:8080":           "10.0.0.1",
+		"::ffff:10.0.0.1":         "10.0.0.1",
+		"::ffff:a00:1":            "10.0.0.1",
+		"[::ffff:10.0.0.1]:8080":  "10.0.0.1",
+		"2001:db8::1":             "2001:db8::1",
+		"2001:DB8:0:0:0:0:0:1":    "2001:db8::1",
+		"[2001:db8::1]":           "2001:db8::1",
+		"[2001:db8::1]:443":       "2001:db8::1",
+		"fe80::1%eth0":            "fe80::1",
+		"[fe80::1%eth0]:443":      "fe80::1",
+		"::1":                     "::1",
+		"2001:db8:0:0:1:0:0:1":    "2001:db8::1:0:0:1",
+		"2001:0db8:0000::0001:80": "2001:db8::1:80",
+	} {
+		ip := Parse(s)
+		Expect(ip).ToNot(BeNil(), s)
+		Expect(ip.String()).To(Equal(want), s)
+	}
+	Expect(Parse("10.0.0.1")).To(HaveLen(net.IPv4len))
+	Expect(Parse("::ffff:10.0.0.1")).To(HaveLen(net.IPv4len))
+
+	for _, s := range []string{"", "pipe", "10.0.0", "[2001:db8::1", "unix:/var/run/envoy.sock"} {
+		Expect(Parse(s)).To(BeNil(), s)
+	}
+}
+
+func TestParseCIDR(t *testing.T) {
+	RegisterTestingT(t)
+
+	for s, want := range map[string]string{
+		"10.0.0.0/8":          "10.0.0.0/8",
+		"10.1.2.3/8":          "10.0.0.0/8",
+		"10.0.0.1":            "10.0.0.1/32",
+		"::ffff:10.0.0.0/104": "10.0.0.0/8",
+		"::ffff:10.0.0.1":     "10.0.0.1/32",
+		"::ffff:0:0/96":       "0.0.0.0/0",
+		"::/0":                "::/0",
+		"2001:db8::/32":       "2001:db8::/32",
+		"2001:db8::1":         "2001:db8::1/128",
+		"::ffff:0:0/80":       "::/80",
+	} {
+		n, err := ParseCIDR(s)
+		Expect(err).ToNot(HaveOccurred(), s)
+		Expect(n.String()).To(Equal(want), s)
+	}
+
+	for _, s := range []string{"", "10.0.0.0/33", "2001:db8::/129", "pipe/8"} {
+		_, err := ParseCIDR(s)
+		Expect(err).To(HaveOccurred(), s)
+	}
+
+	// IPv4 networks match IPv4 addresses however they are written, and IPv6 networks don't.
+	n, _ := ParseCIDR("::ffff:10.0.0.0/104")
+	Expect(n.Contains(Parse("10.1.2.3"))).To(BeTrue())
+	Expect(n.Contains(Parse("::ffff:10.1.2.3"))).To(BeTrue())
+	n, _ = ParseCIDR("2001:db8::/32")
+	Expect(n.Contains(Parse("[2001:db8::5]:80"))).To(BeTrue())
+	Expect(n.Contains(Parse("10.1.2.3"))).To(BeFalse())
+}
+
+func TestCanonical(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(Canonical("::ffff:192.168.0.1")).To(Equal("192.168.0.1"))
+	Expect(Canonical("2001:DB8::0:1")).To(Equal("2001:db8::1"))
+	Expect(Canonical("pipe")).To(Equal("pipe"))
+	Expect(Canonical("")).To(Equal(""))
+}
@@ -0,0 +1,368 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jwks validates JSON Web Tokens against the keys their issuers publish as JSON Web Key Sets, for deployments
+// where Envoy's jwt_authn filter doesn't validate them first. Key sets are downloaded periodically, and again soon
+// after a token is signed with a key that isn't known yet, so that issuers can rotate their keys.
+package jwks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultHeader is the request header that carries tokens if the config doesn't say, as "Bearer <token>".
+	DefaultHeader = "authorization"
+	// DefaultRefreshInterval is how often key sets are downloaded if their provider's config doesn't say.
+	DefaultRefreshInterval = time.Hour
+	// MaxKeySetBytes bounds the size of a key set download.
+	MaxKeySetBytes = 1 << 20
+	// ClockSkew is how far past its expiry, or before its not-before time, a token is still accepted.
+	ClockSkew = time.Minute
+	// retryInterval is how soon a failed download is retried, if sooner than the refresh interval.
+	retryInterval = time.Minute
+)
+
+// minRefetchInterval limits how often an unknown key ID triggers a download, so that clients can't use tokens with made
+// up key IDs to make Dikastes hammer the issuer. It is a variable for tests.
+var minRefetchInterval = 30 * time.Second
+
+var (
+	// ErrNoToken is returned when the request doesn't carry a token.
+	ErrNoToken = errors.New("no token in request")
+	// ErrNotLoaded is returned when a token's issuer's key set hasn't been downloaded successfully yet.
+	ErrNotLoaded = errors.New("key set not loaded yet")
+)
+
+// Config is the JWT validation configuration file.
+type Config struct {
+	// Header is the request header that carries tokens. Defaults to Authorization.
+	Header    string     `json:"header,omitempty"`
+	Providers []Provider `json:"providers"`
+}
+
+// Provider is a token issuer.
+type Provider struct {
+	// Issuer must equal the iss claim of the tokens the provider issues.
+	Issuer string `json:"issuer"`
+	// JWKSURL is where to download the provider's key set from, over HTTPS, or a file:// URL for a local file, such
+	// as a mounted Secret.
+	JWKSURL string `json:"jwksUrl"`
+	// Insecure allows an http:// JWKSURL. Key sets downloaded without TLS can be tampered with, allowing anyone on
+	// the path to forge tokens, so this is only for testing.
+	Insecure bool `json:"insecure,omitempty"`
+	// Audiences, if set, lists the aud claims that tokens are accepted with; tokens must have at least one of them.
+	Audiences []string `json:"audiences,omitempty"`
+	// RefreshInterval is how often to download the key set, as a Go duration. Defaults to an hour.
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+}
+
+// LoadConfig reads a JWT validation configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type provider struct {
+	Provider
+	// file is the path of the key set, for file:// URLs.
+	file     string
+	interval time.Duration
+	// refetch asks the refresh loop to download the key set early.
+	refetch chan struct{}
+
+	mu           sync.RWMutex
+	keys         []key
+	etag         string
+	lastModified string
+}
+
+// Validator validates tokens issued by a set of providers.
+type Validator struct {
+	header    string
+	providers map[string]*provider
+	client    *http.Client
+}
+
+// NewValidator validates config and creates a Validator for its providers. Call Start to begin downloading their key
+// sets.
+func NewValidator(config *Config) (*Validator, error) {
+	v := &Validator{
+		header:    DefaultHeader,
+		providers: map[string]*provider{},
+		client:    &http.Client{Timeout: time.Minute, CheckRedirect: checkRedirect},
+	}
+	if config.Header != "" {
+		v.header = strings.ToLower(config.Header)
+	}
+	if len(config.Providers) == 0 {
+		return nil, errors.New("no JWT providers")
+	}
+	for _, p := range config.Providers {
+		if p.Issuer == "" || p.JWKSURL == "" {
+			return nil, errors.New("JWT providers need an issuer and JWKS URL")
+		}
+		if _, ok := v.providers[p.Issuer]; ok {
+			return nil, fmt.Errorf("duplicate JWT provider %q", p.Issuer)
+		}
+		pr := &provider{Provider: p, interval: DefaultRefreshInterval, refetch: make(chan struct{}, 1)}
+		file, err := keySetFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("JWT provider %s: %v", p.Issuer, err)
+		}
+		pr.file = file
+		if p.RefreshInterval != "" {
+			d, err := time.ParseDuration(p.RefreshInterval)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("JWT provider %s: invalid refresh interval %q", p.Issuer, p.RefreshInterval)
+			}
+			pr.interval = d
+		}
+		v.providers[p.Issuer] = pr
+	}
+	return v, nil
+}
+
+// keySetFile checks that p's JWKS URL is https://, http:// if p is insecure, or file://, and returns the file's path
+// for file:// URLs.
+func keySetFile(p Provider) (string, error) {
+	u, err := url.Parse(p.JWKSURL)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "https":
+		return "", nil
+	case "http":
+		if !p.Insecure {
+			return "", fmt.Errorf("JWKS URL %q isn't https; set insecure to allow it", p.JWKSURL)
+		}
+		return "", nil
+	case "file":
+		if (u.Host != "" && u.Host != "localhost") || !filepath.IsAbs(u.Path) {
+			return "", fmt.Errorf("JWKS URL %q isn't an absolute local file path", p.JWKSURL)
+		}
+		return filepath.Clean(u.Path), nil
+	}
+	return "", fmt.Errorf("JWKS URL %q isn't https or file", p.JWKSURL)
+}
+
+// checkRedirect stops key set downloads from following redirects from https:// to http://.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if via[0].URL.Scheme == "https" && req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to %q isn't https", req.URL)
+	}
+	return nil
+}
+
+// Start downloads each provider's key set and refreshes it periodically until ctx is cancelled. If a download fails
+// the provider keeps its previous keys.
+func (v *Validator) Start(ctx context.Context) {
+	for _, p := range v.providers {
+		go v.refreshLoop(ctx, p)
+	}
+}
+
+func (v *Validator) refreshLoop(ctx context.Context, p *provider) {
+	for {
+		wait := p.interval
+		last := time.Now()
+		if err := v.refresh(ctx, p); err != nil {
+			log.WithError(err).WithField("issuer", p.Issuer).Warn("Failed to refresh JWKS.")
+			if retryInterval < wait {
+				wait = retryInterval
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.refetch:
+			// Don't refetch more often than minRefetchInterval, however many unknown keys are seen.
+			if d := minRefetchInterval - time.Since(last); d > 0 {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(d):
+				}
+			}
+		case <-time.After(wait):
+		}
+	}
+}
+
+// refresh downloads p's key set, or reads its file, replacing its keys if they have changed.
+func (v *Validator) refresh(ctx context.Context, p *provider) error {
+	if p.file != "" {
+		return readKeySetFile(p)
+	}
+	req, err := http.NewRequest(http.MethodGet, p.JWKSURL, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	p.mu.RLock()
+	if p.etag != "" {
+		req.Header.Set("If-None-Match", p.etag)
+	}
+	if p.lastModified != "" {
+		req.Header.Set("If-Modified-Since", p.lastModified)
+	}
+	p.mu.RUnlock()
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		log.WithField("issuer", p.Issuer).Debug("JWKS not modified.")
+		return nil
+	default:
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	b, err := ioutil.ReadAll(&limitedReader{r: resp.Body, remaining: MaxKeySetBytes})
+	if err != nil {
+		return err
+	}
+	keys, err := parseKeySet(b)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.keys = keys
+	p.etag = resp.Header.Get("ETag")
+	p.lastModified = resp.Header.Get("Last-Modified")
+	p.mu.Unlock()
+	log.WithFields(log.Fields{"issuer": p.Issuer, "keys": len(keys)}).Info("Refreshed JWKS.")
+	return nil
+}
+
+// readKeySetFile reads p's key set from its file.
+func readKeySetFile(p *provider) error {
+	f, err := os.Open(p.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	b, err := ioutil.ReadAll(&limitedReader{r: f, remaining: MaxKeySetBytes})
+	if err != nil {
+		return err
+	}
+	keys, err := parseKeySet(b)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+	log.WithFields(log.Fields{"issuer": p.Issuer, "keys": len(keys)}).Debug("Read JWKS file.")
+	return nil
+}
+
+// limitedReader fails reads beyond the remaining bytes, rather than truncating the key set.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, fmt.Errorf("key set larger than %d bytes", MaxKeySetBytes)
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// Validate validates the token in the request headers, which must be keyed by lower case name, and returns its
+// claims.
+func (v *Validator) Validate(headers map[string]string, now time.Time) (map[string]interface{}, error) {
+	token := strings.TrimSpace(headers[v.header])
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+	if token == "" {
+		return nil, ErrNoToken
+	}
+	return v.ValidateToken(token, now)
+}
+
+// ValidateToken validates a token in JWS compact serialization and returns its claims.
+func (v *Validator) ValidateToken(token string, now time.Time) (map[string]interface{}, error) {
+	t, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	iss, _ := t.claims["iss"].(string)
+	p, ok := v.providers[iss]
+	if !ok {
+		return nil, fmt.Errorf("unknown issuer %q", iss)
+	}
+	p.mu.RLock()
+	keys := p.keys
+	p.mu.RUnlock()
+	if keys == nil {
+		return nil, ErrNotLoaded
+	}
+	found := false
+	for _, k := range keys {
+		if t.header.Kid != "" && k.id != t.header.Kid {
+			continue
+		}
+		found = true
+		if err = k.verify(t.header.Alg, t.signed, t.signature); err == nil {
+			if err := checkClaims(t.claims, p.Audiences, now); err != nil {
+				return nil, err
+			}
+			return t.claims, nil
+		}
+	}
+	if !found {
+		// The issuer may have rotated its keys since we last downloaded them.
+		select {
+		case p.refetch <- struct{}{}:
+		default:
+		}
+		return nil, fmt.Errorf("unknown key ID %q", t.header.Kid)
+	}
+	return nil, err
+}
@@ -0,0 +1,266 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+const issuer = "https://issuer.example.com"
+
+const jwksURL = "https://issuer.example.com/jwks.json"
+
+var now = time.Unix(1700000000, 0)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// signer signs tokens with a key, and publishes the key in a JWK.
+type signer struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func (s signer) jwk() map[string]string {
+	switch pub := s.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": s.kid, "n": b64(pub.N.Bytes()),
+			"e": b64(big.NewInt(int64(pub.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		return map[string]string{"kty": "EC", "kid": s.kid, "crv": "P-256", "x": b64(pub.X.FillBytes(make([]byte, 32))),
+			"y": b64(pub.Y.FillBytes(make([]byte, 32)))}
+	}
+	panic("unsupported key")
+}
+
+func (s signer) sign(t *testing.T, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": s.alg, "kid": s.kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+	var sig []byte
+	if k, ok := s.key.(*ecdsa.PrivateKey); ok {
+		// JWS signatures are the fixed size R and S values, rather than ASN.1.
+		r, ss, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		Expect(err).ToNot(HaveOccurred())
+		sig = append(r.FillBytes(make([]byte, 32)), ss.FillBytes(make([]byte, 32))...)
+	} else {
+		var err error
+		sig, err = s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+		Expect(err).ToNot(HaveOccurred())
+	}
+	return signed + "." + b64(sig)
+}
+
+func newSigners(t *testing.T) (signer, signer) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ToNot(HaveOccurred())
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	return signer{kid: "rsa", alg: "RS256", key: rsaKey}, signer{kid: "ec", alg: "ES256", key: ecKey}
+}
+
+// keyServer serves a key set that tests can change.
+type keyServer struct {
+	mu   sync.Mutex
+	keys []map[string]string
+}
+
+func (k *keyServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": k.keys})
+}
+
+func (k *keyServer) set(signers ...signer) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = nil
+	for _, s := range signers {
+		k.keys = append(k.keys, s.jwk())
+	}
+}
+
+// newValidator returns a Validator for the issuer, downloading its key set from server.
+func newValidator(t *testing.T, server *httptest.Server) *Validator {
+	v, err := NewValidator(&Config{Providers: []Provider{
+		{Issuer: issuer, JWKSURL: server.URL, Audiences: []string{"api"}},
+	}})
+	Expect(err).ToNot(HaveOccurred())
+	v.client.Transport = server.Client().Transport
+	return v
+}
+
+func TestValidate(t *testing.T) {
+	RegisterTestingT(t)
+
+	rs, es := newSigners(t)
+	keys := &keyServer{}
+	keys.set(rs, es)
+	server := httptest.NewTLSServer(keys)
+	defer server.Close()
+	v := newValidator(t, server)
+
+	valid := map[string]interface{}{"iss": issuer, "aud": []string{"other", "api"}, "sub": "alice",
+		"exp": now.Add(time.Hour).Unix()}
+	_, err := v.ValidateToken(rs.sign(t, valid), now)
+	Expect(err).To(Equal(ErrNotLoaded))
+	Expect(v.refresh(context.Background(), v.providers[issuer])).To(Succeed())
+
+	for _, s := range []signer{rs, es} {
+		claims, err := v.Validate(map[string]string{"authorization": "Bearer " + s.sign(t, valid)}, now)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(claims["sub"]).To(Equal("alice"))
+	}
+	_, err = v.Validate(map[string]string{}, now)
+	Expect(err).To(Equal(ErrNoToken))
+
+	for name, claims := range map[string]map[string]interface{}{
+		"expired":       {"iss": issuer, "aud": "api", "exp": now.Add(-time.Hour).Unix()},
+		"not yet valid": {"iss": issuer, "aud": "api", "nbf": now.Add(time.Hour).Unix()},
+		"wrong issuer":  {"iss": "https://evil.example.com", "aud": "api"},
+		"wrong aud":     {"iss": issuer, "aud": "other"},
+	} {
+		_, err := v.ValidateToken(rs.sign(t, claims), now)
+		Expect(err).To(HaveOccurred(), name)
+	}
+	// Within the allowed clock skew.
+	_, err = v.ValidateToken(rs.sign(t, map[string]interface{}{"iss": issuer, "aud": "api",
+		"exp": now.Add(-time.Second).Unix()}), now)
+	Expect(err).ToNot(HaveOccurred())
+
+	// Signatures must be made by the key and algorithm the header names.
+	forged := signer{kid: "rsa", alg: "RS256", key: es.key}
+	_, err = v.ValidateToken(forged.sign(t, valid), now)
+	Expect(err).To(HaveOccurred())
+	wrongAlg := rs
+	wrongAlg.alg = "HS256"
+	_, err = v.ValidateToken(wrongAlg.sign(t, valid), now)
+	Expect(err).To(HaveOccurred())
+	_, err = v.ValidateToken("not.a-token", now)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestKeyRotation(t *testing.T) {
+	RegisterTestingT(t)
+	defer func(d time.Duration) { minRefetchInterval = d }(minRefetchInterval)
+	minRefetchInterval = 10 * time.Millisecond
+
+	rs, es := newSigners(t)
+	keys := &keyServer{}
+	keys.set(rs)
+	server := httptest.NewTLSServer(keys)
+	defer server.Close()
+	v := newValidator(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	v.Start(ctx)
+
+	claims := map[string]interface{}{"iss": issuer, "aud": "api"}
+	Eventually(func() error {
+		_, err := v.ValidateToken(rs.sign(t, claims), now)
+		return err
+	}).Should(Succeed())
+
+	// The issuer rotates to a new key, which is downloaded when a token is first signed with it.
+	keys.set(es)
+	token := es.sign(t, claims)
+	Eventually(func() error {
+		_, err := v.ValidateToken(token, now)
+		return err
+	}).Should(Succeed())
+}
+
+func TestNewValidator(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, cfg := range []*Config{
+		{},
+		{Providers: []Provider{{Issuer: issuer}}},
+		{Providers: []Provider{{Issuer: issuer, JWKSURL: jwksURL}, {Issuer: issuer, JWKSURL: jwksURL}}},
+		{Providers: []Provider{{Issuer: issuer, JWKSURL: jwksURL, RefreshInterval: "soon"}}},
+		// Key sets must be downloaded over TLS, unless explicitly allowed, or read from absolute local paths.
+		{Providers: []Provider{{Issuer: issuer, JWKSURL: "http://issuer.example.com/jwks.json"}}},
+		{Providers: []Provider{{Issuer: issuer, JWKSURL: "ftp://issuer.example.com/jwks.json"}}},
+		{Providers: []Provider{{Issuer: issuer, JWKSURL: "jwks.json"}}},
+		{Providers: []Provider{{Issuer: issuer, JWKSURL: "file://issuer.example.com/jwks.json"}}},
+		{Providers: []Provider{{Issuer: issuer, JWKSURL: "file:jwks.json"}}},
+	} {
+		_, err := NewValidator(cfg)
+		Expect(err).To(HaveOccurred())
+	}
+	v, err := NewValidator(&Config{Header: "X-Token", Providers: []Provider{{Issuer: issuer, JWKSURL: jwksURL}}})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(v.header).To(Equal("x-token"))
+	_, err = NewValidator(&Config{Providers: []Provider{
+		{Issuer: issuer, JWKSURL: "http://issuer.example.com/jwks.json", Insecure: true},
+	}})
+	Expect(err).ToNot(HaveOccurred())
+}
+
+func TestKeySetFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	rs, es := newSigners(t)
+	dir, err := ioutil.TempDir("", "jwks")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "jwks.json")
+	b, err := json.Marshal(map[string]interface{}{"keys": []map[string]string{rs.jwk()}})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(ioutil.WriteFile(file, b, 0600)).To(Succeed())
+
+	v, err := NewValidator(&Config{Providers: []Provider{{Issuer: issuer, JWKSURL: "file://" + file}}})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(v.refresh(context.Background(), v.providers[issuer])).To(Succeed())
+	claims := map[string]interface{}{"iss": issuer, "sub": "alice"}
+	_, err = v.ValidateToken(rs.sign(t, claims), now)
+	Expect(err).ToNot(HaveOccurred())
+	_, err = v.ValidateToken(es.sign(t, claims), now)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestRedirectToHTTP(t *testing.T) {
+	RegisterTestingT(t)
+
+	keys := &keyServer{}
+	plain := httptest.NewServer(keys)
+	defer plain.Close()
+	server := httptest.NewTLSServer(http.RedirectHandler(plain.URL, http.StatusFound))
+	defer server.Close()
+	v := newValidator(t, server)
+	Expect(v.refresh(context.Background(), v.providers[issuer])).To(MatchError(ContainSubstring("isn't https")))
+}
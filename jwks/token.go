@@ -0,0 +1,275 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwks
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	// Register the hashes that signatures are verified with.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// token is a parsed, but not yet verified, JWS compact serialization.
+type token struct {
+	header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	claims map[string]interface{}
+	// signed is the part of the token that the signature covers.
+	signed    []byte
+	signature []byte
+}
+
+func parseToken(s string) (*token, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	t := &token{signed: []byte(parts[0] + "." + parts[1])}
+	header, err := decodeSegment(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token header: %v", err)
+	}
+	if err := json.Unmarshal(header, &t.header); err != nil {
+		return nil, fmt.Errorf("malformed token header: %v", err)
+	}
+	payload, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token payload: %v", err)
+	}
+	d := json.NewDecoder(bytes.NewReader(payload))
+	d.UseNumber()
+	if err := d.Decode(&t.claims); err != nil || t.claims == nil {
+		return nil, fmt.Errorf("malformed token payload: %v", err)
+	}
+	if t.signature, err = decodeSegment(parts[2]); err != nil {
+		return nil, fmt.Errorf("malformed token signature: %v", err)
+	}
+	return t, nil
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// checkClaims checks the token's registered claims: its expiry and not-before times, allowing for ClockSkew, and its
+// audience if audiences is set.
+func checkClaims(claims map[string]interface{}, audiences []string, now time.Time) error {
+	exp, ok, err := numericDate(claims, "exp")
+	if err != nil {
+		return err
+	}
+	if ok && now.After(exp.Add(ClockSkew)) {
+		return errors.New("token expired")
+	}
+	nbf, ok, err := numericDate(claims, "nbf")
+	if err != nil {
+		return err
+	}
+	if ok && now.Add(ClockSkew).Before(nbf) {
+		return errors.New("token not valid yet")
+	}
+	if len(audiences) == 0 {
+		return nil
+	}
+	var aud []string
+	switch a := claims["aud"].(type) {
+	case string:
+		aud = []string{a}
+	case []interface{}:
+		for _, v := range a {
+			if s, ok := v.(string); ok {
+				aud = append(aud, s)
+			}
+		}
+	}
+	for _, a := range aud {
+		for _, want := range audiences {
+			if a == want {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("token audience %v not accepted", aud)
+}
+
+func numericDate(claims map[string]interface{}, name string) (time.Time, bool, error) {
+	v, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("malformed %s claim", name)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("malformed %s claim", name)
+	}
+	return time.Unix(int64(f), 0), true, nil
+}
+
+// key is a public key from a key set.
+type key struct {
+	id string
+	// alg, if set, is the only algorithm the key may be used with.
+	alg string
+	pub crypto.PublicKey
+}
+
+// jwk is a JSON Web Key, as in RFC 7517. Only the parameters of RSA and elliptic curve public keys are read.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// parseKeySet reads the signing keys in a JSON Web Key Set. Keys of other types or uses are skipped.
+func parseKeySet(b []byte) ([]key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, err
+	}
+	keys := []key{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", k.Kid, err)
+		}
+		if pub != nil {
+			keys = append(keys, key{id: k.Kid, alg: k.Alg, pub: pub})
+		}
+	}
+	return keys, nil
+}
+
+// publicKey returns the key, or nil if it isn't of a supported type.
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeSegment(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeSegment(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+			return nil, errors.New("bad RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	case "EC":
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeSegment(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeSegment(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, errors.New("bad EC key")
+		}
+		return pub, nil
+	}
+	return nil, nil
+}
+
+// esCurves are the curves that each ECDSA algorithm signs with.
+var esCurves = map[string]string{"ES256": "P-256", "ES384": "P-384", "ES512": "P-521"}
+
+var hashes = map[string]crypto.Hash{
+	"256": crypto.SHA256,
+	"384": crypto.SHA384,
+	"512": crypto.SHA512,
+}
+
+// verify checks a signature made with the given algorithm. Only asymmetric algorithms are supported: the key set is
+// public, so anyone could make HMAC signatures with it, and "none" isn't a signature at all.
+func (k key) verify(alg string, signed, signature []byte) error {
+	if k.alg != "" && k.alg != alg {
+		return fmt.Errorf("key %q can't be used with %s", k.id, alg)
+	}
+	if len(alg) != 5 {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	h, ok := hashes[alg[2:]]
+	if !ok {
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+	hasher := h.New()
+	hasher.Write(signed)
+	digest := hasher.Sum(nil)
+	switch pub := k.pub.(type) {
+	case *rsa.PublicKey:
+		switch alg[:2] {
+		case "RS":
+			return rsa.VerifyPKCS1v15(pub, h, digest, signature)
+		case "PS":
+			return rsa.VerifyPSS(pub, h, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+	case *ecdsa.PublicKey:
+		if alg[:2] != "ES" || pub.Curve != curves[esCurves[alg]] {
+			break
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("bad signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("bad signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("key %q can't be used with %s", k.id, alg)
+}
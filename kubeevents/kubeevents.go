@@ -0,0 +1,269 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kubeevents reports repeated denials as Kubernetes Events on the destination pod, so that application owners
+// see enforcement in `kubectl describe pod` without access to Dikastes's logs.
+package kubeevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/projectcalico/app-policy/gatewayapi"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultThreshold is the number of denials of a source by a policy, within the interval, that raise an Event.
+	DefaultThreshold = 10
+	// DefaultInterval is how long denials are counted over, and how often each source and policy can raise an Event
+	// on a pod.
+	DefaultInterval = 10 * time.Minute
+	// Reason is the reason of the Events raised.
+	Reason = "PolicyDenied"
+	// Component is the source component of the Events raised.
+	Component = "dikastes"
+
+	// maxTuples bounds the number of (source, pod, policy) tuples counted.
+	maxTuples = 10000
+	// queueSize bounds the Events waiting to be sent. More are dropped.
+	queueSize = 100
+	// burst and refill limit the rate Events are sent at across all tuples, to protect the API server from a client
+	// that is denied by many pods at once.
+	burst  = 20
+	refill = time.Second
+	// postTimeout bounds each request to the API server.
+	postTimeout = 10 * time.Second
+)
+
+// tuple is a source denied by a policy on a pod.
+type tuple struct {
+	source, namespace, pod, policy string
+}
+
+// counter counts a tuple's denials in the current interval.
+type counter struct {
+	start time.Time
+	count int
+}
+
+// event is a Kubernetes core/v1 Event, as far as Dikastes fills it in.
+type event struct {
+	APIVersion     string          `json:"apiVersion"`
+	Kind           string          `json:"kind"`
+	Metadata       eventMeta       `json:"metadata"`
+	InvolvedObject objectReference `json:"involvedObject"`
+	Reason         string          `json:"reason"`
+	Message        string          `json:"message"`
+	Type           string          `json:"type"`
+	Source         eventSource     `json:"source"`
+	FirstTimestamp time.Time       `json:"firstTimestamp"`
+	LastTimestamp  time.Time       `json:"lastTimestamp"`
+	Count          int             `json:"count"`
+}
+
+type eventMeta struct {
+	GenerateName string `json:"generateName"`
+	Namespace    string `json:"namespace"`
+}
+
+type objectReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+}
+
+type eventSource struct {
+	Component string `json:"component"`
+	Host      string `json:"host,omitempty"`
+}
+
+// Recorder counts denials and raises an Event on the destination pod when a source is denied by the same policy
+// Threshold times within an Interval. Each tuple raises at most one Event per Interval.
+type Recorder struct {
+	Threshold int
+	Interval  time.Duration
+	// Node, if set, is reported as the host that raised the Events.
+	Node string
+
+	config gatewayapi.KubeConfig
+	now    func() time.Time
+	queue  chan event
+
+	mu       sync.Mutex
+	counters map[tuple]*counter
+	tokens   int
+	refilled time.Time
+}
+
+// NewRecorder creates a Recorder that raises Events in the cluster that config locates. Call Start to begin sending
+// them.
+func NewRecorder(config *gatewayapi.KubeConfig) *Recorder {
+	return &Recorder{
+		Threshold: DefaultThreshold,
+		Interval:  DefaultInterval,
+		config:    *config,
+		now:       time.Now,
+		queue:     make(chan event, queueSize),
+		counters:  map[tuple]*counter{},
+		tokens:    burst,
+	}
+}
+
+// Start sends Events until ctx is done.
+func (r *Recorder) Start(ctx context.Context) {
+	go r.run(ctx)
+}
+
+// Denied counts a denial of source by policy on the pod in namespace, raising an Event if it reaches the threshold.
+// It never blocks on the API server.
+func (r *Recorder) Denied(source, namespace, pod, policy string) {
+	if namespace == "" || pod == "" {
+		return
+	}
+	t := tuple{source: source, namespace: namespace, pod: pod, policy: policy}
+	now := r.now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c := r.counters[t]
+	if c == nil || now.Sub(c.start) >= r.Interval {
+		if c == nil && len(r.counters) >= maxTuples {
+			r.prune(now)
+			if len(r.counters) >= maxTuples {
+				return
+			}
+		}
+		c = &counter{start: now}
+		r.counters[t] = c
+	}
+	c.count++
+	if c.count != r.Threshold || !r.take(now) {
+		return
+	}
+	select {
+	case r.queue <- r.event(t, c, now):
+	default:
+		log.WithField("pod", namespace+"/"+pod).Debug("Event queue full, dropping denial Event.")
+	}
+}
+
+// prune removes the counters whose interval has passed. It must be called with the lock held.
+func (r *Recorder) prune(now time.Time) {
+	for t, c := range r.counters {
+		if now.Sub(c.start) >= r.Interval {
+			delete(r.counters, t)
+		}
+	}
+}
+
+// take takes a token to send an Event with, if there are any left. It must be called with the lock held.
+func (r *Recorder) take(now time.Time) bool {
+	if r.refilled.IsZero() {
+		r.refilled = now
+	}
+	if n := int(now.Sub(r.refilled) / refill); n > 0 {
+		r.tokens += n
+		if r.tokens > burst {
+			r.tokens = burst
+		}
+		r.refilled = r.refilled.Add(time.Duration(n) * refill)
+	}
+	if r.tokens == 0 {
+		log.Debug("Denial Events rate limited.")
+		return false
+	}
+	r.tokens--
+	return true
+}
+
+func (r *Recorder) event(t tuple, c *counter, now time.Time) event {
+	policy := t.policy
+	if policy == "" {
+		policy = "the default deny"
+	} else {
+		policy = "policy " + policy
+	}
+	source := t.source
+	if source == "" {
+		source = "an unknown source"
+	}
+	return event{
+		APIVersion: "v1",
+		Kind:       "Event",
+		Metadata:   eventMeta{GenerateName: t.pod + ".", Namespace: t.namespace},
+		InvolvedObject: objectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  t.namespace,
+			Name:       t.pod,
+		},
+		Reason:         Reason,
+		Message:        fmt.Sprintf("Denied %d requests from %s by %s.", c.count, source, policy),
+		Type:           "Warning",
+		Source:         eventSource{Component: Component, Host: r.Node},
+		FirstTimestamp: c.start,
+		LastTimestamp:  now,
+		Count:          c.count,
+	}
+}
+
+func (r *Recorder) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-r.queue:
+			if err := r.post(ctx, e); err != nil {
+				log.WithError(err).WithField("pod", e.Metadata.Namespace+"/"+e.InvolvedObject.Name).Warn(
+					"Failed to raise denial Event.")
+			}
+		}
+	}
+}
+
+func (r *Recorder) post(ctx context.Context, e event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, postTimeout)
+	defer cancel()
+	u := r.config.Host + "/api/v1/namespaces/" + url.PathEscape(e.Metadata.Namespace) + "/events"
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	if r.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.config.Token)
+	}
+	resp, err := r.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeevents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/gatewayapi"
+)
+
+func testRecorder(host string) (*Recorder, *time.Time) {
+	r := NewRecorder(&gatewayapi.KubeConfig{Host: host, Token: "t0ken", Client: http.DefaultClient})
+	r.Threshold = 3
+	r.Interval = time.Minute
+	now := time.Unix(1000, 0).UTC()
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func TestRecorderRaisesEvents(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan event, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		Expect(req.Method).To(Equal(http.MethodPost))
+		Expect(req.URL.Path).To(Equal("/api/v1/namespaces/shop/events"))
+		Expect(req.Header.Get("Authorization")).To(Equal("Bearer t0ken"))
+		var e event
+		Expect(json.NewDecoder(req.Body).Decode(&e)).To(Succeed())
+		events <- e
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+	r, now := testRecorder(srv.URL)
+	r.Node = "node1"
+	r.Start(ctx)
+
+	src := "spiffe://cluster.local/ns/default/sa/steve"
+	start := *now
+	for i := 0; i < 5; i++ {
+		r.Denied(src, "shop", "cart-1", "default/deny-writes")
+		*now = now.Add(time.Second)
+	}
+	var e event
+	Eventually(events).Should(Receive(&e))
+	Expect(e).To(Equal(event{
+		APIVersion:     "v1",
+		Kind:           "Event",
+		Metadata:       eventMeta{GenerateName: "cart-1.", Namespace: "shop"},
+		InvolvedObject: objectReference{APIVersion: "v1", Kind: "Pod", Namespace: "shop", Name: "cart-1"},
+		Reason:         Reason,
+		Message:        "Denied 3 requests from " + src + " by policy default/deny-writes.",
+		Type:           "Warning",
+		Source:         eventSource{Component: Component, Host: "node1"},
+		FirstTimestamp: start,
+		LastTimestamp:  start.Add(2 * time.Second),
+		Count:          3,
+	}))
+	// Further denials in the interval don't raise more Events, but those of other tuples do.
+	r.Denied("", "shop", "cart-1", "")
+	r.Denied("", "shop", "cart-1", "")
+	r.Denied("", "shop", "cart-1", "")
+	Eventually(events).Should(Receive(&e))
+	Expect(e.Message).To(Equal("Denied 3 requests from an unknown source by the default deny."))
+	Consistently(events).ShouldNot(Receive())
+
+	// The next interval counts afresh.
+	*now = now.Add(time.Minute)
+	for i := 0; i < 3; i++ {
+		r.Denied(src, "shop", "cart-1", "default/deny-writes")
+	}
+	Eventually(events).Should(Receive(&e))
+	Expect(e.FirstTimestamp).To(Equal(*now))
+}
+
+func TestRecorderRateLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	r, now := testRecorder("http://127.0.0.1:0")
+	r.Threshold = 1
+	for i := 0; i < burst+5; i++ {
+		r.Denied(string(rune('a'+i)), "shop", "cart-1", "default/deny")
+	}
+	Expect(r.queue).To(HaveLen(burst))
+
+	// Tokens refill over time.
+	<-r.queue
+	<-r.queue
+	*now = now.Add(2 * refill)
+	r.Denied("later-1", "shop", "cart-1", "default/deny")
+	r.Denied("later-2", "shop", "cart-1", "default/deny")
+	r.Denied("later-3", "shop", "cart-1", "default/deny")
+	Expect(r.queue).To(HaveLen(burst))
+}
+
+func TestRecorderIgnoresUnknownPods(t *testing.T) {
+	RegisterTestingT(t)
+
+	r, _ := testRecorder("http://127.0.0.1:0")
+	r.Threshold = 1
+	r.Denied("a", "", "", "default/deny")
+	Expect(r.queue).To(BeEmpty())
+	Expect(r.counters).To(BeEmpty())
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package l7flows aggregates Dikastes's decisions into L7 flow logs and ships them to Calico's flow aggregation
+// service, so that application layer policy shows up in the same pipeline as the flow logs Felix reports.
+package l7flows
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectcalico/app-policy/decisionsink"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultInterval is how long decisions are aggregated for before their flows are sent.
+	DefaultInterval = 15 * time.Second
+	// DefaultBatchSize is the most flows sent in one request.
+	DefaultBatchSize = 1000
+	// DefaultMaxFlows bounds the flows aggregated in an interval. Decisions that would start more are dropped.
+	DefaultMaxFlows = 10000
+	// DefaultMaxPending bounds the flows waiting to be sent while the service is unavailable. The oldest are dropped
+	// to make room for more.
+	DefaultMaxPending = 100000
+
+	// minBackoff and maxBackoff bound the delay before retrying flows the service failed to take.
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+	// postTimeout bounds each request to the service.
+	postTimeout = 10 * time.Second
+	// reporter identifies Dikastes as the source of the flows.
+	reporter = "dikastes"
+)
+
+var (
+	sentFlows = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "dikastes_l7_flows_sent_total",
+		Help: "L7 flow logs sent to the flow aggregation service.",
+	})
+	droppedFlows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dikastes_l7_flows_dropped_total",
+		Help: "L7 flow logs dropped, because there were too many in an interval, too many waiting to be sent, " +
+			"or the service rejected them, by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	prometheus.MustRegister(sentFlows, droppedFlows)
+}
+
+// Flow is an L7 flow log: the decisions made on requests with the same source, destination, request line and outcome
+// over an interval.
+type Flow struct {
+	// StartTime and EndTime are the times of the first and last of the flow's decisions, in Unix seconds.
+	StartTime int64 `json:"start_time"`
+	EndTime   int64 `json:"end_time"`
+
+	SourceNamespace      string `json:"src_namespace,omitempty"`
+	SourceServiceAccount string `json:"src_service_account,omitempty"`
+	// SourceIP is only set for sources without a principal that identifies them.
+	SourceIP           string `json:"src_ip,omitempty"`
+	DestNamespace      string `json:"dest_namespace,omitempty"`
+	DestServiceAccount string `json:"dest_service_account,omitempty"`
+	DestPort           uint32 `json:"dest_port_num,omitempty"`
+
+	Method string `json:"method,omitempty"`
+	Host   string `json:"host,omitempty"`
+	URL    string `json:"url,omitempty"`
+
+	// Action is "allow" or "deny", and Verdict the status code Dikastes returned, e.g. PERMISSION_DENIED.
+	Action   string `json:"action"`
+	Verdict  string `json:"verdict"`
+	Enforced bool   `json:"enforced"`
+	Policy   string `json:"policy,omitempty"`
+	RuleID   string `json:"rule_id,omitempty"`
+
+	Count int64 `json:"count"`
+	// DecisionLatencyMean and DecisionLatencyMax are the time taken to decide the flow's requests, in microseconds.
+	DecisionLatencyMean int64 `json:"decision_latency_mean_us"`
+	DecisionLatencyMax  int64 `json:"decision_latency_max_us"`
+
+	Reporter string `json:"reporter"`
+	Node     string `json:"host_name,omitempty"`
+}
+
+// flowKey is what a flow's decisions have in common.
+type flowKey struct {
+	srcNamespace, srcServiceAccount, srcIP string
+	dstNamespace, dstServiceAccount        string
+	dstPort                                uint32
+	method, host, url                      string
+	verdict                                string
+	enforced                               bool
+	policy, ruleID                         string
+}
+
+// flow is a flow being aggregated.
+type flow struct {
+	first, last  time.Time
+	count        int64
+	totalLatency int64
+	maxLatency   int64
+}
+
+// Aggregator aggregates the decisions published to it into flows, and POSTs them to the flow aggregation service at
+// the end of each interval, as newline-delimited JSON. Flows the service fails to take are retried with exponential
+// backoff.
+type Aggregator struct {
+	Interval   time.Duration
+	BatchSize  int
+	MaxFlows   int
+	MaxPending int
+	// Node, if set, is reported as the host the flows were seen on.
+	Node string
+
+	url       string
+	tokenFile string
+	client    *http.Client
+
+	mu    sync.Mutex
+	flows map[flowKey]*flow
+	// pending are the flows waiting to be sent. Only the run loop uses it.
+	pending []Flow
+}
+
+// NewAggregator creates an Aggregator that POSTs flows to url, authenticating with the bearer token read from
+// tokenFile before each request, if it is set. Call Start to begin sending them.
+func NewAggregator(url, tokenFile string) *Aggregator {
+	return &Aggregator{
+		Interval:   DefaultInterval,
+		BatchSize:  DefaultBatchSize,
+		MaxFlows:   DefaultMaxFlows,
+		MaxPending: DefaultMaxPending,
+		url:        url,
+		tokenFile:  tokenFile,
+		client:     &http.Client{},
+		flows:      map[flowKey]*flow{},
+	}
+}
+
+// Publish adds a decision to its flow. It never blocks on the service.
+func (a *Aggregator) Publish(e decisionsink.Event) {
+	k := flowKey{
+		srcNamespace:      e.Source.Namespace,
+		srcServiceAccount: e.Source.ServiceAccount,
+		dstNamespace:      e.Destination.Namespace,
+		dstServiceAccount: e.Destination.ServiceAccount,
+		dstPort:           e.Destination.Port,
+		method:            e.Method,
+		host:              e.Host,
+		url:               e.Path,
+		verdict:           e.Verdict,
+		enforced:          e.Enforced,
+		policy:            e.Policy,
+		ruleID:            e.RuleID,
+	}
+	if e.Source.Principal == "" {
+		k.srcIP = e.Source.IP
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	f := a.flows[k]
+	if f == nil {
+		if len(a.flows) >= a.MaxFlows {
+			droppedFlows.WithLabelValues("too_many_flows").Inc()
+			return
+		}
+		f = &flow{first: e.Time}
+		a.flows[k] = f
+	}
+	if e.Time.Before(f.first) {
+		f.first = e.Time
+	}
+	if e.Time.After(f.last) {
+		f.last = e.Time
+	}
+	f.count++
+	f.totalLatency += e.LatencyMicros
+	if e.LatencyMicros > f.maxLatency {
+		f.maxLatency = e.LatencyMicros
+	}
+}
+
+// Start sends flows until ctx is done.
+func (a *Aggregator) Start(ctx context.Context) {
+	go a.run(ctx)
+}
+
+func (a *Aggregator) run(ctx context.Context) {
+	ticker := time.NewTicker(a.Interval)
+	defer ticker.Stop()
+	var retry <-chan time.Time
+	var backoff time.Duration
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.flush()
+			if retry != nil {
+				// Wait for the retry, rather than hammering a service that is failing.
+				continue
+			}
+		case <-retry:
+			retry = nil
+		}
+		if err := a.send(ctx); err != nil {
+			if backoff *= 2; backoff < minBackoff {
+				backoff = minBackoff
+			} else if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			log.WithError(err).WithFields(log.Fields{"flows": len(a.pending), "retry": backoff}).Warn(
+				"Unable to send L7 flow logs.")
+			retry = time.After(backoff)
+		} else {
+			backoff = 0
+		}
+	}
+}
+
+// flush moves the flows aggregated so far to those waiting to be sent, dropping the oldest waiting if there are too
+// many.
+func (a *Aggregator) flush() {
+	a.mu.Lock()
+	flows := a.flows
+	a.flows = map[flowKey]*flow{}
+	a.mu.Unlock()
+	for k, f := range flows {
+		a.pending = append(a.pending, Flow{
+			StartTime:            f.first.Unix(),
+			EndTime:              f.last.Unix(),
+			SourceNamespace:      k.srcNamespace,
+			SourceServiceAccount: k.srcServiceAccount,
+			SourceIP:             k.srcIP,
+			DestNamespace:        k.dstNamespace,
+			DestServiceAccount:   k.dstServiceAccount,
+			DestPort:             k.dstPort,
+			Method:               k.method,
+			Host:                 k.host,
+			URL:                  k.url,
+			Action:               action(k.verdict),
+			Verdict:              k.verdict,
+			Enforced:             k.enforced,
+			Policy:               k.policy,
+			RuleID:               k.ruleID,
+			Count:                f.count,
+			DecisionLatencyMean:  f.totalLatency / f.count,
+			DecisionLatencyMax:   f.maxLatency,
+			Reporter:             reporter,
+			Node:                 a.Node,
+		})
+	}
+	if n := len(a.pending) - a.MaxPending; n > 0 {
+		droppedFlows.WithLabelValues("buffer_full").Add(float64(n))
+		a.pending = append([]Flow(nil), a.pending[n:]...)
+	}
+}
+
+// action returns the flow log action of a verdict.
+func action(verdict string) string {
+	if verdict == "OK" {
+		return "allow"
+	}
+	return "deny"
+}
+
+// send sends the waiting flows in batches, until the service fails to take one. Batches the service rejects as
+// invalid are dropped, since retrying them won't help.
+func (a *Aggregator) send(ctx context.Context) error {
+	for len(a.pending) > 0 {
+		n := len(a.pending)
+		if n > a.BatchSize {
+			n = a.BatchSize
+		}
+		retryable, err := a.post(ctx, a.pending[:n])
+		if err != nil && retryable {
+			return err
+		} else if err != nil {
+			log.WithError(err).WithField("flows", n).Warn("Flow aggregation service rejected L7 flow logs.")
+			droppedFlows.WithLabelValues("rejected").Add(float64(n))
+		} else {
+			sentFlows.Add(float64(n))
+		}
+		a.pending = a.pending[n:]
+	}
+	a.pending = nil
+	return nil
+}
+
+// post sends a batch of flows, returning whether a failure is worth retrying.
+func (a *Aggregator) post(ctx context.Context, flows []Flow) (bool, error) {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for i := range flows {
+		if err := enc.Encode(&flows[i]); err != nil {
+			return false, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, postTimeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPost, a.url, &body)
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if a.tokenFile != "" {
+		// Read the token every time, since projected service account tokens are rotated.
+		token, err := ioutil.ReadFile(a.tokenFile)
+		if err != nil {
+			return true, err
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return false, nil
+	}
+	err = fmt.Errorf("unexpected status %s", resp.Status)
+	retryable := resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden
+	return retryable, err
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package l7flows
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/projectcalico/app-policy/decisionsink"
+)
+
+func decision(src string, method string, verdict string, at time.Time, latency int64) decisionsink.Event {
+	return decisionsink.Event{
+		Time:          at,
+		Source:        decisionsink.Peer{Principal: src, Namespace: "web", ServiceAccount: "frontend", IP: "10.0.0.1"},
+		Destination:   decisionsink.Peer{Namespace: "shop", ServiceAccount: "cart", IP: "10.0.0.2", Port: 8080},
+		Method:        method,
+		Path:          "/carts",
+		Verdict:       verdict,
+		Enforced:      true,
+		Policy:        "default/carts",
+		LatencyMicros: latency,
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	RegisterTestingT(t)
+
+	a := NewAggregator("http://unused", "")
+	a.Node = "node1"
+	start := time.Unix(1700000000, 0)
+	src := "spiffe://cluster.local/ns/web/sa/frontend"
+	a.Publish(decision(src, "GET", "OK", start.Add(2*time.Second), 30))
+	a.Publish(decision(src, "GET", "OK", start, 10))
+	a.Publish(decision(src, "DELETE", "PERMISSION_DENIED", start, 5))
+	// Sources without principals are told apart by their addresses.
+	a.Publish(decision("", "GET", "OK", start, 5))
+	a.flush()
+
+	Expect(a.pending).To(HaveLen(3))
+	sort.Slice(a.pending, func(i, j int) bool {
+		return a.pending[i].Method+a.pending[i].SourceIP < a.pending[j].Method+a.pending[j].SourceIP
+	})
+	Expect(a.pending[0]).To(Equal(Flow{
+		StartTime:            start.Unix(),
+		EndTime:              start.Unix(),
+		SourceNamespace:      "web",
+		SourceServiceAccount: "frontend",
+		DestNamespace:        "shop",
+		DestServiceAccount:   "cart",
+		DestPort:             8080,
+		Method:               "DELETE",
+		URL:                  "/carts",
+		Action:               "deny",
+		Verdict:              "PERMISSION_DENIED",
+		Enforced:             true,
+		Policy:               "default/carts",
+		Count:                1,
+		DecisionLatencyMean:  5,
+		DecisionLatencyMax:   5,
+		Reporter:             "dikastes",
+		Node:                 "node1",
+	}))
+	Expect(a.pending[1].Action).To(Equal("allow"))
+	Expect(a.pending[1].SourceIP).To(BeEmpty())
+	Expect(a.pending[1].StartTime).To(Equal(start.Unix()))
+	Expect(a.pending[1].EndTime).To(Equal(start.Unix() + 2))
+	Expect(a.pending[1].Count).To(BeEquivalentTo(2))
+	Expect(a.pending[1].DecisionLatencyMean).To(BeEquivalentTo(20))
+	Expect(a.pending[1].DecisionLatencyMax).To(BeEquivalentTo(30))
+	Expect(a.pending[2].SourceIP).To(Equal("10.0.0.1"))
+
+	// Flows are aggregated afresh after each flush.
+	a.Publish(decision(src, "GET", "OK", start, 10))
+	a.flush()
+	Expect(a.pending).To(HaveLen(4))
+}
+
+func TestAggregateLimits(t *testing.T) {
+	RegisterTestingT(t)
+
+	a := NewAggregator("http://unused", "")
+	a.MaxFlows = 2
+	a.MaxPending = 3
+	tooMany := testutil.ToFloat64(droppedFlows.WithLabelValues("too_many_flows"))
+	full := testutil.ToFloat64(droppedFlows.WithLabelValues("buffer_full"))
+	now := time.Unix(1700000000, 0)
+	for _, method := range []string{"GET", "PUT", "POST"} {
+		a.Publish(decision("", method, "OK", now, 1))
+	}
+	// Decisions on existing flows still count.
+	a.Publish(decision("", "GET", "OK", now, 1))
+	Expect(testutil.ToFloat64(droppedFlows.WithLabelValues("too_many_flows"))).To(Equal(tooMany + 1))
+	a.flush()
+	Expect(a.pending).To(HaveLen(2))
+
+	a.Publish(decision("", "PATCH", "OK", now, 1))
+	a.Publish(decision("", "HEAD", "OK", now, 1))
+	a.flush()
+	Expect(a.pending).To(HaveLen(3))
+	Expect(testutil.ToFloat64(droppedFlows.WithLabelValues("buffer_full"))).To(Equal(full + 1))
+}
+
+// fakeService is a flow aggregation service that answers with the given statuses in turn, then 200, recording the
+// flows it accepts.
+type fakeService struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	flows    []Flow
+	auth     []string
+}
+
+func newFakeService(statuses ...int) *fakeService {
+	s := &fakeService{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		Expect(req.Method).To(Equal(http.MethodPost))
+		Expect(req.Header.Get("Content-Type")).To(Equal("application/x-ndjson"))
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.auth = append(s.auth, req.Header.Get("Authorization"))
+		if len(s.statuses) > 0 {
+			w.WriteHeader(s.statuses[0])
+			s.statuses = s.statuses[1:]
+			return
+		}
+		scanner := bufio.NewScanner(req.Body)
+		for scanner.Scan() {
+			var f Flow
+			Expect(json.Unmarshal(scanner.Bytes(), &f)).To(Succeed())
+			s.flows = append(s.flows, f)
+		}
+	}))
+	return s
+}
+
+func (s *fakeService) received() []Flow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flows
+}
+
+func TestSend(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "l7flows")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	tokenFile := filepath.Join(dir, "token")
+	Expect(ioutil.WriteFile(tokenFile, []byte("t0ken\n"), 0600)).To(Succeed())
+
+	svc := newFakeService(http.StatusServiceUnavailable, http.StatusBadRequest)
+	defer svc.Close()
+	a := NewAggregator(svc.URL, tokenFile)
+	a.BatchSize = 2
+	for i := 0; i < 3; i++ {
+		a.pending = append(a.pending, Flow{URL: "/" + string(rune('a'+i)), Count: 1})
+	}
+
+	// Failures worth retrying keep the flows waiting.
+	Expect(a.send(ctx)).To(HaveOccurred())
+	Expect(a.pending).To(HaveLen(3))
+
+	// Rejected batches are dropped, and the rest sent.
+	rejected := testutil.ToFloat64(droppedFlows.WithLabelValues("rejected"))
+	sent := testutil.ToFloat64(sentFlows)
+	Expect(a.send(ctx)).To(Succeed())
+	Expect(a.pending).To(BeEmpty())
+	Expect(svc.received()).To(Equal([]Flow{{URL: "/c", Count: 1}}))
+	Expect(testutil.ToFloat64(droppedFlows.WithLabelValues("rejected"))).To(Equal(rejected + 2))
+	Expect(testutil.ToFloat64(sentFlows)).To(Equal(sent + 1))
+	Expect(svc.auth).To(Equal([]string{"Bearer t0ken", "Bearer t0ken", "Bearer t0ken"}))
+}
+
+func TestStart(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svc := newFakeService()
+	defer svc.Close()
+	a := NewAggregator(svc.URL, "")
+	a.Interval = 20 * time.Millisecond
+	a.Start(ctx)
+	for i := 0; i < 3; i++ {
+		a.Publish(decision("", "GET", "OK", time.Now(), 1))
+	}
+	Eventually(svc.received, time.Second).Should(HaveLen(1))
+	Expect(svc.received()[0].Count).To(BeEquivalentTo(3))
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package learn records the traffic Dikastes sees in learning mode, and suggests Calico policies with application
+// layer rules that would allow it, as a starting point for default-deny policy.
+package learn
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Identity is a workload's service account.
+type Identity struct {
+	Namespace      string
+	ServiceAccount string
+}
+
+// Observation is a request seen in learning mode.
+type Observation struct {
+	Source      Identity
+	Destination Identity
+	Method      string
+	Path        string
+}
+
+// tuple is what is recorded of an observation.
+type tuple struct {
+	src, dst Identity
+	method   string
+	prefix   string
+}
+
+// Recorder records observations made within a window.
+type Recorder struct {
+	start        time.Time
+	window       time.Duration
+	pathSegments int
+
+	mu     sync.Mutex
+	tuples map[tuple]uint64
+	// unidentified counts requests that couldn't be recorded because a peer had no identity.
+	unidentified uint64
+}
+
+// NewRecorder returns a Recorder for observations made within window of start, or after start if window is zero.
+// Paths are recorded as prefixes of at most pathSegments segments, so that rules cover paths with IDs in them.
+func NewRecorder(start time.Time, window time.Duration, pathSegments int) *Recorder {
+	return &Recorder{start: start, window: window, pathSegments: pathSegments, tuples: map[tuple]uint64{}}
+}
+
+// Record records an observation made at now. Observations whose source or destination has no identity are only
+// counted, since the suggested rules couldn't match them.
+func (r *Recorder) Record(o Observation, now time.Time) {
+	if now.Before(r.start) || (r.window > 0 && !now.Before(r.start.Add(r.window))) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if o.Source.ServiceAccount == "" || o.Destination.ServiceAccount == "" {
+		r.unidentified++
+		return
+	}
+	r.tuples[tuple{src: o.Source, dst: o.Destination, method: o.Method, prefix: PathPrefix(o.Path, r.pathSegments)}]++
+}
+
+// PathPrefix returns the first segments of the path, without its query string or fragment.
+func PathPrefix(path string, segments int) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) > segments {
+		parts = parts[:segments]
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// Policy is a Calico NetworkPolicy, with only the fields that suggestions use.
+type Policy struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Metadata   PolicyMetadata `json:"metadata"`
+	Spec       PolicySpec     `json:"spec"`
+}
+
+// PolicyMetadata names a Policy.
+type PolicyMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// PolicySpec selects the workloads a Policy applies to and has its rules.
+type PolicySpec struct {
+	Selector string   `json:"selector"`
+	Types    []string `json:"types"`
+	Ingress  []Rule   `json:"ingress"`
+}
+
+// Rule allows requests from a source.
+type Rule struct {
+	Action string     `json:"action"`
+	Source EntityRule `json:"source"`
+	HTTP   HTTPMatch  `json:"http"`
+}
+
+// EntityRule matches the source of a request.
+type EntityRule struct {
+	NamespaceSelector string              `json:"namespaceSelector"`
+	ServiceAccounts   ServiceAccountMatch `json:"serviceAccounts"`
+}
+
+// ServiceAccountMatch matches service accounts by name.
+type ServiceAccountMatch struct {
+	Names []string `json:"names"`
+}
+
+// HTTPMatch matches the method and path of a request.
+type HTTPMatch struct {
+	Methods []string   `json:"methods"`
+	Paths   []HTTPPath `json:"paths"`
+}
+
+// HTTPPath matches requests whose path starts with Prefix.
+type HTTPPath struct {
+	Prefix string `json:"prefix"`
+}
+
+// Suggest returns a policy for each destination seen, allowing the methods seen from each source on each path prefix.
+// Policies, and their rules, are sorted so that suggestions for the same traffic are the same.
+func (r *Recorder) Suggest() []Policy {
+	type ruleKey struct {
+		src    Identity
+		prefix string
+	}
+	byDst := map[Identity]map[ruleKey][]string{}
+	r.mu.Lock()
+	for t := range r.tuples {
+		rules := byDst[t.dst]
+		if rules == nil {
+			rules = map[ruleKey][]string{}
+			byDst[t.dst] = rules
+		}
+		k := ruleKey{src: t.src, prefix: t.prefix}
+		rules[k] = append(rules[k], t.method)
+	}
+	r.mu.Unlock()
+
+	var policies []Policy
+	for dst, rules := range byDst {
+		p := Policy{
+			APIVersion: "projectcalico.org/v3",
+			Kind:       "NetworkPolicy",
+			Metadata:   PolicyMetadata{Name: "learned-" + dst.ServiceAccount, Namespace: dst.Namespace},
+			Spec: PolicySpec{
+				Selector: fmt.Sprintf("projectcalico.org/serviceaccount == '%s'", dst.ServiceAccount),
+				Types:    []string{"Ingress"},
+			},
+		}
+		for k, methods := range rules {
+			sort.Strings(methods)
+			p.Spec.Ingress = append(p.Spec.Ingress, Rule{
+				Action: "Allow",
+				Source: EntityRule{
+					NamespaceSelector: fmt.Sprintf("projectcalico.org/name == '%s'", k.src.Namespace),
+					ServiceAccounts:   ServiceAccountMatch{Names: []string{k.src.ServiceAccount}},
+				},
+				HTTP: HTTPMatch{Methods: methods, Paths: []HTTPPath{{Prefix: k.prefix}}},
+			})
+		}
+		sort.Slice(p.Spec.Ingress, func(i, j int) bool {
+			a, b := p.Spec.Ingress[i], p.Spec.Ingress[j]
+			if a.Source.NamespaceSelector != b.Source.NamespaceSelector {
+				return a.Source.NamespaceSelector < b.Source.NamespaceSelector
+			}
+			if a.Source.ServiceAccounts.Names[0] != b.Source.ServiceAccounts.Names[0] {
+				return a.Source.ServiceAccounts.Names[0] < b.Source.ServiceAccounts.Names[0]
+			}
+			return a.HTTP.Paths[0].Prefix < b.HTTP.Paths[0].Prefix
+		})
+		policies = append(policies, p)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		a, b := policies[i].Metadata, policies[j].Metadata
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return policies
+}
+
+// ServeHTTP writes the suggested policies as a YAML stream that calicoctl can apply.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	r.mu.Lock()
+	unidentified := r.unidentified
+	r.mu.Unlock()
+	if unidentified > 0 {
+		fmt.Fprintf(w, "# %d requests from or to workloads without a service account identity are not covered.\n",
+			unidentified)
+	}
+	for _, p := range r.Suggest() {
+		b, err := yaml.Marshal(p)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, "---\n%s", b)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package learn
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+var (
+	frontend = Identity{Namespace: "web", ServiceAccount: "frontend"}
+	backend  = Identity{Namespace: "api", ServiceAccount: "backend"}
+	admin    = Identity{Namespace: "ops", ServiceAccount: "admin"}
+)
+
+func TestPathPrefix(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(PathPrefix("/users/42/orders?page=2", 1)).To(Equal("/users"))
+	Expect(PathPrefix("/users/42/orders", 2)).To(Equal("/users/42"))
+	Expect(PathPrefix("/users", 2)).To(Equal("/users"))
+	Expect(PathPrefix("/", 1)).To(Equal("/"))
+	Expect(PathPrefix("/#top", 1)).To(Equal("/"))
+}
+
+func TestRecordWindow(t *testing.T) {
+	RegisterTestingT(t)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := NewRecorder(start, time.Hour, 1)
+	o := Observation{Source: frontend, Destination: backend, Method: "GET", Path: "/users"}
+	r.Record(o, start.Add(-time.Second))
+	r.Record(o, start.Add(time.Hour))
+	Expect(r.Suggest()).To(BeEmpty())
+	r.Record(o, start.Add(time.Minute))
+	Expect(r.Suggest()).To(HaveLen(1))
+
+	// Without a window, recording doesn't stop.
+	r = NewRecorder(start, 0, 1)
+	r.Record(o, start.Add(1000*time.Hour))
+	Expect(r.Suggest()).To(HaveLen(1))
+}
+
+func TestSuggest(t *testing.T) {
+	RegisterTestingT(t)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := NewRecorder(start, 0, 1)
+	for _, o := range []Observation{
+		{Source: frontend, Destination: backend, Method: "GET", Path: "/users/1"},
+		{Source: frontend, Destination: backend, Method: "GET", Path: "/users/2"},
+		{Source: frontend, Destination: backend, Method: "POST", Path: "/users"},
+		{Source: admin, Destination: backend, Method: "DELETE", Path: "/users/1"},
+		{Source: frontend, Destination: backend, Method: "GET", Path: "/health"},
+		{Source: admin, Destination: frontend, Method: "GET", Path: "/metrics"},
+		{Source: Identity{}, Destination: frontend, Method: "GET", Path: "/"},
+	} {
+		r.Record(o, start)
+	}
+
+	policies := r.Suggest()
+	Expect(policies).To(HaveLen(2))
+	p := policies[0]
+	Expect(p.Metadata).To(Equal(PolicyMetadata{Name: "learned-backend", Namespace: "api"}))
+	Expect(p.Spec.Selector).To(Equal("projectcalico.org/serviceaccount == 'backend'"))
+	Expect(p.Spec.Ingress).To(Equal([]Rule{
+		{
+			Action: "Allow",
+			Source: EntityRule{
+				NamespaceSelector: "projectcalico.org/name == 'ops'",
+				ServiceAccounts:   ServiceAccountMatch{Names: []string{"admin"}},
+			},
+			HTTP: HTTPMatch{Methods: []string{"DELETE"}, Paths: []HTTPPath{{Prefix: "/users"}}},
+		},
+		{
+			Action: "Allow",
+			Source: EntityRule{
+				NamespaceSelector: "projectcalico.org/name == 'web'",
+				ServiceAccounts:   ServiceAccountMatch{Names: []string{"frontend"}},
+			},
+			HTTP: HTTPMatch{Methods: []string{"GET"}, Paths: []HTTPPath{{Prefix: "/health"}}},
+		},
+		{
+			Action: "Allow",
+			Source: EntityRule{
+				NamespaceSelector: "projectcalico.org/name == 'web'",
+				ServiceAccounts:   ServiceAccountMatch{Names: []string{"frontend"}},
+			},
+			HTTP: HTTPMatch{Methods: []string{"GET", "POST"}, Paths: []HTTPPath{{Prefix: "/users"}}},
+		},
+	}))
+	Expect(policies[1].Metadata).To(Equal(PolicyMetadata{Name: "learned-frontend", Namespace: "web"}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/policy-recommendations", nil))
+	out := w.Body.String()
+	Expect(out).To(HavePrefix("# 1 requests from or to workloads without a service account identity"))
+	Expect(strings.Count(out, "---\n")).To(Equal(2))
+	Expect(out).To(ContainSubstring("learned-frontend"))
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package middleware enforces Calico application layer policy in Go HTTP servers that aren't behind Envoy. Requests
+// are checked either in-process, by a checker sharing an embedded PolicyStore, or by a local Dikastes over its gRPC
+// socket.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc"
+)
+
+// Checker decides CheckRequests, as the server that checker.NewServer returns does.
+type Checker interface {
+	Check(ctx context.Context, req *authz.CheckRequest) (*authz.CheckResponse, error)
+}
+
+// clientChecker checks requests with a Dikastes over gRPC.
+type clientChecker struct {
+	client authz.AuthorizationClient
+}
+
+func (c clientChecker) Check(ctx context.Context, req *authz.CheckRequest) (*authz.CheckResponse, error) {
+	return c.client.Check(ctx, req)
+}
+
+// Dial connects to the Dikastes at target, such as "/var/run/dikastes/dikastes.sock" with uds.GetDialOptions, and
+// returns a Checker that checks requests with it.
+func Dial(target string, opts ...grpc.DialOption) (Checker, *grpc.ClientConn, error) {
+	conn, err := grpc.Dial(target, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return clientChecker{authz.NewAuthorizationClient(conn)}, conn, nil
+}
+
+// Option configures optional behaviour of the middleware.
+type Option func(*middleware)
+
+// WithPrincipal sets the server's own SPIFFE ID, as the destination principal of the requests it checks.
+func WithPrincipal(id string) Option {
+	return func(m *middleware) {
+		m.principal = id
+	}
+}
+
+// WithMaxBodyBytes sends up to n bytes of request bodies to be checked, for rules that match body fields. The body is
+// still passed on in full.
+func WithMaxBodyBytes(n int) Option {
+	return func(m *middleware) {
+		m.maxBodyBytes = n
+	}
+}
+
+// WithFailOpen passes requests on when they can't be checked, rather than answering 403.
+func WithFailOpen(failOpen bool) Option {
+	return func(m *middleware) {
+		m.failOpen = failOpen
+	}
+}
+
+type middleware struct {
+	checker      Checker
+	principal    string
+	maxBodyBytes int
+	failOpen     bool
+}
+
+// New returns middleware that passes requests that policy allows on to the next handler, with any headers the check
+// adds, and answers the rest with the denied response, or 403 if there isn't one.
+func New(c Checker, opts ...Option) func(http.Handler) http.Handler {
+	m := &middleware{checker: c}
+	for _, o := range opts {
+		o(m)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			m.serve(next, w, r)
+		})
+	}
+}
+
+func (m *middleware) serve(next http.Handler, w http.ResponseWriter, r *http.Request) {
+	req, err := m.checkRequest(r)
+	if err != nil {
+		log.WithError(err).Warn("Failed to read request body to check.")
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		return
+	}
+	resp, err := m.checker.Check(r.Context(), req)
+	if err != nil {
+		log.WithError(err).Error("Failed to check request.")
+		if m.failOpen {
+			next.ServeHTTP(w, r)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	if resp.GetStatus().GetCode() == int32(code.Code_OK) {
+		for _, h := range resp.GetOkResponse().GetHeaders() {
+			if h.GetAppend().GetValue() {
+				r.Header.Add(h.GetHeader().GetKey(), h.GetHeader().GetValue())
+			} else {
+				r.Header.Set(h.GetHeader().GetKey(), h.GetHeader().GetValue())
+			}
+		}
+		next.ServeHTTP(w, r)
+		return
+	}
+	denied := resp.GetDeniedResponse()
+	for _, h := range denied.GetHeaders() {
+		w.Header().Add(h.GetHeader().GetKey(), h.GetHeader().GetValue())
+	}
+	status := int(denied.GetStatus().GetCode())
+	if status == 0 {
+		status = http.StatusForbidden
+		if resp.GetStatus().GetCode() == int32(code.Code_UNAUTHENTICATED) {
+			status = http.StatusUnauthorized
+		}
+	}
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, denied.GetBody())
+}
+
+// checkRequest converts r into a CheckRequest, as Envoy would.
+func (m *middleware) checkRequest(r *http.Request) (*authz.CheckRequest, error) {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	headers := make(map[string]string, len(r.Header)+4)
+	for k, v := range r.Header {
+		headers[strings.ToLower(k)] = strings.Join(v, ",")
+	}
+	headers[":authority"] = r.Host
+	headers[":method"] = r.Method
+	headers[":path"] = r.URL.RequestURI()
+	headers[":scheme"] = scheme
+	httpReq := &authz.AttributeContext_HttpRequest{
+		Method:   r.Method,
+		Path:     r.URL.RequestURI(),
+		Host:     r.Host,
+		Scheme:   scheme,
+		Protocol: r.Proto,
+		Headers:  headers,
+		Size:     r.ContentLength,
+	}
+	if m.maxBodyBytes > 0 && r.Body != nil && r.Body != http.NoBody {
+		body, err := ioutil.ReadAll(io.LimitReader(r.Body, int64(m.maxBodyBytes)))
+		if err != nil {
+			return nil, err
+		}
+		r.Body = readCloser{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+		httpReq.Body = string(body)
+	}
+	source := &authz.AttributeContext_Peer{Address: socketAddress(r.RemoteAddr)}
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		for _, u := range r.TLS.PeerCertificates[0].URIs {
+			if u.Scheme == "spiffe" {
+				source.Principal = u.String()
+				break
+			}
+		}
+	}
+	destination := &authz.AttributeContext_Peer{Principal: m.principal}
+	if addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+		destination.Address = socketAddress(addr.String())
+	}
+	return &authz.CheckRequest{Attributes: &authz.AttributeContext{
+		Source:      source,
+		Destination: destination,
+		Request:     &authz.AttributeContext_Request{Http: httpReq},
+	}}, nil
+}
+
+// readCloser reads the checked part of a body followed by the rest, and closes the original.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func socketAddress(hostport string) *core.Address {
+	host, port, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = hostport
+	}
+	p, _ := strconv.ParseUint(port, 10, 32)
+	return &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{
+		Address:       host,
+		PortSpecifier: &core.SocketAddress_PortValue{PortValue: uint32(p)},
+	}}}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+)
+
+// fakeChecker answers every check with resp, or err, and keeps the last request.
+type fakeChecker struct {
+	resp *authz.CheckResponse
+	err  error
+	req  *authz.CheckRequest
+}
+
+func (f *fakeChecker) Check(_ context.Context, req *authz.CheckRequest) (*authz.CheckResponse, error) {
+	f.req = req
+	return f.resp, f.err
+}
+
+func serve(c Checker, r *http.Request, opts ...Option) (*httptest.ResponseRecorder, *http.Request) {
+	var passed *http.Request
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		passed = r
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	})
+	w := httptest.NewRecorder()
+	New(c, opts...)(next).ServeHTTP(w, r)
+	return w, passed
+}
+
+func TestMiddlewareAllows(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := &fakeChecker{resp: &authz.CheckResponse{
+		Status: &status.Status{Code: int32(code.Code_OK)},
+		HttpResponse: &authz.CheckResponse_OkResponse{OkResponse: &authz.OkHttpResponse{
+			Headers: []*core.HeaderValueOption{{Header: &core.HeaderValue{Key: "x-policy", Value: "reads"}}},
+		}},
+	}}
+	r := httptest.NewRequest("POST", "http://shop.example.com/orders?id=7", strings.NewReader(`{"qty":1000}`))
+	r.RemoteAddr = "10.0.0.9:41000"
+	r.Header.Set("Content-Type", "application/json")
+	w, passed := serve(c, r, WithPrincipal("spiffe://cluster.local/ns/shop/sa/orders"), WithMaxBodyBytes(4))
+
+	Expect(w.Code).To(Equal(http.StatusOK))
+	Expect(w.Body.String()).To(Equal(`{"qty":1000}`))
+	Expect(passed.Header.Get("X-Policy")).To(Equal("reads"))
+
+	attrs := c.req.GetAttributes()
+	Expect(attrs.GetRequest().GetHttp().GetMethod()).To(Equal("POST"))
+	Expect(attrs.GetRequest().GetHttp().GetPath()).To(Equal("/orders?id=7"))
+	Expect(attrs.GetRequest().GetHttp().GetHost()).To(Equal("shop.example.com"))
+	Expect(attrs.GetRequest().GetHttp().GetBody()).To(Equal(`{"qt`))
+	Expect(attrs.GetRequest().GetHttp().GetHeaders()["content-type"]).To(Equal("application/json"))
+	Expect(attrs.GetRequest().GetHttp().GetHeaders()[":authority"]).To(Equal("shop.example.com"))
+	Expect(attrs.GetSource().GetAddress().GetSocketAddress().GetAddress()).To(Equal("10.0.0.9"))
+	Expect(attrs.GetSource().GetAddress().GetSocketAddress().GetPortValue()).To(Equal(uint32(41000)))
+	Expect(attrs.GetDestination().GetPrincipal()).To(Equal("spiffe://cluster.local/ns/shop/sa/orders"))
+}
+
+func TestMiddlewareDenies(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := &fakeChecker{resp: &authz.CheckResponse{Status: &status.Status{Code: int32(code.Code_PERMISSION_DENIED)}}}
+	w, passed := serve(c, httptest.NewRequest("GET", "/", nil))
+	Expect(passed).To(BeNil())
+	Expect(w.Code).To(Equal(http.StatusForbidden))
+
+	c.resp.Status.Code = int32(code.Code_UNAUTHENTICATED)
+	w, _ = serve(c, httptest.NewRequest("GET", "/", nil))
+	Expect(w.Code).To(Equal(http.StatusUnauthorized))
+
+	c.resp.HttpResponse = &authz.CheckResponse_DeniedResponse{DeniedResponse: &authz.DeniedHttpResponse{
+		Status:  &_type.HttpStatus{Code: _type.StatusCode_TooManyRequests},
+		Headers: []*core.HeaderValueOption{{Header: &core.HeaderValue{Key: "Retry-After", Value: "1"}}},
+		Body:    "slow down",
+	}}
+	w, _ = serve(c, httptest.NewRequest("GET", "/", nil))
+	Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+	Expect(w.Header().Get("Retry-After")).To(Equal("1"))
+	Expect(w.Body.String()).To(Equal("slow down"))
+}
+
+func TestMiddlewareCheckFails(t *testing.T) {
+	RegisterTestingT(t)
+
+	c := &fakeChecker{err: errors.New("unavailable")}
+	w, passed := serve(c, httptest.NewRequest("GET", "/", nil))
+	Expect(passed).To(BeNil())
+	Expect(w.Code).To(Equal(http.StatusForbidden))
+
+	w, passed = serve(c, httptest.NewRequest("GET", "/", nil), WithFailOpen(true))
+	Expect(passed).ToNot(BeNil())
+	Expect(w.Code).To(Equal(http.StatusOK))
+}
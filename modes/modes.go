@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package modes holds whether each WAF ruleset and threat feed blocks requests or only detects them, so that new
+// rulesets and feeds can be soaked before they are enforced. Modes can be changed at runtime by reloading the file.
+package modes
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+// Mode is how a WAF ruleset or threat feed is enforced.
+type Mode string
+
+const (
+	// Block lets the ruleset or feed decide requests. It is the mode of anything the config doesn't list.
+	Block Mode = "block"
+	// Detect logs and reports what the ruleset or feed matches, but never lets it change a verdict.
+	Detect Mode = "detect"
+)
+
+// Config is the enforcement mode configuration file.
+type Config struct {
+	// WAF maps WAF ruleset names to their modes.
+	WAF map[string]Mode `json:"waf,omitempty"`
+	// ThreatFeeds maps threat feed names to their modes.
+	ThreatFeeds map[string]Mode `json:"threatFeeds,omitempty"`
+}
+
+// LoadConfig reads and validates an enforcement mode configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	for kind, m := range map[string]map[string]Mode{"WAF ruleset": c.WAF, "threat feed": c.ThreatFeeds} {
+		for name, mode := range m {
+			if mode != Block && mode != Detect {
+				return nil, fmt.Errorf("%s %s: unknown mode %q", kind, name, mode)
+			}
+		}
+	}
+	return c, nil
+}
+
+// Modes holds the current enforcement modes. It is safe for concurrent use.
+type Modes struct {
+	mu     sync.RWMutex
+	config *Config
+}
+
+// New returns Modes initially set from config, which may be nil to block with everything.
+func New(config *Config) *Modes {
+	m := &Modes{}
+	m.Set(config)
+	return m
+}
+
+// Set replaces the current modes.
+func (m *Modes) Set(config *Config) {
+	if config == nil {
+		config = &Config{}
+	}
+	m.mu.Lock()
+	m.config = config
+	m.mu.Unlock()
+}
+
+// WAFDetectOnly returns true if the named WAF ruleset only detects.
+func (m *Modes) WAFDetectOnly(ruleset string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config.WAF[ruleset] == Detect
+}
+
+// ThreatFeedDetectOnly returns true if the named threat feed only detects.
+func (m *Modes) ThreatFeedDetectOnly(feed string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.config.ThreatFeeds[feed] == Detect
+}
+
+// ReloadOnSignal reloads the modes from file each time one of sigs is received, until ctx is cancelled. If the file
+// can't be loaded the current modes are kept.
+func (m *Modes) ReloadOnSignal(ctx context.Context, file string, sigs ...os.Signal) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	defer signal.Stop(ch)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			c, err := LoadConfig(file)
+			if err != nil {
+				log.WithError(err).WithField("file", file).Error("Unable to reload enforcement modes; keeping current modes.")
+				continue
+			}
+			m.Set(c)
+			log.WithFields(log.Fields{"signal": sig, "file": file}).Info("Reloaded enforcement modes.")
+		}
+	}
+}
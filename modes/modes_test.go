@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package modes
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestModes(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "modes")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "modes.yaml")
+	Expect(ioutil.WriteFile(file, []byte(`
+waf: {default: block, sqli-beta: detect}
+threatFeeds: {new-feed: detect}
+`), 0644)).To(Succeed())
+	cfg, err := LoadConfig(file)
+	Expect(err).ToNot(HaveOccurred())
+
+	uut := New(cfg)
+	Expect(uut.WAFDetectOnly("default")).To(BeFalse())
+	Expect(uut.WAFDetectOnly("sqli-beta")).To(BeTrue())
+	Expect(uut.WAFDetectOnly("other")).To(BeFalse())
+	Expect(uut.ThreatFeedDetectOnly("new-feed")).To(BeTrue())
+	Expect(uut.ThreatFeedDetectOnly("sqli-beta")).To(BeFalse())
+
+	uut.Set(nil)
+	Expect(uut.WAFDetectOnly("sqli-beta")).To(BeFalse())
+	Expect(New(nil).ThreatFeedDetectOnly("new-feed")).To(BeFalse())
+}
+
+func TestLoadConfigInvalidMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "modes")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "modes.yaml")
+	Expect(ioutil.WriteFile(file, []byte(`threatFeeds: {new-feed: soak}`), 0644)).To(Succeed())
+	_, err = LoadConfig(file)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir, err := ioutil.TempDir("", "modes")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "modes.yaml")
+	Expect(ioutil.WriteFile(file, []byte(`waf: {crs: detect}`), 0644)).To(Succeed())
+
+	// Stop SIGHUP from killing the test before the reloader's handler is installed.
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	uut := New(nil)
+	go uut.ReloadOnSignal(ctx, file, syscall.SIGHUP)
+	// Keep signalling until the handler is installed and reloads.
+	Eventually(func() bool {
+		Expect(syscall.Kill(os.Getpid(), syscall.SIGHUP)).To(Succeed())
+		return uut.WAFDetectOnly("crs")
+	}, time.Second, 10*time.Millisecond).Should(BeTrue())
+
+	// An invalid file keeps the current modes. Replace the file rather than rewriting it, so that a signal still
+	// pending from above doesn't reload it empty.
+	invalid := filepath.Join(dir, "invalid.yaml")
+	Expect(ioutil.WriteFile(invalid, []byte(`waf: {crs: maybe}`), 0644)).To(Succeed())
+	Expect(os.Rename(invalid, file)).To(Succeed())
+	Expect(syscall.Kill(os.Getpid(), syscall.SIGHUP)).To(Succeed())
+	time.Sleep(50 * time.Millisecond)
+	Expect(uut.WAFDetectOnly("crs")).To(BeTrue())
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policylint finds the clauses of synced policies and profiles that Dikastes can't enforce, and keeps a
+// report of them, so that they are visible rather than silently ignored.
+package policylint
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/projectcalico/app-policy/checker"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Clause is a rule clause that Dikastes can't enforce.
+type Clause struct {
+	// Direction is "inbound" or "outbound".
+	Direction string `json:"direction"`
+	// Rule is the index of the rule in its direction's rules.
+	Rule   int    `json:"rule"`
+	RuleID string `json:"ruleId,omitempty"`
+	// Field is the name of the rule field in the Policy Sync API.
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+const (
+	reasonNotChecked   = "not checked by Dikastes, so the rule matches as if it were absent"
+	reasonNeverMatches = "only TCP requests reach Dikastes, so the rule never matches"
+	reasonWildcard     = "wildcard domains can't be resolved, so they never match"
+	reasonBadRegex     = "the regex is invalid, so the path never matches"
+)
+
+var unenforceable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "dikastes_unenforceable_clauses",
+	Help: "Number of rule clauses that Dikastes can't enforce, by policy or profile.",
+}, []string{"policy"})
+
+func init() {
+	prometheus.MustRegister(unenforceable)
+}
+
+// Lint returns the clauses of a policy or profile's rules that can't be enforced.
+func Lint(inbound, outbound []*proto.Rule) []Clause {
+	var clauses []Clause
+	for _, d := range []struct {
+		name  string
+		rules []*proto.Rule
+	}{{"inbound", inbound}, {"outbound", outbound}} {
+		for i, r := range d.rules {
+			for _, c := range lintRule(r) {
+				c.Direction, c.Rule, c.RuleID = d.name, i, r.GetRuleId()
+				clauses = append(clauses, c)
+			}
+		}
+	}
+	return clauses
+}
+
+// lintRule returns the unenforceable clauses of a rule, in field order.
+func lintRule(r *proto.Rule) []Clause {
+	var clauses []Clause
+	add := func(field, reason string) {
+		clauses = append(clauses, Clause{Field: field, Reason: reason})
+	}
+	if p := r.GetProtocol(); p != nil && !isTCP(p) {
+		add("protocol", reasonNeverMatches)
+	}
+	if p := r.GetNotProtocol(); p != nil && isTCP(p) {
+		add("not_protocol", reasonNeverMatches)
+	}
+	if r.GetIcmp() != nil {
+		add("icmp", reasonNotChecked)
+	}
+	if r.GetNotIcmp() != nil {
+		add("not_icmp", reasonNotChecked)
+	}
+	if domains, ok := r.GetMetadata().GetAnnotations()[checker.DstDomainsAnnotation]; ok {
+		for _, name := range strings.Split(domains, ",") {
+			if checker.IsWildcardDomain(strings.TrimSpace(name)) {
+				add("metadata.annotations["+checker.DstDomainsAnnotation+"]", reasonWildcard)
+				break
+			}
+		}
+	}
+	for _, p := range r.GetHttpMatch().GetPaths() {
+		if m, ok := p.GetPathMatch().(*proto.HTTPMatch_PathMatch_Regex); ok {
+			if _, err := policystore.CompilePathRegexp(m.Regex); err != nil {
+				add("http_match.paths", reasonBadRegex)
+				break
+			}
+		}
+	}
+	return clauses
+}
+
+func isTCP(p *proto.Protocol) bool {
+	if name := p.GetName(); name != "" {
+		return strings.EqualFold(name, "tcp")
+	}
+	return p.GetNumber() == 6
+}
+
+// Report holds the unenforceable clauses of each policy and profile. It is safe for concurrent use, and serves itself
+// as JSON over HTTP.
+type Report struct {
+	mu      sync.RWMutex
+	clauses map[string][]Clause
+}
+
+// NewReport returns an empty Report.
+func NewReport() *Report {
+	return &Report{clauses: map[string][]Clause{}}
+}
+
+// PolicyName names a policy in the report, as "policy/<tier>/<name>".
+func PolicyName(id proto.PolicyID) string {
+	return "policy/" + id.GetTier() + "/" + id.GetName()
+}
+
+// ProfileName names a profile in the report, as "profile/<name>".
+func ProfileName(id proto.ProfileID) string {
+	return "profile/" + id.GetName()
+}
+
+// Set records the unenforceable clauses of the named policy or profile. Passing none removes it from the report.
+func (r *Report) Set(name string, clauses []Clause) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(clauses) == 0 {
+		delete(r.clauses, name)
+		unenforceable.DeleteLabelValues(name)
+		return
+	}
+	r.clauses[name] = clauses
+	unenforceable.WithLabelValues(name).Set(float64(len(clauses)))
+}
+
+// Reset empties the report, for example before a resync.
+func (r *Report) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name := range r.clauses {
+		unenforceable.DeleteLabelValues(name)
+	}
+	r.clauses = map[string][]Clause{}
+}
+
+// Clauses returns a copy of the report.
+func (r *Report) Clauses() map[string][]Clause {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string][]Clause, len(r.clauses))
+	for k, v := range r.clauses {
+		out[k] = v
+	}
+	return out
+}
+
+// ServeHTTP writes the report as a JSON object mapping policy and profile names to their unenforceable clauses.
+func (r *Report) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(r.Clauses())
+}
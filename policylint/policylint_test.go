@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policylint
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/checker"
+	"github.com/projectcalico/app-policy/proto"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLint(t *testing.T) {
+	RegisterTestingT(t)
+
+	inbound := []*proto.Rule{
+		{Action: "allow", Protocol: &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: "TCP"}}},
+		{Action: "deny", RuleId: "r1", Protocol: &proto.Protocol{NumberOrName: &proto.Protocol_Number{Number: 17}}},
+		{Action: "allow", Icmp: &proto.Rule_IcmpType{IcmpType: 8}, NotDstPorts: []*proto.PortRange{{First: 22, Last: 22}}},
+	}
+	outbound := []*proto.Rule{
+		{Action: "allow", IpVersion: proto.IPVersion_IPV6},
+		{Action: "allow", NotProtocol: &proto.Protocol{NumberOrName: &proto.Protocol_Number{Number: 6}}},
+		{Action: "deny", Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+			checker.DstDomainsAnnotation: "api.example.com, *.example.com",
+		}}},
+		{Action: "deny", Metadata: &proto.RuleMetadata{Annotations: map[string]string{
+			checker.DstDomainsAnnotation: "api.example.com",
+		}}},
+		{Action: "allow", HttpMatch: &proto.HTTPMatch{Paths: []*proto.HTTPMatch_PathMatch{
+			{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/carts/[0-9]+"}},
+			{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: "/carts/("}},
+		}}},
+	}
+	Expect(Lint(inbound, outbound)).To(Equal([]Clause{
+		{Direction: "inbound", Rule: 1, RuleID: "r1", Field: "protocol", Reason: reasonNeverMatches},
+		{Direction: "inbound", Rule: 2, Field: "icmp", Reason: reasonNotChecked},
+		{Direction: "outbound", Rule: 1, Field: "not_protocol", Reason: reasonNeverMatches},
+		{Direction: "outbound", Rule: 2, Field: "metadata.annotations[alp.projectcalico.org/dst-domains]",
+			Reason: reasonWildcard},
+		{Direction: "outbound", Rule: 4, Field: "http_match.paths", Reason: reasonBadRegex},
+	}))
+	Expect(Lint(inbound[:1], nil)).To(BeEmpty())
+}
+
+func TestReport(t *testing.T) {
+	RegisterTestingT(t)
+
+	r := NewReport()
+	name := PolicyName(proto.PolicyID{Tier: "default", Name: "p1"})
+	clauses := []Clause{{Direction: "inbound", Field: "icmp", Reason: reasonNotChecked}}
+	r.Set(name, clauses)
+	r.Set(ProfileName(proto.ProfileID{Name: "clean"}), nil)
+	Expect(r.Clauses()).To(Equal(map[string][]Clause{"policy/default/p1": clauses}))
+	Expect(testutil.ToFloat64(unenforceable.WithLabelValues(name))).To(Equal(1.0))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/unenforceable-clauses", nil))
+	Expect(w.Header().Get("Content-Type")).To(Equal("application/json"))
+	var got map[string][]Clause
+	Expect(json.Unmarshal(w.Body.Bytes(), &got)).To(Succeed())
+	Expect(got).To(Equal(r.Clauses()))
+
+	r.Set(name, nil)
+	Expect(r.Clauses()).To(BeEmpty())
+	r.Set(name, clauses)
+	r.Reset()
+	Expect(r.Clauses()).To(BeEmpty())
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policystatus writes the hit counts of Calico policies that Dikastes enforces to annotations on the policies
+// themselves, so that `calicoctl get` and `kubectl get` show which policies are matching application traffic when
+// statistics can't be reported through Felix.
+//
+// Each Dikastes writes one annotation per policy, keyed by its reporter name:
+//
+//	metadata:
+//	  annotations:
+//	    status.alp.projectcalico.org/frontend-7d4b9c: '{"enforcedHits":120,"dryRunHits":0,"lastHit":"..."}'
+//
+// Counts are totals since Dikastes started.
+package policystatus
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectcalico/app-policy/gatewayapi"
+	"github.com/projectcalico/app-policy/statscache"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultInterval is how often the status of policies hit since the last write is written.
+	DefaultInterval = time.Minute
+	// AnnotationPrefix prefixes the name of the annotation each reporter writes a policy's status to.
+	AnnotationPrefix = "status.alp.projectcalico.org/"
+	// Group and Version of the resources Calico stores policies as in Kubernetes.
+	Group   = "crd.projectcalico.org"
+	Version = "v1"
+
+	// maxNameLength is the longest an annotation's name, after the prefix, can be.
+	maxNameLength = 63
+	// maxPolicies bounds the number of policies tracked.
+	maxPolicies = 10000
+	// patchTimeout bounds each request to the API server.
+	patchTimeout = 10 * time.Second
+)
+
+// Status is what a reporter writes to a policy's annotation.
+type Status struct {
+	// EnforcedHits is the number of requests the policy decided, and whose verdict was enforced.
+	EnforcedHits int64 `json:"enforcedHits"`
+	// DryRunHits is the number of requests the policy decided, and whose verdict was only logged.
+	DryRunHits int64     `json:"dryRunHits"`
+	LastHit    time.Time `json:"lastHit"`
+}
+
+// policy is a policy, as Felix names it.
+type policy struct {
+	tier, name string
+}
+
+// Writer accumulates the policy hits it's given and writes the status of the policies hit every Interval.
+type Writer struct {
+	Interval time.Duration
+
+	config   gatewayapi.KubeConfig
+	reporter string
+	now      func() time.Time
+
+	mu       sync.Mutex
+	statuses map[policy]*Status
+	dirty    map[policy]bool
+}
+
+// NewWriter creates a Writer that writes statuses under the reporter's name, usually the pod name, to the cluster that
+// config locates. Call Start to begin writing them.
+func NewWriter(config *gatewayapi.KubeConfig, reporter string) *Writer {
+	return &Writer{
+		Interval: DefaultInterval,
+		config:   *config,
+		reporter: reporter,
+		now:      time.Now,
+		statuses: map[policy]*Status{},
+		dirty:    map[policy]bool{},
+	}
+}
+
+// Start writes statuses every Interval until ctx is done.
+func (w *Writer) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Add counts the policy hits in d. It never blocks on the API server.
+func (w *Writer) Add(d statscache.DPStats) {
+	now := w.now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for h, n := range d.Values.PolicyHits {
+		p := policy{tier: h.Tier, name: h.Name}
+		s := w.statuses[p]
+		if s == nil {
+			if len(w.statuses) >= maxPolicies {
+				log.WithField("policy", h.Tier+"/"+h.Name).Debug("Too many policies to track status of.")
+				continue
+			}
+			s = &Status{}
+			w.statuses[p] = s
+		}
+		if h.Enforced {
+			s.EnforcedHits += n
+		} else {
+			s.DryRunHits += n
+		}
+		s.LastHit = now
+		w.dirty[p] = true
+	}
+}
+
+func (w *Writer) run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.flush(ctx)
+		}
+	}
+}
+
+// flush writes the status of each policy hit since the last flush. Policies that fail to be written are retried on
+// the next flush, unless they don't exist as resources.
+func (w *Writer) flush(ctx context.Context) {
+	w.mu.Lock()
+	statuses := map[policy]Status{}
+	for p := range w.dirty {
+		statuses[p] = *w.statuses[p]
+	}
+	w.dirty = map[policy]bool{}
+	w.mu.Unlock()
+
+	for p, s := range statuses {
+		logCxt := log.WithField("policy", p.tier+"/"+p.name)
+		path, ok := policyPath(p.name)
+		if !ok {
+			// Kubernetes NetworkPolicies have no Calico resource to annotate.
+			w.forget(p)
+			continue
+		}
+		found, err := w.patch(ctx, path, s)
+		if err != nil {
+			logCxt.WithError(err).Warn("Failed to write policy status.")
+			w.mu.Lock()
+			w.dirty[p] = true
+			w.mu.Unlock()
+		} else if !found {
+			logCxt.Debug("Policy not found, not writing its status.")
+			w.forget(p)
+		}
+	}
+}
+
+// forget stops tracking p, for policies whose status can't be written.
+func (w *Writer) forget(p policy) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.statuses, p)
+	delete(w.dirty, p)
+}
+
+// policyPath returns the API path of the resource a policy is stored as. Felix names namespaced policies
+// "<namespace>/<name>". ok is false for Kubernetes NetworkPolicies, which aren't stored as Calico resources.
+func policyPath(name string) (path string, ok bool) {
+	base := "/apis/" + Group + "/" + Version
+	if i := strings.Index(name, "/"); i >= 0 {
+		ns, name := name[:i], name[i+1:]
+		if strings.HasPrefix(name, "knp.") {
+			return "", false
+		}
+		return base + "/namespaces/" + url.PathEscape(ns) + "/networkpolicies/" + url.PathEscape(name), true
+	}
+	return base + "/globalnetworkpolicies/" + url.PathEscape(name), true
+}
+
+// annotationName returns the name of the reporter's annotation. Reporter names too long for an annotation are
+// truncated, keeping a hash of the whole name to tell them apart.
+func annotationName(reporter string) string {
+	if len(reporter) <= maxNameLength {
+		return AnnotationPrefix + reporter
+	}
+	sum := sha256.Sum256([]byte(reporter))
+	hash := hex.EncodeToString(sum[:])[:8]
+	return AnnotationPrefix + reporter[:maxNameLength-len(hash)-1] + "-" + hash
+}
+
+// patch writes s to the reporter's annotation on the policy at path. found is false if there's no such policy.
+func (w *Writer) patch(ctx context.Context, path string, s Status) (found bool, err error) {
+	value, err := json.Marshal(s)
+	if err != nil {
+		return false, err
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{annotationName(w.reporter): string(value)},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, patchTimeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodPatch, w.config.Host+path, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	if w.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.config.Token)
+	}
+	resp, err := w.config.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected status %s", resp.Status)
+}
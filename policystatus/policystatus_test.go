@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystatus
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/gatewayapi"
+	"github.com/projectcalico/app-policy/statscache"
+)
+
+// fakeAPIServer records the annotations patched onto policies, by path, and returns 404 for paths in missing.
+type fakeAPIServer struct {
+	mu      sync.Mutex
+	patches map[string]map[string]string
+	missing map[string]bool
+	fail    bool
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	Expect(req.Method).To(Equal(http.MethodPatch))
+	Expect(req.Header.Get("Content-Type")).To(Equal("application/merge-patch+json"))
+	Expect(req.Header.Get("Authorization")).To(Equal("Bearer t0ken"))
+	var body struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	Expect(json.NewDecoder(req.Body).Decode(&body)).To(Succeed())
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if f.missing[req.URL.Path] {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	f.patches[req.URL.Path] = body.Metadata.Annotations
+}
+
+func (f *fakeAPIServer) status(path, reporter string) *Status {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v, ok := f.patches[path][annotationName(reporter)]
+	if !ok {
+		return nil
+	}
+	var s Status
+	Expect(json.Unmarshal([]byte(v), &s)).To(Succeed())
+	return &s
+}
+
+func hits(hs map[statscache.PolicyHit]int64) statscache.DPStats {
+	return statscache.DPStats{Values: statscache.Values{PolicyHits: hs}}
+}
+
+func TestWriterPatchesPolicies(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.Background()
+
+	api := &fakeAPIServer{
+		patches: map[string]map[string]string{},
+		missing: map[string]bool{"/apis/crd.projectcalico.org/v1/globalnetworkpolicies/default.deleted": true},
+	}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	w := NewWriter(&gatewayapi.KubeConfig{Host: srv.URL, Token: "t0ken", Client: http.DefaultClient}, "frontend-1")
+	now := time.Unix(1000, 0).UTC()
+	w.now = func() time.Time { return now }
+
+	reads := statscache.PolicyHit{Tier: "default", Name: "shop/default.reads", Enforced: true}
+	readsDryRun := statscache.PolicyHit{Tier: "default", Name: "shop/default.reads"}
+	global := statscache.PolicyHit{Tier: "security", Name: "security.block-scanners", Enforced: true}
+	knp := statscache.PolicyHit{Tier: "default", Name: "shop/knp.default.allow-all", Enforced: true}
+	deleted := statscache.PolicyHit{Tier: "default", Name: "default.deleted", Enforced: true}
+	w.Add(hits(map[statscache.PolicyHit]int64{reads: 2, readsDryRun: 1, global: 1, knp: 1, deleted: 1}))
+	w.Add(hits(map[statscache.PolicyHit]int64{reads: 1}))
+	w.flush(ctx)
+
+	readsPath := "/apis/crd.projectcalico.org/v1/namespaces/shop/networkpolicies/default.reads"
+	globalPath := "/apis/crd.projectcalico.org/v1/globalnetworkpolicies/security.block-scanners"
+	Expect(api.status(readsPath, "frontend-1")).To(Equal(&Status{EnforcedHits: 3, DryRunHits: 1, LastHit: now}))
+	Expect(api.status(globalPath, "frontend-1")).To(Equal(&Status{EnforcedHits: 1, LastHit: now}))
+	Expect(api.patches).To(HaveLen(2))
+	// Policies that can't be annotated are no longer tracked.
+	Expect(w.statuses).To(HaveLen(2))
+
+	// Only the policies hit since the last flush are written, with their totals.
+	later := now.Add(time.Minute)
+	w.now = func() time.Time { return later }
+	w.Add(hits(map[statscache.PolicyHit]int64{reads: 1}))
+	delete(api.patches, globalPath)
+	w.flush(ctx)
+	Expect(api.status(readsPath, "frontend-1")).To(Equal(&Status{EnforcedHits: 4, DryRunHits: 1, LastHit: later}))
+	Expect(api.status(globalPath, "frontend-1")).To(BeNil())
+}
+
+func TestWriterRetriesFailures(t *testing.T) {
+	RegisterTestingT(t)
+	ctx := context.Background()
+
+	api := &fakeAPIServer{patches: map[string]map[string]string{}, fail: true}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	w := NewWriter(&gatewayapi.KubeConfig{Host: srv.URL, Token: "t0ken", Client: http.DefaultClient}, "frontend-1")
+
+	w.Add(hits(map[statscache.PolicyHit]int64{{Tier: "default", Name: "default.allow", Enforced: true}: 1}))
+	w.flush(ctx)
+	Expect(api.patches).To(BeEmpty())
+
+	api.mu.Lock()
+	api.fail = false
+	api.mu.Unlock()
+	w.flush(ctx)
+	Expect(api.status("/apis/crd.projectcalico.org/v1/globalnetworkpolicies/default.allow", "frontend-1")).
+		ToNot(BeNil())
+}
+
+func TestAnnotationName(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(annotationName("frontend-1")).To(Equal("status.alp.projectcalico.org/frontend-1"))
+
+	long := strings.Repeat("a", 100)
+	name := annotationName(long)
+	Expect(len(strings.TrimPrefix(name, AnnotationPrefix))).To(Equal(maxNameLength))
+	Expect(annotationName(long + "b")).ToNot(Equal(name))
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	"github.com/gogo/protobuf/jsonpb"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+// ExportVersion is the version of the format that Export writes. When the format changes, bump it and add the
+// upgrade from the previous version to exportUpgrades, so that ReadExport still reads older exports.
+const ExportVersion = 1
+
+// exportUpgrades upgrade exports to the current version. exportUpgrades[i] rewrites the top-level fields of an export
+// of version i+1 into those of version i+2, so there is one fewer than ExportVersion.
+var exportUpgrades []func(fields map[string]json.RawMessage) error
+
+// Export is a copy of the contents of a PolicyStore in a stable, versioned format, for support bundles and for
+// debugging a store with other versions of Dikastes. Unlike the admin API's dump, it holds everything needed to
+// rebuild the store. Its contents are the messages that the Policy Sync API syncs them with, written as JSON.
+type Export struct {
+	// Version is the version of the format the export was read from. Exports are always written as ExportVersion.
+	Version int
+	// Generation is the store's Generation.
+	Generation uint64
+	// Endpoint is the store's Endpoint, which is also in Endpoints if it was synced.
+	Endpoint        *proto.WorkloadEndpoint
+	Endpoints       []*proto.WorkloadEndpointUpdate
+	Policies        []*proto.ActivePolicyUpdate
+	Profiles        []*proto.ActiveProfileUpdate
+	IPSets          []*proto.IPSetUpdate
+	ServiceAccounts []*proto.ServiceAccountUpdate
+	Namespaces      []*proto.NamespaceUpdate
+	Services        []*proto.ServiceUpdate
+}
+
+// exportJSON is the JSON form of an Export.
+type exportJSON struct {
+	Version int `json:"version"`
+	// Generation is a string, since JSON numbers don't have the precision for it.
+	Generation      string            `json:"generation"`
+	Endpoint        json.RawMessage   `json:"endpoint,omitempty"`
+	Endpoints       []json.RawMessage `json:"endpoints"`
+	Policies        []json.RawMessage `json:"policies"`
+	Profiles        []json.RawMessage `json:"profiles"`
+	IPSets          []json.RawMessage `json:"ipSets"`
+	ServiceAccounts []json.RawMessage `json:"serviceAccounts"`
+	Namespaces      []json.RawMessage `json:"namespaces"`
+	Services        []json.RawMessage `json:"services"`
+}
+
+// exportList pairs a list of messages in an Export, as a pointer to its slice, with its JSON form.
+type exportList struct {
+	messages interface{}
+	raw      *[]json.RawMessage
+}
+
+func (e *Export) lists(j *exportJSON) []exportList {
+	return []exportList{
+		{&e.Endpoints, &j.Endpoints},
+		{&e.Policies, &j.Policies},
+		{&e.Profiles, &j.Profiles},
+		{&e.IPSets, &j.IPSets},
+		{&e.ServiceAccounts, &j.ServiceAccounts},
+		{&e.Namespaces, &j.Namespaces},
+		{&e.Services, &j.Services},
+	}
+}
+
+// Export copies the contents of the store, in a consistent order. The caller must hold the store's lock, as in Read.
+func (s *PolicyStore) Export() *Export {
+	e := &Export{Version: ExportVersion, Generation: s.Generation, Endpoint: s.Endpoint}
+	for id, ep := range s.EndpointByID {
+		id := id
+		e.Endpoints = append(e.Endpoints, &proto.WorkloadEndpointUpdate{Id: &id, Endpoint: ep})
+	}
+	for id, p := range s.PolicyByID {
+		id := id
+		e.Policies = append(e.Policies, &proto.ActivePolicyUpdate{Id: &id, Policy: p})
+	}
+	for id, p := range s.ProfileByID {
+		id := id
+		e.Profiles = append(e.Profiles, &proto.ActiveProfileUpdate{Id: &id, Profile: p})
+	}
+	for id, set := range s.IPSetByID {
+		e.IPSets = append(e.IPSets, &proto.IPSetUpdate{Id: id, Type: set.Type(), Members: set.Members()})
+	}
+	for _, sa := range s.ServiceAccountByID {
+		e.ServiceAccounts = append(e.ServiceAccounts, sa)
+	}
+	for _, ns := range s.NamespaceByID {
+		e.Namespaces = append(e.Namespaces, ns)
+	}
+	for _, svc := range s.ServiceByID {
+		e.Services = append(e.Services, svc)
+	}
+	// Each message's text starts with its ID, or for services their name and namespace, so it orders them.
+	for _, l := range e.lists(&exportJSON{}) {
+		v := reflect.ValueOf(l.messages).Elem()
+		text := func(i int) string { return v.Index(i).Interface().(gogoproto.Message).String() }
+		sort.Slice(v.Interface(), func(i, j int) bool { return text(i) < text(j) })
+	}
+	return e
+}
+
+// Store rebuilds the exported store. Its Generation is the exported one.
+func (e *Export) Store() *PolicyStore {
+	s := NewPolicyStore()
+	s.Generation = e.Generation
+	s.Endpoint = e.Endpoint
+	for _, u := range e.Endpoints {
+		s.EndpointByID[*u.Id] = u.Endpoint
+	}
+	for _, u := range e.Policies {
+		s.UpdatePolicy(*u.Id, u.Policy)
+	}
+	for _, u := range e.Profiles {
+		s.UpdateProfile(*u.Id, u.Profile)
+	}
+	for _, u := range e.IPSets {
+		set := NewIPSet(u.Type)
+		for _, m := range u.Members {
+			set.AddString(m)
+		}
+		s.IPSetByID[u.Id] = set
+	}
+	for _, u := range e.ServiceAccounts {
+		s.ServiceAccountByID[*u.Id] = u
+	}
+	for _, u := range e.Namespaces {
+		s.NamespaceByID[*u.Id] = u
+	}
+	for _, u := range e.Services {
+		s.UpdateService(u)
+	}
+	return s
+}
+
+// MarshalJSON writes the export as ExportVersion.
+func (e *Export) MarshalJSON() ([]byte, error) {
+	j := exportJSON{Version: ExportVersion, Generation: strconv.FormatUint(e.Generation, 10)}
+	m := &jsonpb.Marshaler{}
+	if e.Endpoint != nil {
+		s, err := m.MarshalToString(e.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		j.Endpoint = json.RawMessage(s)
+	}
+	for _, l := range e.lists(&j) {
+		v := reflect.ValueOf(l.messages).Elem()
+		*l.raw = make([]json.RawMessage, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s, err := m.MarshalToString(v.Index(i).Interface().(gogoproto.Message))
+			if err != nil {
+				return nil, err
+			}
+			(*l.raw)[i] = json.RawMessage(s)
+		}
+	}
+	return json.Marshal(j)
+}
+
+// ReadExport reads an export written by this or an earlier version of Dikastes, upgrading it to ExportVersion.
+// Fields that later versions of the Policy Sync API's messages add are ignored.
+func ReadExport(r io.Reader) (*Export, error) {
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&fields); err != nil {
+		return nil, err
+	}
+	var version int
+	if err := json.Unmarshal(fields["version"], &version); err != nil {
+		return nil, fmt.Errorf("bad export version: %v", err)
+	}
+	if version < 1 || version > len(exportUpgrades)+1 {
+		return nil, fmt.Errorf("unsupported export version %d, expected 1 to %d", version, len(exportUpgrades)+1)
+	}
+	for v := version; v <= len(exportUpgrades); v++ {
+		if err := exportUpgrades[v-1](fields); err != nil {
+			return nil, fmt.Errorf("unable to upgrade export from version %d: %v", v, err)
+		}
+	}
+	upgraded, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var j exportJSON
+	if err := json.Unmarshal(upgraded, &j); err != nil {
+		return nil, err
+	}
+
+	e := &Export{Version: version}
+	if e.Generation, err = strconv.ParseUint(j.Generation, 10, 64); err != nil {
+		return nil, fmt.Errorf("bad generation: %v", err)
+	}
+	u := &jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if len(j.Endpoint) > 0 {
+		e.Endpoint = &proto.WorkloadEndpoint{}
+		if err := u.Unmarshal(bytes.NewReader(j.Endpoint), e.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+	for _, l := range e.lists(&j) {
+		v := reflect.ValueOf(l.messages).Elem()
+		for _, raw := range *l.raw {
+			m := reflect.New(v.Type().Elem().Elem())
+			if err := u.Unmarshal(bytes.NewReader(raw), m.Interface().(gogoproto.Message)); err != nil {
+				return nil, err
+			}
+			// Most messages are keyed by their Id, which Store needs.
+			if id := m.Elem().FieldByName("Id"); id.Kind() == reflect.Ptr && id.IsNil() {
+				return nil, fmt.Errorf("%s without an ID: %s", m.Elem().Type().Name(), raw)
+			}
+			v.Set(reflect.Append(v, m))
+		}
+	}
+	return e, nil
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func exportStore() *PolicyStore {
+	store := NewPolicyStore()
+	web := proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "shop/web", EndpointId: "eth0"}
+	store.Endpoint = &proto.WorkloadEndpoint{
+		Name: "web", ProfileIds: []string{"kns.shop"}, Ipv4Nets: []string{"10.0.0.5/32"},
+	}
+	store.EndpointByID[web] = store.Endpoint
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "shop/default.b"}] = &proto.Policy{
+		Namespace: "shop",
+		InboundRules: []*proto.Rule{{
+			Action:      "allow",
+			Protocol:    &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: "TCP"}},
+			SrcIpSetIds: []string{"allowed"},
+			HttpMatch:   &proto.HTTPMatch{Methods: []string{"GET"}},
+		}},
+	}
+	store.PolicyByID[proto.PolicyID{Tier: "default", Name: "shop/default.a"}] = &proto.Policy{Namespace: "shop"}
+	store.ProfileByID[proto.ProfileID{Name: "kns.shop"}] = &proto.Profile{
+		InboundRules: []*proto.Rule{{Action: "deny"}},
+	}
+	nets := NewIPSet(proto.IPSetUpdate_NET)
+	nets.AddString("10.0.0.0/8")
+	nets.AddString("fd00::5")
+	store.IPSetByID["allowed"] = nets
+	ports := NewIPSet(proto.IPSetUpdate_IP_AND_PORT)
+	ports.AddString("10.0.0.5,tcp:8080")
+	store.IPSetByID["ports"] = ports
+	sa := proto.ServiceAccountID{Namespace: "shop", Name: "web"}
+	store.ServiceAccountByID[sa] = &proto.ServiceAccountUpdate{Id: &sa, Labels: map[string]string{"app": "web"}}
+	ns := proto.NamespaceID{Name: "shop"}
+	store.NamespaceByID[ns] = &proto.NamespaceUpdate{Id: &ns, Labels: map[string]string{"team": "a"}}
+	store.UpdateService(&proto.ServiceUpdate{Name: "web", Namespace: "shop", ClusterIp: "10.96.0.10"})
+	store.SetGeneration("policy a", "a")
+	return store
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := exportStore()
+	export := store.Export()
+	Expect(export.Version).To(Equal(ExportVersion))
+	Expect(export.Policies).To(HaveLen(2))
+	Expect(export.Policies[0].Id.Name).To(Equal("shop/default.a"))
+	Expect(export.IPSets[0].Members).To(Equal([]string{"10.0.0.0/8", "fd00::5/128"}))
+
+	b, err := json.Marshal(export)
+	Expect(err).ToNot(HaveOccurred())
+	read, err := ReadExport(bytes.NewReader(b))
+	Expect(err).ToNot(HaveOccurred())
+	again, err := json.Marshal(read)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(string(again)).To(Equal(string(b)))
+
+	rebuilt := read.Store()
+	Expect(rebuilt.Generation).To(Equal(store.Generation))
+	Expect(rebuilt.Endpoint.String()).To(Equal(store.Endpoint.String()))
+	Expect(rebuilt.EndpointByID).To(HaveLen(1))
+	Expect(rebuilt.PolicyByID).To(HaveLen(2))
+	Expect(rebuilt.ProfileByID).To(HaveLen(1))
+	Expect(rebuilt.IPSetByID["allowed"].Members()).To(Equal([]string{"10.0.0.0/8", "fd00::5/128"}))
+	Expect(rebuilt.IPSetByID["ports"].Type()).To(Equal(proto.IPSetUpdate_IP_AND_PORT))
+	Expect(rebuilt.ServiceAccountByID).To(HaveLen(1))
+	Expect(rebuilt.NamespaceByID).To(HaveLen(1))
+	Expect(rebuilt.serviceIDsByIP).To(HaveKey("10.96.0.10"))
+
+	// Exports of the same contents are the same.
+	again, err = json.Marshal(rebuilt.Export())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(string(again)).To(Equal(string(b)))
+}
+
+func TestReadExportVersions(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, s := range []string{
+		`{}`,
+		`{"version": 0, "generation": "1"}`,
+		`{"version": 2, "generation": "1"}`,
+		`{"version": "1", "generation": "1"}`,
+		`{"version": 1, "generation": "-1"}`,
+		`{"version": 1, "generation": "1", "policies": [{"policy": {}}]}`,
+	} {
+		_, err := ReadExport(strings.NewReader(s))
+		Expect(err).To(HaveOccurred(), s)
+	}
+
+	// Fields that later versions of Felix's messages add are ignored.
+	e, err := ReadExport(strings.NewReader(
+		`{"version": 1, "generation": "7", "namespaces": [{"id": {"name": "shop"}, "annotations": {"a": "b"}}]}`))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(e.Generation).To(Equal(uint64(7)))
+	Expect(e.Namespaces[0].Id.Name).To(Equal("shop"))
+
+	// Older versions are upgraded.
+	Expect(exportUpgrades).To(HaveLen(ExportVersion - 1))
+	defer func(upgrades []func(map[string]json.RawMessage) error) { exportUpgrades = upgrades }(exportUpgrades)
+	exportUpgrades = append(exportUpgrades, func(fields map[string]json.RawMessage) error {
+		fields["generation"] = fields["gen"]
+		return nil
+	})
+	e, err = ReadExport(strings.NewReader(`{"version": 1, "gen": "7"}`))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(e.Version).To(Equal(1))
+	Expect(e.Generation).To(Equal(uint64(7)))
+}
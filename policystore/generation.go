@@ -0,0 +1,57 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// SetGeneration records that the store holds content under key, or no longer holds anything under it if content is
+// empty, and updates the store's Generation to match.
+func (s *PolicyStore) SetGeneration(key, content string) {
+	h := s.itemHashes[key]
+	if content == "" {
+		delete(s.itemHashes, key)
+	} else {
+		s.itemHashes[key] = itemHash(key, content)
+	}
+	s.Generation ^= h ^ s.itemHashes[key]
+}
+
+// ToggleGeneration records that each of members has been added to, or removed from, the collection under key, such
+// as the members of an IP set, and updates the store's Generation to match.
+func (s *PolicyStore) ToggleGeneration(key string, members ...string) {
+	h := s.itemHashes[key]
+	for _, m := range members {
+		h ^= itemHash(key, m)
+	}
+	s.Generation ^= s.itemHashes[key] ^ h
+	if h == 0 {
+		delete(s.itemHashes, key)
+	} else {
+		s.itemHashes[key] = h
+	}
+}
+
+// itemHash hashes content under key. Hashes are combined with XOR, so that the Generation doesn't depend on the order
+// that items were synced in.
+func itemHash(key, content string) uint64 {
+	d := sha256.New()
+	d.Write([]byte(key))
+	d.Write([]byte{0})
+	d.Write([]byte(content))
+	return binary.BigEndian.Uint64(d.Sum(nil))
+}
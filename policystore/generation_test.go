@@ -0,0 +1,63 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSetGeneration(t *testing.T) {
+	RegisterTestingT(t)
+
+	a := NewPolicyStore()
+	a.SetGeneration("policy 1", "allow")
+	a.SetGeneration("policy 2", "deny")
+	b := NewPolicyStore()
+	b.SetGeneration("policy 2", "deny")
+	b.SetGeneration("policy 1", "deny")
+	Expect(b.Generation).ToNot(Equal(a.Generation))
+	b.SetGeneration("policy 1", "allow")
+	Expect(b.Generation).To(Equal(a.Generation))
+
+	// The same content under a different key is a different item.
+	b.SetGeneration("policy 1", "")
+	b.SetGeneration("policy 3", "allow")
+	Expect(b.Generation).ToNot(Equal(a.Generation))
+
+	a.SetGeneration("policy 1", "")
+	a.SetGeneration("policy 2", "")
+	Expect(a.Generation).To(BeZero())
+	Expect(a.itemHashes).To(BeEmpty())
+}
+
+func TestToggleGeneration(t *testing.T) {
+	RegisterTestingT(t)
+
+	a := NewPolicyStore()
+	a.ToggleGeneration("ipset s", "10.0.0.1", "10.0.0.2")
+	b := NewPolicyStore()
+	b.ToggleGeneration("ipset s", "10.0.0.2")
+	b.ToggleGeneration("ipset s", "10.0.0.3", "10.0.0.1")
+	Expect(b.Generation).ToNot(Equal(a.Generation))
+	b.ToggleGeneration("ipset s", "10.0.0.3")
+	Expect(b.Generation).To(Equal(a.Generation))
+
+	// Clearing a set removes all of its members.
+	b.SetGeneration("ipset s", "")
+	Expect(b.Generation).To(BeZero())
+	Expect(b.itemHashes).To(BeEmpty())
+}
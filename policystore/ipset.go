@@ -17,9 +17,10 @@ package policystore
 import (
 	"fmt"
 	"net"
-	"strconv"
+	"sort"
 	"strings"
 
+	"github.com/projectcalico/app-policy/ipaddr"
 	syncapi "github.com/projectcalico/app-policy/proto"
 
 	envoyapi "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
@@ -45,9 +46,18 @@ type IPSet interface {
 
 	// Test if the address is contained in the set.
 	ContainsAddress(addr *envoyapi.Address) bool
+
+	// Type returns the type of the set.
+	Type() syncapi.IPSetUpdate_IPSetType
+
+	// Members returns the members of the set, sorted, in canonical form. NET members are all CIDRs, including those
+	// added as individual IPs.
+	Members() []string
 }
 
-// We'll use golang's map type under the covers here because it is simple to implement.
+// We'll use golang's map type under the covers here because it is simple to implement. Addresses are keyed in their
+// canonical form, so that IPv6 addresses, and IPv4 addresses written as IPv4-mapped IPv6, match however they are
+// written.
 type ipMapSet map[string]bool
 type ipPortMapSet map[string]bool
 
@@ -65,16 +75,16 @@ func NewIPSet(t syncapi.IPSetUpdate_IPSetType) IPSet {
 }
 
 func (m ipMapSet) AddString(ip string) {
-	m[ip] = true
+	m[ipaddr.Canonical(ip)] = true
 }
 
 func (m ipMapSet) RemoveString(ip string) {
-	delete(m, ip)
+	delete(m, ipaddr.Canonical(ip))
 }
 
 func (m ipMapSet) ContainsAddress(addr *envoyapi.Address) bool {
 	sck := addr.GetSocketAddress()
-	key := sck.GetAddress()
+	key := ipaddr.Canonical(sck.GetAddress())
 	log.WithFields(log.Fields{
 		"proto": addr.String(),
 		"key":   key,
@@ -82,18 +92,26 @@ func (m ipMapSet) ContainsAddress(addr *envoyapi.Address) bool {
 	return m[key]
 }
 
+func (m ipMapSet) Type() syncapi.IPSetUpdate_IPSetType {
+	return syncapi.IPSetUpdate_IP
+}
+
+func (m ipMapSet) Members() []string {
+	return sortedKeys(m)
+}
+
 func (m ipPortMapSet) AddString(ip string) {
-	m[ip] = true
+	m[canonicalIPPort(ip)] = true
 }
 
 func (m ipPortMapSet) RemoveString(ip string) {
-	delete(m, ip)
+	delete(m, canonicalIPPort(ip))
 }
 
 func (m ipPortMapSet) ContainsAddress(addr *envoyapi.Address) bool {
 	sck := addr.GetSocketAddress()
 	p := strings.ToLower(sck.GetProtocol().String())
-	key := fmt.Sprintf("%v,%v:%d", sck.GetAddress(), p, sck.GetPortValue())
+	key := fmt.Sprintf("%v,%v:%d", ipaddr.Canonical(sck.GetAddress()), p, sck.GetPortValue())
 	log.WithFields(log.Fields{
 		"proto": addr.String(),
 		"key":   key,
@@ -101,6 +119,32 @@ func (m ipPortMapSet) ContainsAddress(addr *envoyapi.Address) bool {
 	return m[key]
 }
 
+func (m ipPortMapSet) Type() syncapi.IPSetUpdate_IPSetType {
+	return syncapi.IPSetUpdate_IP_AND_PORT
+}
+
+func (m ipPortMapSet) Members() []string {
+	return sortedKeys(m)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// canonicalIPPort rewrites the address of an IP_AND_PORT member, "<IP>,(tcp|udp):<port-number>", in canonical form.
+func canonicalIPPort(member string) string {
+	i := strings.LastIndex(member, ",")
+	if i < 0 {
+		return member
+	}
+	return ipaddr.Canonical(member[:i]) + member[i:]
+}
+
 // ipNetSet implements an IPSet of type NET, where the members are CIDRs.  These sets are a combination of endpoint IPs
 // and CIDRs from network sets. We expect at scale for there to be a large number of endpoint IPs and relatively few
 // network set entries.
@@ -130,44 +174,48 @@ type networkBitmap [BitmapSize]uint64
 
 func (m ipNetSet) AddString(network string) {
 	ip, mask := parseCIDR(network)
-	ip4 := ip.To4()
-	if ip4 != nil && mask <= 32 {
-		m.v4.insert(ip4, 0, mask, 24)
-	} else if mask <= 128 {
-		m.v6.insert(ip, 0, mask, 120)
+	if len(ip) == net.IPv4len {
+		m.v4.insert(ip, 0, mask, 24)
 	} else {
-		log.WithField("network", network).Panic("invalid CIDR mask length")
+		m.v6.insert(ip, 0, mask, 120)
 	}
 }
 
 func (m ipNetSet) RemoveString(network string) {
 	ip, mask := parseCIDR(network)
-	ip4 := ip.To4()
-	if ip4 != nil && mask <= 32 {
-		m.v4.remove(ip4, 0, mask)
-	} else if mask <= 128 {
-		m.v6.remove(ip, 0, mask)
+	if len(ip) == net.IPv4len {
+		m.v4.remove(ip, 0, mask)
 	} else {
-		log.WithField("network", network).Panic("invalid CIDR mask length")
+		m.v6.remove(ip, 0, mask)
 	}
 }
 
 func (m ipNetSet) ContainsAddress(addr *envoyapi.Address) bool {
-	ip := net.ParseIP(addr.GetSocketAddress().GetAddress())
+	ip := ipaddr.Parse(addr.GetSocketAddress().GetAddress())
 	if ip == nil {
 		// Envoy should not send us malformed IP addresses, but its possible we could get requests from non-IP
 		// connections, like Pipes.
-		log.WithField("addr", ip).Warn("could not parse IP")
+		log.WithField("addr", addr.GetSocketAddress().GetAddress()).Warn("could not parse IP")
 		return false
 	}
-	ip4 := ip.To4()
-	if ip4 != nil {
-		return m.v4.containsIP(ip4, 0)
+	if len(ip) == net.IPv4len {
+		return m.v4.containsIP(ip, 0)
 	} else {
 		return m.v6.containsIP(ip, 0)
 	}
 }
 
+func (m ipNetSet) Type() syncapi.IPSetUpdate_IPSetType {
+	return syncapi.IPSetUpdate_NET
+}
+
+func (m ipNetSet) Members() []string {
+	members := m.v4.members(make(net.IP, net.IPv4len), 0, nil)
+	members = m.v6.members(make(net.IP, net.IPv6len), 0, members)
+	sort.Strings(members)
+	return members
+}
+
 func (n *trieNode) insert(ip net.IP, depth, mask, bitmapDepth uint64) {
 	if depth == mask {
 		// found!
@@ -215,6 +263,35 @@ func (n *trieNode) remove(ip net.IP, depth, mask uint64) {
 	}
 }
 
+// members appends the networks in the trie below n to out. ip holds the depth bits of the path to n, and is zero
+// after them.
+func (n *trieNode) members(ip net.IP, depth uint64, out []string) []string {
+	bits := len(ip) * 8
+	if n.member {
+		out = append(out, (&net.IPNet{IP: ip, Mask: net.CIDRMask(int(depth), bits)}).String())
+	}
+	if n.bitmap != nil {
+		for i := 0; i < 256; i++ {
+			if n.bitmap.contains(byte(i)) {
+				addr := append(net.IP{}, ip...)
+				addr[len(addr)-1] = byte(i)
+				out = append(out, (&net.IPNet{IP: addr, Mask: net.CIDRMask(bits, bits)}).String())
+			}
+		}
+	}
+	for b, child := range n.children {
+		if child == nil {
+			continue
+		}
+		next := append(net.IP{}, ip...)
+		if b == 1 {
+			next[depth/8] |= 1 << (7 - depth%8)
+		}
+		out = child.members(next, depth+1, out)
+	}
+	return out
+}
+
 // okToRemove checks if the trieNode can be removed from the trie
 func (n *trieNode) okToRemove() bool {
 	if n.member {
@@ -281,16 +358,13 @@ func getBitAt(ip net.IP, depth uint64) byte {
 	return (ip[by] & (1 << bi)) >> bi
 }
 
+// parseCIDR returns the network address and prefix length of a CIDR, or of a single address as a full-length prefix.
+// IPv4 networks, including those written as IPv4-mapped IPv6, have 4-byte addresses.
 func parseCIDR(network string) (net.IP, uint64) {
-	r := strings.Split(network, "/")
-	addr := r[0]
-	mask, err := strconv.ParseUint(r[1], 10, 64)
+	n, err := ipaddr.ParseCIDR(network)
 	if err != nil {
-		log.WithField("network", network).Panic("bad CIDR")
-	}
-	ip := net.ParseIP(addr)
-	if ip == nil {
-		log.WithField("network", network).Panic("bad CIDR IP")
+		log.WithError(err).WithField("network", network).Panic("bad CIDR")
 	}
-	return ip, mask
+	ones, _ := n.Mask.Size()
+	return n.IP, uint64(ones)
 }
@@ -286,3 +286,80 @@ func TestIPNetV6(t *testing.T) {
 	Expect(uut.ContainsAddress(&addrfe80_23af_22)).To(BeFalse())
 	Expect(uut.ContainsAddress(&addrfe81_23af_77bd_fe80)).To(BeFalse())
 }
+
+func TestIPSetsMatchAddressesHoweverWritten(t *testing.T) {
+	RegisterTestingT(t)
+
+	ips := NewIPSet(proto.IPSetUpdate_IP)
+	ips.AddString("2001:db8::1")
+	ips.AddString("10.0.0.1")
+	for _, a := range []string{"2001:DB8:0::1", "2001:db8:0:0:0:0:0:1", "::ffff:10.0.0.1"} {
+		addr := makeIpAddr(a)
+		Expect(ips.ContainsAddress(&addr)).To(BeTrue(), a)
+	}
+	ips.RemoveString("2001:db8:0::1")
+	addr := makeIpAddr("2001:db8::1")
+	Expect(ips.ContainsAddress(&addr)).To(BeFalse())
+
+	ports := NewIPSet(proto.IPSetUpdate_IP_AND_PORT)
+	ports.AddString("2001:db8::1,tcp:8080")
+	ports.AddString("10.0.0.1,tcp:8080")
+	for _, a := range []string{"2001:db8:0::1", "::ffff:10.0.0.1"} {
+		addr := makeAddr(a, envoyapi.SocketAddress_TCP, 8080)
+		Expect(ports.ContainsAddress(&addr)).To(BeTrue(), a)
+	}
+	addr = makeAddr("2001:db8::1", envoyapi.SocketAddress_TCP, 8081)
+	Expect(ports.ContainsAddress(&addr)).To(BeFalse())
+
+	nets := NewIPSet(proto.IPSetUpdate_NET)
+	nets.AddString("10.0.0.0/8")
+	nets.AddString("::ffff:192.168.0.0/112")
+	nets.AddString("2001:db8::5")
+	for _, a := range []string{"::ffff:10.1.2.3", "192.168.4.5", "::ffff:192.168.4.5", "2001:db8:0::5"} {
+		addr := makeIpAddr(a)
+		Expect(nets.ContainsAddress(&addr)).To(BeTrue(), a)
+	}
+	for _, a := range []string{"11.0.0.1", "2001:db8::6", "pipe"} {
+		addr := makeIpAddr(a)
+		Expect(nets.ContainsAddress(&addr)).To(BeFalse(), a)
+	}
+	nets.RemoveString("::ffff:10.0.0.0/104")
+	addr = makeIpAddr("10.1.2.3")
+	Expect(nets.ContainsAddress(&addr)).To(BeFalse())
+}
+
+// Members lists each type of set in canonical form, so that a set rebuilt from them is the same.
+func TestIPSetMembers(t *testing.T) {
+	RegisterTestingT(t)
+
+	ips := NewIPSet(proto.IPSetUpdate_IP)
+	ips.AddString("2001:db8:0::1")
+	ips.AddString("::ffff:10.0.0.1")
+	Expect(ips.Type()).To(Equal(proto.IPSetUpdate_IP))
+	Expect(ips.Members()).To(Equal([]string{"10.0.0.1", "2001:db8::1"}))
+
+	ports := NewIPSet(proto.IPSetUpdate_IP_AND_PORT)
+	ports.AddString("2001:db8:0::1,tcp:8080")
+	Expect(ports.Type()).To(Equal(proto.IPSetUpdate_IP_AND_PORT))
+	Expect(ports.Members()).To(Equal([]string{"2001:db8::1,tcp:8080"}))
+
+	nets := NewIPSet(proto.IPSetUpdate_NET)
+	Expect(nets.Members()).To(BeEmpty())
+	for _, n := range []string{
+		"10.0.0.0/8", "10.1.2.3", "10.1.2.128/25", "10.1.2.4/32", "0.0.0.0/0", "2001:db8::/32", "2001:db8::5",
+	} {
+		nets.AddString(n)
+	}
+	nets.RemoveString("10.1.2.4")
+	Expect(nets.Type()).To(Equal(proto.IPSetUpdate_NET))
+	members := nets.Members()
+	Expect(members).To(Equal([]string{
+		"0.0.0.0/0", "10.0.0.0/8", "10.1.2.128/25", "10.1.2.3/32", "2001:db8::/32", "2001:db8::5/128",
+	}))
+
+	rebuilt := NewIPSet(proto.IPSetUpdate_NET)
+	for _, m := range members {
+		rebuilt.AddString(m)
+	}
+	Expect(rebuilt.Members()).To(Equal(members))
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"regexp"
+
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// compiledPathRegexp is an HTTP path regex compiled when the first policy or profile using it was synced, and the
+// number of rules using it.
+type compiledPathRegexp struct {
+	re   *regexp.Regexp
+	err  error
+	refs int
+}
+
+// CompilePathRegexp compiles a rule's HTTP path regex, which must match the whole path, as Envoy's safe_regex path
+// matches do.
+func CompilePathRegexp(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + expr + ")$")
+}
+
+// PathRegexp returns the compiled form of an HTTP path regex. Those of policies and profiles added with UpdatePolicy
+// and UpdateProfile were compiled when they were added, along with any error, and others are compiled now.
+func (s *PolicyStore) PathRegexp(expr string) (*regexp.Regexp, error) {
+	if c, ok := s.pathRegexps[expr]; ok {
+		return c.re, c.err
+	}
+	return CompilePathRegexp(expr)
+}
+
+func (s *PolicyStore) retainPathRegexps(rules ...[]*proto.Rule) {
+	forEachPathRegexp(rules, func(expr string) {
+		c, ok := s.pathRegexps[expr]
+		if !ok {
+			c = &compiledPathRegexp{}
+			c.re, c.err = CompilePathRegexp(expr)
+			s.pathRegexps[expr] = c
+		}
+		c.refs++
+	})
+}
+
+func (s *PolicyStore) releasePathRegexps(rules ...[]*proto.Rule) {
+	forEachPathRegexp(rules, func(expr string) {
+		c, ok := s.pathRegexps[expr]
+		if !ok {
+			return
+		}
+		if c.refs--; c.refs <= 0 {
+			delete(s.pathRegexps, expr)
+		}
+	})
+}
+
+// forEachPathRegexp calls f with each of the regexes that rules match HTTP paths with.
+func forEachPathRegexp(rules [][]*proto.Rule, f func(expr string)) {
+	for _, rs := range rules {
+		for _, r := range rs {
+			for _, p := range r.GetHttpMatch().GetPaths() {
+				if m, ok := p.GetPathMatch().(*proto.HTTPMatch_PathMatch_Regex); ok {
+					f(m.Regex)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestPathRegexps(t *testing.T) {
+	RegisterTestingT(t)
+
+	paths := func(exprs ...string) *proto.HTTPMatch {
+		m := &proto.HTTPMatch{}
+		for _, e := range exprs {
+			m.Paths = append(m.Paths, &proto.HTTPMatch_PathMatch{PathMatch: &proto.HTTPMatch_PathMatch_Regex{Regex: e}})
+		}
+		return m
+	}
+	store := NewPolicyStore()
+	carts := proto.PolicyID{Tier: "default", Name: "carts"}
+	store.UpdatePolicy(carts, &proto.Policy{
+		InboundRules: []*proto.Rule{{Action: "allow", HttpMatch: paths("/carts/[0-9]+", "/carts/(")}},
+	})
+	store.UpdateProfile(proto.ProfileID{Name: "ns.shop"}, &proto.Profile{
+		OutboundRules: []*proto.Rule{{Action: "allow", HttpMatch: paths("/carts/[0-9]+")}},
+	})
+	Expect(store.pathRegexps["/carts/[0-9]+"].refs).To(Equal(2))
+
+	// Synced regexes are compiled once, and others on demand.
+	re, err := store.PathRegexp("/carts/[0-9]+")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(re).To(BeIdenticalTo(store.pathRegexps["/carts/[0-9]+"].re))
+	Expect(re.MatchString("/carts/12")).To(BeTrue())
+	Expect(re.MatchString("/carts/12/items")).To(BeFalse())
+	re, err = store.PathRegexp("/orders/.*")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(re.MatchString("/orders/12")).To(BeTrue())
+	Expect(store.pathRegexps).ToNot(HaveKey("/orders/.*"))
+
+	// Invalid regexes keep their error.
+	Expect(store.pathRegexps).To(HaveKey("/carts/("))
+	_, err = store.PathRegexp("/carts/(")
+	Expect(err).To(HaveOccurred())
+
+	// Regexes are dropped once no rule uses them.
+	store.RemovePolicy(carts)
+	Expect(store.pathRegexps).ToNot(HaveKey("/carts/("))
+	Expect(store.pathRegexps["/carts/[0-9]+"].refs).To(Equal(1))
+	store.RemoveProfile(proto.ProfileID{Name: "ns.shop"})
+	Expect(store.pathRegexps).To(BeEmpty())
+}
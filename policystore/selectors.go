@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/libcalico-go/lib/selector"
+)
+
+// parsedSelector is a selector parsed when the first policy or profile using it was synced, and the number of rules
+// using it.
+type parsedSelector struct {
+	sel  selector.Selector
+	err  error
+	refs int
+}
+
+// UpdatePolicy adds or replaces a policy, parsing the label selectors and compiling the path regexes of its rules.
+func (s *PolicyStore) UpdatePolicy(id proto.PolicyID, p *proto.Policy) {
+	s.RemovePolicy(id)
+	s.PolicyByID[id] = p
+	s.retainSelectors(p.GetInboundRules(), p.GetOutboundRules())
+	s.retainPathRegexps(p.GetInboundRules(), p.GetOutboundRules())
+}
+
+// RemovePolicy removes a policy, and the parsed selectors and compiled path regexes no other rule uses.
+func (s *PolicyStore) RemovePolicy(id proto.PolicyID) {
+	old, ok := s.PolicyByID[id]
+	if !ok {
+		return
+	}
+	delete(s.PolicyByID, id)
+	s.releaseSelectors(old.GetInboundRules(), old.GetOutboundRules())
+	s.releasePathRegexps(old.GetInboundRules(), old.GetOutboundRules())
+}
+
+// UpdateProfile adds or replaces a profile, parsing the label selectors and compiling the path regexes of its rules.
+func (s *PolicyStore) UpdateProfile(id proto.ProfileID, p *proto.Profile) {
+	s.RemoveProfile(id)
+	s.ProfileByID[id] = p
+	s.retainSelectors(p.GetInboundRules(), p.GetOutboundRules())
+	s.retainPathRegexps(p.GetInboundRules(), p.GetOutboundRules())
+}
+
+// RemoveProfile removes a profile, and the parsed selectors and compiled path regexes no other rule uses.
+func (s *PolicyStore) RemoveProfile(id proto.ProfileID) {
+	old, ok := s.ProfileByID[id]
+	if !ok {
+		return
+	}
+	delete(s.ProfileByID, id)
+	s.releaseSelectors(old.GetInboundRules(), old.GetOutboundRules())
+	s.releasePathRegexps(old.GetInboundRules(), old.GetOutboundRules())
+}
+
+// Selector returns the parsed form of a label selector. Those of policies and profiles added with UpdatePolicy and
+// UpdateProfile were parsed when they were added, and others are parsed now.
+func (s *PolicyStore) Selector(sel string) (selector.Selector, error) {
+	if p, ok := s.selectors[sel]; ok {
+		return p.sel, p.err
+	}
+	return selector.Parse(sel)
+}
+
+func (s *PolicyStore) retainSelectors(rules ...[]*proto.Rule) {
+	forEachSelector(rules, func(sel string) {
+		p, ok := s.selectors[sel]
+		if !ok {
+			p = &parsedSelector{}
+			p.sel, p.err = selector.Parse(sel)
+			s.selectors[sel] = p
+		}
+		p.refs++
+	})
+}
+
+func (s *PolicyStore) releaseSelectors(rules ...[]*proto.Rule) {
+	forEachSelector(rules, func(sel string) {
+		p, ok := s.selectors[sel]
+		if !ok {
+			return
+		}
+		if p.refs--; p.refs <= 0 {
+			delete(s.selectors, sel)
+		}
+	})
+}
+
+// forEachSelector calls f with each of the label selectors that rules match service accounts and namespaces with.
+func forEachSelector(rules [][]*proto.Rule, f func(sel string)) {
+	for _, rs := range rules {
+		for _, r := range rs {
+			for _, sel := range []string{
+				r.GetOriginalSrcNamespaceSelector(),
+				r.GetOriginalDstNamespaceSelector(),
+				r.GetSrcServiceAccountMatch().GetSelector(),
+				r.GetDstServiceAccountMatch().GetSelector(),
+			} {
+				// Rules without a selector match everything with an empty one, which is parsed like any other.
+				f(sel)
+			}
+		}
+	}
+}
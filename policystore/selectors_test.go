@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestSelectors(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := NewPolicyStore()
+	web := proto.PolicyID{Tier: "default", Name: "web"}
+	store.UpdatePolicy(web, &proto.Policy{
+		InboundRules: []*proto.Rule{{
+			Action:                       "allow",
+			OriginalSrcNamespaceSelector: "team == 'shop'",
+			SrcServiceAccountMatch:       &proto.ServiceAccountMatch{Selector: "app == 'web'"},
+		}},
+	})
+	store.UpdateProfile(proto.ProfileID{Name: "ns.shop"}, &proto.Profile{
+		OutboundRules: []*proto.Rule{{
+			Action:                 "allow",
+			DstServiceAccountMatch: &proto.ServiceAccountMatch{Selector: "app == 'web'"},
+		}},
+	})
+	Expect(store.selectors).To(HaveKey("team == 'shop'"))
+	Expect(store.selectors["app == 'web'"].refs).To(Equal(2))
+
+	// Synced selectors are parsed once, and others on demand.
+	sel, err := store.Selector("app == 'web'")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(sel).To(BeIdenticalTo(store.selectors["app == 'web'"].sel))
+	Expect(sel.Evaluate(map[string]string{"app": "web"})).To(BeTrue())
+	sel, err = store.Selector("app == 'cart'")
+	Expect(err).ToNot(HaveOccurred())
+	Expect(sel.Evaluate(map[string]string{"app": "cart"})).To(BeTrue())
+	Expect(store.selectors).ToNot(HaveKey("app == 'cart'"))
+
+	// Invalid selectors keep their error.
+	store.UpdatePolicy(proto.PolicyID{Tier: "default", Name: "bad"}, &proto.Policy{
+		InboundRules: []*proto.Rule{{SrcServiceAccountMatch: &proto.ServiceAccountMatch{Selector: "not.a.selector"}}},
+	})
+	_, err = store.Selector("not.a.selector")
+	Expect(err).To(HaveOccurred())
+
+	// Selectors are dropped once no rule uses them.
+	store.UpdatePolicy(web, &proto.Policy{InboundRules: []*proto.Rule{{Action: "allow"}}})
+	Expect(store.selectors).ToNot(HaveKey("team == 'shop'"))
+	Expect(store.selectors["app == 'web'"].refs).To(Equal(1))
+	store.RemoveProfile(proto.ProfileID{Name: "ns.shop"})
+	Expect(store.selectors).ToNot(HaveKey("app == 'web'"))
+	store.RemovePolicy(web)
+	store.RemovePolicy(proto.PolicyID{Tier: "default", Name: "bad"})
+	Expect(store.selectors).To(BeEmpty())
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"net"
+	"strings"
+
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// ServiceID identifies a Kubernetes service.
+type ServiceID struct {
+	Namespace string
+	Name      string
+}
+
+// String returns the service's "namespace/name".
+func (id ServiceID) String() string {
+	return id.Namespace + "/" + id.Name
+}
+
+// UpdateService adds or replaces a service, indexing it by its cluster, load balancer and external IPs.
+func (s *PolicyStore) UpdateService(update *proto.ServiceUpdate) {
+	id := ServiceID{Namespace: update.GetNamespace(), Name: update.GetName()}
+	s.RemoveService(id)
+	s.ServiceByID[id] = update
+	for _, a := range serviceAddresses(update) {
+		s.serviceIDsByIP[a] = append(s.serviceIDsByIP[a], id)
+	}
+}
+
+// RemoveService removes a service and its addresses from the index.
+func (s *PolicyStore) RemoveService(id ServiceID) {
+	old, ok := s.ServiceByID[id]
+	if !ok {
+		return
+	}
+	delete(s.ServiceByID, id)
+	for _, a := range serviceAddresses(old) {
+		ids := s.serviceIDsByIP[a]
+		for i, other := range ids {
+			if other == id {
+				ids = append(ids[:i], ids[i+1:]...)
+				break
+			}
+		}
+		if len(ids) == 0 {
+			delete(s.serviceIDsByIP, a)
+		} else {
+			s.serviceIDsByIP[a] = ids
+		}
+	}
+}
+
+// ServiceWithAddress returns the service that serves the given address, port and protocol, such as "TCP". Services
+// that don't list their ports serve any port. found is false if no service, or more than one, serves the address.
+func (s *PolicyStore) ServiceWithAddress(ip net.IP, port int32, protocol string) (id ServiceID, found bool) {
+	if ip == nil {
+		return ServiceID{}, false
+	}
+	var matches int
+	for _, candidate := range s.serviceIDsByIP[ip.String()] {
+		if servesPort(s.ServiceByID[candidate], port, protocol) {
+			id = candidate
+			matches++
+		}
+	}
+	if matches != 1 {
+		return ServiceID{}, false
+	}
+	return id, true
+}
+
+// serviceAddresses returns the canonical forms of the addresses a service can be reached at, without duplicates.
+func serviceAddresses(update *proto.ServiceUpdate) []string {
+	var addrs []string
+	seen := make(map[string]bool)
+	all := append([]string{update.GetClusterIp(), update.GetLoadbalancerIp()}, update.GetClusterIps()...)
+	for _, a := range append(all, update.GetExternalIps()...) {
+		ip := ipaddr.Parse(a)
+		// Headless services have the cluster IP "None".
+		if ip == nil || seen[ip.String()] {
+			continue
+		}
+		seen[ip.String()] = true
+		addrs = append(addrs, ip.String())
+	}
+	return addrs
+}
+
+// servesPort returns true if the service listens on the port, with the protocol if it is given.
+func servesPort(update *proto.ServiceUpdate, port int32, protocol string) bool {
+	if len(update.GetPorts()) == 0 {
+		return true
+	}
+	for _, p := range update.GetPorts() {
+		if p.GetPort() == port && (protocol == "" || strings.EqualFold(p.GetProtocol(), protocol)) {
+			return true
+		}
+	}
+	return false
+}
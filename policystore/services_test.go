@@ -0,0 +1,69 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/projectcalico/app-policy/proto"
+)
+
+func TestServiceWithAddress(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := NewPolicyStore()
+	store.UpdateService(&proto.ServiceUpdate{
+		Name: "web", Namespace: "shop", Type: "LoadBalancer",
+		ClusterIp: "10.96.0.10", ClusterIps: []string{"10.96.0.10", "fd00::a"},
+		LoadbalancerIp: "203.0.113.10",
+		Ports:          []*proto.ServicePort{{Protocol: "TCP", Port: 443}},
+	})
+	store.UpdateService(&proto.ServiceUpdate{Name: "dns", Namespace: "kube-system", ClusterIp: "10.96.0.53"})
+	store.UpdateService(&proto.ServiceUpdate{Name: "headless", Namespace: "shop", ClusterIp: "None"})
+	web := ServiceID{Namespace: "shop", Name: "web"}
+	// lookup returns the service with the address, or the zero ServiceID if there isn't one.
+	lookup := func(ip string, port int32, protocol string) ServiceID {
+		id, found := store.ServiceWithAddress(net.ParseIP(ip), port, protocol)
+		Expect(found).To(Equal(id != ServiceID{}))
+		return id
+	}
+
+	Expect(lookup("10.96.0.10", 443, "TCP")).To(Equal(web))
+	Expect(lookup("::ffff:10.96.0.10", 443, "")).To(Equal(web))
+	Expect(lookup("fd00:0::a", 443, "tcp")).To(Equal(web))
+	Expect(lookup("203.0.113.10", 443, "TCP")).To(Equal(web))
+	Expect(lookup("10.96.0.10", 80, "TCP")).To(BeZero())
+	Expect(lookup("10.96.0.10", 443, "UDP")).To(BeZero())
+	// Services that don't list ports serve every port.
+	Expect(lookup("10.96.0.53", 53, "UDP")).To(Equal(ServiceID{Namespace: "kube-system", Name: "dns"}))
+	Expect(store.serviceIDsByIP).ToNot(HaveKey("None"))
+
+	// Services that share an address on the same port can't be told apart.
+	store.UpdateService(&proto.ServiceUpdate{Name: "web2", Namespace: "shop", LoadbalancerIp: "203.0.113.10"})
+	Expect(lookup("203.0.113.10", 443, "TCP")).To(BeZero())
+
+	// Updates replace services' addresses, and removals drop them.
+	store.UpdateService(&proto.ServiceUpdate{Name: "web", Namespace: "shop", ClusterIp: "10.96.0.11"})
+	Expect(lookup("10.96.0.10", 443, "TCP")).To(BeZero())
+	Expect(lookup("10.96.0.11", 443, "TCP")).To(Equal(web))
+	store.RemoveService(web)
+	store.RemoveService(ServiceID{Namespace: "shop", Name: "web2"})
+	Expect(lookup("10.96.0.11", 443, "TCP")).To(BeZero())
+	Expect(store.serviceIDsByIP).To(HaveLen(1))
+	Expect(store.ServiceByID).To(HaveLen(2))
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policystore
+
+import (
+	"strings"
+
+	"github.com/projectcalico/app-policy/proto"
+)
+
+// StagedPolicyPrefix starts the names of staged policies, which Felix syncs in their tiers alongside enforced
+// policies. Staged policies are evaluated to preview their verdicts, but never enforced.
+const StagedPolicyPrefix = "staged:"
+
+// IsStaged returns whether a policy is staged.
+func IsStaged(id proto.PolicyID) bool {
+	return strings.HasPrefix(id.Name, StagedPolicyPrefix)
+}
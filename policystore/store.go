@@ -16,6 +16,7 @@ package policystore
 
 import (
 	"sync"
+	"time"
 
 	"github.com/projectcalico/app-policy/proto"
 	log "github.com/sirupsen/logrus"
@@ -28,12 +29,33 @@ type PolicyStore struct {
 	// Helper methods Write() and Read() encapsulate the correct locking logic.
 	RWMutex sync.RWMutex
 
-	PolicyByID         map[proto.PolicyID]*proto.Policy
-	ProfileByID        map[proto.ProfileID]*proto.Profile
-	IPSetByID          map[string]IPSet
-	Endpoint           *proto.WorkloadEndpoint
+	// PolicyByID and ProfileByID hold the policies and profiles synced. Those added and removed with UpdatePolicy,
+	// RemovePolicy, UpdateProfile and RemoveProfile have their rules' label selectors parsed in selectors, and their
+	// HTTP path regexes compiled in pathRegexps.
+	PolicyByID  map[proto.PolicyID]*proto.Policy
+	ProfileByID map[proto.ProfileID]*proto.Profile
+	selectors   map[string]*parsedSelector
+	pathRegexps map[string]*compiledPathRegexp
+	IPSetByID   map[string]IPSet
+	Endpoint    *proto.WorkloadEndpoint
+	// EndpointByID holds every endpoint synced, for a node-level Dikastes that serves many. Endpoint is the last of
+	// them to be updated.
+	EndpointByID       map[proto.WorkloadEndpointID]*proto.WorkloadEndpoint
 	ServiceAccountByID map[proto.ServiceAccountID]*proto.ServiceAccountUpdate
 	NamespaceByID      map[proto.NamespaceID]*proto.NamespaceUpdate
+	// ServiceByID holds the services synced, which are indexed by address in serviceIDsByIP. They are added and
+	// removed with UpdateService and RemoveService.
+	ServiceByID    map[ServiceID]*proto.ServiceUpdate
+	serviceIDsByIP map[string][]ServiceID
+
+	// Generation is an order-independent hash of the store's contents, maintained with SetGeneration and
+	// ToggleGeneration as they are synced. Stores with the same contents have the same Generation, however they were
+	// synced, so that replicas can share the decisions they cache under it.
+	Generation uint64
+	itemHashes map[string]uint64
+
+	// SyncLostAt is when the Policy Sync stream the store was synced from ended, or zero while it is still synced.
+	SyncLostAt time.Time
 }
 
 func NewPolicyStore() *PolicyStore {
@@ -42,8 +64,14 @@ func NewPolicyStore() *PolicyStore {
 		IPSetByID:          make(map[string]IPSet),
 		ProfileByID:        make(map[proto.ProfileID]*proto.Profile),
 		PolicyByID:         make(map[proto.PolicyID]*proto.Policy),
+		selectors:          make(map[string]*parsedSelector),
+		pathRegexps:        make(map[string]*compiledPathRegexp),
+		EndpointByID:       make(map[proto.WorkloadEndpointID]*proto.WorkloadEndpoint),
 		ServiceAccountByID: make(map[proto.ServiceAccountID]*proto.ServiceAccountUpdate),
 		NamespaceByID:      make(map[proto.NamespaceID]*proto.NamespaceUpdate),
+		ServiceByID:        make(map[ServiceID]*proto.ServiceUpdate),
+		serviceIDsByIP:     make(map[string][]ServiceID),
+		itemHashes:         make(map[string]uint64),
 	}
 }
 
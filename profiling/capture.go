@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package profiling captures CPU and heap profiles and a goroutine dump on demand, for diagnosing latency problems in
+// environments where the pprof HTTP endpoints can't be reached.
+package profiling
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	CPUProfileFile  = "cpu.pprof"
+	HeapProfileFile = "heap.pprof"
+	GoroutineFile   = "goroutines.txt"
+
+	DefaultDuration = 30 * time.Second
+)
+
+// Capturer writes profiles into a new directory under Dir. Only one capture runs at a time.
+type Capturer struct {
+	// Dir is the parent of each capture's directory. If empty, the system temp directory is used.
+	Dir string
+	// Duration is how long the CPU profile runs for.
+	Duration time.Duration
+
+	mu sync.Mutex
+}
+
+// Capture records a CPU profile for the configured duration, then a heap profile and a dump of every goroutine's
+// stack, and returns the directory they were written to. It returns early, with a truncated CPU profile, if ctx is
+// cancelled.
+func (c *Capturer) Capture(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	dir, err := ioutil.TempDir(c.Dir, "dikastes-profile-")
+	if err != nil {
+		return "", err
+	}
+	if err := c.captureCPU(ctx, filepath.Join(dir, CPUProfileFile)); err != nil {
+		return dir, err
+	}
+	if err := writeFile(filepath.Join(dir, HeapProfileFile), func(f *os.File) error {
+		// Collect garbage first so the profile reflects live objects.
+		runtime.GC()
+		return pprof.WriteHeapProfile(f)
+	}); err != nil {
+		return dir, err
+	}
+	err = writeFile(filepath.Join(dir, GoroutineFile), func(f *os.File) error {
+		return pprof.Lookup("goroutine").WriteTo(f, 2)
+	})
+	return dir, err
+}
+
+func (c *Capturer) captureCPU(ctx context.Context, path string) error {
+	d := c.Duration
+	if d == 0 {
+		d = DefaultDuration
+	}
+	return writeFile(path, func(f *os.File) error {
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return err
+		}
+		defer pprof.StopCPUProfile()
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+}
+
+func writeFile(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// OnSignal runs a capture each time one of the given signals arrives, until ctx is cancelled. Signals that arrive
+// during a capture are coalesced into at most one further capture.
+func (c *Capturer) OnSignal(ctx context.Context, sigs ...os.Signal) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+	defer signal.Stop(ch)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-ch:
+			log.WithFields(log.Fields{"signal": sig, "duration": c.Duration}).Info("Capturing profiles.")
+			dir, err := c.Capture(ctx)
+			if err != nil {
+				log.WithError(err).WithField("dir", dir).Error("Profile capture failed.")
+				continue
+			}
+			log.WithField("dir", dir).Info("Profiles captured.")
+		}
+	}
+}
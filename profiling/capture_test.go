@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profiling
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCapture(t *testing.T) {
+	RegisterTestingT(t)
+
+	parent, err := ioutil.TempDir("", "capture-test")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(parent)
+
+	uut := &Capturer{Dir: parent, Duration: 100 * time.Millisecond}
+	dir, err := uut.Capture(context.Background())
+	Expect(err).ToNot(HaveOccurred())
+	Expect(filepath.Dir(dir)).To(Equal(parent))
+	for _, f := range []string{CPUProfileFile, HeapProfileFile, GoroutineFile} {
+		fi, err := os.Stat(filepath.Join(dir, f))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fi.Size()).To(BeNumerically(">", 0), f)
+	}
+	goroutines, err := ioutil.ReadFile(filepath.Join(dir, GoroutineFile))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(string(goroutines)).To(ContainSubstring("TestCapture"))
+}
+
+// Cancelling the context cuts the CPU profile short.
+func TestCaptureCancel(t *testing.T) {
+	RegisterTestingT(t)
+
+	parent, err := ioutil.TempDir("", "capture-test")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(parent)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	uut := &Capturer{Dir: parent, Duration: time.Hour}
+	done := make(chan error)
+	go func() {
+		_, err := uut.Capture(ctx)
+		done <- err
+	}()
+	cancel()
+	Eventually(done, "5s").Should(Receive(BeNil()))
+}
+
+func TestOnSignal(t *testing.T) {
+	RegisterTestingT(t)
+
+	parent, err := ioutil.TempDir("", "capture-test")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(parent)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Catch SIGUSR2 here too, so that signals sent before OnSignal registers don't kill the test.
+	ignored := make(chan os.Signal, 1)
+	signal.Notify(ignored, syscall.SIGUSR2)
+	defer signal.Stop(ignored)
+
+	uut := &Capturer{Dir: parent, Duration: 10 * time.Millisecond}
+	go uut.OnSignal(ctx, syscall.SIGUSR2)
+
+	// Keep signalling until the handler is registered and a capture appears.
+	Eventually(func() int {
+		_ = syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+		entries, err := ioutil.ReadDir(parent)
+		Expect(err).ToNot(HaveOccurred())
+		return len(entries)
+	}, "5s", "100ms").Should(BeNumerically(">", 0))
+}
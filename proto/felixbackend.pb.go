@@ -58,6 +58,15 @@
 		NamespaceUpdate
 		NamespaceRemove
 		NamespaceID
+		ServiceUpdate
+		ServicePort
+		ServiceRemove
+		RuleMetadata
+		DataplaneStats
+		Statistic
+		WAFRuleHit
+		PolicyHit
+		ReportResult
 		HealthCheckRequest
 		HealthCheckResponse
 */
@@ -135,6 +144,101 @@ func (IPSetUpdate_IPSetType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptorFelixbackend, []int{5, 0}
 }
 
+type Statistic_Direction int32
+
+const (
+	Statistic_IN  Statistic_Direction = 0
+	Statistic_OUT Statistic_Direction = 1
+)
+
+var Statistic_Direction_name = map[int32]string{
+	0: "IN",
+	1: "OUT",
+}
+var Statistic_Direction_value = map[string]int32{
+	"IN":  0,
+	"OUT": 1,
+}
+
+func (x Statistic_Direction) String() string {
+	return proto1.EnumName(Statistic_Direction_name, int32(x))
+}
+func (Statistic_Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{51, 0}
+}
+
+type Statistic_Relativity int32
+
+const (
+	Statistic_TOTAL Statistic_Relativity = 0
+	Statistic_DELTA Statistic_Relativity = 1
+)
+
+var Statistic_Relativity_name = map[int32]string{
+	0: "TOTAL",
+	1: "DELTA",
+}
+var Statistic_Relativity_value = map[string]int32{
+	"TOTAL": 0,
+	"DELTA": 1,
+}
+
+func (x Statistic_Relativity) String() string {
+	return proto1.EnumName(Statistic_Relativity_name, int32(x))
+}
+func (Statistic_Relativity) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{51, 1}
+}
+
+type Statistic_Kind int32
+
+const (
+	Statistic_PACKETS       Statistic_Kind = 0
+	Statistic_BYTES         Statistic_Kind = 1
+	Statistic_HTTP_REQUESTS Statistic_Kind = 2
+)
+
+var Statistic_Kind_name = map[int32]string{
+	0: "PACKETS",
+	1: "BYTES",
+	2: "HTTP_REQUESTS",
+}
+var Statistic_Kind_value = map[string]int32{
+	"PACKETS":       0,
+	"BYTES":         1,
+	"HTTP_REQUESTS": 2,
+}
+
+func (x Statistic_Kind) String() string {
+	return proto1.EnumName(Statistic_Kind_name, int32(x))
+}
+func (Statistic_Kind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{51, 2}
+}
+
+type Statistic_Action int32
+
+const (
+	Statistic_ALLOWED Statistic_Action = 0
+	Statistic_DENIED  Statistic_Action = 1
+)
+
+var Statistic_Action_name = map[int32]string{
+	0: "ALLOWED",
+	1: "DENIED",
+}
+var Statistic_Action_value = map[string]int32{
+	"ALLOWED": 0,
+	"DENIED":  1,
+}
+
+func (x Statistic_Action) String() string {
+	return proto1.EnumName(Statistic_Action_name, int32(x))
+}
+func (Statistic_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{51, 3}
+}
+
 type SyncRequest struct {
 }
 
@@ -169,6 +273,8 @@ type ToDataplane struct {
 	//	*ToDataplane_ServiceAccountRemove
 	//	*ToDataplane_NamespaceUpdate
 	//	*ToDataplane_NamespaceRemove
+	//	*ToDataplane_ServiceUpdate
+	//	*ToDataplane_ServiceRemove
 	Payload isToDataplane_Payload `protobuf_oneof:"payload"`
 }
 
@@ -246,6 +352,12 @@ type ToDataplane_NamespaceUpdate struct {
 type ToDataplane_NamespaceRemove struct {
 	NamespaceRemove *NamespaceRemove `protobuf:"bytes,22,opt,name=namespace_remove,json=namespaceRemove,oneof"`
 }
+type ToDataplane_ServiceUpdate struct {
+	ServiceUpdate *ServiceUpdate `protobuf:"bytes,23,opt,name=service_update,json=serviceUpdate,oneof"`
+}
+type ToDataplane_ServiceRemove struct {
+	ServiceRemove *ServiceRemove `protobuf:"bytes,24,opt,name=service_remove,json=serviceRemove,oneof"`
+}
 
 func (*ToDataplane_InSync) isToDataplane_Payload()                 {}
 func (*ToDataplane_IpsetUpdate) isToDataplane_Payload()            {}
@@ -268,6 +380,8 @@ func (*ToDataplane_ServiceAccountUpdate) isToDataplane_Payload()   {}
 func (*ToDataplane_ServiceAccountRemove) isToDataplane_Payload()   {}
 func (*ToDataplane_NamespaceUpdate) isToDataplane_Payload()        {}
 func (*ToDataplane_NamespaceRemove) isToDataplane_Payload()        {}
+func (*ToDataplane_ServiceUpdate) isToDataplane_Payload()          {}
+func (*ToDataplane_ServiceRemove) isToDataplane_Payload()          {}
 
 func (m *ToDataplane) GetPayload() isToDataplane_Payload {
 	if m != nil {
@@ -430,6 +544,20 @@ func (m *ToDataplane) GetNamespaceRemove() *NamespaceRemove {
 	return nil
 }
 
+func (m *ToDataplane) GetServiceUpdate() *ServiceUpdate {
+	if x, ok := m.GetPayload().(*ToDataplane_ServiceUpdate); ok {
+		return x.ServiceUpdate
+	}
+	return nil
+}
+
+func (m *ToDataplane) GetServiceRemove() *ServiceRemove {
+	if x, ok := m.GetPayload().(*ToDataplane_ServiceRemove); ok {
+		return x.ServiceRemove
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*ToDataplane) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
 	return _ToDataplane_OneofMarshaler, _ToDataplane_OneofUnmarshaler, _ToDataplane_OneofSizer, []interface{}{
@@ -454,6 +582,8 @@ func (*ToDataplane) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer)
 		(*ToDataplane_ServiceAccountRemove)(nil),
 		(*ToDataplane_NamespaceUpdate)(nil),
 		(*ToDataplane_NamespaceRemove)(nil),
+		(*ToDataplane_ServiceUpdate)(nil),
+		(*ToDataplane_ServiceRemove)(nil),
 	}
 }
 
@@ -566,6 +696,16 @@ func _ToDataplane_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
 		if err := b.EncodeMessage(x.NamespaceRemove); err != nil {
 			return err
 		}
+	case *ToDataplane_ServiceUpdate:
+		_ = b.EncodeVarint(23<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.ServiceUpdate); err != nil {
+			return err
+		}
+	case *ToDataplane_ServiceRemove:
+		_ = b.EncodeVarint(24<<3 | proto1.WireBytes)
+		if err := b.EncodeMessage(x.ServiceRemove); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("ToDataplane.Payload has unexpected type %T", x)
@@ -744,6 +884,22 @@ func _ToDataplane_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto1.
 		err := b.DecodeMessage(msg)
 		m.Payload = &ToDataplane_NamespaceRemove{msg}
 		return true, err
+	case 23: // payload.service_update
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(ServiceUpdate)
+		err := b.DecodeMessage(msg)
+		m.Payload = &ToDataplane_ServiceUpdate{msg}
+		return true, err
+	case 24: // payload.service_remove
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		msg := new(ServiceRemove)
+		err := b.DecodeMessage(msg)
+		m.Payload = &ToDataplane_ServiceRemove{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -858,6 +1014,16 @@ func _ToDataplane_OneofSizer(msg proto1.Message) (n int) {
 		n += proto1.SizeVarint(22<<3 | proto1.WireBytes)
 		n += proto1.SizeVarint(uint64(s))
 		n += s
+	case *ToDataplane_ServiceUpdate:
+		s := proto1.Size(x.ServiceUpdate)
+		n += proto1.SizeVarint(23<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
+	case *ToDataplane_ServiceRemove:
+		s := proto1.Size(x.ServiceRemove)
+		n += proto1.SizeVarint(24<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1434,6 +1600,8 @@ type Rule struct {
 	HttpMatch *HTTPMatch `protobuf:"bytes,122,opt,name=http_match,json=httpMatch" json:"http_match,omitempty"`
 	// An opaque ID/hash for the rule.
 	RuleId string `protobuf:"bytes,201,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	// Rule metadata.
+	Metadata *RuleMetadata `protobuf:"bytes,202,opt,name=metadata" json:"metadata,omitempty"`
 }
 
 func (m *Rule) Reset()                    { *m = Rule{} }
@@ -1721,6 +1889,13 @@ func (m *Rule) GetRuleId() string {
 	return ""
 }
 
+func (m *Rule) GetMetadata() *RuleMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Rule) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
 	return _Rule_OneofMarshaler, _Rule_OneofUnmarshaler, _Rule_OneofSizer, []interface{}{
@@ -1860,8 +2035,9 @@ func (m *ServiceAccountMatch) GetNames() []string {
 }
 
 type HTTPMatch struct {
-	Methods []string               `protobuf:"bytes,1,rep,name=methods" json:"methods,omitempty"`
-	Paths   []*HTTPMatch_PathMatch `protobuf:"bytes,2,rep,name=paths" json:"paths,omitempty"`
+	Methods []string                 `protobuf:"bytes,1,rep,name=methods" json:"methods,omitempty"`
+	Paths   []*HTTPMatch_PathMatch   `protobuf:"bytes,2,rep,name=paths" json:"paths,omitempty"`
+	Headers []*HTTPMatch_HeaderMatch `protobuf:"bytes,3,rep,name=headers" json:"headers,omitempty"`
 }
 
 func (m *HTTPMatch) Reset()                    { *m = HTTPMatch{} }
@@ -1883,10 +2059,18 @@ func (m *HTTPMatch) GetPaths() []*HTTPMatch_PathMatch {
 	return nil
 }
 
+func (m *HTTPMatch) GetHeaders() []*HTTPMatch_HeaderMatch {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
 type HTTPMatch_PathMatch struct {
 	// Types that are valid to be assigned to PathMatch:
 	//	*HTTPMatch_PathMatch_Exact
 	//	*HTTPMatch_PathMatch_Prefix
+	//	*HTTPMatch_PathMatch_Regex
 	PathMatch isHTTPMatch_PathMatch_PathMatch `protobuf_oneof:"path_match"`
 }
 
@@ -1909,9 +2093,13 @@ type HTTPMatch_PathMatch_Exact struct {
 type HTTPMatch_PathMatch_Prefix struct {
 	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3,oneof"`
 }
+type HTTPMatch_PathMatch_Regex struct {
+	Regex string `protobuf:"bytes,3,opt,name=regex,proto3,oneof"`
+}
 
 func (*HTTPMatch_PathMatch_Exact) isHTTPMatch_PathMatch_PathMatch()  {}
 func (*HTTPMatch_PathMatch_Prefix) isHTTPMatch_PathMatch_PathMatch() {}
+func (*HTTPMatch_PathMatch_Regex) isHTTPMatch_PathMatch_PathMatch()  {}
 
 func (m *HTTPMatch_PathMatch) GetPathMatch() isHTTPMatch_PathMatch_PathMatch {
 	if m != nil {
@@ -1934,11 +2122,19 @@ func (m *HTTPMatch_PathMatch) GetPrefix() string {
 	return ""
 }
 
+func (m *HTTPMatch_PathMatch) GetRegex() string {
+	if x, ok := m.GetPathMatch().(*HTTPMatch_PathMatch_Regex); ok {
+		return x.Regex
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*HTTPMatch_PathMatch) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
 	return _HTTPMatch_PathMatch_OneofMarshaler, _HTTPMatch_PathMatch_OneofUnmarshaler, _HTTPMatch_PathMatch_OneofSizer, []interface{}{
 		(*HTTPMatch_PathMatch_Exact)(nil),
 		(*HTTPMatch_PathMatch_Prefix)(nil),
+		(*HTTPMatch_PathMatch_Regex)(nil),
 	}
 }
 
@@ -1952,6 +2148,9 @@ func _HTTPMatch_PathMatch_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) e
 	case *HTTPMatch_PathMatch_Prefix:
 		_ = b.EncodeVarint(2<<3 | proto1.WireBytes)
 		_ = b.EncodeStringBytes(x.Prefix)
+	case *HTTPMatch_PathMatch_Regex:
+		_ = b.EncodeVarint(3<<3 | proto1.WireBytes)
+		_ = b.EncodeStringBytes(x.Regex)
 	case nil:
 	default:
 		return fmt.Errorf("HTTPMatch_PathMatch.PathMatch has unexpected type %T", x)
@@ -1976,6 +2175,13 @@ func _HTTPMatch_PathMatch_OneofUnmarshaler(msg proto1.Message, tag, wire int, b
 		x, err := b.DecodeStringBytes()
 		m.PathMatch = &HTTPMatch_PathMatch_Prefix{x}
 		return true, err
+	case 3: // path_match.regex
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.PathMatch = &HTTPMatch_PathMatch_Regex{x}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1993,6 +2199,165 @@ func _HTTPMatch_PathMatch_OneofSizer(msg proto1.Message) (n int) {
 		n += proto1.SizeVarint(2<<3 | proto1.WireBytes)
 		n += proto1.SizeVarint(uint64(len(x.Prefix)))
 		n += len(x.Prefix)
+	case *HTTPMatch_PathMatch_Regex:
+		n += proto1.SizeVarint(3<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(len(x.Regex)))
+		n += len(x.Regex)
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type HTTPMatch_HeaderMatch struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Types that are valid to be assigned to HeaderMatch:
+	//	*HTTPMatch_HeaderMatch_Exact
+	//	*HTTPMatch_HeaderMatch_Present
+	//	*HTTPMatch_HeaderMatch_Prefix
+	HeaderMatch isHTTPMatch_HeaderMatch_HeaderMatch `protobuf_oneof:"header_match"`
+}
+
+func (m *HTTPMatch_HeaderMatch) Reset()         { *m = HTTPMatch_HeaderMatch{} }
+func (m *HTTPMatch_HeaderMatch) String() string { return proto1.CompactTextString(m) }
+func (*HTTPMatch_HeaderMatch) ProtoMessage()    {}
+func (*HTTPMatch_HeaderMatch) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{18, 1}
+}
+
+type isHTTPMatch_HeaderMatch_HeaderMatch interface {
+	isHTTPMatch_HeaderMatch_HeaderMatch()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type HTTPMatch_HeaderMatch_Exact struct {
+	Exact string `protobuf:"bytes,2,opt,name=exact,proto3,oneof"`
+}
+type HTTPMatch_HeaderMatch_Present struct {
+	Present bool `protobuf:"varint,3,opt,name=present,proto3,oneof"`
+}
+type HTTPMatch_HeaderMatch_Prefix struct {
+	Prefix string `protobuf:"bytes,4,opt,name=prefix,proto3,oneof"`
+}
+
+func (*HTTPMatch_HeaderMatch_Exact) isHTTPMatch_HeaderMatch_HeaderMatch()   {}
+func (*HTTPMatch_HeaderMatch_Present) isHTTPMatch_HeaderMatch_HeaderMatch() {}
+func (*HTTPMatch_HeaderMatch_Prefix) isHTTPMatch_HeaderMatch_HeaderMatch()  {}
+
+func (m *HTTPMatch_HeaderMatch) GetHeaderMatch() isHTTPMatch_HeaderMatch_HeaderMatch {
+	if m != nil {
+		return m.HeaderMatch
+	}
+	return nil
+}
+
+func (m *HTTPMatch_HeaderMatch) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HTTPMatch_HeaderMatch) GetExact() string {
+	if x, ok := m.GetHeaderMatch().(*HTTPMatch_HeaderMatch_Exact); ok {
+		return x.Exact
+	}
+	return ""
+}
+
+func (m *HTTPMatch_HeaderMatch) GetPresent() bool {
+	if x, ok := m.GetHeaderMatch().(*HTTPMatch_HeaderMatch_Present); ok {
+		return x.Present
+	}
+	return false
+}
+
+func (m *HTTPMatch_HeaderMatch) GetPrefix() string {
+	if x, ok := m.GetHeaderMatch().(*HTTPMatch_HeaderMatch_Prefix); ok {
+		return x.Prefix
+	}
+	return ""
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*HTTPMatch_HeaderMatch) XXX_OneofFuncs() (func(msg proto1.Message, b *proto1.Buffer) error, func(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error), func(msg proto1.Message) (n int), []interface{}) {
+	return _HTTPMatch_HeaderMatch_OneofMarshaler, _HTTPMatch_HeaderMatch_OneofUnmarshaler, _HTTPMatch_HeaderMatch_OneofSizer, []interface{}{
+		(*HTTPMatch_HeaderMatch_Exact)(nil),
+		(*HTTPMatch_HeaderMatch_Present)(nil),
+		(*HTTPMatch_HeaderMatch_Prefix)(nil),
+	}
+}
+
+func _HTTPMatch_HeaderMatch_OneofMarshaler(msg proto1.Message, b *proto1.Buffer) error {
+	m := msg.(*HTTPMatch_HeaderMatch)
+	// header_match
+	switch x := m.HeaderMatch.(type) {
+	case *HTTPMatch_HeaderMatch_Exact:
+		_ = b.EncodeVarint(2<<3 | proto1.WireBytes)
+		_ = b.EncodeStringBytes(x.Exact)
+	case *HTTPMatch_HeaderMatch_Present:
+		t := uint64(0)
+		if x.Present {
+			t = 1
+		}
+		_ = b.EncodeVarint(3<<3 | proto1.WireVarint)
+		_ = b.EncodeVarint(t)
+	case *HTTPMatch_HeaderMatch_Prefix:
+		_ = b.EncodeVarint(4<<3 | proto1.WireBytes)
+		_ = b.EncodeStringBytes(x.Prefix)
+	case nil:
+	default:
+		return fmt.Errorf("HTTPMatch_HeaderMatch.HeaderMatch has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _HTTPMatch_HeaderMatch_OneofUnmarshaler(msg proto1.Message, tag, wire int, b *proto1.Buffer) (bool, error) {
+	m := msg.(*HTTPMatch_HeaderMatch)
+	switch tag {
+	case 2: // header_match.exact
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.HeaderMatch = &HTTPMatch_HeaderMatch_Exact{x}
+		return true, err
+	case 3: // header_match.present
+		if wire != proto1.WireVarint {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.HeaderMatch = &HTTPMatch_HeaderMatch_Present{x != 0}
+		return true, err
+	case 4: // header_match.prefix
+		if wire != proto1.WireBytes {
+			return true, proto1.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.HeaderMatch = &HTTPMatch_HeaderMatch_Prefix{x}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _HTTPMatch_HeaderMatch_OneofSizer(msg proto1.Message) (n int) {
+	m := msg.(*HTTPMatch_HeaderMatch)
+	// header_match
+	switch x := m.HeaderMatch.(type) {
+	case *HTTPMatch_HeaderMatch_Exact:
+		n += proto1.SizeVarint(2<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(len(x.Exact)))
+		n += len(x.Exact)
+	case *HTTPMatch_HeaderMatch_Present:
+		n += proto1.SizeVarint(3<<3 | proto1.WireVarint)
+		n += 1
+	case *HTTPMatch_HeaderMatch_Prefix:
+		n += proto1.SizeVarint(4<<3 | proto1.WireBytes)
+		n += proto1.SizeVarint(uint64(len(x.Prefix)))
+		n += len(x.Prefix)
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -2867,547 +3232,636 @@ func (m *NamespaceID) GetName() string {
 	return ""
 }
 
-func init() {
-	proto1.RegisterType((*SyncRequest)(nil), "felix.SyncRequest")
-	proto1.RegisterType((*ToDataplane)(nil), "felix.ToDataplane")
-	proto1.RegisterType((*FromDataplane)(nil), "felix.FromDataplane")
-	proto1.RegisterType((*ConfigUpdate)(nil), "felix.ConfigUpdate")
-	proto1.RegisterType((*InSync)(nil), "felix.InSync")
-	proto1.RegisterType((*IPSetUpdate)(nil), "felix.IPSetUpdate")
-	proto1.RegisterType((*IPSetDeltaUpdate)(nil), "felix.IPSetDeltaUpdate")
-	proto1.RegisterType((*IPSetRemove)(nil), "felix.IPSetRemove")
-	proto1.RegisterType((*ActiveProfileUpdate)(nil), "felix.ActiveProfileUpdate")
-	proto1.RegisterType((*ActiveProfileRemove)(nil), "felix.ActiveProfileRemove")
-	proto1.RegisterType((*ProfileID)(nil), "felix.ProfileID")
-	proto1.RegisterType((*Profile)(nil), "felix.Profile")
-	proto1.RegisterType((*ActivePolicyUpdate)(nil), "felix.ActivePolicyUpdate")
-	proto1.RegisterType((*ActivePolicyRemove)(nil), "felix.ActivePolicyRemove")
-	proto1.RegisterType((*PolicyID)(nil), "felix.PolicyID")
-	proto1.RegisterType((*Policy)(nil), "felix.Policy")
-	proto1.RegisterType((*Rule)(nil), "felix.Rule")
-	proto1.RegisterType((*ServiceAccountMatch)(nil), "felix.ServiceAccountMatch")
-	proto1.RegisterType((*HTTPMatch)(nil), "felix.HTTPMatch")
-	proto1.RegisterType((*HTTPMatch_PathMatch)(nil), "felix.HTTPMatch.PathMatch")
-	proto1.RegisterType((*IcmpTypeAndCode)(nil), "felix.IcmpTypeAndCode")
-	proto1.RegisterType((*Protocol)(nil), "felix.Protocol")
-	proto1.RegisterType((*PortRange)(nil), "felix.PortRange")
-	proto1.RegisterType((*WorkloadEndpointID)(nil), "felix.WorkloadEndpointID")
-	proto1.RegisterType((*WorkloadEndpointUpdate)(nil), "felix.WorkloadEndpointUpdate")
-	proto1.RegisterType((*WorkloadEndpoint)(nil), "felix.WorkloadEndpoint")
-	proto1.RegisterType((*WorkloadEndpointRemove)(nil), "felix.WorkloadEndpointRemove")
-	proto1.RegisterType((*HostEndpointID)(nil), "felix.HostEndpointID")
-	proto1.RegisterType((*HostEndpointUpdate)(nil), "felix.HostEndpointUpdate")
-	proto1.RegisterType((*HostEndpoint)(nil), "felix.HostEndpoint")
-	proto1.RegisterType((*HostEndpointRemove)(nil), "felix.HostEndpointRemove")
-	proto1.RegisterType((*TierInfo)(nil), "felix.TierInfo")
-	proto1.RegisterType((*NatInfo)(nil), "felix.NatInfo")
-	proto1.RegisterType((*ProcessStatusUpdate)(nil), "felix.ProcessStatusUpdate")
-	proto1.RegisterType((*HostEndpointStatusUpdate)(nil), "felix.HostEndpointStatusUpdate")
-	proto1.RegisterType((*EndpointStatus)(nil), "felix.EndpointStatus")
-	proto1.RegisterType((*HostEndpointStatusRemove)(nil), "felix.HostEndpointStatusRemove")
-	proto1.RegisterType((*WorkloadEndpointStatusUpdate)(nil), "felix.WorkloadEndpointStatusUpdate")
-	proto1.RegisterType((*WorkloadEndpointStatusRemove)(nil), "felix.WorkloadEndpointStatusRemove")
-	proto1.RegisterType((*HostMetadataUpdate)(nil), "felix.HostMetadataUpdate")
-	proto1.RegisterType((*HostMetadataRemove)(nil), "felix.HostMetadataRemove")
-	proto1.RegisterType((*IPAMPoolUpdate)(nil), "felix.IPAMPoolUpdate")
-	proto1.RegisterType((*IPAMPoolRemove)(nil), "felix.IPAMPoolRemove")
-	proto1.RegisterType((*IPAMPool)(nil), "felix.IPAMPool")
-	proto1.RegisterType((*ServiceAccountUpdate)(nil), "felix.ServiceAccountUpdate")
-	proto1.RegisterType((*ServiceAccountRemove)(nil), "felix.ServiceAccountRemove")
-	proto1.RegisterType((*ServiceAccountID)(nil), "felix.ServiceAccountID")
-	proto1.RegisterType((*NamespaceUpdate)(nil), "felix.NamespaceUpdate")
-	proto1.RegisterType((*NamespaceRemove)(nil), "felix.NamespaceRemove")
-	proto1.RegisterType((*NamespaceID)(nil), "felix.NamespaceID")
-	proto1.RegisterEnum("felix.IPVersion", IPVersion_name, IPVersion_value)
-	proto1.RegisterEnum("felix.IPSetUpdate_IPSetType", IPSetUpdate_IPSetType_name, IPSetUpdate_IPSetType_value)
+type ServiceUpdate struct {
+	Name           string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace      string         `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Type           string         `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	ClusterIp      string         `protobuf:"bytes,4,opt,name=cluster_ip,json=clusterIp,proto3" json:"cluster_ip,omitempty"`
+	LoadbalancerIp string         `protobuf:"bytes,5,opt,name=loadbalancer_ip,json=loadbalancerIp,proto3" json:"loadbalancer_ip,omitempty"`
+	ExternalIps    []string       `protobuf:"bytes,6,rep,name=external_ips,json=externalIps" json:"external_ips,omitempty"`
+	Ports          []*ServicePort `protobuf:"bytes,7,rep,name=ports" json:"ports,omitempty"`
+	// All of the service's cluster IPs, including cluster_ip, for dual-stack services.
+	ClusterIps []string `protobuf:"bytes,8,rep,name=cluster_ips,json=clusterIps" json:"cluster_ips,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *ServiceUpdate) Reset()         { *m = ServiceUpdate{} }
+func (m *ServiceUpdate) String() string { return proto1.CompactTextString(m) }
+func (*ServiceUpdate) ProtoMessage()    {}
+func (*ServiceUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{54}
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ServiceUpdate) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
 
-// Client API for PolicySync service
+func (m *ServiceUpdate) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
 
-type PolicySyncClient interface {
-	// On this API, only the following payloads will be sent:
-	//  - InSync
-	//  - IPSetUpdate
-	//  - IPSetDeltaUpdate
-	//  - IPSetRemove
-	//  - ActiveProfileUpdate
-	//  - ActiveProfileRemove
-	//  - ActivePolicyUpdate
-	//  - ActivePolicyRemove
-	//  - WorkloadEndpointUpdate
-	//  - WorkloadEndpointRemove
-	//  - ServiceAccountUpdate
-	//  - ServiceAccountRemove
-	//  - NamespaceUpdate
-	//  - NamespaceRemove
-	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (PolicySync_SyncClient, error)
+func (m *ServiceUpdate) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
 }
 
-type policySyncClient struct {
-	cc *grpc.ClientConn
+func (m *ServiceUpdate) GetClusterIp() string {
+	if m != nil {
+		return m.ClusterIp
+	}
+	return ""
 }
 
-func NewPolicySyncClient(cc *grpc.ClientConn) PolicySyncClient {
-	return &policySyncClient{cc}
+func (m *ServiceUpdate) GetLoadbalancerIp() string {
+	if m != nil {
+		return m.LoadbalancerIp
+	}
+	return ""
 }
 
-func (c *policySyncClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (PolicySync_SyncClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_PolicySync_serviceDesc.Streams[0], c.cc, "/felix.PolicySync/Sync", opts...)
-	if err != nil {
-		return nil, err
+func (m *ServiceUpdate) GetExternalIps() []string {
+	if m != nil {
+		return m.ExternalIps
 	}
-	x := &policySyncSyncClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *ServiceUpdate) GetPorts() []*ServicePort {
+	if m != nil {
+		return m.Ports
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *ServiceUpdate) GetClusterIps() []string {
+	if m != nil {
+		return m.ClusterIps
 	}
-	return x, nil
+	return nil
 }
 
-type PolicySync_SyncClient interface {
-	Recv() (*ToDataplane, error)
-	grpc.ClientStream
+type ServicePort struct {
+	Protocol string `protobuf:"bytes,1,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Port     int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	NodePort int32  `protobuf:"varint,3,opt,name=node_port,json=nodePort,proto3" json:"node_port,omitempty"`
 }
 
-type policySyncSyncClient struct {
-	grpc.ClientStream
+func (m *ServicePort) Reset()         { *m = ServicePort{} }
+func (m *ServicePort) String() string { return proto1.CompactTextString(m) }
+func (*ServicePort) ProtoMessage()    {}
+func (*ServicePort) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{55}
 }
 
-func (x *policySyncSyncClient) Recv() (*ToDataplane, error) {
-	m := new(ToDataplane)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *ServicePort) GetProtocol() string {
+	if m != nil {
+		return m.Protocol
 	}
-	return m, nil
+	return ""
 }
 
-// Server API for PolicySync service
+func (m *ServicePort) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
 
-type PolicySyncServer interface {
-	// On this API, only the following payloads will be sent:
-	//  - InSync
-	//  - IPSetUpdate
-	//  - IPSetDeltaUpdate
-	//  - IPSetRemove
-	//  - ActiveProfileUpdate
-	//  - ActiveProfileRemove
-	//  - ActivePolicyUpdate
-	//  - ActivePolicyRemove
-	//  - WorkloadEndpointUpdate
-	//  - WorkloadEndpointRemove
-	//  - ServiceAccountUpdate
-	//  - ServiceAccountRemove
-	//  - NamespaceUpdate
-	//  - NamespaceRemove
-	Sync(*SyncRequest, PolicySync_SyncServer) error
+func (m *ServicePort) GetNodePort() int32 {
+	if m != nil {
+		return m.NodePort
+	}
+	return 0
 }
 
-func RegisterPolicySyncServer(s *grpc.Server, srv PolicySyncServer) {
-	s.RegisterService(&_PolicySync_serviceDesc, srv)
+type ServiceRemove struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
 }
 
-func _PolicySync_Sync_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SyncRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *ServiceRemove) Reset()         { *m = ServiceRemove{} }
+func (m *ServiceRemove) String() string { return proto1.CompactTextString(m) }
+func (*ServiceRemove) ProtoMessage()    {}
+func (*ServiceRemove) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{56}
+}
+
+func (m *ServiceRemove) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return srv.(PolicySyncServer).Sync(m, &policySyncSyncServer{stream})
+	return ""
 }
 
-type PolicySync_SyncServer interface {
-	Send(*ToDataplane) error
-	grpc.ServerStream
+func (m *ServiceRemove) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
 }
 
-type policySyncSyncServer struct {
-	grpc.ServerStream
+type RuleMetadata struct {
+	Annotations map[string]string `protobuf:"bytes,1,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *policySyncSyncServer) Send(m *ToDataplane) error {
-	return x.ServerStream.SendMsg(m)
+func (m *RuleMetadata) Reset()                    { *m = RuleMetadata{} }
+func (m *RuleMetadata) String() string            { return proto1.CompactTextString(m) }
+func (*RuleMetadata) ProtoMessage()               {}
+func (*RuleMetadata) Descriptor() ([]byte, []int) { return fileDescriptorFelixbackend, []int{49} }
+
+func (m *RuleMetadata) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
 }
 
-var _PolicySync_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "felix.PolicySync",
-	HandlerType: (*PolicySyncServer)(nil),
-	Methods:     []grpc.MethodDesc{},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "Sync",
-			Handler:       _PolicySync_Sync_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "felixbackend.proto",
+type DataplaneStats struct {
+	SrcIp    string       `protobuf:"bytes,1,opt,name=src_ip,json=srcIp,proto3" json:"src_ip,omitempty"`
+	DstIp    string       `protobuf:"bytes,2,opt,name=dst_ip,json=dstIp,proto3" json:"dst_ip,omitempty"`
+	SrcPort  int32        `protobuf:"varint,3,opt,name=src_port,json=srcPort,proto3" json:"src_port,omitempty"`
+	DstPort  int32        `protobuf:"varint,4,opt,name=dst_port,json=dstPort,proto3" json:"dst_port,omitempty"`
+	Protocol *Protocol    `protobuf:"bytes,5,opt,name=protocol" json:"protocol,omitempty"`
+	Stats    []*Statistic `protobuf:"bytes,6,rep,name=stats" json:"stats,omitempty"`
+	// WAF rules that matched requests on the connection.
+	WafRuleHits []*WAFRuleHit `protobuf:"bytes,100,rep,name=waf_rule_hits,json=wafRuleHits" json:"waf_rule_hits,omitempty"`
+	// Policies that decided requests on the connection.
+	PolicyHits []*PolicyHit `protobuf:"bytes,101,rep,name=policy_hits,json=policyHits" json:"policy_hits,omitempty"`
 }
 
-func (m *SyncRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DataplaneStats) Reset()         { *m = DataplaneStats{} }
+func (m *DataplaneStats) String() string { return proto1.CompactTextString(m) }
+func (*DataplaneStats) ProtoMessage()    {}
+func (*DataplaneStats) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{50}
+}
+
+func (m *DataplaneStats) GetSrcIp() string {
+	if m != nil {
+		return m.SrcIp
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SyncRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+func (m *DataplaneStats) GetDstIp() string {
+	if m != nil {
+		return m.DstIp
+	}
+	return ""
 }
 
-func (m *ToDataplane) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *DataplaneStats) GetSrcPort() int32 {
+	if m != nil {
+		return m.SrcPort
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ToDataplane) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Payload != nil {
-		nn1, err := m.Payload.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += nn1
+func (m *DataplaneStats) GetDstPort() int32 {
+	if m != nil {
+		return m.DstPort
 	}
-	if m.SequenceNumber != 0 {
-		dAtA[i] = 0x78
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.SequenceNumber))
+	return 0
+}
+
+func (m *DataplaneStats) GetProtocol() *Protocol {
+	if m != nil {
+		return m.Protocol
 	}
-	return i, nil
+	return nil
 }
 
-func (m *ToDataplane_InSync) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.InSync != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.InSync.Size()))
-		n2, err := m.InSync.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n2
+func (m *DataplaneStats) GetStats() []*Statistic {
+	if m != nil {
+		return m.Stats
 	}
-	return i, nil
+	return nil
 }
-func (m *ToDataplane_IpsetUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.IpsetUpdate != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpsetUpdate.Size()))
-		n3, err := m.IpsetUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n3
+
+func (m *DataplaneStats) GetWafRuleHits() []*WAFRuleHit {
+	if m != nil {
+		return m.WafRuleHits
 	}
-	return i, nil
+	return nil
 }
-func (m *ToDataplane_IpsetDeltaUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.IpsetDeltaUpdate != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpsetDeltaUpdate.Size()))
-		n4, err := m.IpsetDeltaUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
+
+func (m *DataplaneStats) GetPolicyHits() []*PolicyHit {
+	if m != nil {
+		return m.PolicyHits
 	}
-	return i, nil
+	return nil
 }
-func (m *ToDataplane_IpsetRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.IpsetRemove != nil {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpsetRemove.Size()))
-		n5, err := m.IpsetRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
+
+type Statistic struct {
+	Direction  Statistic_Direction  `protobuf:"varint,1,opt,name=direction,proto3,enum=felix.Statistic_Direction" json:"direction,omitempty"`
+	Relativity Statistic_Relativity `protobuf:"varint,2,opt,name=relativity,proto3,enum=felix.Statistic_Relativity" json:"relativity,omitempty"`
+	Kind       Statistic_Kind       `protobuf:"varint,3,opt,name=kind,proto3,enum=felix.Statistic_Kind" json:"kind,omitempty"`
+	Action     Statistic_Action     `protobuf:"varint,4,opt,name=action,proto3,enum=felix.Statistic_Action" json:"action,omitempty"`
+	Value      int64                `protobuf:"varint,5,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Statistic) Reset()         { *m = Statistic{} }
+func (m *Statistic) String() string { return proto1.CompactTextString(m) }
+func (*Statistic) ProtoMessage()    {}
+func (*Statistic) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{51}
+}
+
+func (m *Statistic) GetDirection() Statistic_Direction {
+	if m != nil {
+		return m.Direction
 	}
-	return i, nil
+	return 0
 }
-func (m *ToDataplane_ActiveProfileUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.ActiveProfileUpdate != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ActiveProfileUpdate.Size()))
-		n6, err := m.ActiveProfileUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n6
+
+func (m *Statistic) GetRelativity() Statistic_Relativity {
+	if m != nil {
+		return m.Relativity
 	}
-	return i, nil
+	return 0
 }
-func (m *ToDataplane_ActiveProfileRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.ActiveProfileRemove != nil {
-		dAtA[i] = 0x32
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ActiveProfileRemove.Size()))
-		n7, err := m.ActiveProfileRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
+
+func (m *Statistic) GetKind() Statistic_Kind {
+	if m != nil {
+		return m.Kind
 	}
-	return i, nil
+	return 0
 }
-func (m *ToDataplane_ActivePolicyUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.ActivePolicyUpdate != nil {
-		dAtA[i] = 0x3a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ActivePolicyUpdate.Size()))
-		n8, err := m.ActivePolicyUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n8
+
+func (m *Statistic) GetAction() Statistic_Action {
+	if m != nil {
+		return m.Action
 	}
-	return i, nil
+	return 0
 }
-func (m *ToDataplane_ActivePolicyRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.ActivePolicyRemove != nil {
-		dAtA[i] = 0x42
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ActivePolicyRemove.Size()))
-		n9, err := m.ActivePolicyRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n9
+
+func (m *Statistic) GetValue() int64 {
+	if m != nil {
+		return m.Value
 	}
-	return i, nil
+	return 0
 }
-func (m *ToDataplane_HostEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.HostEndpointUpdate != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointUpdate.Size()))
-		n10, err := m.HostEndpointUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n10
-	}
-	return i, nil
+
+type WAFRuleHit struct {
+	RuleId   int32  `protobuf:"varint,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Severity string `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`
+	Message  string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Whether the rule blocked the requests it matched.
+	Blocked bool `protobuf:"varint,4,opt,name=blocked,proto3" json:"blocked,omitempty"`
+	// The number of requests the rule matched since the last report.
+	Count int64 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
 }
-func (m *ToDataplane_HostEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.HostEndpointRemove != nil {
-		dAtA[i] = 0x52
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointRemove.Size()))
-		n11, err := m.HostEndpointRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n11
-	}
-	return i, nil
+
+func (m *WAFRuleHit) Reset()         { *m = WAFRuleHit{} }
+func (m *WAFRuleHit) String() string { return proto1.CompactTextString(m) }
+func (*WAFRuleHit) ProtoMessage()    {}
+func (*WAFRuleHit) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{52}
 }
-func (m *ToDataplane_WorkloadEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.WorkloadEndpointUpdate != nil {
-		dAtA[i] = 0x5a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointUpdate.Size()))
-		n12, err := m.WorkloadEndpointUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n12
+
+func (m *WAFRuleHit) GetRuleId() int32 {
+	if m != nil {
+		return m.RuleId
 	}
-	return i, nil
+	return 0
 }
-func (m *ToDataplane_WorkloadEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.WorkloadEndpointRemove != nil {
-		dAtA[i] = 0x62
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointRemove.Size()))
-		n13, err := m.WorkloadEndpointRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n13
+
+func (m *WAFRuleHit) GetSeverity() string {
+	if m != nil {
+		return m.Severity
 	}
-	return i, nil
+	return ""
 }
-func (m *ToDataplane_ConfigUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.ConfigUpdate != nil {
-		dAtA[i] = 0x6a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ConfigUpdate.Size()))
-		n14, err := m.ConfigUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n14
+
+func (m *WAFRuleHit) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return i, nil
+	return ""
 }
-func (m *ToDataplane_HostMetadataUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.HostMetadataUpdate != nil {
-		dAtA[i] = 0x72
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostMetadataUpdate.Size()))
-		n15, err := m.HostMetadataUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n15
+
+func (m *WAFRuleHit) GetBlocked() bool {
+	if m != nil {
+		return m.Blocked
 	}
-	return i, nil
+	return false
 }
-func (m *ToDataplane_IpamPoolUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.IpamPoolUpdate != nil {
-		dAtA[i] = 0x82
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpamPoolUpdate.Size()))
-		n16, err := m.IpamPoolUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n16
+
+func (m *WAFRuleHit) GetCount() int64 {
+	if m != nil {
+		return m.Count
 	}
-	return i, nil
+	return 0
 }
-func (m *ToDataplane_IpamPoolRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.IpamPoolRemove != nil {
-		dAtA[i] = 0x8a
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpamPoolRemove.Size()))
-		n17, err := m.IpamPoolRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n17
-	}
-	return i, nil
+
+type PolicyHit struct {
+	Tier string `protobuf:"bytes,1,opt,name=tier,proto3" json:"tier,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Whether the policy's verdict was enforced, rather than only logged in dry-run.
+	Enforced bool `protobuf:"varint,3,opt,name=enforced,proto3" json:"enforced,omitempty"`
+	// The number of requests the policy decided since the last report.
+	Count int64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
 }
-func (m *ToDataplane_HostMetadataRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.HostMetadataRemove != nil {
-		dAtA[i] = 0x92
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostMetadataRemove.Size()))
-		n18, err := m.HostMetadataRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n18
+
+func (m *PolicyHit) Reset()         { *m = PolicyHit{} }
+func (m *PolicyHit) String() string { return proto1.CompactTextString(m) }
+func (*PolicyHit) ProtoMessage()    {}
+func (*PolicyHit) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{53}
+}
+
+func (m *PolicyHit) GetTier() string {
+	if m != nil {
+		return m.Tier
 	}
-	return i, nil
+	return ""
 }
-func (m *ToDataplane_ServiceAccountUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.ServiceAccountUpdate != nil {
-		dAtA[i] = 0x9a
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ServiceAccountUpdate.Size()))
-		n19, err := m.ServiceAccountUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n19
+
+func (m *PolicyHit) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return i, nil
+	return ""
 }
-func (m *ToDataplane_ServiceAccountRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.ServiceAccountRemove != nil {
-		dAtA[i] = 0xa2
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ServiceAccountRemove.Size()))
-		n20, err := m.ServiceAccountRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n20
+
+func (m *PolicyHit) GetEnforced() bool {
+	if m != nil {
+		return m.Enforced
 	}
-	return i, nil
+	return false
 }
-func (m *ToDataplane_NamespaceUpdate) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.NamespaceUpdate != nil {
-		dAtA[i] = 0xaa
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NamespaceUpdate.Size()))
-		n21, err := m.NamespaceUpdate.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n21
+
+func (m *PolicyHit) GetCount() int64 {
+	if m != nil {
+		return m.Count
 	}
-	return i, nil
+	return 0
 }
-func (m *ToDataplane_NamespaceRemove) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.NamespaceRemove != nil {
-		dAtA[i] = 0xb2
-		i++
-		dAtA[i] = 0x1
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NamespaceRemove.Size()))
-		n22, err := m.NamespaceRemove.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n22
+
+type ReportResult struct {
+	Successful bool `protobuf:"varint,1,opt,name=successful,proto3" json:"successful,omitempty"`
+}
+
+func (m *ReportResult) Reset()         { *m = ReportResult{} }
+func (m *ReportResult) String() string { return proto1.CompactTextString(m) }
+func (*ReportResult) ProtoMessage()    {}
+func (*ReportResult) Descriptor() ([]byte, []int) {
+	return fileDescriptorFelixbackend, []int{53}
+}
+
+func (m *ReportResult) GetSuccessful() bool {
+	if m != nil {
+		return m.Successful
 	}
-	return i, nil
+	return false
 }
-func (m *FromDataplane) Marshal() (dAtA []byte, err error) {
+
+func init() {
+	proto1.RegisterType((*SyncRequest)(nil), "felix.SyncRequest")
+	proto1.RegisterType((*ToDataplane)(nil), "felix.ToDataplane")
+	proto1.RegisterType((*FromDataplane)(nil), "felix.FromDataplane")
+	proto1.RegisterType((*ConfigUpdate)(nil), "felix.ConfigUpdate")
+	proto1.RegisterType((*InSync)(nil), "felix.InSync")
+	proto1.RegisterType((*IPSetUpdate)(nil), "felix.IPSetUpdate")
+	proto1.RegisterType((*IPSetDeltaUpdate)(nil), "felix.IPSetDeltaUpdate")
+	proto1.RegisterType((*IPSetRemove)(nil), "felix.IPSetRemove")
+	proto1.RegisterType((*ActiveProfileUpdate)(nil), "felix.ActiveProfileUpdate")
+	proto1.RegisterType((*ActiveProfileRemove)(nil), "felix.ActiveProfileRemove")
+	proto1.RegisterType((*ProfileID)(nil), "felix.ProfileID")
+	proto1.RegisterType((*Profile)(nil), "felix.Profile")
+	proto1.RegisterType((*ActivePolicyUpdate)(nil), "felix.ActivePolicyUpdate")
+	proto1.RegisterType((*ActivePolicyRemove)(nil), "felix.ActivePolicyRemove")
+	proto1.RegisterType((*PolicyID)(nil), "felix.PolicyID")
+	proto1.RegisterType((*Policy)(nil), "felix.Policy")
+	proto1.RegisterType((*Rule)(nil), "felix.Rule")
+	proto1.RegisterType((*ServiceAccountMatch)(nil), "felix.ServiceAccountMatch")
+	proto1.RegisterType((*HTTPMatch)(nil), "felix.HTTPMatch")
+	proto1.RegisterType((*HTTPMatch_PathMatch)(nil), "felix.HTTPMatch.PathMatch")
+	proto1.RegisterType((*HTTPMatch_HeaderMatch)(nil), "felix.HTTPMatch.HeaderMatch")
+	proto1.RegisterType((*IcmpTypeAndCode)(nil), "felix.IcmpTypeAndCode")
+	proto1.RegisterType((*Protocol)(nil), "felix.Protocol")
+	proto1.RegisterType((*PortRange)(nil), "felix.PortRange")
+	proto1.RegisterType((*WorkloadEndpointID)(nil), "felix.WorkloadEndpointID")
+	proto1.RegisterType((*WorkloadEndpointUpdate)(nil), "felix.WorkloadEndpointUpdate")
+	proto1.RegisterType((*WorkloadEndpoint)(nil), "felix.WorkloadEndpoint")
+	proto1.RegisterType((*WorkloadEndpointRemove)(nil), "felix.WorkloadEndpointRemove")
+	proto1.RegisterType((*HostEndpointID)(nil), "felix.HostEndpointID")
+	proto1.RegisterType((*HostEndpointUpdate)(nil), "felix.HostEndpointUpdate")
+	proto1.RegisterType((*HostEndpoint)(nil), "felix.HostEndpoint")
+	proto1.RegisterType((*HostEndpointRemove)(nil), "felix.HostEndpointRemove")
+	proto1.RegisterType((*TierInfo)(nil), "felix.TierInfo")
+	proto1.RegisterType((*NatInfo)(nil), "felix.NatInfo")
+	proto1.RegisterType((*ProcessStatusUpdate)(nil), "felix.ProcessStatusUpdate")
+	proto1.RegisterType((*HostEndpointStatusUpdate)(nil), "felix.HostEndpointStatusUpdate")
+	proto1.RegisterType((*EndpointStatus)(nil), "felix.EndpointStatus")
+	proto1.RegisterType((*HostEndpointStatusRemove)(nil), "felix.HostEndpointStatusRemove")
+	proto1.RegisterType((*WorkloadEndpointStatusUpdate)(nil), "felix.WorkloadEndpointStatusUpdate")
+	proto1.RegisterType((*WorkloadEndpointStatusRemove)(nil), "felix.WorkloadEndpointStatusRemove")
+	proto1.RegisterType((*HostMetadataUpdate)(nil), "felix.HostMetadataUpdate")
+	proto1.RegisterType((*HostMetadataRemove)(nil), "felix.HostMetadataRemove")
+	proto1.RegisterType((*IPAMPoolUpdate)(nil), "felix.IPAMPoolUpdate")
+	proto1.RegisterType((*IPAMPoolRemove)(nil), "felix.IPAMPoolRemove")
+	proto1.RegisterType((*IPAMPool)(nil), "felix.IPAMPool")
+	proto1.RegisterType((*ServiceAccountUpdate)(nil), "felix.ServiceAccountUpdate")
+	proto1.RegisterType((*ServiceAccountRemove)(nil), "felix.ServiceAccountRemove")
+	proto1.RegisterType((*ServiceAccountID)(nil), "felix.ServiceAccountID")
+	proto1.RegisterType((*NamespaceUpdate)(nil), "felix.NamespaceUpdate")
+	proto1.RegisterType((*NamespaceRemove)(nil), "felix.NamespaceRemove")
+	proto1.RegisterType((*NamespaceID)(nil), "felix.NamespaceID")
+	proto1.RegisterType((*ServiceUpdate)(nil), "felix.ServiceUpdate")
+	proto1.RegisterType((*ServicePort)(nil), "felix.ServicePort")
+	proto1.RegisterType((*ServiceRemove)(nil), "felix.ServiceRemove")
+	proto1.RegisterType((*RuleMetadata)(nil), "felix.RuleMetadata")
+	proto1.RegisterType((*DataplaneStats)(nil), "felix.DataplaneStats")
+	proto1.RegisterType((*Statistic)(nil), "felix.Statistic")
+	proto1.RegisterType((*WAFRuleHit)(nil), "felix.WAFRuleHit")
+	proto1.RegisterType((*PolicyHit)(nil), "felix.PolicyHit")
+	proto1.RegisterType((*ReportResult)(nil), "felix.ReportResult")
+	proto1.RegisterEnum("felix.IPVersion", IPVersion_name, IPVersion_value)
+	proto1.RegisterEnum("felix.IPSetUpdate_IPSetType", IPSetUpdate_IPSetType_name, IPSetUpdate_IPSetType_value)
+	proto1.RegisterEnum("felix.Statistic_Direction", Statistic_Direction_name, Statistic_Direction_value)
+	proto1.RegisterEnum("felix.Statistic_Relativity", Statistic_Relativity_name, Statistic_Relativity_value)
+	proto1.RegisterEnum("felix.Statistic_Kind", Statistic_Kind_name, Statistic_Kind_value)
+	proto1.RegisterEnum("felix.Statistic_Action", Statistic_Action_name, Statistic_Action_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for PolicySync service
+
+type PolicySyncClient interface {
+	// On this API, only the following payloads will be sent:
+	//  - InSync
+	//  - IPSetUpdate
+	//  - IPSetDeltaUpdate
+	//  - IPSetRemove
+	//  - ActiveProfileUpdate
+	//  - ActiveProfileRemove
+	//  - ActivePolicyUpdate
+	//  - ActivePolicyRemove
+	//  - WorkloadEndpointUpdate
+	//  - WorkloadEndpointRemove
+	//  - ServiceAccountUpdate
+	//  - ServiceAccountRemove
+	//  - NamespaceUpdate
+	//  - NamespaceRemove
+	//  - ServiceUpdate
+	//  - ServiceRemove
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (PolicySync_SyncClient, error)
+	// Report dataplane statistics to Felix.
+	Report(ctx context.Context, in *DataplaneStats, opts ...grpc.CallOption) (*ReportResult, error)
+}
+
+type policySyncClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPolicySyncClient(cc *grpc.ClientConn) PolicySyncClient {
+	return &policySyncClient{cc}
+}
+
+func (c *policySyncClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (PolicySync_SyncClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_PolicySync_serviceDesc.Streams[0], c.cc, "/felix.PolicySync/Sync", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &policySyncSyncClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PolicySync_SyncClient interface {
+	Recv() (*ToDataplane, error)
+	grpc.ClientStream
+}
+
+type policySyncSyncClient struct {
+	grpc.ClientStream
+}
+
+func (x *policySyncSyncClient) Recv() (*ToDataplane, error) {
+	m := new(ToDataplane)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *policySyncClient) Report(ctx context.Context, in *DataplaneStats, opts ...grpc.CallOption) (*ReportResult, error) {
+	out := new(ReportResult)
+	err := grpc.Invoke(ctx, "/felix.PolicySync/Report", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for PolicySync service
+
+type PolicySyncServer interface {
+	// On this API, only the following payloads will be sent:
+	//  - InSync
+	//  - IPSetUpdate
+	//  - IPSetDeltaUpdate
+	//  - IPSetRemove
+	//  - ActiveProfileUpdate
+	//  - ActiveProfileRemove
+	//  - ActivePolicyUpdate
+	//  - ActivePolicyRemove
+	//  - WorkloadEndpointUpdate
+	//  - WorkloadEndpointRemove
+	//  - ServiceAccountUpdate
+	//  - ServiceAccountRemove
+	//  - NamespaceUpdate
+	//  - NamespaceRemove
+	//  - ServiceUpdate
+	//  - ServiceRemove
+	Sync(*SyncRequest, PolicySync_SyncServer) error
+	// Report dataplane statistics to Felix.
+	Report(context.Context, *DataplaneStats) (*ReportResult, error)
+}
+
+func RegisterPolicySyncServer(s *grpc.Server, srv PolicySyncServer) {
+	s.RegisterService(&_PolicySync_serviceDesc, srv)
+}
+
+func _PolicySync_Sync_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SyncRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PolicySyncServer).Sync(m, &policySyncSyncServer{stream})
+}
+
+type PolicySync_SyncServer interface {
+	Send(*ToDataplane) error
+	grpc.ServerStream
+}
+
+type policySyncSyncServer struct {
+	grpc.ServerStream
+}
+
+func (x *policySyncSyncServer) Send(m *ToDataplane) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PolicySync_Report_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataplaneStats)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PolicySyncServer).Report(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/felix.PolicySync/Report",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PolicySyncServer).Report(ctx, req.(*DataplaneStats))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PolicySync_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "felix.PolicySync",
+	HandlerType: (*PolicySyncServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Report",
+			Handler:    _PolicySync_Report_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Sync",
+			Handler:       _PolicySync_Sync_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "felixbackend.proto",
+}
+
+func (m *SyncRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3417,362 +3871,385 @@ func (m *FromDataplane) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *FromDataplane) MarshalTo(dAtA []byte) (int, error) {
+func (m *SyncRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ToDataplane) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ToDataplane) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	if m.Payload != nil {
-		nn23, err := m.Payload.MarshalTo(dAtA[i:])
+		nn1, err := m.Payload.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn23
+		i += nn1
 	}
 	if m.SequenceNumber != 0 {
-		dAtA[i] = 0x40
+		dAtA[i] = 0x78
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.SequenceNumber))
 	}
 	return i, nil
 }
 
-func (m *FromDataplane_ProcessStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *ToDataplane_InSync) MarshalTo(dAtA []byte) (int, error) {
 	i := 0
-	if m.ProcessStatusUpdate != nil {
-		dAtA[i] = 0x1a
+	if m.InSync != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ProcessStatusUpdate.Size()))
-		n24, err := m.ProcessStatusUpdate.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.InSync.Size()))
+		n2, err := m.InSync.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n24
+		i += n2
 	}
 	return i, nil
 }
-func (m *FromDataplane_HostEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *ToDataplane_IpsetUpdate) MarshalTo(dAtA []byte) (int, error) {
 	i := 0
-	if m.HostEndpointStatusUpdate != nil {
-		dAtA[i] = 0x22
+	if m.IpsetUpdate != nil {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointStatusUpdate.Size()))
-		n25, err := m.HostEndpointStatusUpdate.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpsetUpdate.Size()))
+		n3, err := m.IpsetUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n25
+		i += n3
 	}
 	return i, nil
 }
-func (m *FromDataplane_HostEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+func (m *ToDataplane_IpsetDeltaUpdate) MarshalTo(dAtA []byte) (int, error) {
 	i := 0
-	if m.HostEndpointStatusRemove != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointStatusRemove.Size()))
-		n26, err := m.HostEndpointStatusRemove.MarshalTo(dAtA[i:])
+	if m.IpsetDeltaUpdate != nil {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpsetDeltaUpdate.Size()))
+		n4, err := m.IpsetDeltaUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n26
+		i += n4
 	}
 	return i, nil
 }
-func (m *FromDataplane_WorkloadEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *ToDataplane_IpsetRemove) MarshalTo(dAtA []byte) (int, error) {
 	i := 0
-	if m.WorkloadEndpointStatusUpdate != nil {
-		dAtA[i] = 0x32
+	if m.IpsetRemove != nil {
+		dAtA[i] = 0x22
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointStatusUpdate.Size()))
-		n27, err := m.WorkloadEndpointStatusUpdate.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpsetRemove.Size()))
+		n5, err := m.IpsetRemove.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n27
+		i += n5
 	}
 	return i, nil
 }
-func (m *FromDataplane_WorkloadEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+func (m *ToDataplane_ActiveProfileUpdate) MarshalTo(dAtA []byte) (int, error) {
 	i := 0
-	if m.WorkloadEndpointStatusRemove != nil {
-		dAtA[i] = 0x3a
+	if m.ActiveProfileUpdate != nil {
+		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointStatusRemove.Size()))
-		n28, err := m.WorkloadEndpointStatusRemove.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ActiveProfileUpdate.Size()))
+		n6, err := m.ActiveProfileUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n28
+		i += n6
 	}
 	return i, nil
 }
-func (m *ConfigUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ToDataplane_ActiveProfileRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ActiveProfileRemove != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ActiveProfileRemove.Size()))
+		n7, err := m.ActiveProfileRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
 	}
-	return dAtA[:n], nil
+	return i, nil
 }
-
-func (m *ConfigUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Config) > 0 {
-		for k, _ := range m.Config {
-			dAtA[i] = 0xa
-			i++
-			v := m.Config[k]
-			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
-			i = encodeVarintFelixbackend(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
+func (m *ToDataplane_ActivePolicyUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ActivePolicyUpdate != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ActivePolicyUpdate.Size()))
+		n8, err := m.ActivePolicyUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n8
 	}
 	return i, nil
 }
-
-func (m *InSync) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ToDataplane_ActivePolicyRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ActivePolicyRemove != nil {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ActivePolicyRemove.Size()))
+		n9, err := m.ActivePolicyRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n9
 	}
-	return dAtA[:n], nil
-}
-
-func (m *InSync) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
 	return i, nil
 }
-
-func (m *IPSetUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ToDataplane_HostEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.HostEndpointUpdate != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointUpdate.Size()))
+		n10, err := m.HostEndpointUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
 	}
-	return dAtA[:n], nil
+	return i, nil
 }
-
-func (m *IPSetUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
+func (m *ToDataplane_HostEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.HostEndpointRemove != nil {
+		dAtA[i] = 0x52
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
-	}
-	if len(m.Members) > 0 {
-		for _, s := range m.Members {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointRemove.Size()))
+		n11, err := m.HostEndpointRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n11
 	}
-	if m.Type != 0 {
-		dAtA[i] = 0x18
+	return i, nil
+}
+func (m *ToDataplane_WorkloadEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.WorkloadEndpointUpdate != nil {
+		dAtA[i] = 0x5a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Type))
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointUpdate.Size()))
+		n12, err := m.WorkloadEndpointUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n12
 	}
 	return i, nil
 }
-
-func (m *IPSetDeltaUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ToDataplane_WorkloadEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.WorkloadEndpointRemove != nil {
+		dAtA[i] = 0x62
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointRemove.Size()))
+		n13, err := m.WorkloadEndpointRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n13
 	}
-	return dAtA[:n], nil
+	return i, nil
 }
-
-func (m *IPSetDeltaUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
+func (m *ToDataplane_ConfigUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ConfigUpdate != nil {
+		dAtA[i] = 0x6a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
-	}
-	if len(m.AddedMembers) > 0 {
-		for _, s := range m.AddedMembers {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ConfigUpdate.Size()))
+		n14, err := m.ConfigUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n14
 	}
-	if len(m.RemovedMembers) > 0 {
-		for _, s := range m.RemovedMembers {
-			dAtA[i] = 0x1a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+	return i, nil
+}
+func (m *ToDataplane_HostMetadataUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.HostMetadataUpdate != nil {
+		dAtA[i] = 0x72
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostMetadataUpdate.Size()))
+		n15, err := m.HostMetadataUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n15
 	}
 	return i, nil
 }
-
-func (m *IPSetRemove) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ToDataplane_IpamPoolUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.IpamPoolUpdate != nil {
+		dAtA[i] = 0x82
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpamPoolUpdate.Size()))
+		n16, err := m.IpamPoolUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n16
 	}
-	return dAtA[:n], nil
+	return i, nil
 }
-
-func (m *IPSetRemove) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
+func (m *ToDataplane_IpamPoolRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.IpamPoolRemove != nil {
+		dAtA[i] = 0x8a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpamPoolRemove.Size()))
+		n17, err := m.IpamPoolRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n17
 	}
 	return i, nil
 }
-
-func (m *ActiveProfileUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ToDataplane_HostMetadataRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.HostMetadataRemove != nil {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostMetadataRemove.Size()))
+		n18, err := m.HostMetadataRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n18
 	}
-	return dAtA[:n], nil
+	return i, nil
 }
-
-func (m *ActiveProfileUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
+func (m *ToDataplane_ServiceAccountUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ServiceAccountUpdate != nil {
+		dAtA[i] = 0x9a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n29, err := m.Id.MarshalTo(dAtA[i:])
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ServiceAccountUpdate.Size()))
+		n19, err := m.ServiceAccountUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n29
+		i += n19
 	}
-	if m.Profile != nil {
-		dAtA[i] = 0x12
+	return i, nil
+}
+func (m *ToDataplane_ServiceAccountRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ServiceAccountRemove != nil {
+		dAtA[i] = 0xa2
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Profile.Size()))
-		n30, err := m.Profile.MarshalTo(dAtA[i:])
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ServiceAccountRemove.Size()))
+		n20, err := m.ServiceAccountRemove.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n30
+		i += n20
 	}
 	return i, nil
 }
-
-func (m *ActiveProfileRemove) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ToDataplane_NamespaceUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.NamespaceUpdate != nil {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NamespaceUpdate.Size()))
+		n21, err := m.NamespaceUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n21
 	}
-	return dAtA[:n], nil
+	return i, nil
 }
-
-func (m *ActiveProfileRemove) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
+func (m *ToDataplane_NamespaceRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.NamespaceRemove != nil {
+		dAtA[i] = 0xb2
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n31, err := m.Id.MarshalTo(dAtA[i:])
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NamespaceRemove.Size()))
+		n22, err := m.NamespaceRemove.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n31
+		i += n22
 	}
 	return i, nil
 }
-
-func (m *ProfileID) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *ToDataplane_ServiceUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ServiceUpdate != nil {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ServiceUpdate.Size()))
+		n23, err := m.ServiceUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n23
 	}
-	return dAtA[:n], nil
+	return i, nil
 }
-
-func (m *ProfileID) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
+func (m *ToDataplane_ServiceRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ServiceRemove != nil {
+		dAtA[i] = 0xc2
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+		dAtA[i] = 0x1
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ServiceRemove.Size()))
+		n24, err := m.ServiceRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n24
 	}
 	return i, nil
 }
-
-func (m *Profile) Marshal() (dAtA []byte, err error) {
+func (m *FromDataplane) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3782,77 +4259,97 @@ func (m *Profile) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Profile) MarshalTo(dAtA []byte) (int, error) {
+func (m *FromDataplane) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.InboundRules) > 0 {
-		for _, msg := range m.InboundRules {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+	if m.Payload != nil {
+		nn23, err := m.Payload.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += nn23
 	}
-	if len(m.OutboundRules) > 0 {
-		for _, msg := range m.OutboundRules {
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if m.SequenceNumber != 0 {
+		dAtA[i] = 0x40
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.SequenceNumber))
 	}
 	return i, nil
 }
 
-func (m *ActivePolicyUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *ActivePolicyUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
+func (m *FromDataplane_ProcessStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.ProcessStatusUpdate != nil {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n32, err := m.Id.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.ProcessStatusUpdate.Size()))
+		n24, err := m.ProcessStatusUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n32
+		i += n24
 	}
-	if m.Policy != nil {
-		dAtA[i] = 0x12
+	return i, nil
+}
+func (m *FromDataplane_HostEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.HostEndpointStatusUpdate != nil {
+		dAtA[i] = 0x22
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Policy.Size()))
-		n33, err := m.Policy.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointStatusUpdate.Size()))
+		n25, err := m.HostEndpointStatusUpdate.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n33
+		i += n25
 	}
 	return i, nil
 }
-
-func (m *ActivePolicyRemove) Marshal() (dAtA []byte, err error) {
+func (m *FromDataplane_HostEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.HostEndpointStatusRemove != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HostEndpointStatusRemove.Size()))
+		n26, err := m.HostEndpointStatusRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n26
+	}
+	return i, nil
+}
+func (m *FromDataplane_WorkloadEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.WorkloadEndpointStatusUpdate != nil {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointStatusUpdate.Size()))
+		n27, err := m.WorkloadEndpointStatusUpdate.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
+	}
+	return i, nil
+}
+func (m *FromDataplane_WorkloadEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.WorkloadEndpointStatusRemove != nil {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.WorkloadEndpointStatusRemove.Size()))
+		n28, err := m.WorkloadEndpointStatusRemove.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
+	}
+	return i, nil
+}
+func (m *ConfigUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3862,25 +4359,32 @@ func (m *ActivePolicyRemove) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ActivePolicyRemove) MarshalTo(dAtA []byte) (int, error) {
+func (m *ConfigUpdate) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n34, err := m.Id.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Config) > 0 {
+		for k, _ := range m.Config {
+			dAtA[i] = 0xa
+			i++
+			v := m.Config[k]
+			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
+			i = encodeVarintFelixbackend(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
 		}
-		i += n34
 	}
 	return i, nil
 }
 
-func (m *PolicyID) Marshal() (dAtA []byte, err error) {
+func (m *InSync) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3890,27 +4394,15 @@ func (m *PolicyID) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PolicyID) MarshalTo(dAtA []byte) (int, error) {
+func (m *InSync) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Tier) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Tier)))
-		i += copy(dAtA[i:], m.Tier)
-	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
 	return i, nil
 }
 
-func (m *Policy) Marshal() (dAtA []byte, err error) {
+func (m *IPSetUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3920,65 +4412,41 @@ func (m *Policy) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Policy) MarshalTo(dAtA []byte) (int, error) {
+func (m *IPSetUpdate) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.InboundRules) > 0 {
-		for _, msg := range m.InboundRules {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
 	}
-	if len(m.OutboundRules) > 0 {
-		for _, msg := range m.OutboundRules {
+	if len(m.Members) > 0 {
+		for _, s := range m.Members {
 			dAtA[i] = 0x12
 			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
 			}
-			i += n
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if m.Untracked {
+	if m.Type != 0 {
 		dAtA[i] = 0x18
 		i++
-		if m.Untracked {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if m.PreDnat {
-		dAtA[i] = 0x20
-		i++
-		if m.PreDnat {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	if len(m.Namespace) > 0 {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Namespace)))
-		i += copy(dAtA[i:], m.Namespace)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Type))
 	}
 	return i, nil
 }
 
-func (m *Rule) Marshal() (dAtA []byte, err error) {
+func (m *IPSetDeltaUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -3988,35 +4456,20 @@ func (m *Rule) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
+func (m *IPSetDeltaUpdate) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Action) > 0 {
+	if len(m.Id) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Action)))
-		i += copy(dAtA[i:], m.Action)
-	}
-	if m.IpVersion != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpVersion))
-	}
-	if m.Protocol != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Protocol.Size()))
-		n35, err := m.Protocol.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n35
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
 	}
-	if len(m.SrcNet) > 0 {
-		for _, s := range m.SrcNet {
-			dAtA[i] = 0x22
+	if len(m.AddedMembers) > 0 {
+		for _, s := range m.AddedMembers {
+			dAtA[i] = 0x12
 			i++
 			l = len(s)
 			for l >= 1<<7 {
@@ -4029,21 +4482,9 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.SrcPorts) > 0 {
-		for _, msg := range m.SrcPorts {
-			dAtA[i] = 0x2a
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if len(m.DstNet) > 0 {
-		for _, s := range m.DstNet {
-			dAtA[i] = 0x32
+	if len(m.RemovedMembers) > 0 {
+		for _, s := range m.RemovedMembers {
+			dAtA[i] = 0x1a
 			i++
 			l = len(s)
 			for l >= 1<<7 {
@@ -4056,119 +4497,141 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.DstPorts) > 0 {
-		for _, msg := range m.DstPorts {
-			dAtA[i] = 0x3a
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
+	return i, nil
+}
+
+func (m *IPSetRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.Icmp != nil {
-		nn36, err := m.Icmp.MarshalTo(dAtA[i:])
+	return dAtA[:n], nil
+}
+
+func (m *IPSetRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	return i, nil
+}
+
+func (m *ActiveProfileUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActiveProfileUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n29, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn36
+		i += n29
 	}
-	if len(m.SrcIpSetIds) > 0 {
-		for _, s := range m.SrcIpSetIds {
-			dAtA[i] = 0x52
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.DstIpSetIds) > 0 {
-		for _, s := range m.DstIpSetIds {
-			dAtA[i] = 0x5a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.SrcNamedPortIpSetIds) > 0 {
-		for _, s := range m.SrcNamedPortIpSetIds {
-			dAtA[i] = 0x62
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+	if m.Profile != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Profile.Size()))
+		n30, err := m.Profile.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n30
 	}
-	if len(m.DstNamedPortIpSetIds) > 0 {
-		for _, s := range m.DstNamedPortIpSetIds {
-			dAtA[i] = 0x6a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return i, nil
+}
+
+func (m *ActiveProfileRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if m.NotProtocol != nil {
-		dAtA[i] = 0xb2
-		i++
-		dAtA[i] = 0x6
+	return dAtA[:n], nil
+}
+
+func (m *ActiveProfileRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Id != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NotProtocol.Size()))
-		n37, err := m.NotProtocol.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n31, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n37
+		i += n31
 	}
-	if len(m.NotSrcNet) > 0 {
-		for _, s := range m.NotSrcNet {
-			dAtA[i] = 0xba
-			i++
-			dAtA[i] = 0x6
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	return i, nil
+}
+
+func (m *ProfileID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.NotSrcPorts) > 0 {
-		for _, msg := range m.NotSrcPorts {
-			dAtA[i] = 0xc2
-			i++
-			dAtA[i] = 0x6
+	return dAtA[:n], nil
+}
+
+func (m *ProfileID) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
+}
+
+func (m *Profile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Profile) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.InboundRules) > 0 {
+		for _, msg := range m.InboundRules {
+			dAtA[i] = 0xa
 			i++
 			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -4178,28 +4641,9 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if len(m.NotDstNet) > 0 {
-		for _, s := range m.NotDstNet {
-			dAtA[i] = 0xca
-			i++
-			dAtA[i] = 0x6
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.NotDstPorts) > 0 {
-		for _, msg := range m.NotDstPorts {
-			dAtA[i] = 0xd2
-			i++
-			dAtA[i] = 0x6
+	if len(m.OutboundRules) > 0 {
+		for _, msg := range m.OutboundRules {
+			dAtA[i] = 0x12
 			i++
 			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -4209,223 +4653,76 @@ func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if m.NotIcmp != nil {
-		nn38, err := m.NotIcmp.MarshalTo(dAtA[i:])
+	return i, nil
+}
+
+func (m *ActivePolicyUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActivePolicyUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n32, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += nn38
-	}
-	if len(m.NotSrcIpSetIds) > 0 {
-		for _, s := range m.NotSrcIpSetIds {
-			dAtA[i] = 0xea
-			i++
-			dAtA[i] = 0x6
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+		i += n32
 	}
-	if len(m.NotDstIpSetIds) > 0 {
-		for _, s := range m.NotDstIpSetIds {
-			dAtA[i] = 0xf2
-			i++
-			dAtA[i] = 0x6
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+	if m.Policy != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Policy.Size()))
+		n33, err := m.Policy.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
-	}
-	if len(m.NotSrcNamedPortIpSetIds) > 0 {
-		for _, s := range m.NotSrcNamedPortIpSetIds {
-			dAtA[i] = 0x82
-			i++
-			dAtA[i] = 0x7
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.NotDstNamedPortIpSetIds) > 0 {
-		for _, s := range m.NotDstNamedPortIpSetIds {
-			dAtA[i] = 0x8a
-			i++
-			dAtA[i] = 0x7
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
-	}
-	if len(m.OriginalSrcSelector) > 0 {
-		dAtA[i] = 0x92
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalSrcSelector)))
-		i += copy(dAtA[i:], m.OriginalSrcSelector)
-	}
-	if len(m.OriginalDstSelector) > 0 {
-		dAtA[i] = 0x9a
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalDstSelector)))
-		i += copy(dAtA[i:], m.OriginalDstSelector)
-	}
-	if len(m.OriginalSrcNamespaceSelector) > 0 {
-		dAtA[i] = 0xa2
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalSrcNamespaceSelector)))
-		i += copy(dAtA[i:], m.OriginalSrcNamespaceSelector)
-	}
-	if len(m.OriginalDstNamespaceSelector) > 0 {
-		dAtA[i] = 0xaa
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalDstNamespaceSelector)))
-		i += copy(dAtA[i:], m.OriginalDstNamespaceSelector)
-	}
-	if len(m.OriginalNotSrcSelector) > 0 {
-		dAtA[i] = 0xb2
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalNotSrcSelector)))
-		i += copy(dAtA[i:], m.OriginalNotSrcSelector)
-	}
-	if len(m.OriginalNotDstSelector) > 0 {
-		dAtA[i] = 0xba
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalNotDstSelector)))
-		i += copy(dAtA[i:], m.OriginalNotDstSelector)
-	}
-	if m.SrcServiceAccountMatch != nil {
-		dAtA[i] = 0xc2
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.SrcServiceAccountMatch.Size()))
-		n39, err := m.SrcServiceAccountMatch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n39
-	}
-	if m.DstServiceAccountMatch != nil {
-		dAtA[i] = 0xca
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.DstServiceAccountMatch.Size()))
-		n40, err := m.DstServiceAccountMatch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n40
-	}
-	if m.HttpMatch != nil {
-		dAtA[i] = 0xd2
-		i++
-		dAtA[i] = 0x7
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HttpMatch.Size()))
-		n41, err := m.HttpMatch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n41
-	}
-	if len(m.RuleId) > 0 {
-		dAtA[i] = 0xca
-		i++
-		dAtA[i] = 0xc
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.RuleId)))
-		i += copy(dAtA[i:], m.RuleId)
+		i += n33
 	}
 	return i, nil
 }
 
-func (m *Rule_IcmpType) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	dAtA[i] = 0x40
-	i++
-	i = encodeVarintFelixbackend(dAtA, i, uint64(m.IcmpType))
-	return i, nil
-}
-func (m *Rule_IcmpTypeCode) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.IcmpTypeCode != nil {
-		dAtA[i] = 0x4a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IcmpTypeCode.Size()))
-		n42, err := m.IcmpTypeCode.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n42
+func (m *ActivePolicyRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return i, nil
-}
-func (m *Rule_NotIcmpType) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	dAtA[i] = 0xd8
-	i++
-	dAtA[i] = 0x6
-	i++
-	i = encodeVarintFelixbackend(dAtA, i, uint64(m.NotIcmpType))
-	return i, nil
+	return dAtA[:n], nil
 }
-func (m *Rule_NotIcmpTypeCode) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	if m.NotIcmpTypeCode != nil {
-		dAtA[i] = 0xe2
-		i++
-		dAtA[i] = 0x6
+
+func (m *ActivePolicyRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Id != nil {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NotIcmpTypeCode.Size()))
-		n43, err := m.NotIcmpTypeCode.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n34, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n43
+		i += n34
 	}
 	return i, nil
 }
-func (m *ServiceAccountMatch) Marshal() (dAtA []byte, err error) {
+
+func (m *PolicyID) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -4435,36 +4732,27 @@ func (m *ServiceAccountMatch) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ServiceAccountMatch) MarshalTo(dAtA []byte) (int, error) {
+func (m *PolicyID) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Selector) > 0 {
+	if len(m.Tier) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Selector)))
-		i += copy(dAtA[i:], m.Selector)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Tier)))
+		i += copy(dAtA[i:], m.Tier)
 	}
-	if len(m.Names) > 0 {
-		for _, s := range m.Names {
-			dAtA[i] = 0x12
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
 	return i, nil
 }
 
-func (m *HTTPMatch) Marshal() (dAtA []byte, err error) {
+func (m *Policy) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -4474,28 +4762,25 @@ func (m *HTTPMatch) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HTTPMatch) MarshalTo(dAtA []byte) (int, error) {
+func (m *Policy) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Methods) > 0 {
-		for _, s := range m.Methods {
+	if len(m.InboundRules) > 0 {
+		for _, msg := range m.InboundRules {
 			dAtA[i] = 0xa
 			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+			i += n
 		}
 	}
-	if len(m.Paths) > 0 {
-		for _, msg := range m.Paths {
+	if len(m.OutboundRules) > 0 {
+		for _, msg := range m.OutboundRules {
 			dAtA[i] = 0x12
 			i++
 			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
@@ -4506,119 +4791,36 @@ func (m *HTTPMatch) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	return i, nil
-}
-
-func (m *HTTPMatch_PathMatch) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if m.Untracked {
+		dAtA[i] = 0x18
+		i++
+		if m.Untracked {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HTTPMatch_PathMatch) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.PathMatch != nil {
-		nn44, err := m.PathMatch.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if m.PreDnat {
+		dAtA[i] = 0x20
+		i++
+		if m.PreDnat {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-		i += nn44
-	}
-	return i, nil
-}
-
-func (m *HTTPMatch_PathMatch_Exact) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	dAtA[i] = 0xa
-	i++
-	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Exact)))
-	i += copy(dAtA[i:], m.Exact)
-	return i, nil
-}
-func (m *HTTPMatch_PathMatch_Prefix) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Prefix)))
-	i += copy(dAtA[i:], m.Prefix)
-	return i, nil
-}
-func (m *IcmpTypeAndCode) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *IcmpTypeAndCode) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Type != 0 {
-		dAtA[i] = 0x8
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Type))
 	}
-	if m.Code != 0 {
-		dAtA[i] = 0x10
+	if len(m.Namespace) > 0 {
+		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Code))
-	}
-	return i, nil
-}
-
-func (m *Protocol) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *Protocol) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.NumberOrName != nil {
-		nn45, err := m.NumberOrName.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += nn45
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Namespace)))
+		i += copy(dAtA[i:], m.Namespace)
 	}
 	return i, nil
 }
 
-func (m *Protocol_Number) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	dAtA[i] = 0x8
-	i++
-	i = encodeVarintFelixbackend(dAtA, i, uint64(m.Number))
-	return i, nil
-}
-func (m *Protocol_Name) MarshalTo(dAtA []byte) (int, error) {
-	i := 0
-	dAtA[i] = 0x12
-	i++
-	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
-	i += copy(dAtA[i:], m.Name)
-	return i, nil
-}
-func (m *PortRange) Marshal() (dAtA []byte, err error) {
+func (m *Rule) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -4628,133 +4830,34 @@ func (m *PortRange) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *PortRange) MarshalTo(dAtA []byte) (int, error) {
+func (m *Rule) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.First != 0 {
-		dAtA[i] = 0x8
+	if len(m.Action) > 0 {
+		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.First))
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Action)))
+		i += copy(dAtA[i:], m.Action)
 	}
-	if m.Last != 0 {
+	if m.IpVersion != 0 {
 		dAtA[i] = 0x10
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Last))
-	}
-	return i, nil
-}
-
-func (m *WorkloadEndpointID) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *WorkloadEndpointID) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.OrchestratorId) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OrchestratorId)))
-		i += copy(dAtA[i:], m.OrchestratorId)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IpVersion))
 	}
-	if len(m.WorkloadId) > 0 {
+	if m.Protocol != nil {
 		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.WorkloadId)))
-		i += copy(dAtA[i:], m.WorkloadId)
-	}
-	if len(m.EndpointId) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.EndpointId)))
-		i += copy(dAtA[i:], m.EndpointId)
-	}
-	return i, nil
-}
-
-func (m *WorkloadEndpointUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *WorkloadEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n46, err := m.Id.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n46
-	}
-	if m.Endpoint != nil {
-		dAtA[i] = 0x2a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Endpoint.Size()))
-		n47, err := m.Endpoint.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Protocol.Size()))
+		n35, err := m.Protocol.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n47
-	}
-	return i, nil
-}
-
-func (m *WorkloadEndpoint) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *WorkloadEndpoint) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.State) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.State)))
-		i += copy(dAtA[i:], m.State)
-	}
-	if len(m.Name) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
-	if len(m.Mac) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Mac)))
-		i += copy(dAtA[i:], m.Mac)
+		i += n35
 	}
-	if len(m.ProfileIds) > 0 {
-		for _, s := range m.ProfileIds {
+	if len(m.SrcNet) > 0 {
+		for _, s := range m.SrcNet {
 			dAtA[i] = 0x22
 			i++
 			l = len(s)
@@ -4768,23 +4871,20 @@ func (m *WorkloadEndpoint) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.Ipv4Nets) > 0 {
-		for _, s := range m.Ipv4Nets {
+	if len(m.SrcPorts) > 0 {
+		for _, msg := range m.SrcPorts {
 			dAtA[i] = 0x2a
 			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
 			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+			i += n
 		}
 	}
-	if len(m.Ipv6Nets) > 0 {
-		for _, s := range m.Ipv6Nets {
+	if len(m.DstNet) > 0 {
+		for _, s := range m.DstNet {
 			dAtA[i] = 0x32
 			i++
 			l = len(s)
@@ -4798,8 +4898,8 @@ func (m *WorkloadEndpoint) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.Tiers) > 0 {
-		for _, msg := range m.Tiers {
+	if len(m.DstPorts) > 0 {
+		for _, msg := range m.DstPorts {
 			dAtA[i] = 0x3a
 			i++
 			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
@@ -4810,147 +4910,31 @@ func (m *WorkloadEndpoint) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if len(m.Ipv4Nat) > 0 {
-		for _, msg := range m.Ipv4Nat {
-			dAtA[i] = 0x42
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	if len(m.Ipv6Nat) > 0 {
-		for _, msg := range m.Ipv6Nat {
-			dAtA[i] = 0x4a
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
-	return i, nil
-}
-
-func (m *WorkloadEndpointRemove) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *WorkloadEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n48, err := m.Id.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n48
-	}
-	return i, nil
-}
-
-func (m *HostEndpointID) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *HostEndpointID) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.EndpointId) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.EndpointId)))
-		i += copy(dAtA[i:], m.EndpointId)
-	}
-	return i, nil
-}
-
-func (m *HostEndpointUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *HostEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n49, err := m.Id.MarshalTo(dAtA[i:])
+	if m.Icmp != nil {
+		nn36, err := m.Icmp.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n49
+		i += nn36
 	}
-	if m.Endpoint != nil {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Endpoint.Size()))
-		n50, err := m.Endpoint.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.SrcIpSetIds) > 0 {
+		for _, s := range m.SrcIpSetIds {
+			dAtA[i] = 0x52
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
-		i += n50
-	}
-	return i, nil
-}
-
-func (m *HostEndpoint) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *HostEndpoint) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
-	}
-	if len(m.ProfileIds) > 0 {
-		for _, s := range m.ProfileIds {
-			dAtA[i] = 0x12
+	if len(m.DstIpSetIds) > 0 {
+		for _, s := range m.DstIpSetIds {
+			dAtA[i] = 0x5a
 			i++
 			l = len(s)
 			for l >= 1<<7 {
@@ -4963,21 +4947,24 @@ func (m *HostEndpoint) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.Tiers) > 0 {
-		for _, msg := range m.Tiers {
-			dAtA[i] = 0x1a
+	if len(m.SrcNamedPortIpSetIds) > 0 {
+		for _, s := range m.SrcNamedPortIpSetIds {
+			dAtA[i] = 0x62
 			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
 			}
-			i += n
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.ExpectedIpv4Addrs) > 0 {
-		for _, s := range m.ExpectedIpv4Addrs {
-			dAtA[i] = 0x22
+	if len(m.DstNamedPortIpSetIds) > 0 {
+		for _, s := range m.DstNamedPortIpSetIds {
+			dAtA[i] = 0x6a
 			i++
 			l = len(s)
 			for l >= 1<<7 {
@@ -4990,9 +4977,23 @@ func (m *HostEndpoint) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.ExpectedIpv6Addrs) > 0 {
-		for _, s := range m.ExpectedIpv6Addrs {
-			dAtA[i] = 0x2a
+	if m.NotProtocol != nil {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NotProtocol.Size()))
+		n37, err := m.NotProtocol.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n37
+	}
+	if len(m.NotSrcNet) > 0 {
+		for _, s := range m.NotSrcNet {
+			dAtA[i] = 0xba
+			i++
+			dAtA[i] = 0x6
 			i++
 			l = len(s)
 			for l >= 1<<7 {
@@ -5005,9 +5006,11 @@ func (m *HostEndpoint) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.UntrackedTiers) > 0 {
-		for _, msg := range m.UntrackedTiers {
-			dAtA[i] = 0x32
+	if len(m.NotSrcPorts) > 0 {
+		for _, msg := range m.NotSrcPorts {
+			dAtA[i] = 0xc2
+			i++
+			dAtA[i] = 0x6
 			i++
 			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -5017,21 +5020,28 @@ func (m *HostEndpoint) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	if len(m.PreDnatTiers) > 0 {
-		for _, msg := range m.PreDnatTiers {
-			dAtA[i] = 0x3a
+	if len(m.NotDstNet) > 0 {
+		for _, s := range m.NotDstNet {
+			dAtA[i] = 0xca
 			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
+			dAtA[i] = 0x6
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
 			}
-			i += n
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.ForwardTiers) > 0 {
-		for _, msg := range m.ForwardTiers {
-			dAtA[i] = 0x42
+	if len(m.NotDstPorts) > 0 {
+		for _, msg := range m.NotDstPorts {
+			dAtA[i] = 0xd2
+			i++
+			dAtA[i] = 0x6
 			i++
 			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
 			n, err := msg.MarshalTo(dAtA[i:])
@@ -5041,61 +5051,52 @@ func (m *HostEndpoint) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
-	return i, nil
-}
-
-func (m *HostEndpointRemove) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *HostEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n51, err := m.Id.MarshalTo(dAtA[i:])
+	if m.NotIcmp != nil {
+		nn38, err := m.NotIcmp.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n51
+		i += nn38
 	}
-	return i, nil
-}
-
-func (m *TierInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if len(m.NotSrcIpSetIds) > 0 {
+		for _, s := range m.NotSrcIpSetIds {
+			dAtA[i] = 0xea
+			i++
+			dAtA[i] = 0x6
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *TierInfo) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Name) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+	if len(m.NotDstIpSetIds) > 0 {
+		for _, s := range m.NotDstIpSetIds {
+			dAtA[i] = 0xf2
+			i++
+			dAtA[i] = 0x6
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	if len(m.IngressPolicies) > 0 {
-		for _, s := range m.IngressPolicies {
-			dAtA[i] = 0x12
+	if len(m.NotSrcNamedPortIpSetIds) > 0 {
+		for _, s := range m.NotSrcNamedPortIpSetIds {
+			dAtA[i] = 0x82
+			i++
+			dAtA[i] = 0x7
 			i++
 			l = len(s)
 			for l >= 1<<7 {
@@ -5108,9 +5109,11 @@ func (m *TierInfo) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.EgressPolicies) > 0 {
-		for _, s := range m.EgressPolicies {
-			dAtA[i] = 0x1a
+	if len(m.NotDstNamedPortIpSetIds) > 0 {
+		for _, s := range m.NotDstNamedPortIpSetIds {
+			dAtA[i] = 0x8a
+			i++
+			dAtA[i] = 0x7
 			i++
 			l = len(s)
 			for l >= 1<<7 {
@@ -5123,10 +5126,160 @@ func (m *TierInfo) MarshalTo(dAtA []byte) (int, error) {
 			i += copy(dAtA[i:], s)
 		}
 	}
+	if len(m.OriginalSrcSelector) > 0 {
+		dAtA[i] = 0x92
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalSrcSelector)))
+		i += copy(dAtA[i:], m.OriginalSrcSelector)
+	}
+	if len(m.OriginalDstSelector) > 0 {
+		dAtA[i] = 0x9a
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalDstSelector)))
+		i += copy(dAtA[i:], m.OriginalDstSelector)
+	}
+	if len(m.OriginalSrcNamespaceSelector) > 0 {
+		dAtA[i] = 0xa2
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalSrcNamespaceSelector)))
+		i += copy(dAtA[i:], m.OriginalSrcNamespaceSelector)
+	}
+	if len(m.OriginalDstNamespaceSelector) > 0 {
+		dAtA[i] = 0xaa
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalDstNamespaceSelector)))
+		i += copy(dAtA[i:], m.OriginalDstNamespaceSelector)
+	}
+	if len(m.OriginalNotSrcSelector) > 0 {
+		dAtA[i] = 0xb2
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalNotSrcSelector)))
+		i += copy(dAtA[i:], m.OriginalNotSrcSelector)
+	}
+	if len(m.OriginalNotDstSelector) > 0 {
+		dAtA[i] = 0xba
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OriginalNotDstSelector)))
+		i += copy(dAtA[i:], m.OriginalNotDstSelector)
+	}
+	if m.SrcServiceAccountMatch != nil {
+		dAtA[i] = 0xc2
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.SrcServiceAccountMatch.Size()))
+		n39, err := m.SrcServiceAccountMatch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n39
+	}
+	if m.DstServiceAccountMatch != nil {
+		dAtA[i] = 0xca
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.DstServiceAccountMatch.Size()))
+		n40, err := m.DstServiceAccountMatch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n40
+	}
+	if m.HttpMatch != nil {
+		dAtA[i] = 0xd2
+		i++
+		dAtA[i] = 0x7
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.HttpMatch.Size()))
+		n41, err := m.HttpMatch.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n41
+	}
+	if len(m.RuleId) > 0 {
+		dAtA[i] = 0xca
+		i++
+		dAtA[i] = 0xc
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.RuleId)))
+		i += copy(dAtA[i:], m.RuleId)
+	}
+	if m.Metadata != nil {
+		dAtA[i] = 0xd2
+		i++
+		dAtA[i] = 0xc
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Metadata.Size()))
+		n63, err := m.Metadata.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n63
+	}
 	return i, nil
 }
 
-func (m *NatInfo) Marshal() (dAtA []byte, err error) {
+func (m *Rule_IcmpType) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x40
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(m.IcmpType))
+	return i, nil
+}
+func (m *Rule_IcmpTypeCode) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.IcmpTypeCode != nil {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.IcmpTypeCode.Size()))
+		n42, err := m.IcmpTypeCode.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n42
+	}
+	return i, nil
+}
+func (m *Rule_NotIcmpType) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0xd8
+	i++
+	dAtA[i] = 0x6
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(m.NotIcmpType))
+	return i, nil
+}
+func (m *Rule_NotIcmpTypeCode) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	if m.NotIcmpTypeCode != nil {
+		dAtA[i] = 0xe2
+		i++
+		dAtA[i] = 0x6
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NotIcmpTypeCode.Size()))
+		n43, err := m.NotIcmpTypeCode.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n43
+	}
+	return i, nil
+}
+func (m *ServiceAccountMatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5136,27 +5289,36 @@ func (m *NatInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NatInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *ServiceAccountMatch) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.ExtIp) > 0 {
+	if len(m.Selector) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.ExtIp)))
-		i += copy(dAtA[i:], m.ExtIp)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Selector)))
+		i += copy(dAtA[i:], m.Selector)
 	}
-	if len(m.IntIp) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.IntIp)))
-		i += copy(dAtA[i:], m.IntIp)
+	if len(m.Names) > 0 {
+		for _, s := range m.Names {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
 	return i, nil
 }
 
-func (m *ProcessStatusUpdate) Marshal() (dAtA []byte, err error) {
+func (m *HTTPMatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5166,27 +5328,54 @@ func (m *ProcessStatusUpdate) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ProcessStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *HTTPMatch) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.IsoTimestamp) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.IsoTimestamp)))
-		i += copy(dAtA[i:], m.IsoTimestamp)
-	}
-	if m.Uptime != 0 {
-		dAtA[i] = 0x11
-		i++
-		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Uptime))))
-		i += 8
+	if len(m.Methods) > 0 {
+		for _, s := range m.Methods {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Paths) > 0 {
+		for _, msg := range m.Paths {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Headers) > 0 {
+		for _, msg := range m.Headers {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	return i, nil
 }
 
-func (m *HostEndpointStatusUpdate) Marshal() (dAtA []byte, err error) {
+func (m *HTTPMatch_PathMatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5196,59 +5385,46 @@ func (m *HostEndpointStatusUpdate) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HostEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *HTTPMatch_PathMatch) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n52, err := m.Id.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n52
-	}
-	if m.Status != nil {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Status.Size()))
-		n53, err := m.Status.MarshalTo(dAtA[i:])
+	if m.PathMatch != nil {
+		nn44, err := m.PathMatch.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n53
+		i += nn44
 	}
 	return i, nil
 }
 
-func (m *EndpointStatus) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *HTTPMatch_PathMatch_Exact) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Exact)))
+	i += copy(dAtA[i:], m.Exact)
+	return i, nil
 }
-
-func (m *EndpointStatus) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Status) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Status)))
-		i += copy(dAtA[i:], m.Status)
-	}
+func (m *HTTPMatch_PathMatch_Prefix) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Prefix)))
+	i += copy(dAtA[i:], m.Prefix)
 	return i, nil
 }
-
-func (m *HostEndpointStatusRemove) Marshal() (dAtA []byte, err error) {
+func (m *HTTPMatch_PathMatch_Regex) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x1a
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Regex)))
+	i += copy(dAtA[i:], m.Regex)
+	return i, nil
+}
+func (m *HTTPMatch_HeaderMatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5258,25 +5434,56 @@ func (m *HostEndpointStatusRemove) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HostEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+func (m *HTTPMatch_HeaderMatch) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Id != nil {
+	if len(m.Name) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n54, err := m.Id.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.HeaderMatch != nil {
+		nn45, err := m.HeaderMatch.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n54
+		i += nn45
 	}
 	return i, nil
 }
 
-func (m *WorkloadEndpointStatusUpdate) Marshal() (dAtA []byte, err error) {
+func (m *HTTPMatch_HeaderMatch_Exact) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Exact)))
+	i += copy(dAtA[i:], m.Exact)
+	return i, nil
+}
+func (m *HTTPMatch_HeaderMatch_Present) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x18
+	i++
+	if m.Present {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
+	return i, nil
+}
+func (m *HTTPMatch_HeaderMatch_Prefix) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x22
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Prefix)))
+	i += copy(dAtA[i:], m.Prefix)
+	return i, nil
+}
+func (m *IcmpTypeAndCode) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5286,35 +5493,25 @@ func (m *WorkloadEndpointStatusUpdate) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *WorkloadEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *IcmpTypeAndCode) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
+	if m.Type != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n55, err := m.Id.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n55
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Type))
 	}
-	if m.Status != nil {
-		dAtA[i] = 0x12
+	if m.Code != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Status.Size()))
-		n56, err := m.Status.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n56
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Code))
 	}
 	return i, nil
 }
 
-func (m *WorkloadEndpointStatusRemove) Marshal() (dAtA []byte, err error) {
+func (m *Protocol) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5324,25 +5521,37 @@ func (m *WorkloadEndpointStatusRemove) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *WorkloadEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+func (m *Protocol) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n57, err := m.Id.MarshalTo(dAtA[i:])
+	if m.NumberOrName != nil {
+		nn45, err := m.NumberOrName.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n57
+		i += nn45
 	}
 	return i, nil
 }
 
-func (m *HostMetadataUpdate) Marshal() (dAtA []byte, err error) {
+func (m *Protocol_Number) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x8
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(m.Number))
+	return i, nil
+}
+func (m *Protocol_Name) MarshalTo(dAtA []byte) (int, error) {
+	i := 0
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+	i += copy(dAtA[i:], m.Name)
+	return i, nil
+}
+func (m *PortRange) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5352,27 +5561,25 @@ func (m *HostMetadataUpdate) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HostMetadataUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *PortRange) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Hostname) > 0 {
-		dAtA[i] = 0xa
+	if m.First != 0 {
+		dAtA[i] = 0x8
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Hostname)))
-		i += copy(dAtA[i:], m.Hostname)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.First))
 	}
-	if len(m.Ipv4Addr) > 0 {
-		dAtA[i] = 0x12
+	if m.Last != 0 {
+		dAtA[i] = 0x10
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Ipv4Addr)))
-		i += copy(dAtA[i:], m.Ipv4Addr)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Last))
 	}
 	return i, nil
 }
 
-func (m *HostMetadataRemove) Marshal() (dAtA []byte, err error) {
+func (m *WorkloadEndpointID) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5382,27 +5589,33 @@ func (m *HostMetadataRemove) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *HostMetadataRemove) MarshalTo(dAtA []byte) (int, error) {
+func (m *WorkloadEndpointID) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Hostname) > 0 {
-		dAtA[i] = 0xa
+	if len(m.OrchestratorId) > 0 {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Hostname)))
-		i += copy(dAtA[i:], m.Hostname)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.OrchestratorId)))
+		i += copy(dAtA[i:], m.OrchestratorId)
 	}
-	if len(m.Ipv4Addr) > 0 {
-		dAtA[i] = 0x12
+	if len(m.WorkloadId) > 0 {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Ipv4Addr)))
-		i += copy(dAtA[i:], m.Ipv4Addr)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.WorkloadId)))
+		i += copy(dAtA[i:], m.WorkloadId)
+	}
+	if len(m.EndpointId) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.EndpointId)))
+		i += copy(dAtA[i:], m.EndpointId)
 	}
 	return i, nil
 }
 
-func (m *IPAMPoolUpdate) Marshal() (dAtA []byte, err error) {
+func (m *WorkloadEndpointUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5412,31 +5625,35 @@ func (m *IPAMPoolUpdate) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *IPAMPoolUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *WorkloadEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Id) > 0 {
+	if m.Id != nil {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n46, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n46
 	}
-	if m.Pool != nil {
-		dAtA[i] = 0x12
+	if m.Endpoint != nil {
+		dAtA[i] = 0x2a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Pool.Size()))
-		n58, err := m.Pool.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Endpoint.Size()))
+		n47, err := m.Endpoint.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n58
+		i += n47
 	}
 	return i, nil
 }
 
-func (m *IPAMPoolRemove) Marshal() (dAtA []byte, err error) {
+func (m *WorkloadEndpoint) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5446,100 +5663,114 @@ func (m *IPAMPoolRemove) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *IPAMPoolRemove) MarshalTo(dAtA []byte) (int, error) {
+func (m *WorkloadEndpoint) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Id) > 0 {
+	if len(m.State) > 0 {
 		dAtA[i] = 0xa
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
-	}
-	return i, nil
-}
-
-func (m *IPAMPool) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.State)))
+		i += copy(dAtA[i:], m.State)
 	}
-	return dAtA[:n], nil
-}
-
-func (m *IPAMPool) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Cidr) > 0 {
-		dAtA[i] = 0xa
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Cidr)))
-		i += copy(dAtA[i:], m.Cidr)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
-	if m.Masquerade {
-		dAtA[i] = 0x10
+	if len(m.Mac) > 0 {
+		dAtA[i] = 0x1a
 		i++
-		if m.Masquerade {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Mac)))
+		i += copy(dAtA[i:], m.Mac)
+	}
+	if len(m.ProfileIds) > 0 {
+		for _, s := range m.ProfileIds {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
-		i++
 	}
-	return i, nil
-}
-
-func (m *ServiceAccountUpdate) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+	if len(m.Ipv4Nets) > 0 {
+		for _, s := range m.Ipv4Nets {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ServiceAccountUpdate) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n59, err := m.Id.MarshalTo(dAtA[i:])
-		if err != nil {
-			return 0, err
+	if len(m.Ipv6Nets) > 0 {
+		for _, s := range m.Ipv6Nets {
+			dAtA[i] = 0x32
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
-		i += n59
 	}
-	if len(m.Labels) > 0 {
-		for k, _ := range m.Labels {
-			dAtA[i] = 0x12
+	if len(m.Tiers) > 0 {
+		for _, msg := range m.Tiers {
+			dAtA[i] = 0x3a
 			i++
-			v := m.Labels[k]
-			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
-			i = encodeVarintFelixbackend(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Ipv4Nat) > 0 {
+		for _, msg := range m.Ipv4Nat {
+			dAtA[i] = 0x42
 			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Ipv6Nat) > 0 {
+		for _, msg := range m.Ipv6Nat {
+			dAtA[i] = 0x4a
 			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
 	return i, nil
 }
 
-func (m *ServiceAccountRemove) Marshal() (dAtA []byte, err error) {
+func (m *WorkloadEndpointRemove) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5549,7 +5780,7 @@ func (m *ServiceAccountRemove) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ServiceAccountRemove) MarshalTo(dAtA []byte) (int, error) {
+func (m *WorkloadEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5558,16 +5789,16 @@ func (m *ServiceAccountRemove) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n60, err := m.Id.MarshalTo(dAtA[i:])
+		n48, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n60
+		i += n48
 	}
 	return i, nil
 }
 
-func (m *ServiceAccountID) Marshal() (dAtA []byte, err error) {
+func (m *HostEndpointID) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5577,27 +5808,21 @@ func (m *ServiceAccountID) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *ServiceAccountID) MarshalTo(dAtA []byte) (int, error) {
+func (m *HostEndpointID) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if len(m.Namespace) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Namespace)))
-		i += copy(dAtA[i:], m.Namespace)
-	}
-	if len(m.Name) > 0 {
+	if len(m.EndpointId) > 0 {
 		dAtA[i] = 0x12
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
-		i += copy(dAtA[i:], m.Name)
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.EndpointId)))
+		i += copy(dAtA[i:], m.EndpointId)
 	}
 	return i, nil
 }
 
-func (m *NamespaceUpdate) Marshal() (dAtA []byte, err error) {
+func (m *HostEndpointUpdate) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5607,7 +5832,7 @@ func (m *NamespaceUpdate) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NamespaceUpdate) MarshalTo(dAtA []byte) (int, error) {
+func (m *HostEndpointUpdate) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5616,61 +5841,26 @@ func (m *NamespaceUpdate) MarshalTo(dAtA []byte) (int, error) {
 		dAtA[i] = 0xa
 		i++
 		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n61, err := m.Id.MarshalTo(dAtA[i:])
+		n49, err := m.Id.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n61
-	}
-	if len(m.Labels) > 0 {
-		for k, _ := range m.Labels {
-			dAtA[i] = 0x12
-			i++
-			v := m.Labels[k]
-			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
-			i = encodeVarintFelixbackend(dAtA, i, uint64(mapSize))
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(len(k)))
-			i += copy(dAtA[i:], k)
-			dAtA[i] = 0x12
-			i++
-			i = encodeVarintFelixbackend(dAtA, i, uint64(len(v)))
-			i += copy(dAtA[i:], v)
-		}
-	}
-	return i, nil
-}
-
-func (m *NamespaceRemove) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+		i += n49
 	}
-	return dAtA[:n], nil
-}
-
-func (m *NamespaceRemove) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Id != nil {
-		dAtA[i] = 0xa
+	if m.Endpoint != nil {
+		dAtA[i] = 0x1a
 		i++
-		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
-		n62, err := m.Id.MarshalTo(dAtA[i:])
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Endpoint.Size()))
+		n50, err := m.Endpoint.MarshalTo(dAtA[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n62
+		i += n50
 	}
 	return i, nil
 }
 
-func (m *NamespaceID) Marshal() (dAtA []byte, err error) {
+func (m *HostEndpoint) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalTo(dAtA)
@@ -5680,7 +5870,7 @@ func (m *NamespaceID) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *NamespaceID) MarshalTo(dAtA []byte) (int, error) {
+func (m *HostEndpoint) MarshalTo(dAtA []byte) (int, error) {
 	var i int
 	_ = i
 	var l int
@@ -5691,1130 +5881,1493 @@ func (m *NamespaceID) MarshalTo(dAtA []byte) (int, error) {
 		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
 		i += copy(dAtA[i:], m.Name)
 	}
-	return i, nil
-}
-
-func encodeVarintFelixbackend(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
-}
-func (m *SyncRequest) Size() (n int) {
-	var l int
-	_ = l
-	return n
-}
-
-func (m *ToDataplane) Size() (n int) {
-	var l int
-	_ = l
-	if m.Payload != nil {
-		n += m.Payload.Size()
+	if len(m.ProfileIds) > 0 {
+		for _, s := range m.ProfileIds {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	if m.SequenceNumber != 0 {
-		n += 1 + sovFelixbackend(uint64(m.SequenceNumber))
+	if len(m.Tiers) > 0 {
+		for _, msg := range m.Tiers {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	return n
+	if len(m.ExpectedIpv4Addrs) > 0 {
+		for _, s := range m.ExpectedIpv4Addrs {
+			dAtA[i] = 0x22
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.ExpectedIpv6Addrs) > 0 {
+		for _, s := range m.ExpectedIpv6Addrs {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.UntrackedTiers) > 0 {
+		for _, msg := range m.UntrackedTiers {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.PreDnatTiers) > 0 {
+		for _, msg := range m.PreDnatTiers {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.ForwardTiers) > 0 {
+		for _, msg := range m.ForwardTiers {
+			dAtA[i] = 0x42
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
 }
 
-func (m *ToDataplane_InSync) Size() (n int) {
-	var l int
-	_ = l
-	if m.InSync != nil {
-		l = m.InSync.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+func (m *HostEndpointRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_IpsetUpdate) Size() (n int) {
+
+func (m *HostEndpointRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.IpsetUpdate != nil {
-		l = m.IpsetUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n51, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n51
 	}
-	return n
+	return i, nil
 }
-func (m *ToDataplane_IpsetDeltaUpdate) Size() (n int) {
-	var l int
-	_ = l
-	if m.IpsetDeltaUpdate != nil {
-		l = m.IpsetDeltaUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+
+func (m *TierInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_IpsetRemove) Size() (n int) {
+
+func (m *TierInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.IpsetRemove != nil {
-		l = m.IpsetRemove.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
-	return n
-}
-func (m *ToDataplane_ActiveProfileUpdate) Size() (n int) {
-	var l int
-	_ = l
-	if m.ActiveProfileUpdate != nil {
-		l = m.ActiveProfileUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.IngressPolicies) > 0 {
+		for _, s := range m.IngressPolicies {
+			dAtA[i] = 0x12
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
 	}
-	return n
+	if len(m.EgressPolicies) > 0 {
+		for _, s := range m.EgressPolicies {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
 }
-func (m *ToDataplane_ActiveProfileRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.ActiveProfileRemove != nil {
-		l = m.ActiveProfileRemove.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+
+func (m *NatInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_ActivePolicyUpdate) Size() (n int) {
+
+func (m *NatInfo) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ActivePolicyUpdate != nil {
-		l = m.ActivePolicyUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.ExtIp) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.ExtIp)))
+		i += copy(dAtA[i:], m.ExtIp)
 	}
-	return n
+	if len(m.IntIp) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.IntIp)))
+		i += copy(dAtA[i:], m.IntIp)
+	}
+	return i, nil
 }
-func (m *ToDataplane_ActivePolicyRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.ActivePolicyRemove != nil {
-		l = m.ActivePolicyRemove.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+
+func (m *ProcessStatusUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_HostEndpointUpdate) Size() (n int) {
+
+func (m *ProcessStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.HostEndpointUpdate != nil {
-		l = m.HostEndpointUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.IsoTimestamp) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.IsoTimestamp)))
+		i += copy(dAtA[i:], m.IsoTimestamp)
 	}
-	return n
-}
-func (m *ToDataplane_HostEndpointRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.HostEndpointRemove != nil {
-		l = m.HostEndpointRemove.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.Uptime != 0 {
+		dAtA[i] = 0x11
+		i++
+		binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Uptime))))
+		i += 8
 	}
-	return n
+	return i, nil
 }
-func (m *ToDataplane_WorkloadEndpointUpdate) Size() (n int) {
-	var l int
-	_ = l
-	if m.WorkloadEndpointUpdate != nil {
-		l = m.WorkloadEndpointUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+
+func (m *HostEndpointStatusUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_WorkloadEndpointRemove) Size() (n int) {
+
+func (m *HostEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.WorkloadEndpointRemove != nil {
-		l = m.WorkloadEndpointRemove.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n52, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n52
 	}
-	return n
+	if m.Status != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Status.Size()))
+		n53, err := m.Status.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
+	}
+	return i, nil
 }
-func (m *ToDataplane_ConfigUpdate) Size() (n int) {
-	var l int
-	_ = l
-	if m.ConfigUpdate != nil {
-		l = m.ConfigUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+
+func (m *EndpointStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_HostMetadataUpdate) Size() (n int) {
+
+func (m *EndpointStatus) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.HostMetadataUpdate != nil {
-		l = m.HostMetadataUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Status) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Status)))
+		i += copy(dAtA[i:], m.Status)
 	}
-	return n
+	return i, nil
 }
-func (m *ToDataplane_IpamPoolUpdate) Size() (n int) {
-	var l int
-	_ = l
-	if m.IpamPoolUpdate != nil {
-		l = m.IpamPoolUpdate.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+
+func (m *HostEndpointStatusRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_IpamPoolRemove) Size() (n int) {
+
+func (m *HostEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.IpamPoolRemove != nil {
-		l = m.IpamPoolRemove.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n54, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
 	}
-	return n
+	return i, nil
 }
-func (m *ToDataplane_HostMetadataRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.HostMetadataRemove != nil {
-		l = m.HostMetadataRemove.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+
+func (m *WorkloadEndpointStatusUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_ServiceAccountUpdate) Size() (n int) {
+
+func (m *WorkloadEndpointStatusUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.ServiceAccountUpdate != nil {
-		l = m.ServiceAccountUpdate.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n55, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
 	}
-	return n
+	if m.Status != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Status.Size()))
+		n56, err := m.Status.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n56
+	}
+	return i, nil
 }
-func (m *ToDataplane_ServiceAccountRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.ServiceAccountRemove != nil {
-		l = m.ServiceAccountRemove.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+
+func (m *WorkloadEndpointStatusRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ToDataplane_NamespaceUpdate) Size() (n int) {
+
+func (m *WorkloadEndpointStatusRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.NamespaceUpdate != nil {
-		l = m.NamespaceUpdate.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n57, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n57
 	}
-	return n
+	return i, nil
 }
-func (m *ToDataplane_NamespaceRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.NamespaceRemove != nil {
-		l = m.NamespaceRemove.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+
+func (m *HostMetadataUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *FromDataplane) Size() (n int) {
+
+func (m *HostMetadataUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Payload != nil {
-		n += m.Payload.Size()
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
 	}
-	if m.SequenceNumber != 0 {
-		n += 1 + sovFelixbackend(uint64(m.SequenceNumber))
+	if len(m.Ipv4Addr) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Ipv4Addr)))
+		i += copy(dAtA[i:], m.Ipv4Addr)
 	}
-	return n
+	return i, nil
 }
 
-func (m *FromDataplane_ProcessStatusUpdate) Size() (n int) {
-	var l int
-	_ = l
-	if m.ProcessStatusUpdate != nil {
-		l = m.ProcessStatusUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+func (m *HostMetadataRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *FromDataplane_HostEndpointStatusUpdate) Size() (n int) {
+
+func (m *HostMetadataRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.HostEndpointStatusUpdate != nil {
-		l = m.HostEndpointStatusUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Hostname) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Hostname)))
+		i += copy(dAtA[i:], m.Hostname)
 	}
-	return n
+	if len(m.Ipv4Addr) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Ipv4Addr)))
+		i += copy(dAtA[i:], m.Ipv4Addr)
+	}
+	return i, nil
 }
-func (m *FromDataplane_HostEndpointStatusRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.HostEndpointStatusRemove != nil {
-		l = m.HostEndpointStatusRemove.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+
+func (m *IPAMPoolUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *FromDataplane_WorkloadEndpointStatusUpdate) Size() (n int) {
+
+func (m *IPAMPoolUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.WorkloadEndpointStatusUpdate != nil {
-		l = m.WorkloadEndpointStatusUpdate.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
 	}
-	return n
+	if m.Pool != nil {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Pool.Size()))
+		n58, err := m.Pool.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n58
+	}
+	return i, nil
 }
-func (m *FromDataplane_WorkloadEndpointStatusRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.WorkloadEndpointStatusRemove != nil {
-		l = m.WorkloadEndpointStatusRemove.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+
+func (m *IPAMPoolRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ConfigUpdate) Size() (n int) {
+
+func (m *IPAMPoolRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.Config) > 0 {
-		for k, v := range m.Config {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
-			n += mapEntrySize + 1 + sovFelixbackend(uint64(mapEntrySize))
-		}
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
 	}
-	return n
+	return i, nil
 }
 
-func (m *InSync) Size() (n int) {
-	var l int
-	_ = l
-	return n
+func (m *IPAMPool) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
-func (m *IPSetUpdate) Size() (n int) {
+func (m *IPAMPool) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Cidr) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Cidr)))
+		i += copy(dAtA[i:], m.Cidr)
 	}
-	if len(m.Members) > 0 {
-		for _, s := range m.Members {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
+	if m.Masquerade {
+		dAtA[i] = 0x10
+		i++
+		if m.Masquerade {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i++
 	}
-	if m.Type != 0 {
-		n += 1 + sovFelixbackend(uint64(m.Type))
+	return i, nil
+}
+
+func (m *ServiceAccountUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *IPSetDeltaUpdate) Size() (n int) {
+func (m *ServiceAccountUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	if len(m.AddedMembers) > 0 {
-		for _, s := range m.AddedMembers {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n59, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n59
 	}
-	if len(m.RemovedMembers) > 0 {
-		for _, s := range m.RemovedMembers {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x12
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
+			i = encodeVarintFelixbackend(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
 		}
 	}
-	return n
+	return i, nil
 }
 
-func (m *IPSetRemove) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+func (m *ServiceAccountRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ActiveProfileUpdate) Size() (n int) {
+func (m *ServiceAccountRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	if m.Profile != nil {
-		l = m.Profile.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n60, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n60
 	}
-	return n
+	return i, nil
 }
 
-func (m *ActiveProfileRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+func (m *ServiceAccountID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ProfileID) Size() (n int) {
+func (m *ServiceAccountID) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Namespace) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Namespace)))
+		i += copy(dAtA[i:], m.Namespace)
 	}
-	return n
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
 }
 
-func (m *Profile) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.InboundRules) > 0 {
-		for _, e := range m.InboundRules {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.OutboundRules) > 0 {
-		for _, e := range m.OutboundRules {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
+func (m *NamespaceUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ActivePolicyUpdate) Size() (n int) {
+func (m *NamespaceUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
 	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n61, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n61
 	}
-	if m.Policy != nil {
-		l = m.Policy.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Labels) > 0 {
+		for k, _ := range m.Labels {
+			dAtA[i] = 0x12
+			i++
+			v := m.Labels[k]
+			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
+			i = encodeVarintFelixbackend(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
 	}
-	return n
+	return i, nil
 }
 
-func (m *ActivePolicyRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+func (m *NamespaceRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *PolicyID) Size() (n int) {
+func (m *NamespaceRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Tier)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.Id != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Id.Size()))
+		n62, err := m.Id.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n62
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	return i, nil
+}
+
+func (m *NamespaceID) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Policy) Size() (n int) {
+func (m *NamespaceID) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if len(m.InboundRules) > 0 {
-		for _, e := range m.InboundRules {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.OutboundRules) > 0 {
-		for _, e := range m.OutboundRules {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if m.Untracked {
-		n += 2
-	}
-	if m.PreDnat {
-		n += 2
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	return i, nil
+}
+
+func (m *ServiceUpdate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Rule) Size() (n int) {
+func (m *ServiceUpdate) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Action)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
 	}
-	if m.IpVersion != 0 {
-		n += 1 + sovFelixbackend(uint64(m.IpVersion))
+	if len(m.Namespace) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Namespace)))
+		i += copy(dAtA[i:], m.Namespace)
 	}
-	if m.Protocol != nil {
-		l = m.Protocol.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Type) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Type)))
+		i += copy(dAtA[i:], m.Type)
 	}
-	if len(m.SrcNet) > 0 {
-		for _, s := range m.SrcNet {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
+	if len(m.ClusterIp) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.ClusterIp)))
+		i += copy(dAtA[i:], m.ClusterIp)
 	}
-	if len(m.SrcPorts) > 0 {
-		for _, e := range m.SrcPorts {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
+	if len(m.LoadbalancerIp) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.LoadbalancerIp)))
+		i += copy(dAtA[i:], m.LoadbalancerIp)
 	}
-	if len(m.DstNet) > 0 {
-		for _, s := range m.DstNet {
+	if len(m.ExternalIps) > 0 {
+		for _, s := range m.ExternalIps {
+			dAtA[i] = 0x32
+			i++
 			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.DstPorts) > 0 {
-		for _, e := range m.DstPorts {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Ports) > 0 {
+		for _, msg := range m.Ports {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	if m.Icmp != nil {
-		n += m.Icmp.Size()
-	}
-	if len(m.SrcIpSetIds) > 0 {
-		for _, s := range m.SrcIpSetIds {
+	if len(m.ClusterIps) > 0 {
+		for _, s := range m.ClusterIps {
+			dAtA[i] = 0x42
+			i++
 			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
 		}
 	}
-	if len(m.DstIpSetIds) > 0 {
-		for _, s := range m.DstIpSetIds {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
+	return i, nil
+}
+
+func (m *ServicePort) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.SrcNamedPortIpSetIds) > 0 {
-		for _, s := range m.SrcNamedPortIpSetIds {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
+	return dAtA[:n], nil
+}
+
+func (m *ServicePort) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Protocol) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Protocol)))
+		i += copy(dAtA[i:], m.Protocol)
 	}
-	if len(m.DstNamedPortIpSetIds) > 0 {
-		for _, s := range m.DstNamedPortIpSetIds {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
+	if m.Port != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Port))
 	}
-	if m.NotProtocol != nil {
-		l = m.NotProtocol.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+	if m.NodePort != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.NodePort))
 	}
-	if len(m.NotSrcNet) > 0 {
-		for _, s := range m.NotSrcNet {
-			l = len(s)
-			n += 2 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.NotSrcPorts) > 0 {
-		for _, e := range m.NotSrcPorts {
-			l = e.Size()
-			n += 2 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.NotDstNet) > 0 {
-		for _, s := range m.NotDstNet {
-			l = len(s)
-			n += 2 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.NotDstPorts) > 0 {
-		for _, e := range m.NotDstPorts {
-			l = e.Size()
-			n += 2 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if m.NotIcmp != nil {
-		n += m.NotIcmp.Size()
-	}
-	if len(m.NotSrcIpSetIds) > 0 {
-		for _, s := range m.NotSrcIpSetIds {
-			l = len(s)
-			n += 2 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.NotDstIpSetIds) > 0 {
-		for _, s := range m.NotDstIpSetIds {
-			l = len(s)
-			n += 2 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.NotSrcNamedPortIpSetIds) > 0 {
-		for _, s := range m.NotSrcNamedPortIpSetIds {
-			l = len(s)
-			n += 2 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.NotDstNamedPortIpSetIds) > 0 {
-		for _, s := range m.NotDstNamedPortIpSetIds {
-			l = len(s)
-			n += 2 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	l = len(m.OriginalSrcSelector)
-	if l > 0 {
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.OriginalDstSelector)
-	if l > 0 {
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.OriginalSrcNamespaceSelector)
-	if l > 0 {
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.OriginalDstNamespaceSelector)
-	if l > 0 {
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.OriginalNotSrcSelector)
-	if l > 0 {
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.OriginalNotDstSelector)
-	if l > 0 {
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	if m.SrcServiceAccountMatch != nil {
-		l = m.SrcServiceAccountMatch.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	if m.DstServiceAccountMatch != nil {
-		l = m.DstServiceAccountMatch.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	if m.HttpMatch != nil {
-		l = m.HttpMatch.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.RuleId)
-	if l > 0 {
-		n += 2 + l + sovFelixbackend(uint64(l))
+	return i, nil
+}
+
+func (m *ServiceRemove) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Rule_IcmpType) Size() (n int) {
+func (m *ServiceRemove) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	n += 1 + sovFelixbackend(uint64(m.IcmpType))
-	return n
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Namespace) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Namespace)))
+		i += copy(dAtA[i:], m.Namespace)
+	}
+	return i, nil
 }
-func (m *Rule_IcmpTypeCode) Size() (n int) {
-	var l int
-	_ = l
-	if m.IcmpTypeCode != nil {
-		l = m.IcmpTypeCode.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+
+func (m *RuleMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *Rule_NotIcmpType) Size() (n int) {
+
+func (m *RuleMetadata) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	n += 2 + sovFelixbackend(uint64(m.NotIcmpType))
-	return n
+	if len(m.Annotations) > 0 {
+		for k, _ := range m.Annotations {
+			dAtA[i] = 0xa
+			i++
+			v := m.Annotations[k]
+			mapSize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
+			i = encodeVarintFelixbackend(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	return i, nil
 }
-func (m *Rule_NotIcmpTypeCode) Size() (n int) {
-	var l int
-	_ = l
-	if m.NotIcmpTypeCode != nil {
-		l = m.NotIcmpTypeCode.Size()
-		n += 2 + l + sovFelixbackend(uint64(l))
+
+func (m *DataplaneStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
-func (m *ServiceAccountMatch) Size() (n int) {
+
+func (m *DataplaneStats) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Selector)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.SrcIp) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.SrcIp)))
+		i += copy(dAtA[i:], m.SrcIp)
 	}
-	if len(m.Names) > 0 {
-		for _, s := range m.Names {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.DstIp) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.DstIp)))
+		i += copy(dAtA[i:], m.DstIp)
+	}
+	if m.SrcPort != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.SrcPort))
+	}
+	if m.DstPort != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.DstPort))
+	}
+	if m.Protocol != nil {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Protocol.Size()))
+		n64, err := m.Protocol.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n64
 	}
-	return n
-}
-
-func (m *HTTPMatch) Size() (n int) {
-	var l int
-	_ = l
-	if len(m.Methods) > 0 {
-		for _, s := range m.Methods {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Stats) > 0 {
+		for _, msg := range m.Stats {
+			dAtA[i] = 0x32
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	if len(m.Paths) > 0 {
-		for _, e := range m.Paths {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.WafRuleHits) > 0 {
+		for _, msg := range m.WafRuleHits {
+			dAtA[i] = 0xa2
+			i++
+			dAtA[i] = 0x6
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
-	return n
+	if len(m.PolicyHits) > 0 {
+		for _, msg := range m.PolicyHits {
+			dAtA[i] = 0xaa
+			i++
+			dAtA[i] = 0x6
+			i++
+			i = encodeVarintFelixbackend(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
 }
 
-func (m *HTTPMatch_PathMatch) Size() (n int) {
-	var l int
-	_ = l
-	if m.PathMatch != nil {
-		n += m.PathMatch.Size()
+func (m *Statistic) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *HTTPMatch_PathMatch_Exact) Size() (n int) {
+func (m *Statistic) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Exact)
-	n += 1 + l + sovFelixbackend(uint64(l))
-	return n
-}
-func (m *HTTPMatch_PathMatch_Prefix) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Prefix)
-	n += 1 + l + sovFelixbackend(uint64(l))
-	return n
+	if m.Direction != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Direction))
+	}
+	if m.Relativity != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Relativity))
+	}
+	if m.Kind != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Kind))
+	}
+	if m.Action != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Action))
+	}
+	if m.Value != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Value))
+	}
+	return i, nil
 }
-func (m *IcmpTypeAndCode) Size() (n int) {
+
+func (m *WAFRuleHit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WAFRuleHit) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Type != 0 {
-		n += 1 + sovFelixbackend(uint64(m.Type))
+	if m.RuleId != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.RuleId))
 	}
-	if m.Code != 0 {
-		n += 1 + sovFelixbackend(uint64(m.Code))
+	if len(m.Severity) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Severity)))
+		i += copy(dAtA[i:], m.Severity)
 	}
-	return n
+	if len(m.Message) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Message)))
+		i += copy(dAtA[i:], m.Message)
+	}
+	if m.Blocked {
+		dAtA[i] = 0x20
+		i++
+		if m.Blocked {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Count))
+	}
+	return i, nil
 }
 
-func (m *Protocol) Size() (n int) {
+func (m *PolicyHit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PolicyHit) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.NumberOrName != nil {
-		n += m.NumberOrName.Size()
+	if len(m.Tier) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Tier)))
+		i += copy(dAtA[i:], m.Tier)
 	}
-	return n
+	if len(m.Name) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if m.Enforced {
+		dAtA[i] = 0x18
+		i++
+		if m.Enforced {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.Count != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintFelixbackend(dAtA, i, uint64(m.Count))
+	}
+	return i, nil
 }
 
-func (m *Protocol_Number) Size() (n int) {
+func (m *ReportResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReportResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	n += 1 + sovFelixbackend(uint64(m.Number))
-	return n
+	if m.Successful {
+		dAtA[i] = 0x8
+		i++
+		if m.Successful {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
 }
-func (m *Protocol_Name) Size() (n int) {
+
+func encodeVarintFelixbackend(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *SyncRequest) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Name)
-	n += 1 + l + sovFelixbackend(uint64(l))
 	return n
 }
-func (m *PortRange) Size() (n int) {
+
+func (m *ToDataplane) Size() (n int) {
 	var l int
 	_ = l
-	if m.First != 0 {
-		n += 1 + sovFelixbackend(uint64(m.First))
+	if m.Payload != nil {
+		n += m.Payload.Size()
 	}
-	if m.Last != 0 {
-		n += 1 + sovFelixbackend(uint64(m.Last))
+	if m.SequenceNumber != 0 {
+		n += 1 + sovFelixbackend(uint64(m.SequenceNumber))
 	}
 	return n
 }
 
-func (m *WorkloadEndpointID) Size() (n int) {
+func (m *ToDataplane_InSync) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.OrchestratorId)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.WorkloadId)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.EndpointId)
-	if l > 0 {
+	if m.InSync != nil {
+		l = m.InSync.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *WorkloadEndpointUpdate) Size() (n int) {
+func (m *ToDataplane_IpsetUpdate) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	if m.Endpoint != nil {
-		l = m.Endpoint.Size()
+	if m.IpsetUpdate != nil {
+		l = m.IpsetUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *WorkloadEndpoint) Size() (n int) {
+func (m *ToDataplane_IpsetDeltaUpdate) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.State)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	l = len(m.Mac)
-	if l > 0 {
+	if m.IpsetDeltaUpdate != nil {
+		l = m.IpsetDeltaUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if len(m.ProfileIds) > 0 {
-		for _, s := range m.ProfileIds {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.Ipv4Nets) > 0 {
-		for _, s := range m.Ipv4Nets {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.Ipv6Nets) > 0 {
-		for _, s := range m.Ipv6Nets {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.Tiers) > 0 {
-		for _, e := range m.Tiers {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.Ipv4Nat) > 0 {
-		for _, e := range m.Ipv4Nat {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.Ipv6Nat) > 0 {
-		for _, e := range m.Ipv6Nat {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
 	return n
 }
-
-func (m *WorkloadEndpointRemove) Size() (n int) {
+func (m *ToDataplane_IpsetRemove) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
+	if m.IpsetRemove != nil {
+		l = m.IpsetRemove.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *HostEndpointID) Size() (n int) {
+func (m *ToDataplane_ActiveProfileUpdate) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.EndpointId)
-	if l > 0 {
+	if m.ActiveProfileUpdate != nil {
+		l = m.ActiveProfileUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *HostEndpointUpdate) Size() (n int) {
+func (m *ToDataplane_ActiveProfileRemove) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
+	if m.ActiveProfileRemove != nil {
+		l = m.ActiveProfileRemove.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if m.Endpoint != nil {
-		l = m.Endpoint.Size()
+	return n
+}
+func (m *ToDataplane_ActivePolicyUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.ActivePolicyUpdate != nil {
+		l = m.ActivePolicyUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *HostEndpoint) Size() (n int) {
+func (m *ToDataplane_ActivePolicyRemove) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
+	if m.ActivePolicyRemove != nil {
+		l = m.ActivePolicyRemove.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if len(m.ProfileIds) > 0 {
-		for _, s := range m.ProfileIds {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.Tiers) > 0 {
-		for _, e := range m.Tiers {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.ExpectedIpv4Addrs) > 0 {
-		for _, s := range m.ExpectedIpv4Addrs {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.ExpectedIpv6Addrs) > 0 {
-		for _, s := range m.ExpectedIpv6Addrs {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.UntrackedTiers) > 0 {
-		for _, e := range m.UntrackedTiers {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.PreDnatTiers) > 0 {
-		for _, e := range m.PreDnatTiers {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.ForwardTiers) > 0 {
-		for _, e := range m.ForwardTiers {
-			l = e.Size()
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
 	return n
 }
-
-func (m *HostEndpointRemove) Size() (n int) {
+func (m *ToDataplane_HostEndpointUpdate) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
+	if m.HostEndpointUpdate != nil {
+		l = m.HostEndpointUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *TierInfo) Size() (n int) {
+func (m *ToDataplane_HostEndpointRemove) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Name)
-	if l > 0 {
+	if m.HostEndpointRemove != nil {
+		l = m.HostEndpointRemove.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if len(m.IngressPolicies) > 0 {
-		for _, s := range m.IngressPolicies {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
-	if len(m.EgressPolicies) > 0 {
-		for _, s := range m.EgressPolicies {
-			l = len(s)
-			n += 1 + l + sovFelixbackend(uint64(l))
-		}
-	}
 	return n
 }
-
-func (m *NatInfo) Size() (n int) {
+func (m *ToDataplane_WorkloadEndpointUpdate) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.ExtIp)
-	if l > 0 {
+	if m.WorkloadEndpointUpdate != nil {
+		l = m.WorkloadEndpointUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	l = len(m.IntIp)
-	if l > 0 {
+	return n
+}
+func (m *ToDataplane_WorkloadEndpointRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.WorkloadEndpointRemove != nil {
+		l = m.WorkloadEndpointRemove.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *ProcessStatusUpdate) Size() (n int) {
+func (m *ToDataplane_ConfigUpdate) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.IsoTimestamp)
-	if l > 0 {
+	if m.ConfigUpdate != nil {
+		l = m.ConfigUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if m.Uptime != 0 {
-		n += 9
-	}
 	return n
 }
-
-func (m *HostEndpointStatusUpdate) Size() (n int) {
+func (m *ToDataplane_HostMetadataUpdate) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
+	if m.HostMetadataUpdate != nil {
+		l = m.HostMetadataUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if m.Status != nil {
-		l = m.Status.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *ToDataplane_IpamPoolUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.IpamPoolUpdate != nil {
+		l = m.IpamPoolUpdate.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *EndpointStatus) Size() (n int) {
+func (m *ToDataplane_IpamPoolRemove) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Status)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.IpamPoolRemove != nil {
+		l = m.IpamPoolRemove.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *HostEndpointStatusRemove) Size() (n int) {
+func (m *ToDataplane_HostMetadataRemove) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.HostMetadataRemove != nil {
+		l = m.HostMetadataRemove.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *WorkloadEndpointStatusUpdate) Size() (n int) {
+func (m *ToDataplane_ServiceAccountUpdate) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.ServiceAccountUpdate != nil {
+		l = m.ServiceAccountUpdate.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
-	if m.Status != nil {
-		l = m.Status.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *ToDataplane_ServiceAccountRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.ServiceAccountRemove != nil {
+		l = m.ServiceAccountRemove.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *WorkloadEndpointStatusRemove) Size() (n int) {
+func (m *ToDataplane_NamespaceUpdate) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.NamespaceUpdate != nil {
+		l = m.NamespaceUpdate.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *HostMetadataUpdate) Size() (n int) {
+func (m *ToDataplane_NamespaceRemove) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Hostname)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.NamespaceRemove != nil {
+		l = m.NamespaceRemove.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
-	l = len(m.Ipv4Addr)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *ToDataplane_ServiceUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.ServiceUpdate != nil {
+		l = m.ServiceUpdate.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *HostMetadataRemove) Size() (n int) {
+func (m *ToDataplane_ServiceRemove) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Hostname)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if m.ServiceRemove != nil {
+		l = m.ServiceRemove.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
 	}
-	l = len(m.Ipv4Addr)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *FromDataplane) Size() (n int) {
+	var l int
+	_ = l
+	if m.Payload != nil {
+		n += m.Payload.Size()
+	}
+	if m.SequenceNumber != 0 {
+		n += 1 + sovFelixbackend(uint64(m.SequenceNumber))
 	}
 	return n
 }
 
-func (m *IPAMPoolUpdate) Size() (n int) {
+func (m *FromDataplane_ProcessStatusUpdate) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Id)
-	if l > 0 {
+	if m.ProcessStatusUpdate != nil {
+		l = m.ProcessStatusUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if m.Pool != nil {
-		l = m.Pool.Size()
+	return n
+}
+func (m *FromDataplane_HostEndpointStatusUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.HostEndpointStatusUpdate != nil {
+		l = m.HostEndpointStatusUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *IPAMPoolRemove) Size() (n int) {
+func (m *FromDataplane_HostEndpointStatusRemove) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Id)
-	if l > 0 {
+	if m.HostEndpointStatusRemove != nil {
+		l = m.HostEndpointStatusRemove.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
 	return n
 }
-
-func (m *IPAMPool) Size() (n int) {
+func (m *FromDataplane_WorkloadEndpointStatusUpdate) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Cidr)
-	if l > 0 {
+	if m.WorkloadEndpointStatusUpdate != nil {
+		l = m.WorkloadEndpointStatusUpdate.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if m.Masquerade {
-		n += 2
-	}
 	return n
 }
-
-func (m *ServiceAccountUpdate) Size() (n int) {
+func (m *FromDataplane_WorkloadEndpointStatusRemove) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
+	if m.WorkloadEndpointStatusRemove != nil {
+		l = m.WorkloadEndpointStatusRemove.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if len(m.Labels) > 0 {
-		for k, v := range m.Labels {
+	return n
+}
+func (m *ConfigUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Config) > 0 {
+		for k, v := range m.Config {
 			_ = k
 			_ = v
 			mapEntrySize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
@@ -6824,82 +7377,2430 @@ func (m *ServiceAccountUpdate) Size() (n int) {
 	return n
 }
 
-func (m *ServiceAccountRemove) Size() (n int) {
+func (m *InSync) Size() (n int) {
 	var l int
 	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
 	return n
 }
 
-func (m *ServiceAccountID) Size() (n int) {
+func (m *IPSetUpdate) Size() (n int) {
 	var l int
 	_ = l
-	l = len(m.Namespace)
+	l = len(m.Id)
 	if l > 0 {
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
+	if len(m.Members) > 0 {
+		for _, s := range m.Members {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if m.Type != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Type))
 	}
 	return n
 }
 
-func (m *NamespaceUpdate) Size() (n int) {
+func (m *IPSetDeltaUpdate) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.AddedMembers) > 0 {
+		for _, s := range m.AddedMembers {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.RemovedMembers) > 0 {
+		for _, s := range m.RemovedMembers {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *IPSetRemove) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *ActiveProfileUpdate) Size() (n int) {
 	var l int
 	_ = l
 	if m.Id != nil {
 		l = m.Id.Size()
 		n += 1 + l + sovFelixbackend(uint64(l))
 	}
-	if len(m.Labels) > 0 {
-		for k, v := range m.Labels {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
-			n += mapEntrySize + 1 + sovFelixbackend(uint64(mapEntrySize))
+	if m.Profile != nil {
+		l = m.Profile.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *ActiveProfileRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *ProfileID) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *Profile) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.InboundRules) > 0 {
+		for _, e := range m.InboundRules {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.OutboundRules) > 0 {
+		for _, e := range m.OutboundRules {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ActivePolicyUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Policy != nil {
+		l = m.Policy.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *ActivePolicyRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *PolicyID) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Tier)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *Policy) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.InboundRules) > 0 {
+		for _, e := range m.InboundRules {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.OutboundRules) > 0 {
+		for _, e := range m.OutboundRules {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if m.Untracked {
+		n += 2
+	}
+	if m.PreDnat {
+		n += 2
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *Rule) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Action)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.IpVersion != 0 {
+		n += 1 + sovFelixbackend(uint64(m.IpVersion))
+	}
+	if m.Protocol != nil {
+		l = m.Protocol.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.SrcNet) > 0 {
+		for _, s := range m.SrcNet {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.SrcPorts) > 0 {
+		for _, e := range m.SrcPorts {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.DstNet) > 0 {
+		for _, s := range m.DstNet {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.DstPorts) > 0 {
+		for _, e := range m.DstPorts {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if m.Icmp != nil {
+		n += m.Icmp.Size()
+	}
+	if len(m.SrcIpSetIds) > 0 {
+		for _, s := range m.SrcIpSetIds {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.DstIpSetIds) > 0 {
+		for _, s := range m.DstIpSetIds {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.SrcNamedPortIpSetIds) > 0 {
+		for _, s := range m.SrcNamedPortIpSetIds {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.DstNamedPortIpSetIds) > 0 {
+		for _, s := range m.DstNamedPortIpSetIds {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if m.NotProtocol != nil {
+		l = m.NotProtocol.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.NotSrcNet) > 0 {
+		for _, s := range m.NotSrcNet {
+			l = len(s)
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.NotSrcPorts) > 0 {
+		for _, e := range m.NotSrcPorts {
+			l = e.Size()
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.NotDstNet) > 0 {
+		for _, s := range m.NotDstNet {
+			l = len(s)
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.NotDstPorts) > 0 {
+		for _, e := range m.NotDstPorts {
+			l = e.Size()
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if m.NotIcmp != nil {
+		n += m.NotIcmp.Size()
+	}
+	if len(m.NotSrcIpSetIds) > 0 {
+		for _, s := range m.NotSrcIpSetIds {
+			l = len(s)
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.NotDstIpSetIds) > 0 {
+		for _, s := range m.NotDstIpSetIds {
+			l = len(s)
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.NotSrcNamedPortIpSetIds) > 0 {
+		for _, s := range m.NotSrcNamedPortIpSetIds {
+			l = len(s)
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.NotDstNamedPortIpSetIds) > 0 {
+		for _, s := range m.NotDstNamedPortIpSetIds {
+			l = len(s)
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	l = len(m.OriginalSrcSelector)
+	if l > 0 {
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.OriginalDstSelector)
+	if l > 0 {
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.OriginalSrcNamespaceSelector)
+	if l > 0 {
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.OriginalDstNamespaceSelector)
+	if l > 0 {
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.OriginalNotSrcSelector)
+	if l > 0 {
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.OriginalNotDstSelector)
+	if l > 0 {
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	if m.SrcServiceAccountMatch != nil {
+		l = m.SrcServiceAccountMatch.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	if m.DstServiceAccountMatch != nil {
+		l = m.DstServiceAccountMatch.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	if m.HttpMatch != nil {
+		l = m.HttpMatch.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.RuleId)
+	if l > 0 {
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Metadata != nil {
+		l = m.Metadata.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *Rule_IcmpType) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovFelixbackend(uint64(m.IcmpType))
+	return n
+}
+func (m *Rule_IcmpTypeCode) Size() (n int) {
+	var l int
+	_ = l
+	if m.IcmpTypeCode != nil {
+		l = m.IcmpTypeCode.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+func (m *Rule_NotIcmpType) Size() (n int) {
+	var l int
+	_ = l
+	n += 2 + sovFelixbackend(uint64(m.NotIcmpType))
+	return n
+}
+func (m *Rule_NotIcmpTypeCode) Size() (n int) {
+	var l int
+	_ = l
+	if m.NotIcmpTypeCode != nil {
+		l = m.NotIcmpTypeCode.Size()
+		n += 2 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+func (m *ServiceAccountMatch) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Selector)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.Names) > 0 {
+		for _, s := range m.Names {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HTTPMatch) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Methods) > 0 {
+		for _, s := range m.Methods {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Paths) > 0 {
+		for _, e := range m.Paths {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HTTPMatch_PathMatch) Size() (n int) {
+	var l int
+	_ = l
+	if m.PathMatch != nil {
+		n += m.PathMatch.Size()
+	}
+	return n
+}
+
+func (m *HTTPMatch_PathMatch_Exact) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Exact)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *HTTPMatch_PathMatch_Prefix) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *HTTPMatch_PathMatch_Regex) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Regex)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *HTTPMatch_HeaderMatch) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.HeaderMatch != nil {
+		n += m.HeaderMatch.Size()
+	}
+	return n
+}
+
+func (m *HTTPMatch_HeaderMatch_Exact) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Exact)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *HTTPMatch_HeaderMatch_Present) Size() (n int) {
+	var l int
+	_ = l
+	n += 2
+	return n
+}
+func (m *HTTPMatch_HeaderMatch_Prefix) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *IcmpTypeAndCode) Size() (n int) {
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Type))
+	}
+	if m.Code != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Code))
+	}
+	return n
+}
+
+func (m *Protocol) Size() (n int) {
+	var l int
+	_ = l
+	if m.NumberOrName != nil {
+		n += m.NumberOrName.Size()
+	}
+	return n
+}
+
+func (m *Protocol_Number) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovFelixbackend(uint64(m.Number))
+	return n
+}
+func (m *Protocol_Name) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	n += 1 + l + sovFelixbackend(uint64(l))
+	return n
+}
+func (m *PortRange) Size() (n int) {
+	var l int
+	_ = l
+	if m.First != 0 {
+		n += 1 + sovFelixbackend(uint64(m.First))
+	}
+	if m.Last != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Last))
+	}
+	return n
+}
+
+func (m *WorkloadEndpointID) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.OrchestratorId)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.WorkloadId)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.EndpointId)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *WorkloadEndpointUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Endpoint != nil {
+		l = m.Endpoint.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *WorkloadEndpoint) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.State)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Mac)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.ProfileIds) > 0 {
+		for _, s := range m.ProfileIds {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Ipv4Nets) > 0 {
+		for _, s := range m.Ipv4Nets {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Ipv6Nets) > 0 {
+		for _, s := range m.Ipv6Nets {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Tiers) > 0 {
+		for _, e := range m.Tiers {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Ipv4Nat) > 0 {
+		for _, e := range m.Ipv4Nat {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Ipv6Nat) > 0 {
+		for _, e := range m.Ipv6Nat {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *WorkloadEndpointRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *HostEndpointID) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.EndpointId)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *HostEndpointUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Endpoint != nil {
+		l = m.Endpoint.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *HostEndpoint) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.ProfileIds) > 0 {
+		for _, s := range m.ProfileIds {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Tiers) > 0 {
+		for _, e := range m.Tiers {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.ExpectedIpv4Addrs) > 0 {
+		for _, s := range m.ExpectedIpv4Addrs {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.ExpectedIpv6Addrs) > 0 {
+		for _, s := range m.ExpectedIpv6Addrs {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.UntrackedTiers) > 0 {
+		for _, e := range m.UntrackedTiers {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.PreDnatTiers) > 0 {
+		for _, e := range m.PreDnatTiers {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.ForwardTiers) > 0 {
+		for _, e := range m.ForwardTiers {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HostEndpointRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *TierInfo) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.IngressPolicies) > 0 {
+		for _, s := range m.IngressPolicies {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.EgressPolicies) > 0 {
+		for _, s := range m.EgressPolicies {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NatInfo) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.ExtIp)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.IntIp)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *ProcessStatusUpdate) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.IsoTimestamp)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Uptime != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *HostEndpointStatusUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Status != nil {
+		l = m.Status.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *EndpointStatus) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Status)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *HostEndpointStatusRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *WorkloadEndpointStatusUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Status != nil {
+		l = m.Status.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *WorkloadEndpointStatusRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *HostMetadataUpdate) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Ipv4Addr)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *HostMetadataRemove) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Hostname)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Ipv4Addr)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *IPAMPoolUpdate) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Pool != nil {
+		l = m.Pool.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *IPAMPoolRemove) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *IPAMPool) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Cidr)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Masquerade {
+		n += 2
+	}
+	return n
+}
+
+func (m *ServiceAccountUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
+			n += mapEntrySize + 1 + sovFelixbackend(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *ServiceAccountRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *ServiceAccountID) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *NamespaceUpdate) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
+			n += mapEntrySize + 1 + sovFelixbackend(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *NamespaceRemove) Size() (n int) {
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *NamespaceID) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *ServiceUpdate) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.ClusterIp)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.LoadbalancerIp)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.ExternalIps) > 0 {
+		for _, s := range m.ExternalIps {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.Ports) > 0 {
+		for _, e := range m.Ports {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.ClusterIps) > 0 {
+		for _, s := range m.ClusterIps {
+			l = len(s)
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ServicePort) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Protocol)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Port))
+	}
+	if m.NodePort != 0 {
+		n += 1 + sovFelixbackend(uint64(m.NodePort))
+	}
+	return n
+}
+
+func (m *ServiceRemove) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	return n
+}
+
+func (m *RuleMetadata) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Annotations) > 0 {
+		for k, v := range m.Annotations {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovFelixbackend(uint64(len(k))) + 1 + len(v) + sovFelixbackend(uint64(len(v)))
+			n += mapEntrySize + 1 + sovFelixbackend(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *DataplaneStats) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.SrcIp)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.DstIp)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.SrcPort != 0 {
+		n += 1 + sovFelixbackend(uint64(m.SrcPort))
+	}
+	if m.DstPort != 0 {
+		n += 1 + sovFelixbackend(uint64(m.DstPort))
+	}
+	if m.Protocol != nil {
+		l = m.Protocol.Size()
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if len(m.Stats) > 0 {
+		for _, e := range m.Stats {
+			l = e.Size()
+			n += 1 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.WafRuleHits) > 0 {
+		for _, e := range m.WafRuleHits {
+			l = e.Size()
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	if len(m.PolicyHits) > 0 {
+		for _, e := range m.PolicyHits {
+			l = e.Size()
+			n += 2 + l + sovFelixbackend(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Statistic) Size() (n int) {
+	var l int
+	_ = l
+	if m.Direction != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Direction))
+	}
+	if m.Relativity != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Relativity))
+	}
+	if m.Kind != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Kind))
+	}
+	if m.Action != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Action))
+	}
+	if m.Value != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Value))
+	}
+	return n
+}
+
+func (m *WAFRuleHit) Size() (n int) {
+	var l int
+	_ = l
+	if m.RuleId != 0 {
+		n += 1 + sovFelixbackend(uint64(m.RuleId))
+	}
+	l = len(m.Severity)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Blocked {
+		n += 2
+	}
+	if m.Count != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *PolicyHit) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Tier)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovFelixbackend(uint64(l))
+	}
+	if m.Enforced {
+		n += 2
+	}
+	if m.Count != 0 {
+		n += 1 + sovFelixbackend(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *ReportResult) Size() (n int) {
+	var l int
+	_ = l
+	if m.Successful {
+		n += 2
+	}
+	return n
+}
+
+func sovFelixbackend(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozFelixbackend(x uint64) (n int) {
+	return sovFelixbackend(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SyncRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SyncRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SyncRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ToDataplane) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ToDataplane: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ToDataplane: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InSync", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &InSync{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_InSync{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpsetUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IPSetUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_IpsetUpdate{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpsetDeltaUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IPSetDeltaUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_IpsetDeltaUpdate{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpsetRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IPSetRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_IpsetRemove{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveProfileUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ActiveProfileUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ActiveProfileUpdate{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActiveProfileRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ActiveProfileRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ActiveProfileRemove{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivePolicyUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ActivePolicyUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ActivePolicyUpdate{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActivePolicyRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ActivePolicyRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ActivePolicyRemove{v}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostEndpointUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HostEndpointUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_HostEndpointUpdate{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostEndpointRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HostEndpointRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_HostEndpointRemove{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadEndpointUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &WorkloadEndpointUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_WorkloadEndpointUpdate{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadEndpointRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &WorkloadEndpointRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_WorkloadEndpointRemove{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ConfigUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ConfigUpdate{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostMetadataUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HostMetadataUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_HostMetadataUpdate{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
+			}
+			m.SequenceNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequenceNumber |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpamPoolUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IPAMPoolUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_IpamPoolUpdate{v}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpamPoolRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IPAMPoolRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_IpamPoolRemove{v}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostMetadataRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HostMetadataRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_HostMetadataRemove{v}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceAccountUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ServiceAccountUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ServiceAccountUpdate{v}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceAccountRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ServiceAccountRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ServiceAccountRemove{v}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NamespaceUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_NamespaceUpdate{v}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NamespaceRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_NamespaceRemove{v}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ServiceUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ServiceUpdate{v}
+			iNdEx = postIndex
+		case 24:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServiceRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ServiceRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &ToDataplane_ServiceRemove{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FromDataplane) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FromDataplane: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FromDataplane: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessStatusUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ProcessStatusUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &FromDataplane_ProcessStatusUpdate{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostEndpointStatusUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HostEndpointStatusUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &FromDataplane_HostEndpointStatusUpdate{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HostEndpointStatusRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HostEndpointStatusRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &FromDataplane_HostEndpointStatusRemove{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadEndpointStatusUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &WorkloadEndpointStatusUpdate{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &FromDataplane_WorkloadEndpointStatusUpdate{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadEndpointStatusRemove", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &WorkloadEndpointStatusRemove{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &FromDataplane_WorkloadEndpointStatusRemove{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
+			}
+			m.SequenceNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequenceNumber |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Config == nil {
+				m.Config = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowFelixbackend
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipFelixbackend(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Config[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
-
-func (m *NamespaceRemove) Size() (n int) {
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = m.Id.Size()
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	return n
-}
-
-func (m *NamespaceID) Size() (n int) {
-	var l int
-	_ = l
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovFelixbackend(uint64(l))
-	}
-	return n
-}
 
-func sovFelixbackend(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozFelixbackend(x uint64) (n int) {
-	return sovFelixbackend(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *SyncRequest) Unmarshal(dAtA []byte) error {
+func (m *InSync) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6922,10 +9823,10 @@ func (m *SyncRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SyncRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: InSync: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SyncRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: InSync: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -6949,7 +9850,7 @@ func (m *SyncRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ToDataplane) Unmarshal(dAtA []byte) error {
+func (m *IPSetUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6972,17 +9873,17 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ToDataplane: wiretype end group for non-group")
+			return fmt.Errorf("proto: IPSetUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ToDataplane: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IPSetUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InSync", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -6992,29 +9893,26 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &InSync{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Payload = &ToDataplane_InSync{v}
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IpsetUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7024,29 +9922,26 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &IPSetUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Payload = &ToDataplane_IpsetUpdate{v}
+			m.Members = append(m.Members, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IpsetDeltaUpdate", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var msglen int
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7056,93 +9951,66 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Type |= (IPSetUpdate_IPSetType(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			v := &IPSetDeltaUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_IpsetDeltaUpdate{v}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IpsetRemove", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &IPSetRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Payload = &ToDataplane_IpsetRemove{v}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActiveProfileUpdate", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IPSetDeltaUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ActiveProfileUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			m.Payload = &ToDataplane_ActiveProfileUpdate{v}
-			iNdEx = postIndex
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IPSetDeltaUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IPSetDeltaUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActiveProfileRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7152,29 +10020,26 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ActiveProfileRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Payload = &ToDataplane_ActiveProfileRemove{v}
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivePolicyUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AddedMembers", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7184,29 +10049,26 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ActivePolicyUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Payload = &ToDataplane_ActivePolicyUpdate{v}
+			m.AddedMembers = append(m.AddedMembers, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 8:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ActivePolicyRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RemovedMembers", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7216,61 +10078,76 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ActivePolicyRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.RemovedMembers = append(m.RemovedMembers, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_ActivePolicyRemove{v}
-			iNdEx = postIndex
-		case 9:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HostEndpointUpdate", wireType)
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IPSetRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &HostEndpointUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			m.Payload = &ToDataplane_HostEndpointUpdate{v}
-			iNdEx = postIndex
-		case 10:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IPSetRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IPSetRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HostEndpointRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7280,27 +10157,74 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &HostEndpointRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_HostEndpointRemove{v}
-			iNdEx = postIndex
-		case 11:
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActiveProfileUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActiveProfileUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActiveProfileUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadEndpointUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7324,15 +10248,16 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &WorkloadEndpointUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Id == nil {
+				m.Id = &ProfileID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_WorkloadEndpointUpdate{v}
 			iNdEx = postIndex
-		case 12:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadEndpointRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7356,15 +10281,66 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &WorkloadEndpointRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Profile == nil {
+				m.Profile = &Profile{}
+			}
+			if err := m.Profile.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_WorkloadEndpointRemove{v}
 			iNdEx = postIndex
-		case 13:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActiveProfileRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActiveProfileRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActiveProfileRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConfigUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7388,68 +10364,68 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ConfigUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Id == nil {
+				m.Id = &ProfileID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_ConfigUpdate{v}
 			iNdEx = postIndex
-		case 14:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HostMetadataUpdate", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &HostMetadataUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProfileID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			m.Payload = &ToDataplane_HostMetadataUpdate{v}
-			iNdEx = postIndex
-		case 15:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			m.SequenceNumber = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SequenceNumber |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-		case 16:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProfileID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProfileID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IpamPoolUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7459,59 +10435,74 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &IPAMPoolUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_IpamPoolUpdate{v}
-			iNdEx = postIndex
-		case 17:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IpamPoolRemove", wireType)
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Profile) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &IPAMPoolRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			m.Payload = &ToDataplane_IpamPoolRemove{v}
-			iNdEx = postIndex
-		case 18:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Profile: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Profile: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HostMetadataRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InboundRules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7535,15 +10526,14 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &HostMetadataRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.InboundRules = append(m.InboundRules, &Rule{})
+			if err := m.InboundRules[len(m.InboundRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_HostMetadataRemove{v}
 			iNdEx = postIndex
-		case 19:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceAccountUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OutboundRules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7567,15 +10557,64 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ServiceAccountUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.OutboundRules = append(m.OutboundRules, &Rule{})
+			if err := m.OutboundRules[len(m.OutboundRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_ServiceAccountUpdate{v}
 			iNdEx = postIndex
-		case 20:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivePolicyUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivePolicyUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivePolicyUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ServiceAccountRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7599,15 +10638,16 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ServiceAccountRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Id == nil {
+				m.Id = &PolicyID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_ServiceAccountRemove{v}
 			iNdEx = postIndex
-		case 21:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7631,15 +10671,66 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &NamespaceUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Policy == nil {
+				m.Policy = &Policy{}
+			}
+			if err := m.Policy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_NamespaceUpdate{v}
 			iNdEx = postIndex
-		case 22:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActivePolicyRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActivePolicyRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActivePolicyRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7663,11 +10754,12 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &NamespaceRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Id == nil {
+				m.Id = &PolicyID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &ToDataplane_NamespaceRemove{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -7690,7 +10782,7 @@ func (m *ToDataplane) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FromDataplane) Unmarshal(dAtA []byte) error {
+func (m *PolicyID) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7713,17 +10805,17 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FromDataplane: wiretype end group for non-group")
+			return fmt.Errorf("proto: PolicyID: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FromDataplane: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PolicyID: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 3:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProcessStatusUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tier", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7733,29 +10825,26 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ProcessStatusUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Payload = &FromDataplane_ProcessStatusUpdate{v}
+			m.Tier = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HostEndpointStatusUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7765,27 +10854,74 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &HostEndpointStatusUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			m.Payload = &FromDataplane_HostEndpointStatusUpdate{v}
-			iNdEx = postIndex
-		case 5:
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Policy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Policy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Policy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HostEndpointStatusRemove", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InboundRules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7809,15 +10945,14 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &HostEndpointStatusRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.InboundRules = append(m.InboundRules, &Rule{})
+			if err := m.InboundRules[len(m.InboundRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &FromDataplane_HostEndpointStatusRemove{v}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadEndpointStatusUpdate", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OutboundRules", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7841,17 +10976,16 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &WorkloadEndpointStatusUpdate{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.OutboundRules = append(m.OutboundRules, &Rule{})
+			if err := m.OutboundRules[len(m.OutboundRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Payload = &FromDataplane_WorkloadEndpointStatusUpdate{v}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadEndpointStatusRemove", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Untracked", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7861,29 +10995,37 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Untracked = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreDnat", wireType)
 			}
-			v := &WorkloadEndpointStatusRemove{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Payload = &FromDataplane_WorkloadEndpointStatusRemove{v}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
+			m.PreDnat = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			m.SequenceNumber = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7893,11 +11035,21 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SequenceNumber |= (uint64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -7919,7 +11071,7 @@ func (m *FromDataplane) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConfigUpdate) Unmarshal(dAtA []byte) error {
+func (m *Rule) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7942,17 +11094,17 @@ func (m *ConfigUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConfigUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: Rule: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConfigUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Rule: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -7962,213 +11114,136 @@ func (m *ConfigUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Config == nil {
-				m.Config = make(map[string]string)
+			m.Action = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IpVersion", wireType)
 			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowFelixbackend
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			m.IpVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipFelixbackend(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IpVersion |= (IPVersion(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Config[mapkey] = mapvalue
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *InSync) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			if m.Protocol == nil {
+				m.Protocol = &Protocol{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if err := m.Protocol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcNet", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: InSync: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: InSync: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *IPSetUpdate) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			m.SrcNet = append(m.SrcNet, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcPorts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.SrcPorts = append(m.SrcPorts, &PortRange{})
+			if err := m.SrcPorts[len(m.SrcPorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: IPSetUpdate: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IPSetUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DstNet", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8193,13 +11268,13 @@ func (m *IPSetUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.DstNet = append(m.DstNet, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DstPorts", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -8209,26 +11284,28 @@ func (m *IPSetUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, string(dAtA[iNdEx:postIndex]))
+			m.DstPorts = append(m.DstPorts, &PortRange{})
+			if err := m.DstPorts[len(m.DstPorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IcmpType", wireType)
 			}
-			m.Type = 0
+			var v int32
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -8238,64 +11315,47 @@ func (m *IPSetUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= (IPSetUpdate_IPSetType(b) & 0x7F) << shift
+				v |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
+			m.Icmp = &Rule_IcmpType{v}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IcmpTypeCode", wireType)
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *IPSetDeltaUpdate) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-			if iNdEx >= l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			v := &IcmpTypeAndCode{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: IPSetDeltaUpdate: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IPSetDeltaUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Icmp = &Rule_IcmpTypeCode{v}
+			iNdEx = postIndex
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcIpSetIds", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8320,11 +11380,11 @@ func (m *IPSetDeltaUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.SrcIpSetIds = append(m.SrcIpSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AddedMembers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DstIpSetIds", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8349,11 +11409,11 @@ func (m *IPSetDeltaUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AddedMembers = append(m.AddedMembers, string(dAtA[iNdEx:postIndex]))
+			m.DstIpSetIds = append(m.DstIpSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 3:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RemovedMembers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcNamedPortIpSetIds", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8378,61 +11438,11 @@ func (m *IPSetDeltaUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RemovedMembers = append(m.RemovedMembers, string(dAtA[iNdEx:postIndex]))
+			m.SrcNamedPortIpSetIds = append(m.SrcNamedPortIpSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *IPSetRemove) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: IPSetRemove: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IPSetRemove: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DstNamedPortIpSetIds", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8457,61 +11467,11 @@ func (m *IPSetRemove) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.DstNamedPortIpSetIds = append(m.DstNamedPortIpSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ActiveProfileUpdate) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActiveProfileUpdate: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActiveProfileUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 102:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotProtocol", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8535,18 +11495,18 @@ func (m *ActiveProfileUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ProfileID{}
+			if m.NotProtocol == nil {
+				m.NotProtocol = &Protocol{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.NotProtocol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 103:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotSrcNet", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -8556,78 +11516,24 @@ func (m *ActiveProfileUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Profile == nil {
-				m.Profile = &Profile{}
-			}
-			if err := m.Profile.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NotSrcNet = append(m.NotSrcNet, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ActiveProfileRemove) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActiveProfileRemove: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActiveProfileRemove: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 104:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotSrcPorts", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -8651,66 +11557,14 @@ func (m *ActiveProfileRemove) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ProfileID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.NotSrcPorts = append(m.NotSrcPorts, &PortRange{})
+			if err := m.NotSrcPorts[len(m.NotSrcPorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ProfileID) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ProfileID: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProfileID: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 105:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotDstNet", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -8735,63 +11589,96 @@ func (m *ProfileID) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.NotDstNet = append(m.NotDstNet, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 106:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotDstPorts", wireType)
 			}
-			if skippy < 0 {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Profile) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			m.NotDstPorts = append(m.NotDstPorts, &PortRange{})
+			if err := m.NotDstPorts[len(m.NotDstPorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			iNdEx = postIndex
+		case 107:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotIcmpType", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Profile: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Profile: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.NotIcmp = &Rule_NotIcmpType{v}
+		case 108:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NotIcmpTypeCode", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &IcmpTypeAndCode{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.NotIcmp = &Rule_NotIcmpTypeCode{v}
+			iNdEx = postIndex
+		case 109:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InboundRules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotSrcIpSetIds", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -8801,28 +11688,26 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InboundRules = append(m.InboundRules, &Rule{})
-			if err := m.InboundRules[len(m.InboundRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NotSrcIpSetIds = append(m.NotSrcIpSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 110:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OutboundRules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotDstIpSetIds", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -8832,78 +11717,26 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OutboundRules = append(m.OutboundRules, &Rule{})
-			if err := m.OutboundRules[len(m.OutboundRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NotDstIpSetIds = append(m.NotDstIpSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ActivePolicyUpdate) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActivePolicyUpdate: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActivePolicyUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 112:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotSrcNamedPortIpSetIds", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -8913,30 +11746,26 @@ func (m *ActivePolicyUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &PolicyID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NotSrcNamedPortIpSetIds = append(m.NotSrcNamedPortIpSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 113:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NotDstNamedPortIpSetIds", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -8946,80 +11775,26 @@ func (m *ActivePolicyUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Policy == nil {
-				m.Policy = &Policy{}
-			}
-			if err := m.Policy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.NotDstNamedPortIpSetIds = append(m.NotDstNamedPortIpSetIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ActivePolicyRemove) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ActivePolicyRemove: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ActivePolicyRemove: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 114:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginalSrcSelector", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9029,78 +11804,53 @@ func (m *ActivePolicyRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &PolicyID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.OriginalSrcSelector = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 115:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginalDstSelector", wireType)
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *PolicyID) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PolicyID: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PolicyID: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.OriginalDstSelector = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 116:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tier", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginalSrcNamespaceSelector", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9125,11 +11875,11 @@ func (m *PolicyID) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tier = string(dAtA[iNdEx:postIndex])
+			m.OriginalSrcNamespaceSelector = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 117:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginalDstNamespaceSelector", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9154,61 +11904,69 @@ func (m *PolicyID) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.OriginalDstNamespaceSelector = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 118:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginalNotSrcSelector", wireType)
 			}
-			if skippy < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Policy) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			m.OriginalNotSrcSelector = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 119:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginalNotDstSelector", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Policy: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Policy: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OriginalNotDstSelector = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 120:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InboundRules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcServiceAccountMatch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9232,14 +11990,16 @@ func (m *Policy) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.InboundRules = append(m.InboundRules, &Rule{})
-			if err := m.InboundRules[len(m.InboundRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.SrcServiceAccountMatch == nil {
+				m.SrcServiceAccountMatch = &ServiceAccountMatch{}
+			}
+			if err := m.SrcServiceAccountMatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 121:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OutboundRules", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DstServiceAccountMatch", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9263,16 +12023,18 @@ func (m *Policy) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OutboundRules = append(m.OutboundRules, &Rule{})
-			if err := m.OutboundRules[len(m.OutboundRules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.DstServiceAccountMatch == nil {
+				m.DstServiceAccountMatch = &ServiceAccountMatch{}
+			}
+			if err := m.DstServiceAccountMatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Untracked", wireType)
+		case 122:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HttpMatch", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9282,17 +12044,30 @@ func (m *Policy) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Untracked = bool(v != 0)
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PreDnat", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-			var v int
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HttpMatch == nil {
+				m.HttpMatch = &HTTPMatch{}
+			}
+			if err := m.HttpMatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 201:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RuleId", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9302,17 +12077,26 @@ func (m *Policy) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.PreDnat = bool(v != 0)
-		case 5:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RuleId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 202:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9322,20 +12106,24 @@ func (m *Policy) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			if m.Metadata == nil {
+				m.Metadata = &RuleMetadata{}
+			}
+			if err := m.Metadata.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -9358,7 +12146,7 @@ func (m *Policy) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Rule) Unmarshal(dAtA []byte) error {
+func (m *ServiceAccountMatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -9381,65 +12169,17 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Rule: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServiceAccountMatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Rule: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Action = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IpVersion", wireType)
-			}
-			m.IpVersion = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.IpVersion |= (IPVersion(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
+			return fmt.Errorf("proto: ServiceAccountMatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Selector", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9449,28 +12189,24 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Protocol == nil {
-				m.Protocol = &Protocol{}
-			}
-			if err := m.Protocol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Selector = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SrcNet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9495,42 +12231,61 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SrcNet = append(m.SrcNet, string(dAtA[iNdEx:postIndex]))
+			m.Names = append(m.Names, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SrcPorts", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			if msglen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SrcPorts = append(m.SrcPorts, &PortRange{})
-			if err := m.SrcPorts[len(m.SrcPorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HTTPMatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			iNdEx = postIndex
-		case 6:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HTTPMatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HTTPMatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DstNet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Methods", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9555,11 +12310,11 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DstNet = append(m.DstNet, string(dAtA[iNdEx:postIndex]))
+			m.Methods = append(m.Methods, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 7:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DstPorts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Paths", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -9583,16 +12338,16 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DstPorts = append(m.DstPorts, &PortRange{})
-			if err := m.DstPorts[len(m.DstPorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Paths = append(m.Paths, &HTTPMatch_PathMatch{})
+			if err := m.Paths[len(m.Paths)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IcmpType", wireType)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
 			}
-			var v int32
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9602,17 +12357,78 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Icmp = &Rule_IcmpType{v}
-		case 9:
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Headers = append(m.Headers, &HTTPMatch_HeaderMatch{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HTTPMatch_PathMatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PathMatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PathMatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IcmpTypeCode", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Exact", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9622,27 +12438,24 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &IcmpTypeAndCode{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Icmp = &Rule_IcmpTypeCode{v}
+			m.PathMatch = &HTTPMatch_PathMatch_Exact{string(dAtA[iNdEx:postIndex])}
 			iNdEx = postIndex
-		case 10:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SrcIpSetIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9667,11 +12480,11 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SrcIpSetIds = append(m.SrcIpSetIds, string(dAtA[iNdEx:postIndex]))
+			m.PathMatch = &HTTPMatch_PathMatch_Prefix{string(dAtA[iNdEx:postIndex])}
 			iNdEx = postIndex
-		case 11:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DstIpSetIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Regex", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9696,11 +12509,61 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DstIpSetIds = append(m.DstIpSetIds, string(dAtA[iNdEx:postIndex]))
+			m.PathMatch = &HTTPMatch_PathMatch_Regex{string(dAtA[iNdEx:postIndex])}
 			iNdEx = postIndex
-		case 12:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HTTPMatch_HeaderMatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeaderMatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeaderMatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SrcNamedPortIpSetIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9725,11 +12588,11 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SrcNamedPortIpSetIds = append(m.SrcNamedPortIpSetIds, string(dAtA[iNdEx:postIndex]))
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 13:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DstNamedPortIpSetIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Exact", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9754,13 +12617,13 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DstNamedPortIpSetIds = append(m.DstNamedPortIpSetIds, string(dAtA[iNdEx:postIndex]))
+			m.HeaderMatch = &HTTPMatch_HeaderMatch_Exact{string(dAtA[iNdEx:postIndex])}
 			iNdEx = postIndex
-		case 102:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotProtocol", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Present", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9770,28 +12633,16 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.NotProtocol == nil {
-				m.NotProtocol = &Protocol{}
-			}
-			if err := m.NotProtocol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 103:
+			b := bool(v != 0)
+			m.HeaderMatch = &HTTPMatch_HeaderMatch_Present{b}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotSrcNet", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9816,13 +12667,63 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NotSrcNet = append(m.NotSrcNet, string(dAtA[iNdEx:postIndex]))
+			m.HeaderMatch = &HTTPMatch_HeaderMatch_Prefix{string(dAtA[iNdEx:postIndex])}
 			iNdEx = postIndex
-		case 104:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotSrcPorts", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var msglen int
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IcmpTypeAndCode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IcmpTypeAndCode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9832,28 +12733,85 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Type |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NotSrcPorts = append(m.NotSrcPorts, &PortRange{})
-			if err := m.NotSrcPorts[len(m.NotSrcPorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Protocol) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			iNdEx = postIndex
-		case 105:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotDstNet", wireType)
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			var stringLen uint64
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Protocol: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Protocol: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Number", wireType)
+			}
+			var v int32
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9863,26 +12821,17 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.NotDstNet = append(m.NotDstNet, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 106:
+			m.NumberOrName = &Protocol_Number{v}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotDstPorts", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9892,28 +12841,76 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NotDstPorts = append(m.NotDstPorts, &PortRange{})
-			if err := m.NotDstPorts[len(m.NotDstPorts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.NumberOrName = &Protocol_Name{string(dAtA[iNdEx:postIndex])}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 107:
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PortRange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PortRange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PortRange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotIcmpType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field First", wireType)
 			}
-			var v int32
+			m.First = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9923,17 +12920,16 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int32(b) & 0x7F) << shift
+				m.First |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.NotIcmp = &Rule_NotIcmpType{v}
-		case 108:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotIcmpTypeCode", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
 			}
-			var msglen int
+			m.Last = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -9943,27 +12939,64 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Last |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &IcmpTypeAndCode{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			m.NotIcmp = &Rule_NotIcmpTypeCode{v}
-			iNdEx = postIndex
-		case 109:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkloadEndpointID: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkloadEndpointID: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotSrcIpSetIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OrchestratorId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -9988,11 +13021,11 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NotSrcIpSetIds = append(m.NotSrcIpSetIds, string(dAtA[iNdEx:postIndex]))
+			m.OrchestratorId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 110:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotDstIpSetIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10017,11 +13050,11 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NotDstIpSetIds = append(m.NotDstIpSetIds, string(dAtA[iNdEx:postIndex]))
+			m.WorkloadId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 112:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotSrcNamedPortIpSetIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndpointId", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10046,42 +13079,63 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NotSrcNamedPortIpSetIds = append(m.NotSrcNamedPortIpSetIds, string(dAtA[iNdEx:postIndex]))
+			m.EndpointId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 113:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NotDstNamedPortIpSetIds", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NotDstNamedPortIpSetIds = append(m.NotDstNamedPortIpSetIds, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 114:
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkloadEndpointUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkloadEndpointUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginalSrcSelector", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10091,26 +13145,30 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OriginalSrcSelector = string(dAtA[iNdEx:postIndex])
+			if m.Id == nil {
+				m.Id = &WorkloadEndpointID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 115:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginalDstSelector", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10120,53 +13178,78 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OriginalDstSelector = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 116:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginalSrcNamespaceSelector", wireType)
+			if m.Endpoint == nil {
+				m.Endpoint = &WorkloadEndpoint{}
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OriginalSrcNamespaceSelector = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 117:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkloadEndpoint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkloadEndpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginalDstNamespaceSelector", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10191,11 +13274,11 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OriginalDstNamespaceSelector = string(dAtA[iNdEx:postIndex])
+			m.State = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 118:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginalNotSrcSelector", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10220,11 +13303,11 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OriginalNotSrcSelector = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 119:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginalNotDstSelector", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Mac", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10249,79 +13332,13 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OriginalNotDstSelector = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 120:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SrcServiceAccountMatch", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.SrcServiceAccountMatch == nil {
-				m.SrcServiceAccountMatch = &ServiceAccountMatch{}
-			}
-			if err := m.SrcServiceAccountMatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 121:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DstServiceAccountMatch", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.DstServiceAccountMatch == nil {
-				m.DstServiceAccountMatch = &ServiceAccountMatch{}
-			}
-			if err := m.DstServiceAccountMatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Mac = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 122:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field HttpMatch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ProfileIds", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10331,28 +13348,24 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.HttpMatch == nil {
-				m.HttpMatch = &HTTPMatch{}
-			}
-			if err := m.HttpMatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ProfileIds = append(m.ProfileIds, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 201:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RuleId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Nets", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10377,61 +13390,11 @@ func (m *Rule) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.RuleId = string(dAtA[iNdEx:postIndex])
+			m.Ipv4Nets = append(m.Ipv4Nets, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ServiceAccountMatch) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceAccountMatch: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceAccountMatch: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Selector", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv6Nets", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -10456,13 +13419,13 @@ func (m *ServiceAccountMatch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Selector = string(dAtA[iNdEx:postIndex])
+			m.Ipv6Nets = append(m.Ipv6Nets, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10472,76 +13435,28 @@ func (m *ServiceAccountMatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Names = append(m.Names, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
+			m.Tiers = append(m.Tiers, &TierInfo{})
+			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *HTTPMatch) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: HTTPMatch: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HTTPMatch: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Methods", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Nat", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10551,24 +13466,26 @@ func (m *HTTPMatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Methods = append(m.Methods, string(dAtA[iNdEx:postIndex]))
+			m.Ipv4Nat = append(m.Ipv4Nat, &NatInfo{})
+			if err := m.Ipv4Nat[len(m.Ipv4Nat)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Paths", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv6Nat", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -10592,8 +13509,8 @@ func (m *HTTPMatch) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Paths = append(m.Paths, &HTTPMatch_PathMatch{})
-			if err := m.Paths[len(m.Paths)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Ipv6Nat = append(m.Ipv6Nat, &NatInfo{})
+			if err := m.Ipv6Nat[len(m.Ipv6Nat)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -10618,7 +13535,7 @@ func (m *HTTPMatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HTTPMatch_PathMatch) Unmarshal(dAtA []byte) error {
+func (m *WorkloadEndpointRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10641,17 +13558,17 @@ func (m *HTTPMatch_PathMatch) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PathMatch: wiretype end group for non-group")
+			return fmt.Errorf("proto: WorkloadEndpointRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PathMatch: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WorkloadEndpointRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Exact", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10661,49 +13578,24 @@ func (m *HTTPMatch_PathMatch) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PathMatch = &HTTPMatch_PathMatch_Exact{string(dAtA[iNdEx:postIndex])}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
+			if m.Id == nil {
+				m.Id = &WorkloadEndpointID{}
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.PathMatch = &HTTPMatch_PathMatch_Prefix{string(dAtA[iNdEx:postIndex])}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10726,7 +13618,7 @@ func (m *HTTPMatch_PathMatch) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
+func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10749,17 +13641,17 @@ func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IcmpTypeAndCode: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostEndpointID: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IcmpTypeAndCode: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostEndpointID: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndpointId", wireType)
 			}
-			m.Type = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10769,30 +13661,21 @@ func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= (int32(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.EndpointId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -10814,7 +13697,7 @@ func (m *IcmpTypeAndCode) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Protocol) Unmarshal(dAtA []byte) error {
+func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10837,17 +13720,17 @@ func (m *Protocol) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Protocol: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostEndpointUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Protocol: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostEndpointUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Number", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var v int32
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10857,17 +13740,30 @@ func (m *Protocol) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.NumberOrName = &Protocol_Number{v}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &HostEndpointID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10877,20 +13773,24 @@ func (m *Protocol) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NumberOrName = &Protocol_Name{string(dAtA[iNdEx:postIndex])}
+			if m.Endpoint == nil {
+				m.Endpoint = &HostEndpoint{}
+			}
+			if err := m.Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -10913,7 +13813,7 @@ func (m *Protocol) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PortRange) Unmarshal(dAtA []byte) error {
+func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -10936,17 +13836,17 @@ func (m *PortRange) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PortRange: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostEndpoint: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PortRange: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostEndpoint: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field First", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			m.First = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10956,16 +13856,26 @@ func (m *PortRange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.First |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProfileIds", wireType)
 			}
-			m.Last = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -10975,64 +13885,55 @@ func (m *PortRange) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Last |= (int32(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			m.ProfileIds = append(m.ProfileIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointID: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointID: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 2:
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tiers = append(m.Tiers, &TierInfo{})
+			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrchestratorId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedIpv4Addrs", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11057,11 +13958,11 @@ func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.OrchestratorId = string(dAtA[iNdEx:postIndex])
+			m.ExpectedIpv4Addrs = append(m.ExpectedIpv4Addrs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 3:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field WorkloadId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedIpv6Addrs", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11086,13 +13987,13 @@ func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.WorkloadId = string(dAtA[iNdEx:postIndex])
+			m.ExpectedIpv6Addrs = append(m.ExpectedIpv6Addrs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 4:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndpointId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UntrackedTiers", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -11102,20 +14003,84 @@ func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EndpointId = string(dAtA[iNdEx:postIndex])
+			m.UntrackedTiers = append(m.UntrackedTiers, &TierInfo{})
+			if err := m.UntrackedTiers[len(m.UntrackedTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreDnatTiers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreDnatTiers = append(m.PreDnatTiers, &TierInfo{})
+			if err := m.PreDnatTiers[len(m.PreDnatTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ForwardTiers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ForwardTiers = append(m.ForwardTiers, &TierInfo{})
+			if err := m.ForwardTiers[len(m.ForwardTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11138,7 +14103,7 @@ func (m *WorkloadEndpointID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
+func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11161,10 +14126,10 @@ func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostEndpointRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostEndpointRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11194,45 +14159,12 @@ func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Id == nil {
-				m.Id = &WorkloadEndpointID{}
+				m.Id = &HostEndpointID{}
 			}
 			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Endpoint == nil {
-				m.Endpoint = &WorkloadEndpoint{}
-			}
-			if err := m.Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -11254,7 +14186,7 @@ func (m *WorkloadEndpointUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
+func (m *TierInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11277,15 +14209,15 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpoint: wiretype end group for non-group")
+			return fmt.Errorf("proto: TierInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TierInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11310,11 +14242,11 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.State = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IngressPolicies", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11339,11 +14271,11 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.IngressPolicies = append(m.IngressPolicies, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Mac", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EgressPolicies", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11368,11 +14300,61 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Mac = string(dAtA[iNdEx:postIndex])
+			m.EgressPolicies = append(m.EgressPolicies, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 4:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NatInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NatInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NatInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProfileIds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtIp", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11397,11 +14379,11 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ProfileIds = append(m.ProfileIds, string(dAtA[iNdEx:postIndex]))
+			m.ExtIp = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Nets", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IntIp", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11426,11 +14408,61 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv4Nets = append(m.Ipv4Nets, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 6:
+			m.IntIp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProcessStatusUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProcessStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv6Nets", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field IsoTimestamp", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11455,42 +14487,72 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv6Nets = append(m.Ipv6Nets, string(dAtA[iNdEx:postIndex]))
+			m.IsoTimestamp = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
 			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
 			}
-			if msglen < 0 {
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Uptime = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tiers = append(m.Tiers, &TierInfo{})
-			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
 			}
-			iNdEx = postIndex
-		case 8:
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HostEndpointStatusUpdate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HostEndpointStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Nat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11514,14 +14576,16 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv4Nat = append(m.Ipv4Nat, &NatInfo{})
-			if err := m.Ipv4Nat[len(m.Ipv4Nat)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Id == nil {
+				m.Id = &HostEndpointID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv6Nat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11545,8 +14609,10 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv6Nat = append(m.Ipv6Nat, &NatInfo{})
-			if err := m.Ipv6Nat[len(m.Ipv6Nat)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Status == nil {
+				m.Status = &EndpointStatus{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11571,7 +14637,7 @@ func (m *WorkloadEndpoint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpointRemove) Unmarshal(dAtA []byte) error {
+func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11594,17 +14660,17 @@ func (m *WorkloadEndpointRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: EndpointStatus: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EndpointStatus: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -11614,24 +14680,20 @@ func (m *WorkloadEndpointRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &WorkloadEndpointID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Status = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11654,7 +14716,7 @@ func (m *WorkloadEndpointRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
+func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11677,17 +14739,17 @@ func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointID: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostEndpointStatusRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostEndpointStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndpointId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -11697,20 +14759,24 @@ func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EndpointId = string(dAtA[iNdEx:postIndex])
+			if m.Id == nil {
+				m.Id = &HostEndpointID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -11733,7 +14799,7 @@ func (m *HostEndpointID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
+func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11756,10 +14822,10 @@ func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: WorkloadEndpointStatusUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WorkloadEndpointStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -11789,15 +14855,98 @@ func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Id == nil {
-				m.Id = &HostEndpointID{}
+				m.Id = &WorkloadEndpointID{}
 			}
 			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Status == nil {
+				m.Status = &EndpointStatus{}
+			}
+			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WorkloadEndpointStatusRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WorkloadEndpointStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -11821,10 +14970,10 @@ func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Endpoint == nil {
-				m.Endpoint = &HostEndpoint{}
+			if m.Id == nil {
+				m.Id = &WorkloadEndpointID{}
 			}
-			if err := m.Endpoint.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -11849,7 +14998,7 @@ func (m *HostEndpointUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
+func (m *HostMetadataUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -11872,15 +15021,15 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpoint: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostMetadataUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpoint: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostMetadataUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -11905,100 +15054,11 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Hostname = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ProfileIds", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ProfileIds = append(m.ProfileIds, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Tiers = append(m.Tiers, &TierInfo{})
-			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedIpv4Addrs", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ExpectedIpv4Addrs = append(m.ExpectedIpv4Addrs, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedIpv6Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Addr", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12023,100 +15083,7 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExpectedIpv6Addrs = append(m.ExpectedIpv6Addrs, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UntrackedTiers", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.UntrackedTiers = append(m.UntrackedTiers, &TierInfo{})
-			if err := m.UntrackedTiers[len(m.UntrackedTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PreDnatTiers", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.PreDnatTiers = append(m.PreDnatTiers, &TierInfo{})
-			if err := m.PreDnatTiers[len(m.PreDnatTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ForwardTiers", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ForwardTiers = append(m.ForwardTiers, &TierInfo{})
-			if err := m.ForwardTiers[len(m.ForwardTiers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Ipv4Addr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12139,7 +15106,7 @@ func (m *HostEndpoint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
+func (m *HostMetadataRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12162,17 +15129,17 @@ func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: HostMetadataRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HostMetadataRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -12182,24 +15149,49 @@ func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Addr", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &HostEndpointID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Ipv4Addr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12222,7 +15214,7 @@ func (m *HostEndpointRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TierInfo) Unmarshal(dAtA []byte) error {
+func (m *IPAMPoolUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12245,15 +15237,15 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TierInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: IPAMPoolUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TierInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IPAMPoolUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12278,13 +15270,13 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IngressPolicies", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -12294,24 +15286,78 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IngressPolicies = append(m.IngressPolicies, string(dAtA[iNdEx:postIndex]))
+			if m.Pool == nil {
+				m.Pool = &IPAMPool{}
+			}
+			if err := m.Pool.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFelixbackend(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IPAMPoolRemove) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFelixbackend
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IPAMPoolRemove: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IPAMPoolRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EgressPolicies", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12336,7 +15382,7 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EgressPolicies = append(m.EgressPolicies, string(dAtA[iNdEx:postIndex]))
+			m.Id = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12359,7 +15405,7 @@ func (m *TierInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NatInfo) Unmarshal(dAtA []byte) error {
+func (m *IPAMPool) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12382,15 +15428,15 @@ func (m *NatInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NatInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: IPAMPool: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NatInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: IPAMPool: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ExtIp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cidr", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12415,13 +15461,13 @@ func (m *NatInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ExtIp = string(dAtA[iNdEx:postIndex])
+			m.Cidr = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IntIp", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Masquerade", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -12431,21 +15477,12 @@ func (m *NatInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.IntIp = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Masquerade = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -12467,7 +15504,7 @@ func (m *NatInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
+func (m *ServiceAccountUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12490,17 +15527,17 @@ func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProcessStatusUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServiceAccountUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProcessStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServiceAccountUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IsoTimestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -12510,32 +15547,143 @@ func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IsoTimestamp = string(dAtA[iNdEx:postIndex])
+			if m.Id == nil {
+				m.Id = &ServiceAccountID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
 			}
-			var v uint64
-			if (iNdEx + 8) > l {
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.Uptime = float64(math.Float64frombits(v))
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowFelixbackend
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipFelixbackend(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -12557,7 +15705,7 @@ func (m *ProcessStatusUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
+func (m *ServiceAccountRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12580,10 +15728,10 @@ func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointStatusUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServiceAccountRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServiceAccountRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12613,45 +15761,12 @@ func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Id == nil {
-				m.Id = &HostEndpointID{}
+				m.Id = &ServiceAccountID{}
 			}
 			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Status == nil {
-				m.Status = &EndpointStatus{}
-			}
-			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -12673,7 +15788,7 @@ func (m *HostEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
+func (m *ServiceAccountID) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12696,15 +15811,15 @@ func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EndpointStatus: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServiceAccountID: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EndpointStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServiceAccountID: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -12729,7 +15844,36 @@ func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Status = string(dAtA[iNdEx:postIndex])
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -12752,7 +15896,7 @@ func (m *EndpointStatus) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
+func (m *NamespaceUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12775,10 +15919,10 @@ func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostEndpointStatusRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: NamespaceUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostEndpointStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NamespaceUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -12808,12 +15952,130 @@ func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			if m.Id == nil {
-				m.Id = &HostEndpointID{}
+				m.Id = &NamespaceID{}
 			}
 			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowFelixbackend
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipFelixbackend(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -12835,7 +16097,7 @@ func (m *HostEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
+func (m *NamespaceRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12858,48 +16120,15 @@ func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointStatusUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: NamespaceRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointStatusUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NamespaceRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowFelixbackend
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Id == nil {
-				m.Id = &WorkloadEndpointID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Status", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -12923,10 +16152,10 @@ func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Status == nil {
-				m.Status = &EndpointStatus{}
+			if m.Id == nil {
+				m.Id = &NamespaceID{}
 			}
-			if err := m.Status.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -12951,7 +16180,7 @@ func (m *WorkloadEndpointStatusUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
+func (m *NamespaceID) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -12974,17 +16203,17 @@ func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: WorkloadEndpointStatusRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: NamespaceID: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: WorkloadEndpointStatusRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NamespaceID: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -12994,24 +16223,20 @@ func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &WorkloadEndpointID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13034,7 +16259,7 @@ func (m *WorkloadEndpointStatusRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostMetadataUpdate) Unmarshal(dAtA []byte) error {
+func (m *ServiceUpdate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13057,15 +16282,15 @@ func (m *HostMetadataUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostMetadataUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServiceUpdate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostMetadataUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServiceUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13090,11 +16315,11 @@ func (m *HostMetadataUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hostname = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Addr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13119,7 +16344,183 @@ func (m *HostMetadataUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Ipv4Addr = string(dAtA[iNdEx:postIndex])
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterIp", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClusterIp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LoadbalancerIp", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LoadbalancerIp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExternalIps", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExternalIps = append(m.ExternalIps, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ports = append(m.Ports, &ServicePort{})
+			if err := m.Ports[len(m.Ports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterIps", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClusterIps = append(m.ClusterIps, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13142,7 +16543,7 @@ func (m *HostMetadataUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *HostMetadataRemove) Unmarshal(dAtA []byte) error {
+func (m *ServicePort) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13165,15 +16566,15 @@ func (m *HostMetadataRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: HostMetadataRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServicePort: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: HostMetadataRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServicePort: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13198,13 +16599,13 @@ func (m *HostMetadataRemove) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Hostname = string(dAtA[iNdEx:postIndex])
+			m.Protocol = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ipv4Addr", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
 			}
-			var stringLen uint64
+			m.Port = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -13214,21 +16615,30 @@ func (m *HostMetadataRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Port |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodePort", wireType)
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.NodePort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NodePort |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Ipv4Addr = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -13250,7 +16660,7 @@ func (m *HostMetadataRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IPAMPoolUpdate) Unmarshal(dAtA []byte) error {
+func (m *ServiceRemove) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13273,15 +16683,15 @@ func (m *IPAMPoolUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IPAMPoolUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: ServiceRemove: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IPAMPoolUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ServiceRemove: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13306,13 +16716,13 @@ func (m *IPAMPoolUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -13322,24 +16732,20 @@ func (m *IPAMPoolUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pool == nil {
-				m.Pool = &IPAMPool{}
-			}
-			if err := m.Pool.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13362,7 +16768,7 @@ func (m *IPAMPoolUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IPAMPoolRemove) Unmarshal(dAtA []byte) error {
+func (m *RuleMetadata) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13385,17 +16791,17 @@ func (m *IPAMPoolRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IPAMPoolRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: RuleMetadata: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IPAMPoolRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RuleMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Annotations", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -13405,20 +16811,109 @@ func (m *IPAMPoolRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			if m.Annotations == nil {
+				m.Annotations = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowFelixbackend
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowFelixbackend
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipFelixbackend(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthFelixbackend
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Annotations[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13441,7 +16936,7 @@ func (m *IPAMPoolRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IPAMPool) Unmarshal(dAtA []byte) error {
+func (m *DataplaneStats) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13464,15 +16959,15 @@ func (m *IPAMPool) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IPAMPool: wiretype end group for non-group")
+			return fmt.Errorf("proto: DataplaneStats: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IPAMPool: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DataplaneStats: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cidr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcIp", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13497,13 +16992,42 @@ func (m *IPAMPool) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Cidr = string(dAtA[iNdEx:postIndex])
+			m.SrcIp = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DstIp", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DstIp = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Masquerade", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SrcPort", wireType)
 			}
-			var v int
+			m.SrcPort = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -13513,65 +17037,97 @@ func (m *IPAMPool) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				m.SrcPort |= (int32(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Masquerade = bool(v != 0)
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DstPort", wireType)
 			}
-			if skippy < 0 {
+			m.DstPort = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DstPort |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocol", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ServiceAccountUpdate) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
+			if m.Protocol == nil {
+				m.Protocol = &Protocol{}
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if err := m.Protocol.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stats", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceAccountUpdate: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceAccountUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stats = append(m.Stats, &Statistic{})
+			if err := m.Stats[len(m.Stats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 100:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field WafRuleHits", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13595,16 +17151,14 @@ func (m *ServiceAccountUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ServiceAccountID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.WafRuleHits = append(m.WafRuleHits, &WAFRuleHit{})
+			if err := m.WafRuleHits[len(m.WafRuleHits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 101:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PolicyHits", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -13628,97 +17182,10 @@ func (m *ServiceAccountUpdate) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = make(map[string]string)
-			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowFelixbackend
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipFelixbackend(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
+			m.PolicyHits = append(m.PolicyHits, &PolicyHit{})
+			if err := m.PolicyHits[len(m.PolicyHits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Labels[mapkey] = mapvalue
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -13741,7 +17208,7 @@ func (m *ServiceAccountUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceAccountRemove) Unmarshal(dAtA []byte) error {
+func (m *Statistic) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13764,17 +17231,17 @@ func (m *ServiceAccountRemove) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceAccountRemove: wiretype end group for non-group")
+			return fmt.Errorf("proto: Statistic: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceAccountRemove: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Statistic: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
 			}
-			var msglen int
+			m.Direction = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -13784,25 +17251,87 @@ func (m *ServiceAccountRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Direction |= (Statistic_Direction(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relativity", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Relativity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Relativity |= (Statistic_Relativity(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if m.Id == nil {
-				m.Id = &ServiceAccountID{}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Kind = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Kind |= (Statistic_Kind(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Action", wireType)
+			}
+			m.Action = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Action |= (Statistic_Action(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -13824,7 +17353,7 @@ func (m *ServiceAccountRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ServiceAccountID) Unmarshal(dAtA []byte) error {
+func (m *WAFRuleHit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13847,15 +17376,63 @@ func (m *ServiceAccountID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ServiceAccountID: wiretype end group for non-group")
+			return fmt.Errorf("proto: WAFRuleHit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ServiceAccountID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: WAFRuleHit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RuleId", wireType)
+			}
+			m.RuleId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RuleId |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Severity", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Severity = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -13867,26 +17444,46 @@ func (m *ServiceAccountID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFelixbackend
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Blocked", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			m.Blocked = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			var stringLen uint64
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -13896,21 +17493,11 @@ func (m *ServiceAccountID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				m.Count |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -13932,7 +17519,7 @@ func (m *ServiceAccountID) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NamespaceUpdate) Unmarshal(dAtA []byte) error {
+func (m *PolicyHit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -13955,17 +17542,17 @@ func (m *NamespaceUpdate) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NamespaceUpdate: wiretype end group for non-group")
+			return fmt.Errorf("proto: PolicyHit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NamespaceUpdate: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PolicyHit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tier", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -13975,30 +17562,26 @@ func (m *NamespaceUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &NamespaceID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Tier = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -14008,165 +17591,46 @@ func (m *NamespaceUpdate) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				stringLen |= (uint64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthFelixbackend
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Labels == nil {
-				m.Labels = make(map[string]string)
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enforced", wireType)
 			}
-			var mapkey string
-			var mapvalue string
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowFelixbackend
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFelixbackend
 				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					var stringLenmapvalue uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowFelixbackend
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapvalue := int(stringLenmapvalue)
-					if intStringLenmapvalue < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
-					if postStringIndexmapvalue > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
-					iNdEx = postStringIndexmapvalue
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipFelixbackend(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthFelixbackend
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
 				}
 			}
-			m.Labels[mapkey] = mapvalue
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipFelixbackend(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *NamespaceRemove) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowFelixbackend
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: NamespaceRemove: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NamespaceRemove: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			m.Enforced = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
 			}
-			var msglen int
+			m.Count = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -14176,25 +17640,11 @@ func (m *NamespaceRemove) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.Count |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Id == nil {
-				m.Id = &NamespaceID{}
-			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -14216,7 +17666,7 @@ func (m *NamespaceRemove) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *NamespaceID) Unmarshal(dAtA []byte) error {
+func (m *ReportResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -14239,17 +17689,17 @@ func (m *NamespaceID) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NamespaceID: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReportResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NamespaceID: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReportResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Successful", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowFelixbackend
@@ -14259,21 +17709,12 @@ func (m *NamespaceID) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthFelixbackend
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			m.Successful = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFelixbackend(dAtA[iNdEx:])
@@ -14403,173 +17844,204 @@ var (
 func init() { proto1.RegisterFile("felixbackend.proto", fileDescriptorFelixbackend) }
 
 var fileDescriptorFelixbackend = []byte{
-	// 2683 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0x4b, 0x73, 0xdc, 0xc6,
-	0xf1, 0x27, 0x96, 0xfb, 0x00, 0x7a, 0x9f, 0x1e, 0x3e, 0xb4, 0xa2, 0x25, 0x91, 0x7f, 0xe8, 0xef,
-	0x12, 0xed, 0x94, 0x69, 0x15, 0x2d, 0x51, 0x96, 0x53, 0x25, 0x17, 0xa9, 0x65, 0xcc, 0x75, 0x4c,
-	0x66, 0x0b, 0xa4, 0x9d, 0x72, 0x2a, 0x55, 0x08, 0x04, 0x0c, 0x49, 0x44, 0xbb, 0x00, 0x04, 0xcc,
-	0x52, 0x64, 0x8e, 0xf9, 0x02, 0xb9, 0xe6, 0x03, 0xa4, 0x72, 0xca, 0x35, 0xa7, 0x9c, 0x53, 0x65,
-	0xdf, 0x7c, 0xcd, 0x2d, 0xa5, 0x6f, 0x90, 0x6f, 0x90, 0x9a, 0xe7, 0xe2, 0xb5, 0x14, 0x95, 0x4a,
-	0xe5, 0x44, 0x4c, 0xcf, 0xaf, 0x7f, 0xd3, 0xd3, 0xdd, 0x68, 0xf4, 0xcc, 0x12, 0xd0, 0x29, 0x1e,
-	0xfb, 0x97, 0x2f, 0x1c, 0xf7, 0x25, 0x0e, 0xbc, 0xad, 0x28, 0x0e, 0x49, 0x88, 0x6a, 0x4c, 0x66,
-	0xb6, 0xa1, 0x79, 0x7c, 0x15, 0xb8, 0x16, 0x7e, 0x35, 0xc5, 0x09, 0x31, 0x7f, 0x6c, 0x41, 0xf3,
-	0x24, 0x1c, 0x38, 0xc4, 0x89, 0xc6, 0x4e, 0x80, 0xd1, 0x26, 0x34, 0xfc, 0xc0, 0x4e, 0xae, 0x02,
-	0xb7, 0xaf, 0x6d, 0x68, 0x9b, 0xcd, 0xed, 0xf6, 0x16, 0xd3, 0xdb, 0x1a, 0x06, 0x54, 0xed, 0x60,
-	0xc1, 0xaa, 0xfb, 0xec, 0x09, 0x3d, 0x81, 0x96, 0x1f, 0x25, 0x98, 0xd8, 0xd3, 0xc8, 0x73, 0x08,
-	0xee, 0x57, 0x18, 0x1c, 0x49, 0xf8, 0xe8, 0x18, 0x93, 0x6f, 0xd8, 0xcc, 0xc1, 0x82, 0xd5, 0x64,
-	0x48, 0x3e, 0x44, 0x5f, 0x02, 0xe2, 0x8a, 0x1e, 0x1e, 0x13, 0x47, 0xaa, 0x2f, 0x32, 0xf5, 0x5b,
-	0x69, 0xf5, 0x01, 0x9d, 0x57, 0x1c, 0x3d, 0xa6, 0x94, 0x92, 0xcd, 0x2c, 0x88, 0xf1, 0x24, 0xbc,
-	0xc0, 0xfd, 0x6a, 0xd1, 0x02, 0x8b, 0xcd, 0x28, 0x0b, 0xf8, 0x10, 0x8d, 0x60, 0xc5, 0x71, 0x89,
-	0x7f, 0x81, 0xed, 0x28, 0x0e, 0x4f, 0xfd, 0x31, 0x96, 0x46, 0xd4, 0x18, 0xc3, 0x9a, 0x60, 0xd8,
-	0x65, 0x98, 0x11, 0x87, 0x28, 0x3b, 0x96, 0x9c, 0xa2, 0xb8, 0x84, 0x51, 0xd8, 0x54, 0x9f, 0xcf,
-	0xa8, 0x6c, 0xcb, 0x32, 0x0a, 0x1b, 0x0f, 0x61, 0x59, 0x32, 0x86, 0x63, 0xdf, 0xbd, 0x92, 0x26,
-	0x36, 0x18, 0xe1, 0xed, 0x2c, 0x21, 0x43, 0x28, 0x0b, 0x91, 0x53, 0x90, 0x16, 0xe9, 0x84, 0x7d,
-	0xfa, 0x5c, 0x3a, 0x65, 0x5e, 0x86, 0x6e, 0x66, 0xdd, 0x79, 0x98, 0x10, 0x1b, 0x07, 0x5e, 0x14,
-	0xfa, 0x81, 0x4a, 0x02, 0x23, 0x43, 0x77, 0x10, 0x26, 0x64, 0x5f, 0x20, 0x66, 0xd6, 0x9d, 0x17,
-	0xa4, 0x45, 0x3a, 0x61, 0x1d, 0xcc, 0xa5, 0x9b, 0x59, 0x77, 0x5e, 0x90, 0xa2, 0xef, 0xa0, 0xff,
-	0x3a, 0x8c, 0x5f, 0x8e, 0x43, 0xc7, 0x2b, 0x58, 0xd8, 0x64, 0x94, 0x77, 0x05, 0xe5, 0x2f, 0x05,
-	0xac, 0x60, 0xe5, 0xea, 0xeb, 0xd2, 0x99, 0x72, 0x6a, 0x61, 0x6d, 0xeb, 0x5a, 0x6a, 0x65, 0x71,
-	0x81, 0x5a, 0x58, 0xfd, 0x39, 0xb4, 0xdd, 0x30, 0x38, 0xf5, 0xcf, 0xa4, 0xa9, 0x6d, 0xc6, 0xb7,
-	0x24, 0xf8, 0x9e, 0xb3, 0x39, 0x65, 0x60, 0xcb, 0x4d, 0x8d, 0x95, 0x03, 0x27, 0x98, 0x38, 0x9e,
-	0x33, 0x7b, 0xab, 0x3a, 0x05, 0x07, 0x1e, 0x0a, 0x44, 0x36, 0x1e, 0x59, 0x29, 0x7a, 0x00, 0xdd,
-	0x84, 0x16, 0x88, 0xc0, 0xc5, 0x76, 0x30, 0x9d, 0xbc, 0xc0, 0x71, 0xbf, 0xbb, 0xa1, 0x6d, 0x56,
-	0xad, 0x8e, 0x14, 0x1f, 0x31, 0x29, 0xda, 0x85, 0x9e, 0x1f, 0x39, 0x13, 0x3b, 0x0a, 0xc3, 0xb1,
-	0x5c, 0xb3, 0xc7, 0xd6, 0x5c, 0x51, 0xaf, 0xe1, 0xee, 0xe1, 0x28, 0x0c, 0xc7, 0x6a, 0xbd, 0x0e,
-	0x55, 0x98, 0x49, 0xb2, 0x14, 0xc2, 0x93, 0xef, 0x95, 0x52, 0x28, 0x0f, 0x2a, 0x8a, 0x5c, 0x36,
-	0xaa, 0xdd, 0x0b, 0x1a, 0x34, 0x77, 0xf7, 0xd9, 0xf4, 0xc9, 0x4a, 0xd1, 0x31, 0xac, 0x26, 0x38,
-	0xbe, 0xf0, 0x5d, 0x6c, 0x3b, 0xae, 0x1b, 0x4e, 0x67, 0xc9, 0xb3, 0xc4, 0x08, 0xdf, 0x17, 0x84,
-	0xc7, 0x1c, 0xb4, 0xcb, 0x31, 0x6a, 0x83, 0xcb, 0x49, 0x89, 0xbc, 0x8c, 0x54, 0x58, 0xb9, 0x7c,
-	0x0d, 0xa9, 0xb2, 0x33, 0x47, 0x2a, 0x2c, 0x7d, 0x0e, 0xbd, 0xc0, 0x99, 0xe0, 0x24, 0x72, 0x5c,
-	0x55, 0xc3, 0x56, 0x18, 0xdd, 0xaa, 0xa0, 0x3b, 0x92, 0xd3, 0xca, 0xbc, 0x6e, 0x90, 0x15, 0x65,
-	0x49, 0x84, 0x4d, 0xab, 0xe5, 0x24, 0xca, 0x9c, 0x19, 0x09, 0x17, 0xed, 0x19, 0xd0, 0x88, 0x9c,
-	0x2b, 0x9a, 0xd5, 0xe6, 0x5f, 0xab, 0xd0, 0xfe, 0x59, 0x1c, 0x4e, 0x66, 0x1f, 0x95, 0x11, 0xac,
-	0x44, 0x71, 0xe8, 0xe2, 0x24, 0xb1, 0x13, 0xe2, 0x90, 0x69, 0x92, 0x2d, 0xfa, 0xb2, 0x3a, 0x8e,
-	0x38, 0xe6, 0x98, 0x41, 0x66, 0xf5, 0x36, 0x2a, 0x8a, 0xd1, 0x6f, 0xe0, 0xfd, 0x6c, 0xc1, 0xc8,
-	0xf2, 0xf2, 0x2f, 0xc1, 0x7a, 0x49, 0xdd, 0xc8, 0x91, 0xf7, 0xcf, 0xe7, 0xcc, 0xcd, 0x5d, 0x41,
-	0x38, 0xa8, 0xf6, 0x96, 0x15, 0x94, 0xa7, 0x4a, 0x56, 0x10, 0xc1, 0x1b, 0xc3, 0x7a, 0xb1, 0x94,
-	0x64, 0xf7, 0xc1, 0xbf, 0x1e, 0xf7, 0xe7, 0x54, 0x94, 0xdc, 0x5e, 0xee, 0xbc, 0xbe, 0x66, 0xfe,
-	0xda, 0xd5, 0xc4, 0x9e, 0x1a, 0x37, 0x58, 0x4d, 0xed, 0x6b, 0xce, 0x6a, 0x62, 0x6f, 0x25, 0x05,
-	0x44, 0x2f, 0x2b, 0x20, 0xe9, 0xbc, 0xf9, 0xbd, 0x06, 0xad, 0x74, 0x91, 0x43, 0x4f, 0xa0, 0xce,
-	0x8b, 0x5c, 0x5f, 0xdb, 0x58, 0x4c, 0x79, 0x3b, 0x0d, 0x12, 0x83, 0xfd, 0x80, 0xc4, 0x57, 0x96,
-	0x80, 0xaf, 0x3d, 0x85, 0x66, 0x4a, 0x8c, 0x7a, 0xb0, 0xf8, 0x12, 0x5f, 0xb1, 0x7e, 0xc6, 0xb0,
-	0xe8, 0x23, 0x5a, 0x86, 0xda, 0x85, 0x33, 0x9e, 0xf2, 0xa6, 0xc5, 0xb0, 0xf8, 0xe0, 0xf3, 0xca,
-	0x67, 0x9a, 0xa9, 0x43, 0x9d, 0x77, 0x3a, 0xe6, 0x1f, 0x35, 0x68, 0xa6, 0xba, 0x18, 0xd4, 0x81,
-	0x8a, 0xef, 0x09, 0x92, 0x8a, 0xef, 0xa1, 0x3e, 0x34, 0x26, 0x98, 0xee, 0x21, 0xe9, 0x57, 0x36,
-	0x16, 0x37, 0x0d, 0x4b, 0x0e, 0xd1, 0x43, 0xa8, 0x92, 0xab, 0x88, 0x67, 0x77, 0x67, 0xfb, 0x4e,
-	0xb1, 0x23, 0xe2, 0xcf, 0x27, 0x57, 0x11, 0xb6, 0x18, 0xd2, 0xfc, 0x18, 0x0c, 0x25, 0x42, 0x75,
-	0xa8, 0x0c, 0x47, 0xbd, 0x05, 0xd4, 0xa5, 0xeb, 0xdb, 0xbb, 0x47, 0x03, 0x7b, 0xf4, 0x0b, 0xeb,
-	0xa4, 0xa7, 0xa1, 0x06, 0x2c, 0x1e, 0xed, 0x9f, 0xf4, 0x2a, 0x66, 0x04, 0xbd, 0x7c, 0x83, 0x54,
-	0x30, 0xef, 0x3e, 0xb4, 0x1d, 0xcf, 0xc3, 0x9e, 0x9d, 0x35, 0xb2, 0xc5, 0x84, 0x87, 0xc2, 0xd2,
-	0x07, 0xd0, 0xe5, 0xb1, 0x9f, 0xc1, 0x16, 0x19, 0xac, 0x23, 0xc4, 0x02, 0x68, 0xde, 0x15, 0xbe,
-	0x10, 0xe1, 0xcd, 0x2d, 0x66, 0x3a, 0xb0, 0x54, 0xd2, 0x2c, 0xa1, 0x0d, 0x05, 0x6b, 0x6e, 0xf7,
-	0x66, 0x2f, 0x39, 0x45, 0x0c, 0x07, 0xcc, 0xca, 0x4d, 0x68, 0x88, 0x86, 0x49, 0xf4, 0x8f, 0x9d,
-	0x2c, 0xcc, 0x92, 0xd3, 0xe6, 0x93, 0xdc, 0x12, 0xc2, 0x92, 0xb7, 0x2e, 0x61, 0xae, 0x83, 0xa1,
-	0x04, 0x08, 0x41, 0x95, 0x56, 0x2e, 0x61, 0x3a, 0x7b, 0x36, 0x43, 0x68, 0x08, 0x00, 0x7a, 0x08,
-	0x6d, 0x3f, 0x78, 0x11, 0x4e, 0x03, 0xcf, 0x8e, 0xa7, 0x63, 0x9c, 0x88, 0xc4, 0x6b, 0x0a, 0x62,
-	0x6b, 0x3a, 0xc6, 0x56, 0x4b, 0x20, 0xe8, 0x20, 0x41, 0xdb, 0xd0, 0x09, 0xa7, 0x24, 0xad, 0x52,
-	0x29, 0xaa, 0xb4, 0x25, 0x84, 0xe9, 0x98, 0xbf, 0x06, 0x54, 0xec, 0xdb, 0xd0, 0x7a, 0x6a, 0x27,
-	0x5d, 0xb9, 0x13, 0x06, 0x10, 0xbe, 0xfa, 0x00, 0xea, 0xbc, 0x77, 0x13, 0xae, 0x6a, 0x67, 0x40,
-	0x96, 0x98, 0x34, 0x1f, 0x67, 0xd9, 0x85, 0x9f, 0xde, 0xc6, 0x6e, 0x6e, 0x83, 0x2e, 0xc7, 0xd4,
-	0x4b, 0xc4, 0xc7, 0xb1, 0xf4, 0x12, 0x7d, 0x56, 0x9e, 0xab, 0xa4, 0x3c, 0xf7, 0x77, 0x0d, 0xea,
-	0x5c, 0xe9, 0x7f, 0xe3, 0x39, 0x74, 0x07, 0x8c, 0x69, 0x40, 0x62, 0x7a, 0xae, 0xf1, 0xd8, 0xeb,
-	0xa5, 0x5b, 0x33, 0x01, 0xba, 0x0d, 0x7a, 0x14, 0x63, 0xdb, 0x0b, 0x1c, 0xc2, 0xbe, 0x00, 0x3a,
-	0xcd, 0x1e, 0x3c, 0x08, 0x1c, 0x42, 0x15, 0xd5, 0x17, 0x8b, 0xd5, 0x6e, 0xc3, 0x9a, 0x09, 0xcc,
-	0x7f, 0xb4, 0xa1, 0x4a, 0x17, 0x40, 0xab, 0x50, 0xa7, 0xcd, 0x6e, 0x18, 0x88, 0xad, 0x8b, 0x11,
-	0xfa, 0x04, 0xc0, 0x8f, 0xec, 0x0b, 0x1c, 0x27, 0x74, 0xae, 0xc2, 0xde, 0xeb, 0x9e, 0x7a, 0xaf,
-	0xbf, 0xe5, 0x72, 0xcb, 0xf0, 0x23, 0xf1, 0x88, 0x7e, 0x42, 0x4d, 0x09, 0x49, 0xe8, 0x86, 0x63,
-	0xf1, 0x91, 0xeb, 0xce, 0x92, 0x93, 0x89, 0x2d, 0x05, 0x40, 0xb7, 0xa0, 0x91, 0xc4, 0xae, 0x1d,
-	0x60, 0x6a, 0x36, 0x7d, 0xfb, 0xea, 0x49, 0xec, 0x1e, 0x61, 0x82, 0x3e, 0x06, 0x83, 0x4e, 0x44,
-	0x61, 0x4c, 0x92, 0x7e, 0x8d, 0x79, 0x47, 0xe5, 0x78, 0x18, 0x13, 0xcb, 0x09, 0xce, 0xb0, 0xa5,
-	0x27, 0xb1, 0x4b, 0x47, 0x09, 0xe5, 0xf1, 0x12, 0xc2, 0x78, 0xea, 0x9c, 0xc7, 0x4b, 0x88, 0xe0,
-	0xa1, 0x13, 0x9c, 0xa7, 0x31, 0x8f, 0xc7, 0x4b, 0x08, 0xe7, 0xb9, 0x0b, 0x86, 0xef, 0x4e, 0x22,
-	0x9b, 0x15, 0x31, 0x5a, 0xb6, 0x6b, 0x07, 0x0b, 0x96, 0x4e, 0x45, 0xac, 0x3e, 0x3d, 0x83, 0x8e,
-	0x9a, 0xb6, 0xdd, 0xd0, 0x93, 0x5d, 0xbf, 0xec, 0x16, 0x86, 0x02, 0xb8, 0x1b, 0x78, 0xcf, 0x43,
-	0x8f, 0xf5, 0xaa, 0x52, 0x97, 0x8e, 0xd1, 0x7d, 0xe8, 0xd0, 0x5d, 0xf9, 0x91, 0x4d, 0xcf, 0x6e,
-	0xbe, 0x97, 0xf4, 0x81, 0x59, 0xdb, 0x4c, 0x62, 0x77, 0x18, 0x1d, 0x63, 0x32, 0xf4, 0x12, 0x0a,
-	0xa2, 0x26, 0xa7, 0x40, 0x4d, 0x0e, 0xf2, 0x12, 0xa2, 0x40, 0x4f, 0xe0, 0x36, 0x73, 0x9c, 0x33,
-	0xc1, 0x1e, 0xdb, 0x5d, 0x1a, 0xdf, 0x62, 0xf8, 0x65, 0xea, 0x4a, 0x3a, 0x4f, 0xb7, 0x96, 0x56,
-	0x64, 0x9e, 0x2a, 0x55, 0x6c, 0x73, 0x45, 0xea, 0xbb, 0x82, 0xe2, 0x36, 0xb4, 0x82, 0x90, 0xd8,
-	0x2a, 0xb6, 0xa7, 0xe5, 0xb1, 0x6d, 0x06, 0x21, 0x91, 0x03, 0x74, 0x0f, 0xe8, 0xd0, 0x96, 0x21,
-	0x3e, 0x63, 0xf4, 0x46, 0x10, 0x92, 0x63, 0x1e, 0xe5, 0x47, 0xd0, 0x96, 0xf3, 0x3c, 0x42, 0xe7,
-	0x73, 0x22, 0xd4, 0xe4, 0x3a, 0x3c, 0x48, 0x82, 0x55, 0x06, 0xdc, 0x57, 0xac, 0x03, 0x1e, 0x73,
-	0xc1, 0x3a, 0x8b, 0xfb, 0x6f, 0xaf, 0x61, 0x1d, 0xc8, 0xd0, 0xff, 0x3f, 0xd7, 0x9a, 0x85, 0xff,
-	0x25, 0x0b, 0xbf, 0xc6, 0x50, 0x32, 0xb0, 0x68, 0x1f, 0x50, 0x06, 0xc5, 0xb3, 0x60, 0x7c, 0x6d,
-	0x16, 0x68, 0x56, 0x37, 0x45, 0xc1, 0x12, 0xe1, 0x23, 0x4e, 0x93, 0x4b, 0x86, 0x09, 0xff, 0x00,
-	0xf1, 0xbd, 0x2a, 0xc7, 0x0b, 0x6c, 0x2e, 0x27, 0x02, 0x85, 0x1d, 0xa4, 0xd2, 0xe2, 0x19, 0xdc,
-	0x55, 0x0e, 0x2f, 0x8d, 0x70, 0xc4, 0xd4, 0x6e, 0x89, 0x10, 0x14, 0x82, 0x2c, 0xf4, 0xe7, 0x67,
-	0xc8, 0x2b, 0xa5, 0x3f, 0x28, 0x4f, 0x92, 0x95, 0x30, 0xf6, 0xcf, 0xfc, 0xc0, 0x19, 0x33, 0x23,
-	0x12, 0x3c, 0xc6, 0x2e, 0x09, 0xe3, 0x7e, 0xcc, 0x8a, 0xca, 0x92, 0x9c, 0x3c, 0x8e, 0xdd, 0x63,
-	0x31, 0x95, 0xd1, 0xa1, 0x0b, 0x2b, 0x9d, 0x24, 0xab, 0x33, 0x48, 0x88, 0xd2, 0xd9, 0x87, 0xf5,
-	0xcc, 0x3a, 0xb3, 0x2e, 0x5e, 0x69, 0x13, 0xa6, 0x7d, 0x27, 0xb5, 0xa2, 0xea, 0xe5, 0x4b, 0x69,
-	0xe4, 0x9e, 0x73, 0x34, 0xd3, 0x2c, 0x8d, 0xd8, 0x75, 0x96, 0xe6, 0x29, 0xdc, 0x56, 0x34, 0xd2,
-	0xfd, 0x8a, 0xe0, 0x82, 0x11, 0xac, 0x4a, 0xc0, 0x11, 0xf3, 0xfc, 0x5c, 0xd5, 0x8c, 0x03, 0x5e,
-	0x17, 0x54, 0xd3, 0x3e, 0xf8, 0x86, 0x97, 0x80, 0xfc, 0xd1, 0x6a, 0xe2, 0x10, 0xf7, 0xbc, 0x7f,
-	0x99, 0x39, 0x5e, 0x64, 0x4f, 0x56, 0x87, 0x14, 0x61, 0xad, 0x26, 0xd4, 0x8c, 0x82, 0x9c, 0xd2,
-	0x72, 0x23, 0xca, 0x68, 0xaf, 0xde, 0x4e, 0xeb, 0x51, 0x13, 0x8b, 0xb4, 0x9f, 0x00, 0x9c, 0x13,
-	0x12, 0x09, 0x9e, 0xdf, 0x65, 0xba, 0x96, 0x83, 0x93, 0x93, 0x11, 0xd7, 0x36, 0x28, 0x86, 0x2b,
-	0xf4, 0xa1, 0x41, 0xbf, 0x8d, 0xb6, 0xef, 0xf5, 0x7f, 0x10, 0x9f, 0x24, 0x3a, 0x1e, 0x7a, 0x7b,
-	0x75, 0xa8, 0xd2, 0xf7, 0x6f, 0x0f, 0x40, 0x97, 0xef, 0xe2, 0x57, 0x75, 0xfd, 0x7b, 0xad, 0xf7,
-	0x83, 0x66, 0xc1, 0x38, 0x3c, 0xb3, 0xa3, 0x18, 0x9f, 0xfa, 0x97, 0xe6, 0x97, 0xb0, 0x54, 0x66,
-	0xc9, 0x1a, 0xe8, 0xca, 0xc3, 0x9c, 0x58, 0x8d, 0x69, 0x77, 0xcc, 0x72, 0x40, 0xb4, 0x8c, 0x7c,
-	0x60, 0xfe, 0x49, 0x03, 0x43, 0xd9, 0xc8, 0xbb, 0x5f, 0x72, 0x1e, 0x7a, 0xfc, 0x4b, 0xcf, 0xba,
-	0x5f, 0x36, 0x44, 0x0f, 0xa1, 0x16, 0x39, 0xe4, 0x5c, 0x7e, 0xce, 0xd7, 0xf2, 0xdb, 0xdb, 0x1a,
-	0x39, 0xe4, 0x9c, 0x6f, 0x94, 0x03, 0xd7, 0x7e, 0x0e, 0x86, 0x92, 0xa1, 0x55, 0xa8, 0xe1, 0x4b,
-	0xc7, 0x25, 0xdc, 0xaa, 0x83, 0x05, 0x8b, 0x0f, 0x51, 0x1f, 0xea, 0x7c, 0x47, 0xbc, 0x03, 0x39,
-	0x58, 0xb0, 0xc4, 0x78, 0xaf, 0x05, 0x40, 0x79, 0xb8, 0x53, 0xcd, 0xa7, 0xd0, 0xcd, 0x95, 0x1e,
-	0xd6, 0xce, 0xd0, 0x5a, 0x46, 0x19, 0x6b, 0xbc, 0xe3, 0xa6, 0x32, 0x56, 0xb4, 0x2a, 0x5c, 0x46,
-	0x9f, 0xcd, 0xaf, 0x41, 0x57, 0x45, 0xbb, 0x0f, 0x75, 0x71, 0x6e, 0xd1, 0xc4, 0x07, 0x50, 0x8c,
-	0xd1, 0x72, 0xba, 0x11, 0x3a, 0x58, 0xe0, 0xad, 0xd0, 0x5e, 0x0f, 0x3a, 0x7c, 0xde, 0x0e, 0x63,
-	0xf6, 0x06, 0x99, 0x8f, 0xc1, 0x50, 0x45, 0x96, 0xba, 0xf4, 0xd4, 0x8f, 0x13, 0x22, 0x6c, 0xe0,
-	0x03, 0x6a, 0xc4, 0xd8, 0x49, 0x88, 0x34, 0x82, 0x3e, 0x9b, 0x7f, 0xd0, 0x00, 0xe5, 0x8f, 0x5e,
-	0xc3, 0x01, 0xed, 0xd4, 0xc3, 0xd8, 0x3d, 0xc7, 0x09, 0x89, 0x1d, 0x12, 0xc6, 0x34, 0x21, 0x78,
-	0x27, 0xd6, 0x49, 0x8b, 0x87, 0x1e, 0x5a, 0x87, 0xa6, 0x3a, 0xe7, 0xf9, 0xbc, 0x49, 0x32, 0x2c,
-	0x90, 0x22, 0x0e, 0x50, 0xe7, 0x3f, 0xdf, 0x63, 0x8d, 0x92, 0x61, 0x81, 0x14, 0x0d, 0xbd, 0xaf,
-	0xaa, 0xba, 0xd6, 0xab, 0x58, 0x3a, 0x3d, 0xb7, 0xb2, 0x8d, 0x5c, 0xc2, 0x6a, 0xf9, 0x35, 0x19,
-	0xfa, 0x30, 0xd5, 0x54, 0xde, 0x9e, 0x73, 0x6c, 0x14, 0xcd, 0xeb, 0xa7, 0xa0, 0xcb, 0x25, 0xc4,
-	0xd9, 0xf9, 0xd6, 0xbc, 0x7b, 0x32, 0x05, 0x34, 0xff, 0x5c, 0x81, 0x5e, 0x7e, 0x9a, 0xba, 0x92,
-	0x1e, 0x5b, 0x65, 0x0f, 0xcf, 0x07, 0x65, 0xed, 0x29, 0x3d, 0xf7, 0x4d, 0x1c, 0x57, 0xb8, 0x80,
-	0x3e, 0xd2, 0xbd, 0xcb, 0xfb, 0x59, 0x5a, 0xc7, 0x79, 0xb7, 0x05, 0x42, 0x44, 0x4b, 0xf7, 0xfb,
-	0x60, 0xf8, 0xd1, 0xc5, 0x23, 0xfa, 0x49, 0xe5, 0x1d, 0x97, 0x61, 0xe9, 0x54, 0x70, 0x84, 0x89,
-	0x9c, 0xdc, 0xe1, 0x93, 0x75, 0x35, 0xb9, 0xc3, 0x26, 0x3f, 0x80, 0x1a, 0xed, 0x93, 0x65, 0x7f,
-	0x25, 0x5b, 0x82, 0x13, 0x1f, 0xc7, 0xc3, 0xe0, 0x34, 0xb4, 0xf8, 0x2c, 0xfa, 0x10, 0x74, 0xbe,
-	0x80, 0x43, 0xfa, 0x3a, 0x43, 0x76, 0xd4, 0x25, 0x0b, 0x61, 0xc0, 0x06, 0x5b, 0xcf, 0x21, 0x02,
-	0xba, 0xc3, 0xa0, 0xc6, 0x5c, 0xe8, 0xce, 0x91, 0x43, 0xcc, 0xe7, 0xc5, 0x10, 0x89, 0xbe, 0xff,
-	0xe6, 0x21, 0x32, 0x77, 0xa1, 0x93, 0xbe, 0xc7, 0x18, 0x0e, 0xf2, 0xa9, 0x52, 0x79, 0x6b, 0xaa,
-	0x8c, 0x01, 0x15, 0xef, 0x7c, 0xd1, 0x07, 0x29, 0x1b, 0x56, 0x4a, 0x6e, 0x4c, 0x44, 0x8a, 0x7c,
-	0x92, 0x4a, 0x91, 0xc5, 0xcc, 0xd5, 0x67, 0xe6, 0xe2, 0x77, 0x96, 0x1e, 0xff, 0xaa, 0x40, 0x2b,
-	0x3d, 0x55, 0x76, 0xba, 0xcb, 0x87, 0xbc, 0x52, 0x08, 0xb9, 0x0a, 0xdc, 0xe2, 0xb5, 0x81, 0xdb,
-	0x82, 0x25, 0x7c, 0x19, 0x61, 0x97, 0x60, 0xcf, 0x66, 0x11, 0x74, 0x3c, 0x2f, 0x96, 0x29, 0xf4,
-	0x9e, 0x9c, 0x1a, 0x46, 0x17, 0x8f, 0x76, 0xe9, 0x44, 0x1e, 0xbf, 0x23, 0xf0, 0xb5, 0x02, 0x7e,
-	0x87, 0xe3, 0x3f, 0x83, 0xae, 0x3a, 0xc9, 0xd8, 0xdc, 0xa0, 0x7a, 0xb9, 0x41, 0x1d, 0x85, 0x3b,
-	0x61, 0x96, 0x3d, 0x86, 0x8e, 0x3c, 0xf6, 0xd8, 0xd7, 0xa6, 0x60, 0x4b, 0x9c, 0x86, 0xb8, 0xda,
-	0x23, 0x68, 0x9f, 0x86, 0xf1, 0x6b, 0x27, 0x96, 0xcb, 0xe9, 0x73, 0xb4, 0x04, 0x8a, 0x69, 0x99,
-	0x3f, 0xcd, 0x46, 0x58, 0x64, 0xd9, 0xcd, 0x22, 0x6c, 0xc6, 0xa0, 0x4b, 0xda, 0xd2, 0x58, 0x7d,
-	0x08, 0x3d, 0x3f, 0x38, 0x8b, 0x71, 0x92, 0xf0, 0x5f, 0x29, 0x7c, 0xf5, 0x0d, 0xea, 0x0a, 0xf9,
-	0x48, 0x88, 0x69, 0x3d, 0xc4, 0x39, 0xa4, 0xb8, 0xb9, 0xc0, 0x19, 0xa0, 0xf9, 0x04, 0x1a, 0xe2,
-	0x75, 0x41, 0x2b, 0x50, 0xc7, 0x97, 0xb4, 0x91, 0x93, 0xa5, 0x03, 0x5f, 0x92, 0x61, 0x44, 0xc5,
-	0x2c, 0xc1, 0x23, 0x79, 0x1b, 0x44, 0x0d, 0x8e, 0x4c, 0x0b, 0x96, 0x4a, 0x2e, 0x24, 0xd1, 0x7d,
-	0x68, 0xfb, 0x49, 0x68, 0x13, 0x7f, 0x82, 0x13, 0xe2, 0x4c, 0x24, 0x57, 0xcb, 0x4f, 0xc2, 0x13,
-	0x29, 0xa3, 0xe7, 0xc8, 0x69, 0x44, 0x21, 0x8c, 0x52, 0xb3, 0xc4, 0xc8, 0x8c, 0xa0, 0x3f, 0xef,
-	0x32, 0xf2, 0xa6, 0x6f, 0xc9, 0xc7, 0x50, 0xe7, 0xb7, 0x76, 0xe2, 0x16, 0x40, 0x42, 0x73, 0xd7,
-	0x70, 0x02, 0x64, 0x6e, 0x42, 0x27, 0x3b, 0x43, 0x6d, 0x13, 0x04, 0xa2, 0xa1, 0x10, 0xc8, 0xdd,
-	0x32, 0xdb, 0xde, 0x2d, 0xbe, 0x97, 0x70, 0xe7, 0xba, 0x3b, 0xca, 0x77, 0xf9, 0x5e, 0xbc, 0xe3,
-	0x36, 0x87, 0xf3, 0x56, 0x7e, 0xf7, 0x32, 0x78, 0xc8, 0x33, 0x3c, 0xf7, 0x8b, 0xc8, 0x1a, 0xa8,
-	0x2a, 0x27, 0xfb, 0x25, 0x39, 0x56, 0x1f, 0x0d, 0xfa, 0x86, 0x8b, 0x1c, 0x62, 0x45, 0x9e, 0xbe,
-	0xd8, 0x79, 0x3a, 0x61, 0xcf, 0x7f, 0x4c, 0xb7, 0x0f, 0x9d, 0xec, 0x2f, 0x2a, 0x25, 0x17, 0x7f,
-	0xd5, 0x28, 0x0c, 0xc7, 0xc2, 0x6f, 0xdd, 0xfc, 0x6f, 0x28, 0x6c, 0xd2, 0xdc, 0x98, 0xd1, 0xcc,
-	0xb9, 0xd2, 0x7b, 0x06, 0xba, 0x44, 0xb0, 0x66, 0xc9, 0xf7, 0xd4, 0x7d, 0x10, 0x7d, 0x46, 0xf7,
-	0x00, 0x26, 0x4e, 0xf2, 0x6a, 0x8a, 0x63, 0x47, 0xb4, 0x51, 0xba, 0x95, 0x92, 0x98, 0x7f, 0xd3,
-	0x60, 0xb9, 0xec, 0x07, 0x12, 0xf4, 0x20, 0x15, 0x8a, 0x5b, 0xa5, 0x3d, 0xb4, 0x48, 0x81, 0x2f,
-	0xa0, 0x3e, 0x76, 0x5e, 0xe0, 0xb1, 0xec, 0x24, 0x1f, 0x5c, 0xf3, 0xb3, 0xcb, 0xd6, 0xd7, 0x0c,
-	0x29, 0xae, 0x81, 0xb9, 0xda, 0xda, 0x53, 0x68, 0xa6, 0xc4, 0xef, 0x74, 0x0d, 0xfc, 0x45, 0xde,
-	0x78, 0x75, 0xaf, 0x7d, 0x33, 0xe3, 0xcd, 0x01, 0xf4, 0xf2, 0xf2, 0xec, 0x25, 0x94, 0x96, 0xbb,
-	0x84, 0x2a, 0xbd, 0x60, 0xfb, 0x8b, 0x06, 0xdd, 0xdc, 0x2f, 0x38, 0xc8, 0x4c, 0x99, 0x80, 0xf2,
-	0x3f, 0xd0, 0x08, 0xd7, 0x7d, 0x9e, 0x73, 0x9d, 0x59, 0xfe, 0x6b, 0xd0, 0x7f, 0xdb, 0x6b, 0x8f,
-	0x53, 0xd6, 0x0a, 0x87, 0xdd, 0xc0, 0x5a, 0xf3, 0xff, 0xa0, 0x99, 0x12, 0x95, 0x7d, 0x19, 0x3e,
-	0xda, 0x04, 0x43, 0xdd, 0xb3, 0xa1, 0x06, 0x2c, 0xee, 0x1e, 0x7d, 0xd7, 0x5b, 0x40, 0x3a, 0x54,
-	0x87, 0xa3, 0x6f, 0x1f, 0xf5, 0xaa, 0xe2, 0x69, 0xa7, 0x57, 0xdf, 0x7e, 0x06, 0xc0, 0xaf, 0x24,
-	0xd9, 0x3f, 0x29, 0x3c, 0x84, 0x2a, 0xfb, 0x2b, 0x97, 0x4e, 0xfd, 0xeb, 0xc3, 0x9a, 0x94, 0xa5,
-	0xfe, 0xfd, 0xe1, 0xa1, 0xb6, 0xb7, 0xf4, 0xfd, 0x9b, 0x7b, 0xda, 0x8f, 0x6f, 0xee, 0x69, 0xff,
-	0x7c, 0x73, 0x4f, 0xfb, 0x55, 0x8d, 0xdd, 0xf4, 0xbc, 0xa8, 0xb3, 0x3f, 0x9f, 0xfe, 0x3b, 0x00,
-	0x00, 0xff, 0xff, 0x02, 0x23, 0x74, 0x37, 0x58, 0x21, 0x00, 0x00,
+	// 3183 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbd, 0x5a, 0x4b, 0x6f, 0x1c, 0xc7,
+	0x11, 0xf6, 0x2c, 0xf7, 0x59, 0xfb, 0xe0, 0x6a, 0x28, 0x52, 0x14, 0x25, 0x59, 0xca, 0xd8, 0x8e,
+	0x65, 0x07, 0xa6, 0x15, 0x5a, 0xa6, 0xfc, 0x00, 0x64, 0x2c, 0xb5, 0x6b, 0x6b, 0x6d, 0x89, 0x62,
+	0x86, 0xab, 0x18, 0x0e, 0x02, 0x6c, 0x46, 0x3b, 0x43, 0x72, 0xa2, 0xe5, 0xcc, 0x7a, 0x66, 0x96,
+	0x22, 0x73, 0x49, 0x90, 0xab, 0x03, 0xe4, 0x16, 0xe4, 0x17, 0xe4, 0x94, 0x6b, 0x4e, 0x39, 0x07,
+	0xb0, 0x73, 0xf2, 0x4f, 0x08, 0xf2, 0x0f, 0x72, 0xc8, 0x3d, 0x55, 0xfd, 0x9a, 0xe7, 0x52, 0x52,
+	0x10, 0xe4, 0x20, 0x68, 0xbb, 0xea, 0xab, 0xea, 0xea, 0xaa, 0xee, 0xea, 0xea, 0x1a, 0x82, 0x7e,
+	0xe0, 0x4c, 0xdd, 0xd3, 0x27, 0xd6, 0xe4, 0xa9, 0xe3, 0xd9, 0x9b, 0xb3, 0xc0, 0x8f, 0x7c, 0xbd,
+	0xc2, 0x68, 0x46, 0x1b, 0x9a, 0xfb, 0x67, 0xde, 0xc4, 0x74, 0xbe, 0x9e, 0x3b, 0x61, 0x64, 0x7c,
+	0xdf, 0x82, 0xe6, 0xc8, 0xef, 0x5b, 0x91, 0x35, 0x9b, 0x5a, 0x9e, 0xa3, 0xdf, 0x84, 0x9a, 0xeb,
+	0x8d, 0x43, 0x44, 0xac, 0x6b, 0x37, 0xb4, 0x9b, 0xcd, 0xad, 0xf6, 0x26, 0x93, 0xdb, 0x1c, 0x7a,
+	0x24, 0x76, 0xff, 0x15, 0xb3, 0xea, 0xb2, 0x5f, 0xfa, 0x1d, 0x68, 0xb9, 0xb3, 0xd0, 0x89, 0xc6,
+	0xf3, 0x99, 0x6d, 0x45, 0xce, 0x7a, 0x89, 0xc1, 0x75, 0x09, 0xdf, 0xdb, 0x77, 0xa2, 0xc7, 0x8c,
+	0x83, 0x32, 0x4d, 0x86, 0xe4, 0x43, 0xfd, 0x33, 0xd0, 0xb9, 0xa0, 0xed, 0x4c, 0x23, 0x4b, 0x8a,
+	0x2f, 0x31, 0xf1, 0x4b, 0x49, 0xf1, 0x3e, 0xf1, 0x95, 0x8e, 0x2e, 0x13, 0x4a, 0xd0, 0x62, 0x0b,
+	0x02, 0xe7, 0xd8, 0x3f, 0x71, 0xd6, 0xcb, 0x79, 0x0b, 0x4c, 0xc6, 0x51, 0x16, 0xf0, 0xa1, 0xbe,
+	0x07, 0xab, 0xd6, 0x24, 0x72, 0x4f, 0x9c, 0x31, 0xba, 0xe6, 0xc0, 0x9d, 0x3a, 0xd2, 0x88, 0x0a,
+	0xd3, 0xb0, 0x21, 0x34, 0xf4, 0x18, 0x66, 0x8f, 0x43, 0x94, 0x1d, 0x2b, 0x56, 0x9e, 0x5c, 0xa0,
+	0x51, 0xd8, 0x54, 0x5d, 0xac, 0x51, 0xd9, 0x96, 0xd6, 0x28, 0x6c, 0x7c, 0x08, 0x17, 0xa5, 0x46,
+	0x7f, 0xea, 0x4e, 0xce, 0xa4, 0x89, 0x35, 0xa6, 0xf0, 0x72, 0x5a, 0x21, 0x43, 0x28, 0x0b, 0x75,
+	0x2b, 0x47, 0xcd, 0xab, 0x13, 0xf6, 0xd5, 0x17, 0xaa, 0x53, 0xe6, 0xa5, 0xd4, 0xc5, 0xd6, 0x1d,
+	0xf9, 0x61, 0x34, 0xc6, 0xed, 0x35, 0xf3, 0x5d, 0x4f, 0x6d, 0x82, 0x46, 0x4a, 0xdd, 0x7d, 0x84,
+	0x0c, 0x04, 0x22, 0xb6, 0xee, 0x28, 0x47, 0xcd, 0xab, 0x13, 0xd6, 0xc1, 0x42, 0x75, 0xb1, 0x75,
+	0x47, 0x39, 0xaa, 0xfe, 0x15, 0xac, 0x3f, 0xf3, 0x83, 0xa7, 0x53, 0xdf, 0xb2, 0x73, 0x16, 0x36,
+	0x99, 0xca, 0x6b, 0x42, 0xe5, 0x97, 0x02, 0x96, 0xb3, 0x72, 0xed, 0x59, 0x21, 0xa7, 0x58, 0xb5,
+	0xb0, 0xb6, 0x75, 0xae, 0x6a, 0x65, 0x71, 0x4e, 0xb5, 0xb0, 0xfa, 0x23, 0x68, 0x4f, 0x7c, 0xef,
+	0xc0, 0x3d, 0x94, 0xa6, 0xb6, 0x99, 0xbe, 0x15, 0xa1, 0xef, 0x1e, 0xe3, 0x29, 0x03, 0x5b, 0x93,
+	0xc4, 0x58, 0x39, 0xf0, 0xd8, 0x89, 0x2c, 0x24, 0xa8, 0x53, 0xd5, 0xc9, 0x39, 0xf0, 0xa1, 0x40,
+	0xa4, 0xe3, 0x91, 0xa6, 0xea, 0x6f, 0xc2, 0x72, 0x48, 0x09, 0xc2, 0x9b, 0x38, 0x63, 0x6f, 0x7e,
+	0xfc, 0xc4, 0x09, 0xd6, 0x97, 0x51, 0x53, 0xd9, 0xec, 0x48, 0xf2, 0x2e, 0xa3, 0xea, 0x3d, 0xc0,
+	0x63, 0x69, 0x1d, 0xe3, 0xa6, 0xf2, 0xa7, 0x72, 0xce, 0x2e, 0x9b, 0x73, 0x55, 0x1d, 0xc3, 0xde,
+	0xc3, 0x3d, 0xe4, 0xaa, 0xf9, 0x3a, 0x24, 0x10, 0x53, 0xd2, 0x2a, 0x84, 0x27, 0x2f, 0x14, 0xaa,
+	0x50, 0x1e, 0x54, 0x2a, 0x32, 0xbb, 0x51, 0xad, 0x5e, 0xa8, 0xd1, 0x17, 0xae, 0x3e, 0xbd, 0x7d,
+	0xd2, 0x54, 0x7d, 0x1f, 0xd6, 0x42, 0x27, 0x38, 0x71, 0x71, 0xf1, 0xd6, 0x64, 0xe2, 0xcf, 0xe3,
+	0xcd, 0xb3, 0xc2, 0x14, 0x5e, 0x11, 0x0a, 0xf7, 0x39, 0xa8, 0xc7, 0x31, 0x6a, 0x81, 0x17, 0xc3,
+	0x02, 0x7a, 0x91, 0x52, 0x61, 0xe5, 0xc5, 0x73, 0x94, 0x2a, 0x3b, 0x33, 0x4a, 0x85, 0xa5, 0xf7,
+	0xa0, 0xeb, 0x59, 0xc7, 0x4e, 0x38, 0xb3, 0x26, 0x2a, 0x87, 0xad, 0x32, 0x75, 0x6b, 0x42, 0xdd,
+	0xae, 0x64, 0x2b, 0xf3, 0x96, 0xbd, 0x34, 0x29, 0xad, 0x44, 0xd8, 0xb4, 0x56, 0xac, 0x44, 0x99,
+	0x13, 0x2b, 0xe1, 0xa4, 0x9d, 0x06, 0xd4, 0x66, 0xd6, 0x19, 0xed, 0x6a, 0xe3, 0x2f, 0x65, 0x68,
+	0x7f, 0x1a, 0xf8, 0xc7, 0xf1, 0xa5, 0x82, 0xd9, 0x11, 0xd3, 0xe2, 0xc4, 0x09, 0xc3, 0x71, 0x18,
+	0x59, 0xd1, 0x3c, 0x4c, 0x27, 0x7d, 0x99, 0x1d, 0xf7, 0x38, 0x66, 0x9f, 0x41, 0xe2, 0x7c, 0x3b,
+	0xcb, 0x93, 0xf5, 0x5f, 0xc0, 0x95, 0x74, 0xc2, 0x48, 0xeb, 0xe5, 0x37, 0xc1, 0xf5, 0x82, 0xbc,
+	0x91, 0x51, 0xbe, 0x7e, 0xb4, 0x80, 0xb7, 0x70, 0x06, 0xe1, 0xa0, 0xca, 0x73, 0x66, 0x50, 0x9e,
+	0x2a, 0x98, 0x41, 0x04, 0x6f, 0x0a, 0xd7, 0xf3, 0xa9, 0x24, 0xbd, 0x0e, 0x7e, 0x7b, 0xbc, 0xb6,
+	0x20, 0xa3, 0x64, 0xd6, 0x72, 0xf5, 0xd9, 0x39, 0xfc, 0x73, 0x67, 0x13, 0x6b, 0xaa, 0xbd, 0xc0,
+	0x6c, 0x6a, 0x5d, 0x0b, 0x66, 0x13, 0x6b, 0x2b, 0x48, 0x20, 0xf5, 0xa2, 0x04, 0x92, 0xdc, 0x37,
+	0xbf, 0xd5, 0xa0, 0x95, 0x4c, 0x72, 0x78, 0xbf, 0x57, 0x79, 0x92, 0xc3, 0x52, 0x64, 0x29, 0xe1,
+	0xed, 0x24, 0x48, 0x0c, 0x06, 0x5e, 0x14, 0x9c, 0x99, 0x02, 0xbe, 0xf1, 0x21, 0x34, 0x13, 0x64,
+	0xbd, 0x0b, 0x4b, 0x4f, 0x9d, 0x33, 0x56, 0xcf, 0x34, 0x4c, 0xfa, 0xa9, 0x5f, 0x84, 0xca, 0x89,
+	0x35, 0x9d, 0xf3, 0xa2, 0xa5, 0x61, 0xf2, 0xc1, 0x47, 0xa5, 0x0f, 0x34, 0xa3, 0x0e, 0x55, 0x5e,
+	0xe9, 0x18, 0x7f, 0xd4, 0xa0, 0x99, 0xa8, 0x62, 0xf4, 0x0e, 0x94, 0x5c, 0x5b, 0x28, 0xc1, 0x5f,
+	0xfa, 0x3a, 0xd4, 0x8e, 0x1d, 0x5a, 0x43, 0x88, 0x5a, 0x96, 0x90, 0x28, 0x87, 0xfa, 0x2d, 0x28,
+	0x47, 0x67, 0x33, 0xbe, 0xbb, 0x3b, 0x5b, 0x57, 0xf3, 0x15, 0x11, 0xff, 0x3d, 0x42, 0x8c, 0xc9,
+	0x90, 0xc6, 0x3b, 0xd0, 0x50, 0x24, 0xbd, 0x0a, 0xa5, 0xe1, 0x5e, 0xf7, 0x15, 0x7d, 0x99, 0xe6,
+	0x1f, 0xf7, 0x76, 0xfb, 0xe3, 0xbd, 0x47, 0xe6, 0xa8, 0xab, 0xe9, 0x35, 0x58, 0xda, 0x1d, 0x8c,
+	0xba, 0x25, 0x63, 0x06, 0xdd, 0x6c, 0x81, 0x94, 0x33, 0xef, 0x35, 0x68, 0x5b, 0xb6, 0xed, 0xd8,
+	0xe3, 0xb4, 0x91, 0x2d, 0x46, 0x7c, 0x28, 0x2c, 0xc5, 0x30, 0xf1, 0xd8, 0xc7, 0xb0, 0x25, 0x06,
+	0xeb, 0x08, 0xb2, 0x00, 0x1a, 0xd7, 0x84, 0x2f, 0x44, 0x78, 0x33, 0x93, 0x19, 0x16, 0xac, 0x14,
+	0x14, 0x4b, 0xfa, 0x0d, 0x05, 0x6b, 0x6e, 0x75, 0xe3, 0x43, 0x4e, 0x88, 0x61, 0x9f, 0x59, 0x89,
+	0xe5, 0xa6, 0x28, 0x98, 0x44, 0xfd, 0xd8, 0x49, 0xc3, 0x4c, 0xc9, 0x36, 0xee, 0x64, 0xa6, 0x10,
+	0x96, 0x3c, 0x77, 0x0a, 0xe3, 0x3a, 0x34, 0x14, 0x41, 0xd7, 0xa1, 0x4c, 0x99, 0x4b, 0x98, 0xce,
+	0x7e, 0x1b, 0x3e, 0xd4, 0x04, 0x00, 0x23, 0xd7, 0x76, 0xbd, 0x27, 0x98, 0x60, 0xed, 0x71, 0x30,
+	0x9f, 0x3a, 0xa1, 0xd8, 0x78, 0x4d, 0xa1, 0xd8, 0x44, 0x9a, 0xd9, 0x12, 0x08, 0x1a, 0x84, 0xfa,
+	0x16, 0x74, 0xfc, 0x79, 0x94, 0x14, 0x29, 0xe5, 0x45, 0xda, 0x12, 0xc2, 0x64, 0x8c, 0x9f, 0x83,
+	0x9e, 0xaf, 0xdb, 0xf4, 0xeb, 0x89, 0x95, 0x2c, 0xcb, 0x95, 0x30, 0x80, 0xf0, 0xd5, 0x1b, 0x50,
+	0xe5, 0xb5, 0x9b, 0x70, 0x55, 0x3b, 0x05, 0x32, 0x05, 0xd3, 0x78, 0x3f, 0xad, 0x5d, 0xf8, 0xe9,
+	0x79, 0xda, 0x8d, 0x2d, 0xa8, 0xcb, 0x31, 0x79, 0x29, 0x72, 0xf1, 0xc8, 0x0a, 0x2f, 0xd1, 0x6f,
+	0xe5, 0xb9, 0x52, 0xc2, 0x73, 0x7f, 0xd3, 0xa0, 0xca, 0x85, 0xfe, 0x3f, 0x9e, 0xd3, 0xaf, 0x42,
+	0x03, 0x2f, 0xbf, 0x80, 0xde, 0x35, 0x36, 0x3b, 0x5e, 0x75, 0x33, 0x26, 0xe8, 0x97, 0xa1, 0x3e,
+	0x0b, 0x9c, 0xb1, 0xed, 0x59, 0x11, 0xbb, 0x01, 0xea, 0xb4, 0x7b, 0x9c, 0x3e, 0x0e, 0x49, 0x50,
+	0xdd, 0x58, 0x2c, 0x77, 0x37, 0xcc, 0x98, 0x60, 0x7c, 0xd3, 0x81, 0x32, 0x4d, 0xa0, 0xaf, 0x41,
+	0x95, 0x8a, 0x5d, 0xdf, 0x13, 0x4b, 0x17, 0x23, 0xfd, 0x5d, 0x00, 0x77, 0x36, 0x3e, 0xc1, 0x93,
+	0x40, 0xbc, 0x12, 0x3b, 0xd7, 0x5d, 0x75, 0xae, 0x7f, 0xca, 0xe9, 0x66, 0xc3, 0x9d, 0x89, 0x9f,
+	0xfa, 0x8f, 0xc8, 0x14, 0x7c, 0x75, 0x4d, 0xfc, 0xa9, 0xb8, 0xe4, 0x96, 0xe3, 0xcd, 0xc9, 0xc8,
+	0xa6, 0x02, 0xe8, 0x97, 0xa0, 0x16, 0x06, 0x93, 0xb1, 0xe7, 0x90, 0xd9, 0x74, 0xfa, 0xaa, 0x38,
+	0xdc, 0x75, 0x22, 0x1d, 0xd3, 0x02, 0x31, 0x66, 0x7e, 0x10, 0x85, 0x68, 0xf5, 0x52, 0x72, 0x8f,
+	0x23, 0xcd, 0xb4, 0xbc, 0x43, 0xc7, 0xac, 0x23, 0x84, 0x46, 0x21, 0xe9, 0xb1, 0xf1, 0xc6, 0x22,
+	0x3d, 0x55, 0xae, 0x07, 0x87, 0x42, 0x0f, 0x31, 0xb8, 0x9e, 0xda, 0x22, 0x3d, 0x08, 0xe1, 0x7a,
+	0xae, 0x41, 0xc3, 0x9d, 0x1c, 0xcf, 0xc6, 0x2c, 0x89, 0x51, 0xda, 0xae, 0x60, 0xbe, 0xaf, 0x13,
+	0x89, 0xe5, 0xa7, 0xbb, 0xd0, 0x51, 0xec, 0xf1, 0xc4, 0xb7, 0x65, 0xd5, 0x2f, 0xab, 0x85, 0xa1,
+	0x00, 0xf6, 0x3c, 0xfb, 0x1e, 0x72, 0xa9, 0x56, 0x95, 0xb2, 0x34, 0xc6, 0xcc, 0xd4, 0xa1, 0x55,
+	0xa1, 0x43, 0xe9, 0xed, 0xe6, 0xda, 0x21, 0x96, 0xf9, 0x64, 0x6d, 0x13, 0xa9, 0xc3, 0x19, 0x26,
+	0x99, 0xa1, 0x1d, 0x12, 0x88, 0x4c, 0x4e, 0x80, 0x9a, 0x1c, 0x84, 0x54, 0x05, 0xba, 0x03, 0x97,
+	0x99, 0xe3, 0x30, 0x90, 0x36, 0x5b, 0x5d, 0x12, 0xdf, 0x62, 0xf8, 0x8b, 0xe4, 0x4a, 0xe2, 0xd3,
+	0xd2, 0x92, 0x82, 0xcc, 0x53, 0x85, 0x82, 0x6d, 0x2e, 0x48, 0xbe, 0xcb, 0x09, 0x6e, 0x41, 0xcb,
+	0xf3, 0xa3, 0xb1, 0x8a, 0xed, 0x41, 0x71, 0x6c, 0x9b, 0x08, 0x92, 0x03, 0xfd, 0x55, 0xa0, 0xe1,
+	0x58, 0x86, 0xf8, 0x90, 0xa9, 0x6f, 0x20, 0x69, 0x9f, 0x47, 0xf9, 0x36, 0xb4, 0x25, 0x9f, 0x47,
+	0xe8, 0x68, 0x41, 0x84, 0x9a, 0x5c, 0x86, 0x07, 0x49, 0x68, 0x95, 0x01, 0x77, 0x95, 0xd6, 0x3e,
+	0x8f, 0xb9, 0xd0, 0x1a, 0xc7, 0xfd, 0x97, 0xe7, 0x68, 0xed, 0xcb, 0xd0, 0xbf, 0xce, 0xa5, 0xe2,
+	0xf0, 0x3f, 0x65, 0xe1, 0xd7, 0x18, 0x4a, 0x06, 0x56, 0x1f, 0x80, 0x9e, 0x42, 0xf1, 0x5d, 0x30,
+	0x3d, 0x77, 0x17, 0x68, 0x58, 0x33, 0xc6, 0x2a, 0xd8, 0x46, 0x78, 0x9b, 0xab, 0xc9, 0x6c, 0x86,
+	0x63, 0x7e, 0x01, 0xf1, 0xb5, 0x2a, 0xc7, 0x0b, 0x6c, 0x66, 0x4f, 0x78, 0x0a, 0xdb, 0x4f, 0x6c,
+	0x8b, 0xbb, 0x70, 0x4d, 0x39, 0xbc, 0x30, 0xc2, 0x33, 0x26, 0x76, 0x49, 0x84, 0x20, 0x17, 0x64,
+	0x21, 0xbf, 0x78, 0x87, 0x7c, 0xad, 0xe4, 0xfb, 0xc5, 0x9b, 0x64, 0xd5, 0x0f, 0xdc, 0x43, 0xd7,
+	0xb3, 0xa6, 0xcc, 0x88, 0xd0, 0x99, 0x3a, 0x93, 0xc8, 0x0f, 0xd6, 0x03, 0x96, 0x54, 0x56, 0x24,
+	0x13, 0x27, 0xdf, 0x17, 0xac, 0x94, 0x0c, 0x4d, 0xac, 0x64, 0xc2, 0xb4, 0x0c, 0x4e, 0xa8, 0x64,
+	0x06, 0x70, 0x3d, 0x35, 0x4f, 0x5c, 0xc5, 0x2b, 0xe9, 0x88, 0x49, 0x5f, 0x4d, 0xcc, 0xa8, 0x6a,
+	0xf9, 0x42, 0x35, 0x72, 0xcd, 0x19, 0x35, 0xf3, 0xb4, 0x1a, 0xb1, 0xea, 0xb4, 0x9a, 0x0f, 0xe1,
+	0xb2, 0x52, 0x23, 0xdd, 0xaf, 0x14, 0x9c, 0x30, 0x05, 0x6b, 0x12, 0xb0, 0xcb, 0x3c, 0xbf, 0x50,
+	0x34, 0xe5, 0x80, 0x67, 0x39, 0xd1, 0xa4, 0x0f, 0x1e, 0xf3, 0x14, 0x90, 0x7d, 0x5a, 0x1d, 0x5b,
+	0xd1, 0xe4, 0x68, 0xfd, 0x34, 0xf5, 0xbc, 0x48, 0xbf, 0xac, 0x1e, 0x12, 0xc2, 0x5c, 0x0b, 0xc9,
+	0x8c, 0x1c, 0x9d, 0xd4, 0x72, 0x23, 0x8a, 0xd4, 0x9e, 0x3d, 0x5f, 0xad, 0x4d, 0x26, 0xe6, 0xd5,
+	0xe2, 0x3d, 0x72, 0x14, 0x45, 0x33, 0xa1, 0xe7, 0x57, 0xa9, 0xaa, 0xe5, 0xfe, 0x68, 0xb4, 0xc7,
+	0xa5, 0x1b, 0x84, 0xe1, 0x02, 0x58, 0x64, 0xd2, 0xdd, 0x88, 0xbb, 0x6e, 0xfd, 0x3b, 0x71, 0x25,
+	0xd1, 0x78, 0x68, 0xe3, 0x85, 0x5b, 0x97, 0xcf, 0xdd, 0xf5, 0xbf, 0x6b, 0xa9, 0x4e, 0x01, 0x5d,
+	0x65, 0xea, 0x49, 0xab, 0x50, 0x3b, 0x55, 0x28, 0xd3, 0x89, 0xdd, 0x01, 0xa8, 0xcb, 0xd3, 0xfb,
+	0x79, 0xb5, 0xfe, 0xad, 0xd6, 0xfd, 0x4e, 0x33, 0x61, 0xea, 0x1f, 0x62, 0x56, 0x73, 0x0e, 0xdc,
+	0x53, 0xe3, 0x33, 0x58, 0x29, 0xb2, 0x7d, 0x03, 0xea, 0x2a, 0x26, 0xdc, 0x14, 0x35, 0xa6, 0x7a,
+	0x9a, 0xed, 0x1a, 0x51, 0x64, 0xf2, 0x81, 0xf1, 0xef, 0x12, 0x34, 0xd4, 0xaa, 0x78, 0xbd, 0x1c,
+	0x1d, 0xf9, 0x36, 0xaf, 0x0d, 0x58, 0xbd, 0xcc, 0x86, 0xb8, 0x94, 0xca, 0xcc, 0x8a, 0x8e, 0x64,
+	0x01, 0xb0, 0x91, 0x75, 0xc8, 0xe6, 0x1e, 0x72, 0xb9, 0x6b, 0x38, 0x50, 0xdf, 0x86, 0xda, 0x91,
+	0x63, 0xd9, 0xb2, 0x5e, 0x6d, 0xaa, 0x22, 0x3b, 0x96, 0xb9, 0xcf, 0xf8, 0x5c, 0x4a, 0x82, 0x37,
+	0x26, 0x58, 0x0b, 0x4a, 0x5d, 0x78, 0xd9, 0x57, 0x9c, 0x53, 0xbc, 0xe0, 0xf9, 0x6a, 0xf0, 0x9a,
+	0xe2, 0x43, 0x34, 0xb4, 0xca, 0x3d, 0xc1, 0x6b, 0x1d, 0x6a, 0x79, 0xf2, 0x31, 0x49, 0x04, 0xce,
+	0xa1, 0x73, 0xca, 0xae, 0x74, 0x26, 0xc1, 0x86, 0x3b, 0x2d, 0x00, 0xb2, 0x8b, 0x87, 0x75, 0xe3,
+	0xd7, 0xd0, 0x4c, 0x4c, 0x5e, 0x54, 0x72, 0xc6, 0x53, 0x97, 0xd2, 0x53, 0x6f, 0x50, 0x39, 0xec,
+	0x84, 0x8e, 0x17, 0xf1, 0xea, 0x06, 0x39, 0x92, 0x90, 0x30, 0xab, 0x9c, 0x36, 0x6b, 0xa7, 0x03,
+	0x2d, 0xbe, 0x40, 0x6e, 0x80, 0xf1, 0x21, 0x2c, 0x67, 0xb2, 0x2f, 0xab, 0xe8, 0x28, 0x9d, 0x93,
+	0x11, 0x15, 0xfe, 0xe8, 0x20, 0x1a, 0xcb, 0xdb, 0x25, 0x4e, 0xa3, 0xdf, 0xc6, 0x03, 0xac, 0x02,
+	0xe5, 0xbd, 0x85, 0x13, 0x8a, 0xa7, 0x9b, 0x26, 0x6a, 0x00, 0x31, 0xc6, 0x70, 0x27, 0x6a, 0x41,
+	0xa4, 0xb3, 0xd1, 0x4e, 0x17, 0x3a, 0x9c, 0x3f, 0xf6, 0x03, 0x96, 0x44, 0xb0, 0x14, 0x6d, 0xa8,
+	0x7b, 0x86, 0xf6, 0xc8, 0x81, 0x1b, 0x84, 0x91, 0xb0, 0x81, 0x0f, 0xc8, 0x88, 0xa9, 0x15, 0x46,
+	0xd2, 0x08, 0xfa, 0x6d, 0xfc, 0x5e, 0x03, 0x3d, 0xfb, 0xfa, 0xc4, 0xaa, 0x14, 0x1f, 0x2b, 0x7e,
+	0x30, 0x39, 0x72, 0x42, 0xac, 0xf7, 0x70, 0xd3, 0xd1, 0x99, 0xe0, 0xc5, 0x68, 0x27, 0x49, 0xc6,
+	0xa3, 0x71, 0x1d, 0x9a, 0xea, 0xa9, 0xeb, 0xf2, 0x3a, 0xb1, 0x61, 0x82, 0x24, 0x71, 0x80, 0x7a,
+	0x02, 0x23, 0xa0, 0xcc, 0x01, 0x92, 0x34, 0xb4, 0x3f, 0x2f, 0xd7, 0xb5, 0x6e, 0xc9, 0xac, 0xd3,
+	0xd3, 0x9d, 0x2d, 0xe4, 0x14, 0xd6, 0x8a, 0x3b, 0x85, 0xfa, 0x5b, 0x89, 0xba, 0xfa, 0xf2, 0x82,
+	0x97, 0xb3, 0xa8, 0xdf, 0xdf, 0x83, 0xba, 0x9c, 0x42, 0xb4, 0x0f, 0x2e, 0x2d, 0x6a, 0x15, 0x2a,
+	0xa0, 0xf1, 0xa7, 0x12, 0x74, 0xb3, 0x6c, 0x72, 0x25, 0xbd, 0xdc, 0xe5, 0x9e, 0xe2, 0x83, 0xa2,
+	0x0a, 0x9d, 0x9e, 0xbe, 0xc7, 0xd6, 0x44, 0xb8, 0x80, 0x7e, 0xd2, 0xda, 0x65, 0x8b, 0x9a, 0xae,
+	0x32, 0x5e, 0x70, 0x82, 0x20, 0xd1, 0xed, 0x75, 0x05, 0xab, 0xbf, 0xd9, 0xc9, 0x6d, 0xaa, 0x2a,
+	0x78, 0xd1, 0x89, 0x07, 0x9d, 0x08, 0x58, 0x54, 0x48, 0xe6, 0x36, 0x67, 0x56, 0x15, 0x73, 0x9b,
+	0x31, 0xdf, 0x80, 0x0a, 0x3d, 0x15, 0x64, 0x89, 0x29, 0xab, 0xa2, 0x11, 0xd2, 0x86, 0xde, 0x81,
+	0x6f, 0x72, 0x2e, 0xba, 0xac, 0xce, 0x27, 0xc0, 0x32, 0xbd, 0xce, 0x90, 0x1d, 0xd5, 0x67, 0x8a,
+	0x18, 0xb0, 0xc6, 0xe6, 0xc3, 0xb2, 0x9d, 0x43, 0xb7, 0x19, 0xb4, 0xb1, 0x10, 0xba, 0x8d, 0x03,
+	0xe3, 0x5e, 0x3e, 0x44, 0xe2, 0xe9, 0xf3, 0xe2, 0x21, 0x32, 0x7a, 0xd0, 0x49, 0xb6, 0x72, 0x70,
+	0xd3, 0x65, 0xb6, 0x4a, 0xe9, 0xb9, 0x5b, 0x65, 0x0a, 0x7a, 0xbe, 0xed, 0x8d, 0xae, 0x89, 0x6d,
+	0x58, 0x2d, 0x68, 0x1a, 0x89, 0x2d, 0xf2, 0x6e, 0x62, 0x8b, 0x2c, 0xa5, 0x72, 0x7a, 0xaa, 0xf7,
+	0x1d, 0x6f, 0x8f, 0x7f, 0x95, 0xa0, 0x95, 0x64, 0x15, 0x66, 0x9b, 0x4c, 0xc8, 0x4b, 0xb9, 0x90,
+	0xab, 0xc0, 0x2d, 0x9d, 0x1b, 0xb8, 0x4d, 0x58, 0x71, 0x4e, 0x67, 0x98, 0xf1, 0xb1, 0x24, 0x62,
+	0x11, 0xb4, 0x6c, 0x3b, 0x90, 0x5b, 0xe8, 0x82, 0x64, 0x0d, 0x91, 0xd3, 0x23, 0x46, 0x16, 0xbf,
+	0x2d, 0xf0, 0x95, 0x1c, 0x7e, 0x9b, 0xe3, 0x3f, 0x80, 0x65, 0xf5, 0x98, 0x1b, 0x73, 0x83, 0xaa,
+	0xc5, 0x06, 0x75, 0x14, 0x6e, 0xc4, 0x2c, 0x7b, 0x1f, 0x3a, 0xf2, 0xe5, 0x37, 0x3e, 0x77, 0x0b,
+	0xb6, 0xc4, 0x83, 0x90, 0x8b, 0x61, 0x8d, 0x7c, 0xe0, 0x07, 0xcf, 0xac, 0x40, 0x4e, 0x57, 0x5f,
+	0x20, 0x25, 0x50, 0x4c, 0xca, 0xf8, 0x38, 0x1d, 0x61, 0xb1, 0xcb, 0x5e, 0x2c, 0xc2, 0x46, 0x00,
+	0x75, 0xa9, 0xb6, 0x30, 0x56, 0x6f, 0x41, 0xd7, 0xf5, 0x0e, 0x03, 0x6a, 0x95, 0xb2, 0xf7, 0xbc,
+	0xab, 0x2e, 0xd5, 0x65, 0x41, 0xdf, 0x13, 0x64, 0xca, 0x87, 0x4e, 0x06, 0x29, 0x9a, 0x37, 0x4e,
+	0x0a, 0x68, 0xdc, 0x81, 0x9a, 0x38, 0x2e, 0xfa, 0x2a, 0x54, 0x9d, 0x53, 0xaa, 0x65, 0x65, 0xea,
+	0xc0, 0xd1, 0x70, 0x46, 0x64, 0xb6, 0xc1, 0x67, 0xb2, 0x21, 0x46, 0x06, 0xcf, 0x0c, 0x13, 0x56,
+	0x0a, 0x7a, 0xb2, 0xd4, 0x5a, 0x72, 0x43, 0x1f, 0x5d, 0x86, 0x97, 0x7c, 0x64, 0x1d, 0x4b, 0x5d,
+	0x2d, 0x24, 0x8e, 0x24, 0x8d, 0x9e, 0xd2, 0xf3, 0x19, 0x41, 0x98, 0x4a, 0xcd, 0x14, 0x23, 0x63,
+	0x06, 0xeb, 0x8b, 0xfa, 0xb1, 0x2f, 0x7a, 0x4a, 0xde, 0x81, 0x2a, 0x6f, 0x5c, 0x8a, 0x46, 0x88,
+	0x84, 0x66, 0x3a, 0x91, 0x02, 0x64, 0xdc, 0x84, 0x4e, 0x9a, 0x43, 0xb6, 0x09, 0x05, 0xa2, 0xa6,
+	0x12, 0xc8, 0x5e, 0x91, 0x6d, 0x2f, 0x17, 0xdf, 0x53, 0xb8, 0x7a, 0x5e, 0x9b, 0xf6, 0x65, 0xee,
+	0x8b, 0x97, 0x5c, 0xe6, 0x70, 0xd1, 0xcc, 0x2f, 0x9f, 0x06, 0x1f, 0xf2, 0x1d, 0x9e, 0xf9, 0x28,
+	0x84, 0x05, 0xa0, 0xcc, 0x72, 0xb2, 0x00, 0x94, 0x63, 0x75, 0x69, 0xd0, 0x09, 0x17, 0x7b, 0x88,
+	0x25, 0x79, 0x3a, 0xd8, 0x59, 0x75, 0xc2, 0x9e, 0xff, 0x5a, 0xdd, 0x00, 0x3a, 0xe9, 0x8f, 0x4a,
+	0x05, 0xbd, 0xcf, 0x32, 0x7d, 0x4d, 0x12, 0x7e, 0x5b, 0xce, 0x7e, 0x46, 0x62, 0x4c, 0xe3, 0x46,
+	0xac, 0x66, 0x41, 0x57, 0xf3, 0x2e, 0xd4, 0x25, 0x82, 0x15, 0x4b, 0xae, 0xad, 0x5a, 0x62, 0xf4,
+	0x1b, 0x9f, 0xe0, 0x70, 0x6c, 0x85, 0x5f, 0xcf, 0x9d, 0xc0, 0x12, 0x65, 0x54, 0xdd, 0x4c, 0x50,
+	0x8c, 0xbf, 0x6a, 0x70, 0xb1, 0xe8, 0x1b, 0x11, 0x9e, 0xdc, 0x38, 0x14, 0x97, 0x0a, 0x9f, 0x11,
+	0x62, 0x0b, 0x7c, 0x02, 0xd5, 0xa9, 0xf5, 0xc4, 0x99, 0xca, 0xd2, 0xf8, 0xcd, 0x73, 0xbe, 0x3c,
+	0x6d, 0x3e, 0x60, 0x48, 0xd1, 0x09, 0xe7, 0x62, 0xd4, 0x09, 0x4f, 0x90, 0x5f, 0xaa, 0x13, 0xfe,
+	0x49, 0xd6, 0x78, 0xd5, 0xda, 0x7f, 0x31, 0xe3, 0x8d, 0x3e, 0x74, 0xb3, 0xf4, 0x74, 0x1f, 0x4e,
+	0xcb, 0xf4, 0xe1, 0x0a, 0x7b, 0x8c, 0x7f, 0xd6, 0x60, 0x39, 0xf3, 0x11, 0x4b, 0x37, 0x12, 0x26,
+	0xe8, 0xd9, 0x6f, 0x54, 0xc2, 0x75, 0x1f, 0x65, 0x5c, 0x67, 0x14, 0x7f, 0x10, 0xfb, 0x5f, 0x7b,
+	0xed, 0xfd, 0x84, 0xb5, 0xc2, 0x61, 0x2f, 0x60, 0xad, 0xf1, 0x03, 0x68, 0x26, 0x48, 0x85, 0x6d,
+	0xea, 0x3f, 0x68, 0xd0, 0x4a, 0xbe, 0xec, 0xf4, 0x4f, 0xa1, 0x69, 0x79, 0xf8, 0x92, 0xb3, 0xa8,
+	0x45, 0x29, 0x1b, 0xae, 0xaf, 0x17, 0xbc, 0x01, 0x37, 0x7b, 0x31, 0x8c, 0x2f, 0x34, 0x29, 0xb8,
+	0x71, 0x17, 0xba, 0x59, 0xc0, 0x4b, 0x2d, 0xf9, 0x37, 0x25, 0xe8, 0xa8, 0x6f, 0x7d, 0x94, 0x72,
+	0x42, 0xba, 0x4f, 0x78, 0x57, 0x47, 0x55, 0xa8, 0xd4, 0xca, 0x21, 0x32, 0x6f, 0xe0, 0x48, 0x25,
+	0xac, 0x99, 0x47, 0x7d, 0x5b, 0xd9, 0xfc, 0x62, 0x55, 0x4f, 0xc5, 0xac, 0x89, 0x9e, 0x26, 0xb1,
+	0x64, 0x07, 0x8b, 0x95, 0xe9, 0xc8, 0x12, 0x6d, 0xca, 0x54, 0x8b, 0xb5, 0xf2, 0xbc, 0x16, 0xeb,
+	0x0f, 0x79, 0xc5, 0x2c, 0x0b, 0x0a, 0xf9, 0xe6, 0x26, 0x6b, 0xdd, 0x30, 0x72, 0x27, 0xbc, 0x86,
+	0xa6, 0x42, 0xa2, 0xfd, 0xcc, 0x3a, 0x60, 0xfd, 0xe8, 0xf1, 0x91, 0x8b, 0x78, 0x9b, 0xe1, 0x2f,
+	0xc8, 0x7c, 0xd9, 0xfb, 0x94, 0x1c, 0x7b, 0xdf, 0x8d, 0xcc, 0x26, 0xe2, 0xc4, 0xef, 0xd0, 0xf8,
+	0xdd, 0x12, 0x34, 0x94, 0x2e, 0xac, 0x63, 0x1a, 0xb6, 0x1b, 0x38, 0x71, 0x23, 0xb9, 0x13, 0x37,
+	0x0b, 0x24, 0x68, 0xb3, 0x2f, 0x11, 0x66, 0x0c, 0xd6, 0x3f, 0x06, 0x08, 0x9c, 0x29, 0x42, 0x4e,
+	0xdc, 0xe8, 0x4c, 0xf4, 0x99, 0xaf, 0xe4, 0x44, 0x4d, 0x05, 0x31, 0x13, 0x70, 0x4c, 0xf0, 0xe5,
+	0xa7, 0xae, 0x67, 0x8b, 0xcf, 0x4e, 0xab, 0x39, 0xb1, 0x2f, 0x90, 0x69, 0x32, 0x08, 0xd6, 0x99,
+	0xb2, 0xcf, 0x5d, 0x66, 0xe0, 0x4b, 0x39, 0x70, 0x8f, 0xdb, 0x26, 0x1b, 0xe0, 0x2a, 0xfa, 0xe4,
+	0xe9, 0x25, 0x11, 0x7d, 0x03, 0x4f, 0xb3, 0x5a, 0x06, 0xfb, 0x6c, 0xb5, 0xdb, 0x7d, 0x85, 0xbe,
+	0x52, 0x3d, 0x7a, 0x3c, 0xea, 0x6a, 0x86, 0x01, 0x10, 0x5b, 0xaa, 0x37, 0xa0, 0x32, 0x7a, 0x34,
+	0xea, 0x3d, 0x40, 0x04, 0xfe, 0xec, 0x0f, 0x1e, 0x8c, 0x7a, 0x88, 0xf9, 0x31, 0x94, 0xc9, 0x2c,
+	0xbd, 0x09, 0xb5, 0xbd, 0xde, 0xbd, 0x2f, 0x06, 0xa3, 0x7d, 0xce, 0xdf, 0xf9, 0x6a, 0x34, 0xd8,
+	0xef, 0x6a, 0xfa, 0x05, 0x68, 0xd3, 0x93, 0x7e, 0x6c, 0x0e, 0x7e, 0xf2, 0x78, 0xb0, 0x8f, 0xdc,
+	0x12, 0x1e, 0x95, 0x2a, 0x37, 0x8e, 0x84, 0x7a, 0x0f, 0x1e, 0x3c, 0xfa, 0x72, 0xd0, 0x47, 0x21,
+	0x80, 0x6a, 0x7f, 0xb0, 0x3b, 0xc4, 0xdf, 0x9a, 0xf1, 0x8d, 0x06, 0x10, 0x87, 0x8a, 0xfa, 0xe2,
+	0xb2, 0x89, 0xc2, 0xdf, 0x9e, 0xb2, 0x87, 0xc2, 0x5a, 0x1a, 0x27, 0x4e, 0x20, 0x9d, 0xcd, 0x5a,
+	0x1a, 0x7c, 0xcc, 0xdb, 0x15, 0x61, 0x68, 0x1d, 0x3a, 0xe2, 0xf5, 0x24, 0x87, 0xc4, 0x79, 0x32,
+	0xf5, 0xd9, 0x27, 0x08, 0xf1, 0x95, 0x41, 0x0c, 0xc9, 0x4b, 0x2c, 0xd1, 0x49, 0x2f, 0xb1, 0x81,
+	0xb1, 0x89, 0xe7, 0xd6, 0xa1, 0x1d, 0x6c, 0x3a, 0xe1, 0x7c, 0x1a, 0xd1, 0xb5, 0x11, 0xce, 0x27,
+	0x54, 0x55, 0x1d, 0xcc, 0xa7, 0xcc, 0x22, 0xbc, 0x36, 0x62, 0xca, 0xdb, 0x37, 0xe9, 0x63, 0xa0,
+	0xfc, 0x90, 0x80, 0xde, 0xec, 0xed, 0x7e, 0x85, 0xeb, 0xab, 0x43, 0x19, 0xa9, 0xb7, 0xbb, 0x65,
+	0xf1, 0x6b, 0xbb, 0x5b, 0xdd, 0x8a, 0x00, 0xf8, 0xe7, 0x17, 0xf6, 0x07, 0x59, 0xb7, 0xa0, 0xcc,
+	0xfe, 0x97, 0x39, 0x26, 0xf1, 0x67, 0x5e, 0x1b, 0x92, 0x96, 0xf8, 0x53, 0xaf, 0x5b, 0x1a, 0xd6,
+	0xbf, 0x55, 0x6e, 0x99, 0x2e, 0x77, 0x4b, 0xfa, 0x1c, 0x6f, 0xa8, 0x8e, 0x52, 0xc2, 0xfe, 0x9d,
+	0x95, 0x6f, 0xff, 0xf9, 0xaa, 0xf6, 0x3d, 0xfe, 0xfb, 0x07, 0xfe, 0xfb, 0x59, 0x85, 0x9d, 0xb1,
+	0x27, 0x55, 0xf6, 0xdf, 0x7b, 0xff, 0x01, 0xec, 0x9e, 0x96, 0x9c, 0x7a, 0x26, 0x00, 0x00,
 }
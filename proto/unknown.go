@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proto
+
+import (
+	"encoding/binary"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fieldsByType caches the fields of each message type, by the pointer type of the message. It holds at most one entry
+// per message type in this package.
+var fieldsByType sync.Map
+
+// UnknownFields calls fn with the name of the message and the number of each field in data, the encoding of m, that
+// isn't in this version of the protocol, such as those that newer versions of Felix send. Unmarshal skips those
+// fields without reporting them. Fields of nested messages are included. Data that Unmarshal would reject is ignored.
+func UnknownFields(m interface{}, data []byte, fn func(message string, field int32)) {
+	unknownFields(reflect.TypeOf(m), data, fn)
+}
+
+func unknownFields(t reflect.Type, data []byte, fn func(message string, field int32)) {
+	fields := messageFields(t)
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return
+		}
+		data = data[n:]
+		var value []byte
+		switch key & 7 {
+		case 0: // varint
+			if _, n = binary.Uvarint(data); n <= 0 {
+				return
+			}
+		case 1: // fixed64
+			n = 8
+		case 2: // length-delimited
+			l, m := binary.Uvarint(data)
+			if m <= 0 || l > uint64(len(data)-m) {
+				return
+			}
+			value = data[m : m+int(l)]
+			n = m + int(l)
+		case 5: // fixed32
+			n = 4
+		default:
+			return
+		}
+		if n > len(data) {
+			return
+		}
+		data = data[n:]
+		num := int32(key >> 3)
+		field, ok := fields[num]
+		if !ok {
+			fn(t.Elem().Name(), num)
+		} else if field != nil && value != nil {
+			unknownFields(field, value, fn)
+		}
+	}
+}
+
+// messageFields returns the numbers of the fields of the message with pointer type t, mapped to the pointer types of
+// the messages they hold, or nil for other fields.
+func messageFields(t reflect.Type) map[int32]reflect.Type {
+	if f, ok := fieldsByType.Load(t); ok {
+		return f.(map[int32]reflect.Type)
+	}
+	fields := map[int32]reflect.Type{}
+	s := t.Elem()
+	for i := 0; i < s.NumField(); i++ {
+		addField(fields, s.Field(i))
+	}
+	// The fields of oneofs are in wrapper types, which the generated code lists.
+	if m, ok := t.MethodByName("XXX_OneofFuncs"); ok {
+		out := m.Func.Call([]reflect.Value{reflect.Zero(t)})
+		for _, w := range out[3].Interface().([]interface{}) {
+			addField(fields, reflect.TypeOf(w).Elem().Field(0))
+		}
+	}
+	fieldsByType.Store(t, fields)
+	return fields
+}
+
+// addField adds the generated struct field f to fields, if it is a protobuf field.
+func addField(fields map[int32]reflect.Type, f reflect.StructField) {
+	tag := strings.Split(f.Tag.Get("protobuf"), ",")
+	if len(tag) < 2 {
+		return
+	}
+	num, err := strconv.Atoi(tag[1])
+	if err != nil {
+		return
+	}
+	t := f.Type
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+		fields[int32(num)] = t
+	} else {
+		fields[int32(num)] = nil
+	}
+}
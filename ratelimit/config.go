@@ -0,0 +1,135 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the rate limit configuration file.
+type Config struct {
+	// PathClasses group request paths so that they share buckets.
+	PathClasses []PathClass `json:"pathClasses,omitempty"`
+	// Limits are evaluated in order; a request is limited by the first one it matches.
+	Limits []Rule `json:"limits,omitempty"`
+}
+
+// PathClass names a set of request paths.
+type PathClass struct {
+	Name string `json:"name"`
+	// Prefixes are the path prefixes in the class. The query string is ignored.
+	Prefixes []string `json:"prefixes"`
+}
+
+// Rule applies a limit to the requests it matches. Each distinct source, destination and path class gets its own
+// bucket.
+type Rule struct {
+	// Name identifies the rule's buckets. Defaults to the rule's index.
+	Name string `json:"name,omitempty"`
+	// Source and Destination match the peers' identities: their SPIFFE IDs, or IP addresses if they have none. A
+	// trailing "*" matches any suffix, and an empty value matches anything.
+	Source      string `json:"source,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	// PathClass, if set, restricts the rule to requests in the named class.
+	PathClass string `json:"pathClass,omitempty"`
+	// Rate is the limit, in the format accepted by ParseLimit.
+	Rate string `json:"rate"`
+
+	limit Limit
+}
+
+// LoadConfig reads and validates a rate limit configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConfig(b)
+}
+
+// ParseConfig parses and validates a rate limit configuration.
+func ParseConfig(b []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	classes := map[string]bool{}
+	for _, pc := range c.PathClasses {
+		if pc.Name == "" {
+			return nil, fmt.Errorf("path class with no name")
+		}
+		classes[pc.Name] = true
+	}
+	for i := range c.Limits {
+		r := &c.Limits[i]
+		if r.Name == "" {
+			r.Name = strconv.Itoa(i)
+		}
+		if r.PathClass != "" && !classes[r.PathClass] {
+			return nil, fmt.Errorf("limit %s: unknown path class %q", r.Name, r.PathClass)
+		}
+		l, err := ParseLimit(r.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("limit %s: %v", r.Name, err)
+		}
+		r.limit = l
+	}
+	return c, nil
+}
+
+// Classify returns the name of the first path class that path belongs to, or "" if none.
+func (c *Config) Classify(path string) string {
+	if c == nil {
+		return ""
+	}
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	for _, pc := range c.PathClasses {
+		for _, p := range pc.Prefixes {
+			if strings.HasPrefix(path, p) {
+				return pc.Name
+			}
+		}
+	}
+	return ""
+}
+
+// match returns the first rule that matches the request, or nil.
+func (c *Config) match(source, destination, class string) *Rule {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Limits {
+		r := &c.Limits[i]
+		if identityMatches(r.Source, source) && identityMatches(r.Destination, destination) &&
+			(r.PathClass == "" || r.PathClass == class) {
+			return r
+		}
+	}
+	return nil
+}
+
+func identityMatches(pattern, id string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(id, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == "" || pattern == id
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+const testConfig = `
+pathClasses:
+- name: login
+  prefixes: ["/login", "/oauth/"]
+- name: api
+  prefixes: ["/api/"]
+limits:
+- name: login
+  pathClass: login
+  rate: 5/m,burst=2
+- source: spiffe://cluster.local/ns/batch/*
+  destination: spiffe://cluster.local/ns/default/sa/api
+  rate: 1/s
+`
+
+func TestParseConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte(testConfig))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(c.Limits).To(HaveLen(2))
+	Expect(c.Limits[0].limit).To(Equal(Limit{Rate: 5.0 / 60, Burst: 2}))
+	Expect(c.Limits[1].Name).To(Equal("1"))
+
+	Expect(c.Classify("/login")).To(Equal("login"))
+	Expect(c.Classify("/oauth/token?x=/api/")).To(Equal("login"))
+	Expect(c.Classify("/api/v1/things")).To(Equal("api"))
+	Expect(c.Classify("/")).To(Equal(""))
+
+	for _, bad := range []string{
+		"limits: [{rate: 1/x}]",
+		"limits: [{rate: 1/s, pathClass: nope}]",
+		"pathClasses: [{prefixes: [/]}]",
+		"limit: []",
+	} {
+		_, err := ParseConfig([]byte(bad))
+		Expect(err).To(HaveOccurred(), bad)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	RegisterTestingT(t)
+
+	c, err := ParseConfig([]byte(testConfig))
+	Expect(err).ToNot(HaveOccurred())
+	uut := NewLimiter(c)
+	now := time.Unix(1000, 0)
+	alice := "spiffe://cluster.local/ns/default/sa/alice"
+	bob := "spiffe://cluster.local/ns/default/sa/bob"
+	api := "spiffe://cluster.local/ns/default/sa/api"
+	batch := "spiffe://cluster.local/ns/batch/sa/job"
+
+	// Each source gets its own login bucket.
+	for i := 0; i < 2; i++ {
+		d, ok := uut.Check(alice, api, "/login", now)
+		Expect(ok).To(BeTrue())
+		Expect(d.Allowed).To(BeTrue())
+	}
+	d, _ := uut.Check(alice, api, "/oauth/authorize", now)
+	Expect(d.Allowed).To(BeFalse())
+	d, _ = uut.Check(bob, api, "/login", now)
+	Expect(d.Allowed).To(BeTrue())
+
+	// The first matching limit applies.
+	d, _ = uut.Check(batch, api, "/api/v1", now)
+	Expect(d.Allowed).To(BeTrue())
+	d, _ = uut.Check(batch, api, "/api/v2", now)
+	Expect(d.Allowed).To(BeFalse())
+
+	// Requests that match no limit are not limited.
+	_, ok := uut.Check(alice, api, "/api/v1", now)
+	Expect(ok).To(BeFalse())
+	_, ok = NewLimiter(nil).Check(alice, api, "/login", now)
+	Expect(ok).To(BeFalse())
+}
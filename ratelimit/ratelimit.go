@@ -0,0 +1,243 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit implements token-bucket rate limiting keyed by source identity, destination and path class.
+package ratelimit
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultMaxBuckets bounds the number of buckets a Limiter tracks.
+const DefaultMaxBuckets = 65536
+
+// Limit is a token-bucket rate: Rate tokens are added per second, up to Burst.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimit parses a limit of the form "<count>/<unit>[,burst=<n>]", where unit is s, m or h. For example "10/s" or
+// "100/m,burst=20". The burst defaults to the count.
+func ParseLimit(s string) (Limit, error) {
+	parts := strings.Split(strings.TrimSpace(s), ",")
+	rate := strings.Split(parts[0], "/")
+	if len(rate) != 2 || len(parts) > 2 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: want <count>/<unit>[,burst=<n>]", s)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(rate[0]))
+	if err != nil || count <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: count must be a positive integer", s)
+	}
+	var per time.Duration
+	switch strings.TrimSpace(rate[1]) {
+	case "s":
+		per = time.Second
+	case "m":
+		per = time.Minute
+	case "h":
+		per = time.Hour
+	default:
+		return Limit{}, fmt.Errorf("invalid rate limit %q: unit must be s, m or h", s)
+	}
+	l := Limit{Rate: float64(count) / per.Seconds(), Burst: count}
+	if len(parts) == 2 {
+		b := strings.TrimPrefix(strings.TrimSpace(parts[1]), "burst=")
+		l.Burst, err = strconv.Atoi(b)
+		if err != nil || l.Burst <= 0 || b == strings.TrimSpace(parts[1]) {
+			return Limit{}, fmt.Errorf("invalid rate limit %q: burst must be burst=<positive integer>", s)
+		}
+	}
+	return l, nil
+}
+
+// Key identifies a bucket. Scope separates buckets created by different limits for the same traffic.
+type Key struct {
+	Scope       string
+	Source      string
+	Destination string
+	PathClass   string
+}
+
+// Decision is the outcome of taking a token from a bucket.
+type Decision struct {
+	Allowed bool
+	Limit   Limit
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is how long until a token is available, if the request was not allowed.
+	RetryAfter time.Duration
+}
+
+// Stricter returns whichever of d and o should be reported: a denial over an allow, and otherwise the one with fewer
+// tokens remaining.
+func (d Decision) Stricter(o Decision) Decision {
+	if d.Allowed != o.Allowed {
+		if !d.Allowed {
+			return d
+		}
+		return o
+	}
+	if !d.Allowed {
+		if o.RetryAfter > d.RetryAfter {
+			return o
+		}
+		return d
+	}
+	if o.Remaining < d.Remaining {
+		return o
+	}
+	return d
+}
+
+// Headers returns the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers describing the decision, and
+// Retry-After if the request was not allowed. Times are rounded up to whole seconds.
+func (d Decision) Headers() map[string]string {
+	h := map[string]string{
+		"RateLimit-Limit":     strconv.Itoa(d.Limit.Burst),
+		"RateLimit-Remaining": strconv.Itoa(d.Remaining),
+		"RateLimit-Reset":     strconv.Itoa(seconds(d.Reset)),
+	}
+	if !d.Allowed {
+		h["Retry-After"] = strconv.Itoa(seconds(d.RetryAfter))
+	}
+	return h
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+type bucket struct {
+	key    Key
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// tokensAt returns the tokens the bucket will hold at now, with those accumulated since it was last used.
+func (b *bucket) tokensAt(now time.Time) float64 {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		return math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.Rate)
+	}
+	return b.tokens
+}
+
+// refill adds the tokens accumulated since the bucket was last used.
+func (b *bucket) refill(now time.Time) {
+	b.tokens = b.tokensAt(now)
+	b.last = now
+}
+
+// Limiter tracks token buckets. It is safe for concurrent use.
+type Limiter struct {
+	config     *Config
+	maxBuckets int
+
+	mu      sync.Mutex
+	buckets map[Key]*list.Element
+	// lru holds the buckets, most recently used first.
+	lru *list.List
+}
+
+// NewLimiter creates a Limiter that applies the limits in config, which may be nil.
+func NewLimiter(config *Config) *Limiter {
+	return &Limiter{config: config, maxBuckets: DefaultMaxBuckets, buckets: map[Key]*list.Element{}, lru: list.New()}
+}
+
+// PathClass returns the configured class of path, or "" if it matches no class.
+func (l *Limiter) PathClass(path string) string {
+	return l.config.Classify(path)
+}
+
+// Check takes a token for a request from the first configured limit it matches. It returns false if no limit
+// matches.
+func (l *Limiter) Check(source, destination, path string, now time.Time) (Decision, bool) {
+	class := l.PathClass(path)
+	r := l.config.match(source, destination, class)
+	if r == nil {
+		return Decision{}, false
+	}
+	return l.Take(Key{Scope: "limit/" + r.Name, Source: source, Destination: destination, PathClass: class}, r.limit, now), true
+}
+
+// Take takes a token from the bucket for k, creating it full if necessary. If the bucket exists with a different
+// limit, the new limit takes effect and the bucket keeps its tokens up to the new burst.
+func (l *Limiter) Take(k Key, limit Limit, now time.Time) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var b *bucket
+	if e, ok := l.buckets[k]; ok {
+		b = e.Value.(*bucket)
+		l.lru.MoveToFront(e)
+	} else {
+		l.makeRoom(now)
+		b = &bucket{key: k, limit: limit, tokens: float64(limit.Burst), last: now}
+		l.buckets[k] = l.lru.PushFront(b)
+	}
+	if b.limit != limit {
+		b.limit = limit
+		b.tokens = math.Min(b.tokens, float64(limit.Burst))
+	}
+	b.refill(now)
+
+	d := Decision{Limit: limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		d.Allowed = true
+	} else {
+		d.RetryAfter = time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	}
+	d.Remaining = int(b.tokens)
+	d.Reset = time.Duration((float64(limit.Burst) - b.tokens) / limit.Rate * float64(time.Second))
+	return d
+}
+
+// makeRoom ensures there is space for a new bucket. Buckets that have refilled are indistinguishable from new ones,
+// so those least recently used are dropped first; if that is not enough the least recently used bucket is evicted.
+func (l *Limiter) makeRoom(now time.Time) {
+	if len(l.buckets) < l.maxBuckets {
+		return
+	}
+	// Checking for refilled buckets mustn't refill them, which would mark them as used now.
+	for e := l.lru.Back(); e != nil; e = l.lru.Back() {
+		b := e.Value.(*bucket)
+		if b.tokensAt(now) < float64(b.limit.Burst) {
+			break
+		}
+		l.remove(e)
+	}
+	if len(l.buckets) < l.maxBuckets {
+		return
+	}
+	oldest := l.lru.Back()
+	log.WithField("key", oldest.Value.(*bucket).key).Warn(
+		"Rate limiter is tracking too many buckets, evicting least recently used.")
+	l.remove(oldest)
+}
+
+func (l *Limiter) remove(e *list.Element) {
+	delete(l.buckets, e.Value.(*bucket).key)
+	l.lru.Remove(e)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ParseLimit("10/s")).To(Equal(Limit{Rate: 10, Burst: 10}))
+	Expect(ParseLimit("120/m")).To(Equal(Limit{Rate: 2, Burst: 120}))
+	Expect(ParseLimit("3600/h,burst=5")).To(Equal(Limit{Rate: 1, Burst: 5}))
+	Expect(ParseLimit(" 5/s, burst=1 ")).To(Equal(Limit{Rate: 5, Burst: 1}))
+
+	for _, bad := range []string{"", "10", "10/d", "0/s", "-1/s", "x/s", "10/s,5", "10/s,burst=0", "10/s,burst=1,x"} {
+		_, err := ParseLimit(bad)
+		Expect(err).To(HaveOccurred(), bad)
+	}
+}
+
+func TestTake(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := NewLimiter(nil)
+	k := Key{Source: "a", Destination: "b"}
+	l := Limit{Rate: 1, Burst: 2}
+	now := time.Unix(1000, 0)
+
+	d := uut.Take(k, l, now)
+	Expect(d).To(Equal(Decision{Allowed: true, Limit: l, Remaining: 1, Reset: time.Second}))
+	d = uut.Take(k, l, now)
+	Expect(d).To(Equal(Decision{Allowed: true, Limit: l, Remaining: 0, Reset: 2 * time.Second}))
+	d = uut.Take(k, l, now)
+	Expect(d).To(Equal(Decision{Allowed: false, Limit: l, Remaining: 0, Reset: 2 * time.Second, RetryAfter: time.Second}))
+
+	// Other keys have their own buckets.
+	Expect(uut.Take(Key{Source: "c", Destination: "b"}, l, now).Allowed).To(BeTrue())
+
+	// Tokens refill over time, up to the burst.
+	Expect(uut.Take(k, l, now.Add(500*time.Millisecond)).Allowed).To(BeFalse())
+	Expect(uut.Take(k, l, now.Add(time.Second)).Allowed).To(BeTrue())
+	d = uut.Take(k, l, now.Add(time.Hour))
+	Expect(d.Allowed).To(BeTrue())
+	Expect(d.Remaining).To(Equal(1))
+}
+
+func TestTakeLimitChange(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := NewLimiter(nil)
+	k := Key{Source: "a"}
+	now := time.Unix(1000, 0)
+	Expect(uut.Take(k, Limit{Rate: 1, Burst: 10}, now).Remaining).To(Equal(9))
+	// Lowering the burst caps the tokens already in the bucket.
+	Expect(uut.Take(k, Limit{Rate: 1, Burst: 2}, now).Remaining).To(Equal(1))
+}
+
+func TestEviction(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := NewLimiter(nil)
+	uut.maxBuckets = 2
+	l := Limit{Rate: 1, Burst: 1}
+	now := time.Unix(1000, 0)
+
+	uut.Take(Key{Source: "a"}, l, now)
+	uut.Take(Key{Source: "b"}, l, now.Add(time.Millisecond))
+	// Neither bucket has refilled, so the least recently used one is evicted.
+	uut.Take(Key{Source: "c"}, l, now.Add(2*time.Millisecond))
+	Expect(uut.buckets).To(HaveLen(2))
+	Expect(uut.buckets).ToNot(HaveKey(Key{Source: "a"}))
+
+	// Using a bucket makes it the most recently used.
+	uut.Take(Key{Source: "b"}, l, now.Add(3*time.Millisecond))
+	uut.Take(Key{Source: "e"}, l, now.Add(4*time.Millisecond))
+	Expect(uut.buckets).To(HaveLen(2))
+	Expect(uut.buckets).To(HaveKey(Key{Source: "b"}))
+	Expect(uut.buckets).ToNot(HaveKey(Key{Source: "c"}))
+
+	// Once they have refilled, all of them are dropped.
+	uut.Take(Key{Source: "d"}, l, now.Add(time.Minute))
+	Expect(uut.buckets).To(HaveLen(1))
+	Expect(uut.buckets).To(HaveKey(Key{Source: "d"}))
+	Expect(uut.lru.Len()).To(Equal(1))
+}
+
+func TestDecisionHeaders(t *testing.T) {
+	RegisterTestingT(t)
+
+	d := Decision{Allowed: true, Limit: Limit{Rate: 1, Burst: 5}, Remaining: 3, Reset: 1500 * time.Millisecond}
+	Expect(d.Headers()).To(Equal(map[string]string{
+		"RateLimit-Limit":     "5",
+		"RateLimit-Remaining": "3",
+		"RateLimit-Reset":     "2",
+	}))
+	d = Decision{Limit: Limit{Rate: 1, Burst: 5}, Reset: 5 * time.Second, RetryAfter: 200 * time.Millisecond}
+	Expect(d.Headers()).To(HaveKeyWithValue("Retry-After", "1"))
+}
+
+func TestStricter(t *testing.T) {
+	RegisterTestingT(t)
+
+	allow3 := Decision{Allowed: true, Remaining: 3}
+	allow1 := Decision{Allowed: true, Remaining: 1}
+	deny1s := Decision{RetryAfter: time.Second}
+	deny5s := Decision{RetryAfter: 5 * time.Second}
+	Expect(allow3.Stricter(allow1)).To(Equal(allow1))
+	Expect(allow1.Stricter(allow3)).To(Equal(allow1))
+	Expect(allow1.Stricter(deny1s)).To(Equal(deny1s))
+	Expect(deny1s.Stricter(allow1)).To(Equal(deny1s))
+	Expect(deny1s.Stricter(deny5s)).To(Equal(deny5s))
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package statscache aggregates per-connection statistics in memory and hands them off periodically for reporting to
+// Felix.
+package statscache
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultFlushInterval is how often aggregated statistics are flushed.
+	DefaultFlushInterval = 5 * time.Second
+	// DefaultMaxTuples bounds the connections statistics are aggregated for in an interval. Statistics for further
+	// connections are dropped until the next flush.
+	DefaultMaxTuples = 10000
+
+	// inputBufferSize bounds the number of DPStats queued for aggregation. Stats added while the queue is full are
+	// dropped rather than blocking the checker.
+	inputBufferSize = 1000
+)
+
+// Tuple identifies a connection.
+type Tuple struct {
+	SrcIp    string
+	DstIp    string
+	SrcPort  int32
+	DstPort  int32
+	Protocol string
+}
+
+// WAFHit identifies a WAF rule that matched a request.
+type WAFHit struct {
+	RuleID   int
+	Severity string
+	Message  string
+	Blocked  bool
+}
+
+// PolicyHit identifies a Calico policy that decided a request.
+type PolicyHit struct {
+	Tier string
+	Name string
+	// Enforced is whether the policy's verdict applied, rather than being logged in dry-run.
+	Enforced bool
+}
+
+// RequestOutcome distinguishes the HTTP requests decided on a connection by their direction and verdict.
+type RequestOutcome struct {
+	// Outbound is whether the request was checked against egress policy.
+	Outbound bool
+	// Denied is whether the request was denied, rather than allowed or only denied in dry-run.
+	Denied bool
+}
+
+// Values are the statistics accumulated for a connection.
+type Values struct {
+	// WAFHits counts the requests each WAF rule matched.
+	WAFHits map[WAFHit]int64
+	// PolicyHits counts the requests each policy decided.
+	PolicyHits map[PolicyHit]int64
+	// HTTPRequests counts the requests decided, by outcome.
+	HTTPRequests map[RequestOutcome]int64
+}
+
+// add accumulates other into v.
+func (v *Values) add(other Values) {
+	for h, n := range other.WAFHits {
+		if v.WAFHits == nil {
+			v.WAFHits = map[WAFHit]int64{}
+		}
+		v.WAFHits[h] += n
+	}
+	for h, n := range other.PolicyHits {
+		if v.PolicyHits == nil {
+			v.PolicyHits = map[PolicyHit]int64{}
+		}
+		v.PolicyHits[h] += n
+	}
+	for o, n := range other.HTTPRequests {
+		if v.HTTPRequests == nil {
+			v.HTTPRequests = map[RequestOutcome]int64{}
+		}
+		v.HTTPRequests[o] += n
+	}
+}
+
+// DPStats are statistics for a single connection.
+type DPStats struct {
+	Tuple  Tuple
+	Values Values
+}
+
+// StatsCache aggregates DPStats by connection and passes the totals to a flush callback once per flush interval.
+type StatsCache struct {
+	// MaxTuples bounds the connections aggregated in an interval. Set it before calling Start.
+	MaxTuples int
+
+	in            chan DPStats
+	flushInterval time.Duration
+	flush         func(map[Tuple]Values)
+}
+
+// New creates a StatsCache that calls flush with the statistics aggregated over each interval. The callback is not
+// called for intervals with no statistics.
+func New(flushInterval time.Duration, flush func(map[Tuple]Values)) *StatsCache {
+	return &StatsCache{
+		MaxTuples:     DefaultMaxTuples,
+		in:            make(chan DPStats, inputBufferSize),
+		flushInterval: flushInterval,
+		flush:         flush,
+	}
+}
+
+// Add queues statistics for aggregation. It never blocks.
+func (s *StatsCache) Add(d DPStats) {
+	select {
+	case s.in <- d:
+	default:
+		log.WithField("tuple", d.Tuple).Debug("Stats cache input queue full, dropping stats.")
+	}
+}
+
+// Start aggregates statistics until ctx is cancelled.
+func (s *StatsCache) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	stats := map[Tuple]Values{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-s.in:
+			v, ok := stats[d.Tuple]
+			if !ok && len(stats) >= s.MaxTuples {
+				log.WithField("tuple", d.Tuple).Debug("Stats cache full, dropping stats for new connection.")
+				continue
+			}
+			v.add(d.Values)
+			stats[d.Tuple] = v
+		case <-ticker.C:
+			if len(stats) > 0 {
+				s.flush(stats)
+				stats = map[Tuple]Values{}
+			}
+		}
+	}
+}
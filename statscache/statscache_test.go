@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAggregation(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	flushed := make(chan map[Tuple]Values, 10)
+	uut := New(50*time.Millisecond, func(m map[Tuple]Values) { flushed <- m })
+
+	t1 := Tuple{SrcIp: "10.0.0.1", DstIp: "10.0.0.2", SrcPort: 40000, DstPort: 80, Protocol: "TCP"}
+	t2 := Tuple{SrcIp: "10.0.0.3", DstIp: "10.0.0.2", SrcPort: 40001, DstPort: 80, Protocol: "TCP"}
+	sqli := WAFHit{RuleID: 942100, Severity: "critical", Message: "SQL Injection Attack", Blocked: true}
+	xss := WAFHit{RuleID: 941100, Severity: "critical", Message: "XSS Attack"}
+
+	// Queue the stats before starting so they all land in the first interval.
+	uut.Add(DPStats{Tuple: t1, Values: Values{WAFHits: map[WAFHit]int64{sqli: 1}}})
+	uut.Add(DPStats{Tuple: t1, Values: Values{WAFHits: map[WAFHit]int64{sqli: 1, xss: 1}}})
+	uut.Add(DPStats{Tuple: t2, Values: Values{WAFHits: map[WAFHit]int64{xss: 1}}})
+	go uut.Start(ctx)
+
+	var m map[Tuple]Values
+	Eventually(flushed).Should(Receive(&m))
+	Expect(m).To(Equal(map[Tuple]Values{
+		t1: {WAFHits: map[WAFHit]int64{sqli: 2, xss: 1}},
+		t2: {WAFHits: map[WAFHit]int64{xss: 1}},
+	}))
+
+	// Policy hits are aggregated alongside WAF hits.
+	allow := PolicyHit{Tier: "default", Name: "allow-frontend", Enforced: true}
+	uut.Add(DPStats{Tuple: t1, Values: Values{PolicyHits: map[PolicyHit]int64{allow: 1}}})
+	uut.Add(DPStats{Tuple: t1, Values: Values{
+		WAFHits:    map[WAFHit]int64{xss: 1},
+		PolicyHits: map[PolicyHit]int64{allow: 1},
+	}})
+	Eventually(flushed).Should(Receive(&m))
+	Expect(m).To(Equal(map[Tuple]Values{
+		t1: {WAFHits: map[WAFHit]int64{xss: 1}, PolicyHits: map[PolicyHit]int64{allow: 2}},
+	}))
+
+	// So are request counts.
+	allowed, denied := RequestOutcome{}, RequestOutcome{Denied: true}
+	uut.Add(DPStats{Tuple: t1, Values: Values{HTTPRequests: map[RequestOutcome]int64{allowed: 1}}})
+	uut.Add(DPStats{Tuple: t1, Values: Values{HTTPRequests: map[RequestOutcome]int64{allowed: 1}}})
+	uut.Add(DPStats{Tuple: t1, Values: Values{HTTPRequests: map[RequestOutcome]int64{denied: 1}}})
+	Eventually(flushed).Should(Receive(&m))
+	Expect(m).To(Equal(map[Tuple]Values{
+		t1: {HTTPRequests: map[RequestOutcome]int64{allowed: 2, denied: 1}},
+	}))
+
+	// Nothing is flushed for empty intervals.
+	Consistently(flushed, "200ms").ShouldNot(Receive())
+}
+
+func TestMaxTuples(t *testing.T) {
+	RegisterTestingT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	flushed := make(chan map[Tuple]Values, 10)
+	uut := New(50*time.Millisecond, func(m map[Tuple]Values) { flushed <- m })
+	uut.MaxTuples = 2
+
+	allowed := Values{HTTPRequests: map[RequestOutcome]int64{{}: 1}}
+	t1 := Tuple{SrcIp: "10.0.0.1", DstIp: "10.0.0.2", SrcPort: 40000, DstPort: 80, Protocol: "TCP"}
+	t2 := Tuple{SrcIp: "10.0.0.1", DstIp: "10.0.0.2", SrcPort: 40001, DstPort: 80, Protocol: "TCP"}
+	t3 := Tuple{SrcIp: "10.0.0.1", DstIp: "10.0.0.2", SrcPort: 40002, DstPort: 80, Protocol: "TCP"}
+	uut.Add(DPStats{Tuple: t1, Values: allowed})
+	uut.Add(DPStats{Tuple: t2, Values: allowed})
+	uut.Add(DPStats{Tuple: t3, Values: allowed})
+	// Connections already aggregated still count.
+	uut.Add(DPStats{Tuple: t1, Values: allowed})
+	go uut.Start(ctx)
+
+	var m map[Tuple]Values
+	Eventually(flushed).Should(Receive(&m))
+	Expect(m).To(Equal(map[Tuple]Values{
+		t1: {HTTPRequests: map[RequestOutcome]int64{{}: 2}},
+		t2: {HTTPRequests: map[RequestOutcome]int64{{}: 1}},
+	}))
+
+	// The bound applies afresh after each flush.
+	uut.Add(DPStats{Tuple: t3, Values: allowed})
+	Eventually(flushed).Should(Receive(&m))
+	Expect(m).To(HaveKey(t3))
+}
+
+func TestAddNeverBlocks(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := New(time.Hour, func(map[Tuple]Values) {})
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*inputBufferSize; i++ {
+			uut.Add(DPStats{})
+		}
+		close(done)
+	}()
+	Eventually(done).Should(BeClosed())
+}
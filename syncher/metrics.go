@@ -0,0 +1,34 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	inSyncGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "dikastes_policy_in_sync",
+		Help: "1 while the policy store is in sync with the Policy Sync API, otherwise 0.",
+	})
+	syncFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "dikastes_sync_reconnects_total",
+		Help: "Times the Policy Sync stream failed or ended, and was reconnected.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(inSyncGauge, syncFailures)
+}
@@ -1,4 +1,4 @@
-// Copyright (c) 2018-2019 Tigera, Inc. All rights reserved.
+// Copyright (c) 2018-2026 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -17,22 +17,67 @@ package syncher
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/projectcalico/app-policy/health"
+	"github.com/projectcalico/app-policy/policylint"
 	"github.com/projectcalico/app-policy/policystore"
 	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/statscache"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-const PolicySyncRetryTime = 500 * time.Millisecond
+const (
+	// PolicySyncRetryTime is the initial delay before reconnecting to the Policy Sync API in retry mode.
+	PolicySyncRetryTime = 500 * time.Millisecond
+	// PolicySyncMaxRetryTime bounds the exponential backoff between reconnection attempts.
+	PolicySyncMaxRetryTime = 30 * time.Second
+)
+
+// FailureMode determines what the syncClient does when synchronization with the Policy Sync API fails.
+type FailureMode string
+
+const (
+	// FailureModeRetry reconnects with a bounded exponential backoff. The checker keeps serving the last in-sync
+	// PolicyStore until its unsynced grace period has passed, and its fallback verdict after that or if there has never
+	// been one.
+	FailureModeRetry FailureMode = "retry"
+	// FailureModeCrash exits the process on the first sync error, relying on the kubelet to restart the container.
+	FailureModeCrash FailureMode = "crash"
+)
+
+// ParseFailureMode converts a command line value into a FailureMode.
+func ParseFailureMode(s string) (FailureMode, error) {
+	switch m := FailureMode(strings.ToLower(s)); m {
+	case FailureModeRetry, FailureModeCrash:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown sync failure mode %q", s)
+}
 
 type syncClient struct {
 	target   string
 	dialOpts []grpc.DialOption
-	inSync   bool
+	// mu protects inSync and syncLostAt, which health checks read.
+	mu     sync.Mutex
+	inSync bool
+	// syncLostAt is when the client last stopped being in sync, or zero if it never has.
+	syncLostAt time.Time
+	// readinessGrace is how long the client still reports itself ready after it stops being in sync.
+	readinessGrace time.Duration
+	failureMode    FailureMode
+	// fatal is called to exit the process in crash-only mode.
+	fatal func(args ...interface{})
+	// stats holds flushed statistics waiting to be reported on the current Policy Sync connection.
+	stats chan map[statscache.Tuple]statscache.Values
+	// lint, if set, records the clauses of synced policies and profiles that can't be enforced.
+	lint *policylint.Report
 }
 
 type SyncClient interface {
@@ -42,16 +87,55 @@ type SyncClient interface {
 	// PolicyStore is created.
 	Sync(ctx context.Context, stores chan<- *policystore.PolicyStore)
 
+	// OnStatsCacheFlush queues statistics to be reported to the Policy Sync API server. Statistics flushed while
+	// disconnected, or while a previous flush is still being sent, are dropped.
+	OnStatsCacheFlush(v map[statscache.Tuple]statscache.Values)
+
 	// SyncClient knows how to report its readiness.
 	health.ReadinessReporter
 }
 
+// ClientOption configures optional behaviour of the syncClient.
+type ClientOption func(*syncClient)
+
+// WithFailureMode sets how the syncClient handles sync errors. The default is FailureModeRetry.
+func WithFailureMode(m FailureMode) ClientOption {
+	return func(s *syncClient) {
+		s.failureMode = m
+	}
+}
+
+// WithReadinessGracePeriod keeps the syncClient reporting itself ready for d after it stops being in sync, so that a
+// brief loss of the Policy Sync connection, such as Felix restarting, doesn't take Dikastes out of service. By default
+// it is unready as soon as it is out of sync.
+func WithReadinessGracePeriod(d time.Duration) ClientOption {
+	return func(s *syncClient) {
+		s.readinessGrace = d
+	}
+}
+
+// WithLintReport records the clauses of synced policies and profiles that Dikastes can't enforce in r.
+func WithLintReport(r *policylint.Report) ClientOption {
+	return func(s *syncClient) {
+		s.lint = r
+	}
+}
+
 // NewClient creates a new syncClient.
-func NewClient(target string, opts []grpc.DialOption) SyncClient {
-	return &syncClient{target: target, dialOpts: opts}
+func NewClient(target string, opts []grpc.DialOption, options ...ClientOption) SyncClient {
+	s := &syncClient{target: target, dialOpts: opts, failureMode: FailureModeRetry, fatal: log.Fatal,
+		stats: make(chan map[statscache.Tuple]statscache.Values, 1),
+	}
+	for _, o := range options {
+		o(s)
+	}
+	return s
 }
 
 func (s *syncClient) Sync(cxt context.Context, stores chan<- *policystore.PolicyStore) {
+	log.WithField("failureMode", s.failureMode).Info("Starting Policy Sync client")
+	retry := PolicySyncRetryTime
+	failures := 0
 	for {
 		select {
 		case <-cxt.Done():
@@ -59,37 +143,75 @@ func (s *syncClient) Sync(cxt context.Context, stores chan<- *policystore.Policy
 		default:
 			store := policystore.NewPolicyStore()
 			inSync := make(chan struct{})
-			done := make(chan struct{})
+			done := make(chan error, 1)
 			go s.syncStore(cxt, store, inSync, done)
 
 			// Block until we receive InSync message, or cancelled.
+			var err error
+			ended := false
 			select {
 			case <-inSync:
-				s.inSync = true
+				log.Info("Policy store in sync")
+				s.setInSync(true)
+				retry = PolicySyncRetryTime
+				failures = 0
 				stores <- store
 			// Also catch the case where syncStore ends before it gets an InSync message.
-			case <-done:
-				// pass
+			case err = <-done:
+				ended = true
 			case <-cxt.Done():
 				return
 			}
 
 			// Block until syncStore() ends (e.g. disconnected), or cancelled.
+			if !ended {
+				select {
+				case err = <-done:
+					// pass
+				case <-cxt.Done():
+					return
+				}
+			}
+			if cxt.Err() != nil {
+				return
+			}
+			s.setInSync(false)
+			// The store stops getting updates, but may still be enforced, so note how long it has been stale for.
+			store.Write(func(ps *policystore.PolicyStore) { ps.SyncLostAt = time.Now() })
+
+			if s.failureMode == FailureModeCrash {
+				log.WithError(err).Error("Policy Sync failed and sync failure mode is crash; exiting")
+				s.fatal("Policy Sync failed: ", err)
+				return
+			}
+			failures++
+			syncFailures.Inc()
+			log.WithFields(log.Fields{
+				"error":    err,
+				"failures": failures,
+				"retryIn":  retry,
+			}).Warn("Policy Sync failed; will retry")
 			select {
-			case <-done:
+			case <-time.After(retry):
 				// pass
 			case <-cxt.Done():
 				return
 			}
-
-			time.Sleep(PolicySyncRetryTime)
+			retry *= 2
+			if retry > PolicySyncMaxRetryTime {
+				retry = PolicySyncMaxRetryTime
+			}
 		}
 	}
 }
 
-func (s *syncClient) syncStore(cxt context.Context, store *policystore.PolicyStore, inSync chan<- struct{}, done chan<- struct{}) {
-	defer close(done)
-	conn, err := grpc.Dial(s.target, s.dialOpts...)
+// syncStore streams updates from the Policy Sync API into the store until the stream ends, then sends the reason it
+// ended on done.
+func (s *syncClient) syncStore(cxt context.Context, store *policystore.PolicyStore, inSync chan<- struct{}, done chan<- error) {
+	var err error
+	defer func() { done <- err }()
+	opts := append([]grpc.DialOption{grpc.WithDefaultCallOptions(grpc.ForceCodec(unknownFieldsCodec{}))}, s.dialOpts...)
+	conn, err := grpc.Dial(s.target, opts...)
 	if err != nil {
 		log.Warnf("fail to dial Policy Sync server: %v", err)
 		return
@@ -100,18 +222,63 @@ func (s *syncClient) syncStore(cxt context.Context, store *policystore.PolicySto
 	stream, err := client.Sync(cxt, &proto.SyncRequest{})
 	if err != nil {
 		log.Warnf("failed to synchronize with Policy Sync server: %v", err)
-		s.inSync = false
 		return
 	}
+	if s.lint != nil {
+		// The store is rebuilt from scratch on each connection, so the report is too.
+		s.lint.Reset()
+	}
+	statsCxt, stopStats := context.WithCancel(cxt)
+	defer stopStats()
+	go s.sendStats(statsCxt, client)
 	log.Info("Starting synchronization with Policy Sync server")
 	for {
-		update, err := stream.Recv()
+		var update *proto.ToDataplane
+		update, err = stream.Recv()
 		if err != nil {
 			log.Warnf("connection to Policy Sync server broken: %v", err)
 			return
 		}
 		log.WithFields(log.Fields{"proto": update}).Debug("Received sync API Update")
-		store.Write(func(ps *policystore.PolicyStore) { processUpdate(ps, inSync, update) })
+		if err = s.applyUpdate(store, inSync, update); err != nil {
+			log.WithError(err).Error("Failed to process sync API Update")
+			return
+		}
+	}
+}
+
+// applyUpdate writes the update to the store. In retry mode a panic while processing the update is converted into
+// an error so that the client can resync; in crash mode it is allowed to take down the process.
+func (s *syncClient) applyUpdate(store *policystore.PolicyStore, inSync chan<- struct{}, update *proto.ToDataplane) (err error) {
+	if s.failureMode != FailureModeCrash {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic processing update %v: %v", update.String(), r)
+			}
+		}()
+	}
+	store.Write(func(ps *policystore.PolicyStore) { processUpdate(ps, inSync, update) })
+	if s.lint != nil {
+		lintUpdate(s.lint, update)
+	}
+	return nil
+}
+
+// lintUpdate records the unenforceable clauses of a policy or profile update in the report.
+func lintUpdate(r *policylint.Report, update *proto.ToDataplane) {
+	switch payload := update.Payload.(type) {
+	case *proto.ToDataplane_ActiveProfileUpdate:
+		p := payload.ActiveProfileUpdate.GetProfile()
+		name := policylint.ProfileName(*payload.ActiveProfileUpdate.Id)
+		r.Set(name, policylint.Lint(p.GetInboundRules(), p.GetOutboundRules()))
+	case *proto.ToDataplane_ActiveProfileRemove:
+		r.Set(policylint.ProfileName(*payload.ActiveProfileRemove.Id), nil)
+	case *proto.ToDataplane_ActivePolicyUpdate:
+		p := payload.ActivePolicyUpdate.GetPolicy()
+		name := policylint.PolicyName(*payload.ActivePolicyUpdate.Id)
+		r.Set(name, policylint.Lint(p.GetInboundRules(), p.GetOutboundRules()))
+	case *proto.ToDataplane_ActivePolicyRemove:
+		r.Set(policylint.PolicyName(*payload.ActivePolicyRemove.Id), nil)
 	}
 }
 
@@ -147,8 +314,14 @@ func processUpdate(store *policystore.PolicyStore, inSync chan<- struct{}, updat
 		processNamespaceUpdate(store, payload.NamespaceUpdate)
 	case *proto.ToDataplane_NamespaceRemove:
 		processNamespaceRemove(store, payload.NamespaceRemove)
+	case *proto.ToDataplane_ServiceUpdate:
+		processServiceUpdate(store, payload.ServiceUpdate)
+	case *proto.ToDataplane_ServiceRemove:
+		processServiceRemove(store, payload.ServiceRemove)
 	default:
-		panic(fmt.Sprintf("unknown payload %v", update.String()))
+		// Newer versions of Felix may send updates that this version doesn't know, and Felix may send some that it
+		// doesn't use.
+		recordUnknownUpdate(update)
 	}
 }
 
@@ -167,6 +340,8 @@ func processIPSetUpdate(store *policystore.PolicyStore, update *proto.IPSetUpdat
 		s.AddString(addr)
 	}
 	store.IPSetByID[update.Id] = s
+	store.SetGeneration("ipset "+update.Id, "")
+	store.ToggleGeneration("ipset "+update.Id, update.Members...)
 }
 
 func processIPSetDeltaUpdate(store *policystore.PolicyStore, update *proto.IPSetDeltaUpdate) {
@@ -184,6 +359,8 @@ func processIPSetDeltaUpdate(store *policystore.PolicyStore, update *proto.IPSet
 	for _, addr := range update.RemovedMembers {
 		s.RemoveString(addr)
 	}
+	store.ToggleGeneration("ipset "+update.Id, update.AddedMembers...)
+	store.ToggleGeneration("ipset "+update.Id, update.RemovedMembers...)
 }
 
 func processIPSetRemove(store *policystore.PolicyStore, update *proto.IPSetRemove) {
@@ -191,6 +368,7 @@ func processIPSetRemove(store *policystore.PolicyStore, update *proto.IPSetRemov
 		"id": update.Id,
 	}).Debug("Processing IPSetRemove")
 	delete(store.IPSetByID, update.Id)
+	store.SetGeneration("ipset "+update.Id, "")
 }
 
 func processActiveProfileUpdate(store *policystore.PolicyStore, update *proto.ActiveProfileUpdate) {
@@ -200,7 +378,8 @@ func processActiveProfileUpdate(store *policystore.PolicyStore, update *proto.Ac
 	if update.Id == nil {
 		panic("got ActiveProfileUpdate with nil ProfileID")
 	}
-	store.ProfileByID[*update.Id] = update.Profile
+	store.UpdateProfile(*update.Id, update.Profile)
+	store.SetGeneration("profile "+update.Id.String(), update.String())
 }
 
 func processActiveProfileRemove(store *policystore.PolicyStore, update *proto.ActiveProfileRemove) {
@@ -210,7 +389,8 @@ func processActiveProfileRemove(store *policystore.PolicyStore, update *proto.Ac
 	if update.Id == nil {
 		panic("got ActiveProfileRemove with nil ProfileID")
 	}
-	delete(store.ProfileByID, *update.Id)
+	store.RemoveProfile(*update.Id)
+	store.SetGeneration("profile "+update.Id.String(), "")
 }
 
 func processActivePolicyUpdate(store *policystore.PolicyStore, update *proto.ActivePolicyUpdate) {
@@ -220,7 +400,8 @@ func processActivePolicyUpdate(store *policystore.PolicyStore, update *proto.Act
 	if update.Id == nil {
 		panic("got ActivePolicyUpdate with nil PolicyID")
 	}
-	store.PolicyByID[*update.Id] = update.Policy
+	store.UpdatePolicy(*update.Id, update.Policy)
+	store.SetGeneration("policy "+update.Id.String(), update.String())
 }
 
 func processActivePolicyRemove(store *policystore.PolicyStore, update *proto.ActivePolicyRemove) {
@@ -230,7 +411,8 @@ func processActivePolicyRemove(store *policystore.PolicyStore, update *proto.Act
 	if update.Id == nil {
 		panic("got ActivePolicyRemove with nil PolicyID")
 	}
-	delete(store.PolicyByID, *update.Id)
+	store.RemovePolicy(*update.Id)
+	store.SetGeneration("policy "+update.Id.String(), "")
 }
 
 func processWorkloadEndpointUpdate(store *policystore.PolicyStore, update *proto.WorkloadEndpointUpdate) {
@@ -241,6 +423,8 @@ func processWorkloadEndpointUpdate(store *policystore.PolicyStore, update *proto
 		"endpointID":     update.GetId().GetEndpointId(),
 	}).Info("Processing WorkloadEndpointUpdate")
 	store.Endpoint = update.Endpoint
+	store.EndpointByID[endpointID(update.GetId())] = update.Endpoint
+	store.SetGeneration("endpoint "+update.GetId().String(), update.String())
 }
 
 func processWorkloadEndpointRemove(store *policystore.PolicyStore, update *proto.WorkloadEndpointRemove) {
@@ -251,6 +435,16 @@ func processWorkloadEndpointRemove(store *policystore.PolicyStore, update *proto
 		"endpointID":     update.GetId().GetEndpointId(),
 	}).Warning("Processing WorkloadEndpointRemove")
 	store.Endpoint = nil
+	delete(store.EndpointByID, endpointID(update.GetId()))
+	store.SetGeneration("endpoint "+update.GetId().String(), "")
+}
+
+// endpointID dereferences an endpoint's ID, if it has one.
+func endpointID(id *proto.WorkloadEndpointID) proto.WorkloadEndpointID {
+	if id == nil {
+		return proto.WorkloadEndpointID{}
+	}
+	return *id
 }
 
 func processServiceAccountUpdate(store *policystore.PolicyStore, update *proto.ServiceAccountUpdate) {
@@ -259,6 +453,7 @@ func processServiceAccountUpdate(store *policystore.PolicyStore, update *proto.S
 		panic("got ServiceAccountUpdate with nil ServiceAccountID")
 	}
 	store.ServiceAccountByID[*update.Id] = update
+	store.SetGeneration("serviceaccount "+update.Id.String(), update.String())
 }
 
 func processServiceAccountRemove(store *policystore.PolicyStore, update *proto.ServiceAccountRemove) {
@@ -267,6 +462,7 @@ func processServiceAccountRemove(store *policystore.PolicyStore, update *proto.S
 		panic("got ServiceAccountRemove with nil ServiceAccountID")
 	}
 	delete(store.ServiceAccountByID, *update.Id)
+	store.SetGeneration("serviceaccount "+update.Id.String(), "")
 }
 
 func processNamespaceUpdate(store *policystore.PolicyStore, update *proto.NamespaceUpdate) {
@@ -275,6 +471,7 @@ func processNamespaceUpdate(store *policystore.PolicyStore, update *proto.Namesp
 		panic("got NamespaceUpdate with nil NamespaceID")
 	}
 	store.NamespaceByID[*update.Id] = update
+	store.SetGeneration("namespace "+update.Id.String(), update.String())
 }
 
 func processNamespaceRemove(store *policystore.PolicyStore, update *proto.NamespaceRemove) {
@@ -283,9 +480,120 @@ func processNamespaceRemove(store *policystore.PolicyStore, update *proto.Namesp
 		panic("got NamespaceRemove with nil NamespaceID")
 	}
 	delete(store.NamespaceByID, *update.Id)
+	store.SetGeneration("namespace "+update.Id.String(), "")
+}
+
+func processServiceUpdate(store *policystore.PolicyStore, update *proto.ServiceUpdate) {
+	id := policystore.ServiceID{Namespace: update.Namespace, Name: update.Name}
+	log.WithField("id", id).Debug("Processing ServiceUpdate")
+	store.UpdateService(update)
+	store.SetGeneration("service "+id.String(), update.String())
+}
+
+func processServiceRemove(store *policystore.PolicyStore, update *proto.ServiceRemove) {
+	id := policystore.ServiceID{Namespace: update.Namespace, Name: update.Name}
+	log.WithField("id", id).Debug("Processing ServiceRemove")
+	store.RemoveService(id)
+	store.SetGeneration("service "+id.String(), "")
+}
+
+// setInSync records whether the client is in sync.
+func (s *syncClient) setInSync(inSync bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inSync && !inSync {
+		s.syncLostAt = time.Now()
+	}
+	s.inSync = inSync
+	if inSync {
+		inSyncGauge.Set(1)
+	} else {
+		inSyncGauge.Set(0)
+	}
 }
 
-// Readiness returns whether the SyncClient is InSync.
+// Readiness returns whether the SyncClient is InSync, or stopped being in sync less than the readiness grace period
+// ago.
 func (s *syncClient) Readiness() bool {
-	return s.inSync
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inSync || (!s.syncLostAt.IsZero() && time.Since(s.syncLostAt) < s.readinessGrace)
+}
+
+func (s *syncClient) OnStatsCacheFlush(v map[statscache.Tuple]statscache.Values) {
+	select {
+	case s.stats <- v:
+	default:
+		log.Debug("Previous statistics not yet reported, dropping flushed statistics.")
+	}
+}
+
+// sendStats reports flushed statistics over client until cxt is cancelled. Felix versions that predate the Report
+// RPC return Unimplemented, in which case reporting is abandoned for the rest of the connection.
+func (s *syncClient) sendStats(cxt context.Context, client proto.PolicySyncClient) {
+	for {
+		select {
+		case <-cxt.Done():
+			return
+		case v := <-s.stats:
+			for t, vals := range v {
+				d := dataplaneStats(t, vals)
+				r, err := client.Report(cxt, d)
+				if status.Code(err) == codes.Unimplemented {
+					log.Warn("Policy Sync server does not support statistics reporting; disabling it until reconnect.")
+					return
+				} else if err != nil {
+					log.WithError(err).Warn("Failed to report statistics.")
+					break
+				} else if !r.GetSuccessful() {
+					log.WithField("stats", d).Debug("Policy Sync server did not accept statistics.")
+				}
+			}
+		}
+	}
+}
+
+// dataplaneStats converts aggregated statistics for a connection into a DataplaneStats message.
+func dataplaneStats(t statscache.Tuple, v statscache.Values) *proto.DataplaneStats {
+	d := &proto.DataplaneStats{
+		SrcIp:    t.SrcIp,
+		DstIp:    t.DstIp,
+		SrcPort:  t.SrcPort,
+		DstPort:  t.DstPort,
+		Protocol: &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: t.Protocol}},
+	}
+	for h, n := range v.WAFHits {
+		d.WafRuleHits = append(d.WafRuleHits, &proto.WAFRuleHit{
+			RuleId:   int32(h.RuleID),
+			Severity: h.Severity,
+			Message:  h.Message,
+			Blocked:  h.Blocked,
+			Count:    n,
+		})
+	}
+	for h, n := range v.PolicyHits {
+		d.PolicyHits = append(d.PolicyHits, &proto.PolicyHit{
+			Tier:     h.Tier,
+			Name:     h.Name,
+			Enforced: h.Enforced,
+			Count:    n,
+		})
+	}
+	for o, n := range v.HTTPRequests {
+		s := &proto.Statistic{
+			Direction:  proto.Statistic_IN,
+			Relativity: proto.Statistic_DELTA,
+			Kind:       proto.Statistic_HTTP_REQUESTS,
+			Action:     proto.Statistic_ALLOWED,
+			Value:      n,
+		}
+		if o.Outbound {
+			s.Direction = proto.Statistic_OUT
+		}
+		if o.Denied {
+			s.Action = proto.Statistic_DENIED
+		}
+		d.Stats = append(d.Stats, s)
+	}
+	return d
 }
@@ -1,4 +1,4 @@
-// Copyright (c) 2018 Tigera, Inc. All rights reserved.
+// Copyright (c) 2018-2026 Tigera, Inc. All rights reserved.
 
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -24,13 +24,20 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
+	"github.com/projectcalico/app-policy/checker"
+	"github.com/projectcalico/app-policy/policylint"
 	"github.com/projectcalico/app-policy/policystore"
 	"github.com/projectcalico/app-policy/proto"
+	"github.com/projectcalico/app-policy/statscache"
 	"github.com/projectcalico/app-policy/uds"
 
 	envoyapi "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authz "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const addr1Ip = "3.4.6.8"
@@ -501,6 +508,34 @@ func TestActivePolicyRemoveDispatch(t *testing.T) {
 	Expect(func() { processUpdate(store, inSync, update) }).ToNot(Panic())
 }
 
+// Unenforceable clauses of synced policies are reported, and removed with the policy
+func TestApplyUpdateLintReport(t *testing.T) {
+	RegisterTestingT(t)
+
+	id := proto.PolicyID{Tier: "test_tier", Name: "test_id"}
+	store := policystore.NewPolicyStore()
+	inSync := make(chan struct{})
+	report := policylint.NewReport()
+	uut := NewClient("", nil, WithLintReport(report)).(*syncClient)
+
+	update := &proto.ToDataplane{Payload: &proto.ToDataplane_ActivePolicyUpdate{
+		ActivePolicyUpdate: &proto.ActivePolicyUpdate{
+			Id: &id,
+			Policy: &proto.Policy{InboundRules: []*proto.Rule{
+				{Action: "allow", Protocol: &proto.Protocol{NumberOrName: &proto.Protocol_Name{Name: "udp"}}},
+			}},
+		},
+	}}
+	Expect(uut.applyUpdate(store, inSync, update)).To(Succeed())
+	Expect(report.Clauses()).To(HaveKey("policy/test_tier/test_id"))
+
+	update = &proto.ToDataplane{Payload: &proto.ToDataplane_ActivePolicyRemove{
+		ActivePolicyRemove: &proto.ActivePolicyRemove{Id: &id},
+	}}
+	Expect(uut.applyUpdate(store, inSync, update)).To(Succeed())
+	Expect(report.Clauses()).To(BeEmpty())
+}
+
 // WorkloadEndpointUpdate sets the endpoint
 func TestWorkloadEndpointUpdate(t *testing.T) {
 	RegisterTestingT(t)
@@ -510,6 +545,26 @@ func TestWorkloadEndpointUpdate(t *testing.T) {
 	update := &proto.WorkloadEndpointUpdate{Endpoint: endpoint1}
 	processWorkloadEndpointUpdate(store, update)
 	Expect(store.Endpoint).To(BeIdenticalTo(endpoint1))
+	Expect(store.EndpointByID).To(HaveLen(1))
+}
+
+// A node-level Dikastes keeps every endpoint it is sent.
+func TestWorkloadEndpointUpdateMany(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	id1 := proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "default/pod1", EndpointId: "eth0"}
+	id2 := proto.WorkloadEndpointID{OrchestratorId: "k8s", WorkloadId: "default/pod2", EndpointId: "eth0"}
+	endpoint2 := &proto.WorkloadEndpoint{Name: "pod2"}
+	processWorkloadEndpointUpdate(store, &proto.WorkloadEndpointUpdate{Id: &id1, Endpoint: endpoint1})
+	processWorkloadEndpointUpdate(store, &proto.WorkloadEndpointUpdate{Id: &id2, Endpoint: endpoint2})
+	Expect(store.Endpoint).To(BeIdenticalTo(endpoint2))
+	Expect(store.EndpointByID[id1]).To(BeIdenticalTo(endpoint1))
+	Expect(store.EndpointByID[id2]).To(BeIdenticalTo(endpoint2))
+
+	processWorkloadEndpointRemove(store, &proto.WorkloadEndpointRemove{Id: &id1})
+	Expect(store.EndpointByID).To(HaveLen(1))
+	Expect(store.EndpointByID[id2]).To(BeIdenticalTo(endpoint2))
 }
 
 // processUpdate handles WorkloadEndpointUpdate
@@ -628,6 +683,116 @@ func TestNamespaceRemoveNilId(t *testing.T) {
 	Expect(func() { processNamespaceRemove(store, &proto.NamespaceRemove{}) }).To(Panic())
 }
 
+// Updates and fields that newer versions of Felix send are counted and ignored.
+func TestUnknownUpdates(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := policystore.NewPolicyStore()
+	inSync := make(chan struct{})
+	unknownPayload := unknownUpdates.WithLabelValues("unknown")
+	before := testutil.ToFloat64(unknownPayload)
+	Expect(func() { processUpdate(store, inSync, &proto.ToDataplane{SequenceNumber: 1}) }).ToNot(Panic())
+	Expect(testutil.ToFloat64(unknownPayload) - before).To(Equal(1.0))
+
+	// A ServiceRemove, with an unknown field 50 added, in an unknown payload field 99.
+	remove, err := (&proto.ServiceRemove{Name: "web", Namespace: "shop"}).Marshal()
+	Expect(err).ToNot(HaveOccurred())
+	remove = append(remove, 0x90, 0x03, 0x01)
+	msg := append([]byte{0xc2, 0x01, byte(len(remove))}, remove...)
+	msg = append(msg, 0x98, 0x06, 0x01)
+	fields := unknownFields.WithLabelValues("ServiceRemove")
+	before = testutil.ToFloat64(fields)
+	payloads := unknownFields.WithLabelValues("ToDataplane")
+	beforePayloads := testutil.ToFloat64(payloads)
+	var decoded proto.ToDataplane
+	Expect(unknownFieldsCodec{}.Unmarshal(msg, &decoded)).To(Succeed())
+	Expect(decoded.GetServiceRemove().GetName()).To(Equal("web"))
+	Expect(testutil.ToFloat64(fields) - before).To(Equal(1.0))
+	Expect(testutil.ToFloat64(payloads) - beforePayloads).To(Equal(1.0))
+
+	// Known fields, including those of oneofs and nested messages, aren't counted.
+	msg, err = (&proto.ToDataplane{SequenceNumber: 2, Payload: &proto.ToDataplane_ActivePolicyUpdate{
+		ActivePolicyUpdate: &proto.ActivePolicyUpdate{
+			Id:     &proto.PolicyID{Tier: "default", Name: "web"},
+			Policy: &proto.Policy{InboundRules: []*proto.Rule{{Action: "allow", SrcNet: []string{"10.0.0.0/8"}}}},
+		},
+	}}).Marshal()
+	Expect(err).ToNot(HaveOccurred())
+	var unknown []int32
+	proto.UnknownFields(&decoded, msg, func(_ string, field int32) { unknown = append(unknown, field) })
+	Expect(unknown).To(BeEmpty())
+}
+
+func TestServiceUpdateDispatch(t *testing.T) {
+	RegisterTestingT(t)
+	store := policystore.NewPolicyStore()
+	inSync := make(chan struct{})
+
+	service := &proto.ServiceUpdate{Name: "web", Namespace: "shop", ClusterIp: "10.96.0.10"}
+	update := &proto.ToDataplane{Payload: &proto.ToDataplane_ServiceUpdate{ServiceUpdate: service}}
+	Expect(func() { processUpdate(store, inSync, update) }).ToNot(Panic())
+	Expect(store.ServiceByID).To(Equal(map[policystore.ServiceID]*proto.ServiceUpdate{
+		{Namespace: "shop", Name: "web"}: service,
+	}))
+	Expect(store.Generation).ToNot(BeZero())
+
+	remove := &proto.ToDataplane{Payload: &proto.ToDataplane_ServiceRemove{
+		ServiceRemove: &proto.ServiceRemove{Name: "web", Namespace: "shop"}}}
+	Expect(func() { processUpdate(store, inSync, remove) }).ToNot(Panic())
+	Expect(store.ServiceByID).To(Equal(map[policystore.ServiceID]*proto.ServiceUpdate{}))
+	Expect(store.Generation).To(BeZero())
+}
+
+// Stores synced with the same contents, in any order, have the same generation.
+func TestGeneration(t *testing.T) {
+	RegisterTestingT(t)
+
+	policyID := proto.PolicyID{Tier: "default", Name: "policy1"}
+	nsID := proto.NamespaceID{Name: "default"}
+	updates := []*proto.ToDataplane{
+		{Payload: &proto.ToDataplane_IpsetUpdate{IpsetUpdate: &proto.IPSetUpdate{
+			Id: "set1", Type: proto.IPSetUpdate_IP, Members: []string{addr1Ip, addr2Ip},
+		}}},
+		{Payload: &proto.ToDataplane_ActivePolicyUpdate{ActivePolicyUpdate: &proto.ActivePolicyUpdate{
+			Id: &policyID, Policy: &proto.Policy{InboundRules: []*proto.Rule{{Action: "allow"}}},
+		}}},
+		{Payload: &proto.ToDataplane_NamespaceUpdate{NamespaceUpdate: &proto.NamespaceUpdate{
+			Id: &nsID, Labels: map[string]string{"a": "1", "b": "2", "c": "3"},
+		}}},
+	}
+	sync := func(order ...int) *policystore.PolicyStore {
+		store := policystore.NewPolicyStore()
+		for _, i := range order {
+			processUpdate(store, make(chan struct{}), updates[i])
+		}
+		return store
+	}
+	store := sync(0, 1, 2)
+	Expect(store.Generation).ToNot(BeZero())
+	Expect(sync(2, 1, 0).Generation).To(Equal(store.Generation))
+	Expect(sync(0, 1).Generation).ToNot(Equal(store.Generation))
+
+	// Deltas that arrive at the same members as a full update give the same generation.
+	delta := &proto.ToDataplane{Payload: &proto.ToDataplane_IpsetDeltaUpdate{IpsetDeltaUpdate: &proto.IPSetDeltaUpdate{
+		Id: "set1", AddedMembers: []string{addr3Ip}, RemovedMembers: []string{addr1Ip},
+	}}}
+	processUpdate(store, make(chan struct{}), delta)
+	updates[0].GetIpsetUpdate().Members = []string{addr3Ip, addr2Ip}
+	Expect(sync(1, 2, 0).Generation).To(Equal(store.Generation))
+
+	// Removing everything returns the store to the generation of an empty one.
+	processUpdate(store, make(chan struct{}), &proto.ToDataplane{Payload: &proto.ToDataplane_IpsetRemove{
+		IpsetRemove: &proto.IPSetRemove{Id: "set1"},
+	}})
+	processUpdate(store, make(chan struct{}), &proto.ToDataplane{Payload: &proto.ToDataplane_ActivePolicyRemove{
+		ActivePolicyRemove: &proto.ActivePolicyRemove{Id: &policyID},
+	}})
+	processUpdate(store, make(chan struct{}), &proto.ToDataplane{Payload: &proto.ToDataplane_NamespaceRemove{
+		NamespaceRemove: &proto.NamespaceRemove{Id: &nsID},
+	}})
+	Expect(store.Generation).To(BeZero())
+}
+
 // processUpdate handles InSync
 func TestInSyncDispatch(t *testing.T) {
 	RegisterTestingT(t)
@@ -646,7 +811,10 @@ func TestProcessUpdateUnknown(t *testing.T) {
 	store := policystore.NewPolicyStore()
 	inSync := make(chan struct{})
 	update := &proto.ToDataplane{Payload: &proto.ToDataplane_ConfigUpdate{}}
-	Expect(func() { processUpdate(store, inSync, update) }).To(Panic())
+	config := unknownUpdates.WithLabelValues("ConfigUpdate")
+	before := testutil.ToFloat64(config)
+	Expect(func() { processUpdate(store, inSync, update) }).ToNot(Panic())
+	Expect(testutil.ToFloat64(config) - before).To(Equal(1.0))
 }
 
 func TestSyncRestart(t *testing.T) {
@@ -669,12 +837,20 @@ func TestSyncRestart(t *testing.T) {
 	}
 
 	server.SendInSync()
+	var synced *policystore.PolicyStore
 	select {
 	case <-time.After(1 * time.Second):
 		t.Error("Failed to get sync'd PolicyStore")
-	case <-stores:
+	case synced = <-stores:
 		// pass
 	}
+	Expect(testutil.ToFloat64(inSyncGauge)).To(Equal(1.0))
+	reconnects := testutil.ToFloat64(syncFailures)
+	syncLostAt := func() (t time.Time) {
+		synced.Read(func(ps *policystore.PolicyStore) { t = ps.SyncLostAt })
+		return
+	}
+	Expect(syncLostAt().IsZero()).To(BeTrue())
 
 	server.Restart()
 	select {
@@ -683,6 +859,10 @@ func TestSyncRestart(t *testing.T) {
 	case <-time.After(100 * time.Millisecond):
 		// pass
 	}
+	Eventually(func() float64 { return testutil.ToFloat64(inSyncGauge) }).Should(Equal(0.0))
+	Eventually(func() float64 { return testutil.ToFloat64(syncFailures) }).Should(Equal(reconnects + 1))
+	// The store that was enforced records when it stopped being synced.
+	Expect(syncLostAt().IsZero()).To(BeFalse())
 
 	server.SendInSync()
 	select {
@@ -697,6 +877,66 @@ func TestSyncRestart(t *testing.T) {
 	}
 }
 
+// In retry mode, the checker enforces the last synced policy while reconnecting, until it has been stale for longer
+// than the unsynced grace period, and then returns its fallback verdict.
+func TestSyncRetryStaleFallback(t *testing.T) {
+	RegisterTestingT(t)
+
+	sCtx, sCancel := context.WithCancel(context.Background())
+	defer sCancel()
+
+	server := newTestSyncServer(sCtx)
+
+	uut := NewClient(server.GetTarget(), uds.GetDialOptions(), WithFailureMode(FailureModeRetry))
+	stores := make(chan *policystore.PolicyStore)
+
+	cCtx, cCancel := context.WithCancel(context.Background())
+	defer cCancel()
+	go uut.Sync(cCtx, stores)
+
+	checkServer := checker.NewServer(cCtx, make(chan *policystore.PolicyStore),
+		checker.WithFallbackVerdict(checker.INTERNAL), checker.WithUnsyncedGracePeriod(200*time.Millisecond))
+	check := func() int32 {
+		resp, err := checkServer.Check(cCtx, &authz.CheckRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		return resp.GetStatus().GetCode()
+	}
+
+	server.SendInSync()
+	select {
+	case <-time.After(1 * time.Second):
+		t.Fatal("Failed to get sync'd PolicyStore")
+	case checkServer.Store = <-stores:
+		// pass
+	}
+	Expect(check()).To(Equal(checker.PERMISSION_DENIED))
+
+	// The new connection never gets in sync, so the old store goes stale.
+	server.Restart()
+	Eventually(func() bool { return uut.Readiness() }).Should(BeFalse())
+	Expect(check()).To(Equal(checker.PERMISSION_DENIED))
+	Eventually(check, time.Second, 10*time.Millisecond).Should(Equal(checker.INTERNAL))
+}
+
+func TestReadinessGracePeriod(t *testing.T) {
+	RegisterTestingT(t)
+
+	uut := NewClient("unused", nil, WithReadinessGracePeriod(time.Hour)).(*syncClient)
+	Expect(uut.Readiness()).To(BeFalse())
+	uut.setInSync(true)
+	Expect(uut.Readiness()).To(BeTrue())
+	// Losing sync only makes the client unready once the grace period has passed.
+	uut.setInSync(false)
+	Expect(uut.Readiness()).To(BeTrue())
+	uut.syncLostAt = time.Now().Add(-2 * time.Hour)
+	Expect(uut.Readiness()).To(BeFalse())
+
+	uut = NewClient("unused", nil).(*syncClient)
+	uut.setInSync(true)
+	uut.setInSync(false)
+	Expect(uut.Readiness()).To(BeFalse())
+}
+
 func TestSyncCancelBeforeInSync(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -772,6 +1012,161 @@ func TestSyncServerCancelBeforeInSync(t *testing.T) {
 	Eventually(syncDone).Should(BeClosed())
 }
 
+func TestSyncCrashMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	sCtx, sCancel := context.WithCancel(context.Background())
+	defer sCancel()
+
+	server := newTestSyncServer(sCtx)
+
+	uut := NewClient(server.GetTarget(), uds.GetDialOptions(), WithFailureMode(FailureModeCrash)).(*syncClient)
+	fatal := make(chan struct{})
+	uut.fatal = func(args ...interface{}) { close(fatal) }
+	stores := make(chan *policystore.PolicyStore)
+
+	cCtx, cCancel := context.WithCancel(context.Background())
+	defer cCancel()
+	syncDone := make(chan struct{})
+	go func() {
+		uut.Sync(cCtx, stores)
+		close(syncDone)
+	}()
+
+	server.SendInSync()
+	Eventually(stores).Should(Receive())
+	Expect(uut.Readiness()).To(BeTrue())
+
+	// Losing the connection is fatal in crash mode, rather than triggering a resync.
+	server.Restart()
+	Eventually(fatal).Should(BeClosed())
+	Eventually(syncDone).Should(BeClosed())
+	Expect(uut.Readiness()).To(BeFalse())
+}
+
+// In retry mode a panic while processing an update causes a resync rather than a crash.
+func TestSyncRetryAfterBadUpdate(t *testing.T) {
+	RegisterTestingT(t)
+
+	sCtx, sCancel := context.WithCancel(context.Background())
+	defer sCancel()
+
+	server := newTestSyncServer(sCtx)
+
+	uut := NewClient(server.GetTarget(), uds.GetDialOptions())
+	stores := make(chan *policystore.PolicyStore)
+
+	cCtx, cCancel := context.WithCancel(context.Background())
+	defer cCancel()
+	go uut.Sync(cCtx, stores)
+
+	// A delta update for an IP set we don't know about panics in processUpdate.
+	server.updates <- proto.ToDataplane{Payload: &proto.ToDataplane_IpsetDeltaUpdate{
+		IpsetDeltaUpdate: &proto.IPSetDeltaUpdate{Id: "unknown"}}}
+
+	// The client reconnects and can still get in sync. The handler for the broken stream may swallow an InSync, so
+	// keep sending them until one arrives.
+	Eventually(func() bool {
+		server.SendInSync()
+		select {
+		case <-stores:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond).Should(BeTrue())
+}
+
+var statsTuple = statscache.Tuple{SrcIp: addr1Ip, DstIp: addr2Ip, SrcPort: 40000, DstPort: 8080, Protocol: "TCP"}
+var statsHit = statscache.WAFHit{RuleID: 942100, Severity: "critical", Message: "SQL Injection Attack", Blocked: true}
+var statsPolicyHit = statscache.PolicyHit{Tier: "default", Name: "default/default.allow-frontend", Enforced: true}
+
+func TestReportStats(t *testing.T) {
+	RegisterTestingT(t)
+
+	sCtx, sCancel := context.WithCancel(context.Background())
+	defer sCancel()
+	server := newTestSyncServer(sCtx)
+
+	uut := NewClient(server.GetTarget(), uds.GetDialOptions())
+	stores := make(chan *policystore.PolicyStore)
+	cCtx, cCancel := context.WithCancel(context.Background())
+	defer cCancel()
+	go uut.Sync(cCtx, stores)
+	server.SendInSync()
+	Eventually(stores).Should(Receive())
+
+	uut.OnStatsCacheFlush(map[statscache.Tuple]statscache.Values{
+		statsTuple: {
+			WAFHits:    map[statscache.WAFHit]int64{statsHit: 3},
+			PolicyHits: map[statscache.PolicyHit]int64{statsPolicyHit: 5},
+			HTTPRequests: map[statscache.RequestOutcome]int64{
+				{Denied: true}: 2,
+			},
+		},
+	})
+	var d *proto.DataplaneStats
+	Eventually(server.reports).Should(Receive(&d))
+	Expect(d.SrcIp).To(Equal(addr1Ip))
+	Expect(d.DstIp).To(Equal(addr2Ip))
+	Expect(d.SrcPort).To(Equal(int32(40000)))
+	Expect(d.DstPort).To(Equal(int32(8080)))
+	Expect(d.Protocol.GetName()).To(Equal("TCP"))
+	Expect(d.WafRuleHits).To(Equal([]*proto.WAFRuleHit{
+		{RuleId: 942100, Severity: "critical", Message: "SQL Injection Attack", Blocked: true, Count: 3},
+	}))
+	Expect(d.PolicyHits).To(Equal([]*proto.PolicyHit{
+		{Tier: "default", Name: "default/default.allow-frontend", Enforced: true, Count: 5},
+	}))
+	Expect(d.Stats).To(Equal([]*proto.Statistic{{
+		Direction:  proto.Statistic_IN,
+		Relativity: proto.Statistic_DELTA,
+		Kind:       proto.Statistic_HTTP_REQUESTS,
+		Action:     proto.Statistic_DENIED,
+		Value:      2,
+	}}))
+}
+
+func TestReportStatsUnimplemented(t *testing.T) {
+	RegisterTestingT(t)
+
+	sCtx, sCancel := context.WithCancel(context.Background())
+	defer sCancel()
+	server := newTestSyncServer(sCtx)
+	server.reportErr = status.Error(codes.Unimplemented, "unknown method Report")
+
+	uut := NewClient(server.GetTarget(), uds.GetDialOptions())
+	stores := make(chan *policystore.PolicyStore)
+	cCtx, cCancel := context.WithCancel(context.Background())
+	defer cCancel()
+	go uut.Sync(cCtx, stores)
+	server.SendInSync()
+	Eventually(stores).Should(Receive())
+
+	flush := map[statscache.Tuple]statscache.Values{
+		statsTuple: {WAFHits: map[statscache.WAFHit]int64{statsHit: 1}},
+	}
+	uut.OnStatsCacheFlush(flush)
+	// Once the server has rejected the RPC, later flushes sit in the queue and further ones are dropped rather than
+	// blocking.
+	Eventually(func() int { return len(uut.(*syncClient).stats) }).Should(Equal(0))
+	uut.OnStatsCacheFlush(flush)
+	uut.OnStatsCacheFlush(flush)
+	Consistently(func() int { return len(uut.(*syncClient).stats) }).Should(Equal(1))
+
+	// The sync stream is unaffected.
+	Expect(uut.Readiness()).To(BeTrue())
+}
+
+func TestParseFailureMode(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ParseFailureMode("retry")).To(Equal(FailureModeRetry))
+	Expect(ParseFailureMode("Crash")).To(Equal(FailureModeCrash))
+	_, err := ParseFailureMode("ignore")
+	Expect(err).To(HaveOccurred())
+}
+
 type testSyncServer struct {
 	context    context.Context
 	updates    chan proto.ToDataplane
@@ -780,12 +1175,15 @@ type testSyncServer struct {
 	listener   net.Listener
 	cLock      sync.Mutex
 	cancelFns  []func()
+	reports    chan *proto.DataplaneStats
+	// reportErr, if set, is returned from Report instead of recording the stats.
+	reportErr error
 }
 
 func newTestSyncServer(ctx context.Context) *testSyncServer {
 	socketDir := makeTmpListenerDir()
 	socketPath := path.Join(socketDir, ListenerSocket)
-	ss := &testSyncServer{context: ctx, updates: make(chan proto.ToDataplane), path: socketPath, gRPCServer: grpc.NewServer()}
+	ss := &testSyncServer{context: ctx, updates: make(chan proto.ToDataplane), reports: make(chan *proto.DataplaneStats, 10), path: socketPath, gRPCServer: grpc.NewServer()}
 	proto.RegisterPolicySyncServer(ss.gRPCServer, ss)
 	ss.listen()
 	return ss
@@ -810,6 +1208,14 @@ func (this *testSyncServer) Sync(_ *proto.SyncRequest, stream proto.PolicySync_S
 	}
 }
 
+func (this *testSyncServer) Report(_ context.Context, d *proto.DataplaneStats) (*proto.ReportResult, error) {
+	if this.reportErr != nil {
+		return nil, this.reportErr
+	}
+	this.reports <- d
+	return &proto.ReportResult{Successful: true}, nil
+}
+
 func (this *testSyncServer) SendInSync() {
 	this.updates <- proto.ToDataplane{Payload: &proto.ToDataplane_InSync{InSync: &proto.InSync{}}}
 }
@@ -833,17 +1239,11 @@ func (this *testSyncServer) GetTarget() string {
 }
 
 func (this *testSyncServer) listen() {
-	var err error
-	var wg sync.WaitGroup
-	wg.Add(1)
-
 	this.listener = openListener(this.path)
+	// Serve only returns once the server stops, so it can't be waited for.
 	go func() {
-		err = this.gRPCServer.Serve(this.listener)
-		wg.Done()
+		_ = this.gRPCServer.Serve(this.listener)
 	}()
-	wg.Wait()
-	Expect(err).ToNot(HaveOccurred())
 }
 
 const ListenerSocket = "policysync.sock"
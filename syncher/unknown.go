@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syncher
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/projectcalico/app-policy/proto"
+
+	gogoproto "github.com/gogo/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	unknownUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dikastes_sync_unknown_updates_total",
+		Help: "Updates from the Policy Sync API of kinds that Dikastes doesn't know or use, which are ignored.",
+	}, []string{"payload"})
+	unknownFields = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "dikastes_sync_unknown_fields_total",
+		Help: "Fields of messages from the Policy Sync API that Dikastes doesn't know, which are ignored.",
+	}, []string{"message"})
+)
+
+// unknownLogged holds the unknown payloads and fields that have been logged, so that each is only logged once.
+var unknownLogged sync.Map
+
+func init() {
+	prometheus.MustRegister(unknownUpdates, unknownFields)
+}
+
+// unknownFieldsCodec is gRPC's proto codec, but also records the fields of the messages it receives that aren't in this
+// version of the protocol, which Unmarshal skips.
+type unknownFieldsCodec struct{}
+
+func (unknownFieldsCodec) Marshal(v interface{}) ([]byte, error) {
+	return gogoproto.Marshal(v.(gogoproto.Message))
+}
+
+func (unknownFieldsCodec) Unmarshal(data []byte, v interface{}) error {
+	if err := gogoproto.Unmarshal(data, v.(gogoproto.Message)); err != nil {
+		return err
+	}
+	proto.UnknownFields(v, data, recordUnknownField)
+	return nil
+}
+
+func (unknownFieldsCodec) Name() string {
+	return "proto"
+}
+
+// recordUnknownField counts a field that a newer version of Felix sent and this version skipped.
+func recordUnknownField(message string, field int32) {
+	unknownFields.WithLabelValues(message).Inc()
+	if _, logged := unknownLogged.LoadOrStore(fmt.Sprintf("%s.%d", message, field), true); !logged {
+		log.WithFields(log.Fields{"message": message, "field": field}).Info(
+			"Ignoring unknown field from the Policy Sync API.")
+	}
+}
+
+// recordUnknownUpdate counts an update with a payload that processUpdate doesn't handle. Its payload is nil if it is
+// of a kind that this version of the protocol doesn't have, in which case its field was counted by
+// recordUnknownField.
+func recordUnknownUpdate(update *proto.ToDataplane) {
+	payload := "unknown"
+	if update.Payload != nil {
+		payload = strings.TrimPrefix(fmt.Sprintf("%T", update.Payload), "*proto.ToDataplane_")
+	}
+	unknownUpdates.WithLabelValues(payload).Inc()
+	if _, logged := unknownLogged.LoadOrStore("payload "+payload, true); !logged {
+		log.WithFields(log.Fields{"payload": payload, "seq": update.SequenceNumber}).Info(
+			"Ignoring update of unknown kind from the Policy Sync API.")
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package threatfeed periodically downloads IP blocklists into in-memory sets that rules can match against,
+// independently of the IP sets Felix manages.
+package threatfeed
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectcalico/app-policy/ipaddr"
+	"github.com/projectcalico/app-policy/policystore"
+	"github.com/projectcalico/app-policy/proto"
+
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultRefreshInterval is how often feeds are downloaded if their config doesn't say.
+	DefaultRefreshInterval = time.Hour
+	// MaxFeedBytes bounds the size of a feed download.
+	MaxFeedBytes = 64 << 20
+	// retryInterval is how soon a failed download is retried, if sooner than the refresh interval.
+	retryInterval = time.Minute
+)
+
+// ErrNotLoaded is returned when a feed is matched before it has been downloaded successfully.
+var ErrNotLoaded = errors.New("threat feed not loaded yet")
+
+// Config is the threat feed configuration file.
+type Config struct {
+	Feeds []Feed `json:"feeds"`
+}
+
+// Feed is an IP blocklist to download.
+type Feed struct {
+	// Name is how rules refer to the feed.
+	Name string `json:"name"`
+	// URL is where to download the feed from, over HTTP or HTTPS, or a file:// URL for a local file.
+	URL string `json:"url"`
+	// RefreshInterval is how often to download the feed, as a Go duration. Defaults to an hour.
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+}
+
+// LoadConfig reads and validates a threat feed configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Parse reads a feed in any of the common plain text formats: one IP address or CIDR per line, optionally followed by
+// other fields separated by whitespace, a comma or a semicolon. This covers plain lists, FireHOL netsets, Spamhaus
+// DROP lists and CSV files with the address in the first column. Blank lines and lines starting with "#", ";" or
+// "//" are ignored. It returns the set, the number of entries in it, and the number of lines that could not be
+// parsed.
+func Parse(r io.Reader) (set policystore.IPSet, entries, invalid int, err error) {
+	set = policystore.NewIPSet(proto.IPSetUpdate_NET)
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if i := strings.IndexAny(line, " \t,;"); i >= 0 {
+			line = line[:i]
+		}
+		cidr, ok := normalize(line)
+		if !ok {
+			invalid++
+			continue
+		}
+		set.AddString(cidr)
+		entries++
+	}
+	return set, entries, invalid, s.Err()
+}
+
+// normalize converts an IP address or CIDR into the CIDR form the IP set expects.
+func normalize(s string) (string, bool) {
+	n, err := ipaddr.ParseCIDR(s)
+	if err != nil {
+		return "", false
+	}
+	return n.String(), true
+}
+
+type feed struct {
+	Feed
+	interval time.Duration
+
+	mu           sync.RWMutex
+	set          policystore.IPSet
+	etag         string
+	lastModified string
+}
+
+// Manager keeps a set of feeds up to date.
+type Manager struct {
+	feeds  map[string]*feed
+	client *http.Client
+}
+
+// NewManager validates config and creates a Manager for its feeds. Call Start to begin downloading them.
+func NewManager(config *Config) (*Manager, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+	m := &Manager{feeds: map[string]*feed{}, client: &http.Client{Transport: t, Timeout: time.Minute}}
+	for _, f := range config.Feeds {
+		if f.Name == "" || f.URL == "" {
+			return nil, errors.New("threat feeds need a name and URL")
+		}
+		if _, ok := m.feeds[f.Name]; ok {
+			return nil, fmt.Errorf("duplicate threat feed %q", f.Name)
+		}
+		fd := &feed{Feed: f, interval: DefaultRefreshInterval}
+		if f.RefreshInterval != "" {
+			d, err := time.ParseDuration(f.RefreshInterval)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("threat feed %s: invalid refresh interval %q", f.Name, f.RefreshInterval)
+			}
+			fd.interval = d
+		}
+		m.feeds[f.Name] = fd
+	}
+	return m, nil
+}
+
+// Start downloads each feed and refreshes it periodically until ctx is cancelled. If a download fails the feed keeps
+// its previous contents.
+func (m *Manager) Start(ctx context.Context) {
+	for _, f := range m.feeds {
+		go m.refreshLoop(ctx, f)
+	}
+}
+
+func (m *Manager) refreshLoop(ctx context.Context, f *feed) {
+	for {
+		wait := f.interval
+		if err := m.refresh(ctx, f); err != nil {
+			log.WithError(err).WithField("feed", f.Name).Warn("Failed to refresh threat feed.")
+			if retryInterval < wait {
+				wait = retryInterval
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// refresh downloads f, replacing its set if it has changed.
+func (m *Manager) refresh(ctx context.Context, f *feed) error {
+	req, err := http.NewRequest(http.MethodGet, f.URL, nil)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	f.mu.RLock()
+	if f.etag != "" {
+		req.Header.Set("If-None-Match", f.etag)
+	}
+	if f.lastModified != "" {
+		req.Header.Set("If-Modified-Since", f.lastModified)
+	}
+	f.mu.RUnlock()
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		log.WithField("feed", f.Name).Debug("Threat feed not modified.")
+		return nil
+	default:
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	set, entries, invalid, err := Parse(&limitedReader{r: resp.Body, remaining: MaxFeedBytes})
+	if err != nil {
+		return err
+	}
+	if entries == 0 && invalid > 0 {
+		// Most likely an error page, rather than an empty feed.
+		return fmt.Errorf("no addresses in %d lines", invalid)
+	}
+	f.mu.Lock()
+	f.set = set
+	f.etag = resp.Header.Get("ETag")
+	f.lastModified = resp.Header.Get("Last-Modified")
+	f.mu.Unlock()
+	log.WithFields(log.Fields{"feed": f.Name, "entries": entries, "invalidLines": invalid}).Info("Refreshed threat feed.")
+	return nil
+}
+
+// limitedReader fails reads beyond the remaining bytes. Unlike io.LimitReader it doesn't silently truncate, which
+// could turn the last address in a feed into a much larger network.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, fmt.Errorf("feed larger than %d bytes", MaxFeedBytes)
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// Contains returns true if ip is in the named feed. It returns an error if there is no such feed, or it has not been
+// downloaded yet.
+func (m *Manager) Contains(name string, ip net.IP) (bool, error) {
+	f, ok := m.feeds[name]
+	if !ok {
+		return false, fmt.Errorf("unknown threat feed %q", name)
+	}
+	f.mu.RLock()
+	set := f.set
+	f.mu.RUnlock()
+	if set == nil {
+		return false, ErrNotLoaded
+	}
+	addr := &envoyapi.Address{Address: &envoyapi.Address_SocketAddress{
+		SocketAddress: &envoyapi.SocketAddress{Address: ip.String()},
+	}}
+	return set.ContainsAddress(addr), nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package threatfeed
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	envoyapi "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	. "github.com/onsi/gomega"
+)
+
+const spamhausDrop = `; Spamhaus DROP List 2026/10/16 - (c) 2026 The Spamhaus Project
+; Last-Modified: Fri, 16 Oct 2026 09:00:00 GMT
+1.10.16.0/20 ; SBL256894
+2.56.192.0/22 ; SBL459831
+`
+
+func contains(t *testing.T, m *Manager, feed, ip string) bool {
+	in, err := m.Contains(feed, net.ParseIP(ip))
+	Expect(err).ToNot(HaveOccurred())
+	return in
+}
+
+func TestParse(t *testing.T) {
+	RegisterTestingT(t)
+
+	set, entries, invalid, err := Parse(strings.NewReader(spamhausDrop + `
+# a plain list
+203.0.113.7
+2001:db8::/32
+// and some CSV
+198.51.100.1,2026-10-16,scanner
+not an address
+10.1.2.3/99
+`))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(entries).To(Equal(5))
+	Expect(invalid).To(Equal(2))
+	in := func(ip string) bool {
+		return set.ContainsAddress(&envoyapi.Address{Address: &envoyapi.Address_SocketAddress{
+			SocketAddress: &envoyapi.SocketAddress{Address: ip},
+		}})
+	}
+	Expect(in("1.10.20.30")).To(BeTrue())
+	Expect(in("2.56.195.255")).To(BeTrue())
+	Expect(in("203.0.113.7")).To(BeTrue())
+	Expect(in("::ffff:203.0.113.7")).To(BeTrue())
+	Expect(in("203.0.113.8")).To(BeFalse())
+	Expect(in("2001:db8:1::1")).To(BeTrue())
+	Expect(in("198.51.100.1")).To(BeTrue())
+	Expect(in("10.1.2.3")).To(BeFalse())
+}
+
+func TestRefresh(t *testing.T) {
+	RegisterTestingT(t)
+
+	body := spamhausDrop
+	status := http.StatusOK
+	var gotETag string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotETag = r.Header.Get("If-None-Match")
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	m, err := NewManager(&Config{Feeds: []Feed{{Name: "drop", URL: server.URL}}})
+	Expect(err).ToNot(HaveOccurred())
+	f := m.feeds["drop"]
+
+	_, err = m.Contains("drop", net.ParseIP("1.10.16.1"))
+	Expect(err).To(Equal(ErrNotLoaded))
+	_, err = m.Contains("edrop", net.ParseIP("1.10.16.1"))
+	Expect(err).To(HaveOccurred())
+
+	Expect(m.refresh(context.Background(), f)).To(Succeed())
+	Expect(contains(t, m, "drop", "1.10.16.1")).To(BeTrue())
+	Expect(contains(t, m, "drop", "8.8.8.8")).To(BeFalse())
+
+	// Conditional requests that aren't modified keep the set.
+	status = http.StatusNotModified
+	Expect(m.refresh(context.Background(), f)).To(Succeed())
+	Expect(gotETag).To(Equal(`"v1"`))
+	Expect(contains(t, m, "drop", "1.10.16.1")).To(BeTrue())
+
+	// As do failed downloads, and responses that aren't feeds.
+	status = http.StatusInternalServerError
+	Expect(m.refresh(context.Background(), f)).ToNot(Succeed())
+	status = http.StatusOK
+	body = "<html><body>Rate limited</body></html>\n"
+	Expect(m.refresh(context.Background(), f)).ToNot(Succeed())
+	Expect(contains(t, m, "drop", "1.10.16.1")).To(BeTrue())
+
+	body = "8.8.8.8\n"
+	Expect(m.refresh(context.Background(), f)).To(Succeed())
+	Expect(contains(t, m, "drop", "1.10.16.1")).To(BeFalse())
+	Expect(contains(t, m, "drop", "8.8.8.8")).To(BeTrue())
+}
+
+func TestRefreshFile(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "threatfeed")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "local.txt")
+	Expect(ioutil.WriteFile(file, []byte("192.0.2.0/24\n"), 0644)).To(Succeed())
+
+	m, err := NewManager(&Config{Feeds: []Feed{{Name: "local", URL: "file://" + file}}})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(m.refresh(context.Background(), m.feeds["local"])).To(Succeed())
+	Expect(contains(t, m, "local", "192.0.2.99")).To(BeTrue())
+}
+
+func TestNewManager(t *testing.T) {
+	RegisterTestingT(t)
+
+	m, err := NewManager(&Config{Feeds: []Feed{{Name: "a", URL: "http://a", RefreshInterval: "5m"}}})
+	Expect(err).ToNot(HaveOccurred())
+	Expect(m.feeds["a"].interval.Minutes()).To(Equal(5.0))
+
+	for _, c := range []Config{
+		{Feeds: []Feed{{Name: "a"}}},
+		{Feeds: []Feed{{URL: "http://a"}}},
+		{Feeds: []Feed{{Name: "a", URL: "http://a"}, {Name: "a", URL: "http://b"}}},
+		{Feeds: []Feed{{Name: "a", URL: "http://a", RefreshInterval: "often"}}},
+	} {
+		_, err := NewManager(&c)
+		Expect(err).To(HaveOccurred())
+	}
+}
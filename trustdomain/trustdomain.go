@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trustdomain maps the SPIFFE trust domains of peers in a multi-cluster mesh to the clusters they come from,
+// and their identities to this cluster's namespaces, so that rules can tell local workloads from those of other
+// clusters:
+//
+//	localCluster: west
+//	trustDomains:
+//	- name: east.example.com
+//	  cluster: east
+//	  namespaces:
+//	    shop-east: shop
+//	- name: partner.example.org
+//	  cluster: partner
+//	  external: true
+//
+// Peers in an external trust domain have no namespace or service account here, so only rules that match their
+// cluster or principal apply to them. Peers in trust domains that aren't listed belong to the local cluster.
+package trustdomain
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the trust domain configuration file.
+type Config struct {
+	// LocalCluster is the name of this cluster, which peers in unlisted trust domains belong to.
+	LocalCluster string        `json:"localCluster"`
+	TrustDomains []TrustDomain `json:"trustDomains"`
+}
+
+// TrustDomain maps a foreign trust domain to the cluster it belongs to.
+type TrustDomain struct {
+	// Name is the trust domain, as it appears in SPIFFE IDs.
+	Name    string `json:"name"`
+	Cluster string `json:"cluster"`
+	// External marks a trust domain whose namespaces and service accounts have nothing to do with this cluster's.
+	External bool `json:"external,omitempty"`
+	// Namespaces maps namespaces in the trust domain to this cluster's namespaces. Those not listed keep their names.
+	Namespaces map[string]string `json:"namespaces,omitempty"`
+}
+
+// LoadConfig reads a trust domain configuration file.
+func LoadConfig(file string) (*Config, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	c := &Config{}
+	if err := yaml.UnmarshalStrict(b, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Identity is where a peer's principal places it.
+type Identity struct {
+	Cluster string
+	// Namespace is the peer's namespace in this cluster, or "" for external peers.
+	Namespace string
+	External  bool
+}
+
+// Mapper maps trust domains to clusters.
+type Mapper struct {
+	local   string
+	domains map[string]TrustDomain
+}
+
+// NewMapper validates config and creates a Mapper for it.
+func NewMapper(config *Config) (*Mapper, error) {
+	if config.LocalCluster == "" {
+		return nil, errors.New("the local cluster needs a name")
+	}
+	m := &Mapper{local: config.LocalCluster, domains: map[string]TrustDomain{}}
+	for _, td := range config.TrustDomains {
+		if td.Name == "" || td.Cluster == "" {
+			return nil, errors.New("trust domains need a name and cluster")
+		}
+		name := strings.ToLower(td.Name)
+		if _, ok := m.domains[name]; ok {
+			return nil, fmt.Errorf("duplicate trust domain %q", td.Name)
+		}
+		if td.External && len(td.Namespaces) > 0 {
+			return nil, fmt.Errorf("trust domain %s: external trust domains can't map namespaces", td.Name)
+		}
+		m.domains[name] = td
+	}
+	return m, nil
+}
+
+// Map returns where a peer in the namespace of trustDomain belongs. Trust domains are case insensitive.
+func (m *Mapper) Map(trustDomain, namespace string) Identity {
+	td, ok := m.domains[strings.ToLower(trustDomain)]
+	if !ok {
+		return Identity{Cluster: m.local, Namespace: namespace}
+	}
+	if td.External {
+		return Identity{Cluster: td.Cluster, External: true}
+	}
+	if ns, ok := td.Namespaces[namespace]; ok {
+		namespace = ns
+	}
+	return Identity{Cluster: td.Cluster, Namespace: namespace}
+}
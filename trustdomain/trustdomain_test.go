@@ -0,0 +1,88 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trustdomain
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMap(t *testing.T) {
+	RegisterTestingT(t)
+
+	m, err := NewMapper(&Config{
+		LocalCluster: "west",
+		TrustDomains: []TrustDomain{
+			{Name: "east.example.com", Cluster: "east", Namespaces: map[string]string{"shop-east": "shop"}},
+			{Name: "partner.example.org", Cluster: "partner", External: true},
+		},
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	Expect(m.Map("cluster.local", "shop")).To(Equal(Identity{Cluster: "west", Namespace: "shop"}))
+	Expect(m.Map("east.example.com", "shop-east")).To(Equal(Identity{Cluster: "east", Namespace: "shop"}))
+	Expect(m.Map("East.Example.com", "billing")).To(Equal(Identity{Cluster: "east", Namespace: "billing"}))
+	Expect(m.Map("partner.example.org", "shop")).To(Equal(Identity{Cluster: "partner", External: true}))
+}
+
+func TestNewMapperValidates(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, c := range []*Config{
+		{},
+		{LocalCluster: "west", TrustDomains: []TrustDomain{{Name: "east.example.com"}}},
+		{LocalCluster: "west", TrustDomains: []TrustDomain{
+			{Name: "east.example.com", Cluster: "east"},
+			{Name: "EAST.example.com", Cluster: "east2"},
+		}},
+		{LocalCluster: "west", TrustDomains: []TrustDomain{
+			{Name: "partner.example.org", Cluster: "partner", External: true, Namespaces: map[string]string{"a": "b"}},
+		}},
+	} {
+		_, err := NewMapper(c)
+		Expect(err).To(HaveOccurred(), "%+v", c)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "trustdomain")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "trust-domains.yaml")
+	Expect(ioutil.WriteFile(file, []byte(`
+localCluster: west
+trustDomains:
+- name: partner.example.org
+  cluster: partner
+  external: true
+`), 0600)).To(Succeed())
+
+	c, err := LoadConfig(file)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(c).To(Equal(&Config{
+		LocalCluster: "west",
+		TrustDomains: []TrustDomain{{Name: "partner.example.org", Cluster: "partner", External: true}},
+	}))
+
+	Expect(ioutil.WriteFile(file, []byte("localCluster: west\nclusters: []\n"), 0600)).To(Succeed())
+	_, err = LoadConfig(file)
+	Expect(err).To(HaveOccurred())
+}
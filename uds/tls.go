@@ -0,0 +1,138 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uds
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TLSFiles configures a TCP listener for clients, such as an Envoy that can't share Dikastes's Unix socket, that must
+// authenticate with mutual TLS.
+type TLSFiles struct {
+	// CertFile and KeyFile are the PEM encoded certificate and key that Dikastes serves with.
+	CertFile string
+	KeyFile  string
+	// CAFile holds the PEM encoded certificates of the CAs that issue clients' certificates.
+	CAFile string
+	// SPIFFEIDs, if set, are the SPIFFE IDs that clients' certificates must have one of as a URI SAN.
+	SPIFFEIDs []string
+}
+
+// NewTLSConfig returns the server TLS config for f, which requires clients to present certificates issued by the CA.
+// The files are read again when they change, so that certificates can be rotated without restarting, and the last
+// certificates read are kept if the new ones can't be.
+func NewTLSConfig(f TLSFiles) (*tls.Config, error) {
+	r := &tlsReloader{files: f}
+	if _, err := r.load(); err != nil {
+		return nil, err
+	}
+	return &tls.Config{GetConfigForClient: r.configForClient}, nil
+}
+
+// ListenTLS listens on a TCP address, serving connections with config.
+func ListenTLS(addr string, config *tls.Config) (net.Listener, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return tls.NewListener(lis, config), nil
+}
+
+// tlsReloader reads the files of a TLS config, and reads them again when they change.
+type tlsReloader struct {
+	files TLSFiles
+
+	mu       sync.Mutex
+	modTimes [3]time.Time
+	config   *tls.Config
+}
+
+func (r *tlsReloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	c, err := r.load()
+	if err != nil {
+		log.WithError(err).Warn("Unable to reload TLS certificates, serving with the previous ones.")
+	}
+	return c, nil
+}
+
+// load returns the config, read from the files if they have changed since it was last read. If they can't be read, it
+// returns the error along with the config last read, if any.
+func (r *tlsReloader) load() (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var modTimes [3]time.Time
+	for i, path := range []string{r.files.CertFile, r.files.KeyFile, r.files.CAFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return r.config, err
+		}
+		modTimes[i] = info.ModTime()
+	}
+	if r.config != nil && modTimes == r.modTimes {
+		return r.config, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.files.CertFile, r.files.KeyFile)
+	if err != nil {
+		return r.config, err
+	}
+	ca, err := ioutil.ReadFile(r.files.CAFile)
+	if err != nil {
+		return r.config, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return r.config, fmt.Errorf("no CA certificates in %s", r.files.CAFile)
+	}
+	r.config = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS12,
+		// gRPC clients, like Envoy, require HTTP/2 to be negotiated.
+		NextProtos:            []string{"h2"},
+		VerifyPeerCertificate: r.verifySPIFFEID,
+	}
+	r.modTimes = modTimes
+	return r.config, nil
+}
+
+// verifySPIFFEID checks that a client certificate, already verified against the CA, has one of the allowed SPIFFE IDs.
+func (r *tlsReloader) verifySPIFFEID(_ [][]byte, chains [][]*x509.Certificate) error {
+	if len(r.files.SPIFFEIDs) == 0 {
+		return nil
+	}
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return errors.New("no verified client certificate")
+	}
+	for _, uri := range chains[0][0].URIs {
+		for _, id := range r.files.SPIFFEIDs {
+			if uri.String() == id {
+				return nil
+			}
+		}
+	}
+	return errors.New("client certificate has none of the allowed SPIFFE IDs")
+}
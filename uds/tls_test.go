@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uds
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// testCA issues certificates for tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA() *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(der)
+	Expect(err).ToNot(HaveOccurred())
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM encoded certificate and key with the given SPIFFE ID, if any, for server or client auth.
+func (ca *testCA) issue(serial int64, spiffeID string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if spiffeID != "" {
+		u, err := url.Parse(spiffeID)
+		Expect(err).ToNot(HaveOccurred())
+		tmpl.URIs = []*url.URL{u}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	Expect(err).ToNot(HaveOccurred())
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// clientConfig returns a client TLS config with a certificate for spiffeID, or none if it is empty.
+func (ca *testCA) clientConfig(spiffeID string) *tls.Config {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	c := &tls.Config{RootCAs: pool, ServerName: "127.0.0.1", NextProtos: []string{"h2"}}
+	if spiffeID != "" {
+		cert, err := tls.X509KeyPair(ca.issue(3, spiffeID, x509.ExtKeyUsageClientAuth))
+		Expect(err).ToNot(HaveOccurred())
+		c.Certificates = []tls.Certificate{cert}
+	}
+	return c
+}
+
+// writeServerFiles writes a server certificate, key and the CA to dir.
+func writeServerFiles(dir string, ca *testCA, serial int64) TLSFiles {
+	f := TLSFiles{
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	certPEM, keyPEM := ca.issue(serial, "", x509.ExtKeyUsageServerAuth)
+	Expect(ioutil.WriteFile(f.CertFile, certPEM, 0600)).To(Succeed())
+	Expect(ioutil.WriteFile(f.KeyFile, keyPEM, 0600)).To(Succeed())
+	Expect(ioutil.WriteFile(f.CAFile, ca.pem, 0600)).To(Succeed())
+	return f
+}
+
+// handshake connects to lis with config, returning the server certificate's serial number or the handshake error.
+func handshake(lis net.Listener, config *tls.Config) (*big.Int, error) {
+	go func() {
+		c, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		// The server side of the handshake happens on first use.
+		b := make([]byte, 1)
+		if _, err := c.Read(b); err == nil {
+			_, _ = c.Write(b)
+		}
+	}()
+	c, err := tls.Dial("tcp", lis.Addr().String(), config)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	// With TLS 1.3, the server only rejects the client certificate after the client has finished its handshake.
+	if _, err := c.Write([]byte("x")); err != nil {
+		return nil, err
+	}
+	if _, err := c.Read(make([]byte, 1)); err != nil {
+		return nil, err
+	}
+	return c.ConnectionState().PeerCertificates[0].SerialNumber, nil
+}
+
+func TestListenTLS(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "uds")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	ca := newTestCA()
+	files := writeServerFiles(dir, ca, 2)
+	files.SPIFFEIDs = []string{"spiffe://cluster.local/ns/envoy/sa/gateway"}
+	config, err := NewTLSConfig(files)
+	Expect(err).ToNot(HaveOccurred())
+	lis, err := ListenTLS("127.0.0.1:0", config)
+	Expect(err).ToNot(HaveOccurred())
+	defer lis.Close()
+
+	serial, err := handshake(lis, ca.clientConfig("spiffe://cluster.local/ns/envoy/sa/gateway"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(serial.Int64()).To(BeEquivalentTo(2))
+
+	// Clients must present a certificate with an allowed SPIFFE ID.
+	_, err = handshake(lis, ca.clientConfig("spiffe://cluster.local/ns/default/sa/other"))
+	Expect(err).To(HaveOccurred())
+	_, err = handshake(lis, ca.clientConfig(""))
+	Expect(err).To(HaveOccurred())
+
+	// Rotated certificates are picked up.
+	writeServerFiles(dir, ca, 4)
+	later := time.Now().Add(time.Minute)
+	Expect(os.Chtimes(files.CertFile, later, later)).To(Succeed())
+	serial, err = handshake(lis, ca.clientConfig("spiffe://cluster.local/ns/envoy/sa/gateway"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(serial.Int64()).To(BeEquivalentTo(4))
+
+	// Certificates that can't be read leave the last ones in place.
+	Expect(ioutil.WriteFile(files.KeyFile, []byte("garbage"), 0600)).To(Succeed())
+	later = later.Add(time.Minute)
+	Expect(os.Chtimes(files.KeyFile, later, later)).To(Succeed())
+	serial, err = handshake(lis, ca.clientConfig("spiffe://cluster.local/ns/envoy/sa/gateway"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(serial.Int64()).To(BeEquivalentTo(4))
+}
+
+func TestNewTLSConfigErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "uds")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	files := writeServerFiles(dir, newTestCA(), 2)
+
+	missing := files
+	missing.CAFile = filepath.Join(dir, "missing.crt")
+	_, err = NewTLSConfig(missing)
+	Expect(err).To(HaveOccurred())
+
+	Expect(ioutil.WriteFile(files.CAFile, []byte("not a certificate"), 0600)).To(Succeed())
+	_, err = NewTLSConfig(files)
+	Expect(err).To(HaveOccurred())
+}
@@ -28,6 +28,11 @@ func getDialer(proto string) func(context.Context, string) (net.Conn, error) {
 	}
 }
 
+// GetDialer returns the dialer that connections made with GetDialOptions use.
+func GetDialer() func(context.Context, string) (net.Conn, error) {
+	return getDialer("unix")
+}
+
 func GetDialOptions() []grpc.DialOption {
 	return []grpc.DialOption{
 		grpc.WithInsecure(),
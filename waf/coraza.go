@@ -0,0 +1,133 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build coraza
+// +build coraza
+
+package waf
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	coreruleset "github.com/corazawaf/coraza-coreruleset"
+	"github.com/corazawaf/coraza/v3"
+)
+
+// crsDirectives loads the embedded Core Rule Set with its recommended setup.
+const crsDirectives = `
+Include @coraza.conf-recommended
+Include @crs-setup.conf.example
+Include @owasp_crs/*.conf
+SecRuleEngine On
+`
+
+type corazaEngine struct {
+	waf coraza.WAF
+}
+
+// NewCoraza returns an Engine that runs Coraza with the configured rules.
+func NewCoraza(c Config) (Engine, error) {
+	var directives []string
+	if c.CoreRuleSet {
+		directives = append(directives, crsDirectives)
+	}
+	// The engine's root filesystem is the embedded Core Rule Set, so local rule files are read here rather than
+	// with Include.
+	for _, pattern := range c.Files {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no rule files match %q", pattern)
+		}
+		for _, f := range files {
+			b, err := ioutil.ReadFile(f)
+			if err != nil {
+				return nil, err
+			}
+			directives = append(directives, string(b))
+		}
+	}
+	if c.Directives != "" {
+		directives = append(directives, c.Directives)
+	}
+	cfg := coraza.NewWAFConfig().
+		WithRootFS(coreruleset.FS).
+		WithDirectives(strings.Join(directives, "\n"))
+	w, err := coraza.NewWAF(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &corazaEngine{waf: w}, nil
+}
+
+func (e *corazaEngine) Inspect(r *Request) (res *Result, err error) {
+	tx := e.waf.NewTransaction()
+	defer func() {
+		tx.ProcessLogging()
+		if cerr := tx.Close(); err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+
+	tx.ProcessConnection(r.SourceAddress, r.SourcePort, r.DestinationAddress, r.DestinationPort)
+	tx.ProcessURI(r.URI, r.Method, r.Protocol)
+	for k, v := range r.Headers {
+		tx.AddRequestHeader(k, v)
+	}
+	if r.Host != "" {
+		tx.AddRequestHeader("Host", r.Host)
+		tx.SetServerName(r.Host)
+	}
+
+	it := tx.ProcessRequestHeaders()
+	if it == nil && len(r.Body) > 0 && tx.IsRequestBodyAccessible() {
+		if it, _, err = tx.WriteRequestBody(r.Body); err != nil {
+			return nil, fmt.Errorf("writing request body: %v", err)
+		}
+	}
+	if it == nil {
+		if it, err = tx.ProcessRequestBody(); err != nil {
+			return nil, fmt.Errorf("processing request body: %v", err)
+		}
+	}
+
+	res = &Result{}
+	for _, m := range tx.MatchedRules() {
+		// Rules without messages are CRS bookkeeping, such as anomaly score updates.
+		if m.Message() == "" {
+			continue
+		}
+		res.Matches = append(res.Matches, Match{
+			RuleID:   m.Rule().ID(),
+			Severity: m.Rule().Severity().String(),
+			Message:  m.Message(),
+			Data:     m.Data(),
+		})
+	}
+	if it != nil {
+		res.Blocked = it.Action != "allow"
+		res.RuleID = it.RuleID
+		res.Status = it.Status
+		if res.Status == 0 {
+			res.Status = http.StatusForbidden
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !coraza
+// +build !coraza
+
+package waf
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewCorazaNotSupported(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, c := range []Config{{CoreRuleSet: true}, {Files: []string{"rules/*.conf"}}, {}} {
+		engine, err := NewCoraza(c)
+		Expect(err).To(Equal(ErrNotSupported))
+		Expect(engine).To(BeNil())
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build coraza
+// +build coraza
+
+package waf
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const testDirectives = `
+SecRuleEngine On
+SecRequestBodyAccess On
+SecRule ARGS_GET:id "@streq attack" "id:1001,phase:1,deny,status:406,msg:'Attack in id'"
+SecRule ARGS_POST:q "@contains evil" "id:1002,phase:2,deny,msg:'Evil form field'"
+SecRule REQUEST_HEADERS:x-scanner "@rx ." "id:1003,phase:1,pass,log,msg:'Scanner header'"
+`
+
+func getRequest(uri string) *Request {
+	return &Request{
+		SourceAddress:      "10.0.0.1",
+		SourcePort:         40000,
+		DestinationAddress: "10.0.0.2",
+		DestinationPort:    8080,
+		Method:             "GET",
+		URI:                uri,
+		Protocol:           "HTTP/1.1",
+		Host:               "app.example.com",
+		Headers:            map[string]string{},
+	}
+}
+
+func TestCorazaInspect(t *testing.T) {
+	RegisterTestingT(t)
+
+	engine, err := NewCoraza(Config{Directives: testDirectives})
+	Expect(err).ToNot(HaveOccurred())
+
+	res, err := engine.Inspect(getRequest("/items?id=1"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(res.Blocked).To(BeFalse())
+	Expect(res.Matches).To(BeEmpty())
+
+	res, err = engine.Inspect(getRequest("/items?id=attack"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(res.Blocked).To(BeTrue())
+	Expect(res.RuleID).To(Equal(1001))
+	Expect(res.Status).To(Equal(406))
+	Expect(res.Matches).To(HaveLen(1))
+	Expect(res.Matches[0].RuleID).To(Equal(1001))
+	Expect(res.Matches[0].Message).To(Equal("Attack in id"))
+
+	// Rules that only log are reported without blocking the request.
+	r := getRequest("/items")
+	r.Headers["x-scanner"] = "nikto"
+	res, err = engine.Inspect(r)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(res.Blocked).To(BeFalse())
+	Expect(res.Matches).To(HaveLen(1))
+	Expect(res.Matches[0].RuleID).To(Equal(1003))
+}
+
+func TestCorazaInspectBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	engine, err := NewCoraza(Config{Directives: testDirectives})
+	Expect(err).ToNot(HaveOccurred())
+
+	r := getRequest("/search")
+	r.Method = "POST"
+	r.Headers["content-type"] = "application/x-www-form-urlencoded"
+	r.Body = []byte("q=something+evil")
+	res, err := engine.Inspect(r)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(res.Blocked).To(BeTrue())
+	Expect(res.RuleID).To(Equal(1002))
+	// Rules that deny without a status block with 403 Forbidden.
+	Expect(res.Status).To(Equal(403))
+
+	r.Body = []byte("q=something+nice")
+	res, err = engine.Inspect(r)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(res.Blocked).To(BeFalse())
+}
+
+func TestCorazaRuleFiles(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "waf")
+	Expect(err).ToNot(HaveOccurred())
+	defer os.RemoveAll(dir)
+	Expect(ioutil.WriteFile(filepath.Join(dir, "engine.conf"), []byte("SecRuleEngine On\n"), 0600)).To(Succeed())
+	Expect(ioutil.WriteFile(filepath.Join(dir, "rules.conf"), []byte(
+		`SecRule REQUEST_URI "@beginsWith /admin" "id:2001,phase:1,deny,status:403,msg:'Admin area'"`+"\n"), 0600)).
+		To(Succeed())
+
+	engine, err := NewCoraza(Config{Files: []string{filepath.Join(dir, "*.conf")}})
+	Expect(err).ToNot(HaveOccurred())
+	res, err := engine.Inspect(getRequest("/admin/users"))
+	Expect(err).ToNot(HaveOccurred())
+	Expect(res.Blocked).To(BeTrue())
+	Expect(res.RuleID).To(Equal(2001))
+
+	_, err = NewCoraza(Config{Files: []string{filepath.Join(dir, "*.rules")}})
+	Expect(err).To(MatchError(ContainSubstring("no rule files match")))
+
+	Expect(ioutil.WriteFile(filepath.Join(dir, "bad.conf"), []byte("SecRule ARGS\n"), 0600)).To(Succeed())
+	_, err = NewCoraza(Config{Files: []string{filepath.Join(dir, "*.conf")}})
+	Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Tigera, Inc. All rights reserved.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package waf inspects HTTP requests with a web application firewall engine, such as Coraza running the OWASP Core
+// Rule Set, so that its verdict can be combined with Calico policy.
+package waf
+
+import "errors"
+
+// ErrNotSupported is returned when the requested engine was not compiled in. The Coraza engine is only compiled in
+// with the coraza build tag, once github.com/corazawaf/coraza/v3 and github.com/corazawaf/coraza-coreruleset are
+// added to go.mod.
+var ErrNotSupported = errors.New("WAF engine not supported by this build: rebuild with the coraza build tag")
+
+// Request holds the attributes of an HTTP request that the engine inspects.
+type Request struct {
+	SourceAddress      string
+	SourcePort         int
+	DestinationAddress string
+	DestinationPort    int
+
+	Method   string
+	URI      string
+	Protocol string
+	Host     string
+	Headers  map[string]string
+	// Body is the request body, if Envoy was configured to send it. It may be truncated.
+	Body []byte
+}
+
+// Match describes a rule that matched the request.
+type Match struct {
+	RuleID   int
+	Severity string
+	Message  string
+	Data     string
+}
+
+// Result is the outcome of inspecting a request.
+type Result struct {
+	// Blocked is true if a rule interrupted the request.
+	Blocked bool
+	// RuleID is the ID of the rule that blocked the request.
+	RuleID int
+	// Status is the HTTP status the blocking rule asked for.
+	Status int
+	// Matches lists every rule that matched, whether or not it blocked the request.
+	Matches []Match
+}
+
+// Engine inspects requests. Implementations must be safe for concurrent use.
+type Engine interface {
+	Inspect(r *Request) (*Result, error)
+}
+
+// Config selects the rules a Coraza engine loads.
+type Config struct {
+	// CoreRuleSet loads the embedded OWASP Core Rule Set, with the recommended Coraza and CRS setup.
+	CoreRuleSet bool
+	// Files are SecLang rule files, or glob patterns matching them, loaded after the Core Rule Set.
+	Files []string
+	// Directives are extra SecLang directives loaded last, for example to tune the Core Rule Set.
+	Directives string
+}